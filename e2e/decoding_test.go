@@ -6,7 +6,6 @@ package tests
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
@@ -14,14 +13,9 @@ import (
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var _ = Describe("Decoding Service E2E", func() {
-	conn, err := grpc.NewClient(fmt.Sprintf("%s:%s", "0.0.0.0", "31234"), grpc.WithTransportCredentials(insecure.NewCredentials()))
-	Expect(err).NotTo(HaveOccurred())
-
 	client := decodingv1grpc.NewDecodingServiceClient(conn)
 
 	Context("0.8.0 Record Decoding", func() {
@@ -6,10 +6,28 @@ package tests
 import (
 	"testing"
 
+	"github.com/agntcy/oasf-sdk/pkg/client"
 	ginkgo "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
 )
 
+// serverAddress is the address of the server under test.
+const serverAddress = "0.0.0.0:31234"
+
+// conn is shared by the specs, as clients of the server should share one
+// connection rather than dial per caller.
+var conn = mustDial()
+
+func mustDial() *grpc.ClientConn {
+	conn, err := client.Dial([]string{serverAddress})
+	if err != nil {
+		panic(err)
+	}
+
+	return conn
+}
+
 func TestEndToEnd(t *testing.T) {
 	gomega.RegisterFailHandler(ginkgo.Fail)
 	ginkgo.RunSpecs(t, "Run end-to-end tests")
@@ -6,7 +6,6 @@ package tests
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"sort"
 	"strings"
 	"time"
@@ -14,12 +13,27 @@ import (
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
 	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// expectRecordEqual compares a translated record with the expected fixture,
+// ignoring the generated created_at and the fixtures' comment fields.
+func expectRecordEqual(actual, expected map[string]any, description string) {
+	actualRecord, err := structpb.NewStruct(actual)
+	Expect(err).NotTo(HaveOccurred(), "Failed to convert actual output")
+
+	expectedRecord, err := structpb.NewStruct(expected)
+	Expect(err).NotTo(HaveOccurred(), "Failed to convert expected output")
+
+	if !record.Equal(actualRecord, expectedRecord, record.IgnoreFields("created_at", "_comment_*")) {
+		// Let gomega print the difference.
+		Expect(actual).To(Equal(expected), description)
+	}
+}
+
 // normalizeMapOrder recursively normalizes map order by sorting keys for deterministic comparison.
 func normalizeMapOrder(data any) any {
 	switch v := data.(type) {
@@ -51,9 +65,6 @@ func normalizeMapOrder(data any) any {
 }
 
 var _ = Describe("Translation Service E2E", func() {
-	conn, err := grpc.NewClient(fmt.Sprintf("%s:%s", "0.0.0.0", "31234"), grpc.WithTransportCredentials(insecure.NewCredentials()))
-	Expect(err).NotTo(HaveOccurred())
-
 	client := translationv1grpc.NewTranslationServiceClient(conn)
 
 	Context("GH Copilot config Generation", func() { //nolint:dupl
@@ -306,13 +317,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			// Remove dynamic fields from comparison
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at") // Remove comment field
-
-			// Compare structure against expected output
-			Expect(actualOutput).To(Equal(expectedOutput), "OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "OASF record should match expected output")
 		})
 
 		It("should convert 1.0.0 OASF record back to A2A card matching expected output", func() { //nolint:dupl
@@ -427,13 +432,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			// Remove dynamic fields from comparison
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at") // Remove comment field
-
-			// Compare structure against expected output
-			Expect(actualOutput).To(Equal(expectedOutput), "OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "OASF record should match expected output")
 		})
 
 		It("should convert minimal local MCP server (pypi, no runtimeHint) to OASF record", func() { //nolint:dupl
@@ -474,13 +473,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			// Remove dynamic fields from comparison
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at") // Remove comment field
-
-			// Compare structure against expected output
-			Expect(actualOutput).To(Equal(expectedOutput), "Minimal local OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "Minimal local OASF record should match expected output")
 		})
 
 		It("should convert HTTP remote MCP server with headers to OASF record", func() { //nolint:dupl
@@ -521,13 +514,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			// Remove dynamic fields from comparison
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at") // Remove comment field
-
-			// Compare structure against expected output
-			Expect(actualOutput).To(Equal(expectedOutput), "HTTP headers OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "HTTP headers OASF record should match expected output")
 		})
 
 		It("should convert SSE minimal MCP server to OASF record", func() { //nolint:dupl
@@ -568,13 +555,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			// Remove dynamic fields from comparison
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at") // Remove comment field
-
-			// Compare structure against expected output
-			Expect(actualOutput).To(Equal(expectedOutput), "SSE minimal OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "SSE minimal OASF record should match expected output")
 		})
 	})
 	Context("Agent Skills Translation", func() {
@@ -611,11 +592,7 @@ var _ = Describe("Translation Service E2E", func() {
 			_, err = time.Parse(time.RFC3339, actualCreatedAt)
 			Expect(err).NotTo(HaveOccurred(), "created_at should be valid RFC3339 timestamp")
 
-			delete(actualOutput, "created_at")
-			delete(expectedOutput, "created_at")
-			delete(expectedOutput, "_comment_created_at")
-
-			Expect(actualOutput).To(Equal(expectedOutput), "OASF record should match expected output")
+			expectRecordEqual(actualOutput, expectedOutput, "OASF record should match expected output")
 		})
 
 		It("should convert OASF record to SKILL.md matching expected output", func() {
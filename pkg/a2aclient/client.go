@@ -0,0 +1,278 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package a2aclient fetches A2A agent cards: the public card from an agent's
+// well-known endpoints and, for agents advertising one, the authenticated
+// extended card. Cards are cached by ETag and revalidated with conditional
+// requests.
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	// maxCardSize caps the size of fetched agent cards.
+	maxCardSize = 10 << 20
+	// extendedCardPath is the path of the authenticated extended card,
+	// relative to the agent's URL.
+	extendedCardPath = "/agent/authenticatedExtendedCard"
+)
+
+// WellKnownPaths are the well-known agent card locations, newest first.
+var WellKnownPaths = []string{"/.well-known/agent-card.json", "/.well-known/agent.json"}
+
+var (
+	// ErrNotFound is returned when no agent card was found.
+	ErrNotFound = errors.New("agent card not found")
+	// ErrNoExtendedCard is returned by FetchExtended for cards that do not
+	// advertise an authenticated extended card.
+	ErrNoExtendedCard = errors.New("agent does not support an authenticated extended card")
+)
+
+// Authenticator adds credentials to requests for extended cards.
+type Authenticator func(req *http.Request) error
+
+// BearerToken authenticates requests with a bearer token.
+func BearerToken(token string) Authenticator {
+	return func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		return nil
+	}
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	httpClient *http.Client
+	auth       Authenticator
+	cache      bool
+}
+
+// WithHTTPClient sets the HTTP client. It defaults to a client with a 30
+// second timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithAuthenticator sets the credentials used for authenticated extended
+// cards. Public cards are always fetched without them.
+func WithAuthenticator(auth Authenticator) Option {
+	return func(o *options) {
+		o.auth = auth
+	}
+}
+
+// WithCache enables or disables the ETag cache. It is enabled by default.
+func WithCache(enabled bool) Option {
+	return func(o *options) {
+		o.cache = enabled
+	}
+}
+
+// Card is a fetched agent card.
+type Card struct {
+	// URL is the location the card was fetched from.
+	URL string
+	// Data is the card document.
+	Data *structpb.Struct
+	// ETag is the entity tag of the response, if the agent sent one.
+	ETag string
+	// Extended reports whether this is the authenticated extended card.
+	Extended bool
+}
+
+// SupportsExtendedCard reports whether the card advertises an authenticated
+// extended card, with the supportsAuthenticatedExtendedCard field (A2A 0.x)
+// or the extendedAgentCard capability (A2A 1.0).
+func (c *Card) SupportsExtendedCard() bool {
+	fields := c.Data.GetFields()
+
+	return fields["supportsAuthenticatedExtendedCard"].GetBoolValue() ||
+		fields["capabilities"].GetStructValue().GetFields()["extendedAgentCard"].GetBoolValue()
+}
+
+// Client fetches agent cards. It is safe for concurrent use.
+type Client struct {
+	options
+
+	mu    sync.Mutex
+	cards map[string]*Card
+}
+
+// New returns a client.
+func New(opts ...Option) *Client {
+	c := &Client{
+		options: options{
+			httpClient: &http.Client{Timeout: defaultTimeout},
+			cache:      true,
+		},
+		cards: map[string]*Card{},
+	}
+
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+
+	return c
+}
+
+// Fetch fetches the public agent card. rawURL is the card itself (a .json
+// path) or the agent's base URL, in which case WellKnownPaths are tried in
+// order.
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*Card, error) {
+	base, err := url.Parse(rawURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid agent URL %q", rawURL)
+	}
+
+	if strings.HasSuffix(base.Path, ".json") {
+		return c.get(ctx, base.String(), false)
+	}
+
+	for _, p := range WellKnownPaths {
+		candidate := *base
+		candidate.Path = strings.TrimSuffix(base.Path, "/") + p
+
+		card, err := c.get(ctx, candidate.String(), false)
+		if !errors.Is(err, ErrNotFound) {
+			return card, err
+		}
+	}
+
+	return nil, fmt.Errorf("%s: %w", rawURL, ErrNotFound)
+}
+
+// FetchExtended fetches the authenticated extended card of an agent, using
+// the client's Authenticator. It returns ErrNoExtendedCard when the public
+// card does not advertise one.
+func (c *Client) FetchExtended(ctx context.Context, public *Card) (*Card, error) {
+	if !public.SupportsExtendedCard() {
+		return nil, ErrNoExtendedCard
+	}
+
+	if c.auth == nil {
+		return nil, errors.New("an authenticator is required for the extended card")
+	}
+
+	agentURL := agentURL(public)
+	if agentURL == "" {
+		return nil, errors.New("agent card has no URL to fetch the extended card from")
+	}
+
+	return c.get(ctx, strings.TrimSuffix(agentURL, "/")+extendedCardPath, true)
+}
+
+// agentURL returns the URL the agent serves on: the card's "url" (A2A 0.x)
+// or its first supported interface (A2A 1.0).
+func agentURL(card *Card) string {
+	fields := card.Data.GetFields()
+	if u := fields["url"].GetStringValue(); u != "" {
+		return u
+	}
+
+	for _, iface := range fields["supportedInterfaces"].GetListValue().GetValues() {
+		if u := iface.GetStructValue().GetFields()["url"].GetStringValue(); u != "" {
+			return u
+		}
+	}
+
+	return ""
+}
+
+// get fetches a card, revalidating a cached copy with If-None-Match.
+func (c *Client) get(ctx context.Context, target string, extended bool) (*Card, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request to %s: %w", target, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if extended {
+		if err := c.auth(req); err != nil {
+			return nil, fmt.Errorf("failed to authenticate request to %s: %w", target, err)
+		}
+	}
+
+	cached := c.cached(target)
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send GET request to %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxCardSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", target, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return cached.clone(), nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", target, ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch %s: HTTP %d, body: %s", target, resp.StatusCode, string(body))
+	}
+
+	data := &structpb.Struct{}
+	if err := data.UnmarshalJSON(body); err != nil {
+		return nil, fmt.Errorf("failed to parse agent card from %s: %w", target, err)
+	}
+
+	card := &Card{URL: target, Data: data, ETag: resp.Header.Get("ETag"), Extended: extended}
+	c.store(card)
+
+	return card, nil
+}
+
+func (c *Client) cached(target string) *Card {
+	if !c.cache {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.cards[target]
+}
+
+func (c *Client) store(card *Card) {
+	if !c.cache || card.ETag == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cards[card.URL] = card.clone()
+}
+
+// clone copies a card so cached cards are not shared with callers.
+func (c *Card) clone() *Card {
+	out := *c
+	out.Data = recordutil.Clone(c.Data)
+
+	return &out
+}
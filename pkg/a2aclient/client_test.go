@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package a2aclient_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/a2aclient"
+)
+
+// newAgent serves a public card at the legacy well-known path and an
+// extended card requiring the "secret" bearer token.
+func newAgent(t *testing.T, extended bool) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var requests []string
+
+	srv := httptest.NewServer(nil)
+	t.Cleanup(srv.Close)
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("If-None-Match"))
+
+		switch r.URL.Path {
+		case "/.well-known/agent.json":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+
+				return
+			}
+
+			w.Header().Set("ETag", `"v1"`)
+
+			if extended {
+				_, _ = w.Write([]byte(`{"name": "agent", "url": "` + srv.URL + `/a2a", "supportsAuthenticatedExtendedCard": true}`))
+			} else {
+				_, _ = w.Write([]byte(`{"name": "agent", "url": "` + srv.URL + `/a2a"}`))
+			}
+		case "/a2a/agent/authenticatedExtendedCard":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"name": "agent", "skills": [{"id": "private"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	return srv, &requests
+}
+
+func TestFetch(t *testing.T) {
+	srv, requests := newAgent(t, false)
+	client := a2aclient.New()
+
+	card, err := client.Fetch(t.Context(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	if card.URL != srv.URL+"/.well-known/agent.json" || card.ETag != `"v1"` || card.Data.GetFields()["name"].GetStringValue() != "agent" {
+		t.Errorf("unexpected card: %+v", card)
+	}
+
+	card.Data.Fields["name"] = nil
+
+	again, err := client.Fetch(t.Context(), srv.URL+"/.well-known/agent.json")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	if again.Data.GetFields()["name"].GetStringValue() != "agent" {
+		t.Errorf("cached card was modified through a returned one: %v", again.Data)
+	}
+
+	if last := (*requests)[len(*requests)-1]; last != `GET /.well-known/agent.json "v1"` {
+		t.Errorf("expected a conditional request, got %q", last)
+	}
+
+	if _, err := client.FetchExtended(t.Context(), card); !errors.Is(err, a2aclient.ErrNoExtendedCard) {
+		t.Errorf("expected ErrNoExtendedCard, got %v", err)
+	}
+}
+
+func TestFetchNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, err := a2aclient.New().Fetch(t.Context(), srv.URL); !errors.Is(err, a2aclient.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := a2aclient.New().Fetch(t.Context(), "not a url"); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
+
+func TestFetchExtended(t *testing.T) {
+	srv, _ := newAgent(t, true)
+
+	public, err := a2aclient.New().Fetch(t.Context(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+
+	if !public.SupportsExtendedCard() {
+		t.Fatal("expected the card to advertise an extended card")
+	}
+
+	if _, err := a2aclient.New().FetchExtended(t.Context(), public); err == nil {
+		t.Error("expected an error without an authenticator")
+	}
+
+	if _, err := a2aclient.New(a2aclient.WithAuthenticator(a2aclient.BearerToken("wrong"))).FetchExtended(t.Context(), public); err == nil {
+		t.Error("expected an error for rejected credentials")
+	}
+
+	card, err := a2aclient.New(a2aclient.WithAuthenticator(a2aclient.BearerToken("secret"))).FetchExtended(t.Context(), public)
+	if err != nil {
+		t.Fatalf("FetchExtended: %v", err)
+	}
+
+	if !card.Extended || card.Data.GetFields()["skills"] == nil {
+		t.Errorf("unexpected extended card: %+v", card)
+	}
+}
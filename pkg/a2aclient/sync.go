@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package a2aclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// SyncResult is the outcome of Sync.
+type SyncResult struct {
+	// Record is the updated record, or the input record when the card did
+	// not change.
+	Record *structpb.Struct
+	// Card is the fetched card.
+	Card *Card
+	// Changes are the differences from the card stored in the record.
+	Changes []translator.CardChange
+}
+
+// Sync reconciles a record with the deployed agent: it fetches the agent's
+// current card and updates the record's A2A module to it (see
+// translator.SyncA2ACard). The input record is not modified.
+//
+// rawURL is as for Fetch. When empty, the card is fetched from the origin of
+// the URL in the card stored in the record. With an Authenticator, the
+// extended card is used for agents that advertise one.
+func (c *Client) Sync(ctx context.Context, record *structpb.Struct, rawURL string) (*SyncResult, error) {
+	if rawURL == "" {
+		stored, err := translator.RecordToA2A(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		rawURL = agentOrigin(&Card{Data: stored})
+		if rawURL == "" {
+			return nil, errors.New("the record's agent card has no URL; pass the agent URL")
+		}
+	}
+
+	card, err := c.Fetch(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.auth != nil && card.SupportsExtendedCard() {
+		if card, err = c.FetchExtended(ctx, card); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, changes, err := translator.SyncA2ACard(record, card.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+
+	return &SyncResult{Record: updated, Card: card, Changes: changes}, nil
+}
+
+// agentOrigin returns the scheme and host of the card's agent URL, where the
+// well-known card locations are.
+func agentOrigin(card *Card) string {
+	u, err := url.Parse(agentURL(card))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
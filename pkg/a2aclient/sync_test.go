@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package a2aclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/a2aclient"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSync(t *testing.T) {
+	var live string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/agent-card.json" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(live))
+	}))
+	defer srv.Close()
+
+	stored, err := structpb.NewStruct(map[string]any{
+		"name":    "weather",
+		"version": "1.0.0",
+		"url":     srv.URL + "/a2a",
+		"skills": []any{
+			map[string]any{"id": "forecast", "name": "Forecast"},
+			map[string]any{"id": "alerts", "name": "Alerts"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := translator.A2AToRecord(stored)
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	live = `{"name": "weather", "version": "1.1.0", "url": "` + srv.URL + `/a2a", "skills": [
+		{"id": "forecast", "name": "Forecast", "tags": ["weather"]},
+		{"id": "radar", "name": "Radar"}]}`
+
+	client := a2aclient.New()
+
+	result, err := client.Sync(context.Background(), record, "")
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	want := map[string]string{
+		"skills.alerts":   translator.CardChangeRemoved,
+		"skills.forecast": translator.CardChangeChanged,
+		"skills.radar":    translator.CardChangeAdded,
+		"version":         translator.CardChangeChanged,
+	}
+
+	if len(result.Changes) != len(want) {
+		t.Fatalf("changes = %+v, want %v", result.Changes, want)
+	}
+
+	for _, c := range result.Changes {
+		if want[c.Path] != c.Kind {
+			t.Errorf("change %s: kind %s, want %s", c.Path, c.Kind, want[c.Path])
+		}
+	}
+
+	card, err := translator.RecordToA2A(result.Record)
+	if err != nil {
+		t.Fatalf("RecordToA2A: %v", err)
+	}
+
+	if card.GetFields()["version"].GetStringValue() != "1.1.0" || len(card.GetFields()["skills"].GetListValue().GetValues()) != 2 {
+		t.Errorf("record not updated to the live card: %v", card)
+	}
+
+	if original, _ := translator.RecordToA2A(record); original.GetFields()["version"].GetStringValue() != "1.0.0" {
+		t.Error("input record modified")
+	}
+
+	// A second sync finds nothing to change.
+	again, err := client.Sync(context.Background(), result.Record, srv.URL)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if len(again.Changes) != 0 || again.Record != result.Record {
+		t.Errorf("expected no changes, got %+v", again.Changes)
+	}
+}
+
+func TestSyncWithoutA2AModule(t *testing.T) {
+	record, _ := structpb.NewStruct(map[string]any{"name": "no-modules"})
+
+	if _, err := a2aclient.New().Sync(context.Background(), record, ""); err == nil {
+		t.Error("expected an error for a record without A2A module")
+	}
+}
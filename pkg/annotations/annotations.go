@@ -0,0 +1,170 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package annotations reads and writes the string annotations of OASF records
+// using dotted, namespaced keys such as "a2a.url" or "mcp.package.registry".
+package annotations
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const field = "annotations"
+
+// InternalNamespace holds annotations the SDK keeps for its own bookkeeping;
+// they are dropped before a record is published.
+const InternalNamespace = "oasf.sdk"
+
+// maxKeyLength bounds annotation keys so they stay usable as labels and tags.
+const maxKeyLength = 253
+
+// ErrInvalidKey is returned (wrapped, with the key) for annotation keys that
+// do not follow the naming convention.
+var ErrInvalidKey = errors.New("invalid annotation key")
+
+// segmentPattern is one dot-separated segment of a key: lowercase letters,
+// digits, '_' and '-', starting with a letter or digit.
+var segmentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateKey checks that key is a dot-separated list of non-empty segments
+// made of lowercase letters, digits, '_' and '-', e.g. "mcp.package.registry".
+func ValidateKey(key string) error {
+	if key == "" || len(key) > maxKeyLength {
+		return fmt.Errorf("%w %q: must be 1-%d characters", ErrInvalidKey, key, maxKeyLength)
+	}
+
+	for _, segment := range strings.Split(key, ".") {
+		if !segmentPattern.MatchString(segment) {
+			return fmt.Errorf("%w %q: segment %q must match %s", ErrInvalidKey, key, segment, segmentPattern)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the annotation stored under key and whether it is set.
+func Get(record *structpb.Struct, key string) (string, bool) {
+	value, ok := annotations(record).GetFields()[key]
+	if !ok {
+		return "", false
+	}
+
+	return value.GetStringValue(), true
+}
+
+// Set stores value under key, creating the annotations map when needed.
+func Set(record *structpb.Struct, key, value string) error {
+	return SetAll(record, map[string]string{key: value})
+}
+
+// SetAll stores all entries of values. Keys are validated first, so an invalid
+// key leaves the record unchanged.
+func SetAll(record *structpb.Struct, values map[string]string) error {
+	if record == nil {
+		return errors.New("record is nil")
+	}
+
+	for key := range values {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	target := annotations(record)
+	if target == nil {
+		target = &structpb.Struct{}
+
+		if record.Fields == nil {
+			record.Fields = map[string]*structpb.Value{}
+		}
+
+		record.Fields[field] = structpb.NewStructValue(target)
+	}
+
+	if target.Fields == nil {
+		target.Fields = map[string]*structpb.Value{}
+	}
+
+	for key, value := range values {
+		target.Fields[key] = structpb.NewStringValue(value)
+	}
+
+	return nil
+}
+
+// Delete removes the annotation stored under key, if any.
+func Delete(record *structpb.Struct, key string) {
+	if target := annotations(record); target != nil {
+		delete(target.Fields, key)
+	}
+}
+
+// Namespace returns the annotations under namespace with the namespace prefix
+// stripped, e.g. Namespace(record, "mcp.package") maps "registry" to the value
+// of "mcp.package.registry". The result is empty for an invalid namespace.
+func Namespace(record *structpb.Struct, namespace string) map[string]string {
+	out := map[string]string{}
+
+	if ValidateKey(namespace) != nil {
+		return out
+	}
+
+	prefix := namespace + "."
+
+	for key, value := range annotations(record).GetFields() {
+		if rest, ok := strings.CutPrefix(key, prefix); ok {
+			out[rest] = value.GetStringValue()
+		}
+	}
+
+	return out
+}
+
+// DeleteNamespace removes every annotation under namespace and returns how
+// many were removed.
+func DeleteNamespace(record *structpb.Struct, namespace string) int {
+	target := annotations(record)
+	if target == nil || ValidateKey(namespace) != nil {
+		return 0
+	}
+
+	removed := 0
+
+	for key := range target.Fields {
+		if strings.HasPrefix(key, namespace+".") {
+			delete(target.Fields, key)
+
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Keys returns the annotation keys of the record in sorted order.
+func Keys(record *structpb.Struct) []string {
+	fields := annotations(record).GetFields()
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func annotations(record *structpb.Struct) *structpb.Struct {
+	return record.GetFields()[field].GetStructValue()
+}
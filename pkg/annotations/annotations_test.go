@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package annotations_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSetAndGet(t *testing.T) {
+	record := &structpb.Struct{}
+
+	if err := annotations.Set(record, "a2a.url", "https://agent.example.com"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	err := annotations.SetAll(record, map[string]string{
+		"mcp.package.registry": "npm",
+		"mcp.package.name":     "@vendor/server",
+		"team":                 "search",
+	})
+	if err != nil {
+		t.Fatalf("SetAll: %v", err)
+	}
+
+	if got, ok := annotations.Get(record, "a2a.url"); !ok || got != "https://agent.example.com" {
+		t.Errorf("Get(a2a.url) = %q, %v", got, ok)
+	}
+
+	if _, ok := annotations.Get(record, "a2a.missing"); ok {
+		t.Error("Get must report unset keys")
+	}
+
+	want := map[string]string{"registry": "npm", "name": "@vendor/server"}
+	if got := annotations.Namespace(record, "mcp.package"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Namespace = %v, want %v", got, want)
+	}
+
+	annotations.Delete(record, "team")
+
+	wantKeys := []string{"a2a.url", "mcp.package.name", "mcp.package.registry"}
+	if got := annotations.Keys(record); !reflect.DeepEqual(got, wantKeys) {
+		t.Errorf("Keys = %v, want %v", got, wantKeys)
+	}
+
+	if removed := annotations.DeleteNamespace(record, "mcp"); removed != 2 {
+		t.Errorf("DeleteNamespace removed %d annotations, want 2", removed)
+	}
+
+	if got := annotations.Keys(record); !reflect.DeepEqual(got, []string{"a2a.url"}) {
+		t.Errorf("Keys after DeleteNamespace = %v", got)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	record := &structpb.Struct{}
+
+	for _, key := range []string{"", "A2A.url", "a2a..url", ".a2a", "a2a.", "a2a url", "_a2a"} {
+		if err := annotations.ValidateKey(key); !errors.Is(err, annotations.ErrInvalidKey) {
+			t.Errorf("ValidateKey(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	err := annotations.SetAll(record, map[string]string{"a2a.url": "x", "Bad Key": "y"})
+	if !errors.Is(err, annotations.ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+
+	if len(annotations.Keys(record)) != 0 {
+		t.Error("SetAll must not write anything when a key is invalid")
+	}
+
+	if got := annotations.Namespace(record, "Bad Namespace"); len(got) != 0 {
+		t.Errorf("Namespace with an invalid name = %v", got)
+	}
+
+	if err := annotations.Set(nil, "a2a.url", "x"); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+}
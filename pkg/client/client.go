@@ -0,0 +1,272 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// RequestIDHeader is the metadata key of the request ID of a call. The
+// server logs it with every record of the request, sends it to the schema
+// servers it calls, and returns it in the response headers.
+const RequestIDHeader = "x-request-id"
+
+// Call defaults of New.
+const (
+	// DefaultTimeout bounds a call, retries included, when its context has
+	// no deadline.
+	DefaultTimeout = 30 * time.Second
+	// DefaultRetries is the number of times a call is retried while the
+	// server or its schema server is unavailable.
+	DefaultRetries = 3
+
+	retryBaseDelay = 100 * time.Millisecond
+	retryMaxDelay  = 2 * time.Second
+)
+
+// WithTimeout sets the timeout of calls whose context has no deadline; 0
+// disables it. It only applies to New and NewFromConn.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetries sets the number of retries of calls that fail with
+// UNAVAILABLE; 0 disables them. All calls of the Client are idempotent. It
+// only applies to New and NewFromConn.
+func WithRetries(retries int) Option {
+	return func(o *options) {
+		o.retries = max(retries, 0)
+	}
+}
+
+// Target is the output format of Translate.
+type Target string
+
+// Translation targets.
+const (
+	TargetGHCopilot     Target = "gh-copilot"
+	TargetA2A           Target = "a2a"
+	TargetSkillMarkdown Target = "skill-markdown"
+)
+
+// WithRequestID returns a context whose calls carry the request ID, e.g. the
+// ID of the request the caller is handling, so its logs correlate with those
+// of the server. Calls without one get a generated ID, reported by Error.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIDHeader, id)
+}
+
+// ValidateOption configures Validate.
+type ValidateOption func(*validationv1.ValidateRecordRequest)
+
+// WithSchemaURL validates against the schema server at url. It is required:
+// the server has no default schema URL, so Validate without it fails with an
+// INVALID_ARGUMENT Error of reason SCHEMA_URL_INVALID (in-process, with the
+// validator's error).
+func WithSchemaURL(url string) ValidateOption {
+	return func(req *validationv1.ValidateRecordRequest) {
+		req.SchemaUrl = url
+	}
+}
+
+// ValidationResult is the outcome of Validate.
+type ValidationResult struct {
+	Valid    bool
+	Errors   []string
+	Warnings []string
+}
+
+// Interface is implemented by Client, which calls a server, and by the
+// in-process client of NewInProcess, so callers switch between remote and
+// embedded modes by changing the constructor.
+type Interface interface {
+	Decode(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error)
+	Validate(ctx context.Context, record *structpb.Struct, opts ...ValidateOption) (*ValidationResult, error)
+	Translate(ctx context.Context, record *structpb.Struct, target Target) (*structpb.Value, error)
+	Close() error
+}
+
+var _ Interface = (*Client)(nil)
+
+// Client calls the services of an oasf-sdk server. Errors are *Error values
+// matching the errors of the in-process SDK libraries. A Client is safe for
+// concurrent use.
+type Client struct {
+	conn        *grpc.ClientConn
+	decoding    decodingv1grpc.DecodingServiceClient
+	validation  validationv1grpc.ValidationServiceClient
+	translation translationv1grpc.TranslationServiceClient
+	timeout     time.Duration
+	retries     int
+}
+
+// New dials the server at the endpoints with Dial and returns a client
+// owning the connection.
+func New(endpoints []string, opts ...Option) (*Client, error) {
+	conn, err := Dial(endpoints, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewFromConn(conn, opts...)
+	c.conn = conn
+
+	return c, nil
+}
+
+// NewFromConn returns a client using a connection owned by the caller, e.g.
+// one shared with other generated stubs. Close does not close it.
+func NewFromConn(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Client{
+		decoding:    decodingv1grpc.NewDecodingServiceClient(conn),
+		validation:  validationv1grpc.NewValidationServiceClient(conn),
+		translation: translationv1grpc.NewTranslationServiceClient(conn),
+		timeout:     o.timeout,
+		retries:     o.retries,
+	}
+}
+
+// Close closes the connection of a client created with New.
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+
+	return c.conn.Close() //nolint:wrapcheck
+}
+
+// Decode decodes the record into the typed OASF model of its schema version.
+func (c *Client) Decode(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
+	return invoke(ctx, c, c.decoding.DecodeRecord, &decodingv1.DecodeRecordRequest{Record: record})
+}
+
+// Validate validates the record. Validation errors are reported in the
+// result; the error is only set when the record could not be validated.
+func (c *Client) Validate(ctx context.Context, record *structpb.Struct, opts ...ValidateOption) (*ValidationResult, error) {
+	req := &validationv1.ValidateRecordRequest{Record: record}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := invoke(ctx, c, c.validation.ValidateRecord, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidationResult{Valid: resp.GetIsValid(), Errors: resp.GetErrors(), Warnings: resp.GetWarnings()}, nil
+}
+
+// Translate translates the record to the target format. JSON targets are
+// returned as struct values shaped like the server's responses, e.g.
+// {"mcpConfig": ...} or {"a2aCard": ...}; the skill markdown as a string
+// value.
+func (c *Client) Translate(ctx context.Context, record *structpb.Struct, target Target) (*structpb.Value, error) {
+	switch target {
+	case TargetGHCopilot:
+		resp, err := invoke(ctx, c, c.translation.RecordToGHCopilot, &translationv1.RecordToGHCopilotRequest{Record: record})
+		if err != nil {
+			return nil, err
+		}
+
+		return structpb.NewStructValue(resp.GetData()), nil
+	case TargetA2A:
+		resp, err := invoke(ctx, c, c.translation.RecordToA2A, &translationv1.RecordToA2ARequest{Record: record})
+		if err != nil {
+			return nil, err
+		}
+
+		return structpb.NewStructValue(resp.GetData()), nil
+	case TargetSkillMarkdown:
+		resp, err := invoke(ctx, c, c.translation.RecordToSkillMarkdown, &translationv1.RecordToSkillMarkdownRequest{Record: record})
+		if err != nil {
+			return nil, err
+		}
+
+		return structpb.NewStringValue(resp.GetData()), nil
+	default:
+		return nil, unsupportedTarget(target)
+	}
+}
+
+func unsupportedTarget(target Target) error {
+	return fmt.Errorf("%w: unsupported translation target %q", translator.ErrInvalidInput, target)
+}
+
+// invoke calls the RPC with the client's default timeout, retrying while it
+// fails with UNAVAILABLE. The attempts share the request ID of the call.
+func invoke[Req, Resp any](ctx context.Context, c *Client, call func(context.Context, Req, ...grpc.CallOption) (Resp, error), req Req) (Resp, error) {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	ctx, requestID := ensureRequestID(ctx)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := call(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if attempt >= c.retries || status.Code(err) != codes.Unavailable {
+			return resp, withRequestID(FromStatus(err), requestID)
+		}
+
+		select {
+		case <-time.After(min(retryBaseDelay<<attempt, retryMaxDelay)):
+		case <-ctx.Done():
+			return resp, withRequestID(FromStatus(err), requestID)
+		}
+	}
+}
+
+// ensureRequestID returns the request ID of the outgoing metadata of the
+// context, adding a generated one when it has none.
+func ensureRequestID(ctx context.Context) (context.Context, string) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 {
+			return ctx, ids[0]
+		}
+	}
+
+	b := make([]byte, 16) //nolint:mnd
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	return WithRequestID(ctx, id), id
+}
+
+func withRequestID(err error, requestID string) error {
+	if e, ok := err.(*Error); ok { //nolint:errorlint
+		e.RequestID = requestID
+	}
+
+	return err
+}
@@ -0,0 +1,247 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// reasonError returns a status error with an ErrorInfo reason, as the
+// oasf-sdk server sends them.
+func reasonError(code codes.Code, reason, message string) error {
+	st, _ := status.New(code, message).WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: "oasf-sdk.agntcy.org"})
+
+	return st.Err()
+}
+
+type fakeDecoding struct {
+	decodingv1grpc.UnimplementedDecodingServiceServer
+}
+
+func (fakeDecoding) DecodeRecord(_ context.Context, req *decodingv1.DecodeRecordRequest) (*decodingv1.DecodeRecordResponse, error) {
+	if req.GetRecord().GetFields()["schema_version"] == nil {
+		return nil, reasonError(codes.InvalidArgument, "SCHEMA_VERSION_MISSING", "failed to decode record: schema_version field is missing")
+	}
+
+	return decoder.DecodeRecord(req.GetRecord()) //nolint:wrapcheck
+}
+
+// fakeValidation fails with UNAVAILABLE until failures is exhausted.
+type fakeValidation struct {
+	validationv1grpc.UnimplementedValidationServiceServer
+
+	failures atomic.Int32
+	calls    atomic.Int32
+
+	mu         sync.Mutex
+	requestIDs []string
+}
+
+func (f *fakeValidation) ValidateRecord(ctx context.Context, req *validationv1.ValidateRecordRequest) (*validationv1.ValidateRecordResponse, error) {
+	f.calls.Add(1)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	f.mu.Lock()
+	f.requestIDs = append(f.requestIDs, md.Get(client.RequestIDHeader)...)
+	f.mu.Unlock()
+
+	if f.failures.Add(-1) >= 0 {
+		return nil, status.Error(codes.Unavailable, "try again")
+	}
+
+	return &validationv1.ValidateRecordResponse{IsValid: false, Errors: []string{"bad " + req.GetSchemaUrl()}}, nil
+}
+
+type fakeTranslation struct {
+	translationv1grpc.UnimplementedTranslationServiceServer
+}
+
+func (fakeTranslation) RecordToA2A(context.Context, *translationv1.RecordToA2ARequest) (*translationv1.RecordToA2AResponse, error) {
+	return nil, reasonError(codes.FailedPrecondition, "MODULE_MISSING", "A2A module not found in record")
+}
+
+func (fakeTranslation) RecordToSkillMarkdown(context.Context, *translationv1.RecordToSkillMarkdownRequest) (*translationv1.RecordToSkillMarkdownResponse, error) {
+	return &translationv1.RecordToSkillMarkdownResponse{Data: "---\nname: skill\n---\n"}, nil
+}
+
+func newTestClient(t *testing.T, validation *fakeValidation, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	decodingv1grpc.RegisterDecodingServiceServer(srv, fakeDecoding{})
+	validationv1grpc.RegisterValidationServiceServer(srv, validation)
+	translationv1grpc.RegisterTranslationServiceServer(srv, fakeTranslation{})
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return client.NewFromConn(conn, opts...)
+}
+
+func testRecord(t *testing.T, fields map[string]any) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return record
+}
+
+func TestDecode(t *testing.T) {
+	c := newTestClient(t, &fakeValidation{})
+
+	resp, err := c.Decode(context.Background(), testRecord(t, map[string]any{"schema_version": "1.0.0", "name": "agent"}))
+	if err != nil || resp.GetV1().GetName() != "agent" {
+		t.Fatalf("Decode = %v, %v", resp, err)
+	}
+
+	_, err = c.Decode(context.Background(), testRecord(t, map[string]any{"name": "agent"}))
+	if !errors.Is(err, decoder.ErrMissingSchemaVersion) {
+		t.Fatalf("got %v, want decoder.ErrMissingSchemaVersion", err)
+	}
+
+	var clientErr *client.Error
+	if !errors.As(err, &clientErr) || clientErr.Reason != "SCHEMA_VERSION_MISSING" || status.Code(err) != codes.InvalidArgument {
+		t.Errorf("got %#v, want an InvalidArgument *client.Error", err)
+	}
+}
+
+func TestValidateRetries(t *testing.T) {
+	validation := &fakeValidation{}
+	validation.failures.Store(2)
+
+	c := newTestClient(t, validation)
+
+	result, err := c.Validate(context.Background(), testRecord(t, map[string]any{}), client.WithSchemaURL("https://schema.example.org"))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0] != "bad https://schema.example.org" {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	if got := validation.calls.Load(); got != 3 {
+		t.Errorf("got %d calls, want 3", got)
+	}
+}
+
+func TestValidateGivesUp(t *testing.T) {
+	validation := &fakeValidation{}
+	validation.failures.Store(10)
+
+	c := newTestClient(t, validation, client.WithRetries(1))
+
+	_, err := c.Validate(context.Background(), testRecord(t, map[string]any{}))
+	if !errors.Is(err, client.ErrUnavailable) {
+		t.Fatalf("got %v, want ErrUnavailable", err)
+	}
+
+	if got := validation.calls.Load(); got != 2 {
+		t.Errorf("got %d calls, want 2", got)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	validation := &fakeValidation{}
+	validation.failures.Store(10)
+
+	c := newTestClient(t, validation, client.WithRetries(1))
+
+	_, err := c.Validate(context.Background(), testRecord(t, map[string]any{}))
+
+	var clientErr *client.Error
+	if !errors.As(err, &clientErr) || len(clientErr.RequestID) != 32 {
+		t.Fatalf("got %#v, want an error with a generated request ID", err)
+	}
+
+	if ids := validation.requestIDs; len(ids) != 2 || ids[0] != clientErr.RequestID || ids[1] != ids[0] {
+		t.Errorf("request IDs = %v, want the ID of the error on every attempt", ids)
+	}
+
+	validation.failures.Store(0)
+	validation.requestIDs = nil
+
+	if _, err := c.Validate(client.WithRequestID(context.Background(), "caller-1"), testRecord(t, map[string]any{})); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	if ids := validation.requestIDs; len(ids) != 1 || ids[0] != "caller-1" {
+		t.Errorf("request IDs = %v, want the ID of the caller", ids)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	validation := &fakeValidation{}
+	validation.failures.Store(100)
+
+	c := newTestClient(t, validation, client.WithTimeout(50*time.Millisecond), client.WithRetries(100))
+
+	start := time.Now()
+
+	if _, err := c.Validate(context.Background(), testRecord(t, map[string]any{})); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Validate took %v, want the 50ms timeout to stop the retries", elapsed)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	c := newTestClient(t, &fakeValidation{})
+	record := testRecord(t, map[string]any{"schema_version": "1.0.0"})
+
+	markdown, err := c.Translate(context.Background(), record, client.TargetSkillMarkdown)
+	if err != nil || markdown.GetStringValue() != "---\nname: skill\n---\n" {
+		t.Fatalf("Translate = %v, %v", markdown, err)
+	}
+
+	if _, err := c.Translate(context.Background(), record, client.TargetA2A); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("got %v, want translator.ErrModuleNotFound", err)
+	}
+
+	if _, err := c.Translate(context.Background(), record, "unknown"); err == nil {
+		t.Error("expected an error for an unknown target")
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client is the Go client of the oasf-sdk server. Client wraps the
+// generated gRPC stubs with retries, default deadlines and errors matching
+// the in-process SDK libraries; NewInProcess runs those libraries behind the
+// same Interface. Dial returns the underlying gRPC connection, with keepalive,
+// reconnect backoff and, across several endpoints, round-robin load
+// balancing; it is meant to be created once and shared, not dialed per call.
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// Keepalive and backoff defaults. The keepalive interval is above the 20
+// second minimum the oasf-sdk server permits; servers enforcing a longer one
+// make the client ping less often.
+const (
+	DefaultKeepaliveTime    = 30 * time.Second
+	DefaultKeepaliveTimeout = 10 * time.Second
+	DefaultMaxBackoff       = 30 * time.Second
+)
+
+// endpointsScheme is the resolver scheme of connections to several endpoints.
+const endpointsScheme = "oasf-sdk"
+
+// roundRobinConfig is the service config balancing RPCs across addresses.
+const roundRobinConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+
+// Option configures Dial and New.
+type Option func(*options)
+
+type options struct {
+	creds      credentials.TransportCredentials
+	apiKey     string
+	keepalive  keepalive.ClientParameters
+	backoff    backoff.Config
+	roundRobin bool
+	dialOpts   []grpc.DialOption
+	timeout    time.Duration
+	retries    int
+}
+
+func defaultOptions() *options {
+	o := &options{
+		creds: insecure.NewCredentials(),
+		keepalive: keepalive.ClientParameters{
+			Time:                DefaultKeepaliveTime,
+			Timeout:             DefaultKeepaliveTimeout,
+			PermitWithoutStream: true,
+		},
+		backoff: backoff.DefaultConfig,
+		timeout: DefaultTimeout,
+		retries: DefaultRetries,
+	}
+	o.backoff.MaxDelay = DefaultMaxBackoff
+
+	return o
+}
+
+// WithTLS connects over TLS with the given configuration; nil uses the
+// system roots. Connections are plaintext by default.
+func WithTLS(cfg *tls.Config) Option {
+	return func(o *options) {
+		if cfg == nil {
+			cfg = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		o.creds = credentials.NewTLS(cfg)
+	}
+}
+
+// WithAPIKey sends the key as a bearer token on every RPC.
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithKeepalive sets the keepalive parameters. Pings keep idle shared
+// connections open through proxies and detect dead servers; they default to
+// every DefaultKeepaliveTime, also without active RPCs.
+func WithKeepalive(params keepalive.ClientParameters) Option {
+	return func(o *options) {
+		o.keepalive = params
+	}
+}
+
+// WithBackoff sets the backoff between reconnection attempts. It defaults to
+// the gRPC backoff capped at DefaultMaxBackoff.
+func WithBackoff(cfg backoff.Config) Option {
+	return func(o *options) {
+		o.backoff = cfg
+	}
+}
+
+// WithRoundRobin balances RPCs across all the addresses an endpoint resolves
+// to, e.g. the pods of a headless Kubernetes service. It is always on with
+// several endpoints.
+func WithRoundRobin() Option {
+	return func(o *options) {
+		o.roundRobin = true
+	}
+}
+
+// WithDialOptions appends gRPC dial options, e.g. interceptors.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOpts = append(o.dialOpts, opts...)
+	}
+}
+
+// Dial returns a connection to the oasf-sdk server at the endpoints
+// (host:port or gRPC targets). With several endpoints, RPCs are balanced
+// round-robin across them and a failing endpoint is skipped until it
+// reconnects. No connection is made until the first RPC. The connection is
+// safe for concurrent use; close it when done.
+func Dial(endpoints []string, opts ...Option) (*grpc.ClientConn, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("at least one endpoint is required")
+	}
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithKeepaliveParams(o.keepalive),
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: o.backoff}),
+	}
+
+	if o.apiKey != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(apiKeyCredentials{key: o.apiKey, requireTLS: o.creds.Info().SecurityProtocol != "insecure"}))
+	}
+
+	target := endpoints[0]
+
+	if len(endpoints) > 1 {
+		addresses := make([]resolver.Address, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			addresses = append(addresses, resolver.Address{Addr: endpoint})
+		}
+
+		r := manual.NewBuilderWithScheme(endpointsScheme)
+		r.InitialState(resolver.State{Addresses: addresses})
+
+		target = endpointsScheme + ":///"
+		dialOpts = append(dialOpts, grpc.WithResolvers(r))
+		o.roundRobin = true
+	}
+
+	if o.roundRobin {
+		dialOpts = append(dialOpts, grpc.WithDefaultServiceConfig(roundRobinConfig))
+	}
+
+	conn, err := grpc.NewClient(target, append(dialOpts, o.dialOpts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", target, err)
+	}
+
+	return conn, nil
+}
+
+// apiKeyCredentials sends the API key as a bearer token on every RPC.
+type apiKeyCredentials struct {
+	key        string
+	requireTLS bool
+}
+
+func (c apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.key}, nil
+}
+
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
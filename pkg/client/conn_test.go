@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// testServer serves the health service and counts its calls, recording the
+// last authorization header.
+type testServer struct {
+	addr  string
+	calls atomic.Int32
+	auth  atomic.Value
+}
+
+func startServer(t *testing.T) *testServer {
+	t.Helper()
+
+	lis, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	ts := &testServer{addr: lis.Addr().String()}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ts.calls.Add(1)
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		ts.auth.Store(md.Get("authorization"))
+
+		return handler(ctx, req)
+	}))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	return ts
+}
+
+func TestDialRoundRobin(t *testing.T) {
+	first, second := startServer(t), startServer(t)
+
+	conn, err := client.Dial([]string{first.addr, second.addr})
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	health := healthpb.NewHealthClient(conn)
+
+	for range 10 {
+		if _, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+
+	if first.calls.Load() == 0 || second.calls.Load() == 0 {
+		t.Errorf("calls = %d, %d, want both endpoints used", first.calls.Load(), second.calls.Load())
+	}
+}
+
+func TestDialAPIKey(t *testing.T) {
+	server := startServer(t)
+
+	// Over a plaintext connection the key is sent without requiring TLS.
+	conn, err := client.Dial([]string{server.addr}, client.WithAPIKey("secret"))
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	if auth, _ := server.auth.Load().([]string); len(auth) != 1 || auth[0] != "Bearer secret" {
+		t.Errorf("authorization = %v, want [Bearer secret]", auth)
+	}
+}
+
+func TestDialRequiresEndpoint(t *testing.T) {
+	if _, err := client.Dial(nil); err == nil {
+		t.Error("expected an error without endpoints")
+	}
+}
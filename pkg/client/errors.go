@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUnavailable is returned (wrapped) when the server cannot be reached.
+var ErrUnavailable = errors.New("server unavailable")
+
+// reasonErrors maps the ErrorInfo reasons of the oasf-sdk server to the
+// errors the SDK libraries return in-process for the same failure, so
+// callers branch on them the same way locally and remotely.
+var reasonErrors = map[string][]error{
+	"RECORD_MISSING":             {decoder.ErrNilRecord},
+	"RECORD_TOO_LARGE":           {record.ErrTooLarge},
+	"SCHEMA_VERSION_MISSING":     {decoder.ErrMissingSchemaVersion},
+	"SCHEMA_VERSION_INVALID":     {decoder.ErrInvalidSchemaVersion},
+	"SCHEMA_VERSION_UNSUPPORTED": {decoder.ErrUnsupportedSchemaVersion, schema.ErrUnsupportedVersion},
+	"SCHEMA_UNAVAILABLE":         {schema.ErrSchemaUnavailable},
+	"MODULE_MISSING":             {translator.ErrModuleNotFound},
+	"INVALID_INPUT":              {translator.ErrInvalidInput},
+	"RECORD_INVALID":             {translator.ErrInvalidRecord},
+}
+
+// Error is an error returned by the server. errors.Is matches it against the
+// SDK error of its reason, e.g. decoder.ErrMissingSchemaVersion, and against
+// ErrUnavailable when the server could not be reached.
+type Error struct {
+	Code codes.Code
+	// Reason is the machine-readable ErrorInfo reason, e.g.
+	// SCHEMA_VERSION_MISSING; empty when the server sent none.
+	Reason  string
+	Message string
+	// Violations are the offending request fields.
+	Violations []*errdetails.BadRequest_FieldViolation
+	// RequestID is the request ID of the call, to find its logs on the
+	// server; empty for errors built with FromStatus.
+	RequestID string
+
+	status *status.Status
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Code == codes.Unavailable && e.Reason == "" {
+		return []error{ErrUnavailable}
+	}
+
+	return reasonErrors[e.Reason]
+}
+
+// GRPCStatus returns the status the error was built from, so status.Code and
+// status.FromError keep working on it.
+func (e *Error) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// FromStatus converts a gRPC status error into an *Error. Other errors, and
+// nil, are returned unchanged.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+
+	e := &Error{Code: st.Code(), Message: st.Message(), status: st}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			e.Reason = d.GetReason()
+		case *errdetails.BadRequest:
+			e.Violations = append(e.Violations, d.GetFieldViolations()...)
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var _ client.Interface = (*Client)(nil)
+
+// Client is a scripted client.Interface. The zero value is ready to use:
+// unscripted Decode and Translate run the in-process libraries, which make no
+// network calls, and unscripted Validate reports the record valid. A Client
+// is safe for concurrent use once scripted.
+type Client struct {
+	recorder
+
+	// DecodeFunc, ValidateFunc and TranslateFunc script the results of the
+	// methods. ValidateFunc receives the schema URL of WithSchemaURL.
+	DecodeFunc    func(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error)
+	ValidateFunc  func(ctx context.Context, record *structpb.Struct, schemaURL string) (*client.ValidationResult, error)
+	TranslateFunc func(ctx context.Context, record *structpb.Struct, target client.Target) (*structpb.Value, error)
+	// CloseErr is returned by Close.
+	CloseErr error
+}
+
+func (c *Client) Decode(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
+	c.record("Decode", record)
+
+	if c.DecodeFunc != nil {
+		return c.DecodeFunc(ctx, record)
+	}
+
+	return client.NewInProcess().Decode(ctx, record) //nolint:wrapcheck
+}
+
+func (c *Client) Validate(ctx context.Context, record *structpb.Struct, opts ...client.ValidateOption) (*client.ValidationResult, error) {
+	req := &validationv1.ValidateRecordRequest{}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	c.record("Validate", record, req.GetSchemaUrl())
+
+	if c.ValidateFunc != nil {
+		return c.ValidateFunc(ctx, record, req.GetSchemaUrl())
+	}
+
+	return &client.ValidationResult{Valid: true}, nil
+}
+
+func (c *Client) Translate(ctx context.Context, record *structpb.Struct, target client.Target) (*structpb.Value, error) {
+	c.record("Translate", record, target)
+
+	if c.TranslateFunc != nil {
+		return c.TranslateFunc(ctx, record, target)
+	}
+
+	return client.NewInProcess().Translate(ctx, record, target) //nolint:wrapcheck
+}
+
+func (c *Client) Close() error {
+	c.record("Close", nil)
+
+	return c.CloseErr
+}
@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fake provides scripted implementations of the SDK clients for unit
+// tests, so code using the SDK can be tested without an oasf-sdk server or a
+// schema server. Client implements client.Interface; Validator and Schema
+// have the methods of *validator.Validator and *schema.Schema, so they
+// satisfy any interface the caller declares over them. Results are set
+// through exported fields and every call is recorded.
+package fake
+
+import (
+	"slices"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Call is a recorded method call.
+type Call struct {
+	Method string
+	// Record is the record of the call, if any.
+	Record *structpb.Struct
+	// Args are the other arguments, without the context and the options:
+	// the schema URL of Validate, the target of Translate, the records of
+	// ValidateRecords, the type and name of GetJSONSchema, the endpoint of
+	// GetSchemaTaxonomy and the schema version selected by the options of the
+	// Schema methods.
+	Args []any
+}
+
+// recorder records the calls of a fake.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, record *structpb.Struct, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Record: record, Args: args})
+}
+
+// Calls returns the recorded calls, oldest first.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.calls)
+}
+
+// CallCount returns the number of recorded calls of the method.
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+
+	for _, call := range r.calls {
+		if call.Method == method {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Reset forgets the recorded calls.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+}
@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"github.com/agntcy/oasf-sdk/pkg/client/fake"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// validatorAPI and schemaAPI are the exported methods of *validator.Validator
+// and *schema.Schema; the assertions below fail to compile when the fakes
+// fall behind them.
+type validatorAPI interface {
+	ValidateRecord(ctx context.Context, record *structpb.Struct) (bool, []string, []string, error)
+	ValidateRecords(ctx context.Context, records []*structpb.Struct, opts ...validator.BatchOption) (*validator.BatchResult, error)
+}
+
+type schemaAPI interface {
+	GetDefaultSchemaVersion(ctx context.Context) (string, error)
+	GetAvailableSchemaVersions(ctx context.Context) ([]string, error)
+	ClearCache()
+	GetJSONSchema(ctx context.Context, schemaType schema.EntityType, name string, opts ...schema.SchemaOption) ([]byte, error)
+	GetSchemaTaxonomy(ctx context.Context, endpoint string, opts ...schema.SchemaOption) (schema.Taxonomy, error)
+	GetRecordJSONSchema(ctx context.Context, opts ...schema.SchemaOption) ([]byte, error)
+	GetSchemaSkills(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error)
+	GetSchemaDomains(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error)
+	GetSchemaModules(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error)
+}
+
+var (
+	_ validatorAPI        = (*validator.Validator)(nil)
+	_ validatorAPI        = (*fake.Validator)(nil)
+	_ schemaAPI           = (*schema.Schema)(nil)
+	_ schemaAPI           = (*fake.Schema)(nil)
+	_ record.SchemaClient = (*fake.Schema)(nil)
+)
+
+func TestClient(t *testing.T) {
+	errBoom := errors.New("boom")
+	c := &fake.Client{
+		ValidateFunc: func(_ context.Context, _ *structpb.Struct, schemaURL string) (*client.ValidationResult, error) {
+			return &client.ValidationResult{Errors: []string{schemaURL}}, nil
+		},
+		TranslateFunc: func(context.Context, *structpb.Struct, client.Target) (*structpb.Value, error) {
+			return nil, errBoom
+		},
+	}
+
+	rec, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0", "name": "agent"})
+
+	resp, err := c.Decode(context.Background(), rec)
+	if err != nil || resp.GetV1().GetName() != "agent" {
+		t.Fatalf("unscripted Decode = %v, %v", resp, err)
+	}
+
+	result, err := c.Validate(context.Background(), rec, client.WithSchemaURL("https://schema.example.org"))
+	if err != nil || result.Valid || result.Errors[0] != "https://schema.example.org" {
+		t.Errorf("Validate = %+v, %v", result, err)
+	}
+
+	if _, err := c.Translate(context.Background(), rec, client.TargetA2A); !errors.Is(err, errBoom) {
+		t.Errorf("got %v, want the scripted error", err)
+	}
+
+	calls := c.Calls()
+	if len(calls) != 3 || calls[1].Method != "Validate" || calls[1].Args[0] != "https://schema.example.org" || calls[2].Args[0] != client.TargetA2A {
+		t.Errorf("unexpected calls %+v", calls)
+	}
+
+	c.Reset()
+
+	if c.CallCount("Decode") != 0 {
+		t.Error("Reset kept the calls")
+	}
+}
+
+func TestValidator(t *testing.T) {
+	v := &fake.Validator{
+		ValidateFunc: func(_ context.Context, rec *structpb.Struct) validator.RecordResult {
+			if rec.GetFields()["name"].GetStringValue() == "" {
+				return validator.RecordResult{Errors: []string{"name is required"}}
+			}
+
+			return validator.RecordResult{Valid: true}
+		},
+	}
+
+	named, _ := structpb.NewStruct(map[string]any{"name": "agent"})
+	unnamed, _ := structpb.NewStruct(map[string]any{})
+
+	valid, errs, _, err := v.ValidateRecord(context.Background(), unnamed)
+	if err != nil || valid || len(errs) != 1 {
+		t.Errorf("ValidateRecord = %v, %v, %v", valid, errs, err)
+	}
+
+	batch, err := v.ValidateRecords(context.Background(), []*structpb.Struct{named, unnamed})
+	if err != nil || batch.Valid != 1 || batch.Invalid != 1 {
+		t.Errorf("ValidateRecords = %+v, %v", batch, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	batch, err = v.ValidateRecords(ctx, []*structpb.Struct{named})
+	if !errors.Is(err, context.Canceled) || batch.Failed != 1 {
+		t.Errorf("canceled ValidateRecords = %+v, %v", batch, err)
+	}
+
+	if got := v.CallCount("ValidateRecords"); got != 2 {
+		t.Errorf("got %d ValidateRecords calls, want 2", got)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	s := &fake.Schema{
+		Versions:    []string{"0.8.0", "1.0.0"},
+		JSONSchemas: map[string][]byte{"record": []byte(`{"type": "object"}`)},
+		Taxonomies:  map[string]schema.Taxonomy{fake.SkillCategories: {"core": {ID: 1, Name: "core"}}},
+	}
+
+	if version, err := s.GetDefaultSchemaVersion(context.Background()); err != nil || version != record.DefaultSchemaVersion {
+		t.Errorf("GetDefaultSchemaVersion = %q, %v", version, err)
+	}
+
+	if data, err := s.GetRecordJSONSchema(context.Background(), schema.WithSchemaVersion("0.8.0")); err != nil || string(data) != `{"type": "object"}` {
+		t.Errorf("GetRecordJSONSchema = %s, %v", data, err)
+	}
+
+	if _, err := s.GetRecordJSONSchema(context.Background(), schema.WithSchemaVersion("9.9.9")); !errors.Is(err, schema.ErrUnsupportedVersion) {
+		t.Errorf("got %v, want schema.ErrUnsupportedVersion", err)
+	}
+
+	if skills, err := s.GetSchemaSkills(context.Background()); err != nil || skills["core"].ID != 1 {
+		t.Errorf("GetSchemaSkills = %v, %v", skills, err)
+	}
+
+	if _, err := s.GetSchemaDomains(context.Background()); !errors.Is(err, schema.ErrSchemaUnavailable) {
+		t.Errorf("got %v, want schema.ErrSchemaUnavailable", err)
+	}
+
+	calls := s.Calls()
+	if last := calls[len(calls)-1]; last.Method != "GetSchemaTaxonomy" || last.Args[0] != fake.DomainCategories {
+		t.Errorf("unexpected last call %+v", last)
+	}
+
+	if call := calls[1]; call.Args[2] != "0.8.0" {
+		t.Errorf("got %+v, want the selected version recorded", call)
+	}
+}
+
+func TestSchemaWithFillDefaults(t *testing.T) {
+	s := &fake.Schema{JSONSchemas: map[string][]byte{"record": []byte(`{"properties": {"version": {"default": "v1"}}}`)}}
+	rec, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0"})
+
+	if err := record.FillDefaults(context.Background(), rec, s); err != nil {
+		t.Fatalf("FillDefaults: %v", err)
+	}
+
+	if got := rec.GetFields()["version"].GetStringValue(); got != "v1" {
+		t.Errorf("got version %q, want the schema default", got)
+	}
+}
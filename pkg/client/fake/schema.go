@@ -0,0 +1,142 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+)
+
+// Taxonomy endpoints of GetSchemaSkills, GetSchemaDomains and
+// GetSchemaModules, the keys of Schema.Taxonomies.
+const (
+	SkillCategories  = "skill_categories"
+	DomainCategories = "domain_categories"
+	ModuleCategories = "module_categories"
+)
+
+// Schema is a scripted stand-in for *schema.Schema serving fixed content. The
+// zero value serves no schemas. GetRecordJSONSchema and the taxonomy
+// shorthands are recorded as the GetJSONSchema and GetSchemaTaxonomy calls
+// they make.
+type Schema struct {
+	recorder
+
+	// DefaultVersion is used when no version is selected; it defaults to
+	// record.DefaultSchemaVersion.
+	DefaultVersion string
+	// Versions are the supported versions; other versions fail with
+	// schema.ErrUnsupportedVersion. Nil supports every version.
+	Versions []string
+	// JSONSchemas are the JSON schemas by name, e.g. "record", for every
+	// version and entity type.
+	JSONSchemas map[string][]byte
+	// Taxonomies are the taxonomies by endpoint, e.g. SkillCategories, for
+	// every version.
+	Taxonomies map[string]schema.Taxonomy
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func (s *Schema) GetDefaultSchemaVersion(context.Context) (string, error) {
+	s.record("GetDefaultSchemaVersion", nil)
+
+	if s.Err != nil {
+		return "", s.Err
+	}
+
+	return s.defaultVersion(), nil
+}
+
+func (s *Schema) GetAvailableSchemaVersions(context.Context) ([]string, error) {
+	s.record("GetAvailableSchemaVersions", nil)
+
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	if s.Versions == nil {
+		return []string{s.defaultVersion()}, nil
+	}
+
+	return slices.Clone(s.Versions), nil
+}
+
+func (s *Schema) ClearCache() {
+	s.record("ClearCache", nil)
+}
+
+func (s *Schema) GetJSONSchema(_ context.Context, schemaType schema.EntityType, name string, opts ...schema.SchemaOption) ([]byte, error) {
+	version := schema.SchemaVersionOf(opts...)
+	s.record("GetJSONSchema", nil, schemaType, name, version)
+
+	if err := s.check(version); err != nil {
+		return nil, err
+	}
+
+	data, ok := s.JSONSchemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: no JSON schema for %s %s", schema.ErrSchemaUnavailable, schemaType, name)
+	}
+
+	return slices.Clone(data), nil
+}
+
+func (s *Schema) GetSchemaTaxonomy(_ context.Context, endpoint string, opts ...schema.SchemaOption) (schema.Taxonomy, error) {
+	version := schema.SchemaVersionOf(opts...)
+	s.record("GetSchemaTaxonomy", nil, endpoint, version)
+
+	if err := s.check(version); err != nil {
+		return nil, err
+	}
+
+	taxonomy, ok := s.Taxonomies[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("%w: no taxonomy for %s", schema.ErrSchemaUnavailable, endpoint)
+	}
+
+	return maps.Clone(taxonomy), nil
+}
+
+func (s *Schema) GetRecordJSONSchema(ctx context.Context, opts ...schema.SchemaOption) ([]byte, error) {
+	return s.GetJSONSchema(ctx, schema.EntityTypeObjects, "record", opts...)
+}
+
+func (s *Schema) GetSchemaSkills(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error) {
+	return s.GetSchemaTaxonomy(ctx, SkillCategories, opts...)
+}
+
+func (s *Schema) GetSchemaDomains(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error) {
+	return s.GetSchemaTaxonomy(ctx, DomainCategories, opts...)
+}
+
+func (s *Schema) GetSchemaModules(ctx context.Context, opts ...schema.SchemaOption) (schema.Taxonomy, error) {
+	return s.GetSchemaTaxonomy(ctx, ModuleCategories, opts...)
+}
+
+func (s *Schema) defaultVersion() string {
+	if s.DefaultVersion == "" {
+		return record.DefaultSchemaVersion
+	}
+
+	return s.DefaultVersion
+}
+
+// check returns the error of a call selecting the version.
+func (s *Schema) check(version string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if version != "" && s.Versions != nil && !slices.Contains(s.Versions, version) {
+		return fmt.Errorf("%w: %s", schema.ErrUnsupportedVersion, version)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package fake
+
+import (
+	"context"
+
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Validator is a scripted stand-in for *validator.Validator. The zero value
+// reports every record valid.
+type Validator struct {
+	recorder
+
+	// ValidateFunc scripts the result of each record, for ValidateRecord and
+	// ValidateRecords alike; Err in the result is returned as the error of
+	// ValidateRecord.
+	ValidateFunc func(ctx context.Context, record *structpb.Struct) validator.RecordResult
+}
+
+func (v *Validator) ValidateRecord(ctx context.Context, record *structpb.Struct) (bool, []string, []string, error) {
+	v.record("ValidateRecord", record)
+
+	result := v.result(ctx, record)
+
+	return result.Valid, result.Errors, result.Warnings, result.Err
+}
+
+// ValidateRecords validates the records one at a time, in order, with the
+// results of ValidateFunc. The options are ignored. When ctx is canceled, the
+// remaining records fail with the context error, which is also returned.
+func (v *Validator) ValidateRecords(ctx context.Context, records []*structpb.Struct, _ ...validator.BatchOption) (*validator.BatchResult, error) {
+	v.record("ValidateRecords", nil, records)
+
+	batch := &validator.BatchResult{Results: make([]validator.RecordResult, len(records))}
+
+	for i, record := range records {
+		result := validator.RecordResult{Err: ctx.Err()}
+		if result.Err == nil {
+			result = v.result(ctx, record)
+		}
+
+		switch {
+		case result.Err != nil:
+			batch.Failed++
+		case result.Valid:
+			batch.Valid++
+		default:
+			batch.Invalid++
+		}
+
+		batch.Results[i] = result
+	}
+
+	return batch, ctx.Err() //nolint:wrapcheck
+}
+
+func (v *Validator) result(ctx context.Context, record *structpb.Struct) validator.RecordResult {
+	if v.ValidateFunc != nil {
+		return v.ValidateFunc(ctx, record)
+	}
+
+	return validator.RecordResult{Valid: true}
+}
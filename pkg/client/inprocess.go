@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// inProcess implements Interface with the SDK libraries.
+type inProcess struct{}
+
+// NewInProcess returns a client running the decoder, validator and
+// translators in-process, without a server. Its results have the shape of
+// Client's; its errors are the libraries' own, which errors.Is matches like
+// the errors of Client. Only validation makes network calls, to the schema
+// server.
+func NewInProcess() Interface {
+	return inProcess{}
+}
+
+func (inProcess) Decode(_ context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
+	return decoder.DecodeRecord(record) //nolint:wrapcheck
+}
+
+func (inProcess) Validate(ctx context.Context, record *structpb.Struct, opts ...ValidateOption) (*ValidationResult, error) {
+	req := &validationv1.ValidateRecordRequest{Record: record}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	v, err := validator.New(req.GetSchemaUrl())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	valid, errs, warnings, err := v.ValidateRecord(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate record: %w", err)
+	}
+
+	return &ValidationResult{Valid: valid, Errors: errs, Warnings: warnings}, nil
+}
+
+func (inProcess) Translate(_ context.Context, record *structpb.Struct, target Target) (*structpb.Value, error) {
+	var data map[string]any
+
+	switch target {
+	case TargetGHCopilot:
+		config, err := translator.RecordToGHCopilot(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		data = map[string]any{"mcpConfig": config}
+	case TargetA2A:
+		card, err := translator.RecordToA2A(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		data = map[string]any{"a2aCard": card.AsMap()}
+	case TargetSkillMarkdown:
+		markdown, err := translator.RecordToSkillMarkdown(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return structpb.NewStringValue(markdown), nil
+	default:
+		return nil, unsupportedTarget(target)
+	}
+
+	s, err := decoder.StructToProto(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert result to proto struct: %w", err)
+	}
+
+	return structpb.NewStructValue(s), nil
+}
+
+func (inProcess) Close() error { return nil }
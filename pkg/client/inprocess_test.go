@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+)
+
+func TestInProcessDecode(t *testing.T) {
+	c := client.NewInProcess()
+	defer c.Close()
+
+	resp, err := c.Decode(context.Background(), testRecord(t, map[string]any{"schema_version": "1.0.0", "name": "agent"}))
+	if err != nil || resp.GetV1().GetName() != "agent" {
+		t.Fatalf("Decode = %v, %v", resp, err)
+	}
+
+	_, err = c.Decode(context.Background(), testRecord(t, map[string]any{"name": "agent"}))
+	if !errors.Is(err, decoder.ErrMissingSchemaVersion) {
+		t.Fatalf("got %v, want decoder.ErrMissingSchemaVersion", err)
+	}
+}
+
+func TestInProcessValidateRequiresSchemaURL(t *testing.T) {
+	_, err := client.NewInProcess().Validate(context.Background(), testRecord(t, map[string]any{}))
+	if err == nil {
+		t.Fatal("expected an error without a schema URL")
+	}
+}
+
+func TestInProcessTranslate(t *testing.T) {
+	c := client.NewInProcess()
+
+	record := testRecord(t, map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{map[string]any{
+			"name": translator.A2AModuleName,
+			"data": map[string]any{"card_data": map[string]any{"name": "agent"}},
+		}},
+	})
+
+	card, err := c.Translate(context.Background(), record, client.TargetA2A)
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+
+	if name := card.GetStructValue().GetFields()["a2aCard"].GetStructValue().GetFields()["name"].GetStringValue(); name != "agent" {
+		t.Errorf("got card %v, want it under a2aCard like the server's", card)
+	}
+
+	if _, err := c.Translate(context.Background(), record, client.TargetGHCopilot); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("got %v, want translator.ErrModuleNotFound", err)
+	}
+
+	if _, err := c.Translate(context.Background(), record, "unknown"); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("got %v, want translator.ErrInvalidInput", err)
+	}
+}
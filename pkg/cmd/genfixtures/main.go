@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command genfixtures regenerates the expected outputs of the e2e fixtures
+// from the current decoder and translators. Run it from the pkg module:
+//
+//	go run ./cmd/genfixtures          # rewrite changed fixtures
+//	go run ./cmd/genfixtures -check   # fail if any fixture is stale
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/agntcy/oasf-sdk/pkg/testutil"
+)
+
+func main() {
+	dir := flag.String("dir", "../e2e/fixtures", "e2e fixtures directory")
+	check := flag.Bool("check", false, "report stale fixtures without rewriting them")
+	flag.Parse()
+
+	changed, err := testutil.Regenerate(*dir, !*check)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "genfixtures:", err)
+		os.Exit(2) //nolint:mnd
+	}
+
+	for _, name := range changed {
+		fmt.Println(name)
+	}
+
+	if *check && len(changed) > 0 {
+		fmt.Fprintln(os.Stderr, "genfixtures: fixtures are stale, run go run ./cmd/genfixtures")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command oasfwasm exposes the decoder, validator and translators to
+// JavaScript, so web pages such as the OASF record editor can decode,
+// validate and translate records client-side. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o oasf.wasm ./cmd/oasfwasm
+//
+// and load it with the wasm_exec.js of the Go distribution. It sets a global
+// oasf object:
+//
+//	oasf.decode(recordJSON)            // {result} or {error}
+//	oasf.translate(recordJSON, target) // {result} or {error}
+//	oasf.validate(recordJSON, schemaURL, profiles?) // Promise of {valid, errors, warnings}
+//
+// Decoding and translation run offline; validation calls the schema server
+// with the browser's fetch, so the server must allow the page's origin.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// targets are the JSON outputs of translate; skill-markdown returns the
+// markdown itself.
+var targets = map[string]func(*structpb.Struct) (any, error){
+	"gh-copilot": func(record *structpb.Struct) (any, error) { return translator.RecordToGHCopilot(record) },
+	"a2a":        func(record *structpb.Struct) (any, error) { return translator.RecordToA2A(record) },
+	"langchain":  func(record *structpb.Struct) (any, error) { return translator.RecordToLangChain(record) },
+}
+
+const skillMarkdownTarget = "skill-markdown"
+
+// validationResult is the value the validate promise resolves to.
+type validationResult struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+}
+
+// decode returns the record decoded into the typed model of its schema
+// version, as JSON.
+func decode(recordJSON string) (string, error) {
+	record, err := decoder.JsonToProto([]byte(recordJSON))
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	decoded, err := decoder.DecodeRecord(record)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(decoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal decoded record: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// translate returns the record translated to the target, as JSON or, for
+// skill-markdown, as markdown.
+func translate(recordJSON, target string) (string, error) {
+	record, err := decoder.JsonToProto([]byte(recordJSON))
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if target == skillMarkdownTarget {
+		return translator.RecordToSkillMarkdown(record) //nolint:wrapcheck
+	}
+
+	translate, ok := targets[target]
+	if !ok {
+		names := append(slices.Sorted(maps.Keys(targets)), skillMarkdownTarget)
+
+		return "", fmt.Errorf("%w: unsupported target %q, want one of %s", translator.ErrInvalidInput, target, strings.Join(names, ", "))
+	}
+
+	result, err := translate(record)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if s, ok := result.(*structpb.Struct); ok {
+		result = s.AsMap()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal translation: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// validate validates the record against the schema server at schemaURL.
+func validate(ctx context.Context, recordJSON, schemaURL string, profiles []string) (*validationResult, error) {
+	record, err := decoder.JsonToProto([]byte(recordJSON))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	v, err := validator.New(schemaURL, validator.WithProfiles(profiles...))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	valid, errs, warnings, err := v.ValidateRecord(ctx, record)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &validationResult{Valid: valid, Errors: errs, Warnings: warnings}, nil
+}
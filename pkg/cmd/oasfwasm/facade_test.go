@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+)
+
+const a2aRecord = `{
+	"schema_version": "1.0.0",
+	"name": "agent",
+	"modules": [{"name": "integration/a2a", "data": {"card_data": {"name": "agent"}}}]
+}`
+
+func TestDecode(t *testing.T) {
+	out, err := decode(a2aRecord)
+	if err != nil || !strings.Contains(out, `"name":"agent"`) {
+		t.Fatalf("decode = %s, %v", out, err)
+	}
+
+	if _, err := decode(`{"name": "agent"}`); !errors.Is(err, decoder.ErrMissingSchemaVersion) {
+		t.Errorf("got %v, want decoder.ErrMissingSchemaVersion", err)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	out, err := translate(a2aRecord, "a2a")
+	if err != nil || out != `{"name":"agent"}` {
+		t.Fatalf("translate = %s, %v", out, err)
+	}
+
+	if _, err := translate(a2aRecord, "gh-copilot"); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("got %v, want translator.ErrModuleNotFound", err)
+	}
+
+	_, err = translate(a2aRecord, "unknown")
+	if !errors.Is(err, translator.ErrInvalidInput) || !strings.Contains(err.Error(), "a2a, gh-copilot, langchain, skill-markdown") {
+		t.Errorf("got %v, want the supported targets listed", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("profiles") != "security" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+
+		_, _ = w.Write([]byte(`{"errors": [{"message": "bad name", "attribute_path": "name"}], "warnings": []}`))
+	}))
+	defer server.Close()
+
+	result, err := validate(context.Background(), a2aRecord, server.URL, []string{"security"})
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	if result.Valid || len(result.Errors) != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build js && wasm
+
+package main
+
+import (
+	"context"
+	"syscall/js"
+)
+
+func main() {
+	js.Global().Set("oasf", js.ValueOf(map[string]any{
+		"decode": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return outcome(decode(arg(args, 0)))
+		}),
+		"translate": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			return outcome(translate(arg(args, 0), arg(args, 1)))
+		}),
+		"validate": js.FuncOf(validateJS),
+	}))
+
+	// Keep the functions callable.
+	select {}
+}
+
+// validateJS returns a promise, as the HTTP request cannot block the
+// JavaScript event loop that serves it.
+func validateJS(_ js.Value, args []js.Value) any {
+	recordJSON, schemaURL := arg(args, 0), arg(args, 1)
+
+	var profiles []string
+
+	if len(args) > 2 && args[2].Type() == js.TypeObject {
+		for i := range args[2].Length() {
+			profiles = append(profiles, args[2].Index(i).String())
+		}
+	}
+
+	var executor js.Func
+
+	executor = js.FuncOf(func(_ js.Value, handlers []js.Value) any {
+		resolve, reject := handlers[0], handlers[1]
+
+		go func() {
+			defer executor.Release()
+
+			result, err := validate(context.Background(), recordJSON, schemaURL, profiles)
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(err.Error()))
+
+				return
+			}
+
+			resolve.Invoke(js.ValueOf(map[string]any{
+				"valid":    result.Valid,
+				"errors":   anys(result.Errors),
+				"warnings": anys(result.Warnings),
+			}))
+		}()
+
+		return nil
+	})
+
+	return js.Global().Get("Promise").New(executor)
+}
+
+// arg returns the i-th argument as a string, or "" when it is missing.
+func arg(args []js.Value, i int) string {
+	if i >= len(args) || args[i].Type() != js.TypeString {
+		return ""
+	}
+
+	return args[i].String()
+}
+
+// outcome returns {result} or, on failure, {error}.
+func outcome(result string, err error) any {
+	if err != nil {
+		return js.ValueOf(map[string]any{"error": err.Error()})
+	}
+
+	return js.ValueOf(map[string]any{"result": result})
+}
+
+// anys converts the strings for js.ValueOf, which takes []any.
+func anys(ss []string) []any {
+	values := make([]any, 0, len(ss))
+	for _, s := range ss {
+		values = append(values, s)
+	}
+
+	return values
+}
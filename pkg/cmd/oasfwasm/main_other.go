@@ -0,0 +1,16 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !(js && wasm)
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "oasfwasm: build with GOOS=js GOARCH=wasm and load it in a browser or Node.js")
+	os.Exit(2) //nolint:mnd
+}
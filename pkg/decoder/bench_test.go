@@ -0,0 +1,49 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func BenchmarkDecodeRecord(b *testing.B) {
+	for _, version := range []string{"0.7.0", "0.8.0", "1.0.0"} {
+		b.Run(version, func(b *testing.B) {
+			data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", "valid_"+version+"_record.json"))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			record, err := JsonToProto(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+
+			for b.Loop() {
+				if _, err := DecodeRecord(record); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkJsonToProto(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", "valid_1.0.0_record.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := JsonToProto(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,16 +4,27 @@
 package decoder
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sync"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
-// JsonToProto converts a JSON object to a proto object.
+// JsonToProto converts a JSON object to a proto object. A JSON null yields a
+// nil struct. Numbers become float64 values, so integers beyond 2^53 lose
+// precision, and strings must be valid UTF-8.
 func JsonToProto(data []byte) (*structpb.Struct, error) {
-	var result *structpb.Struct
-	if err := json.Unmarshal(data, &result); err != nil {
+	// protojson parses the document once; json.Unmarshal would first scan it
+	// and then hand it to protojson anyway.
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil, nil //nolint:nilnil
+	}
+
+	result := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON to protobuf struct: %w", err)
 	}
 
@@ -30,15 +41,42 @@ func StructToProto(goObj any) (*structpb.Struct, error) {
 	return JsonToProto(jsonBytes)
 }
 
+// jsonBuffers pools the JSON encoding buffers of ProtoToStruct, which runs
+// once per decoded record.
+var jsonBuffers = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, initialJSONBufferSize)
+
+		return &b
+	},
+}
+
+const (
+	initialJSONBufferSize = 4 << 10
+	// Larger buffers are dropped rather than pooled, so one huge record does
+	// not pin its buffer.
+	maxPooledJSONBufferSize = 1 << 20
+)
+
 // ProtoToStruct converts a proto object to a Go struct.
 func ProtoToStruct[T any](obj *structpb.Struct) (*T, error) {
-	// Convert protobuf Struct to JSON
-	jsonBytes, err := json.Marshal(obj)
+	buf, _ := jsonBuffers.Get().(*[]byte)
+	defer func() {
+		if cap(*buf) <= maxPooledJSONBufferSize {
+			jsonBuffers.Put(buf)
+		}
+	}()
+
+	// Convert protobuf Struct to JSON. protojson directly, as json.Marshal
+	// would call it too and then copy and re-validate its output.
+	jsonBytes, err := protojson.MarshalOptions{}.MarshalAppend((*buf)[:0], obj)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal protobuf struct to JSON: %w", err)
 	}
 
-	// Unmarshal JSON to the target Go type
+	*buf = jsonBytes
+
+	// Unmarshal JSON to the target Go type; it does not retain jsonBytes.
 	var result T
 	if err := json.Unmarshal(jsonBytes, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON to Go struct: %w", err)
@@ -0,0 +1,176 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// referenceJsonToProto is the encoding/json conversion JsonToProto must
+// agree with: decode into Go values, then build the struct from them.
+func referenceJsonToProto(data []byte) (*structpb.Struct, error) {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return structpb.NewStruct(m) //nolint:wrapcheck
+}
+
+func assertSameAsReference(t *testing.T, data []byte) {
+	t.Helper()
+
+	got, err := decoder.JsonToProto(data)
+	if err != nil {
+		t.Fatalf("JsonToProto(%s): %v", data, err)
+	}
+
+	want, err := referenceJsonToProto(data)
+	if err != nil {
+		t.Fatalf("reference(%s): %v", data, err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("JsonToProto(%s) = %v, want %v", data, got, want)
+	}
+}
+
+func TestJsonToProto_EdgeCases(t *testing.T) {
+	cases := []string{
+		`{"max_safe": 9007199254740991, "beyond": 9007199254740993, "neg": -9007199254740993}`,
+		`{"big": 1.7976931348623157e308, "tiny": 5e-324, "zero": -0, "exp": 1E3}`,
+		`{"int64": 9223372036854775807, "uint64": 18446744073709551615}`,
+		`{"héllo": "wörld", "日本": "語", "emoji 🤖": "🚀", "escaped é": "😀"}`,
+		`{"": "empty key", "nested": {"list": [1, "two", true, null, {"k": []}]}}`,
+		`{"ctl": "tab\tnewline\n", "quote": "\"", "slash": "\/"}`,
+	}
+
+	for _, c := range cases {
+		assertSameAsReference(t, []byte(c))
+	}
+}
+
+func TestJsonToProto_Rejects(t *testing.T) {
+	for _, c := range []string{
+		"",
+		`[]`,
+		`"string"`,
+		`{"a": 1} trailing`,
+		`{"a": 1, "a": 2}`,
+		`{"n": 1e400}`,
+		"{\"bad\": \"\xff\"}",
+	} {
+		if _, err := decoder.JsonToProto([]byte(c)); err == nil {
+			t.Errorf("JsonToProto(%q): expected an error", c)
+		}
+	}
+}
+
+// jsonDoc is a random JSON object for property tests.
+type jsonDoc map[string]any
+
+func (jsonDoc) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(jsonDoc(randomObject(r, min(size, 3))))
+}
+
+func randomObject(r *rand.Rand, depth int) map[string]any {
+	m := map[string]any{}
+	for range r.Intn(5) {
+		m[randomString(r)] = randomValue(r, depth)
+	}
+
+	return m
+}
+
+func randomValue(r *rand.Rand, depth int) any {
+	switch n := r.Intn(8); {
+	case n == 0:
+		return nil
+	case n == 1:
+		return r.Intn(2) == 0
+	case n == 2:
+		// Integers on both sides of the float64 precision limit.
+		return float64(r.Int63n(1<<62) - 1<<61)
+	case n == 3:
+		return r.NormFloat64() * math.Pow(10, float64(r.Intn(40)-20))
+	case n == 4 && depth > 0:
+		list := make([]any, r.Intn(4))
+		for i := range list {
+			list[i] = randomValue(r, depth-1)
+		}
+
+		return list
+	case n == 5 && depth > 0:
+		return randomObject(r, depth-1)
+	default:
+		return randomString(r)
+	}
+}
+
+var stringRunes = []rune("aZ09 _-./\\\"\t\né日🤖 \u0000")
+
+func randomString(r *rand.Rand) string {
+	runes := make([]rune, r.Intn(8))
+	for i := range runes {
+		runes[i] = stringRunes[r.Intn(len(stringRunes))]
+	}
+
+	return string(runes)
+}
+
+func TestJsonToProto_MatchesEncodingJSON(t *testing.T) {
+	property := func(doc jsonDoc) bool {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+
+		got, err := decoder.JsonToProto(data)
+		if err != nil {
+			t.Logf("JsonToProto(%s): %v", data, err)
+
+			return false
+		}
+
+		want, err := referenceJsonToProto(data)
+		if err != nil {
+			t.Fatalf("reference(%s): %v", data, err)
+		}
+
+		if !proto.Equal(got, want) {
+			t.Logf("JsonToProto(%s) = %v, want %v", data, got, want)
+
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJsonToProto_IntegerPrecision(t *testing.T) {
+	for _, n := range []int64{1 << 53, 1<<53 + 1, math.MaxInt64, math.MinInt64} {
+		s, err := decoder.JsonToProto([]byte(`{"n": ` + strconv.FormatInt(n, 10) + `}`))
+		if err != nil {
+			t.Fatalf("JsonToProto(%d): %v", n, err)
+		}
+
+		if got := s.GetFields()["n"].GetNumberValue(); got != float64(n) {
+			t.Errorf("JsonToProto(%d) = %v, want the nearest float64 %v", n, got, float64(n))
+		}
+	}
+}
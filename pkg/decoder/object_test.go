@@ -152,3 +152,10 @@ func TestProtoToStruct_NestedFields(t *testing.T) {
 		t.Errorf("expected organization='ACME', got %q", result.Provider.Organization)
 	}
 }
+
+func TestJsonToProto_Null(t *testing.T) {
+	s, err := decoder.JsonToProto([]byte(" null\n"))
+	if err != nil || s != nil {
+		t.Errorf("expected nil struct and no error for null, got %v, %v", s, err)
+	}
+}
@@ -22,6 +22,19 @@ const schemaVersionField = "schema_version"
 
 const expectedVersionParts = 3 // Expected number of parts (major.minor.patch) in a version string
 
+// Errors returned while resolving a record's schema version. Callers can use
+// errors.Is to branch on them instead of matching error strings.
+var (
+	// ErrNilRecord is returned when no record is provided.
+	ErrNilRecord = errors.New("request is nil")
+	// ErrMissingSchemaVersion is returned when the record has no schema_version field.
+	ErrMissingSchemaVersion = fmt.Errorf("%s field is missing", schemaVersionField)
+	// ErrInvalidSchemaVersion is returned when schema_version is not a strict major.minor.patch version.
+	ErrInvalidSchemaVersion = errors.New("invalid version format")
+	// ErrUnsupportedSchemaVersion is returned when schema_version is well-formed but not supported.
+	ErrUnsupportedSchemaVersion = errors.New("unsupported OASF version")
+)
+
 // getProtoVersionForSchemaVersion determines which proto version to use based on the OASF schema version.
 //   - 0.7.x -> v1alpha1
 //   - 0.8.x -> v1alpha2
@@ -29,20 +42,20 @@ const expectedVersionParts = 3 // Expected number of parts (major.minor.patch) i
 func getProtoVersionForSchemaVersion(schemaVersion string) (string, error) {
 	// Reject versions with "v" prefix as OASF versions don't use it
 	if strings.HasPrefix(schemaVersion, "v") || strings.HasPrefix(schemaVersion, "V") {
-		return "", fmt.Errorf("invalid version format: %s (OASF versions must not have 'v' prefix, expected format: major.minor.patch)", schemaVersion)
+		return "", fmt.Errorf("%w: %s (OASF versions must not have 'v' prefix, expected format: major.minor.patch)", ErrInvalidSchemaVersion, schemaVersion)
 	}
 
 	// Validate that version has exactly 3 parts (major.minor.patch)
 	// semver.NewVersion() accepts formats like "1.0" and normalizes them, but we require strict x.x.x format
 	parts := strings.Split(schemaVersion, ".")
 	if len(parts) != expectedVersionParts {
-		return "", fmt.Errorf("invalid version format: %s (expected exactly %d parts: major.minor.patch)", schemaVersion, expectedVersionParts)
+		return "", fmt.Errorf("%w: %s (expected exactly %d parts: major.minor.patch)", ErrInvalidSchemaVersion, schemaVersion, expectedVersionParts)
 	}
 
 	// Parse version using semver library for validation and parsing
 	version, err := semver.NewVersion(schemaVersion)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse schema version %s: %w (expected semver format: major.minor.patch)", schemaVersion, err)
+		return "", fmt.Errorf("%w: failed to parse schema version %s: %w (expected semver format: major.minor.patch)", ErrInvalidSchemaVersion, schemaVersion, err)
 	}
 
 	major := version.Major()
@@ -62,14 +75,14 @@ func getProtoVersionForSchemaVersion(schemaVersion string) (string, error) {
 		return "v1", nil
 	}
 
-	return "", fmt.Errorf("unsupported OASF version: %s (major version %d not supported)", schemaVersion, major)
+	return "", fmt.Errorf("%w: %s (major version %d not supported)", ErrUnsupportedSchemaVersion, schemaVersion, major)
 }
 
 // DecodeRecord decodes a Record object into a structured format based on its schema version.
 func DecodeRecord(record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
 	// Validate input
 	if record == nil {
-		return nil, errors.New("request is nil")
+		return nil, ErrNilRecord
 	}
 
 	// Get schema version
@@ -130,13 +143,13 @@ func DecodeRecord(record *structpb.Struct) (*decodingv1.DecodeRecordResponse, er
 // GetRecordSchemaVersion extracts the schema version from the Record object.
 func GetRecordSchemaVersion(record *structpb.Struct) (string, error) {
 	if record == nil {
-		return "", errors.New("request is nil")
+		return "", ErrNilRecord
 	}
 
 	// Extract the schema_version field from the record
 	fieldSchemaVersion := record.GetFields()[schemaVersionField]
 	if fieldSchemaVersion == nil {
-		return "", fmt.Errorf("%s field is missing", schemaVersionField)
+		return "", ErrMissingSchemaVersion
 	}
 
 	return fieldSchemaVersion.GetStringValue(), nil
@@ -4,6 +4,7 @@
 package decoder
 
 import (
+	"errors"
 	"testing"
 
 	"google.golang.org/protobuf/types/known/structpb"
@@ -69,3 +70,35 @@ func TestGetRecordModuleMissing(t *testing.T) {
 		t.Fatalf("Expected nil module when not found")
 	}
 }
+
+func TestDecodeRecordSentinelErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		record  map[string]any
+		wantErr error
+	}{
+		{"missing schema_version", map[string]any{"name": "r"}, ErrMissingSchemaVersion},
+		{"v prefix", map[string]any{"schema_version": "v1.0.0"}, ErrInvalidSchemaVersion},
+		{"two parts", map[string]any{"schema_version": "1.0"}, ErrInvalidSchemaVersion},
+		{"not semver", map[string]any{"schema_version": "a.b.c"}, ErrInvalidSchemaVersion},
+		{"unsupported", map[string]any{"schema_version": "99.99.99"}, ErrUnsupportedSchemaVersion},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recordStruct, err := structpb.NewStruct(tc.record)
+			if err != nil {
+				t.Fatalf("Failed to build record struct: %v", err)
+			}
+
+			_, err = DecodeRecord(recordStruct)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("DecodeRecord error = %v, want errors.Is %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	if _, err := DecodeRecord(nil); !errors.Is(err, ErrNilRecord) {
+		t.Fatalf("DecodeRecord(nil) error = %v, want ErrNilRecord", err)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pb has shorthand constructors for structpb values, so that the
+// record and card trees built by the translators read like the JSON they
+// produce.
+package pb
+
+import "google.golang.org/protobuf/types/known/structpb"
+
+// Fields are the fields of a struct value.
+type Fields = map[string]*structpb.Value
+
+// Str returns a string value.
+func Str(s string) *structpb.Value {
+	return structpb.NewStringValue(s)
+}
+
+// Num returns a number value.
+func Num(n float64) *structpb.Value {
+	return structpb.NewNumberValue(n)
+}
+
+// Bool returns a bool value.
+func Bool(b bool) *structpb.Value {
+	return structpb.NewBoolValue(b)
+}
+
+// List returns a list value of the values.
+func List(values ...*structpb.Value) *structpb.Value {
+	return structpb.NewListValue(&structpb.ListValue{Values: values})
+}
+
+// Strs returns a list value of the strings.
+func Strs(ss []string) *structpb.Value {
+	values := make([]*structpb.Value, 0, len(ss))
+	for _, s := range ss {
+		values = append(values, Str(s))
+	}
+
+	return List(values...)
+}
+
+// Obj returns a struct value of the fields.
+func Obj(fields Fields) *structpb.Value {
+	return structpb.NewStructValue(Struct(fields))
+}
+
+// Struct returns a struct of the fields; nil fields give an empty struct.
+func Struct(fields Fields) *structpb.Struct {
+	if fields == nil {
+		fields = Fields{}
+	}
+
+	return &structpb.Struct{Fields: fields}
+}
@@ -0,0 +1,43 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pb_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestBuilders(t *testing.T) {
+	got := pb.Struct(pb.Fields{
+		"name":    pb.Str("weather"),
+		"port":    pb.Num(8080),
+		"enabled": pb.Bool(true),
+		"args":    pb.Strs([]string{"run", "--rm"}),
+		"empty":   pb.List(),
+		"meta":    pb.Obj(pb.Fields{"tier": pb.Str("gold")}),
+	})
+
+	want, err := structpb.NewStruct(map[string]any{
+		"name":    "weather",
+		"port":    8080,
+		"enabled": true,
+		"args":    []any{"run", "--rm"},
+		"empty":   []any{},
+		"meta":    map[string]any{"tier": "gold"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !proto.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if s := pb.Struct(nil); s.GetFields() == nil {
+		t.Error("Struct(nil) has nil fields")
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transport provides the HTTP transport of the schema server clients.
+// It is gated by platform: wasip1 has no sockets, so there the transport fails
+// every request instead of the packages using it failing to build or failing
+// deep in net.Dial. Under GOOS=js, net/http uses the browser's fetch.
+package transport
+
+import "errors"
+
+// ErrUnsupported is returned (wrapped) by requests on platforms without
+// networking.
+var ErrUnsupported = errors.New("HTTP is not supported on this platform")
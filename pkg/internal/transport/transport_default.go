@@ -0,0 +1,13 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !wasip1
+
+package transport
+
+import "net/http"
+
+// Default returns the transport of the schema server clients.
+func Default() http.RoundTripper {
+	return http.DefaultTransport
+}
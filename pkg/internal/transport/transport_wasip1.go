@@ -0,0 +1,23 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build wasip1
+
+package transport
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Default returns the transport of the schema server clients, which fails
+// every request: wasip1 has no sockets.
+func Default() http.RoundTripper {
+	return unsupported{}
+}
+
+type unsupported struct{}
+
+func (unsupported) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%w: %s %s", ErrUnsupported, req.Method, req.URL)
+}
@@ -0,0 +1,32 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package linter
+
+import "github.com/agntcy/oasf-sdk/pkg/messages"
+
+func init() {
+	for _, rule := range Rules() {
+		messages.Define(messages.Entry{
+			Code:        CodeOf(rule.ID),
+			Description: rule.Description,
+			Template:    rule.Template,
+		})
+	}
+}
+
+// CodeOf returns the message code of a rule, "lint/<rule ID>".
+func CodeOf(ruleID string) messages.Code {
+	return messages.Code("lint/" + ruleID)
+}
+
+// Code returns the message code of the finding.
+func (f Finding) Code() messages.Code {
+	return CodeOf(f.RuleID)
+}
+
+// ToMessage returns the finding as a coded message, which UIs can localize
+// with messages.Message.Localize.
+func (f Finding) ToMessage() messages.Message {
+	return messages.Message{Code: f.Code(), Text: f.Message, Path: f.Path, Args: f.Args}
+}
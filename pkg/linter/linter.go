@@ -0,0 +1,261 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package linter checks OASF records for quality issues that schema validation
+// does not catch, such as missing descriptions or duplicated skills.
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Severity is the level of a lint finding.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// Finding is a single issue reported by a rule.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	// Message is rendered by Lint from the Template of the rule and Args;
+	// rules without a template set it themselves.
+	Message string
+	// Path is the record field the finding refers to (e.g. "skills[1]").
+	Path string
+	// Args are the values of the placeholders of the template of the rule.
+	Args map[string]string
+}
+
+// Rule is a single lint check.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	// Template is the English template of the messages of the rule, with the
+	// {name} placeholders of the Args of its findings (see package messages).
+	Template string
+	// Check returns the findings of the rule, with their Path and Args.
+	Check func(record *structpb.Struct) []Finding
+}
+
+// semverPattern matches record versions such as "1.2.3" or "v1.2.3-rc.1".
+var semverPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+
+// Rules returns the default rule set.
+func Rules() []Rule {
+	return []Rule{
+		{
+			ID:          "description-missing",
+			Description: "Records should have a description.",
+			Severity:    SeverityWarning,
+			Template:    "record has no description",
+			Check:       requireString("description"),
+		},
+		{
+			ID:          "authors-missing",
+			Description: "Records should list at least one author.",
+			Severity:    SeverityWarning,
+			Template:    "record has no authors",
+			Check:       requireList("authors"),
+		},
+		{
+			ID:          "skills-missing",
+			Description: "Records should declare at least one skill.",
+			Severity:    SeverityWarning,
+			Template:    "record has no skills",
+			Check:       requireList("skills"),
+		},
+		{
+			ID:          "locators-missing",
+			Description: "Records should declare at least one locator.",
+			Severity:    SeverityNote,
+			Template:    "record has no locators",
+			Check:       requireList("locators"),
+		},
+		{
+			ID:          "version-format",
+			Description: "Record versions should follow semantic versioning.",
+			Severity:    SeverityWarning,
+			Template:    "version \"{version}\" is not a semantic version",
+			Check:       checkVersionFormat,
+		},
+		{
+			ID:          "created-at-format",
+			Description: "created_at must be an RFC 3339 timestamp.",
+			Severity:    SeverityError,
+			Template:    "created_at \"{created_at}\" is not an RFC 3339 timestamp",
+			Check:       checkCreatedAt,
+		},
+		{
+			ID:          "duplicate-skill",
+			Description: "Skills must not be listed more than once.",
+			Severity:    SeverityError,
+			Template:    "skill \"{name}\" is listed more than once",
+			Check:       duplicateEntries("skills"),
+		},
+		{
+			ID:          "duplicate-domain",
+			Description: "Domains must not be listed more than once.",
+			Severity:    SeverityError,
+			Template:    "domain \"{name}\" is listed more than once",
+			Check:       duplicateEntries("domains"),
+		},
+		{
+			ID:          "duplicate-module",
+			Description: "Modules must not be listed more than once.",
+			Severity:    SeverityError,
+			Template:    "module \"{name}\" is listed more than once",
+			Check:       duplicateEntries("modules"),
+		},
+		{
+			ID:          "module-data-empty",
+			Description: "Modules should carry data.",
+			Severity:    SeverityWarning,
+			Template:    "module \"{module}\" has no data",
+			Check:       checkModuleData,
+		},
+	}
+}
+
+// Lint runs the given rules (the default rule set when none are given) against
+// the record and returns their findings. The severity of each finding is taken
+// from its rule, and its message is rendered from the template of the rule.
+func Lint(record *structpb.Struct, rules ...Rule) []Finding {
+	if len(rules) == 0 {
+		rules = Rules()
+	}
+
+	var findings []Finding
+
+	for _, rule := range rules {
+		for _, finding := range rule.Check(record) {
+			finding.RuleID = rule.ID
+			finding.Severity = rule.Severity
+
+			if rule.Template != "" {
+				finding.Message = messages.Render(rule.Template, finding.Args)
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+func requireString(field string) func(*structpb.Struct) []Finding {
+	return func(record *structpb.Struct) []Finding {
+		if record.GetFields()[field].GetStringValue() == "" {
+			return []Finding{{Path: field}}
+		}
+
+		return nil
+	}
+}
+
+func requireList(field string) func(*structpb.Struct) []Finding {
+	return func(record *structpb.Struct) []Finding {
+		if len(record.GetFields()[field].GetListValue().GetValues()) == 0 {
+			return []Finding{{Path: field}}
+		}
+
+		return nil
+	}
+}
+
+func checkVersionFormat(record *structpb.Struct) []Finding {
+	version := record.GetFields()["version"].GetStringValue()
+	if version == "" || semverPattern.MatchString(version) {
+		return nil
+	}
+
+	return []Finding{{
+		Path: "version",
+		Args: map[string]string{"version": version},
+	}}
+}
+
+func checkCreatedAt(record *structpb.Struct) []Finding {
+	createdAt := record.GetFields()["created_at"].GetStringValue()
+	if createdAt == "" {
+		return nil
+	}
+
+	if _, err := time.Parse(time.RFC3339, createdAt); err != nil {
+		return []Finding{{
+			Path: "created_at",
+			Args: map[string]string{"created_at": createdAt},
+		}}
+	}
+
+	return nil
+}
+
+// duplicateEntries reports list entries whose name (or id) repeats an earlier entry.
+func duplicateEntries(field string) func(*structpb.Struct) []Finding {
+	return func(record *structpb.Struct) []Finding {
+		var findings []Finding
+
+		seen := map[string]bool{}
+
+		for i, value := range record.GetFields()[field].GetListValue().GetValues() {
+			key := entryKey(value.GetStructValue())
+			if key == "" {
+				continue
+			}
+
+			if seen[key] {
+				findings = append(findings, Finding{
+					Path: fmt.Sprintf("%s[%d]", field, i),
+					Args: map[string]string{"name": key},
+				})
+			}
+
+			seen[key] = true
+		}
+
+		return findings
+	}
+}
+
+func checkModuleData(record *structpb.Struct) []Finding {
+	var findings []Finding
+
+	for i, value := range record.GetFields()["modules"].GetListValue().GetValues() {
+		module := value.GetStructValue()
+		if len(module.GetFields()["data"].GetStructValue().GetFields()) > 0 {
+			continue
+		}
+
+		name := module.GetFields()["name"].GetStringValue()
+		findings = append(findings, Finding{
+			Path: fmt.Sprintf("modules[%d].data", i),
+			Args: map[string]string{"module": name},
+		})
+	}
+
+	return findings
+}
+
+func entryKey(entry *structpb.Struct) string {
+	fields := entry.GetFields()
+	if name := fields["name"].GetStringValue(); name != "" {
+		return name
+	}
+
+	if id, ok := fields["id"]; ok {
+		return fmt.Sprint(id.AsInterface())
+	}
+
+	return ""
+}
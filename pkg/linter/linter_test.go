@@ -0,0 +1,149 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package linter_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(t *testing.T, m map[string]any) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	return s
+}
+
+func TestLintCleanRecord(t *testing.T) {
+	record := mustStruct(t, map[string]any{
+		"name":        "example.org/agent",
+		"version":     "v1.0.0",
+		"description": "An agent",
+		"authors":     []any{"Jane"},
+		"created_at":  "2025-01-01T00:00:00Z",
+		"skills":      []any{map[string]any{"name": "a/b", "id": 1}},
+		"locators":    []any{map[string]any{"type": "source_code", "urls": []any{"https://example.org"}}},
+		"modules":     []any{map[string]any{"name": "integration/mcp", "data": map[string]any{"name": "srv"}}},
+	})
+
+	if findings := linter.Lint(record); len(findings) != 0 {
+		t.Errorf("expected no findings, got %+v", findings)
+	}
+}
+
+func TestLintFindings(t *testing.T) {
+	record := mustStruct(t, map[string]any{
+		"name":       "example.org/agent",
+		"version":    "latest",
+		"created_at": "yesterday",
+		"skills": []any{
+			map[string]any{"name": "a/b", "id": 1},
+			map[string]any{"id": 2},
+			map[string]any{"name": "a/b", "id": 1},
+		},
+		"modules": []any{map[string]any{"name": "integration/mcp"}},
+	})
+
+	got := map[string]linter.Finding{}
+	for _, f := range linter.Lint(record) {
+		got[f.RuleID] = f
+	}
+
+	want := map[string]linter.Severity{
+		"description-missing": linter.SeverityWarning,
+		"authors-missing":     linter.SeverityWarning,
+		"locators-missing":    linter.SeverityNote,
+		"version-format":      linter.SeverityWarning,
+		"created-at-format":   linter.SeverityError,
+		"duplicate-skill":     linter.SeverityError,
+		"module-data-empty":   linter.SeverityWarning,
+	}
+
+	for id, severity := range want {
+		f, ok := got[id]
+		if !ok {
+			t.Errorf("missing finding for rule %s", id)
+
+			continue
+		}
+
+		if f.Severity != severity {
+			t.Errorf("rule %s severity = %s, want %s", id, f.Severity, severity)
+		}
+	}
+
+	if got["duplicate-skill"].Path != "skills[2]" {
+		t.Errorf("duplicate-skill path = %q, want skills[2]", got["duplicate-skill"].Path)
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("unexpected findings: %+v", got)
+	}
+}
+
+func TestLintCustomRules(t *testing.T) {
+	rule := linter.Rule{
+		ID:       "always",
+		Severity: linter.SeverityNote,
+		Check: func(*structpb.Struct) []linter.Finding {
+			return []linter.Finding{{Message: "hello"}}
+		},
+	}
+
+	findings := linter.Lint(mustStruct(t, map[string]any{}), rule)
+	if len(findings) != 1 || findings[0].RuleID != "always" || findings[0].Severity != linter.SeverityNote {
+		t.Errorf("unexpected findings: %+v", findings)
+	}
+}
+
+func TestLintRendersTemplate(t *testing.T) {
+	rule := linter.Rule{
+		ID:       "greeting",
+		Severity: linter.SeverityNote,
+		Template: "hello {name}",
+		Check: func(*structpb.Struct) []linter.Finding {
+			return []linter.Finding{{Args: map[string]string{"name": "world"}}}
+		},
+	}
+
+	findings := linter.Lint(mustStruct(t, map[string]any{}), rule)
+	if len(findings) != 1 || findings[0].Message != "hello world" {
+		t.Errorf("unexpected findings: %+v", findings)
+	}
+}
+
+func TestFindingMessages(t *testing.T) {
+	record := mustStruct(t, map[string]any{
+		"version":    "latest",
+		"created_at": "yesterday",
+		"skills":     []any{map[string]any{"name": "a/b"}, map[string]any{"name": "a/b"}},
+		"modules":    []any{map[string]any{"name": "integration/mcp"}},
+	})
+
+	for _, f := range linter.Lint(record) {
+		msg := f.ToMessage()
+		if msg.Code != messages.Code("lint/"+f.RuleID) || msg.Path != f.Path {
+			t.Errorf("unexpected message of %s: %+v", f.RuleID, msg)
+		}
+
+		entry, ok := messages.Lookup(msg.Code)
+		if !ok {
+			t.Errorf("code %s is not defined", msg.Code)
+
+			continue
+		}
+
+		// The English template must render the message of the finding.
+		if got := messages.Render(entry.Template, msg.Args); got != f.Message {
+			t.Errorf("template of %s renders %q, want %q", f.RuleID, got, f.Message)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mcpclient connects to the MCP servers described in records and
+// discovers the tools and resources they actually expose (tools/list,
+// resources/list), so records can be enriched with their live capability
+// surface. Servers are reached over stdio, by running the declared command,
+// or over the streamable HTTP transport.
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProtocolVersion is the MCP protocol version the client requests.
+const ProtocolVersion = "2025-06-18"
+
+// Connection types, as in the connections of 1.0.0 MCP modules.
+const (
+	TypeStdio          = "stdio"
+	TypeStreamableHTTP = "streamable-http"
+	TypeSSE            = "sse"
+)
+
+const (
+	defaultTimeout = 30 * time.Second
+	clientName     = "oasf-sdk"
+)
+
+// ErrUnsupportedTransport is returned for connections the client cannot use,
+// such as the deprecated HTTP+SSE transport.
+var ErrUnsupportedTransport = errors.New("unsupported MCP transport")
+
+// Connection describes how to reach an MCP server.
+type Connection struct {
+	Type string
+	// Command, Args and Env start a stdio server. Env is added to the
+	// environment of the current process, without overriding variables that
+	// are already set.
+	Command string
+	Args    []string
+	Env     map[string]string
+	// URL and Headers address an HTTP server.
+	URL     string
+	Headers map[string]string
+}
+
+// ServerInfo identifies the server implementation.
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// Tool is a tool exposed by the server.
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"inputSchema,omitempty"`
+}
+
+// Resource is a resource exposed by the server.
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// Surface is the capability surface discovered on a server.
+type Surface struct {
+	Server    ServerInfo `json:"server"`
+	Tools     []Tool     `json:"tools,omitempty"`
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// RPCError is a JSON-RPC error returned by the server.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("MCP error %d: %s", e.Code, e.Message)
+}
+
+// Option configures Introspect and Enrich.
+type Option func(*options)
+
+type options struct {
+	httpClient   *http.Client
+	timeout      time.Duration
+	skillMatcher SkillMatcher
+}
+
+// WithHTTPClient sets the HTTP client used for HTTP servers.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithTimeout bounds the whole introspection of a server, including starting
+// a stdio server. It defaults to 30 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{httpClient: &http.Client{}, timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// transport exchanges JSON-RPC messages with a server.
+type transport interface {
+	// call sends a request and returns the result of the matching response.
+	call(ctx context.Context, id int64, method string, params any) (json.RawMessage, error)
+	notify(ctx context.Context, method string, params any) error
+	close() error
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// matches reports whether the message is the response to request id.
+func (r *rpcResponse) matches(id int64) bool {
+	return r.Method == "" && string(r.ID) == fmt.Sprint(id)
+}
+
+func encodeRequest(id int64, method string, params any) ([]byte, error) {
+	req := rpcRequest{JSONRPC: "2.0", Method: method, Params: params}
+	if id != 0 {
+		req.ID = &id
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	return data, nil
+}
+
+// Introspect connects to the server, initializes an MCP session and lists
+// its tools and resources.
+func Introspect(ctx context.Context, conn Connection, opts ...Option) (*Surface, error) {
+	o := newOptions(opts)
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	var (
+		t   transport
+		err error
+	)
+
+	switch conn.Type {
+	case TypeStdio, "":
+		t, err = startStdio(ctx, conn)
+	case TypeStreamableHTTP, "http":
+		t = newHTTPTransport(conn, o.httpClient)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedTransport, conn.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer t.close()
+
+	return introspect(ctx, t)
+}
+
+func introspect(ctx context.Context, t transport) (*Surface, error) {
+	var id int64
+
+	call := func(method string, params, result any) error {
+		id++
+
+		raw, err := t.call(ctx, id, method, params)
+		if err != nil {
+			return fmt.Errorf("%s: %w", method, err)
+		}
+
+		if err := json.Unmarshal(raw, result); err != nil {
+			return fmt.Errorf("%s: invalid result: %w", method, err)
+		}
+
+		return nil
+	}
+
+	var initResult struct {
+		Capabilities struct {
+			Tools     json.RawMessage `json:"tools"`
+			Resources json.RawMessage `json:"resources"`
+		} `json:"capabilities"`
+		ServerInfo ServerInfo `json:"serverInfo"`
+	}
+
+	if err := call("initialize", map[string]any{
+		"protocolVersion": ProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": clientName},
+	}, &initResult); err != nil {
+		return nil, err
+	}
+
+	if err := t.notify(ctx, "notifications/initialized", nil); err != nil {
+		return nil, fmt.Errorf("notifications/initialized: %w", err)
+	}
+
+	surface := &Surface{Server: initResult.ServerInfo}
+
+	if initResult.Capabilities.Tools != nil {
+		if err := listAll(call, "tools/list", "tools", &surface.Tools); err != nil {
+			return nil, err
+		}
+	}
+
+	if initResult.Capabilities.Resources != nil {
+		if err := listAll(call, "resources/list", "resources", &surface.Resources); err != nil {
+			return nil, err
+		}
+	}
+
+	return surface, nil
+}
+
+// listAll calls a paginated list method until the server returns no cursor.
+func listAll[T any](call func(method string, params, result any) error, method, field string, out *[]T) error {
+	cursor := ""
+
+	for {
+		var params any
+		if cursor != "" {
+			params = map[string]any{"cursor": cursor}
+		}
+
+		var page map[string]json.RawMessage
+		if err := call(method, params, &page); err != nil {
+			return err
+		}
+
+		var items []T
+		if raw, ok := page[field]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return fmt.Errorf("%s: invalid %s: %w", method, field, err)
+			}
+		}
+
+		*out = append(*out, items...)
+
+		cursor = ""
+		if raw, ok := page["nextCursor"]; ok {
+			_ = json.Unmarshal(raw, &cursor)
+		}
+
+		if cursor == "" {
+			return nil
+		}
+	}
+}
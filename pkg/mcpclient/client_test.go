@@ -0,0 +1,222 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpclient_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/mcpclient"
+)
+
+// fakeServerEnv makes the test binary act as a stdio MCP server.
+const fakeServerEnv = "MCPCLIENT_FAKE_SERVER"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakeServerEnv) != "" {
+		serveStdio(os.Stdin, os.Stdout)
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params struct {
+		Cursor string `json:"cursor"`
+	} `json:"params"`
+}
+
+// handle answers a request of the fake server; it returns nil for
+// notifications.
+func handle(msg message) any {
+	if msg.ID == nil {
+		return nil
+	}
+
+	var result any
+
+	switch msg.Method {
+	case "initialize":
+		result = map[string]any{
+			"protocolVersion": mcpclient.ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}, "resources": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "weather", "version": "1.2.0"},
+		}
+	case "tools/list":
+		// Two pages, to exercise pagination.
+		if msg.Params.Cursor == "" {
+			result = map[string]any{
+				"tools":      []any{map[string]any{"name": "forecast", "description": "Weather forecast", "inputSchema": map[string]any{"type": "object"}}},
+				"nextCursor": "page2",
+			}
+		} else {
+			result = map[string]any{"tools": []any{map[string]any{"name": "alerts", "description": "Weather alerts"}}}
+		}
+	case "resources/list":
+		result = map[string]any{"resources": []any{map[string]any{"uri": "weather://stations", "name": "stations", "mimeType": "application/json"}}}
+	default:
+		return map[string]any{"jsonrpc": "2.0", "id": msg.ID, "error": map[string]any{"code": -32601, "message": "method not found"}}
+	}
+
+	return map[string]any{"jsonrpc": "2.0", "id": msg.ID, "result": result}
+}
+
+func serveStdio(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+
+	// Servers may log to stdout; the client must skip such lines.
+	fmt.Fprintln(out, "starting weather server")
+
+	for scanner.Scan() {
+		var msg message
+		if json.Unmarshal(scanner.Bytes(), &msg) != nil {
+			continue
+		}
+
+		if msg.Method == "initialize" {
+			fmt.Fprintln(out, `{"jsonrpc":"2.0","method":"notifications/message","params":{"level":"info"}}`)
+		}
+
+		if resp := handle(msg); resp != nil {
+			data, _ := json.Marshal(resp)
+			fmt.Fprintln(out, string(data))
+		}
+	}
+}
+
+var wantSurface = &mcpclient.Surface{
+	Server: mcpclient.ServerInfo{Name: "weather", Version: "1.2.0"},
+	Tools: []mcpclient.Tool{
+		{Name: "forecast", Description: "Weather forecast", InputSchema: map[string]any{"type": "object"}},
+		{Name: "alerts", Description: "Weather alerts"},
+	},
+	Resources: []mcpclient.Resource{{URI: "weather://stations", Name: "stations", MimeType: "application/json"}},
+}
+
+func stdioConnection(t *testing.T) mcpclient.Connection {
+	t.Helper()
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mcpclient.Connection{Type: mcpclient.TypeStdio, Command: exe, Env: map[string]string{fakeServerEnv: "1"}}
+}
+
+func TestIntrospectStdio(t *testing.T) {
+	surface, err := mcpclient.Introspect(context.Background(), stdioConnection(t))
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+
+	if !reflect.DeepEqual(surface, wantSurface) {
+		t.Errorf("surface = %+v, want %+v", surface, wantSurface)
+	}
+}
+
+// newFakeHTTPServer serves the fake MCP server over streamable HTTP,
+// answering with an event stream when stream is set.
+func newFakeHTTPServer(t *testing.T, stream bool) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		var msg message
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if msg.Method == "initialize" {
+			w.Header().Set("Mcp-Session-Id", "session-1")
+		} else if r.Header.Get("Mcp-Session-Id") != "session-1" || r.Header.Get("Mcp-Protocol-Version") != mcpclient.ProtocolVersion {
+			http.Error(w, "missing session", http.StatusBadRequest)
+
+			return
+		}
+
+		resp := handle(msg)
+		if resp == nil {
+			w.WriteHeader(http.StatusAccepted)
+
+			return
+		}
+
+		data, _ := json.Marshal(resp)
+
+		if !stream {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(data)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestIntrospectHTTP(t *testing.T) {
+	for _, stream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("stream=%v", stream), func(t *testing.T) {
+			srv := newFakeHTTPServer(t, stream)
+
+			surface, err := mcpclient.Introspect(context.Background(), mcpclient.Connection{
+				Type:    mcpclient.TypeStreamableHTTP,
+				URL:     srv.URL,
+				Headers: map[string]string{"Authorization": "Bearer secret"},
+			})
+			if err != nil {
+				t.Fatalf("Introspect: %v", err)
+			}
+
+			if !reflect.DeepEqual(surface, wantSurface) {
+				t.Errorf("surface = %+v, want %+v", surface, wantSurface)
+			}
+		})
+	}
+}
+
+func TestIntrospectErrors(t *testing.T) {
+	srv := newFakeHTTPServer(t, false)
+
+	if _, err := mcpclient.Introspect(context.Background(), mcpclient.Connection{Type: mcpclient.TypeStreamableHTTP, URL: srv.URL}); err == nil {
+		t.Error("expected an error for an unauthorized request")
+	}
+
+	if _, err := mcpclient.Introspect(context.Background(), mcpclient.Connection{Type: mcpclient.TypeSSE, URL: srv.URL}); !errors.Is(err, mcpclient.ErrUnsupportedTransport) {
+		t.Errorf("expected ErrUnsupportedTransport, got %v", err)
+	}
+
+	if _, err := mcpclient.Introspect(context.Background(), mcpclient.Connection{Type: mcpclient.TypeStdio, Command: "/nonexistent/mcp-server"}); err == nil {
+		t.Error("expected an error for a missing command")
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/taxonomy"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// moduleName is the MCP module, matched in either namespace.
+const moduleName = "integration/mcp"
+
+// ErrNoMCPModule is returned by Enrich for records without an MCP module.
+var ErrNoMCPModule = errors.New("record has no MCP module")
+
+// SkillMatcher maps the text of a capability surface (tool and resource names
+// and descriptions) to OASF skills, e.g. with the extractor package.
+type SkillMatcher func(ctx context.Context, text string) ([]taxonomy.SkillID, error)
+
+// WithSkillMatcher makes Enrich add the skills matched for the discovered
+// surface to the record.
+func WithSkillMatcher(matcher SkillMatcher) Option {
+	return func(o *options) {
+		o.skillMatcher = matcher
+	}
+}
+
+// Enrich introspects the server described by the record's MCP module, trying
+// its connections in order, and replaces the module's "tools" and
+// "resources" with the discovered ones. With WithSkillMatcher, the matched
+// skills the record does not list yet are added. The record is modified in
+// place.
+//
+// Stdio connections run the declared command, so only enrich records from
+// trusted sources.
+func Enrich(ctx context.Context, record *structpb.Struct, opts ...Option) (*Surface, error) {
+	found, module := recordutil.FindModule(record, moduleName)
+	if !found {
+		return nil, ErrNoMCPModule
+	}
+
+	data := module.GetFields()["data"].GetStructValue()
+	if data == nil {
+		return nil, errors.New("MCP module has no data")
+	}
+
+	connections := ModuleConnections(data)
+	if len(connections) == 0 {
+		return nil, errors.New("MCP module has no connections")
+	}
+
+	var (
+		surface *Surface
+		errs    []error
+	)
+
+	for _, conn := range connections {
+		s, err := Introspect(ctx, conn, opts...)
+		if err == nil {
+			surface = s
+
+			break
+		}
+
+		errs = append(errs, fmt.Errorf("%s connection: %w", conn.Type, err))
+	}
+
+	if surface == nil {
+		return nil, fmt.Errorf("no MCP connection could be introspected: %w", errors.Join(errs...))
+	}
+
+	data.Fields["tools"] = structpb.NewListValue(toolValues(surface.Tools))
+	data.Fields["resources"] = structpb.NewListValue(resourceValues(surface.Resources))
+
+	o := newOptions(opts)
+	if o.skillMatcher != nil {
+		skills, err := o.skillMatcher(ctx, surface.text())
+		if err != nil {
+			return nil, fmt.Errorf("failed to match skills: %w", err)
+		}
+
+		addSkills(record, skills)
+	}
+
+	return surface, nil
+}
+
+// ModuleConnections returns the connections of MCP module data: the
+// "connections" of 1.0.0 modules, or the stdio "servers" of 0.7.0/0.8.0 ones.
+// Environment variables get their default value, if any.
+func ModuleConnections(data *structpb.Struct) []Connection {
+	var connections []Connection
+
+	for _, v := range data.GetFields()["connections"].GetListValue().GetValues() {
+		fields := v.GetStructValue().GetFields()
+
+		conn := Connection{
+			Type:    fields["type"].GetStringValue(),
+			Command: fields["command"].GetStringValue(),
+			Args:    stringValues(fields["args"].GetListValue()),
+			URL:     fields["url"].GetStringValue(),
+			Env:     map[string]string{},
+			Headers: map[string]string{},
+		}
+
+		for _, envVar := range fields["env_vars"].GetListValue().GetValues() {
+			envFields := envVar.GetStructValue().GetFields()
+			if name := envFields["name"].GetStringValue(); name != "" {
+				conn.Env[name] = envFields["default_value"].GetStringValue()
+			}
+		}
+
+		for name, value := range fields["headers"].GetStructValue().GetFields() {
+			conn.Headers[name] = value.GetStringValue()
+		}
+
+		connections = append(connections, conn)
+	}
+
+	for _, v := range data.GetFields()["servers"].GetListValue().GetValues() {
+		fields := v.GetStructValue().GetFields()
+
+		conn := Connection{
+			Type:    TypeStdio,
+			Command: fields["command"].GetStringValue(),
+			Args:    stringValues(fields["args"].GetListValue()),
+			Env:     map[string]string{},
+		}
+
+		for name, value := range fields["env"].GetStructValue().GetFields() {
+			// "${input:NAME}" references are resolved from the environment.
+			if !strings.HasPrefix(value.GetStringValue(), "${") {
+				conn.Env[name] = value.GetStringValue()
+			}
+		}
+
+		connections = append(connections, conn)
+	}
+
+	return connections
+}
+
+// text is the surface as text for skill matching.
+func (s *Surface) text() string {
+	var b strings.Builder
+
+	for _, tool := range s.Tools {
+		b.WriteString(tool.Name + ": " + tool.Description + "\n")
+	}
+
+	for _, resource := range s.Resources {
+		b.WriteString(resource.Name + ": " + resource.Description + "\n")
+	}
+
+	return b.String()
+}
+
+func toolValues(tools []Tool) *structpb.ListValue {
+	list := &structpb.ListValue{}
+
+	for _, tool := range tools {
+		fields := map[string]*structpb.Value{"name": structpb.NewStringValue(tool.Name)}
+
+		if tool.Description != "" {
+			fields["description"] = structpb.NewStringValue(tool.Description)
+		}
+
+		if schema, err := structpb.NewStruct(tool.InputSchema); err == nil && len(tool.InputSchema) > 0 {
+			fields["input_schema"] = structpb.NewStructValue(schema)
+		}
+
+		list.Values = append(list.Values, structpb.NewStructValue(&structpb.Struct{Fields: fields}))
+	}
+
+	return list
+}
+
+func resourceValues(resources []Resource) *structpb.ListValue {
+	list := &structpb.ListValue{}
+
+	for _, resource := range resources {
+		fields := map[string]*structpb.Value{
+			"name": structpb.NewStringValue(resource.Name),
+			"uri":  structpb.NewStringValue(resource.URI),
+		}
+
+		if resource.Description != "" {
+			fields["description"] = structpb.NewStringValue(resource.Description)
+		}
+
+		if resource.MimeType != "" {
+			fields["mime_type"] = structpb.NewStringValue(resource.MimeType)
+		}
+
+		list.Values = append(list.Values, structpb.NewStructValue(&structpb.Struct{Fields: fields}))
+	}
+
+	return list
+}
+
+// addSkills appends the skills the record does not list yet.
+func addSkills(record *structpb.Struct, skills []taxonomy.SkillID) {
+	list := record.GetFields()["skills"].GetListValue()
+	if list == nil {
+		list = &structpb.ListValue{}
+	}
+
+	present := map[uint32]bool{}
+	for _, v := range list.GetValues() {
+		present[uint32(v.GetStructValue().GetFields()["id"].GetNumberValue())] = true
+	}
+
+	for _, id := range skills {
+		if present[uint32(id)] {
+			continue
+		}
+
+		present[uint32(id)] = true
+		list.Values = append(list.Values, structpb.NewStructValue(&structpb.Struct{
+			Fields: map[string]*structpb.Value{"id": structpb.NewNumberValue(float64(id))},
+		}))
+	}
+
+	record.Fields["skills"] = structpb.NewListValue(list)
+}
+
+func stringValues(list *structpb.ListValue) []string {
+	var out []string
+
+	for _, v := range list.GetValues() {
+		out = append(out, v.GetStringValue())
+	}
+
+	return out
+}
@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpclient_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/mcpclient"
+	"github.com/agntcy/oasf-sdk/pkg/taxonomy"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEnrich(t *testing.T) {
+	conn := stdioConnection(t)
+
+	rec, err := structpb.NewStruct(map[string]any{
+		"name":   "example.org/weather",
+		"skills": []any{map[string]any{"id": 10201}},
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{
+				"name": "weather",
+				"connections": []any{
+					// Unsupported by the client: skipped for the next one.
+					map[string]any{"type": "sse", "url": "http://127.0.0.1:1/sse"},
+					map[string]any{
+						"type":     "stdio",
+						"command":  conn.Command,
+						"env_vars": []any{map[string]any{"name": "MCPCLIENT_FAKE_SERVER", "default_value": "1"}},
+					},
+				},
+				"tools": []any{map[string]any{"name": "declared-only"}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var matched string
+
+	matcher := func(_ context.Context, text string) ([]taxonomy.SkillID, error) {
+		matched = text
+
+		return []taxonomy.SkillID{10201, 103}, nil
+	}
+
+	if _, err := mcpclient.Enrich(context.Background(), rec, mcpclient.WithSkillMatcher(matcher)); err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+
+	data := rec.GetFields()["modules"].GetListValue().GetValues()[0].GetStructValue().GetFields()["data"].GetStructValue().AsMap()
+
+	tools := data["tools"].([]any)
+	if len(tools) != 2 || tools[0].(map[string]any)["name"] != "forecast" || tools[0].(map[string]any)["input_schema"] == nil {
+		t.Errorf("unexpected tools %v", tools)
+	}
+
+	if resources := data["resources"].([]any); len(resources) != 1 || resources[0].(map[string]any)["uri"] != "weather://stations" {
+		t.Errorf("unexpected resources %v", resources)
+	}
+
+	if !strings.Contains(matched, "forecast: Weather forecast") {
+		t.Errorf("skill matcher got %q", matched)
+	}
+
+	if skills := rec.GetFields()["skills"].GetListValue().GetValues(); len(skills) != 2 {
+		t.Errorf("got %d skills, want the existing one plus one new", len(skills))
+	}
+}
+
+func TestEnrichErrors(t *testing.T) {
+	rec, _ := structpb.NewStruct(map[string]any{"name": "no-modules"})
+
+	if _, err := mcpclient.Enrich(context.Background(), rec); !errors.Is(err, mcpclient.ErrNoMCPModule) {
+		t.Errorf("expected ErrNoMCPModule, got %v", err)
+	}
+
+	rec, _ = structpb.NewStruct(map[string]any{
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{"connections": []any{map[string]any{"type": "stdio", "command": "/nonexistent/mcp-server"}}},
+		}},
+	})
+
+	if _, err := mcpclient.Enrich(context.Background(), rec); err == nil || !strings.Contains(err.Error(), "stdio connection") {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
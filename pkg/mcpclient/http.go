@@ -0,0 +1,198 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpclient
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const sessionHeader = "Mcp-Session-Id"
+
+// httpTransport implements the streamable HTTP transport: every message is
+// POSTed to the server URL, which answers with JSON or an event stream.
+type httpTransport struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	session string
+	// initialized is set once the protocol version is negotiated, after
+	// which it is sent on every request.
+	initialized bool
+}
+
+func newHTTPTransport(conn Connection, client *http.Client) *httpTransport {
+	return &httpTransport{url: conn.URL, headers: conn.Headers, client: client}
+}
+
+func (t *httpTransport) post(ctx context.Context, body []byte) (*http.Response, error) {
+	if t.url == "" {
+		return nil, errors.New("HTTP connection has no URL")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	t.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", t.url, err)
+	}
+
+	if resp.StatusCode/100 != 2 { //nolint:mnd
+		defer resp.Body.Close()
+
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10)) //nolint:mnd
+
+		return nil, fmt.Errorf("%s returned %s: %s", t.url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if session := resp.Header.Get(sessionHeader); session != "" {
+		t.session = session
+	}
+
+	return resp, nil
+}
+
+func (t *httpTransport) setHeaders(req *http.Request) {
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	if t.session != "" {
+		req.Header.Set(sessionHeader, t.session)
+	}
+
+	if t.initialized {
+		req.Header.Set("Mcp-Protocol-Version", ProtocolVersion)
+	}
+}
+
+func (t *httpTransport) call(ctx context.Context, id int64, method string, params any) (json.RawMessage, error) {
+	body, err := encodeRequest(id, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.post(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	msg, err := readResponse(resp, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Error != nil {
+		return nil, msg.Error
+	}
+
+	if method == "initialize" {
+		t.initialized = true
+	}
+
+	return msg.Result, nil
+}
+
+func (t *httpTransport) notify(ctx context.Context, method string, params any) error {
+	body, err := encodeRequest(0, method, params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.post(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close() //nolint:wrapcheck
+}
+
+// close ends the session, if the server assigned one.
+func (t *httpTransport) close() error {
+	if t.session == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, t.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	t.setHeaders(req)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	return resp.Body.Close() //nolint:wrapcheck
+}
+
+// readResponse reads the response to request id from a JSON body or from an
+// event stream, skipping the other messages of the stream.
+func readResponse(resp *http.Response, id int64) (*rpcResponse, error) {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	if mediaType != "text/event-stream" {
+		var msg rpcResponse
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxMessageSize)).Decode(&msg); err != nil {
+			return nil, fmt.Errorf("invalid response: %w", err)
+		}
+
+		if !msg.matches(id) {
+			return nil, fmt.Errorf("unexpected response id %s", msg.ID)
+		}
+
+		return &msg, nil
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxMessageSize) //nolint:mnd
+
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if rest, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(rest, " "))
+
+			continue
+		}
+
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		// A blank line ends the event.
+		var msg rpcResponse
+		if json.Unmarshal([]byte(data.String()), &msg) == nil && msg.matches(id) {
+			return &msg, nil
+		}
+
+		data.Reset()
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read event stream: %w", err)
+	}
+
+	return nil, errors.New("event stream ended without a response")
+}
@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	// maxMessageSize bounds a single JSON-RPC message read from a server.
+	maxMessageSize = 16 << 20
+	// stopGrace is how long a stdio server may take to exit after its input
+	// is closed before it is killed.
+	stopGrace = 2 * time.Second
+)
+
+// stdioTransport talks to a server process over newline-delimited JSON-RPC
+// on its stdin and stdout.
+type stdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan []byte
+	// readErr is set before lines is closed.
+	readErr error
+}
+
+func startStdio(ctx context.Context, conn Connection) (*stdioTransport, error) {
+	if conn.Command == "" {
+		return nil, errors.New("stdio connection has no command")
+	}
+
+	cmd := exec.CommandContext(ctx, conn.Command, conn.Args...) //nolint:gosec
+	cmd.Env = os.Environ()
+
+	for name, value := range conn.Env {
+		if _, set := os.LookupEnv(name); !set && value != "" {
+			cmd.Env = append(cmd.Env, name+"="+value)
+		}
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdin pipe: %w", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", conn.Command, err)
+	}
+
+	t := &stdioTransport{cmd: cmd, stdin: stdin, lines: make(chan []byte)}
+
+	go t.read(stdout)
+
+	return t, nil
+}
+
+func (t *stdioTransport) read(stdout io.Reader) {
+	defer close(t.lines)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxMessageSize) //nolint:mnd
+
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		t.lines <- line
+	}
+
+	t.readErr = scanner.Err()
+	if t.readErr == nil {
+		t.readErr = errors.New("server closed its output")
+	}
+}
+
+func (t *stdioTransport) send(id int64, method string, params any) error {
+	data, err := encodeRequest(id, method, params)
+	if err != nil {
+		return err
+	}
+
+	if _, err := t.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to server: %w", err)
+	}
+
+	return nil
+}
+
+func (t *stdioTransport) call(ctx context.Context, id int64, method string, params any) (json.RawMessage, error) {
+	if err := t.send(id, method, params); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err() //nolint:wrapcheck
+		case line, ok := <-t.lines:
+			if !ok {
+				return nil, t.readErr
+			}
+
+			var msg rpcResponse
+			// Servers may log non-JSON lines; skip them along with
+			// notifications and requests from the server.
+			if json.Unmarshal(line, &msg) != nil || !msg.matches(id) {
+				continue
+			}
+
+			if msg.Error != nil {
+				return nil, msg.Error
+			}
+
+			return msg.Result, nil
+		}
+	}
+}
+
+func (t *stdioTransport) notify(_ context.Context, method string, params any) error {
+	return t.send(0, method, params)
+}
+
+// close closes the server input, which asks it to exit, and kills it if it
+// does not within stopGrace.
+func (t *stdioTransport) close() error {
+	_ = t.stdin.Close()
+
+	done := make(chan struct{})
+
+	go func() {
+		for range t.lines { //nolint:revive
+			// Drain the output so the server is not blocked writing.
+		}
+
+		_ = t.cmd.Wait()
+
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(stopGrace):
+		_ = t.cmd.Process.Kill()
+		<-done
+	}
+
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mcpregistry is a client for the official MCP registry API
+// (https://registry.modelcontextprotocol.io) and registries implementing it:
+// searching servers, fetching a server version and listing its versions.
+//
+// Requests are rate limited and retried with backoff on network errors, 429
+// and 5xx responses, honoring Retry-After.
+package mcpregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// DefaultURL is the base URL of the official MCP registry.
+	DefaultURL = "https://registry.modelcontextprotocol.io"
+	// LatestVersion selects the latest version of a server.
+	LatestVersion = "latest"
+
+	defaultTimeout  = 30 * time.Second
+	defaultRetries  = 3
+	defaultBackoff  = 500 * time.Millisecond
+	maxBackoff      = 30 * time.Second
+	maxResponseSize = 10 << 20
+)
+
+// ErrNotFound is returned when the registry has no such server or version.
+var ErrNotFound = errors.New("not found")
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	httpClient  *http.Client
+	retries     int
+	backoff     time.Duration
+	minInterval time.Duration
+	userAgent   string
+}
+
+// WithHTTPClient sets the HTTP client. It defaults to a client with a 30
+// second timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithRetries sets how many times failed requests are retried (3 by default)
+// and the initial backoff between attempts, doubled after every attempt.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(o *options) {
+		o.retries = retries
+		o.backoff = backoff
+	}
+}
+
+// WithRateLimit caps the request rate to requestsPerSecond. There is no
+// limit by default.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(o *options) {
+		if requestsPerSecond > 0 {
+			o.minInterval = time.Duration(float64(time.Second) / requestsPerSecond)
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// Client is an MCP registry client. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	options
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// New returns a client for the registry at baseURL (DefaultURL when empty).
+func New(baseURL string, opts ...Option) (*Client, error) {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid registry URL %q", baseURL)
+	}
+
+	c := &Client{
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		options: options{
+			httpClient: &http.Client{Timeout: defaultTimeout},
+			retries:    defaultRetries,
+			backoff:    defaultBackoff,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+
+	return c, nil
+}
+
+// Get fetches a server version; version is an exact version or LatestVersion.
+func (c *Client) Get(ctx context.Context, name, version string) (*ServerResponse, error) {
+	if version == "" {
+		version = LatestVersion
+	}
+
+	var entry ServerResponse
+	if err := c.get(ctx, "/v0/servers/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version), &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Versions lists all versions of a server.
+func (c *Client) Versions(ctx context.Context, name string) ([]ServerResponse, error) {
+	var list serverListResponse
+	if err := c.get(ctx, "/v0/servers/"+url.PathEscape(name)+"/versions", &list); err != nil {
+		return nil, err
+	}
+
+	return list.Servers, nil
+}
+
+// Search returns a page of server entries.
+func (c *Client) Search(ctx context.Context, opts ListOptions) (*ServerList, error) {
+	query := url.Values{}
+
+	if opts.Search != "" {
+		query.Set("search", opts.Search)
+	}
+
+	if opts.Version != "" {
+		query.Set("version", opts.Version)
+	}
+
+	if !opts.UpdatedSince.IsZero() {
+		query.Set("updated_since", opts.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	path := "/v0/servers"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var list serverListResponse
+	if err := c.get(ctx, path, &list); err != nil {
+		return nil, err
+	}
+
+	return &ServerList{Servers: list.Servers, NextCursor: list.Metadata.NextCursor}, nil
+}
+
+// SearchAll calls fn for every entry matching opts, following pagination.
+// Iteration stops at the first error returned by fn.
+func (c *Client) SearchAll(ctx context.Context, opts ListOptions, fn func(ServerResponse) error) error {
+	for {
+		page, err := c.Search(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range page.Servers {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+
+		if page.NextCursor == "" || page.NextCursor == opts.Cursor {
+			return nil
+		}
+
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// serverListResponse is the wire format of server lists.
+type serverListResponse struct {
+	Servers  []ServerResponse `json:"servers"`
+	Metadata struct {
+		NextCursor string `json:"nextCursor,omitempty"`
+	} `json:"metadata"`
+}
+
+// UnmarshalJSON decodes an entry and keeps the raw document in Raw.
+func (r *ServerResponse) UnmarshalJSON(data []byte) error {
+	type plain ServerResponse
+
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	decoded.Raw = &structpb.Struct{}
+	if err := decoded.Raw.UnmarshalJSON(data); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	*r = ServerResponse(decoded)
+
+	return nil
+}
+
+// get GETs path and decodes the JSON response into v, retrying failures.
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	target := c.baseURL + path
+	backoff := c.backoff
+
+	for attempt := 0; ; attempt++ {
+		body, retryAfter, err := c.do(ctx, target)
+		if err == nil {
+			if err := json.Unmarshal(body, v); err != nil {
+				return fmt.Errorf("failed to parse response from %s: %w", target, err)
+			}
+
+			return nil
+		}
+
+		if retryAfter < 0 || attempt >= c.retries {
+			return err
+		}
+
+		wait := max(backoff, retryAfter)
+		backoff = min(backoff*2, maxBackoff)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// do sends one GET request. A negative retryAfter marks errors that must not
+// be retried; otherwise it is the delay the registry asked for, if any.
+func (c *Client) do(ctx context.Context, target string) ([]byte, time.Duration, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, -1, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, -1, fmt.Errorf("failed to create GET request to %s: %w", target, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, -1, fmt.Errorf("failed to send GET request to %s: %w", target, err)
+		}
+
+		return nil, 0, fmt.Errorf("failed to send GET request to %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response from %s: %w", target, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, 0, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, -1, fmt.Errorf("%s: %w", target, ErrNotFound)
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return nil, parseRetryAfter(resp.Header.Get("Retry-After")),
+			fmt.Errorf("failed to fetch %s: HTTP %d, body: %s", target, resp.StatusCode, string(body))
+	default:
+		return nil, -1, fmt.Errorf("failed to fetch %s: HTTP %d, body: %s", target, resp.StatusCode, string(body))
+	}
+}
+
+// wait blocks until the rate limit allows the next request.
+func (c *Client) wait(ctx context.Context) error {
+	if c.minInterval == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	now := time.Now()
+
+	start := now
+	if c.next.After(now) {
+		start = c.next
+	}
+
+	c.next = start.Add(c.minInterval)
+	c.mu.Unlock()
+
+	if delay := start.Sub(now); delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		case <-time.After(delay):
+		}
+	}
+
+	return nil
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return min(time.Duration(seconds)*time.Second, maxBackoff)
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(t), 0), maxBackoff)
+	}
+
+	return 0
+}
@@ -0,0 +1,198 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpregistry_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/mcpregistry"
+)
+
+const entryJSON = `{
+  "server": {
+    "name": "ai.mcpcap/mcpcap",
+    "description": "An MCP server for analyzing PCAP files.",
+    "version": "0.6.0",
+    "repository": {"url": "https://github.com/mcpcap/mcpcap", "source": "github"},
+    "packages": [{"registryType": "pypi", "identifier": "mcpcap"}]
+  },
+  "_meta": {
+    "io.modelcontextprotocol.registry/official": {"status": "active", "publishedAt": "2025-09-30T10:00:00Z", "isLatest": true}
+  }
+}`
+
+func newClient(t *testing.T, handler http.HandlerFunc) *mcpregistry.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client, err := mcpregistry.New(srv.URL, mcpregistry.WithRetries(2, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func TestGet(t *testing.T) {
+	var path string
+
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(entryJSON))
+	})
+
+	entry, err := client.Get(t.Context(), "ai.mcpcap/mcpcap", "")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if path != "/v0/servers/ai.mcpcap%2Fmcpcap/versions/latest" {
+		t.Errorf("unexpected request path %q", path)
+	}
+
+	if entry.Server.Name != "ai.mcpcap/mcpcap" || entry.Server.Repository.Source != "github" ||
+		entry.Meta.Official == nil || !entry.Meta.Official.IsLatest {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if entry.Raw.GetFields()["server"].GetStructValue().GetFields()["packages"] == nil {
+		t.Errorf("raw entry misses unmodelled fields: %v", entry.Raw)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, nil)
+	})
+
+	if _, err := client.Get(t.Context(), "missing/server", "1.0.0"); !errors.Is(err, mcpregistry.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("a 404 must not be retried, got %d requests", calls.Load())
+	}
+}
+
+func TestRetries(t *testing.T) {
+	var calls atomic.Int32
+
+	client := newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(entryJSON))
+		}
+	})
+
+	if _, err := client.Get(t.Context(), "ai.mcpcap/mcpcap", "0.6.0"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", calls.Load())
+	}
+
+	calls.Store(0)
+
+	client = newClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if _, err := client.Get(t.Context(), "ai.mcpcap/mcpcap", "0.6.0"); err == nil || calls.Load() != 3 {
+		t.Errorf("expected an error after 3 requests, got %v after %d", err, calls.Load())
+	}
+}
+
+func TestSearchAll(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("search") != "pcap" || r.URL.Query().Get("version") != "latest" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"servers": [` + entryJSON + `], "metadata": {"nextCursor": "page2"}}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"servers": [` + entryJSON + `], "metadata": {}}`))
+	})
+
+	var names []string
+
+	err := client.SearchAll(t.Context(), mcpregistry.ListOptions{Search: "pcap", Version: mcpregistry.LatestVersion},
+		func(entry mcpregistry.ServerResponse) error {
+			names = append(names, entry.Server.Name)
+
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("SearchAll: %v", err)
+	}
+
+	if len(names) != 2 {
+		t.Errorf("expected both pages, got %v", names)
+	}
+}
+
+func TestVersions(t *testing.T) {
+	client := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v0/servers/ai.mcpcap%2Fmcpcap/versions" {
+			t.Errorf("unexpected request path %q", r.URL.EscapedPath())
+		}
+
+		_, _ = w.Write([]byte(`{"servers": [` + entryJSON + `, ` + entryJSON + `]}`))
+	})
+
+	versions, err := client.Versions(t.Context(), "ai.mcpcap/mcpcap")
+	if err != nil || len(versions) != 2 {
+		t.Errorf("Versions: %v, %d entries", err, len(versions))
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(entryJSON))
+	}))
+	defer srv.Close()
+
+	client, err := mcpregistry.New(srv.URL, mcpregistry.WithRateLimit(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	for range 3 {
+		if _, err := client.Get(t.Context(), "ai.mcpcap/mcpcap", ""); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 requests at 50/s took only %v", elapsed)
+	}
+}
+
+func TestNewInvalidURL(t *testing.T) {
+	if _, err := mcpregistry.New("not a url"); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
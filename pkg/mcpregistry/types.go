@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package mcpregistry
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ServerResponse is a server entry of the registry: the server.json document
+// and the registry's metadata about it.
+type ServerResponse struct {
+	Server Server `json:"server"`
+	Meta   Meta   `json:"_meta,omitempty"` //nolint:tagliatelle
+	// Raw is the entry as returned by the registry, including fields not
+	// modelled here. It is the input of translator.MCPToRecord.
+	Raw *structpb.Struct `json:"-"`
+}
+
+// Server holds the identifying fields of a server.json document. Packages,
+// remotes and other details are available in ServerResponse.Raw.
+type Server struct {
+	Name        string      `json:"name"`
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Version     string      `json:"version"`
+	WebsiteURL  string      `json:"websiteUrl,omitempty"`
+	Repository  *Repository `json:"repository,omitempty"`
+}
+
+// Repository is the source repository of a server.
+type Repository struct {
+	URL       string `json:"url"`
+	Source    string `json:"source,omitempty"`
+	ID        string `json:"id,omitempty"`
+	Subfolder string `json:"subfolder,omitempty"`
+}
+
+// Meta is the registry metadata of a server entry.
+type Meta struct {
+	Official *OfficialMeta `json:"io.modelcontextprotocol.registry/official,omitempty"`
+}
+
+// OfficialMeta is the metadata the official registry adds to every entry.
+type OfficialMeta struct {
+	Status      string    `json:"status"`
+	PublishedAt time.Time `json:"publishedAt"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+	IsLatest    bool      `json:"isLatest"`
+}
+
+// ServerList is a page of server entries.
+type ServerList struct {
+	Servers []ServerResponse
+	// NextCursor is passed as ListOptions.Cursor to fetch the next page, and
+	// empty on the last page.
+	NextCursor string
+}
+
+// ListOptions filter and paginate Search.
+type ListOptions struct {
+	// Search matches server names containing the term.
+	Search string
+	// Version is "latest" to list only the latest version of each server, or
+	// an exact version. Empty lists all versions.
+	Version string
+	// UpdatedSince lists only entries updated after the time.
+	UpdatedSince time.Time
+	// Cursor is the NextCursor of the previous page.
+	Cursor string
+	// Limit is the page size; the registry default applies when zero.
+	Limit int
+}
@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command gen generates REFERENCE.md, the reference of the message codes of
+// the messages package, from the codes the validator and linter define.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	_ "github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	_ "github.com/agntcy/oasf-sdk/pkg/validator"
+)
+
+func main() {
+	out := flag.String("out", "REFERENCE.md", "File to write the reference to")
+
+	flag.Parse()
+
+	if err := os.WriteFile(*out, render(messages.Entries()), 0o644); err != nil { //nolint:gosec,mnd
+		log.Fatal(err)
+	}
+}
+
+// render returns the reference of the entries.
+func render(entries []messages.Entry) []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString("<!-- Code generated by go generate; DO NOT EDIT. -->\n\n")
+	buf.WriteString("# Message codes\n\n")
+	buf.WriteString("Validation and lint messages carry one of these stable codes. The English\n")
+	buf.WriteString("templates are the defaults of `messages.Message.Localize`; register catalogs\n")
+	buf.WriteString("of other languages with `messages.Register`, using the same `{name}`\n")
+	buf.WriteString("placeholders.\n\n")
+	buf.WriteString("| Code | Description | Template |\n")
+	buf.WriteString("| --- | --- | --- |\n")
+
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "| `%s` | %s | %s |\n", entry.Code, cell(entry.Description), cell("`"+entry.Template+"`"))
+	}
+
+	return buf.Bytes()
+}
+
+// cell escapes the pipes of a table cell.
+func cell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
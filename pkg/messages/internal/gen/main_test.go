@@ -0,0 +1,31 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+)
+
+func TestReferenceUpToDate(t *testing.T) {
+	want, err := os.ReadFile("../../REFERENCE.md")
+	if err != nil {
+		t.Fatalf("failed to read REFERENCE.md: %v", err)
+	}
+
+	if got := render(messages.Entries()); !bytes.Equal(got, want) {
+		t.Error("REFERENCE.md is out of date; run go generate ./messages")
+	}
+}
+
+func TestRenderEscapesPipes(t *testing.T) {
+	got := string(render([]messages.Entry{{Code: "x/y", Description: "a | b", Template: "{c}"}}))
+	if !strings.Contains(got, "| `x/y` | a \\| b | `{c}` |\n") {
+		t.Errorf("unexpected reference:\n%s", got)
+	}
+}
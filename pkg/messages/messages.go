@@ -0,0 +1,166 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package messages gives the validation and lint messages of the SDK stable,
+// machine-readable codes, and renders them from per-language catalogs so UIs
+// can localize them instead of showing the English text. The codes are
+// documented in REFERENCE.md, generated by go generate.
+//
+// Codes are "<source>/<id>": validation codes are named after the error kinds
+// of the OASF schema server (validation/attribute_required_missing), lint
+// codes after the lint rules (lint/created-at-format). The packages reporting
+// the messages define their codes with Define.
+package messages
+
+//go:generate go run ./internal/gen -out REFERENCE.md
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultLanguage is the language of the templates given to Define, and the
+// fallback of Localize.
+const DefaultLanguage = "en"
+
+// Code identifies a kind of message. Codes are stable: they are never reused
+// for another kind of message.
+type Code string
+
+// Message is a validation or lint message.
+type Message struct {
+	Code Code `json:"code" yaml:"code"`
+	// Text is the English text, as reported by its source.
+	Text string `json:"message" yaml:"message"`
+	// Path is the record field the message refers to, if any.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Args are the values of the placeholders of the templates of the code.
+	Args map[string]string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// Entry documents a code.
+type Entry struct {
+	Code Code
+	// Description says when the message is reported.
+	Description string
+	// Template is the English template of the message; {name} placeholders
+	// are replaced with the Args of the message.
+	Template string
+}
+
+// Catalog maps codes to the templates of one language.
+type Catalog map[Code]string
+
+var (
+	mu       sync.RWMutex
+	entries  = map[Code]Entry{}
+	catalogs = map[string]Catalog{DefaultLanguage: {}}
+)
+
+// Define defines codes and their English templates. It panics when a code is
+// defined twice, as codes must be unique.
+func Define(defs ...Entry) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, entry := range defs {
+		if _, ok := entries[entry.Code]; ok {
+			panic(fmt.Sprintf("messages: code %s defined twice", entry.Code))
+		}
+
+		entries[entry.Code] = entry
+		catalogs[DefaultLanguage][entry.Code] = entry.Template
+	}
+}
+
+// Lookup returns the entry of a code.
+func Lookup(code Code) (Entry, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	entry, ok := entries[code]
+
+	return entry, ok
+}
+
+// Entries returns the defined codes, sorted.
+func Entries() []Entry {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	out := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, entry)
+	}
+
+	slices.SortFunc(out, func(a, b Entry) int { return strings.Compare(string(a.Code), string(b.Code)) })
+
+	return out
+}
+
+// Register adds the templates of a catalog to the language, e.g. "de" or
+// "pt-BR". Codes missing from it fall back to English.
+func Register(language string, catalog Catalog) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if catalogs[language] == nil {
+		catalogs[language] = Catalog{}
+	}
+
+	for code, template := range catalog {
+		catalogs[language][code] = template
+	}
+}
+
+// Localize renders the message in the language. It falls back to the base
+// language of a regional one ("pt" for "pt-BR"), then to English: the text
+// of the message, as reported by its source, or the English template.
+func (m Message) Localize(language string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	base, _, _ := strings.Cut(language, "-")
+
+	for _, lang := range []string{language, base} {
+		if lang == DefaultLanguage {
+			break
+		}
+
+		if template, ok := catalogs[lang][m.Code]; ok {
+			return Render(template, m.Args)
+		}
+	}
+
+	if m.Text != "" {
+		return m.Text
+	}
+
+	if template, ok := catalogs[DefaultLanguage][m.Code]; ok {
+		return Render(template, m.Args)
+	}
+
+	return string(m.Code)
+}
+
+func (m Message) String() string {
+	return m.Text
+}
+
+// placeholder matches the {name} placeholders of templates.
+var placeholder = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// Render replaces the {name} placeholders of the template with args. Missing
+// arguments are left as placeholders.
+func Render(template string, args map[string]string) string {
+	return placeholder.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := args[match[1:len(match)-1]]; ok {
+			return value
+		}
+
+		return match
+	})
+}
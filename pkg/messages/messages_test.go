@@ -0,0 +1,58 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+)
+
+func TestRender(t *testing.T) {
+	got := messages.Render("{a} and {b}, not {c}", map[string]string{"a": "x", "b": "y"})
+	if want := "x and y, not {c}"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	messages.Define(messages.Entry{Code: "test/greeting", Template: "hello {name}"})
+	messages.Register("de", messages.Catalog{"test/greeting": "hallo {name}"})
+
+	msg := messages.Message{Code: "test/greeting", Args: map[string]string{"name": "Ada"}}
+
+	cases := map[string]string{
+		"de":    "hallo Ada",
+		"de-AT": "hallo Ada",
+		"fr":    "hello Ada",
+		"en":    "hello Ada",
+	}
+
+	for language, want := range cases {
+		if got := msg.Localize(language); got != want {
+			t.Errorf("Localize(%q) = %q, want %q", language, got, want)
+		}
+	}
+
+	msg.Text = "Hello, Ada."
+	if got := msg.Localize("fr"); got != msg.Text {
+		t.Errorf("Localize() = %q, want the text of the message", got)
+	}
+
+	if got := (messages.Message{Code: "test/unknown"}).Localize("de"); got != "test/unknown" {
+		t.Errorf("Localize() of an unknown code = %q, want the code", got)
+	}
+}
+
+func TestDefineTwicePanics(t *testing.T) {
+	messages.Define(messages.Entry{Code: "test/twice"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Define to panic on a duplicate code")
+		}
+	}()
+
+	messages.Define(messages.Entry{Code: "test/twice"})
+}
@@ -0,0 +1,135 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ModuleAlias records that a module was renamed in a schema version. From and
+// To ending in "/" rename a whole namespace, e.g. "runtime/" to
+// "integration/".
+type ModuleAlias struct {
+	// From is the name before Version, To the name from Version on.
+	From, To string
+	// Version is the first schema version using To.
+	Version string
+}
+
+// defaultModuleAliases are the module renames of the OASF schema.
+var defaultModuleAliases = []ModuleAlias{
+	{From: "runtime/", To: "integration/", Version: "0.8.0"},
+}
+
+var (
+	moduleAliasesMu sync.RWMutex
+	moduleAliases   = slices.Clone(defaultModuleAliases)
+)
+
+// RegisterModuleAliases adds module renames to the table used by
+// ModuleNameForVersion, FindModule and Migrate, e.g. for renames of a newer
+// schema version than the SDK knows. Versions must be semantic versions.
+func RegisterModuleAliases(aliases ...ModuleAlias) error {
+	for _, alias := range aliases {
+		if alias.From == "" || alias.To == "" || alias.From == alias.To {
+			return fmt.Errorf("invalid module alias %q to %q", alias.From, alias.To)
+		}
+
+		if strings.HasSuffix(alias.From, "/") != strings.HasSuffix(alias.To, "/") {
+			return fmt.Errorf("module alias %q to %q mixes a namespace and a module", alias.From, alias.To)
+		}
+
+		if _, err := semver.StrictNewVersion(alias.Version); err != nil {
+			return fmt.Errorf("invalid schema version %q of module alias %q: %w", alias.Version, alias.From, err)
+		}
+	}
+
+	moduleAliasesMu.Lock()
+	defer moduleAliasesMu.Unlock()
+
+	moduleAliases = append(moduleAliases, aliases...)
+	slices.SortStableFunc(moduleAliases, func(a, b ModuleAlias) int {
+		return semver.MustParse(a.Version).Compare(semver.MustParse(b.Version))
+	})
+
+	return nil
+}
+
+// ModuleAliases returns the module renames, by schema version.
+func ModuleAliases() []ModuleAlias {
+	moduleAliasesMu.RLock()
+	defer moduleAliasesMu.RUnlock()
+
+	return slices.Clone(moduleAliases)
+}
+
+// ParseModuleAlias parses a module alias spec, "<from>=<to>@<version>", e.g.
+// "integration/mcp=integration/mcp_server@1.1.0".
+func ParseModuleAlias(spec string) (ModuleAlias, error) {
+	names, version, ok := strings.Cut(strings.TrimSpace(spec), "@")
+	if !ok {
+		return ModuleAlias{}, fmt.Errorf("invalid module alias %q, want <from>=<to>@<version>", spec)
+	}
+
+	from, to, ok := strings.Cut(names, "=")
+	if !ok {
+		return ModuleAlias{}, fmt.Errorf("invalid module alias %q, want <from>=<to>@<version>", spec)
+	}
+
+	return ModuleAlias{From: from, To: to, Version: version}, nil
+}
+
+// ModuleNameForVersion maps a module name to its name in the schema version
+// by the module alias table, so callers can pass the name of any version,
+// e.g. "integration/mcp" is "runtime/mcp" in 0.7.0. An empty or unknown
+// version selects the newest names. Names without aliases are returned
+// unchanged.
+func ModuleNameForVersion(name, schemaVersion string) string {
+	name = canonicalModuleName(name)
+
+	target, err := semver.StrictNewVersion(schemaVersion)
+	if err != nil {
+		return name
+	}
+
+	aliases := ModuleAliases()
+	for i := len(aliases) - 1; i >= 0; i-- {
+		if semver.MustParse(aliases[i].Version).GreaterThan(target) {
+			name = renameModule(name, aliases[i].To, aliases[i].From)
+		}
+	}
+
+	return name
+}
+
+// canonicalModuleName returns the newest name of a module.
+func canonicalModuleName(name string) string {
+	for _, alias := range ModuleAliases() {
+		name = renameModule(name, alias.From, alias.To)
+	}
+
+	return name
+}
+
+// renameModule replaces the from module or namespace of name with to.
+func renameModule(name, from, to string) string {
+	if !strings.HasSuffix(from, "/") {
+		if name == from {
+			return to
+		}
+
+		return name
+	}
+
+	if rest, ok := strings.CutPrefix(name, from); ok {
+		return to + rest
+	}
+
+	return name
+}
@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+)
+
+func TestModuleNameForVersion(t *testing.T) {
+	cases := []struct{ name, version, want string }{
+		{"integration/mcp", "0.7.0", "runtime/mcp"},
+		{"runtime/mcp", "0.8.0", "integration/mcp"},
+		{"runtime/a2a", "1.0.0", "integration/a2a"},
+		{"runtime/mcp", "", "integration/mcp"},
+		{"core/language_model/agentskills", "0.7.0", "core/language_model/agentskills"},
+	}
+
+	for _, c := range cases {
+		if got := record.ModuleNameForVersion(c.name, c.version); got != c.want {
+			t.Errorf("ModuleNameForVersion(%q, %q) = %q, want %q", c.name, c.version, got, c.want)
+		}
+	}
+}
+
+func TestRegisterModuleAliases(t *testing.T) {
+	var aliases []record.ModuleAlias
+
+	for _, spec := range []string{"example/new=example/newer@1.0.0", "example/old=example/new@0.8.0"} {
+		alias, err := record.ParseModuleAlias(spec)
+		if err != nil {
+			t.Fatalf("ParseModuleAlias(%q): %v", spec, err)
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	if err := record.RegisterModuleAliases(aliases...); err != nil {
+		t.Fatalf("RegisterModuleAliases: %v", err)
+	}
+
+	for version, want := range map[string]string{"0.7.0": "example/old", "0.8.0": "example/new", "1.0.0": "example/newer"} {
+		if got := record.ModuleNameForVersion("example/old", version); got != want {
+			t.Errorf("ModuleNameForVersion(example/old, %s) = %q, want %q", version, got, want)
+		}
+	}
+
+	legacy := mustStruct(t, map[string]any{
+		"schema_version": "0.7.0",
+		"modules":        []any{map[string]any{"name": "example/old", "data": map[string]any{}}},
+	})
+
+	if !record.HasModule(legacy, "example/newer") {
+		t.Error("HasModule must match any name of the module")
+	}
+
+	migrated, err := record.Migrate(legacy, "1.0.0")
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	if got := moduleNames(migrated); len(got) != 1 || got[0] != "example/newer" {
+		t.Errorf("migrated module names = %v, want [example/newer]", got)
+	}
+}
+
+func TestModuleAliasErrors(t *testing.T) {
+	for _, spec := range []string{"example/a=example/b", "example/a@1.0.0"} {
+		if _, err := record.ParseModuleAlias(spec); err == nil {
+			t.Errorf("ParseModuleAlias(%q) succeeded, want an error", spec)
+		}
+	}
+
+	for _, alias := range []record.ModuleAlias{
+		{From: "example/a", To: "example/a", Version: "1.0.0"},
+		{From: "example/", To: "example/b", Version: "1.0.0"},
+		{From: "example/a", To: "example/b", Version: "latest"},
+	} {
+		if err := record.RegisterModuleAliases(alias); err == nil {
+			t.Errorf("RegisterModuleAliases(%+v) succeeded, want an error", alias)
+		}
+	}
+}
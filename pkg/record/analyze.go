@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Limits are the caps a record is checked against by Analyze. Zero disables
+// a limit.
+type Limits struct {
+	// MaxSize caps the canonical JSON size of the record in bytes.
+	MaxSize int `json:"max_size"             yaml:"max_size"`
+	// MaxModuleSize caps the canonical JSON size of a single module.
+	MaxModuleSize int `json:"max_module_size"      yaml:"max_module_size"`
+	// MaxModules caps the number of modules.
+	MaxModules int `json:"max_modules"          yaml:"max_modules"`
+	// MaxConnections caps the number of connections across all modules.
+	MaxConnections int `json:"max_connections"      yaml:"max_connections"`
+	// MaxAnnotationBytes caps the total size of annotation keys and values.
+	MaxAnnotationBytes int `json:"max_annotation_bytes" yaml:"max_annotation_bytes"`
+}
+
+// DefaultLimits matches the default 4 MiB gRPC message size, used by the
+// SDK server and AGNTCY directories, and flags annotations over 64 KiB.
+var DefaultLimits = Limits{
+	MaxSize:            4 << 20,  //nolint:mnd
+	MaxAnnotationBytes: 64 << 10, //nolint:mnd
+}
+
+// ModuleSize is the canonical JSON size of a module.
+type ModuleSize struct {
+	Name string `json:"name" yaml:"name"`
+	Size int    `json:"size" yaml:"size"`
+	// ArtifactSize is the size of the module's base64 artifact data.
+	ArtifactSize int `json:"artifact_size,omitempty" yaml:"artifact_size,omitempty"`
+}
+
+// Analysis describes the size and complexity of a record.
+type Analysis struct {
+	// Size is the canonical JSON size of the record in bytes.
+	Size            int          `json:"size"             yaml:"size"`
+	Skills          int          `json:"skills"           yaml:"skills"`
+	Domains         int          `json:"domains"          yaml:"domains"`
+	Locators        int          `json:"locators"         yaml:"locators"`
+	Modules         []ModuleSize `json:"modules"          yaml:"modules"`
+	Connections     int          `json:"connections"      yaml:"connections"`
+	Annotations     int          `json:"annotations"      yaml:"annotations"`
+	AnnotationBytes int          `json:"annotation_bytes" yaml:"annotation_bytes"`
+	// Depth is the deepest nesting of objects and lists.
+	Depth int `json:"depth" yaml:"depth"`
+	// Warnings lists the exceeded limits.
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// Analyze reports the size and complexity of a record and warns about every
+// limit it exceeds. Modules are listed largest first.
+func Analyze(record *structpb.Struct, limits Limits) (*Analysis, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	data, err := CanonicalJSON(record, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := record.GetFields()
+	analysis := &Analysis{
+		Size:     len(data),
+		Skills:   len(fields["skills"].GetListValue().GetValues()),
+		Domains:  len(fields["domains"].GetListValue().GetValues()),
+		Locators: len(fields["locators"].GetListValue().GetValues()),
+		Modules:  []ModuleSize{},
+		Depth:    depth(structpb.NewStructValue(record)),
+	}
+
+	for _, key := range annotations.Keys(record) {
+		value, _ := annotations.Get(record, key)
+
+		analysis.Annotations++
+		analysis.AnnotationBytes += len(key) + len(value)
+	}
+
+	for _, module := range fields["modules"].GetListValue().GetValues() {
+		moduleFields := module.GetStructValue().GetFields()
+
+		moduleJSON, err := CanonicalJSON(module.GetStructValue(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		analysis.Modules = append(analysis.Modules, ModuleSize{
+			Name:         moduleFields["name"].GetStringValue(),
+			Size:         len(moduleJSON),
+			ArtifactSize: len(moduleFields["artifact"].GetStructValue().GetFields()["data"].GetStringValue()),
+		})
+		analysis.Connections += connectionCount(moduleFields["data"].GetStructValue())
+	}
+
+	sort.SliceStable(analysis.Modules, func(i, j int) bool {
+		return analysis.Modules[i].Size > analysis.Modules[j].Size
+	})
+
+	analysis.Warnings = limitWarnings(analysis, limits)
+
+	return analysis, nil
+}
+
+// connectionCount counts MCP connections and A2A interfaces of module data.
+func connectionCount(data *structpb.Struct) int {
+	card := data.GetFields()["card_data"].GetStructValue().GetFields()
+
+	return len(data.GetFields()["connections"].GetListValue().GetValues()) +
+		len(card["supportedInterfaces"].GetListValue().GetValues())
+}
+
+func depth(value *structpb.Value) int {
+	deepest := 0
+
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		for _, field := range kind.StructValue.GetFields() {
+			deepest = max(deepest, depth(field))
+		}
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			deepest = max(deepest, depth(item))
+		}
+	default:
+		return 0
+	}
+
+	return deepest + 1
+}
+
+func limitWarnings(analysis *Analysis, limits Limits) []string {
+	var warnings []string
+
+	exceeds := func(limit, value int) bool { return limit > 0 && value > limit }
+
+	if exceeds(limits.MaxSize, analysis.Size) {
+		warnings = append(warnings, fmt.Sprintf("record is %d bytes, over the %d byte limit", analysis.Size, limits.MaxSize))
+	}
+
+	for _, module := range analysis.Modules {
+		if exceeds(limits.MaxModuleSize, module.Size) {
+			warnings = append(warnings, fmt.Sprintf("module %s is %d bytes, over the %d byte limit", module.Name, module.Size, limits.MaxModuleSize))
+		}
+	}
+
+	if exceeds(limits.MaxModules, len(analysis.Modules)) {
+		warnings = append(warnings, fmt.Sprintf("record has %d modules, over the limit of %d", len(analysis.Modules), limits.MaxModules))
+	}
+
+	if exceeds(limits.MaxConnections, analysis.Connections) {
+		warnings = append(warnings, fmt.Sprintf("record has %d connections, over the limit of %d", analysis.Connections, limits.MaxConnections))
+	}
+
+	if exceeds(limits.MaxAnnotationBytes, analysis.AnnotationBytes) {
+		warnings = append(warnings, fmt.Sprintf("annotations take %d bytes, over the %d byte limit", analysis.AnnotationBytes, limits.MaxAnnotationBytes))
+	}
+
+	return warnings
+}
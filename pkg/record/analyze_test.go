@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAnalyze(t *testing.T) {
+	in, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/agent",
+		"version":        "1.0.0",
+		"schema_version": "1.0.0",
+		"skills":         []any{map[string]any{"id": 10201}},
+		"annotations":    map[string]any{"team": strings.Repeat("x", 100)},
+		"modules": []any{
+			map[string]any{
+				"name": "integration/mcp",
+				"data": map[string]any{"connections": []any{
+					map[string]any{"type": "stdio"},
+					map[string]any{"type": "sse"},
+				}},
+			},
+			map[string]any{
+				"name": "integration/a2a",
+				"data": map[string]any{"card_data": map[string]any{
+					"description": strings.Repeat("y", 200),
+					"supportedInterfaces": []any{
+						map[string]any{"url": "https://example.org/a2a"},
+					},
+				}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := record.Analyze(in, record.DefaultLimits)
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if analysis.Skills != 1 || analysis.Connections != 3 || analysis.Annotations != 1 || analysis.AnnotationBytes != 104 {
+		t.Errorf("unexpected analysis: %+v", analysis)
+	}
+
+	if len(analysis.Modules) != 2 || analysis.Modules[0].Name != "integration/a2a" || analysis.Depth != 7 {
+		t.Errorf("unexpected modules or depth: %+v", analysis)
+	}
+
+	if len(analysis.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", analysis.Warnings)
+	}
+
+	analysis, err = record.Analyze(in, record.Limits{MaxSize: 100, MaxModules: 1, MaxConnections: 2, MaxAnnotationBytes: 50, MaxModuleSize: 200})
+	if err != nil {
+		t.Fatalf("Analyze: %v", err)
+	}
+
+	if len(analysis.Warnings) != 5 {
+		t.Errorf("expected a warning per exceeded limit, got %v", analysis.Warnings)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/taxonomy"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultSchemaVersion is the schema version of records built without an
+// explicit SchemaVersion.
+const DefaultSchemaVersion = "1.0.0"
+
+// Builder assembles an OASF record field by field:
+//
+//	rec, err := record.NewBuilder().
+//		Name("example.org/agent").
+//		Version("v1.0.0").
+//		AddSkill(10201).
+//		AddModule("integration/mcp", data).
+//		Build()
+//
+// Errors are collected along the way and returned by Build, so calls can be
+// chained without intermediate checks.
+type Builder struct {
+	fields map[string]*structpb.Value
+	// lists holds the list fields built with the Add methods.
+	lists   map[string][]*structpb.Value
+	created time.Time
+	errs    []error
+}
+
+// NewBuilder returns a builder for a record of DefaultSchemaVersion.
+func NewBuilder() *Builder {
+	return &Builder{
+		fields: map[string]*structpb.Value{
+			"schema_version": structpb.NewStringValue(DefaultSchemaVersion),
+		},
+		lists: map[string][]*structpb.Value{},
+	}
+}
+
+// Name sets the record name.
+func (b *Builder) Name(name string) *Builder {
+	return b.setString("name", name)
+}
+
+// Version sets the record version.
+func (b *Builder) Version(version string) *Builder {
+	return b.setString("version", version)
+}
+
+// SchemaVersion sets the OASF schema version of the record.
+func (b *Builder) SchemaVersion(version string) *Builder {
+	return b.setString("schema_version", version)
+}
+
+// Description sets the record description.
+func (b *Builder) Description(description string) *Builder {
+	return b.setString("description", description)
+}
+
+// Authors sets the record authors.
+func (b *Builder) Authors(authors ...string) *Builder {
+	values := make([]*structpb.Value, 0, len(authors))
+	for _, author := range authors {
+		values = append(values, structpb.NewStringValue(author))
+	}
+
+	b.fields["authors"] = structpb.NewListValue(&structpb.ListValue{Values: values})
+
+	return b
+}
+
+// CreatedAt sets the creation time. It defaults to the time Build is called.
+func (b *Builder) CreatedAt(t time.Time) *Builder {
+	b.created = t
+
+	return b
+}
+
+// AddSkill adds a skill by its taxonomy ID (see the taxonomy package).
+func (b *Builder) AddSkill(id taxonomy.SkillID) *Builder {
+	return b.add("skills", classValue(uint32(id)))
+}
+
+// AddDomain adds a domain by its taxonomy ID (see the taxonomy package).
+func (b *Builder) AddDomain(id taxonomy.DomainID) *Builder {
+	return b.add("domains", classValue(uint32(id)))
+}
+
+// AddLocator adds a locator of the given type (e.g. "source_code").
+func (b *Builder) AddLocator(locatorType string, urls ...string) *Builder {
+	urlValues := make([]*structpb.Value, 0, len(urls))
+	for _, url := range urls {
+		urlValues = append(urlValues, structpb.NewStringValue(url))
+	}
+
+	return b.add("locators", structpb.NewStructValue(&structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"type": structpb.NewStringValue(locatorType),
+			"urls": structpb.NewListValue(&structpb.ListValue{Values: urlValues}),
+		},
+	}))
+}
+
+// ModuleOption configures a module added with AddModule.
+type ModuleOption func(fields map[string]*structpb.Value)
+
+// WithModuleID sets the module's taxonomy ID.
+func WithModuleID(id taxonomy.ModuleID) ModuleOption {
+	return func(fields map[string]*structpb.Value) {
+		fields["id"] = structpb.NewNumberValue(float64(id))
+	}
+}
+
+// WithModuleArtifact attaches the source the module was generated from as an
+// OCI-like artifact descriptor. The payload is base64-encoded into "data";
+// "size" and "digest" are derived from the raw bytes so they stay verifiable
+// independently of any serialization choice.
+func WithModuleArtifact(payload []byte, mediaType string) ModuleOption {
+	return func(fields map[string]*structpb.Value) {
+		sum := sha256.Sum256(payload)
+
+		fields["artifact"] = structpb.NewStructValue(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"media_type": structpb.NewStringValue(mediaType),
+				"size":       structpb.NewNumberValue(float64(len(payload))),
+				"digest":     structpb.NewStringValue(fmt.Sprintf("sha256:%x", sum)),
+				"data":       structpb.NewStringValue(base64.StdEncoding.EncodeToString(payload)),
+			},
+		})
+	}
+}
+
+// AddModule adds a module with the given name and data.
+func (b *Builder) AddModule(name string, data *structpb.Struct, opts ...ModuleOption) *Builder {
+	return b.add("modules", moduleValue(name, data, opts))
+}
+
+// Set sets a top-level field, replacing anything set for key before. The
+// value is converted with structpb.NewValue. Setting a list field (e.g.
+// "locators") replaces its items; later Add calls append to them.
+func (b *Builder) Set(key string, value any) *Builder {
+	v, err := structpb.NewValue(value)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("invalid value for %s: %w", key, err))
+
+		return b
+	}
+
+	if list := v.GetListValue(); list != nil {
+		delete(b.fields, key)
+		b.lists[key] = list.GetValues()
+
+		return b
+	}
+
+	delete(b.lists, key)
+	b.fields[key] = v
+
+	return b
+}
+
+// Build returns the record. It fails when a required field (name, version,
+// schema_version) is missing, the schema version is malformed, two modules
+// share a name, or an earlier call recorded an error.
+func (b *Builder) Build() (*structpb.Struct, error) {
+	errs := append([]error{}, b.errs...)
+
+	for _, key := range []string{"name", "version", "schema_version"} {
+		if b.fields[key].GetStringValue() == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+	}
+
+	if strings.HasPrefix(b.fields["schema_version"].GetStringValue(), "v") {
+		errs = append(errs, errors.New("schema_version must not have 'v' prefix"))
+	}
+
+	seen := map[string]bool{}
+
+	for _, module := range b.lists["modules"] {
+		name := module.GetStructValue().GetFields()["name"].GetStringValue()
+		if seen[name] {
+			errs = append(errs, fmt.Errorf("duplicate module %q", name))
+		}
+
+		seen[name] = true
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	fields := make(map[string]*structpb.Value, len(b.fields)+len(b.lists))
+	for key, value := range b.fields {
+		fields[key] = value
+	}
+
+	for key, values := range b.lists {
+		fields[key] = structpb.NewListValue(&structpb.ListValue{Values: append([]*structpb.Value{}, values...)})
+	}
+
+	fillRequiredLists(fields)
+
+	created := b.created
+	if created.IsZero() {
+		created = time.Now()
+	}
+
+	if _, ok := fields["created_at"]; !ok {
+		fields["created_at"] = structpb.NewStringValue(created.UTC().Format(time.RFC3339))
+	}
+
+	// Clone so records from repeated Build calls share no values with each
+	// other or with the builder.
+	return Clone(&structpb.Struct{Fields: fields}), nil
+}
+
+func (b *Builder) setString(key, value string) *Builder {
+	b.fields[key] = structpb.NewStringValue(value)
+
+	return b
+}
+
+func (b *Builder) add(key string, value *structpb.Value) *Builder {
+	delete(b.fields, key)
+	b.lists[key] = append(b.lists[key], value)
+
+	return b
+}
+
+func classValue(id uint32) *structpb.Value {
+	return structpb.NewStructValue(&structpb.Struct{
+		Fields: map[string]*structpb.Value{"id": structpb.NewNumberValue(float64(id))},
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestBuilder(t *testing.T) {
+	data, err := structpb.NewStruct(map[string]any{"name": "example"})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	rec, err := record.NewBuilder().
+		Name("example.org/agent").
+		Version("v1.0.0").
+		Description("An example agent").
+		Authors("Example Org").
+		CreatedAt(time.Date(2025, 9, 11, 12, 0, 0, 0, time.UTC)).
+		AddSkill(10201).
+		AddDomain(101).
+		AddLocator("source_code", "https://github.com/example/agent").
+		AddModule("integration/mcp", data, record.WithModuleID(202), record.WithModuleArtifact([]byte("{}"), "application/json")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if _, err := decoder.DecodeRecord(rec); err != nil {
+		t.Fatalf("built record does not decode: %v", err)
+	}
+
+	fields := rec.GetFields()
+	if got := fields["schema_version"].GetStringValue(); got != record.DefaultSchemaVersion {
+		t.Errorf("schema_version = %q, want %q", got, record.DefaultSchemaVersion)
+	}
+
+	if got := fields["created_at"].GetStringValue(); got != "2025-09-11T12:00:00Z" {
+		t.Errorf("created_at = %q", got)
+	}
+
+	if got := fields["skills"].GetListValue().GetValues()[0].GetStructValue().GetFields()["id"].GetNumberValue(); got != 10201 {
+		t.Errorf("skill id = %v, want 10201", got)
+	}
+
+	found, module := record.GetModule(rec, "integration/mcp")
+	if !found {
+		t.Fatal("module not found")
+	}
+
+	artifact := module.GetFields()["artifact"].GetStructValue().GetFields()
+	if artifact["data"].GetStringValue() != "e30=" || artifact["size"].GetNumberValue() != 2 {
+		t.Errorf("unexpected artifact %v", artifact)
+	}
+
+	data.Fields["name"] = structpb.NewStringValue("changed")
+
+	if got := module.GetFields()["data"].GetStructValue().GetFields()["name"].GetStringValue(); got != "example" {
+		t.Errorf("module data aliases the caller's struct: name = %q", got)
+	}
+}
+
+func TestBuilderListFields(t *testing.T) {
+	rec, err := record.NewBuilder().Name("a").Version("v1").Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	for _, key := range []string{"skills", "domains", "modules"} {
+		if rec.GetFields()[key].GetListValue() == nil {
+			t.Errorf("%s should be an empty list", key)
+		}
+	}
+
+	if _, ok := rec.GetFields()["locators"]; ok {
+		t.Error("locators should be omitted when none were added")
+	}
+
+	rec, err = record.NewBuilder().Name("a").Version("v1").
+		Set("locators", []any{}).
+		Set("annotations", map[string]any{"team": "x"}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if rec.GetFields()["locators"].GetListValue() == nil || rec.GetFields()["annotations"].GetStructValue() == nil {
+		t.Errorf("Set fields missing: %v", rec.AsMap())
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		builder *record.Builder
+		want    string
+	}{
+		{"missing name", record.NewBuilder().Version("v1"), "name is required"},
+		{"missing version", record.NewBuilder().Name("a"), "version is required"},
+		{"v prefix", record.NewBuilder().Name("a").Version("v1").SchemaVersion("v1.0.0"), "must not have 'v' prefix"},
+		{
+			"duplicate module",
+			record.NewBuilder().Name("a").Version("v1").AddModule("integration/mcp", nil).AddModule("integration/mcp", nil),
+			`duplicate module "integration/mcp"`,
+		},
+		{"invalid set value", record.NewBuilder().Name("a").Version("v1").Set("x", make(chan int)), "invalid value for x"},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.builder.Build()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
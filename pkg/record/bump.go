@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// VersionBump is the part of a semantic version BumpVersion increments.
+type VersionBump string
+
+const (
+	BumpPatch VersionBump = "patch"
+	BumpMinor VersionBump = "minor"
+	BumpMajor VersionBump = "major"
+)
+
+// ParseVersionBump parses "patch", "minor" or "major".
+func ParseVersionBump(s string) (VersionBump, error) {
+	switch bump := VersionBump(strings.ToLower(strings.TrimSpace(s))); bump {
+	case BumpPatch, BumpMinor, BumpMajor:
+		return bump, nil
+	default:
+		return "", fmt.Errorf("invalid version bump %q (want patch, minor or major)", s)
+	}
+}
+
+// BumpOption configures BumpVersion.
+type BumpOption func(*bumpOptions)
+
+type bumpOptions struct {
+	createdAt time.Time
+	lineage   []LineageOption
+}
+
+// WithCreatedAt sets the created_at of the new version; it defaults to now.
+func WithCreatedAt(t time.Time) BumpOption {
+	return func(o *bumpOptions) {
+		o.createdAt = t
+	}
+}
+
+// WithBumpLineage sets how the previous version is identified, e.g. with
+// WithRecordID for the CIDs of a directory.
+func WithBumpLineage(opts ...LineageOption) BumpOption {
+	return func(o *bumpOptions) {
+		o.lineage = append(o.lineage, opts...)
+	}
+}
+
+// BumpVersion returns the next version of a record: its semantic version
+// (with or without a "v" prefix) incremented by bump, created_at refreshed,
+// linked to the record as its predecessor (see LinkPrevious) and without the
+// signature, which no longer matches. Pre-release and build metadata are
+// dropped; a patch bump releases a pre-release, e.g. v1.1.0-rc.1 to v1.1.0.
+func BumpVersion(record *structpb.Struct, bump VersionBump, opts ...BumpOption) (*structpb.Struct, error) {
+	o := &bumpOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.createdAt.IsZero() {
+		o.createdAt = time.Now()
+	}
+
+	current := record.GetFields()["version"].GetStringValue()
+
+	version, err := semver.NewVersion(current)
+	if err != nil {
+		return nil, fmt.Errorf("record version %q is not a semantic version: %w", current, err)
+	}
+
+	var next semver.Version
+
+	switch bump {
+	case BumpPatch:
+		next = version.IncPatch()
+	case BumpMinor:
+		next = version.IncMinor()
+	case BumpMajor:
+		next = version.IncMajor()
+	default:
+		return nil, fmt.Errorf("invalid version bump %q (want patch, minor or major)", bump)
+	}
+
+	nextVersion := next.String()
+	if strings.HasPrefix(current, "v") {
+		nextVersion = "v" + nextVersion
+	}
+
+	bumped, err := LinkPrevious(record, record, o.lineage...)
+	if err != nil {
+		return nil, err
+	}
+
+	bumped.Fields["version"] = structpb.NewStringValue(nextVersion)
+	bumped.Fields["created_at"] = structpb.NewStringValue(o.createdAt.UTC().Format(time.RFC3339))
+	delete(bumped.Fields, "signature")
+
+	return bumped, nil
+}
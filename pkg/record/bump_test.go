@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestBumpVersion(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		version string
+		bump    record.VersionBump
+		want    string
+	}{
+		{"v1.2.3", record.BumpPatch, "v1.2.4"},
+		{"v1.2.3", record.BumpMinor, "v1.3.0"},
+		{"1.2.3", record.BumpMajor, "2.0.0"},
+		{"v1.1.0-rc.1", record.BumpPatch, "v1.1.0"},
+	} {
+		rec, err := structpb.NewStruct(map[string]any{
+			"name": "example.org/agent", "schema_version": "0.8.0", "version": tt.version,
+			"created_at": "2025-01-01T00:00:00Z", "signature": map[string]any{"signature": "abc"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bumped, err := record.BumpVersion(rec, tt.bump, record.WithCreatedAt(created))
+		if err != nil {
+			t.Fatalf("BumpVersion(%s, %s): %v", tt.version, tt.bump, err)
+		}
+
+		fields := bumped.GetFields()
+		if got := fields["version"].GetStringValue(); got != tt.want {
+			t.Errorf("BumpVersion(%s, %s) = %s, want %s", tt.version, tt.bump, got, tt.want)
+		}
+
+		if got := fields["created_at"].GetStringValue(); got != "2026-03-01T12:00:00Z" {
+			t.Errorf("created_at = %s", got)
+		}
+
+		if _, ok := fields["signature"]; ok {
+			t.Error("expected the signature to be removed")
+		}
+
+		digest, _ := record.RecordDigest(rec)
+		if got := record.PreviousID(bumped); got != digest {
+			t.Errorf("PreviousID = %q, want %q", got, digest)
+		}
+
+		if err := record.VerifyChain([]*structpb.Struct{rec, bumped}); err != nil {
+			t.Errorf("VerifyChain: %v", err)
+		}
+
+		if rec.GetFields()["version"].GetStringValue() != tt.version {
+			t.Error("expected the input record to be left unchanged")
+		}
+	}
+}
+
+func TestBumpVersionErrors(t *testing.T) {
+	rec, _ := structpb.NewStruct(map[string]any{"name": "example.org/agent", "version": "latest"})
+
+	if _, err := record.BumpVersion(rec, record.BumpPatch); err == nil {
+		t.Error("expected an error for a non-semver version")
+	}
+
+	rec.Fields["version"] = structpb.NewStringValue("v1.0.0")
+
+	if _, err := record.BumpVersion(rec, "build"); err == nil {
+		t.Error("expected an error for an invalid bump")
+	}
+
+	if _, err := record.ParseVersionBump("Minor"); err != nil {
+		t.Errorf("ParseVersionBump: %v", err)
+	}
+
+	if _, err := record.ParseVersionBump("micro"); err == nil {
+		t.Error("expected an error for an invalid bump")
+	}
+}
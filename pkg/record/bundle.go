@@ -0,0 +1,251 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Bundle is a set of related records kept in one file, e.g. the fleet of
+// agents of a repository. A bundle file is one of:
+//
+//   - a JSON or YAML object with the records under "records" and, under
+//     "defaults", fields shared by all of them;
+//   - a JSON or YAML array of records;
+//   - NDJSON, one record per line, or multi-document YAML.
+type Bundle struct {
+	// Defaults are merged into every record; fields set by a record win,
+	// and skills, domains and locators are merged as sets (see Merge).
+	Defaults *structpb.Struct
+	// Entries are the records as written in the file, without defaults.
+	Entries []*structpb.Struct
+}
+
+// Records returns the records of the bundle with the defaults applied.
+func (b *Bundle) Records() ([]*structpb.Struct, error) {
+	records := make([]*structpb.Struct, 0, len(b.Entries))
+
+	for i, entry := range b.Entries {
+		if b.Defaults == nil {
+			records = append(records, Clone(entry))
+
+			continue
+		}
+
+		merged, err := Merge(b.Defaults, entry, MergePreferOverlay)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		records = append(records, merged)
+	}
+
+	return records, nil
+}
+
+// IsBundle reports whether data is a bundle rather than a single JSON
+// record: a JSON array, NDJSON, a JSON object with a "records" list, or
+// YAML. Single-record YAML counts as a bundle of one record, so callers
+// reading JSON records accept it through ParseBundle.
+func IsBundle(data []byte) bool {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case len(data) == 0:
+		return false
+	case data[0] == '[':
+		return true
+	case data[0] == '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+
+		var object map[string]json.RawMessage
+		if err := dec.Decode(&object); err != nil {
+			return false
+		}
+
+		if dec.More() {
+			return true
+		}
+
+		_, ok := object["records"]
+
+		return ok
+	default:
+		var v any
+
+		return yaml.Unmarshal(data, &v) == nil && v != nil
+	}
+}
+
+// ParseBundle parses a bundle file; a single record is a bundle of one.
+func ParseBundle(data []byte) (*Bundle, error) {
+	docs, err := decodeDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 1 {
+		switch doc := docs[0].(type) {
+		case []any:
+			return bundleOf(nil, doc)
+		case map[string]any:
+			if records, ok := doc["records"]; ok {
+				list, ok := records.([]any)
+				if !ok {
+					return nil, errors.New("bundle records must be a list")
+				}
+
+				defaults, ok := doc["defaults"].(map[string]any)
+				if !ok && doc["defaults"] != nil {
+					return nil, errors.New("bundle defaults must be an object")
+				}
+
+				return bundleOf(defaults, list)
+			}
+		}
+	}
+
+	return bundleOf(nil, docs)
+}
+
+func bundleOf(defaults map[string]any, entries []any) (*Bundle, error) {
+	b := &Bundle{Entries: make([]*structpb.Struct, 0, len(entries))}
+
+	if defaults != nil {
+		s, err := structpb.NewStruct(defaults)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle defaults: %w", err)
+		}
+
+		b.Defaults = s
+	}
+
+	for i, entry := range entries {
+		object, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("record %d is not an object", i)
+		}
+
+		s, err := structpb.NewStruct(object)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+
+		b.Entries = append(b.Entries, s)
+	}
+
+	return b, nil
+}
+
+// decodeDocuments decodes the JSON values or YAML documents of data.
+func decodeDocuments(data []byte) ([]any, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("bundle is empty")
+	}
+
+	var docs []any
+
+	if data[0] == '{' || data[0] == '[' {
+		dec := json.NewDecoder(bytes.NewReader(data))
+
+		for {
+			var doc any
+
+			err := dec.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("invalid JSON in bundle: %w", err)
+			}
+
+			docs = append(docs, doc)
+		}
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var node yaml.Node
+
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML in bundle: %w", err)
+		}
+
+		doc, err := yamlValue(&node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML in bundle: %w", err)
+		}
+
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// yamlValue converts a YAML node to the values structpb accepts. Timestamps
+// keep their text instead of becoming time.Time values.
+func yamlValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+
+		return yamlValue(node.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(node.Alias)
+	case yaml.MappingNode:
+		object := make(map[string]any, len(node.Content)/2) //nolint:mnd
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			object[node.Content[i].Value] = value
+		}
+
+		return object, nil
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(node.Content))
+
+		for _, item := range node.Content {
+			value, err := yamlValue(item)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		return list, nil
+	default:
+		if node.Tag == "!!timestamp" {
+			return node.Value, nil
+		}
+
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return value, nil
+	}
+}
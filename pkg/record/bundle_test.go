@@ -0,0 +1,106 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+)
+
+func TestParseBundle(t *testing.T) {
+	for name, tt := range map[string]struct {
+		data   string
+		bundle bool
+		names  []string
+	}{
+		"single JSON record": {`{"name": "a"}`, false, []string{"a"}},
+		"JSON array":         {`[{"name": "a"}, {"name": "b"}]`, true, []string{"a", "b"}},
+		"NDJSON":             {"{\"name\": \"a\"}\n{\"name\": \"b\"}\n", true, []string{"a", "b"}},
+		"JSON bundle":        {`{"defaults": {"version": "v1"}, "records": [{"name": "a"}]}`, true, []string{"a"}},
+		"YAML list":          {"- name: a\n- name: b\n", true, []string{"a", "b"}},
+		"YAML documents":     {"name: a\n---\nname: b\n", true, []string{"a", "b"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := record.IsBundle([]byte(tt.data)); got != tt.bundle {
+				t.Errorf("IsBundle = %v, want %v", got, tt.bundle)
+			}
+
+			bundle, err := record.ParseBundle([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseBundle: %v", err)
+			}
+
+			records, err := bundle.Records()
+			if err != nil {
+				t.Fatalf("Records: %v", err)
+			}
+
+			if len(records) != len(tt.names) {
+				t.Fatalf("got %d records, want %d", len(records), len(tt.names))
+			}
+
+			for i, rec := range records {
+				if got := rec.GetFields()["name"].GetStringValue(); got != tt.names[i] {
+					t.Errorf("record %d name = %q, want %q", i, got, tt.names[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBundleDefaults(t *testing.T) {
+	bundle, err := record.ParseBundle([]byte(`
+defaults:
+  schema_version: 1.0.0
+  created_at: 2026-01-01T00:00:00Z
+  skills: [{name: natural_language_processing/summarization}]
+records:
+  - name: example.org/a
+    skills: [{name: natural_language_processing/translation}]
+  - name: example.org/b
+    schema_version: 0.8.0
+`))
+	if err != nil {
+		t.Fatalf("ParseBundle: %v", err)
+	}
+
+	if len(bundle.Entries) != 2 || len(bundle.Entries[1].GetFields()) != 2 {
+		t.Fatalf("expected the entries without defaults, got %v", bundle.Entries)
+	}
+
+	records, err := bundle.Records()
+	if err != nil {
+		t.Fatalf("Records: %v", err)
+	}
+
+	a, b := records[0].GetFields(), records[1].GetFields()
+
+	if a["schema_version"].GetStringValue() != "1.0.0" || a["created_at"].GetStringValue() != "2026-01-01T00:00:00Z" {
+		t.Errorf("expected the defaults to be applied, got %v", records[0])
+	}
+
+	if len(a["skills"].GetListValue().GetValues()) != 2 {
+		t.Errorf("expected the skills to be merged, got %v", a["skills"])
+	}
+
+	if b["schema_version"].GetStringValue() != "0.8.0" {
+		t.Errorf("expected the record to override the defaults, got %v", records[1])
+	}
+}
+
+func TestParseBundleErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":            "",
+		"not an object":    `[1]`,
+		"records not list": `{"records": {}}`,
+		"bad defaults":     `{"defaults": [], "records": []}`,
+		"broken JSON":      `[{"name": }]`,
+		"broken YAML":      "name: [a",
+	} {
+		if _, err := record.ParseBundle([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Clone returns a deep copy of the record that shares no nested values with
+// it, so either can be modified without affecting the other.
+func Clone(record *structpb.Struct) *structpb.Struct {
+	if record == nil {
+		return nil
+	}
+
+	cloned, _ := proto.Clone(record).(*structpb.Struct)
+
+	return cloned
+}
+
+// CloneValue returns a deep copy of a value.
+func CloneValue(value *structpb.Value) *structpb.Value {
+	if value == nil {
+		return nil
+	}
+
+	cloned, _ := proto.Clone(value).(*structpb.Value)
+
+	return cloned
+}
+
+// Update applies mutate to a copy of the record and returns the copy; the
+// input is never modified, also when mutate fails.
+func Update(record *structpb.Struct, mutate func(*structpb.Struct) error) (*structpb.Struct, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	updated := Clone(record)
+	if err := mutate(updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// SetField returns a copy of the record with value set at the RFC 6901 JSON
+// pointer (e.g. "/annotations/team" or "/skills/-"), with the semantics of a
+// JSON Patch "add": object members are created or replaced, list items are
+// inserted. The parent must exist. value is converted with structpb.NewValue;
+// *structpb.Value and *structpb.Struct values are copied, never aliased.
+func SetField(record *structpb.Struct, pointer string, value any) (*structpb.Struct, error) {
+	v, err := fieldValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return nil, errors.New("cannot replace the whole record")
+	}
+
+	return Update(record, func(updated *structpb.Struct) error {
+		_, err := addValue(structpb.NewStructValue(updated), path, v)
+
+		return err
+	})
+}
+
+// DeleteField returns a copy of the record without the value at the RFC 6901
+// JSON pointer. It fails when there is no such value.
+func DeleteField(record *structpb.Struct, pointer string) (*structpb.Struct, error) {
+	path, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole record")
+	}
+
+	return Update(record, func(updated *structpb.Struct) error {
+		_, err := removeValue(structpb.NewStructValue(updated), path)
+
+		return err
+	})
+}
+
+func fieldValue(value any) (*structpb.Value, error) {
+	switch v := value.(type) {
+	case *structpb.Value:
+		return CloneValue(v), nil
+	case *structpb.Struct:
+		return structpb.NewStructValue(Clone(v)), nil
+	}
+
+	v, err := structpb.NewValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	return v, nil
+}
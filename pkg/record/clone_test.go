@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestClone(t *testing.T) {
+	rec := makeRecord(t, []any{map[string]any{"name": "integration/mcp", "data": map[string]any{"name": "a"}}})
+	cloned := record.Clone(rec)
+
+	if !proto.Equal(rec, cloned) {
+		t.Fatal("clone differs from the record")
+	}
+
+	_, module := record.FindModule(cloned, "integration/mcp")
+	module.GetFields()["data"].GetStructValue().Fields["name"] = structpb.NewStringValue("b")
+
+	_, original := record.FindModule(rec, "integration/mcp")
+	if got := original.GetFields()["data"].GetStructValue().GetFields()["name"].GetStringValue(); got != "a" {
+		t.Errorf("modifying the clone changed the record: name = %q", got)
+	}
+
+	if record.Clone(nil) != nil || record.CloneValue(nil) != nil {
+		t.Error("cloning nil must return nil")
+	}
+}
+
+func TestSetAndDeleteField(t *testing.T) {
+	rec := makeRecord(t, []any{})
+	team := &structpb.Struct{Fields: map[string]*structpb.Value{"team": structpb.NewStringValue("search")}}
+
+	updated, err := record.SetField(rec, "/annotations", team)
+	if err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+
+	if updated, err = record.SetField(updated, "/modules/-", map[string]any{"name": "integration/a2a"}); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+
+	team.Fields["team"] = structpb.NewStringValue("changed")
+
+	if got := updated.GetFields()["annotations"].GetStructValue().GetFields()["team"].GetStringValue(); got != "search" {
+		t.Errorf("SetField aliased its value: team = %q", got)
+	}
+
+	if _, ok := rec.GetFields()["annotations"]; ok || len(rec.GetFields()["modules"].GetListValue().GetValues()) != 0 {
+		t.Errorf("SetField modified its input: %v", rec.AsMap())
+	}
+
+	if !record.HasModule(updated, "integration/a2a") {
+		t.Errorf("module not appended: %v", updated.AsMap())
+	}
+
+	deleted, err := record.DeleteField(updated, "/annotations/team")
+	if err != nil || len(deleted.GetFields()["annotations"].GetStructValue().GetFields()) != 0 {
+		t.Errorf("DeleteField: %v %v", err, deleted.AsMap())
+	}
+
+	for name, err := range map[string]error{
+		"missing parent": func() error { _, err := record.SetField(rec, "/missing/field", 1); return err }(),
+		"missing field":  func() error { _, err := record.DeleteField(rec, "/missing"); return err }(),
+		"whole record":   func() error { _, err := record.SetField(rec, "", 1); return err }(),
+		"bad pointer":    func() error { _, err := record.DeleteField(rec, "name"); return err }(),
+	} {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	rec := makeRecord(t, []any{})
+	errStop := errors.New("stop")
+
+	if _, err := record.Update(rec, func(r *structpb.Struct) error {
+		r.Fields["name"] = structpb.NewStringValue("changed")
+
+		return errStop
+	}); !errors.Is(err, errStop) {
+		t.Errorf("expected the mutation error, got %v", err)
+	}
+
+	if _, ok := rec.GetFields()["name"]; ok {
+		t.Error("Update modified its input")
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxSuggestions caps the classes suggested for a piece of unmapped text.
+const maxSuggestions = 3
+
+// CoverageReport is how a set of records covers the skill and domain
+// taxonomies, as returned by Coverage.
+type CoverageReport struct {
+	Records int              `json:"records" yaml:"records"`
+	Skills  TaxonomyCoverage `json:"skills"  yaml:"skills"`
+	Domains TaxonomyCoverage `json:"domains" yaml:"domains"`
+	// Unmapped lists the description sentences no skill or domain of their
+	// record accounts for, with the classes they may map to.
+	Unmapped []UnmappedText `json:"unmapped,omitempty" yaml:"unmapped,omitempty"`
+}
+
+// TaxonomyCoverage is the coverage of one taxonomy.
+type TaxonomyCoverage struct {
+	// Classes is the number of classes of the taxonomy, Used the number of
+	// them used by at least one record.
+	Classes    int                `json:"classes"    yaml:"classes"`
+	Used       int                `json:"used"       yaml:"used"`
+	Categories []CategoryCoverage `json:"categories" yaml:"categories"`
+	// Unknown lists the classes used by records that the taxonomy does not
+	// define, e.g. classes of another schema version.
+	Unknown []string `json:"unknown,omitempty" yaml:"unknown,omitempty"`
+}
+
+// CategoryCoverage is the coverage of a top-level category of a taxonomy.
+type CategoryCoverage struct {
+	ID      int    `json:"id"      yaml:"id"`
+	Name    string `json:"name"    yaml:"name"`
+	Caption string `json:"caption" yaml:"caption"`
+	Classes int    `json:"classes" yaml:"classes"`
+	Used    int    `json:"used"    yaml:"used"`
+	// Records is the number of records using a class of the category.
+	Records int `json:"records" yaml:"records"`
+	// Usage counts the records using each used class, most used first.
+	Usage []ClassUsage `json:"usage,omitempty" yaml:"usage,omitempty"`
+}
+
+// ClassUsage is the number of records using a class.
+type ClassUsage struct {
+	Name    string `json:"name"    yaml:"name"`
+	Records int    `json:"records" yaml:"records"`
+}
+
+// UnmappedText is a description sentence of a record that none of its
+// skills or domains accounts for.
+type UnmappedText struct {
+	Record string `json:"record" yaml:"record"`
+	Text   string `json:"text"   yaml:"text"`
+	// Suggestions are unclaimed skill and domain classes the text mentions.
+	Suggestions []string `json:"suggestions,omitempty" yaml:"suggestions,omitempty"`
+}
+
+// taxonomyClass is a concrete class of a taxonomy.
+type taxonomyClass struct {
+	id       int
+	name     string
+	category string
+	stems    map[string]struct{}
+}
+
+// taxonomyIndex holds the categories and classes of a taxonomy.
+type taxonomyIndex struct {
+	categories []schema.TaxonomyItem
+	keys       []string
+	classes    []*taxonomyClass
+	byID       map[int]*taxonomyClass
+	byName     map[string]*taxonomyClass
+}
+
+func newTaxonomyIndex(t schema.Taxonomy) *taxonomyIndex {
+	idx := &taxonomyIndex{byID: map[int]*taxonomyClass{}, byName: map[string]*taxonomyClass{}}
+
+	var walk func(category string, items map[string]schema.TaxonomyItem)
+
+	walk = func(category string, items map[string]schema.TaxonomyItem) {
+		for _, it := range items {
+			if !it.Category && !it.Deprecated && it.ID != 0 {
+				c := &taxonomyClass{id: it.ID, name: it.Name, category: category, stems: stems(it.Caption + " " + it.Name)}
+				idx.classes = append(idx.classes, c)
+				idx.byID[c.id] = c
+				idx.byName[c.name] = c
+			}
+
+			walk(category, it.Classes)
+		}
+	}
+
+	for key, category := range t {
+		idx.keys = append(idx.keys, key)
+		idx.categories = append(idx.categories, category)
+		walk(key, category.Classes)
+	}
+
+	sort.Slice(idx.classes, func(i, j int) bool { return idx.classes[i].id < idx.classes[j].id })
+
+	return idx
+}
+
+// lookup returns the class of a record skill or domain entry, by ID then by
+// name.
+func (idx *taxonomyIndex) lookup(entry *structpb.Struct) (*taxonomyClass, string) {
+	name := entry.GetFields()["name"].GetStringValue()
+
+	if id := int(entry.GetFields()["id"].GetNumberValue()); id != 0 {
+		if c, ok := idx.byID[id]; ok {
+			return c, c.name
+		}
+	}
+
+	if c, ok := idx.byName[name]; ok {
+		return c, name
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("#%d", int(entry.GetFields()["id"].GetNumberValue()))
+	}
+
+	return nil, name
+}
+
+// Coverage aggregates the skills and domains of the records and reports how
+// they cover the skill and domain taxonomies, e.g. as returned by
+// schema.Schema.GetSchemaSkills. Every top-level category of the taxonomies
+// is reported, used or not. Description sentences sharing no word with the
+// skills and domains of their record are reported as unmapped, with the
+// classes they mention.
+func Coverage(records []*structpb.Struct, skills, domains schema.Taxonomy) *CoverageReport {
+	skillIndex, domainIndex := newTaxonomyIndex(skills), newTaxonomyIndex(domains)
+	skillUsage, domainUsage := map[string]map[int]bool{}, map[string]map[int]bool{}
+	report := &CoverageReport{Records: len(records)}
+
+	var unknownSkills, unknownDomains []string
+
+	for i, rec := range records {
+		claimed := map[*taxonomyClass]bool{}
+		vocabulary := stems(rec.GetFields()["name"].GetStringValue())
+
+		for _, field := range []struct {
+			name    string
+			index   *taxonomyIndex
+			usage   map[string]map[int]bool
+			unknown *[]string
+		}{
+			{"skills", skillIndex, skillUsage, &unknownSkills},
+			{"domains", domainIndex, domainUsage, &unknownDomains},
+		} {
+			for _, v := range rec.GetFields()[field.name].GetListValue().GetValues() {
+				c, name := field.index.lookup(v.GetStructValue())
+				for stem := range stems(strings.ReplaceAll(name, "_", " ")) {
+					vocabulary[stem] = struct{}{}
+				}
+
+				if c == nil {
+					if !slices.Contains(*field.unknown, name) {
+						*field.unknown = append(*field.unknown, name)
+					}
+
+					continue
+				}
+
+				claimed[c] = true
+
+				for stem := range c.stems {
+					vocabulary[stem] = struct{}{}
+				}
+
+				if field.usage[c.name] == nil {
+					field.usage[c.name] = map[int]bool{}
+				}
+
+				field.usage[c.name][i] = true
+			}
+		}
+
+		report.Unmapped = append(report.Unmapped, unmappedText(rec, i, vocabulary, claimed, skillIndex, domainIndex)...)
+	}
+
+	report.Skills = taxonomyCoverage(skillIndex, skillUsage, unknownSkills)
+	report.Domains = taxonomyCoverage(domainIndex, domainUsage, unknownDomains)
+
+	return report
+}
+
+func taxonomyCoverage(idx *taxonomyIndex, usage map[string]map[int]bool, unknown []string) TaxonomyCoverage {
+	cov := TaxonomyCoverage{Classes: len(idx.classes), Unknown: unknown}
+	byCategory := map[string]*CategoryCoverage{}
+
+	for i, key := range idx.keys {
+		category := idx.categories[i]
+		byCategory[key] = &CategoryCoverage{ID: category.ID, Name: key, Caption: category.Caption}
+	}
+
+	categoryRecords := map[string]map[int]bool{}
+
+	for _, c := range idx.classes {
+		category := byCategory[c.category]
+		category.Classes++
+
+		records := usage[c.name]
+		if len(records) == 0 {
+			continue
+		}
+
+		cov.Used++
+		category.Used++
+		category.Usage = append(category.Usage, ClassUsage{Name: c.name, Records: len(records)})
+
+		if categoryRecords[c.category] == nil {
+			categoryRecords[c.category] = map[int]bool{}
+		}
+
+		for i := range records {
+			categoryRecords[c.category][i] = true
+		}
+	}
+
+	for key, category := range byCategory {
+		category.Records = len(categoryRecords[key])
+		sort.SliceStable(category.Usage, func(i, j int) bool { return category.Usage[i].Records > category.Usage[j].Records })
+		cov.Categories = append(cov.Categories, *category)
+	}
+
+	sort.Slice(cov.Categories, func(i, j int) bool {
+		if cov.Categories[i].ID != cov.Categories[j].ID {
+			return cov.Categories[i].ID < cov.Categories[j].ID
+		}
+
+		return cov.Categories[i].Name < cov.Categories[j].Name
+	})
+
+	return cov
+}
+
+// unmappedText returns the description sentences of the record sharing no
+// word with its vocabulary, the words of its name, skills and domains.
+func unmappedText(rec *structpb.Struct, index int, vocabulary map[string]struct{}, claimed map[*taxonomyClass]bool, indexes ...*taxonomyIndex) []UnmappedText {
+	name := rec.GetFields()["name"].GetStringValue()
+	if name == "" {
+		name = fmt.Sprintf("#%d", index)
+	}
+
+	var out []UnmappedText
+
+	for _, sentence := range sentences(rec.GetFields()["description"].GetStringValue()) {
+		words := stems(sentence)
+		if len(words) == 0 || overlaps(words, vocabulary) {
+			continue
+		}
+
+		out = append(out, UnmappedText{Record: name, Text: sentence, Suggestions: suggestions(words, claimed, indexes)})
+	}
+
+	return out
+}
+
+// suggestions returns the unclaimed classes sharing the most words with the
+// text, most shared first.
+func suggestions(words map[string]struct{}, claimed map[*taxonomyClass]bool, indexes []*taxonomyIndex) []string {
+	type match struct {
+		name  string
+		score int
+	}
+
+	var matches []match
+
+	for _, idx := range indexes {
+		for _, c := range idx.classes {
+			if claimed[c] {
+				continue
+			}
+
+			score := 0
+
+			for stem := range c.stems {
+				if _, ok := words[stem]; ok {
+					score++
+				}
+			}
+
+			if score > 0 {
+				matches = append(matches, match{c.name, score})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	names := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+
+	return names
+}
+
+// sentences splits text into trimmed sentences.
+func sentences(text string) []string {
+	var out []string
+
+	for _, s := range strings.FieldsFunc(text, func(r rune) bool { return r == '.' || r == '!' || r == '?' || r == '\n' || r == ';' }) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// stopWords are the words ignored when matching text to classes.
+var stopWords = map[string]bool{
+	"about": true, "agent": true, "also": true, "based": true, "from": true, "have": true, "into": true,
+	"other": true, "such": true, "that": true, "their": true, "them": true, "then": true, "there": true,
+	"these": true, "this": true, "using": true, "will": true, "with": true, "your": true, "which": true,
+	"when": true, "where": true, "while": true, "what": true, "more": true, "most": true, "over": true,
+}
+
+// minWordLength is the length of the shortest word matched.
+const minWordLength = 4
+
+// stemLength is the length words are cut to, so inflections such as
+// "summarize" and "summarization" match.
+const stemLength = 5
+
+// stems returns the significant words of text, cut to stemLength.
+func stems(text string) map[string]struct{} {
+	out := map[string]struct{}{}
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		runes := []rune(word)
+		if len(runes) < minWordLength || stopWords[word] {
+			continue
+		}
+
+		if len(runes) > stemLength {
+			runes = runes[:stemLength]
+		}
+
+		out[string(runes)] = struct{}{}
+	}
+
+	return out
+}
+
+func overlaps(a, b map[string]struct{}) bool {
+	for word := range a {
+		if _, ok := b[word]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	coverageSkills = schema.Taxonomy{
+		"natural_language_processing": {ID: 1, Caption: "Natural Language Processing", Category: true, Classes: map[string]schema.TaxonomyItem{
+			"summarization": {ID: 10201, Name: "natural_language_processing/summarization", Caption: "Text Summarization"},
+			"translation":   {ID: 10202, Name: "natural_language_processing/translation", Caption: "Translation"},
+		}},
+		"security_privacy": {ID: 8, Caption: "Security and Privacy", Category: true, Classes: map[string]schema.TaxonomyItem{
+			"threat_detection": {ID: 80101, Name: "security_privacy/threat_detection", Caption: "Threat Detection"},
+		}},
+	}
+	coverageDomains = schema.Taxonomy{
+		"finance": {ID: 2, Caption: "Finance", Category: true, Classes: map[string]schema.TaxonomyItem{
+			"banking": {ID: 201, Name: "finance/banking", Caption: "Banking"},
+		}},
+	}
+)
+
+func coverageRecord(t *testing.T, name, description string, skills ...any) *structpb.Struct {
+	t.Helper()
+
+	rec, err := structpb.NewStruct(map[string]any{"name": name, "description": description, "skills": skills})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec
+}
+
+func TestCoverage(t *testing.T) {
+	records := []*structpb.Struct{
+		coverageRecord(t, "example.org/summarizer", "Summarizes long documents. Detects threats in network logs.",
+			map[string]any{"id": 10201}),
+		coverageRecord(t, "example.org/translator", "Translates text between languages.",
+			map[string]any{"name": "natural_language_processing/translation"},
+			map[string]any{"name": "natural_language_processing/summarization"},
+			map[string]any{"name": "legacy/unknown_skill"}),
+	}
+
+	report := record.Coverage(records, coverageSkills, coverageDomains)
+
+	if report.Records != 2 || report.Skills.Classes != 3 || report.Skills.Used != 2 {
+		t.Fatalf("unexpected totals: %+v", report.Skills)
+	}
+
+	if len(report.Skills.Categories) != 2 {
+		t.Fatalf("got %d skill categories, want 2", len(report.Skills.Categories))
+	}
+
+	nlp, security := report.Skills.Categories[0], report.Skills.Categories[1]
+	if nlp.ID != 1 || nlp.Used != 2 || nlp.Records != 2 || nlp.Usage[0].Name != "natural_language_processing/summarization" || nlp.Usage[0].Records != 2 {
+		t.Errorf("unexpected NLP coverage: %+v", nlp)
+	}
+
+	if security.ID != 8 || security.Classes != 1 || security.Used != 0 {
+		t.Errorf("expected the unused category 8 to be reported, got %+v", security)
+	}
+
+	if !slices.Equal(report.Skills.Unknown, []string{"legacy/unknown_skill"}) {
+		t.Errorf("Unknown = %v", report.Skills.Unknown)
+	}
+
+	if report.Domains.Classes != 1 || report.Domains.Used != 0 {
+		t.Errorf("unexpected domain coverage: %+v", report.Domains)
+	}
+
+	if len(report.Unmapped) != 1 {
+		t.Fatalf("Unmapped = %+v, want the threat detection sentence only", report.Unmapped)
+	}
+
+	unmapped := report.Unmapped[0]
+	if unmapped.Record != "example.org/summarizer" || unmapped.Text != "Detects threats in network logs" {
+		t.Errorf("unexpected unmapped text: %+v", unmapped)
+	}
+
+	if !slices.Equal(unmapped.Suggestions, []string{"security_privacy/threat_detection"}) {
+		t.Errorf("Suggestions = %v", unmapped.Suggestions)
+	}
+}
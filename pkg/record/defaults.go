@@ -0,0 +1,247 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxDefaultsDepth bounds recursion through nested (or self-referencing)
+// schemas.
+const maxDefaultsDepth = 16
+
+// SchemaClient fetches OASF record JSON schemas. *schema.Schema implements it.
+type SchemaClient interface {
+	GetRecordJSONSchema(ctx context.Context, opts ...schema.SchemaOption) ([]byte, error)
+}
+
+// requiredLists are required by every OASF version and present in every
+// record FillDefaults or a Builder produces, even when empty.
+var requiredLists = []string{"skills", "domains", "modules"}
+
+// FillDefaults adds what the record JSON schema of the record's schema
+// version declares but the record leaves out: fields with a default value,
+// and required lists and objects, created empty. Nested objects, including
+// list items, are filled the same way. Values present in the record are never
+// changed.
+//
+// Without a client, only the lists required by every OASF version (skills,
+// domains and modules) are added.
+func FillDefaults(ctx context.Context, record *structpb.Struct, client SchemaClient) error {
+	if record == nil {
+		return nil
+	}
+
+	if record.Fields == nil {
+		record.Fields = map[string]*structpb.Value{}
+	}
+
+	if client == nil {
+		fillRequiredLists(record.GetFields())
+
+		return nil
+	}
+
+	var opts []schema.SchemaOption
+	if version := record.GetFields()["schema_version"].GetStringValue(); version != "" {
+		opts = append(opts, schema.WithSchemaVersion(version))
+	}
+
+	data, err := client.GetRecordJSONSchema(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to fetch record schema: %w", err)
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse record schema: %w", err)
+	}
+
+	return fillObject(record, root, root, 0)
+}
+
+func fillRequiredLists(fields map[string]*structpb.Value) {
+	for _, key := range requiredLists {
+		if _, ok := fields[key]; !ok {
+			fields[key] = structpb.NewListValue(&structpb.ListValue{})
+		}
+	}
+}
+
+func fillObject(object *structpb.Struct, objectSchema, root map[string]any, depth int) error {
+	objectSchema = resolveSchema(objectSchema, root)
+	if objectSchema == nil || depth > maxDefaultsDepth {
+		return nil
+	}
+
+	properties, _ := objectSchema["properties"].(map[string]any)
+	required := map[string]bool{}
+
+	if names, ok := objectSchema["required"].([]any); ok {
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propSchema, _ := properties[name].(map[string]any)
+		propSchema = resolveSchema(propSchema, root)
+
+		if propSchema == nil {
+			continue
+		}
+
+		value, ok := object.GetFields()[name]
+		if !ok {
+			var err error
+			if value, err = defaultValue(propSchema, required[name]); err != nil {
+				return fmt.Errorf("invalid default for %s: %w", name, err)
+			}
+
+			if value == nil {
+				continue
+			}
+
+			object.Fields[name] = value
+		}
+
+		if err := fillValue(value, propSchema, root, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fillValue fills an object value, or the object items of a list value.
+func fillValue(value *structpb.Value, valueSchema, root map[string]any, depth int) error {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return fillObject(kind.StructValue, valueSchema, root, depth)
+	case *structpb.Value_ListValue:
+		items, _ := valueSchema["items"].(map[string]any)
+		if items == nil {
+			return nil
+		}
+
+		for _, item := range kind.ListValue.GetValues() {
+			if item.GetStructValue() == nil {
+				continue
+			}
+
+			if err := fillObject(item.GetStructValue(), items, root, depth); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultValue returns the declared default of a missing property, or an
+// empty list or object when it is required, and nil otherwise.
+func defaultValue(propSchema map[string]any, required bool) (*structpb.Value, error) {
+	if def, ok := propSchema["default"]; ok {
+		return structpb.NewValue(def) //nolint:wrapcheck
+	}
+
+	if !required {
+		return nil, nil //nolint:nilnil
+	}
+
+	switch jsonSchemaType(propSchema) {
+	case "array":
+		return structpb.NewListValue(&structpb.ListValue{}), nil
+	case "object":
+		return structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{}}), nil
+	default:
+		return nil, nil //nolint:nilnil
+	}
+}
+
+// resolveSchema follows local "$ref"s ("#/$defs/...", "#/definitions/...")
+// and picks the first allOf entry declaring properties.
+func resolveSchema(s, root map[string]any) map[string]any {
+	for range maxDefaultsDepth {
+		if s == nil {
+			return nil
+		}
+
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			break
+		}
+
+		s = resolvePointer(root, ref)
+	}
+
+	if _, ok := s["properties"]; !ok {
+		if variants, ok := s["allOf"].([]any); ok {
+			for _, variant := range variants {
+				if v, ok := variant.(map[string]any); ok {
+					if v = resolveSchema(v, root); v["properties"] != nil {
+						return v
+					}
+				}
+			}
+		}
+	}
+
+	return s
+}
+
+func resolvePointer(root map[string]any, ref string) map[string]any {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+
+	var current any = root
+
+	for _, part := range strings.Split(pointer, "/") {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = node[strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")]
+	}
+
+	resolved, _ := current.(map[string]any)
+
+	return resolved
+}
+
+// jsonSchemaType returns the schema's type, picking the first non-null entry
+// when "type" is a list.
+func jsonSchemaType(s map[string]any) string {
+	switch t := s["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type fakeSchemaClient struct {
+	schema string
+	// opts counts the schema options of each call.
+	opts []int
+}
+
+func (c *fakeSchemaClient) GetRecordJSONSchema(_ context.Context, opts ...schema.SchemaOption) ([]byte, error) {
+	c.opts = append(c.opts, len(opts))
+
+	return []byte(c.schema), nil
+}
+
+const defaultsSchema = `{
+  "type": "object",
+  "required": ["name", "skills", "domains", "locators", "modules"],
+  "properties": {
+    "name": {"type": "string"},
+    "skills": {"type": "array"},
+    "domains": {"type": "array"},
+    "locators": {"type": "array", "items": {"$ref": "#/$defs/locator"}},
+    "modules": {"type": "array", "items": {"$ref": "#/$defs/module"}},
+    "previous_record_cid": {"type": "string"},
+    "license": {"type": "string", "default": "Apache-2.0"}
+  },
+  "$defs": {
+    "locator": {"type": "object", "required": ["urls"], "properties": {"urls": {"type": "array"}}},
+    "module": {
+      "allOf": [{"type": "object", "required": ["data"], "properties": {
+        "data": {"type": "object"},
+        "id": {"type": ["integer", "null"], "default": 0}
+      }}]
+    }
+  }
+}`
+
+func TestFillDefaults(t *testing.T) {
+	rec, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/agent",
+		"schema_version": "1.0.0",
+		"license":        "MIT",
+		"skills":         []any{map[string]any{"id": 10201}},
+		"locators":       []any{map[string]any{"type": "source_code"}},
+		"modules":        []any{map[string]any{"name": "integration/mcp"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &fakeSchemaClient{schema: defaultsSchema}
+	if err := record.FillDefaults(t.Context(), rec, client); err != nil {
+		t.Fatalf("FillDefaults: %v", err)
+	}
+
+	got, _ := json.Marshal(rec.AsMap())
+	want := `{"domains":[],"license":"MIT","locators":[{"type":"source_code","urls":[]}],` +
+		`"modules":[{"data":{},"id":0,"name":"integration/mcp"}],"name":"example.org/agent",` +
+		`"schema_version":"1.0.0","skills":[{"id":10201}]}`
+
+	if string(got) != want {
+		t.Errorf("unexpected record:\n got %s\nwant %s", got, want)
+	}
+
+	if len(client.opts) != 1 || client.opts[0] != 1 {
+		t.Errorf("expected one fetch for the record's schema version, got %v", client.opts)
+	}
+}
+
+func TestFillDefaultsWithoutClient(t *testing.T) {
+	rec, _ := structpb.NewStruct(map[string]any{"name": "example.org/agent", "skills": []any{"x"}})
+
+	if err := record.FillDefaults(t.Context(), rec, nil); err != nil {
+		t.Fatalf("FillDefaults: %v", err)
+	}
+
+	got, _ := json.Marshal(rec.AsMap())
+	if string(got) != `{"domains":[],"modules":[],"name":"example.org/agent","skills":["x"]}` {
+		t.Errorf("unexpected record: %s", got)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Exclusions lists top-level record fields left out of the canonical form,
+// e.g. fields that change without changing what the record describes.
+type Exclusions []string
+
+// DefaultExclusions leaves out the creation time and the signature, so a
+// record re-created or re-signed with the same content keeps its digest.
+// Use it to cache or deduplicate records by content.
+var DefaultExclusions = Exclusions{"created_at", "signature"}
+
+// CanonicalJSON serializes a record as compact JSON with sorted keys and
+// without the excluded fields, so equal records always produce the same
+// bytes. The record is not modified.
+func CanonicalJSON(record *structpb.Struct, exclusions Exclusions) ([]byte, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	fields := record.AsMap()
+	for _, field := range exclusions {
+		delete(fields, field)
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	return data, nil
+}
+
+// Digest returns the "sha256:<hex>" digest of the record's canonical JSON
+// (see CanonicalJSON).
+func Digest(record *structpb.Struct, exclusions Exclusions) (string, error) {
+	data, err := CanonicalJSON(record, exclusions)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
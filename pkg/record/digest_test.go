@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	rec, err := structpb.NewStruct(map[string]any{
+		"version":    "v1.0.0",
+		"name":       "example.org/agent",
+		"created_at": "2025-01-01T00:00:00Z",
+		"skills":     []any{map[string]any{"name": "b", "id": 2}},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	data, err := record.CanonicalJSON(rec, record.Exclusions{"created_at"})
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+
+	want := `{"name":"example.org/agent","skills":[{"id":2,"name":"b"}],"version":"v1.0.0"}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+
+	if _, ok := rec.GetFields()["created_at"]; !ok {
+		t.Error("CanonicalJSON modified the record")
+	}
+
+	if _, err := record.CanonicalJSON(nil, nil); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	newRecord := func(createdAt string) *structpb.Struct {
+		rec, err := structpb.NewStruct(map[string]any{
+			"name":       "example.org/agent",
+			"created_at": createdAt,
+			"signature":  map[string]any{"signature": createdAt},
+		})
+		if err != nil {
+			t.Fatalf("NewStruct: %v", err)
+		}
+
+		return rec
+	}
+
+	a, err := record.Digest(newRecord("2025-01-01T00:00:00Z"), record.DefaultExclusions)
+	if err != nil {
+		t.Fatalf("Digest: %v", err)
+	}
+
+	b, _ := record.Digest(newRecord("2025-06-01T00:00:00Z"), record.DefaultExclusions)
+	if a != b || !strings.HasPrefix(a, "sha256:") || len(a) != len("sha256:")+64 {
+		t.Errorf("expected equal sha256 digests, got %s and %s", a, b)
+	}
+
+	c, _ := record.Digest(newRecord("2025-06-01T00:00:00Z"), nil)
+	if c == a {
+		t.Error("expected excluded fields to change the digest when not excluded")
+	}
+}
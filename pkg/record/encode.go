@@ -0,0 +1,175 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// keyOrder lists the keys written first, in this order, at every level of a
+// YAML record, so identifying fields lead each object. Other keys follow
+// sorted.
+var keyOrder = []string{
+	"name", "id", "type", "version", "schema_version", "description", "authors", "created_at",
+	"annotations", "skills", "domains", "locators", "modules", "previous_record_cid", "signature",
+}
+
+// MarshalYAML encodes a record as YAML with a stable key order: identifying
+// fields (name, version, schema_version, ...) first, then the remaining keys
+// sorted, so the same record always produces the same document and diffs of
+// reviewed records stay small. Integral numbers are written without a
+// fraction.
+func MarshalYAML(record *structpb.Struct) ([]byte, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2) //nolint:mnd
+
+	if err := enc.Encode(yamlNode(structpb.NewStructValue(record))); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// MarshalTOML encodes a record as TOML with sorted keys. TOML has no null,
+// so null values are left out; integral numbers are written as integers.
+func MarshalTOML(record *structpb.Struct) ([]byte, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	var buf bytes.Buffer
+
+	enc := toml.NewEncoder(&buf)
+	enc.SetIndentTables(true)
+
+	if err := enc.Encode(tomlValue(structpb.NewStructValue(record))); err != nil {
+		return nil, fmt.Errorf("failed to encode TOML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func yamlNode(value *structpb.Value) *yaml.Node {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for _, key := range orderedKeys(kind.StructValue.GetFields()) {
+			node.Content = append(node.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+				yamlNode(kind.StructValue.GetFields()[key]))
+		}
+
+		return node
+	case *structpb.Value_ListValue:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range kind.ListValue.GetValues() {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+
+		return node
+	case *structpb.Value_StringValue:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: kind.StringValue}
+	case *structpb.Value_NumberValue:
+		if n, ok := integral(kind.NumberValue); ok {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.FormatInt(n, 10)}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(kind.NumberValue, 'g', -1, 64)}
+	case *structpb.Value_BoolValue:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(kind.BoolValue)}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
+
+func tomlValue(value *structpb.Value) any {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		out := make(map[string]any, len(kind.StructValue.GetFields()))
+
+		for key, field := range kind.StructValue.GetFields() {
+			if v := tomlValue(field); v != nil {
+				out[key] = v
+			}
+		}
+
+		return out
+	case *structpb.Value_ListValue:
+		out := make([]any, 0, len(kind.ListValue.GetValues()))
+
+		for _, item := range kind.ListValue.GetValues() {
+			if v := tomlValue(item); v != nil {
+				out = append(out, v)
+			}
+		}
+
+		return out
+	case *structpb.Value_StringValue:
+		return kind.StringValue
+	case *structpb.Value_NumberValue:
+		if n, ok := integral(kind.NumberValue); ok {
+			return n
+		}
+
+		return kind.NumberValue
+	case *structpb.Value_BoolValue:
+		return kind.BoolValue
+	default:
+		return nil
+	}
+}
+
+// orderedKeys returns the keys of fields in keyOrder first, then the rest
+// sorted.
+func orderedKeys(fields map[string]*structpb.Value) []string {
+	keys := make([]string, 0, len(fields))
+
+	for _, key := range keyOrder {
+		if _, ok := fields[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+
+	rest := make([]string, 0, len(fields)-len(keys))
+
+	for key := range fields {
+		if !slices.Contains(keyOrder, key) {
+			rest = append(rest, key)
+		}
+	}
+
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// integral reports whether f is a whole number that fits an int64 exactly.
+func integral(f float64) (int64, bool) {
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, false
+	}
+
+	return int64(f), true
+}
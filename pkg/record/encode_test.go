@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func encodeFixture(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	rec, err := structpb.NewStruct(map[string]any{
+		"version":        "v1.0.0",
+		"name":           "example.org/agent",
+		"schema_version": "1.0.0",
+		"skills":         []any{map[string]any{"name": "nlp", "id": 10201}},
+		"annotations":    map[string]any{"team": "x"},
+		"extra":          nil,
+		"score":          0.5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec
+}
+
+func TestMarshalYAML(t *testing.T) {
+	got, err := record.MarshalYAML(encodeFixture(t))
+	if err != nil {
+		t.Fatalf("MarshalYAML: %v", err)
+	}
+
+	want := `name: example.org/agent
+version: v1.0.0
+schema_version: 1.0.0
+annotations:
+  team: x
+skills:
+  - name: nlp
+    id: 10201
+extra: null
+score: 0.5
+`
+	if string(got) != want {
+		t.Errorf("unexpected YAML:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarshalTOML(t *testing.T) {
+	got, err := record.MarshalTOML(encodeFixture(t))
+	if err != nil {
+		t.Fatalf("MarshalTOML: %v", err)
+	}
+
+	want := `name = 'example.org/agent'
+schema_version = '1.0.0'
+score = 0.5
+version = 'v1.0.0'
+
+[annotations]
+  team = 'x'
+
+[[skills]]
+  id = 10201
+  name = 'nlp'
+`
+	if string(got) != want {
+		t.Errorf("unexpected TOML:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"path"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// EqualOption configures Equal.
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	ignore []string
+}
+
+// IgnoreFields leaves fields out of the comparison. Fields are named by their
+// dotted path from the record root, e.g. "created_at" or "locators.urls";
+// list items do not add a path segment. '*' matches any run of characters,
+// dots included, so "annotations.oasf.sdk.*" ignores the SDK's internal
+// annotations and "*.created_at" a created_at field at any depth below the
+// root.
+func IgnoreFields(paths ...string) EqualOption {
+	return func(opts *equalOptions) {
+		opts.ignore = append(opts.ignore, paths...)
+	}
+}
+
+// Equal reports whether two records hold the same content, apart from the
+// ignored fields. Key order does not matter; list order does. Two nil records
+// are equal.
+func Equal(a, b *structpb.Struct, opts ...EqualOption) bool {
+	options := &equalOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if len(options.ignore) == 0 {
+		return proto.Equal(a, b)
+	}
+
+	return proto.Equal(pruneStruct(a, "", options.ignore), pruneStruct(b, "", options.ignore))
+}
+
+// pruneStruct returns a copy of s without the fields matching ignore.
+func pruneStruct(s *structpb.Struct, prefix string, ignore []string) *structpb.Struct {
+	out := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(s.GetFields()))}
+
+	for key, value := range s.GetFields() {
+		fieldPath := prefix + key
+		if ignored(fieldPath, ignore) {
+			continue
+		}
+
+		out.Fields[key] = pruneValue(value, fieldPath, ignore)
+	}
+
+	return out
+}
+
+func pruneValue(value *structpb.Value, fieldPath string, ignore []string) *structpb.Value {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return structpb.NewStructValue(pruneStruct(kind.StructValue, fieldPath+".", ignore))
+	case *structpb.Value_ListValue:
+		items := make([]*structpb.Value, 0, len(kind.ListValue.GetValues()))
+		for _, item := range kind.ListValue.GetValues() {
+			items = append(items, pruneValue(item, fieldPath, ignore))
+		}
+
+		return structpb.NewListValue(&structpb.ListValue{Values: items})
+	default:
+		return value
+	}
+}
+
+func ignored(fieldPath string, ignore []string) bool {
+	for _, pattern := range ignore {
+		// path.Match treats '.' as an ordinary character, so '*' spans
+		// dotted segments; malformed patterns never match.
+		if ok, _ := path.Match(pattern, fieldPath); ok {
+			return true
+		}
+	}
+
+	return false
+}
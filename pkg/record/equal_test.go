@@ -0,0 +1,51 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestEqual(t *testing.T) {
+	newRecord := func(createdAt, internal, skill string) *structpb.Struct {
+		rec, err := structpb.NewStruct(map[string]any{
+			"name":        "example.org/agent",
+			"created_at":  createdAt,
+			"annotations": map[string]any{"team": "search", "oasf.sdk.imported_at": internal},
+			"skills":      []any{map[string]any{"name": skill, "created_at": createdAt}},
+		})
+		if err != nil {
+			t.Fatalf("NewStruct: %v", err)
+		}
+
+		return rec
+	}
+
+	a := newRecord("2025-01-01T00:00:00Z", "1", "search")
+	b := newRecord("2025-06-01T00:00:00Z", "2", "search")
+
+	if record.Equal(a, b) {
+		t.Error("records with different created_at must differ without IgnoreFields")
+	}
+
+	if record.Equal(a, b, record.IgnoreFields("created_at", "annotations.oasf.sdk.*")) {
+		t.Error("nested created_at must only be ignored by a matching pattern")
+	}
+
+	ignore := record.IgnoreFields("created_at", "*.created_at", "annotations.oasf.sdk.*")
+	if !record.Equal(a, b, ignore) {
+		t.Error("records differing only in ignored fields must be equal")
+	}
+
+	if record.Equal(a, newRecord("2025-01-01T00:00:00Z", "1", "summarize"), ignore) {
+		t.Error("records with different skills must differ")
+	}
+
+	if !record.Equal(nil, nil) || record.Equal(a, nil) {
+		t.Error("nil records are only equal to each other")
+	}
+}
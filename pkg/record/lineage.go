@@ -0,0 +1,185 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// previousRecordField links 0.7.0 and 0.8.0 records to their predecessor.
+	previousRecordField = "previous_record_cid"
+	// LineageAnnotation links 1.0.0 records, which no longer have a
+	// previous_record_cid field, to their predecessor.
+	LineageAnnotation = "lineage.previous_record_cid"
+)
+
+// defaultMaxHistory bounds History when WithMaxHistory is not given.
+const defaultMaxHistory = 1000
+
+// ErrBrokenLineage is returned (wrapped, with details) when a record does not
+// link to the record before it.
+var ErrBrokenLineage = errors.New("broken record lineage")
+
+// LineageOption configures the lineage helpers.
+type LineageOption func(*lineageOptions)
+
+type lineageOptions struct {
+	id         func(*structpb.Struct) (string, error)
+	maxHistory int
+}
+
+// WithRecordID sets how records are identified, e.g. by the CID a directory
+// assigns. The default is RecordDigest.
+func WithRecordID(id func(*structpb.Struct) (string, error)) LineageOption {
+	return func(opts *lineageOptions) {
+		opts.id = id
+	}
+}
+
+// WithMaxHistory bounds how many predecessors History walks.
+func WithMaxHistory(n int) LineageOption {
+	return func(opts *lineageOptions) {
+		opts.maxHistory = n
+	}
+}
+
+func newLineageOptions(opts []LineageOption) *lineageOptions {
+	options := &lineageOptions{id: RecordDigest, maxHistory: defaultMaxHistory}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// RecordDigest identifies a record version by the digest of its content
+// without the signature. Unlike DefaultExclusions it covers created_at and
+// the predecessor link, so every version of a chain has its own identifier.
+func RecordDigest(record *structpb.Struct) (string, error) {
+	return Digest(record, Exclusions{"signature"})
+}
+
+// PreviousID returns the identifier of the record's predecessor, read from
+// previous_record_cid or the LineageAnnotation, and "" for the first version.
+func PreviousID(record *structpb.Struct) string {
+	if id := record.GetFields()[previousRecordField].GetStringValue(); id != "" {
+		return id
+	}
+
+	id, _ := annotations.Get(record, LineageAnnotation)
+
+	return id
+}
+
+// SetPreviousID returns a copy of the record linked to the predecessor with
+// the given identifier: in previous_record_cid for 0.7.0 and 0.8.0 records,
+// in the LineageAnnotation otherwise.
+func SetPreviousID(record *structpb.Struct, id string) (*structpb.Struct, error) {
+	if id == "" {
+		return nil, errors.New("previous record id is empty")
+	}
+
+	return Update(record, func(updated *structpb.Struct) error {
+		switch updated.GetFields()["schema_version"].GetStringValue() {
+		case "0.7.0", "0.8.0":
+			updated.Fields[previousRecordField] = structpb.NewStringValue(id)
+
+			return nil
+		default:
+			return annotations.Set(updated, LineageAnnotation, id) //nolint:wrapcheck
+		}
+	})
+}
+
+// LinkPrevious returns a copy of the record linked to previous.
+func LinkPrevious(record, previous *structpb.Struct, opts ...LineageOption) (*structpb.Struct, error) {
+	if previous == nil {
+		return nil, errors.New("previous record is nil")
+	}
+
+	id, err := newLineageOptions(opts).id(previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify previous record: %w", err)
+	}
+
+	return SetPreviousID(record, id)
+}
+
+// VerifyChain checks that records, oldest first, form a chain: every record
+// after the first links to the one before it.
+func VerifyChain(records []*structpb.Struct, opts ...LineageOption) error {
+	options := newLineageOptions(opts)
+
+	for i := 1; i < len(records); i++ {
+		id, err := options.id(records[i-1])
+		if err != nil {
+			return fmt.Errorf("failed to identify record %d: %w", i-1, err)
+		}
+
+		if previous := PreviousID(records[i]); previous != id {
+			return fmt.Errorf("%w: record %d links to %q, want %q", ErrBrokenLineage, i, previous, id)
+		}
+	}
+
+	return nil
+}
+
+// History walks the lineage of record backwards, fetching each predecessor
+// with lookup, and returns the chain oldest first, ending with record. Every
+// fetched record must have the identifier it was looked up by; cycles and
+// chains longer than WithMaxHistory are reported.
+func History(
+	ctx context.Context,
+	record *structpb.Struct,
+	lookup func(ctx context.Context, id string) (*structpb.Struct, error),
+	opts ...LineageOption,
+) ([]*structpb.Struct, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	options := newLineageOptions(opts)
+	chain := []*structpb.Struct{record}
+	seen := map[string]bool{}
+
+	for id := PreviousID(record); id != ""; id = PreviousID(chain[len(chain)-1]) {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: cycle at %q", ErrBrokenLineage, id)
+		}
+
+		if len(chain) > options.maxHistory {
+			return nil, fmt.Errorf("lineage is longer than %d records", options.maxHistory)
+		}
+
+		seen[id] = true
+
+		previous, err := lookup(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up record %q: %w", id, err)
+		}
+
+		got, err := options.id(previous)
+		if err != nil {
+			return nil, fmt.Errorf("failed to identify record %q: %w", id, err)
+		}
+
+		if got != id {
+			return nil, fmt.Errorf("%w: looked up %q but got a record with id %q", ErrBrokenLineage, id, got)
+		}
+
+		chain = append(chain, previous)
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,152 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// lineage returns three linked versions of a record, oldest first, and a
+// lookup serving them by digest.
+func lineage(t *testing.T) ([]*structpb.Struct, func(context.Context, string) (*structpb.Struct, error)) {
+	t.Helper()
+
+	store := map[string]*structpb.Struct{}
+	chain := make([]*structpb.Struct, 0, 3)
+
+	for _, version := range []string{"v1.0.0", "v1.1.0", "v2.0.0"} {
+		rec, err := structpb.NewStruct(map[string]any{"name": "example.org/agent", "schema_version": "1.0.0", "version": version})
+		if err != nil {
+			t.Fatalf("NewStruct: %v", err)
+		}
+
+		if len(chain) > 0 {
+			if rec, err = record.LinkPrevious(rec, chain[len(chain)-1]); err != nil {
+				t.Fatalf("LinkPrevious: %v", err)
+			}
+		}
+
+		id, err := record.RecordDigest(rec)
+		if err != nil {
+			t.Fatalf("RecordDigest: %v", err)
+		}
+
+		store[id] = rec
+		chain = append(chain, rec)
+	}
+
+	return chain, func(_ context.Context, id string) (*structpb.Struct, error) {
+		if rec, ok := store[id]; ok {
+			return rec, nil
+		}
+
+		return nil, errors.New("not found")
+	}
+}
+
+func TestLinkPrevious(t *testing.T) {
+	chain, _ := lineage(t)
+
+	id, _ := record.RecordDigest(chain[0])
+	if got, _ := annotations.Get(chain[1], record.LineageAnnotation); got != id {
+		t.Errorf("1.0.0 link = %q, want %q in the lineage annotation", got, id)
+	}
+
+	if record.PreviousID(chain[0]) != "" {
+		t.Error("the first version must not have a predecessor")
+	}
+
+	legacy, err := record.SetPreviousID(makeRecord(t, nil), "baeareib")
+	if err != nil {
+		t.Fatalf("SetPreviousID: %v", err)
+	}
+
+	legacy.Fields["schema_version"] = structpb.NewStringValue("0.8.0")
+	if legacy, err = record.SetPreviousID(legacy, "baeareic"); err != nil {
+		t.Fatalf("SetPreviousID: %v", err)
+	}
+
+	if got := legacy.GetFields()["previous_record_cid"].GetStringValue(); got != "baeareic" || record.PreviousID(legacy) != "baeareic" {
+		t.Errorf("0.8.0 link = %q, want it in previous_record_cid", got)
+	}
+
+	migrated, err := record.Migrate(legacy, "1.0.0")
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	if got, _ := annotations.Get(migrated, record.LineageAnnotation); got != "baeareic" {
+		t.Errorf("migration lost the predecessor link: %v", migrated.AsMap())
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	chain, _ := lineage(t)
+
+	if err := record.VerifyChain(chain); err != nil {
+		t.Errorf("VerifyChain: %v", err)
+	}
+
+	if err := record.VerifyChain([]*structpb.Struct{chain[0], chain[2]}); !errors.Is(err, record.ErrBrokenLineage) {
+		t.Errorf("expected a broken lineage for a skipped version, got %v", err)
+	}
+
+	cid := record.WithRecordID(func(r *structpb.Struct) (string, error) {
+		return "cid-" + r.GetFields()["version"].GetStringValue(), nil
+	})
+
+	linked, err := record.LinkPrevious(chain[1], chain[0], cid)
+	if err != nil {
+		t.Fatalf("LinkPrevious: %v", err)
+	}
+
+	if err := record.VerifyChain([]*structpb.Struct{chain[0], linked}, cid); err != nil || record.PreviousID(linked) != "cid-v1.0.0" {
+		t.Errorf("custom ids: %v, previous = %q", err, record.PreviousID(linked))
+	}
+}
+
+func TestHistory(t *testing.T) {
+	chain, lookup := lineage(t)
+	ctx := context.Background()
+
+	history, err := record.History(ctx, chain[2], lookup)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+
+	if len(history) != 3 || history[0] != chain[0] || history[2] != chain[2] {
+		t.Errorf("unexpected history %v", history)
+	}
+
+	if _, err := record.History(ctx, chain[2], lookup, record.WithMaxHistory(1)); err == nil {
+		t.Error("expected an error for a history longer than the limit")
+	}
+
+	tampered := func(ctx context.Context, id string) (*structpb.Struct, error) {
+		rec, err := lookup(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		rec = record.Clone(rec)
+		rec.Fields["description"] = structpb.NewStringValue("tampered")
+
+		return rec, nil
+	}
+
+	if _, err := record.History(ctx, chain[2], tampered); !errors.Is(err, record.ErrBrokenLineage) {
+		t.Errorf("expected a broken lineage for a tampered predecessor, got %v", err)
+	}
+
+	unknown, _ := record.SetPreviousID(chain[0], "sha256:unknown")
+	if _, err := record.History(ctx, unknown, lookup); err == nil {
+		t.Error("expected an error for an unknown predecessor")
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// LocatorType is the type of a record locator.
+type LocatorType string
+
+const (
+	// LocatorContainerImage is a container image; 0.7.0 and 0.8.0 records
+	// call it "docker_image".
+	LocatorContainerImage LocatorType = "container_image"
+	LocatorSourceCode     LocatorType = "source_code"
+	LocatorHelmChart      LocatorType = "helm_chart"
+	LocatorBinary         LocatorType = "binary"
+)
+
+// legacyContainerImage is the pre-1.0.0 name of LocatorContainerImage.
+const legacyContainerImage = "docker_image"
+
+// ErrInvalidLocator is returned (wrapped) for unknown locator types and URLs
+// whose scheme does not fit the locator type.
+var ErrInvalidLocator = errors.New("invalid locator")
+
+// locatorSchemes lists the URL schemes accepted for each locator type.
+var locatorSchemes = map[LocatorType][]string{
+	LocatorContainerImage: {"https", "http", "oci", "docker"},
+	LocatorSourceCode:     {"https", "http", "git", "ssh"},
+	LocatorHelmChart:      {"https", "http", "oci"},
+	LocatorBinary:         {"https", "http", "file"},
+}
+
+// Locator is a record locator independent of the schema version's shape.
+type Locator struct {
+	Type LocatorType
+	URLs []string
+}
+
+// ValidateLocator checks that the locator type is known and that rawURL is an
+// absolute URL with a scheme accepted for it. "docker_image" is accepted as
+// LocatorContainerImage.
+func ValidateLocator(locatorType LocatorType, rawURL string) error {
+	locatorType = normalizeLocatorType(string(locatorType))
+
+	schemes, ok := locatorSchemes[locatorType]
+	if !ok {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidLocator, locatorType)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %s url %q: %w", ErrInvalidLocator, locatorType, rawURL, err)
+	}
+
+	if !slices.Contains(schemes, u.Scheme) {
+		return fmt.Errorf("%w: %s url %q must use one of the schemes %v", ErrInvalidLocator, locatorType, rawURL, schemes)
+	}
+
+	if u.Host == "" && u.Scheme != "file" {
+		return fmt.Errorf("%w: %s url %q has no host", ErrInvalidLocator, locatorType, rawURL)
+	}
+
+	return nil
+}
+
+// AddLocator validates the URLs and appends a locator in the shape of the
+// record's schema version: a single locator with a "urls" list for 1.0.0, or
+// one "url" locator per URL (with "docker_image" for container images) for
+// 0.7.0 and 0.8.0.
+func AddLocator(record *structpb.Struct, locatorType LocatorType, urls ...string) error {
+	if record == nil {
+		return errors.New("record is nil")
+	}
+
+	if len(urls) == 0 {
+		return fmt.Errorf("%w: %s locator needs at least one url", ErrInvalidLocator, locatorType)
+	}
+
+	for _, u := range urls {
+		if err := ValidateLocator(locatorType, u); err != nil {
+			return err
+		}
+	}
+
+	if record.Fields == nil {
+		record.Fields = map[string]*structpb.Value{}
+	}
+
+	locators := record.GetFields()["locators"].GetListValue()
+	if locators == nil {
+		locators = &structpb.ListValue{}
+		record.Fields["locators"] = structpb.NewListValue(locators)
+	}
+
+	locatorType = normalizeLocatorType(string(locatorType))
+
+	if !singleURLLocators(record) {
+		values := make([]*structpb.Value, 0, len(urls))
+		for _, u := range urls {
+			values = append(values, structpb.NewStringValue(u))
+		}
+
+		locators.Values = append(locators.Values, structpb.NewStructValue(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"type": structpb.NewStringValue(string(locatorType)),
+				"urls": structpb.NewListValue(&structpb.ListValue{Values: values}),
+			},
+		}))
+
+		return nil
+	}
+
+	typeName := string(locatorType)
+	if locatorType == LocatorContainerImage {
+		typeName = legacyContainerImage
+	}
+
+	for _, u := range urls {
+		locators.Values = append(locators.Values, structpb.NewStructValue(&structpb.Struct{
+			Fields: map[string]*structpb.Value{
+				"type": structpb.NewStringValue(typeName),
+				"url":  structpb.NewStringValue(u),
+			},
+		}))
+	}
+
+	return nil
+}
+
+// Locators returns the record's locators in either shape, with container
+// image locators reported as LocatorContainerImage.
+func Locators(record *structpb.Struct) []Locator {
+	var out []Locator
+
+	for _, value := range record.GetFields()["locators"].GetListValue().GetValues() {
+		fields := value.GetStructValue().GetFields()
+		if fields == nil {
+			continue
+		}
+
+		locator := Locator{Type: normalizeLocatorType(fields["type"].GetStringValue())}
+
+		if u := fields["url"].GetStringValue(); u != "" {
+			locator.URLs = append(locator.URLs, u)
+		}
+
+		for _, u := range fields["urls"].GetListValue().GetValues() {
+			if u.GetStringValue() != "" {
+				locator.URLs = append(locator.URLs, u.GetStringValue())
+			}
+		}
+
+		out = append(out, locator)
+	}
+
+	return out
+}
+
+// LocatorURLs returns the URLs of all locators of the given type.
+func LocatorURLs(record *structpb.Struct, locatorType LocatorType) []string {
+	locatorType = normalizeLocatorType(string(locatorType))
+
+	var out []string
+
+	for _, locator := range Locators(record) {
+		if locator.Type == locatorType {
+			out = append(out, locator.URLs...)
+		}
+	}
+
+	return out
+}
+
+func normalizeLocatorType(locatorType string) LocatorType {
+	if locatorType == legacyContainerImage {
+		return LocatorContainerImage
+	}
+
+	return LocatorType(locatorType)
+}
+
+// singleURLLocators reports whether the record's schema version uses the
+// single-url locator shape.
+func singleURLLocators(record *structpb.Struct) bool {
+	switch record.GetFields()["schema_version"].GetStringValue() {
+	case "0.7.0", "0.8.0":
+		return true
+	default:
+		return false
+	}
+}
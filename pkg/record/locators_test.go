@@ -0,0 +1,112 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestAddLocatorShapes(t *testing.T) {
+	current := mustStruct(t, map[string]any{"schema_version": "1.0.0"})
+
+	if err := record.AddLocator(current, "docker_image", "https://ghcr.io/agntcy/agent", "oci://ghcr.io/agntcy/agent"); err != nil {
+		t.Fatalf("AddLocator: %v", err)
+	}
+
+	want := []any{map[string]any{
+		"type": "container_image",
+		"urls": []any{"https://ghcr.io/agntcy/agent", "oci://ghcr.io/agntcy/agent"},
+	}}
+	if got := current.GetFields()["locators"].GetListValue().AsSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("1.0.0 locators = %v, want %v", got, want)
+	}
+
+	legacy := mustStruct(t, map[string]any{"schema_version": "0.8.0"})
+
+	if err := record.AddLocator(legacy, record.LocatorContainerImage, "https://ghcr.io/agntcy/agent", "oci://ghcr.io/agntcy/agent"); err != nil {
+		t.Fatalf("AddLocator: %v", err)
+	}
+
+	want = []any{
+		map[string]any{"type": "docker_image", "url": "https://ghcr.io/agntcy/agent"},
+		map[string]any{"type": "docker_image", "url": "oci://ghcr.io/agntcy/agent"},
+	}
+	if got := legacy.GetFields()["locators"].GetListValue().AsSlice(); !reflect.DeepEqual(got, want) {
+		t.Errorf("0.8.0 locators = %v, want %v", got, want)
+	}
+
+	wantURLs := []string{"https://ghcr.io/agntcy/agent", "oci://ghcr.io/agntcy/agent"}
+	for _, rec := range []*structpb.Struct{current, legacy} {
+		if got := record.LocatorURLs(rec, record.LocatorContainerImage); !reflect.DeepEqual(got, wantURLs) {
+			t.Errorf("LocatorURLs = %v, want %v", got, wantURLs)
+		}
+	}
+}
+
+func TestLocators(t *testing.T) {
+	rec := mustStruct(t, map[string]any{
+		"locators": []any{
+			map[string]any{"type": "source_code", "url": "https://github.com/agntcy/agent"},
+			map[string]any{"type": "helm_chart", "urls": []any{"oci://ghcr.io/agntcy/charts/agent"}},
+		},
+	})
+
+	want := []record.Locator{
+		{Type: record.LocatorSourceCode, URLs: []string{"https://github.com/agntcy/agent"}},
+		{Type: record.LocatorHelmChart, URLs: []string{"oci://ghcr.io/agntcy/charts/agent"}},
+	}
+	if got := record.Locators(rec); !reflect.DeepEqual(got, want) {
+		t.Errorf("Locators = %+v, want %+v", got, want)
+	}
+
+	if got := record.LocatorURLs(rec, record.LocatorBinary); len(got) != 0 {
+		t.Errorf("LocatorURLs(binary) = %v", got)
+	}
+}
+
+func TestValidateLocator(t *testing.T) {
+	valid := map[record.LocatorType]string{
+		record.LocatorContainerImage: "oci://ghcr.io/agntcy/agent",
+		record.LocatorSourceCode:     "git://github.com/agntcy/agent",
+		record.LocatorHelmChart:      "https://charts.example.com/agent-1.0.0.tgz",
+		record.LocatorBinary:         "file:///opt/agent/bin/agent",
+	}
+
+	for locatorType, u := range valid {
+		if err := record.ValidateLocator(locatorType, u); err != nil {
+			t.Errorf("ValidateLocator(%s, %q): %v", locatorType, u, err)
+		}
+	}
+
+	invalid := map[record.LocatorType]string{
+		record.LocatorSourceCode: "oci://ghcr.io/agntcy/agent",
+		record.LocatorHelmChart:  "ghcr.io/agntcy/charts/agent",
+		record.LocatorBinary:     "https:///agent",
+		"unknown":                "https://example.com",
+	}
+
+	for locatorType, u := range invalid {
+		if err := record.ValidateLocator(locatorType, u); !errors.Is(err, record.ErrInvalidLocator) {
+			t.Errorf("ValidateLocator(%s, %q) = %v, want ErrInvalidLocator", locatorType, u, err)
+		}
+	}
+
+	rec := mustStruct(t, map[string]any{"schema_version": "1.0.0"})
+	if err := record.AddLocator(rec, record.LocatorSourceCode, "https://github.com/agntcy/agent", "ftp://example.com"); err == nil {
+		t.Error("AddLocator must reject invalid URLs")
+	}
+
+	if err := record.AddLocator(rec, record.LocatorSourceCode); err == nil {
+		t.Error("AddLocator must require a URL")
+	}
+
+	if _, ok := rec.GetFields()["locators"]; ok {
+		t.Error("a failed AddLocator must leave the record unchanged")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MergeStrategy decides which value wins when base and overlay set the same
+// field to different values.
+type MergeStrategy int
+
+const (
+	// MergePreferOverlay keeps the overlay value on conflicts.
+	MergePreferOverlay MergeStrategy = iota
+	// MergePreferBase keeps the base value on conflicts, so the overlay only
+	// fills in what the base is missing.
+	MergePreferBase
+	// MergeFailOnConflict makes Merge return an error on the first conflict.
+	MergeFailOnConflict
+)
+
+// ErrMergeConflict is returned (wrapped, with the conflicting path) by Merge
+// with MergeFailOnConflict.
+var ErrMergeConflict = errors.New("merge conflict")
+
+// unionFields are list fields merged as a set of entries rather than as values.
+var unionFields = map[string]func(*structpb.Value) string{
+	"skills":   classKey,
+	"domains":  classKey,
+	"locators": locatorKey,
+}
+
+// Merge returns a new record combining base and overlay; neither input is
+// modified. Objects are merged field by field, skills, domains and locators
+// are merged as sets, and modules with the same name are deep-merged. Any
+// other field set on both sides to different values is a conflict, resolved
+// according to strategy.
+func Merge(base, overlay *structpb.Struct, strategy MergeStrategy) (*structpb.Struct, error) {
+	if base == nil || overlay == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	// Work on copies so the result shares no values with the inputs.
+	merged := Clone(base)
+	if merged.Fields == nil {
+		merged.Fields = map[string]*structpb.Value{}
+	}
+
+	overlay = Clone(overlay)
+
+	for key, value := range overlay.GetFields() {
+		existing, ok := merged.Fields[key]
+		if !ok {
+			merged.Fields[key] = value
+
+			continue
+		}
+
+		var (
+			result *structpb.Value
+			err    error
+		)
+
+		switch {
+		case key == "modules":
+			result, err = mergeList(key, existing, value, strategy, moduleKey, true)
+		case unionFields[key] != nil:
+			result, err = mergeList(key, existing, value, strategy, unionFields[key], false)
+		default:
+			result, err = mergeValue(key, existing, value, strategy)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		merged.Fields[key] = result
+	}
+
+	return merged, nil
+}
+
+// mergeValue merges two values: structs recursively, anything else as a
+// single value subject to the strategy.
+func mergeValue(path string, base, overlay *structpb.Value, strategy MergeStrategy) (*structpb.Value, error) {
+	baseStruct, overlayStruct := base.GetStructValue(), overlay.GetStructValue()
+	if baseStruct != nil && overlayStruct != nil {
+		fields := make(map[string]*structpb.Value, len(baseStruct.GetFields()))
+		for key, value := range baseStruct.GetFields() {
+			fields[key] = value
+		}
+
+		for key, value := range overlayStruct.GetFields() {
+			existing, ok := fields[key]
+			if !ok {
+				fields[key] = value
+
+				continue
+			}
+
+			result, err := mergeValue(path+"."+key, existing, value, strategy)
+			if err != nil {
+				return nil, err
+			}
+
+			fields[key] = result
+		}
+
+		return structpb.NewStructValue(&structpb.Struct{Fields: fields}), nil
+	}
+
+	if proto.Equal(base, overlay) {
+		return base, nil
+	}
+
+	switch strategy {
+	case MergePreferBase:
+		return base, nil
+	case MergeFailOnConflict:
+		return nil, fmt.Errorf("%w at %s", ErrMergeConflict, path)
+	default:
+		return overlay, nil
+	}
+}
+
+// mergeList merges two lists of entries identified by key. Overlay entries
+// whose key is not in base are appended; with deep set, entries present on
+// both sides are merged with mergeValue, otherwise the base entry is kept.
+func mergeList(
+	path string,
+	base, overlay *structpb.Value,
+	strategy MergeStrategy,
+	key func(*structpb.Value) string,
+	deep bool,
+) (*structpb.Value, error) {
+	if base.GetListValue() == nil || overlay.GetListValue() == nil {
+		return mergeValue(path, base, overlay, strategy)
+	}
+
+	values := append([]*structpb.Value{}, base.GetListValue().GetValues()...)
+
+	index := make(map[string]int, len(values))
+	for i, value := range values {
+		index[key(value)] = i
+	}
+
+	for _, value := range overlay.GetListValue().GetValues() {
+		k := key(value)
+
+		i, ok := index[k]
+		if !ok {
+			index[k] = len(values)
+			values = append(values, value)
+
+			continue
+		}
+
+		if !deep {
+			continue
+		}
+
+		result, err := mergeValue(fmt.Sprintf("%s[%s]", path, k), values[i], value, strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = result
+	}
+
+	return structpb.NewListValue(&structpb.ListValue{Values: values}), nil
+}
+
+// classKey identifies a skill or domain by name, falling back to its id.
+func classKey(value *structpb.Value) string {
+	fields := value.GetStructValue().GetFields()
+	if name := fields["name"].GetStringValue(); name != "" {
+		return name
+	}
+
+	if id, ok := fields["id"]; ok {
+		return fmt.Sprint(id.AsInterface())
+	}
+
+	return rawKey(value)
+}
+
+// locatorKey identifies a locator by its type and URLs (1.0.0 "urls" or the
+// older single "url").
+func locatorKey(value *structpb.Value) string {
+	fields := value.GetStructValue().GetFields()
+	if fields == nil {
+		return rawKey(value)
+	}
+
+	urls := []string{fields["url"].GetStringValue()}
+	for _, url := range fields["urls"].GetListValue().GetValues() {
+		urls = append(urls, url.GetStringValue())
+	}
+
+	return fields["type"].GetStringValue() + " " + strings.Join(urls, " ")
+}
+
+// moduleKey identifies a module by name.
+func moduleKey(value *structpb.Value) string {
+	if name := value.GetStructValue().GetFields()["name"].GetStringValue(); name != "" {
+		return name
+	}
+
+	return rawKey(value)
+}
+
+func rawKey(value *structpb.Value) string {
+	raw, _ := json.Marshal(value.AsInterface())
+
+	return string(raw)
+}
@@ -0,0 +1,133 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(t *testing.T, m map[string]any) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	return s
+}
+
+func mergeInputs(t *testing.T) (*structpb.Struct, *structpb.Struct) {
+	t.Helper()
+
+	base := mustStruct(t, map[string]any{
+		"name":        "io.github.vendor/server",
+		"version":     "v1.0.0",
+		"description": "Generated description",
+		"skills":      []any{map[string]any{"id": 10201}},
+		"locators":    []any{map[string]any{"type": "source_code", "urls": []any{"https://github.com/vendor/server"}}},
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{"name": "server", "connections": []any{"stdio"}},
+		}},
+	})
+
+	overlay := mustStruct(t, map[string]any{
+		"description": "Curated description",
+		"authors":     []any{"Vendor Inc."},
+		"skills":      []any{map[string]any{"id": 10201}, map[string]any{"id": 10202}},
+		"locators":    []any{map[string]any{"type": "source_code", "urls": []any{"https://github.com/vendor/server"}}},
+		"modules": []any{
+			map[string]any{"name": "integration/mcp", "data": map[string]any{"name": "curated-server", "tools": []any{"search"}}},
+			map[string]any{"name": "integration/a2a", "data": map[string]any{}},
+		},
+	})
+
+	return base, overlay
+}
+
+func TestMergePreferOverlay(t *testing.T) {
+	base, overlay := mergeInputs(t)
+	baseCopy, overlayCopy := proto.Clone(base), proto.Clone(overlay)
+
+	merged, err := record.Merge(base, overlay, record.MergePreferOverlay)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if !proto.Equal(base, baseCopy) || !proto.Equal(overlay, overlayCopy) {
+		t.Error("Merge must not modify its inputs")
+	}
+
+	fields := merged.GetFields()
+	if got := fields["description"].GetStringValue(); got != "Curated description" {
+		t.Errorf("description = %q", got)
+	}
+
+	if got := len(fields["authors"].GetListValue().GetValues()); got != 1 {
+		t.Errorf("authors not added: %v", fields["authors"])
+	}
+
+	if got := len(fields["skills"].GetListValue().GetValues()); got != 2 {
+		t.Errorf("skills = %d entries, want the union of 2", got)
+	}
+
+	if got := len(fields["locators"].GetListValue().GetValues()); got != 1 {
+		t.Errorf("locators = %d entries, want 1", got)
+	}
+
+	if got := len(fields["modules"].GetListValue().GetValues()); got != 2 {
+		t.Errorf("modules = %d entries, want 2", got)
+	}
+
+	_, mcp := record.GetModule(merged, "integration/mcp")
+	data := mcp.GetFields()["data"].GetStructValue().GetFields()
+
+	if data["name"].GetStringValue() != "curated-server" || data["connections"] == nil || data["tools"] == nil {
+		t.Errorf("module data not deep-merged: %v", mcp.AsMap())
+	}
+}
+
+func TestMergePreferBase(t *testing.T) {
+	base, overlay := mergeInputs(t)
+
+	merged, err := record.Merge(base, overlay, record.MergePreferBase)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if got := merged.GetFields()["description"].GetStringValue(); got != "Generated description" {
+		t.Errorf("description = %q", got)
+	}
+
+	_, mcp := record.GetModule(merged, "integration/mcp")
+	if got := mcp.GetFields()["data"].GetStructValue().GetFields()["name"].GetStringValue(); got != "server" {
+		t.Errorf("module data name = %q, want base value", got)
+	}
+}
+
+func TestMergeFailOnConflict(t *testing.T) {
+	base, overlay := mergeInputs(t)
+
+	_, err := record.Merge(base, overlay, record.MergeFailOnConflict)
+	if !errors.Is(err, record.ErrMergeConflict) {
+		t.Fatalf("expected ErrMergeConflict, got %v", err)
+	}
+
+	delete(overlay.Fields, "description")
+
+	_, err = record.Merge(base, overlay, record.MergeFailOnConflict)
+	if !errors.Is(err, record.ErrMergeConflict) || err.Error() != "merge conflict at modules[integration/mcp].data.name" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if _, err := record.Merge(nil, overlay, record.MergePreferOverlay); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// migrationPath lists the schema versions records can be migrated through, in order.
+var migrationPath = []string{"0.7.0", "0.8.0", "1.0.0"}
+
+// migrations upgrades a record in place from the keyed version to the next
+// version in migrationPath.
+var migrations = map[string]func(fields map[string]*structpb.Value){
+	"0.7.0": migrate070To080,
+	"0.8.0": migrate080To100,
+}
+
+// Migrate returns a copy of the record upgraded to the target schema version.
+// Only structural changes between schema versions are applied (module
+// namespaces, locator shape, fields removed from the schema); module data is
+// carried over as-is. Downgrades are not supported.
+func Migrate(record *structpb.Struct, targetVersion string) (*structpb.Struct, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	current := record.GetFields()["schema_version"].GetStringValue()
+
+	from := slices.Index(migrationPath, current)
+	if from < 0 {
+		return nil, fmt.Errorf("cannot migrate from schema version %q", current)
+	}
+
+	to := slices.Index(migrationPath, targetVersion)
+	if to < 0 {
+		return nil, fmt.Errorf("cannot migrate to schema version %q", targetVersion)
+	}
+
+	if to < from {
+		return nil, fmt.Errorf("cannot downgrade record from %s to %s", current, targetVersion)
+	}
+
+	migrated := Clone(record)
+
+	for _, version := range migrationPath[from:to] {
+		migrations[version](migrated.GetFields())
+	}
+
+	renameModules(migrated.GetFields(), targetVersion)
+
+	migrated.Fields["schema_version"] = structpb.NewStringValue(targetVersion)
+
+	return migrated, nil
+}
+
+// renameModules names the modules for the schema version by the module alias
+// table, e.g. moves them from the "runtime/" to the "integration/" namespace
+// for 0.8.0.
+func renameModules(fields map[string]*structpb.Value, schemaVersion string) {
+	for _, module := range fields["modules"].GetListValue().GetValues() {
+		moduleFields := module.GetStructValue().GetFields()
+		if moduleFields == nil {
+			continue
+		}
+
+		if name, ok := moduleFields["name"]; ok {
+			moduleFields["name"] = structpb.NewStringValue(ModuleNameForVersion(name.GetStringValue(), schemaVersion))
+		}
+	}
+}
+
+// migrate070To080 drops the embedded signature, which 0.8.0 no longer
+// carries. Modules are renamed by renameModules.
+func migrate070To080(fields map[string]*structpb.Value) {
+	delete(fields, "signature")
+}
+
+// migrate080To100 converts locators to the 1.0.0 shape (a "urls" list and
+// "container_image" instead of "docker_image") and moves previous_record_cid,
+// which 1.0.0 no longer has, to the LineageAnnotation.
+func migrate080To100(fields map[string]*structpb.Value) {
+	if previous := fields[previousRecordField].GetStringValue(); previous != "" {
+		_ = annotations.Set(&structpb.Struct{Fields: fields}, LineageAnnotation, previous)
+	}
+
+	delete(fields, previousRecordField)
+
+	for _, locator := range fields["locators"].GetListValue().GetValues() {
+		locatorFields := locator.GetStructValue().GetFields()
+		if locatorFields == nil {
+			continue
+		}
+
+		if locatorFields["type"].GetStringValue() == "docker_image" {
+			locatorFields["type"] = structpb.NewStringValue("container_image")
+		}
+
+		if url, ok := locatorFields["url"]; ok {
+			if _, hasURLs := locatorFields["urls"]; !hasURLs {
+				locatorFields["urls"] = structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{url}})
+			}
+
+			delete(locatorFields, "url")
+		}
+
+		delete(locatorFields, "size")
+		delete(locatorFields, "digest")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func loadFixture(t *testing.T, name string) *structpb.Struct {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", name))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	s, err := decoder.JsonToProto(data)
+	if err != nil {
+		t.Fatalf("JsonToProto: %v", err)
+	}
+
+	return s
+}
+
+func TestMigrate070To100(t *testing.T) {
+	src := loadFixture(t, "valid_0.7.0_record.json")
+	src.Fields["modules"] = structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+		structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"name": structpb.NewStringValue("runtime/mcp"),
+		}}),
+	}})
+	original := proto.Clone(src)
+
+	got, err := record.Migrate(src, "1.0.0")
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	if !proto.Equal(src, original) {
+		t.Error("Migrate must not modify its input")
+	}
+
+	if _, err := decoder.DecodeRecord(got); err != nil {
+		t.Fatalf("migrated record does not decode: %v", err)
+	}
+
+	want := loadFixture(t, "valid_1.0.0_record.json")
+	for _, field := range []string{"schema_version", "locators"} {
+		if !proto.Equal(got.GetFields()[field], want.GetFields()[field]) {
+			t.Errorf("%s = %v, want %v", field, got.GetFields()[field], want.GetFields()[field])
+		}
+	}
+
+	if _, ok := got.GetFields()["signature"]; ok {
+		t.Error("signature should be dropped")
+	}
+
+	if found, _ := record.GetModule(got, "integration/mcp"); !found {
+		t.Error("runtime/mcp should be renamed to integration/mcp")
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	src := loadFixture(t, "valid_1.0.0_record.json")
+
+	if _, err := record.Migrate(src, "0.8.0"); err == nil {
+		t.Error("expected an error for a downgrade")
+	}
+
+	if _, err := record.Migrate(src, "2.0.0"); err == nil {
+		t.Error("expected an error for an unknown target version")
+	}
+
+	if _, err := record.Migrate(nil, "1.0.0"); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+
+	same, err := record.Migrate(src, "1.0.0")
+	if err != nil || !proto.Equal(same, src) {
+		t.Errorf("migrating to the current version should be a no-op, err=%v", err)
+	}
+}
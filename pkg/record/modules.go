@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// FindModule is like GetModule but matches the module under any of its names
+// (see ModuleAliases), e.g. "integration/mcp" also finds a 0.7.0
+// "runtime/mcp" module.
+func FindModule(record *structpb.Struct, moduleName string) (bool, *structpb.Struct) {
+	i := moduleIndex(record, moduleName)
+	if i < 0 {
+		return false, nil
+	}
+
+	return true, record.GetFields()["modules"].GetListValue().GetValues()[i].GetStructValue()
+}
+
+// HasModule reports whether the record has the module, in either namespace.
+func HasModule(record *structpb.Struct, moduleName string) bool {
+	return moduleIndex(record, moduleName) >= 0
+}
+
+// AddModule appends a module to the record, naming it for the record's
+// schema version. It fails when the record already has the module.
+func AddModule(record *structpb.Struct, moduleName string, data *structpb.Struct, opts ...ModuleOption) error {
+	if record == nil {
+		return errors.New("record is nil")
+	}
+
+	if HasModule(record, moduleName) {
+		return fmt.Errorf("module %q already exists", moduleName)
+	}
+
+	if record.Fields == nil {
+		record.Fields = map[string]*structpb.Value{}
+	}
+
+	modules := record.GetFields()["modules"].GetListValue()
+	if modules == nil {
+		modules = &structpb.ListValue{}
+		record.Fields["modules"] = structpb.NewListValue(modules)
+	}
+
+	name := ModuleNameForVersion(moduleName, record.GetFields()["schema_version"].GetStringValue())
+	modules.Values = append(modules.Values, moduleValue(name, data, opts))
+
+	return nil
+}
+
+// ReplaceModule replaces the record's module in place, keeping its position
+// and naming it for the record's schema version. It fails when the record
+// does not have the module.
+func ReplaceModule(record *structpb.Struct, moduleName string, data *structpb.Struct, opts ...ModuleOption) error {
+	i := moduleIndex(record, moduleName)
+	if i < 0 {
+		return fmt.Errorf("module %q not found", moduleName)
+	}
+
+	name := ModuleNameForVersion(moduleName, record.GetFields()["schema_version"].GetStringValue())
+	record.GetFields()["modules"].GetListValue().Values[i] = moduleValue(name, data, opts)
+
+	return nil
+}
+
+// RemoveModule removes the module from the record, in either namespace, and
+// reports whether it was present.
+func RemoveModule(record *structpb.Struct, moduleName string) bool {
+	i := moduleIndex(record, moduleName)
+	if i < 0 {
+		return false
+	}
+
+	modules := record.GetFields()["modules"].GetListValue()
+	modules.Values = append(modules.Values[:i], modules.Values[i+1:]...)
+
+	return true
+}
+
+// moduleIndex returns the position of the module in the record's module list,
+// comparing the newest names of the modules, or -1.
+func moduleIndex(record *structpb.Struct, moduleName string) int {
+	want := canonicalModuleName(moduleName)
+
+	for i, module := range record.GetFields()["modules"].GetListValue().GetValues() {
+		name := module.GetStructValue().GetFields()["name"].GetStringValue()
+		if name != "" && canonicalModuleName(name) == want {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// moduleValue builds a module entry from its name, data and options. data is
+// copied so the module does not alias the caller's struct.
+func moduleValue(name string, data *structpb.Struct, opts []ModuleOption) *structpb.Value {
+	if data == nil {
+		data = &structpb.Struct{}
+	}
+
+	fields := map[string]*structpb.Value{
+		"name": structpb.NewStringValue(name),
+		"data": structpb.NewStructValue(Clone(data)),
+	}
+
+	for _, opt := range opts {
+		opt(fields)
+	}
+
+	return structpb.NewStructValue(&structpb.Struct{Fields: fields})
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func moduleNames(rec *structpb.Struct) []string {
+	var names []string
+
+	for _, module := range rec.GetFields()["modules"].GetListValue().GetValues() {
+		names = append(names, module.GetStructValue().GetFields()["name"].GetStringValue())
+	}
+
+	return names
+}
+
+func TestModuleHelpersAcrossNamespaces(t *testing.T) {
+	legacy := mustStruct(t, map[string]any{
+		"schema_version": "0.7.0",
+		"modules":        []any{map[string]any{"name": "runtime/mcp", "data": map[string]any{}}},
+	})
+
+	if !record.HasModule(legacy, "integration/mcp") || !record.HasModule(legacy, "runtime/mcp") {
+		t.Error("HasModule must match either namespace")
+	}
+
+	if found, module := record.FindModule(legacy, "integration/mcp"); !found || module.GetFields()["name"].GetStringValue() != "runtime/mcp" {
+		t.Errorf("FindModule = %v, %v", found, module)
+	}
+
+	if err := record.AddModule(legacy, "integration/mcp", nil); err == nil {
+		t.Error("AddModule must reject a module present under the other namespace")
+	}
+
+	if err := record.AddModule(legacy, "integration/a2a", nil); err != nil {
+		t.Fatalf("AddModule: %v", err)
+	}
+
+	if got := moduleNames(legacy); len(got) != 2 || got[1] != "runtime/a2a" {
+		t.Errorf("module names = %v, want the 0.7.0 name for the added module", got)
+	}
+
+	data := mustStruct(t, map[string]any{"name": "server"})
+	if err := record.ReplaceModule(legacy, "integration/mcp", data); err != nil {
+		t.Fatalf("ReplaceModule: %v", err)
+	}
+
+	_, module := record.FindModule(legacy, "runtime/mcp")
+	if got := module.GetFields()["data"].GetStructValue().GetFields()["name"].GetStringValue(); got != "server" {
+		t.Errorf("module data not replaced: %v", module)
+	}
+
+	if got := moduleNames(legacy); got[0] != "runtime/mcp" {
+		t.Errorf("ReplaceModule must keep the module position, got %v", got)
+	}
+
+	if !record.RemoveModule(legacy, "integration/mcp") || record.RemoveModule(legacy, "integration/mcp") {
+		t.Error("RemoveModule must remove the module once")
+	}
+
+	if got := moduleNames(legacy); len(got) != 1 || got[0] != "runtime/a2a" {
+		t.Errorf("module names after remove = %v", got)
+	}
+}
+
+func TestModuleHelpersEmptyRecord(t *testing.T) {
+	rec := &structpb.Struct{}
+
+	if record.HasModule(rec, "integration/mcp") || record.RemoveModule(rec, "integration/mcp") {
+		t.Error("empty record must not have modules")
+	}
+
+	if err := record.ReplaceModule(rec, "integration/mcp", nil); err == nil {
+		t.Error("ReplaceModule must fail for a missing module")
+	}
+
+	if err := record.AddModule(rec, "runtime/mcp", nil, record.WithModuleID(202)); err != nil {
+		t.Fatalf("AddModule: %v", err)
+	}
+
+	_, module := record.FindModule(rec, "integration/mcp")
+	if module.GetFields()["name"].GetStringValue() != "integration/mcp" || module.GetFields()["id"].GetNumberValue() != 202 {
+		t.Errorf("unexpected module %v", module)
+	}
+
+	if err := record.AddModule(nil, "integration/mcp", nil); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrPatchTestFailed is returned (wrapped) when a JSON Patch "test" operation
+// does not match the record.
+var ErrPatchTestFailed = errors.New("patch test failed")
+
+// patchOperation is a single RFC 6902 operation. Value is kept raw so a
+// missing value can be told apart from an explicit null.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyPatch returns a copy of the record with the patch applied; the input
+// record is not modified. A patch that is a JSON array is applied as an
+// RFC 6902 JSON Patch, a JSON object as an RFC 7386 merge patch. JSON Patch
+// operations are applied in order and the first failing one aborts the patch.
+func ApplyPatch(record *structpb.Struct, patch []byte) (*structpb.Struct, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	cloned := Clone(record)
+	doc := structpb.NewStructValue(cloned)
+
+	var err error
+
+	switch trimmed := bytes.TrimSpace(patch); {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		doc, err = applyJSONPatch(doc, trimmed)
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		var merge structpb.Value
+		if err := merge.UnmarshalJSON(trimmed); err != nil {
+			return nil, fmt.Errorf("failed to parse merge patch: %w", err)
+		}
+
+		doc = applyMergePatch(doc, &merge)
+	default:
+		return nil, errors.New("patch must be a JSON Patch array or a merge patch object")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := doc.GetStructValue()
+	if result == nil {
+		return nil, errors.New("patched record is not an object")
+	}
+
+	return result, nil
+}
+
+// applyMergePatch implements the RFC 7386 MergePatch algorithm: objects are
+// merged recursively, null removes a field and any other value replaces the
+// target.
+func applyMergePatch(target, patch *structpb.Value) *structpb.Value {
+	patchStruct := patch.GetStructValue()
+	if patchStruct == nil {
+		return patch
+	}
+
+	targetStruct := target.GetStructValue()
+	if targetStruct == nil {
+		targetStruct = &structpb.Struct{}
+	}
+
+	if targetStruct.Fields == nil {
+		targetStruct.Fields = map[string]*structpb.Value{}
+	}
+
+	for key, value := range patchStruct.GetFields() {
+		if _, isNull := value.GetKind().(*structpb.Value_NullValue); isNull {
+			delete(targetStruct.Fields, key)
+
+			continue
+		}
+
+		targetStruct.Fields[key] = applyMergePatch(targetStruct.Fields[key], value)
+	}
+
+	return structpb.NewStructValue(targetStruct)
+}
+
+func applyJSONPatch(doc *structpb.Value, patch []byte) (*structpb.Value, error) {
+	var operations []patchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON patch: %w", err)
+	}
+
+	for i, op := range operations {
+		var err error
+
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+func applyOperation(doc *structpb.Value, op patchOperation) (*structpb.Value, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		value, err := operationValue(op)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Op {
+		case "add":
+			return addValue(doc, path, value)
+		case "replace":
+			if _, err := removeValue(doc, path); err != nil {
+				return nil, err
+			}
+
+			return addValue(doc, path, value)
+		default:
+			current, err := getValue(doc, path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !proto.Equal(current, value) {
+				return nil, ErrPatchTestFailed
+			}
+
+			return doc, nil
+		}
+	case "remove":
+		if len(path) == 0 {
+			return nil, errors.New("cannot remove the whole record")
+		}
+
+		_, err := removeValue(doc, path)
+
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "copy" {
+			value, err := getValue(doc, from)
+			if err != nil {
+				return nil, err
+			}
+
+			return addValue(doc, path, CloneValue(value))
+		}
+
+		if op.From == op.Path {
+			return doc, nil
+		}
+
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return nil, fmt.Errorf("cannot move %s into itself", op.From)
+		}
+
+		value, err := removeValue(doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+func operationValue(op patchOperation) (*structpb.Value, error) {
+	if op.Value == nil {
+		return nil, errors.New("missing value")
+	}
+
+	var value structpb.Value
+	if err := value.UnmarshalJSON(op.Value); err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	return &value, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped reference
+// tokens. The empty pointer refers to the whole record.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+func getValue(doc *structpb.Value, path []string) (*structpb.Value, error) {
+	current := doc
+
+	for _, token := range path {
+		switch {
+		case current.GetStructValue() != nil:
+			next, ok := current.GetStructValue().GetFields()[token]
+			if !ok {
+				return nil, fmt.Errorf("field %q not found", token)
+			}
+
+			current = next
+		case current.GetListValue() != nil:
+			values := current.GetListValue().GetValues()
+
+			index, err := listIndex(token, len(values)-1)
+			if err != nil {
+				return nil, err
+			}
+
+			current = values[index]
+		default:
+			return nil, fmt.Errorf("cannot traverse into %q of a scalar value", token)
+		}
+	}
+
+	return current, nil
+}
+
+// addValue adds value at path, returning the (possibly replaced) document.
+func addValue(doc *structpb.Value, path []string, value *structpb.Value) (*structpb.Value, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+
+	switch {
+	case parent.GetStructValue() != nil:
+		s := parent.GetStructValue()
+		if s.Fields == nil {
+			s.Fields = map[string]*structpb.Value{}
+		}
+
+		s.Fields[token] = value
+	case parent.GetListValue() != nil:
+		list := parent.GetListValue()
+
+		index := len(list.Values)
+		if token != "-" {
+			if index, err = listIndex(token, len(list.Values)); err != nil {
+				return nil, err
+			}
+		}
+
+		list.Values = append(list.Values[:index], append([]*structpb.Value{value}, list.Values[index:]...)...)
+	default:
+		return nil, fmt.Errorf("cannot add %q to a scalar value", token)
+	}
+
+	return doc, nil
+}
+
+// removeValue removes and returns the value at path.
+func removeValue(doc *structpb.Value, path []string) (*structpb.Value, error) {
+	if len(path) == 0 {
+		return doc, nil
+	}
+
+	parent, err := getValue(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+
+	switch {
+	case parent.GetStructValue() != nil:
+		s := parent.GetStructValue()
+
+		value, ok := s.GetFields()[token]
+		if !ok {
+			return nil, fmt.Errorf("field %q not found", token)
+		}
+
+		delete(s.Fields, token)
+
+		return value, nil
+	case parent.GetListValue() != nil:
+		list := parent.GetListValue()
+
+		index, err := listIndex(token, len(list.Values)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		value := list.Values[index]
+		list.Values = append(list.Values[:index], list.Values[index+1:]...)
+
+		return value, nil
+	default:
+		return nil, fmt.Errorf("cannot remove %q from a scalar value", token)
+	}
+}
+
+// listIndex parses a list index token, which must be within [0, upper].
+func listIndex(token string, upper int) (int, error) {
+	if token == "-" {
+		return 0, errors.New("index \"-\" can only be used to append")
+	}
+
+	// RFC 6901 forbids leading zeros.
+	if len(token) > 1 && token[0] == '0' {
+		return 0, fmt.Errorf("invalid list index %q", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid list index %q", token)
+	}
+
+	if index > upper {
+		return 0, fmt.Errorf("list index %d out of range", index)
+	}
+
+	return index, nil
+}
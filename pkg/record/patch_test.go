@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func patchInput(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	return mustStruct(t, map[string]any{
+		"name":        "example.org/agent",
+		"version":     "v1.0.0",
+		"description": "Old description",
+		"authors":     []any{"Alice"},
+		"skills":      []any{map[string]any{"id": 10201}},
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{"name": "server", "env~vars/x": "1"},
+		}},
+	})
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	input := patchInput(t)
+	inputCopy := proto.Clone(input)
+
+	patched, err := record.ApplyPatch(input, []byte(`[
+		{"op": "test", "path": "/name", "value": "example.org/agent"},
+		{"op": "replace", "path": "/description", "value": "New description"},
+		{"op": "add", "path": "/authors/-", "value": "Bob"},
+		{"op": "add", "path": "/authors/0", "value": "Carol"},
+		{"op": "add", "path": "/skills/0/name", "value": "natural_language_processing/text_completion"},
+		{"op": "copy", "from": "/version", "path": "/modules/0/data/version"},
+		{"op": "move", "from": "/modules/0/data/env~0vars~1x", "path": "/modules/0/data/env"},
+		{"op": "remove", "path": "/skills/0/id"}
+	]`))
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+
+	if !proto.Equal(input, inputCopy) {
+		t.Error("ApplyPatch must not modify its input")
+	}
+
+	want := mustStruct(t, map[string]any{
+		"name":        "example.org/agent",
+		"version":     "v1.0.0",
+		"description": "New description",
+		"authors":     []any{"Carol", "Alice", "Bob"},
+		"skills":      []any{map[string]any{"name": "natural_language_processing/text_completion"}},
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{"name": "server", "env": "1", "version": "v1.0.0"},
+		}},
+	})
+
+	if !proto.Equal(patched, want) {
+		t.Errorf("patched record = %v\nwant %v", patched.AsMap(), want.AsMap())
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	patched, err := record.ApplyPatch(patchInput(t), []byte(`{
+		"description": "New description",
+		"authors": ["Bob"],
+		"version": null,
+		"annotations": {"team": "search"}
+	}`))
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v", err)
+	}
+
+	fields := patched.GetFields()
+
+	if got := fields["description"].GetStringValue(); got != "New description" {
+		t.Errorf("description = %q", got)
+	}
+
+	if _, ok := fields["version"]; ok {
+		t.Error("null must remove the field")
+	}
+
+	if got := fields["authors"].GetListValue().GetValues(); len(got) != 1 || got[0].GetStringValue() != "Bob" {
+		t.Errorf("lists must be replaced, got %v", got)
+	}
+
+	if got := fields["annotations"].GetStructValue().GetFields()["team"].GetStringValue(); got != "search" {
+		t.Errorf("annotations not added: %v", fields["annotations"])
+	}
+
+	if fields["modules"] == nil || fields["name"] == nil {
+		t.Error("fields absent from the patch must be kept")
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	cases := map[string]string{
+		"not json":           `name`,
+		"scalar patch":       `"name"`,
+		"unknown op":         `[{"op": "frobnicate", "path": "/name"}]`,
+		"missing value":      `[{"op": "add", "path": "/name"}]`,
+		"missing field":      `[{"op": "remove", "path": "/missing"}]`,
+		"replace missing":    `[{"op": "replace", "path": "/missing", "value": 1}]`,
+		"index out of range": `[{"op": "add", "path": "/authors/5", "value": "Bob"}]`,
+		"leading zero":       `[{"op": "remove", "path": "/authors/00"}]`,
+		"invalid pointer":    `[{"op": "remove", "path": "name"}]`,
+		"move into itself":   `[{"op": "move", "from": "/modules", "path": "/modules/0/x"}]`,
+		"remove root":        `[{"op": "remove", "path": ""}]`,
+		"non-object root":    `[{"op": "replace", "path": "", "value": []}]`,
+	}
+
+	for name, patch := range cases {
+		if _, err := record.ApplyPatch(patchInput(t), []byte(patch)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := record.ApplyPatch(patchInput(t), []byte(`[{"op": "test", "path": "/name", "value": "other"}]`))
+	if !errors.Is(err, record.ErrPatchTestFailed) {
+		t.Errorf("expected ErrPatchTestFailed, got %v", err)
+	}
+
+	if _, err := record.ApplyPatch(nil, []byte(`{}`)); err == nil {
+		t.Error("expected an error for a nil record")
+	}
+}
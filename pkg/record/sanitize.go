@@ -0,0 +1,267 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Redacted replaces secret values removed by Sanitize.
+const Redacted = "[REDACTED]"
+
+// ErrNotPublishable is returned (wrapped, with the problems found) when a
+// sanitized record is still not fit for publication.
+var ErrNotPublishable = errors.New("record is not publishable")
+
+// secretKeyPattern matches field names, and the "name" of name/value pairs
+// such as environment variables and headers, that hold secrets.
+var secretKeyPattern = regexp.MustCompile(
+	`(?i)(password|passwd|secret|token|api[_-]?key|authorization|credentials?|private[_-]?key|access[_-]?key)$`,
+)
+
+// secretValuePattern matches well-known credential formats wherever they
+// appear.
+var secretValuePattern = regexp.MustCompile(
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----|\b(ghp|gho|ghs|ghu)_[A-Za-z0-9]{36}\b|\bgithub_pat_[A-Za-z0-9_]{20,}|` +
+		`\bsk-[A-Za-z0-9_-]{20,}|\bAKIA[0-9A-Z]{16}\b|\bxox[abpr]-[A-Za-z0-9-]{10,}|(?i)\bbearer\s+[A-Za-z0-9._~+/=-]{8,}`,
+)
+
+// IsSecret reports whether a value is a secret by the rules of Redact:
+// because of its name (of a field, or of a name/value pair such as a header)
+// or because it has a well-known credential format.
+func IsSecret(name, value string) bool {
+	return secretKeyPattern.MatchString(name) || secretValuePattern.MatchString(value)
+}
+
+// pairValueFields are the value fields of name/value pairs.
+var pairValueFields = []string{"value", "default_value", "default"}
+
+// requiredFields must be set on every published record.
+var requiredFields = []string{"name", "version", "schema_version"}
+
+type sanitizeOptions struct {
+	validators []func(*structpb.Struct) error
+}
+
+// SanitizeOption configures Sanitize.
+type SanitizeOption func(*sanitizeOptions)
+
+// WithValidator adds a check run on the sanitized record, e.g. schema
+// validation against an OASF server.
+func WithValidator(validate func(*structpb.Struct) error) SanitizeOption {
+	return func(opts *sanitizeOptions) {
+		opts.validators = append(opts.validators, validate)
+	}
+}
+
+// Sanitize prepares a record for publication to a public directory and
+// returns the sanitized copy; the input is not modified. It
+//
+//   - redacts secrets: values of secret-looking fields and name/value pairs,
+//     well-known credential formats and passwords embedded in URLs,
+//   - normalizes: trims surrounding whitespace from strings and drops exact
+//     duplicates from top-level lists,
+//   - drops the SDK's internal annotations (InternalNamespace) and an empty
+//     annotations map,
+//
+// and then validates the result: required fields, locator URLs, annotation
+// keys and any WithValidator checks. Problems are returned wrapped in
+// ErrNotPublishable. Sanitize before signing, as it changes the content.
+func Sanitize(record *structpb.Struct, opts ...SanitizeOption) (*structpb.Struct, error) {
+	if record == nil {
+		return nil, errors.New("record is nil")
+	}
+
+	options := &sanitizeOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	sanitized := Clone(record)
+
+	rewriteFields(sanitized, trimValue)
+	rewriteFields(sanitized, redactValue)
+	dedupLists(sanitized)
+
+	annotations.DeleteNamespace(sanitized, annotations.InternalNamespace)
+
+	if len(annotations.Keys(sanitized)) == 0 {
+		delete(sanitized.Fields, "annotations")
+	}
+
+	if err := validatePublishable(sanitized, options); err != nil {
+		return nil, err
+	}
+
+	return sanitized, nil
+}
+
+// Normalize returns a copy of the record with surrounding whitespace trimmed
+// from its strings and exact duplicates dropped from its top-level lists. The
+// signature is left as is.
+func Normalize(record *structpb.Struct) *structpb.Struct {
+	normalized := Clone(record)
+
+	rewriteFields(normalized, trimValue)
+	dedupLists(normalized)
+
+	return normalized
+}
+
+// Redact returns a copy of the record with its secrets replaced by Redacted:
+// values of secret-looking fields and name/value pairs, well-known credential
+// formats and passwords embedded in URLs, which are removed. Normalize first
+// so that padded values are recognized.
+func Redact(record *structpb.Struct) *structpb.Struct {
+	redacted := Clone(record)
+
+	rewriteFields(redacted, redactValue)
+
+	return redacted
+}
+
+// rewriteFields applies rewrite to the fields of the record but its
+// signature.
+func rewriteFields(record *structpb.Struct, rewrite func(key string, value *structpb.Value)) {
+	for key, value := range record.GetFields() {
+		if key != "signature" {
+			rewrite(key, value)
+		}
+	}
+}
+
+// trimValue trims the strings of value in place.
+func trimValue(_ string, value *structpb.Value) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		kind.StringValue = strings.TrimSpace(kind.StringValue)
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			trimValue("", item)
+		}
+	case *structpb.Value_StructValue:
+		for _, field := range kind.StructValue.GetFields() {
+			trimValue("", field)
+		}
+	}
+}
+
+// redactValue redacts the secrets of value in place; key is the name of the
+// field holding it.
+func redactValue(key string, value *structpb.Value) {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		kind.StringValue = redactString(key, kind.StringValue)
+	case *structpb.Value_ListValue:
+		for _, item := range kind.ListValue.GetValues() {
+			redactValue(key, item)
+		}
+	case *structpb.Value_StructValue:
+		fields := kind.StructValue.GetFields()
+
+		for name, field := range fields {
+			redactValue(name, field)
+		}
+
+		if secretKeyPattern.MatchString(fields["name"].GetStringValue()) {
+			for _, name := range pairValueFields {
+				if v, ok := fields[name]; ok && v.GetStringValue() != "" {
+					fields[name] = structpb.NewStringValue(Redacted)
+				}
+			}
+		}
+	}
+}
+
+func redactString(key, value string) string {
+	switch {
+	case value == "" || value == Redacted:
+		return value
+	case secretKeyPattern.MatchString(key), secretValuePattern.MatchString(value):
+		return Redacted
+	}
+
+	if strings.Contains(value, "://") && strings.Contains(value, "@") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			if _, hasPassword := u.User.Password(); hasPassword {
+				u.User = url.User(u.User.Username())
+
+				return u.String()
+			}
+		}
+	}
+
+	return value
+}
+
+// dedupLists drops exact duplicates from the top-level lists of the record.
+func dedupLists(record *structpb.Struct) {
+	for key, value := range record.GetFields() {
+		if list := value.GetListValue(); list != nil {
+			record.Fields[key] = structpb.NewListValue(&structpb.ListValue{Values: dedupValues(list.GetValues())})
+		}
+	}
+}
+
+// dedupValues drops exact duplicates, keeping the first occurrence.
+func dedupValues(values []*structpb.Value) []*structpb.Value {
+	seen := make(map[string]bool, len(values))
+	out := make([]*structpb.Value, 0, len(values))
+
+	for _, value := range values {
+		key := rawKey(value)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		out = append(out, value)
+	}
+
+	return out
+}
+
+func validatePublishable(record *structpb.Struct, options *sanitizeOptions) error {
+	var problems []error
+
+	for _, name := range requiredFields {
+		if record.GetFields()[name].GetStringValue() == "" {
+			problems = append(problems, fmt.Errorf("%s is required", name))
+		}
+	}
+
+	for _, locator := range Locators(record) {
+		for _, u := range locator.URLs {
+			if err := ValidateLocator(locator.Type, u); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+
+	for _, key := range annotations.Keys(record) {
+		if err := annotations.ValidateKey(key); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	for _, validate := range options.validators {
+		if err := validate(record); err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %w", ErrNotPublishable, errors.Join(problems...))
+	}
+
+	return nil
+}
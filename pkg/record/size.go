@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrTooLarge is returned by CheckSize for records over a size limit.
+var ErrTooLarge = errors.New("record too large")
+
+// CheckSize checks the record against limits.MaxSize and
+// limits.MaxModuleSize, failing with ErrTooLarge on the first limit exceeded.
+// Unlike Analyze it measures the protobuf wire size and encodes nothing, so
+// servers can afford it on every request; the wire size of a record is close
+// to its canonical JSON size.
+func CheckSize(record *structpb.Struct, limits Limits) error {
+	if limits.MaxSize > 0 {
+		if size := proto.Size(record); size > limits.MaxSize {
+			return fmt.Errorf("%w: record is %d bytes, over the %d byte limit", ErrTooLarge, size, limits.MaxSize)
+		}
+	}
+
+	if limits.MaxModuleSize <= 0 {
+		return nil
+	}
+
+	for _, module := range record.GetFields()["modules"].GetListValue().GetValues() {
+		if size := proto.Size(module); size > limits.MaxModuleSize {
+			name := module.GetStructValue().GetFields()["name"].GetStringValue()
+
+			return fmt.Errorf("%w: module %s is %d bytes, over the %d byte limit", ErrTooLarge, name, size, limits.MaxModuleSize)
+		}
+	}
+
+	return nil
+}
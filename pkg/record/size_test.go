@@ -0,0 +1,49 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package record_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCheckSize(t *testing.T) {
+	in, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/agent",
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{"name": "integration/a2a", "data": map[string]any{}},
+			map[string]any{"name": "integration/mcp", "data": map[string]any{"blob": strings.Repeat("x", 2000)}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		limits record.Limits
+		want   string
+	}{
+		{"no limits", record.Limits{}, ""},
+		{"within limits", record.Limits{MaxSize: 4000, MaxModuleSize: 3000}, ""},
+		{"record too large", record.Limits{MaxSize: 1000}, "record is"},
+		{"module too large", record.Limits{MaxSize: 4000, MaxModuleSize: 1000}, "module integration/mcp is"},
+	}
+
+	for _, tc := range cases {
+		err := record.CheckSize(in, tc.limits)
+
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		case tc.want != "" && (!errors.Is(err, record.ErrTooLarge) || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%s: got %v, want ErrTooLarge with %q", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// joinDelay is how long a gated server waits after the first request of a
+// path before answering, so concurrent callers join the same fetch.
+const joinDelay = 100 * time.Millisecond
+
+// newGatedServer counts the requests per path and holds the first request of
+// each path until release is closed.
+func newGatedServer(t *testing.T, calls map[string]*atomic.Int32, arrived chan<- string, release <-chan struct{}) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter, ok := calls[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		if counter.Add(1) == 1 {
+			arrived <- r.URL.Path
+			<-release
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == apiVersionsPath {
+			_ = json.NewEncoder(w).Encode(VersionsResponse{
+				Default:  VersionInfo{SchemaVersion: "0.8.0"},
+				Versions: []VersionInfo{{SchemaVersion: "0.8.0"}},
+			})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(mockCategoriesResponse())
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestConcurrentFetchesAreDeduplicated(t *testing.T) {
+	calls := map[string]*atomic.Int32{apiVersionsPath: {}, pathModuleCategories080: {}}
+	arrived := make(chan string, len(calls))
+	release := make(chan struct{})
+
+	server := newGatedServer(t, calls, arrived, release)
+
+	s, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	const callers = 8
+
+	results := make([]Taxonomy, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+
+	for i := range callers {
+		wg.Go(func() {
+			results[i], errs[i] = s.GetSchemaModules(context.Background())
+		})
+	}
+
+	// Release each path once the callers had time to join its fetch.
+	for range calls {
+		<-arrived
+		time.Sleep(joinDelay)
+
+		release <- struct{}{}
+	}
+
+	wg.Wait()
+
+	for path, counter := range calls {
+		if got := counter.Load(); got != 1 {
+			t.Errorf("%s fetched %d times, want 1", path, got)
+		}
+	}
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: %v", i, err)
+		}
+	}
+
+	// Callers sharing a fetch get their own copies.
+	delete(results[0], "core")
+
+	if _, ok := results[1]["core"]; !ok {
+		t.Error("modifying one caller's taxonomy changed another's")
+	}
+}
+
+func TestCanceledCallerDoesNotFailSharedFetch(t *testing.T) {
+	calls := map[string]*atomic.Int32{apiVersionsPath: {}}
+	arrived := make(chan string, len(calls))
+	release := make(chan struct{})
+
+	server := newGatedServer(t, calls, arrived, release)
+
+	s, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	leaderErr := make(chan error, 1)
+
+	go func() {
+		_, err := s.GetDefaultSchemaVersion(ctx)
+		leaderErr <- err
+	}()
+
+	<-arrived
+
+	followerErr := make(chan error, 1)
+
+	go func() {
+		version, err := s.GetDefaultSchemaVersion(context.Background())
+		if err == nil && version != "0.8.0" {
+			err = errors.New("unexpected version " + version)
+		}
+
+		followerErr <- err
+	}()
+
+	time.Sleep(joinDelay)
+	cancel()
+
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller got %v, want context.Canceled", err)
+	}
+
+	close(release)
+
+	if err := <-followerErr; err != nil {
+		t.Fatalf("other caller failed: %v", err)
+	}
+
+	if got := calls[apiVersionsPath].Load(); got != 1 {
+		t.Errorf("versions fetched %d times, want 1", got)
+	}
+}
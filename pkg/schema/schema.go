@@ -14,6 +14,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/internal/transport"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -24,6 +27,17 @@ const (
 	moduleCategoriesEndpoint  = "module_categories"
 )
 
+// Errors returned (wrapped, with details) by Schema methods. Callers can use
+// errors.Is to branch on them instead of matching error strings.
+var (
+	// ErrSchemaUnavailable is returned when the schema server cannot be
+	// reached or does not answer with the requested document.
+	ErrSchemaUnavailable = errors.New("schema server unavailable")
+	// ErrUnsupportedVersion is returned for schema versions the schema server
+	// does not offer.
+	ErrUnsupportedVersion = errors.New("unsupported schema version")
+)
+
 // VersionsResponse represents the response from the api/versions endpoint.
 type VersionsResponse struct {
 	Default  VersionInfo   `json:"default"`
@@ -90,6 +104,7 @@ const (
 
 type constructorOptions struct {
 	enableCache bool
+	httpClient  *http.Client
 }
 
 // WithCache enables or disables dynamic in-memory caching.
@@ -100,6 +115,14 @@ func WithCache(enabled bool) ConstructorOption {
 	}
 }
 
+// WithHTTPClient sets the HTTP client calling the schema server. It defaults
+// to a client with a 30 second timeout.
+func WithHTTPClient(client *http.Client) ConstructorOption {
+	return func(opts *constructorOptions) {
+		opts.httpClient = client
+	}
+}
+
 // schemaOptions holds the options for schema operations.
 type schemaOptions struct {
 	schemaVersion string
@@ -112,13 +135,31 @@ func WithSchemaVersion(schemaVersion string) SchemaOption {
 	}
 }
 
+// SchemaVersionOf returns the schema version the options select, or "" for
+// the default version. It lets other implementations of the Schema methods,
+// e.g. test fakes, honor WithSchemaVersion.
+func SchemaVersionOf(opts ...SchemaOption) string {
+	options := &schemaOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options.schemaVersion
+}
+
 // Schema provides access to OASF schema definitions via API.
+//
+// A Schema is safe for concurrent use by multiple goroutines and is meant to
+// be shared. Concurrent requests for the same document are deduplicated into
+// a single upstream fetch, whether or not caching is enabled, and cached
+// documents are copied before they are returned, so callers may modify them.
 type Schema struct {
 	schemaURL    string // Normalized schema URL
 	httpClient   *http.Client
 	cacheEnabled bool
 	cacheMu      sync.RWMutex
 	cache        *schemaCache
+	fetches      singleflight.Group
 }
 
 // normalizeURL normalizes a schema URL by removing trailing slashes and adding protocol if missing.
@@ -142,12 +183,18 @@ func New(schemaURL string, opts ...ConstructorOption) (*Schema, error) {
 		opt(options)
 	}
 
+	httpClient := options.httpClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Transport: transport.Default(),
+			Timeout:   defaultHTTPTimeoutSeconds * time.Second,
+		}
+	}
+
 	return &Schema{
 		schemaURL:    normalizeURL(schemaURL),
 		cacheEnabled: options.enableCache,
-		httpClient: &http.Client{
-			Timeout: defaultHTTPTimeoutSeconds * time.Second,
-		},
+		httpClient:   httpClient,
 		cache: &schemaCache{
 			skills:     map[string]Taxonomy{},
 			domains:    map[string]Taxonomy{},
@@ -197,8 +244,40 @@ func (s *Schema) extractVersions(resp *VersionsResponse) (string, []string, erro
 	return defaultSchemaVersion, schemaVersions, nil
 }
 
-// getVersionsResponse fetches the versions response from the server.
+// share runs fetch once for the concurrent callers with the same key. The
+// fetch is not canceled with the caller that started it, so one caller giving
+// up does not fail the others; the HTTP client timeout still bounds it, and
+// each caller returns when its own ctx is done. shared reports whether the
+// result was handed to several callers, which must then not modify it.
+func (s *Schema) share(ctx context.Context, key string, fetch func(context.Context) (any, error)) (any, bool, error) {
+	ch := s.fetches.DoChan(key, func() (any, error) {
+		return fetch(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case res := <-ch:
+		return res.Val, res.Shared, res.Err
+	case <-ctx.Done():
+		return nil, false, ctx.Err() //nolint:wrapcheck
+	}
+}
+
+// getVersionsResponse fetches the versions response from the server. The
+// response is shared between concurrent callers and must not be modified.
 func (s *Schema) getVersionsResponse(ctx context.Context) (*VersionsResponse, error) {
+	resp, _, err := s.share(ctx, apiVersionsPath, func(ctx context.Context) (any, error) {
+		return s.fetchVersionsResponse(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	versionsResp, _ := resp.(*VersionsResponse)
+
+	return versionsResp, nil
+}
+
+func (s *Schema) fetchVersionsResponse(ctx context.Context) (*VersionsResponse, error) {
 	versionsURL := s.schemaURL + apiVersionsPath
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, versionsURL, nil)
@@ -210,14 +289,14 @@ func (s *Schema) getVersionsResponse(ctx context.Context) (*VersionsResponse, er
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send GET request to %s: %w", versionsURL, err)
+		return nil, fmt.Errorf("%w: failed to send GET request to %s: %w", ErrSchemaUnavailable, versionsURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 
-		return nil, fmt.Errorf("failed to fetch versions from URL %s: HTTP %d, body: %s", versionsURL, resp.StatusCode, string(body))
+		return nil, fmt.Errorf("%w: failed to fetch versions from URL %s: HTTP %d, body: %s", ErrSchemaUnavailable, versionsURL, resp.StatusCode, string(body))
 	}
 
 	var versionsResp VersionsResponse
@@ -308,7 +387,7 @@ func (s *Schema) ensureVersionSupported(ctx context.Context, schemaVersion strin
 		return nil
 	}
 
-	return fmt.Errorf("schema version %q is not supported", schemaVersion)
+	return fmt.Errorf("schema version %q is not supported by the schema server: %w", schemaVersion, ErrUnsupportedVersion)
 }
 
 func (s *Schema) resolveVersion(ctx context.Context, schemaVersion string) (string, error) {
@@ -439,14 +518,14 @@ func (s *Schema) fetchTaxonomyForVersion(ctx context.Context, version string, en
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send GET request to %s: %w", categoriesURL, err)
+		return nil, fmt.Errorf("%w: failed to send GET request to %s: %w", ErrSchemaUnavailable, categoriesURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 
-		return nil, fmt.Errorf("failed to fetch categories from URL %s: HTTP %d, body: %s", categoriesURL, resp.StatusCode, string(body))
+		return nil, fmt.Errorf("%w: failed to fetch categories from URL %s: HTTP %d, body: %s", ErrSchemaUnavailable, categoriesURL, resp.StatusCode, string(body))
 	}
 
 	var taxonomy Taxonomy
@@ -484,12 +563,7 @@ func (s *Schema) constructSchemaURL(version string, schemaType EntityType, name
 // GetJSONSchema is a generic function to fetch JSON schema content from the OASF API.
 // It constructs the URL as /schema/<version>/<type>/<name>.
 func (s *Schema) GetJSONSchema(ctx context.Context, schemaType EntityType, name string, opts ...SchemaOption) ([]byte, error) {
-	options := &schemaOptions{}
-	for _, opt := range opts {
-		opt(options)
-	}
-
-	schemaVersion, err := s.resolveVersion(ctx, options.schemaVersion)
+	schemaVersion, err := s.resolveVersion(ctx, SchemaVersionOf(opts...))
 	if err != nil {
 		return nil, err
 	}
@@ -498,6 +572,25 @@ func (s *Schema) GetJSONSchema(ctx context.Context, schemaType EntityType, name
 		return cached, nil
 	}
 
+	key := "schema|" + schemaVersion + "|" + jsonSchemaCacheKey(schemaType, name)
+
+	data, shared, err := s.share(ctx, key, func(ctx context.Context) (any, error) {
+		return s.fetchJSONSchema(ctx, schemaVersion, schemaType, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	schemaData, _ := data.([]byte)
+	if shared {
+		return append([]byte(nil), schemaData...), nil
+	}
+
+	return schemaData, nil
+}
+
+// fetchJSONSchema fetches a JSON schema and caches it.
+func (s *Schema) fetchJSONSchema(ctx context.Context, schemaVersion string, schemaType EntityType, name string) ([]byte, error) {
 	schemaURL := s.constructSchemaURL(schemaVersion, schemaType, name)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, schemaURL, nil)
@@ -509,14 +602,14 @@ func (s *Schema) GetJSONSchema(ctx context.Context, schemaType EntityType, name
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send GET request to %s: %w", schemaURL, err)
+		return nil, fmt.Errorf("%w: failed to send GET request to %s: %w", ErrSchemaUnavailable, schemaURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 
-		return nil, fmt.Errorf("failed to fetch schema from URL %s: HTTP %d, body: %s", schemaURL, resp.StatusCode, string(body))
+		return nil, fmt.Errorf("%w: failed to fetch schema from URL %s: HTTP %d, body: %s", ErrSchemaUnavailable, schemaURL, resp.StatusCode, string(body))
 	}
 
 	schemaData, err := io.ReadAll(resp.Body)
@@ -531,12 +624,7 @@ func (s *Schema) GetJSONSchema(ctx context.Context, schemaType EntityType, name
 
 // GetSchemaTaxonomy fetches nested taxonomy categories from /api/<version>/<endpoint>.
 func (s *Schema) GetSchemaTaxonomy(ctx context.Context, endpoint string, opts ...SchemaOption) (Taxonomy, error) {
-	options := &schemaOptions{}
-	for _, opt := range opts {
-		opt(options)
-	}
-
-	version, err := s.resolveVersion(ctx, options.schemaVersion)
+	version, err := s.resolveVersion(ctx, SchemaVersionOf(opts...))
 	if err != nil {
 		return nil, err
 	}
@@ -545,12 +633,24 @@ func (s *Schema) GetSchemaTaxonomy(ctx context.Context, endpoint string, opts ..
 		return categories, nil
 	}
 
-	categories, err := s.fetchTaxonomyForVersion(ctx, version, endpoint)
+	result, shared, err := s.share(ctx, "taxonomy|"+version+"|"+endpoint, func(ctx context.Context) (any, error) {
+		categories, err := s.fetchTaxonomyForVersion(ctx, version, endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		s.setCachedTaxonomy(endpoint, version, categories)
+
+		return categories, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	s.setCachedTaxonomy(endpoint, version, categories)
+	categories, _ := result.(Taxonomy)
+	if shared {
+		return cloneTaxonomy(categories), nil
+	}
 
 	return categories, nil
 }
@@ -6,6 +6,7 @@ package schema
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -1288,6 +1289,10 @@ func TestGetSchemaModulesRejectsUnsupportedVersionBeforeCategoryFetch(t *testing
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("Expected ErrUnsupportedVersion, got %v", err)
+	}
+
 	if categoryEndpointHit {
 		t.Fatalf("Category endpoint should not be called for unsupported version")
 	}
@@ -1297,6 +1302,27 @@ func TestGetSchemaModulesRejectsUnsupportedVersionBeforeCategoryFetch(t *testing
 	}
 }
 
+func TestSchemaUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	s, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	if _, err := s.GetAvailableSchemaVersions(context.Background()); !errors.Is(err, ErrSchemaUnavailable) {
+		t.Errorf("Expected ErrSchemaUnavailable for HTTP 503, got %v", err)
+	}
+
+	server.Close()
+
+	if _, err := s.GetRecordJSONSchema(context.Background(), WithSchemaVersion("1.0.0")); !errors.Is(err, ErrSchemaUnavailable) {
+		t.Errorf("Expected ErrSchemaUnavailable for a closed server, got %v", err)
+	}
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Signature algorithms, named after their JWA identifiers.
+const (
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// keySigner signs with a private key held in memory.
+type keySigner struct {
+	key       crypto.Signer
+	algorithm string
+}
+
+// NewKeySigner returns a signer for an ECDSA P-256 or Ed25519 private key.
+func NewKeySigner(key crypto.PrivateKey) (Signer, error) {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, errors.New("unsupported ECDSA curve (want P-256)")
+		}
+
+		return &keySigner{key: k, algorithm: AlgorithmES256}, nil
+	case ed25519.PrivateKey:
+		return &keySigner{key: k, algorithm: AlgorithmEdDSA}, nil
+	default:
+		return nil, errors.New("unsupported key type (want ECDSA P-256 or Ed25519)")
+	}
+}
+
+// LoadPrivateKey parses a PEM encoded PKCS #8 or SEC 1 (EC) private key.
+func LoadPrivateKey(pemData []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		ecKey, ecErr := x509.ParseECPrivateKey(block.Bytes)
+		if ecErr != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+
+		return ecKey, nil
+	}
+
+	return key, nil
+}
+
+func (s *keySigner) Algorithm() string { return s.algorithm }
+
+func (s *keySigner) Public() crypto.PublicKey { return s.key.Public() }
+
+func (s *keySigner) CertificateChain() []string { return nil }
+
+func (s *keySigner) Sign(_ context.Context, payload []byte) ([]byte, error) {
+	if k, ok := s.key.(ed25519.PrivateKey); ok {
+		return ed25519.Sign(k, payload), nil
+	}
+
+	digest := sha256.Sum256(payload)
+
+	sig, err := s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return sig, nil
+}
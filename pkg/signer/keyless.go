@@ -0,0 +1,214 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultFulcioURL is the public Sigstore Fulcio instance.
+const DefaultFulcioURL = "https://fulcio.sigstore.dev"
+
+const defaultSigstoreTimeout = 30 * time.Second
+
+type keylessOptions struct {
+	fulcioURL  string
+	httpClient *http.Client
+}
+
+// KeylessOption configures NewKeylessSigner.
+type KeylessOption func(*keylessOptions)
+
+// WithFulcioURL sets the Fulcio instance issuing the signing certificate.
+func WithFulcioURL(url string) KeylessOption {
+	return func(o *keylessOptions) {
+		o.fulcioURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to talk to Fulcio.
+func WithHTTPClient(client *http.Client) KeylessOption {
+	return func(o *keylessOptions) {
+		o.httpClient = client
+	}
+}
+
+// keylessSigner signs with an ephemeral key certified by Fulcio.
+type keylessSigner struct {
+	keySigner
+
+	chain []string
+}
+
+// NewKeylessSigner generates an ephemeral ECDSA P-256 key and obtains a
+// short-lived signing certificate for it from Fulcio, binding it to the
+// identity of the OIDC identity token. The certificate chain is embedded in
+// every signature, so no key has to be distributed to verifiers.
+func NewKeylessSigner(ctx context.Context, identityToken string, opts ...KeylessOption) (Signer, error) {
+	o := &keylessOptions{
+		fulcioURL:  DefaultFulcioURL,
+		httpClient: &http.Client{Timeout: defaultSigstoreTimeout},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	subject, err := tokenSubject(identityToken)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	publicKey, err := EncodePublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	// Fulcio requires a proof of possession: the token subject signed with
+	// the key to certify.
+	subjectDigest := sha256.Sum256([]byte(subject))
+
+	proof, err := ecdsa.SignASN1(rand.Reader, key, subjectDigest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proof of possession: %w", err)
+	}
+
+	chain, err := requestCertificate(ctx, o, identityToken, publicKey, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keylessSigner{keySigner: keySigner{key: key, algorithm: AlgorithmES256}, chain: chain}, nil
+}
+
+func (s *keylessSigner) CertificateChain() []string { return s.chain }
+
+// fulcioRequest is the body of Fulcio's v2 signingCert API.
+type fulcioRequest struct {
+	Credentials struct {
+		OIDCIdentityToken string `json:"oidcIdentityToken"`
+	} `json:"credentials"`
+	PublicKeyRequest struct {
+		PublicKey struct {
+			Algorithm string `json:"algorithm"`
+			Content   string `json:"content"`
+		} `json:"publicKey"`
+		ProofOfPossession string `json:"proofOfPossession"`
+	} `json:"publicKeyRequest"`
+}
+
+type fulcioChain struct {
+	Chain struct {
+		Certificates []string `json:"certificates"`
+	} `json:"chain"`
+}
+
+type fulcioResponse struct {
+	SignedCertificateEmbeddedSct *fulcioChain `json:"signedCertificateEmbeddedSct"`
+	SignedCertificateDetachedSct *fulcioChain `json:"signedCertificateDetachedSct"`
+}
+
+func requestCertificate(ctx context.Context, o *keylessOptions, token, publicKey string, proof []byte) ([]string, error) {
+	var body fulcioRequest
+
+	body.Credentials.OIDCIdentityToken = token
+	body.PublicKeyRequest.PublicKey.Algorithm = "ECDSA"
+	body.PublicKeyRequest.PublicKey.Content = publicKey
+	body.PublicKeyRequest.ProofOfPossession = base64.StdEncoding.EncodeToString(proof)
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal certificate request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.fulcioURL+"/api/v2/signingCert", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request signing certificate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("fulcio returned %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	var parsed fulcioResponse
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse certificate response: %w", err)
+	}
+
+	chain := parsed.SignedCertificateEmbeddedSct
+	if chain == nil {
+		chain = parsed.SignedCertificateDetachedSct
+	}
+
+	if chain == nil || len(chain.Chain.Certificates) == 0 {
+		return nil, errors.New("fulcio returned no certificate")
+	}
+
+	return chain.Chain.Certificates, nil
+}
+
+// tokenSubject returns the identity Fulcio certifies for a token: its email
+// claim when present, its subject otherwise. The token is not verified here;
+// Fulcio does that.
+func tokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:mnd
+		return "", errors.New("identity token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode identity token: %w", err)
+	}
+
+	var claims struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("failed to parse identity token: %w", err)
+	}
+
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("identity token has no subject")
+	}
+
+	return claims.Subject, nil
+}
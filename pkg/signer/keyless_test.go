@@ -0,0 +1,221 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+)
+
+// identityToken returns an unsigned JWT carrying the given claims; the fake
+// Fulcio below does not verify it.
+func identityToken(t *testing.T, claims map[string]string) string {
+	t.Helper()
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	return "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+// fakeIssuer is the OIDC issuer recorded in certificates of the fake Fulcio.
+const fakeIssuer = "https://issuer.example.org"
+
+// newFakeFulcio issues a certificate for the requested key after checking the
+// proof of possession over subject. It returns the server and its root pool.
+func newFakeFulcio(t *testing.T, subject string) (*httptest.Server, *x509.CertPool) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fake-fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	issuerExt, err := asn1.Marshal(fakeIssuer)
+	if err != nil {
+		t.Fatalf("asn1.Marshal: %v", err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/signingCert" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		var req struct {
+			PublicKeyRequest struct {
+				PublicKey struct {
+					Content string `json:"content"`
+				} `json:"publicKey"`
+				ProofOfPossession string `json:"proofOfPossession"`
+			} `json:"publicKeyRequest"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		block, _ := pem.Decode([]byte(req.PublicKeyRequest.PublicKey.Content))
+		if block == nil {
+			http.Error(w, "bad public key", http.StatusBadRequest)
+
+			return
+		}
+
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		proof, _ := base64.StdEncoding.DecodeString(req.PublicKeyRequest.ProofOfPossession)
+		digest := sha256.Sum256([]byte(subject))
+
+		ecPublic, ok := public.(*ecdsa.PublicKey)
+		if !ok || !ecdsa.VerifyASN1(ecPublic, digest[:], proof) {
+			http.Error(w, "invalid proof of possession", http.StatusBadRequest)
+
+			return
+		}
+
+		template := &x509.Certificate{
+			SerialNumber:    big.NewInt(2),
+			NotBefore:       time.Now().Add(-time.Minute),
+			NotAfter:        time.Now().Add(10 * time.Minute),
+			EmailAddresses:  []string{subject},
+			KeyUsage:        x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+			ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}, Value: issuerExt}},
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, ecPublic, caKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"signedCertificateEmbeddedSct": map[string]any{
+				"chain": map[string]any{
+					"certificates": []string{string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))},
+				},
+			},
+		})
+	})), roots
+}
+
+func TestKeylessSigner(t *testing.T) {
+	fulcio, _ := newFakeFulcio(t, "dev@example.org")
+	defer fulcio.Close()
+
+	token := identityToken(t, map[string]string{"sub": "1234", "email": "dev@example.org"})
+
+	s, err := signer.NewKeylessSigner(context.Background(), token, signer.WithFulcioURL(fulcio.URL+"/"))
+	if err != nil {
+		t.Fatalf("NewKeylessSigner: %v", err)
+	}
+
+	sig, err := signer.Sign(context.Background(), testRecord(t), s)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	bundle, err := sig.Bundle()
+	if err != nil {
+		t.Fatalf("Bundle: %v", err)
+	}
+
+	if len(bundle.CertificateChain) != 1 || bundle.PublicKey != "" || sig.Certificate == "" {
+		t.Fatalf("unexpected keyless signature %+v / %+v", sig, bundle)
+	}
+
+	block, _ := pem.Decode([]byte(bundle.CertificateChain[0]))
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+
+	payload, _ := signer.CanonicalJSON(testRecord(t))
+	digest := sha256.Sum256(payload)
+	raw, _ := base64.StdEncoding.DecodeString(sig.Signature)
+
+	certKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok || !ecdsa.VerifyASN1(certKey, digest[:], raw) {
+		t.Error("signature does not verify against the certificate key")
+	}
+}
+
+func TestKeylessSignerErrors(t *testing.T) {
+	fulcio, _ := newFakeFulcio(t, "dev@example.org")
+	defer fulcio.Close()
+
+	ctx := context.Background()
+
+	for name, token := range map[string]string{
+		"not a jwt":        "token",
+		"no subject":       identityToken(t, map[string]string{}),
+		"wrong identity":   identityToken(t, map[string]string{"email": "other@example.org"}),
+		"undecodable body": "e30.!!!.sig",
+	} {
+		if _, err := signer.NewKeylessSigner(ctx, token, signer.WithFulcioURL(fulcio.URL)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	unreachable := &url.URL{Scheme: "http", Host: "127.0.0.1:1"}
+	token := identityToken(t, map[string]string{"sub": "dev@example.org"})
+
+	if _, err := signer.NewKeylessSigner(ctx, token, signer.WithFulcioURL(unreachable.String())); err == nil {
+		t.Error("expected an error for an unreachable Fulcio")
+	}
+}
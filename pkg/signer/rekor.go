@@ -0,0 +1,176 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultRekorURL is the public Sigstore Rekor instance.
+const DefaultRekorURL = "https://rekor.sigstore.dev"
+
+// Signature annotations set when a signature is recorded in a transparency
+// log. They are informational; verification relies on the entry in the
+// content bundle.
+const (
+	LogIndexAnnotation = "transparency_log.log_index"
+	LogURLAnnotation   = "transparency_log.url"
+)
+
+type signOptions struct {
+	rekorURL   string
+	httpClient *http.Client
+}
+
+// SignOption configures Sign and SignPayload.
+type SignOption func(*signOptions)
+
+// WithTransparencyLog records every signature in the Rekor instance at url
+// (see DefaultRekorURL). The returned entry, with its signed entry timestamp
+// and inclusion proof, is stored in the content bundle so the signature can
+// be checked against the log offline.
+func WithTransparencyLog(url string) SignOption {
+	return func(o *signOptions) {
+		o.rekorURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// WithLogHTTPClient sets the HTTP client used to talk to Rekor.
+func WithLogHTTPClient(client *http.Client) SignOption {
+	return func(o *signOptions) {
+		o.httpClient = client
+	}
+}
+
+// rekorEntry is a log entry as returned by the Rekor API.
+type rekorEntry struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+	Verification   struct {
+		SignedEntryTimestamp string `json:"signedEntryTimestamp"`
+		InclusionProof       *struct {
+			LogIndex   int64    `json:"logIndex"`
+			TreeSize   int64    `json:"treeSize"`
+			RootHash   string   `json:"rootHash"`
+			Hashes     []string `json:"hashes"`
+			Checkpoint string   `json:"checkpoint"`
+		} `json:"inclusionProof"`
+	} `json:"verification"`
+}
+
+// uploadLogEntry records a signature as a hashedrekord entry. publicKey is
+// the PEM encoded key or leaf certificate that verifies it.
+func uploadLogEntry(ctx context.Context, o *signOptions, digest, signature, publicKey string) (*TransparencyLogEntry, error) {
+	var body struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+		Spec       struct {
+			Data struct {
+				Hash struct {
+					Algorithm string `json:"algorithm"`
+					Value     string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content   string `json:"content"`
+				PublicKey struct {
+					Content string `json:"content"`
+				} `json:"publicKey"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}
+
+	body.APIVersion = "0.0.1"
+	body.Kind = "hashedrekord"
+	body.Spec.Data.Hash.Algorithm = "sha256"
+	body.Spec.Data.Hash.Value = strings.TrimPrefix(digest, "sha256:")
+	body.Spec.Signature.Content = signature
+	body.Spec.Signature.PublicKey.Content = base64.StdEncoding.EncodeToString([]byte(publicKey))
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal log entry: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.rekorURL+"/api/v1/log/entries", bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log entry request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload log entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log entry response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("rekor returned %s: %s", resp.Status, strings.TrimSpace(string(raw)))
+	}
+
+	// The response maps the entry UUID to the entry.
+	var parsed map[string]rekorEntry
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse log entry response: %w", err)
+	}
+
+	if len(parsed) != 1 {
+		return nil, fmt.Errorf("rekor returned %d entries, expected 1", len(parsed))
+	}
+
+	var e rekorEntry
+	for _, v := range parsed {
+		e = v
+	}
+
+	if e.Body == "" || e.Verification.SignedEntryTimestamp == "" {
+		return nil, errors.New("rekor returned an entry without body or signed entry timestamp")
+	}
+
+	entry := &TransparencyLogEntry{
+		LogIndex:             e.LogIndex,
+		LogID:                e.LogID,
+		IntegratedTime:       e.IntegratedTime,
+		Body:                 e.Body,
+		SignedEntryTimestamp: e.Verification.SignedEntryTimestamp,
+	}
+
+	if p := e.Verification.InclusionProof; p != nil {
+		entry.InclusionProof = &InclusionProof{
+			LogIndex:   p.LogIndex,
+			TreeSize:   p.TreeSize,
+			RootHash:   p.RootHash,
+			Hashes:     p.Hashes,
+			Checkpoint: p.Checkpoint,
+		}
+	}
+
+	return entry, nil
+}
+
+// logAnnotations returns the signature annotations of a recorded entry.
+func logAnnotations(o *signOptions, entry *TransparencyLogEntry) map[string]string {
+	return map[string]string{
+		LogIndexAnnotation: strconv.FormatInt(entry.LogIndex, 10),
+		LogURLAnnotation:   o.rekorURL,
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+)
+
+// fakeRekor serves the Rekor entry upload API, recording every uploaded body
+// at index 2004 of its log. Inclusion proofs are omitted when withProof is
+// false.
+func fakeRekor(t *testing.T, rekorKey *ecdsa.PrivateKey, withProof bool) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/log/entries" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		leaves := logLeaves(2010, 2004, body)
+
+		entry := map[string]any{
+			"body":           base64.StdEncoding.EncodeToString(body),
+			"integratedTime": time.Now().Add(-time.Second).Unix(),
+			"logID":          "c0ffee",
+			"logIndex":       int64(2004),
+		}
+
+		setPayload, _ := json.Marshal(entry)
+		setDigest := sha256.Sum256(setPayload)
+		set, _ := ecdsa.SignASN1(rand.Reader, rekorKey, setDigest[:])
+
+		verification := map[string]any{"signedEntryTimestamp": base64.StdEncoding.EncodeToString(set)}
+		if withProof {
+			root := merkleRoot(leaves)
+			verification["inclusionProof"] = map[string]any{
+				"logIndex":   2004,
+				"treeSize":   len(leaves),
+				"rootHash":   hex.EncodeToString(root),
+				"hashes":     merklePath(2004, leaves),
+				"checkpoint": signedCheckpoint(t, rekorKey, len(leaves), root),
+			}
+		}
+
+		entry["verification"] = verification
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"24296fb24b8ad77a": entry})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestSignTransparencyLog(t *testing.T) {
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	s, err := signer.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner: %v", err)
+	}
+
+	rec := testRecord(t)
+	srv := fakeRekor(t, rekorKey, true)
+
+	sig, err := signer.Sign(context.Background(), rec, s, signer.WithTransparencyLog(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if sig.Annotations[signer.LogIndexAnnotation] != "2004" || sig.Annotations[signer.LogURLAnnotation] != srv.URL {
+		t.Errorf("unexpected annotations %v", sig.Annotations)
+	}
+
+	policy := signer.Policy{
+		TrustedKeys:           []crypto.PublicKey{key.Public()},
+		RekorKeys:             []crypto.PublicKey{rekorKey.Public()},
+		RequireInclusionProof: true,
+	}
+
+	result, err := signer.VerifyDetached(rec, sig, policy)
+	if err != nil || result.LogIndex == nil || *result.LogIndex != 2004 {
+		t.Fatalf("VerifyDetached: %v %+v", err, result)
+	}
+
+	// A signed entry timestamp alone is a promise of inclusion, not a proof.
+	sig, err = signer.Sign(context.Background(), rec, s, signer.WithTransparencyLog(fakeRekor(t, rekorKey, false).URL))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if result, err := signer.VerifyDetached(rec, sig, policy); !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckTransparencyLog {
+		t.Errorf("entry without inclusion proof: %v %+v", err, result)
+	}
+
+	policy.RequireInclusionProof = false
+
+	if _, err := signer.VerifyDetached(rec, sig, policy); err != nil {
+		t.Errorf("entry without inclusion proof, not required: %v", err)
+	}
+
+	// Without a log entry at all, requiring inclusion rejects the signature.
+	plain, err := signer.Sign(context.Background(), rec, s)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	policy.RequireInclusionProof = true
+
+	if result, err := signer.VerifyDetached(rec, plain, policy); err == nil || failedCheck(result) != signer.CheckTransparencyLog {
+		t.Errorf("signature without log entry: %v %+v", err, result)
+	}
+}
+
+func TestSignTransparencyLogError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "entry already exists", http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s, _ := signer.NewKeySigner(key)
+
+	if _, err := signer.Sign(context.Background(), testRecord(t), s, signer.WithTransparencyLog(srv.URL)); err == nil {
+		t.Error("expected an error when the log rejects the entry")
+	}
+}
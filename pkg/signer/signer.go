@@ -0,0 +1,232 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signer signs OASF records, producing the OASF signature object
+// (algorithm, signature, certificate and a content bundle carrying the digest
+// of the signed content and the key material to verify it).
+//
+// Records are signed with a private key (NewKeySigner) or keyless, with a
+// short-lived certificate issued by a Sigstore Fulcio instance for an OIDC
+// identity (NewKeylessSigner), and optionally recorded in a Rekor
+// transparency log (WithTransparencyLog). Verify and VerifyDetached check signatures
+// against a Policy of trusted keys, certificate roots and identities.
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// BundleContentType is the content type of the bundle produced by Sign.
+const BundleContentType = "application/vnd.agntcy.oasf.signature-bundle.v1+json"
+
+// Signer produces raw signatures over payloads.
+type Signer interface {
+	// Algorithm returns the JWA name of the signature algorithm (ES256 or EdDSA).
+	Algorithm() string
+	// Public returns the public key matching the signing key.
+	Public() crypto.PublicKey
+	// CertificateChain returns the PEM encoded certificate chain of keyless
+	// signers, leaf first, and nil for plain keys.
+	CertificateChain() []string
+	// Sign signs payload. ECDSA signers sign its SHA-256 digest.
+	Sign(ctx context.Context, payload []byte) ([]byte, error)
+}
+
+// Signature is the OASF signature object.
+type Signature struct {
+	Annotations   map[string]string `json:"annotations,omitempty"`
+	SignedAt      string            `json:"signed_at"`
+	Algorithm     string            `json:"algorithm"`
+	Signature     string            `json:"signature"`
+	Certificate   string            `json:"certificate,omitempty"`
+	ContentType   string            `json:"content_type"`
+	ContentBundle string            `json:"content_bundle"`
+}
+
+// Bundle is the decoded content bundle of a Signature.
+type Bundle struct {
+	// Digest is the "sha256:<hex>" digest of the signed content.
+	Digest string `json:"digest"`
+	// PublicKey is the PEM encoded public key of key-based signatures.
+	PublicKey string `json:"public_key,omitempty"` //nolint:tagliatelle
+	// CertificateChain is the PEM encoded certificate chain of keyless
+	// signatures, leaf first.
+	CertificateChain []string `json:"certificate_chain,omitempty"` //nolint:tagliatelle
+	// TransparencyLog is the Rekor entry of the signature, when it was
+	// recorded in a transparency log.
+	TransparencyLog *TransparencyLogEntry `json:"transparency_log,omitempty"` //nolint:tagliatelle
+}
+
+// TransparencyLogEntry is a Rekor log entry with the proofs needed to check
+// it offline.
+type TransparencyLogEntry struct {
+	LogIndex       int64  `json:"log_index"`       //nolint:tagliatelle
+	LogID          string `json:"log_id"`          //nolint:tagliatelle
+	IntegratedTime int64  `json:"integrated_time"` //nolint:tagliatelle
+	// Body is the base64 encoded canonical entry body.
+	Body string `json:"body"`
+	// SignedEntryTimestamp is the log's base64 encoded signature over the
+	// entry, attesting its integrated time.
+	SignedEntryTimestamp string          `json:"signed_entry_timestamp"`    //nolint:tagliatelle
+	InclusionProof       *InclusionProof `json:"inclusion_proof,omitempty"` //nolint:tagliatelle
+}
+
+// InclusionProof is an RFC 6962 Merkle inclusion proof of a log entry.
+type InclusionProof struct {
+	LogIndex int64 `json:"log_index"` //nolint:tagliatelle
+	TreeSize int64 `json:"tree_size"` //nolint:tagliatelle
+	// RootHash and Hashes are hex encoded.
+	RootHash string   `json:"root_hash"` //nolint:tagliatelle
+	Hashes   []string `json:"hashes"`
+	// Checkpoint is the log's signed note committing to TreeSize and
+	// RootHash.
+	Checkpoint string `json:"checkpoint,omitempty"`
+}
+
+// CanonicalJSON serializes a record as compact JSON with sorted keys and
+// without its "signature" field, so the same record always produces the same
+// bytes and an embedded signature does not cover itself.
+func CanonicalJSON(record *structpb.Struct) ([]byte, error) {
+	return recordutil.CanonicalJSON(record, recordutil.Exclusions{"signature"}) //nolint:wrapcheck
+}
+
+// Sign signs the canonical JSON of the record (see CanonicalJSON).
+func Sign(ctx context.Context, record *structpb.Struct, s Signer, opts ...SignOption) (*Signature, error) {
+	payload, err := CanonicalJSON(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return SignPayload(ctx, payload, s, opts...)
+}
+
+// SignPayload signs arbitrary content, e.g. the exact bytes of a record
+// pushed to a registry.
+func SignPayload(ctx context.Context, payload []byte, s Signer, opts ...SignOption) (*Signature, error) {
+	if s == nil {
+		return nil, errors.New("signer is nil")
+	}
+
+	o := &signOptions{httpClient: &http.Client{Timeout: defaultSigstoreTimeout}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	raw, err := s.Sign(ctx, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign record: %w", err)
+	}
+
+	bundle := Bundle{Digest: Digest(payload), CertificateChain: s.CertificateChain()}
+
+	if len(bundle.CertificateChain) == 0 {
+		bundle.PublicKey, err = EncodePublicKey(s.Public())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	var annotations map[string]string
+
+	if o.rekorURL != "" {
+		verifier := bundle.PublicKey
+		if verifier == "" {
+			verifier = bundle.CertificateChain[0]
+		}
+
+		bundle.TransparencyLog, err = uploadLogEntry(ctx, o, bundle.Digest, encoded, verifier)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations = logAnnotations(o, bundle.TransparencyLog)
+	}
+
+	bundleJSON, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature bundle: %w", err)
+	}
+
+	signature := &Signature{
+		Annotations:   annotations,
+		SignedAt:      time.Now().UTC().Format(time.RFC3339),
+		Algorithm:     s.Algorithm(),
+		Signature:     encoded,
+		ContentType:   BundleContentType,
+		ContentBundle: base64.StdEncoding.EncodeToString(bundleJSON),
+	}
+
+	if len(bundle.CertificateChain) > 0 {
+		signature.Certificate = base64.StdEncoding.EncodeToString([]byte(bundle.CertificateChain[0]))
+	}
+
+	return signature, nil
+}
+
+// Bundle decodes the signature's content bundle.
+func (s *Signature) Bundle() (*Bundle, error) {
+	if s.ContentType != BundleContentType {
+		return nil, fmt.Errorf("unsupported signature content type %q", s.ContentType)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(s.ContentBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature bundle: %w", err)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to parse signature bundle: %w", err)
+	}
+
+	return &bundle, nil
+}
+
+// AsStruct returns the signature as a struct, e.g. to embed it into a 0.7.0
+// record or return it over gRPC.
+func (s *Signature) AsStruct() (*structpb.Struct, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+
+	out := &structpb.Struct{}
+	if err := out.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to convert signature: %w", err)
+	}
+
+	return out, nil
+}
+
+// Digest returns the "sha256:<hex>" digest of data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// EncodePublicKey encodes a public key as a PEM "PUBLIC KEY" block.
+func EncodePublicKey(public crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	rec, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/agent",
+		"version":        "v1.0.0",
+		"schema_version": "1.0.0",
+		"skills":         []any{map[string]any{"id": 10201}},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	return rec
+}
+
+func TestSignECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+
+	loaded, err := signer.LoadPrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("LoadPrivateKey: %v", err)
+	}
+
+	s, err := signer.NewKeySigner(loaded)
+	if err != nil {
+		t.Fatalf("NewKeySigner: %v", err)
+	}
+
+	rec := testRecord(t)
+
+	sig, err := signer.Sign(context.Background(), rec, s)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	if sig.Algorithm != signer.AlgorithmES256 || sig.SignedAt == "" || sig.Certificate != "" {
+		t.Errorf("unexpected signature %+v", sig)
+	}
+
+	payload, err := signer.CanonicalJSON(rec)
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(sig.Signature)
+	digest := sha256.Sum256(payload)
+
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], raw) {
+		t.Error("signature does not verify")
+	}
+
+	bundle, err := sig.Bundle()
+	if err != nil {
+		t.Fatalf("Bundle: %v", err)
+	}
+
+	wantKey, _ := signer.EncodePublicKey(key.Public())
+	if bundle.Digest != signer.Digest(payload) || bundle.PublicKey != wantKey || len(bundle.CertificateChain) != 0 {
+		t.Errorf("unexpected bundle %+v", bundle)
+	}
+}
+
+func TestSignEd25519(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	s, err := signer.NewKeySigner(private)
+	if err != nil {
+		t.Fatalf("NewKeySigner: %v", err)
+	}
+
+	payload := []byte(`{"name":"example.org/agent"}`)
+
+	sig, err := signer.SignPayload(context.Background(), payload, s)
+	if err != nil {
+		t.Fatalf("SignPayload: %v", err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(sig.Signature)
+	if sig.Algorithm != signer.AlgorithmEdDSA || !ed25519.Verify(public, payload, raw) {
+		t.Errorf("signature does not verify: %+v", sig)
+	}
+
+	st, err := sig.AsStruct()
+	if err != nil {
+		t.Fatalf("AsStruct: %v", err)
+	}
+
+	if st.GetFields()["content_type"].GetStringValue() != signer.BundleContentType || st.GetFields()["signature"].GetStringValue() != sig.Signature {
+		t.Errorf("unexpected signature object %v", st.AsMap())
+	}
+}
+
+func TestCanonicalJSONIgnoresSignature(t *testing.T) {
+	rec := testRecord(t)
+
+	unsigned, err := signer.CanonicalJSON(rec)
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+
+	rec.Fields["signature"] = structpb.NewStructValue(&structpb.Struct{
+		Fields: map[string]*structpb.Value{"algorithm": structpb.NewStringValue("ES256")},
+	})
+
+	signed, err := signer.CanonicalJSON(rec)
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+
+	if string(signed) != string(unsigned) {
+		t.Errorf("embedded signature changed the payload:\n%s\n%s", unsigned, signed)
+	}
+}
+
+func TestNewKeySignerRejectsUnsupportedKeys(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	for _, key := range []any{p384, "not a key", nil} {
+		if _, err := signer.NewKeySigner(key); err == nil {
+			t.Errorf("NewKeySigner(%T): expected an error", key)
+		}
+	}
+
+	if _, err := signer.LoadPrivateKey([]byte("not pem")); err == nil {
+		t.Error("LoadPrivateKey: expected an error for non-PEM data")
+	}
+}
@@ -0,0 +1,621 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrVerificationFailed is returned (wrapped, with the failed checks) when a
+// signature does not satisfy the policy.
+var ErrVerificationFailed = errors.New("signature verification failed")
+
+// Fulcio certificate extensions carrying the OIDC issuer of the identity.
+var (
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Verification check names reported in Result.Checks.
+const (
+	CheckDigest          = "digest"
+	CheckSigner          = "signer"
+	CheckSignature       = "signature"
+	CheckTransparencyLog = "transparency-log"
+	CheckExpiry          = "expiry"
+)
+
+// Policy decides which signatures are trusted.
+type Policy struct {
+	// TrustedKeys are the public keys accepted for key-based signatures.
+	TrustedKeys []crypto.PublicKey
+	// Roots are the CA certificates accepted for keyless signatures.
+	Roots *x509.CertPool
+	// Issuers restricts the OIDC issuers of keyless identities; empty allows
+	// any issuer.
+	Issuers []string
+	// Identities restricts keyless identities (certificate email or URI);
+	// '*' matches any sequence of characters. Empty allows any identity.
+	Identities []string
+	// RekorKeys are the transparency log keys accepted for signed entry
+	// timestamps. Log entries are only trusted when they verify against one.
+	RekorKeys []crypto.PublicKey
+	// RequireTransparencyLog rejects signatures without a verified log entry.
+	RequireTransparencyLog bool
+	// RequireInclusionProof rejects log entries without an inclusion proof,
+	// so a signed entry timestamp alone (a promise of inclusion) is not
+	// enough. The proof must lead to the root of a checkpoint signed with
+	// one of RekorKeys. It implies RequireTransparencyLog.
+	RequireInclusionProof bool
+	// MaxAge rejects signatures older than this; zero disables the check.
+	// The signing time must be attested by a verified log entry, as the
+	// signer-provided signed_at is not covered by the signature.
+	MaxAge time.Duration
+	// CurrentTime overrides the time used for expiry checks (defaults to now).
+	CurrentTime time.Time
+}
+
+// Check is the outcome of one verification step.
+type Check struct {
+	Name   string `json:"name"             yaml:"name"`
+	Passed bool   `json:"passed"           yaml:"passed"`
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// Result describes a verified (or rejected) signature.
+type Result struct {
+	Verified  bool   `json:"verified"            yaml:"verified"`
+	Algorithm string `json:"algorithm"           yaml:"algorithm"`
+	Digest    string `json:"digest"              yaml:"digest"`
+	// Identity and Issuer are set for keyless signatures.
+	Identity string `json:"identity,omitempty" yaml:"identity,omitempty"`
+	Issuer   string `json:"issuer,omitempty"   yaml:"issuer,omitempty"`
+	// SignedAt is the log's integrated time when the signature has a verified
+	// log entry, and the signer-provided signed_at otherwise. Only the former
+	// is trusted: signed_at is not covered by the signature.
+	SignedAt time.Time `json:"signed_at"           yaml:"signed_at"`           //nolint:tagliatelle
+	LogIndex *int64    `json:"log_index,omitempty" yaml:"log_index,omitempty"` //nolint:tagliatelle
+	Checks   []Check   `json:"checks"              yaml:"checks"`
+}
+
+// ParseSignature converts an OASF signature object, e.g. the "signature"
+// field of a 0.7.0 record, into a Signature.
+func ParseSignature(s *structpb.Struct) (*Signature, error) {
+	if s == nil {
+		return nil, errors.New("signature is nil")
+	}
+
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+
+	var sig Signature
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+
+	return &sig, nil
+}
+
+// Verify verifies the signature embedded in the record's "signature" field.
+func Verify(record *structpb.Struct, policy Policy) (*Result, error) {
+	embedded := record.GetFields()["signature"].GetStructValue()
+	if embedded == nil {
+		return nil, errors.New("record has no signature")
+	}
+
+	sig, err := ParseSignature(embedded)
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyDetached(record, sig, policy)
+}
+
+// VerifyDetached verifies a signature over the record's canonical JSON.
+func VerifyDetached(record *structpb.Struct, sig *Signature, policy Policy) (*Result, error) {
+	payload, err := CanonicalJSON(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return VerifyPayload(payload, sig, policy)
+}
+
+// VerifyPayload verifies a signature over payload against the policy. The
+// result lists every check; the error wraps ErrVerificationFailed when any of
+// them failed.
+func VerifyPayload(payload []byte, sig *Signature, policy Policy) (*Result, error) {
+	if sig == nil {
+		return nil, errors.New("signature is nil")
+	}
+
+	bundle, err := sig.Bundle()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Algorithm: sig.Algorithm, Digest: Digest(payload)}
+
+	check := func(name string, err error, detail string) bool {
+		c := Check{Name: name, Passed: err == nil, Detail: detail}
+		if err != nil {
+			c.Detail = err.Error()
+		}
+
+		result.Checks = append(result.Checks, c)
+
+		return err == nil
+	}
+
+	if bundle.Digest != result.Digest {
+		check(CheckDigest, fmt.Errorf("content digest %s does not match %s", result.Digest, bundle.Digest), "")
+
+		return result, failed(result)
+	}
+
+	check(CheckDigest, nil, result.Digest)
+
+	raw, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		check(CheckSignature, fmt.Errorf("invalid signature encoding: %w", err), "")
+
+		return result, failed(result)
+	}
+
+	// The signing time decides certificate validity and expiry. It is the
+	// log's attested time when available, the signer's claim otherwise; the
+	// claim is not signed, so anyone can change it, and it is only used to
+	// reject signatures from the future.
+	signedAt, timeErr := time.Parse(time.RFC3339, sig.SignedAt)
+	attested := false
+
+	if bundle.TransparencyLog != nil {
+		integrated, err := verifyLogEntry(bundle.TransparencyLog, result.Digest, sig.Signature, policy.RekorKeys)
+		if err == nil && policy.RequireInclusionProof && bundle.TransparencyLog.InclusionProof == nil {
+			err = errors.New("log entry has no inclusion proof")
+		}
+
+		if check(CheckTransparencyLog, err, fmt.Sprintf("log index %d", bundle.TransparencyLog.LogIndex)) {
+			signedAt, timeErr, attested = integrated, nil, true
+			index := bundle.TransparencyLog.LogIndex
+			result.LogIndex = &index
+		}
+	} else if policy.RequireTransparencyLog || policy.RequireInclusionProof {
+		check(CheckTransparencyLog, errors.New("signature has no transparency log entry"), "")
+	}
+
+	if timeErr != nil {
+		check(CheckExpiry, fmt.Errorf("invalid signed_at %q", sig.SignedAt), "")
+
+		return result, failed(result)
+	}
+
+	result.SignedAt = signedAt
+
+	public, err := verifySigner(bundle, signedAt, attested, policy, result)
+	if !check(CheckSigner, err, result.Identity) {
+		return result, failed(result)
+	}
+
+	check(CheckSignature, verifyRaw(public, sig.Algorithm, payload, raw), sig.Algorithm)
+
+	now := policy.CurrentTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	switch {
+	case policy.MaxAge > 0 && !attested:
+		check(CheckExpiry, errors.New("signature age needs a signing time attested by a verified transparency log entry"), "")
+	case policy.MaxAge > 0 && now.Sub(signedAt) > policy.MaxAge:
+		check(CheckExpiry, fmt.Errorf("signature from %s is older than %s", signedAt.Format(time.RFC3339), policy.MaxAge), "")
+	case signedAt.After(now.Add(time.Minute)):
+		check(CheckExpiry, fmt.Errorf("signature time %s is in the future", signedAt.Format(time.RFC3339)), "")
+	case attested:
+		check(CheckExpiry, nil, "signing time attested by the transparency log")
+	default:
+		check(CheckExpiry, nil, "signing time claimed by the signer")
+	}
+
+	return result, failed(result)
+}
+
+// failed sets Verified and returns the failed checks as an error.
+func failed(result *Result) error {
+	var errs []error
+
+	for _, c := range result.Checks {
+		if !c.Passed {
+			errs = append(errs, fmt.Errorf("%s: %s", c.Name, c.Detail))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrVerificationFailed, errors.Join(errs...))
+	}
+
+	result.Verified = true
+
+	return nil
+}
+
+// verifySigner returns the key that made the signature after checking that
+// the policy trusts it: a trusted key, or a certificate chaining to a trusted
+// root with an allowed identity. Certificates are short-lived, so they are
+// only checked at a signing time attested by the transparency log.
+func verifySigner(bundle *Bundle, signedAt time.Time, attested bool, policy Policy, result *Result) (crypto.PublicKey, error) {
+	if len(bundle.CertificateChain) == 0 {
+		public, err := parsePublicKey(bundle.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trusted := range policy.TrustedKeys {
+			if k, ok := trusted.(interface{ Equal(x crypto.PublicKey) bool }); ok && k.Equal(public) {
+				return public, nil
+			}
+		}
+
+		return nil, errors.New("signing key is not trusted")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(bundle.CertificateChain))
+
+	for _, certPEM := range bundle.CertificateChain {
+		block, _ := pem.Decode([]byte(certPEM))
+		if block == nil {
+			return nil, errors.New("invalid certificate in chain")
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate in chain: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if policy.Roots == nil {
+		return nil, errors.New("no trusted roots configured for keyless signatures")
+	}
+
+	if !attested {
+		return nil, errors.New("certificate signatures need a signing time attested by a verified transparency log entry")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	leaf := certs[0]
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         policy.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   signedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return nil, fmt.Errorf("certificate not valid at signing time: %w", err)
+	}
+
+	result.Identity = certificateIdentity(leaf)
+	result.Issuer = certificateIssuer(leaf)
+
+	if len(policy.Issuers) > 0 && !slices.Contains(policy.Issuers, result.Issuer) {
+		return nil, fmt.Errorf("issuer %q is not allowed", result.Issuer)
+	}
+
+	if len(policy.Identities) > 0 && !slices.ContainsFunc(policy.Identities, func(pattern string) bool {
+		return matchIdentity(pattern, result.Identity)
+	}) {
+		return nil, fmt.Errorf("identity %q is not allowed", result.Identity)
+	}
+
+	return leaf.PublicKey, nil
+}
+
+func verifyRaw(public crypto.PublicKey, algorithm string, payload, raw []byte) error {
+	switch k := public.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		if algorithm != AlgorithmES256 || !ecdsa.VerifyASN1(k, digest[:], raw) {
+			return errors.New("signature does not match the content")
+		}
+	case ed25519.PublicKey:
+		if algorithm != AlgorithmEdDSA || !ed25519.Verify(k, payload, raw) {
+			return errors.New("signature does not match the content")
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", public)
+	}
+
+	return nil
+}
+
+// hashedRekord is the part of a Rekor hashedrekord entry body checked
+// against the signature.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content string `json:"content"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyLogEntry checks that the entry records this signature, that the log
+// signed it and, when present, its inclusion proof. It returns the entry's
+// integrated time.
+func verifyLogEntry(entry *TransparencyLogEntry, digest, signature string, rekorKeys []crypto.PublicKey) (time.Time, error) {
+	body, err := base64.StdEncoding.DecodeString(entry.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid entry body: %w", err)
+	}
+
+	var rekord hashedRekord
+	if err := json.Unmarshal(body, &rekord); err != nil {
+		return time.Time{}, fmt.Errorf("invalid entry body: %w", err)
+	}
+
+	if rekord.Kind != "hashedrekord" || rekord.Spec.Data.Hash.Algorithm != "sha256" ||
+		"sha256:"+rekord.Spec.Data.Hash.Value != digest || rekord.Spec.Signature.Content != signature {
+		return time.Time{}, errors.New("log entry does not record this signature")
+	}
+
+	if len(rekorKeys) == 0 {
+		return time.Time{}, errors.New("no transparency log keys configured")
+	}
+
+	// The signed entry timestamp covers the canonical JSON of these fields;
+	// json.Marshal sorts map keys.
+	setPayload, err := json.Marshal(map[string]any{
+		"body":           entry.Body,
+		"integratedTime": entry.IntegratedTime,
+		"logID":          entry.LogID,
+		"logIndex":       entry.LogIndex,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to encode log entry: %w", err)
+	}
+
+	set, err := base64.StdEncoding.DecodeString(entry.SignedEntryTimestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid signed entry timestamp: %w", err)
+	}
+
+	setDigest := sha256.Sum256(setPayload)
+
+	if !slices.ContainsFunc(rekorKeys, func(key crypto.PublicKey) bool {
+		k, ok := key.(*ecdsa.PublicKey)
+
+		return ok && ecdsa.VerifyASN1(k, setDigest[:], set)
+	}) {
+		return time.Time{}, errors.New("signed entry timestamp does not verify against a trusted log key")
+	}
+
+	if entry.InclusionProof != nil {
+		if err := verifyInclusion(entry.InclusionProof, entry.LogIndex, body, rekorKeys); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	return time.Unix(entry.IntegratedTime, 0).UTC(), nil
+}
+
+// verifyInclusion checks an RFC 6962 inclusion proof of the entry body at
+// logIndex, following the algorithm of RFC 9162 section 2.1.3.2. The root
+// and tree size of the proof come from the bundle, so they must match those
+// of the checkpoint the log signed.
+func verifyInclusion(proof *InclusionProof, logIndex int64, body []byte, rekorKeys []crypto.PublicKey) error {
+	if proof.LogIndex != logIndex {
+		return fmt.Errorf("inclusion proof index %d does not match log index %d", proof.LogIndex, logIndex)
+	}
+
+	if proof.LogIndex < 0 || proof.LogIndex >= proof.TreeSize {
+		return fmt.Errorf("inclusion proof index %d outside tree of size %d", proof.LogIndex, proof.TreeSize)
+	}
+
+	root, err := hex.DecodeString(proof.RootHash)
+	if err != nil {
+		return fmt.Errorf("invalid inclusion proof root hash: %w", err)
+	}
+
+	if proof.Checkpoint == "" {
+		return errors.New("inclusion proof has no checkpoint")
+	}
+
+	treeSize, checkpointRoot, err := verifyCheckpoint(proof.Checkpoint, rekorKeys)
+	if err != nil {
+		return err
+	}
+
+	if treeSize != proof.TreeSize || !bytes.Equal(checkpointRoot, root) {
+		return errors.New("inclusion proof does not match the log checkpoint")
+	}
+
+	fn, sn := proof.LogIndex, proof.TreeSize-1
+	r := leafHash(body)
+
+	for _, h := range proof.Hashes {
+		p, err := hex.DecodeString(h)
+		if err != nil {
+			return fmt.Errorf("invalid inclusion proof hash: %w", err)
+		}
+
+		if sn == 0 {
+			return errors.New("inclusion proof is too long")
+		}
+
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || !bytes.Equal(r, root) {
+		return errors.New("inclusion proof does not match the root hash")
+	}
+
+	return nil
+}
+
+// checkpointKeyHintSize is the size of the key hint starting the signatures
+// of a checkpoint.
+const checkpointKeyHintSize = 4
+
+// verifyCheckpoint checks that a checkpoint, a signed note whose text is
+// the log origin, the tree size and the base64 root hash, is signed with a
+// trusted log key, and returns its tree size and root hash.
+func verifyCheckpoint(checkpoint string, rekorKeys []crypto.PublicKey) (int64, []byte, error) {
+	text, signatures, ok := strings.Cut(checkpoint, "\n\n")
+	if !ok {
+		return 0, nil, errors.New("invalid checkpoint: no signatures")
+	}
+
+	lines := strings.Split(text, "\n")
+	if len(lines) < 3 { //nolint:mnd
+		return 0, nil, errors.New("invalid checkpoint: missing tree size or root hash")
+	}
+
+	treeSize, err := strconv.ParseInt(lines[1], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid checkpoint tree size: %w", err)
+	}
+
+	root, err := base64.StdEncoding.DecodeString(lines[2])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid checkpoint root hash: %w", err)
+	}
+
+	// The signed text ends with the newline before the blank line.
+	digest := sha256.Sum256([]byte(text + "\n"))
+
+	for _, line := range strings.Split(signatures, "\n") {
+		// "— <key name> <base64 of a 4 byte key hint and the signature>"
+		signature, ok := strings.CutPrefix(line, "— ")
+		if !ok {
+			continue
+		}
+
+		raw, err := base64.StdEncoding.DecodeString(signature[strings.LastIndex(signature, " ")+1:])
+		if err != nil || len(raw) <= checkpointKeyHintSize {
+			continue
+		}
+
+		if slices.ContainsFunc(rekorKeys, func(key crypto.PublicKey) bool {
+			k, ok := key.(*ecdsa.PublicKey)
+
+			return ok && ecdsa.VerifyASN1(k, digest[:], raw[checkpointKeyHintSize:])
+		}) {
+			return treeSize, root, nil
+		}
+	}
+
+	return 0, nil, errors.New("checkpoint is not signed by a trusted log key")
+}
+
+func leafHash(data []byte) []byte {
+	sum := sha256.Sum256(append([]byte{0}, data...))
+
+	return sum[:]
+}
+
+func nodeHash(left, right []byte) []byte {
+	sum := sha256.Sum256(append(append([]byte{1}, left...), right...))
+
+	return sum[:]
+}
+
+func parsePublicKey(keyPEM string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("signature bundle has no public key")
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	return public, nil
+}
+
+// certificateIdentity returns the identity a Fulcio certificate was issued
+// for: its email address, or its URI (e.g. a CI workflow).
+func certificateIdentity(cert *x509.Certificate) string {
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+
+	return ""
+}
+
+// certificateIssuer returns the OIDC issuer recorded in a Fulcio certificate.
+func certificateIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(oidIssuerV1):
+			return string(ext.Value)
+		}
+	}
+
+	return ""
+}
+
+// matchIdentity matches an identity against a pattern where '*' matches any
+// sequence of characters.
+func matchIdentity(pattern, identity string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(identity)
+}
@@ -0,0 +1,432 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package signer_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func keySignature(t *testing.T, rec *structpb.Struct) (*signer.Signature, crypto.PublicKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	s, err := signer.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner: %v", err)
+	}
+
+	sig, err := signer.Sign(context.Background(), rec, s)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	return sig, key.Public()
+}
+
+func failedCheck(result *signer.Result) string {
+	for _, c := range result.Checks {
+		if !c.Passed {
+			return c.Name
+		}
+	}
+
+	return ""
+}
+
+func TestVerifyKey(t *testing.T) {
+	rec := testRecord(t)
+	sig, public := keySignature(t, rec)
+
+	result, err := signer.VerifyDetached(rec, sig, signer.Policy{TrustedKeys: []crypto.PublicKey{public}})
+	if err != nil || !result.Verified {
+		t.Fatalf("VerifyDetached: %v %+v", err, result)
+	}
+
+	_, other := keySignature(t, rec)
+
+	result, err = signer.VerifyDetached(rec, sig, signer.Policy{TrustedKeys: []crypto.PublicKey{other}})
+	if !errors.Is(err, signer.ErrVerificationFailed) || result.Verified || failedCheck(result) != signer.CheckSigner {
+		t.Errorf("untrusted key: %v %+v", err, result)
+	}
+
+	rec.Fields["version"] = structpb.NewStringValue("v2.0.0")
+
+	result, err = signer.VerifyDetached(rec, sig, signer.Policy{TrustedKeys: []crypto.PublicKey{public}})
+	if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckDigest {
+		t.Errorf("modified record: %v %+v", err, result)
+	}
+}
+
+func TestVerifyEmbedded(t *testing.T) {
+	rec := testRecord(t)
+	rec.Fields["schema_version"] = structpb.NewStringValue("0.7.0")
+
+	sig, public := keySignature(t, rec)
+
+	embedded, err := sig.AsStruct()
+	if err != nil {
+		t.Fatalf("AsStruct: %v", err)
+	}
+
+	rec.Fields["signature"] = structpb.NewStructValue(embedded)
+
+	if result, err := signer.Verify(rec, signer.Policy{TrustedKeys: []crypto.PublicKey{public}}); err != nil || !result.Verified {
+		t.Errorf("Verify: %v %+v", err, result)
+	}
+
+	delete(rec.Fields, "signature")
+
+	if _, err := signer.Verify(rec, signer.Policy{}); err == nil {
+		t.Error("expected an error for a record without signature")
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	rec := testRecord(t)
+	sig, public := keySignature(t, rec)
+
+	policy := signer.Policy{
+		TrustedKeys: []crypto.PublicKey{public},
+		RekorKeys:   []crypto.PublicKey{rekorKey.Public()},
+		MaxAge:      time.Hour,
+	}
+
+	// signed_at is not signed: refreshing it must not pass MaxAge.
+	result, err := signer.VerifyDetached(rec, sig, policy)
+	if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckExpiry {
+		t.Errorf("unattested signing time: %v %+v", err, result)
+	}
+
+	withLogEntry(t, sig, rekorKey, time.Now().Add(-time.Minute))
+
+	if _, err := signer.VerifyDetached(rec, sig, policy); err != nil {
+		t.Errorf("attested signing time: %v", err)
+	}
+
+	policy.CurrentTime = time.Now().Add(2 * time.Hour)
+
+	result, err = signer.VerifyDetached(rec, sig, policy)
+	if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckExpiry {
+		t.Errorf("expired signature: %v %+v", err, result)
+	}
+
+	policy.CurrentTime = time.Now().Add(-time.Hour)
+
+	if _, err := signer.VerifyDetached(rec, sig, policy); err == nil {
+		t.Error("expected signatures from the future to be rejected")
+	}
+}
+
+func TestVerifyKeyless(t *testing.T) {
+	fulcio, roots := newFakeFulcio(t, "dev@example.org")
+	defer fulcio.Close()
+
+	token := identityToken(t, map[string]string{"email": "dev@example.org"})
+
+	s, err := signer.NewKeylessSigner(context.Background(), token, signer.WithFulcioURL(fulcio.URL))
+	if err != nil {
+		t.Fatalf("NewKeylessSigner: %v", err)
+	}
+
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	rekorKeys := []crypto.PublicKey{rekorKey.Public()}
+	rec := testRecord(t)
+
+	sig, err := signer.Sign(context.Background(), rec, s, signer.WithTransparencyLog(fakeRekor(t, rekorKey, true).URL))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	policy := signer.Policy{Roots: roots, Issuers: []string{fakeIssuer}, Identities: []string{"*@example.org"}, RekorKeys: rekorKeys}
+
+	result, err := signer.VerifyDetached(rec, sig, policy)
+	if err != nil || result.Identity != "dev@example.org" || result.Issuer != fakeIssuer {
+		t.Fatalf("VerifyDetached: %v %+v", err, result)
+	}
+
+	// Without a log entry, the certificate would be checked at the unsigned
+	// signed_at, which a holder of an expired certificate's key could
+	// backdate.
+	unattested, err := signer.Sign(context.Background(), rec, s)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	unattested.SignedAt = time.Now().Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+
+	result, err = signer.VerifyDetached(rec, unattested, policy)
+	if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckSigner {
+		t.Errorf("keyless signature without log entry: %v %+v", err, result)
+	}
+
+	for name, p := range map[string]signer.Policy{
+		"no roots":         {RekorKeys: rekorKeys},
+		"other issuer":     {Roots: roots, Issuers: []string{"https://other.example.org"}, RekorKeys: rekorKeys},
+		"other identity":   {Roots: roots, Identities: []string{"ops@example.org"}, RekorKeys: rekorKeys},
+		"wildcard anchors": {Roots: roots, Identities: []string{"dev@example.*.evil"}, RekorKeys: rekorKeys},
+	} {
+		result, err := signer.VerifyDetached(rec, sig, p)
+		if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckSigner {
+			t.Errorf("%s: %v %+v", name, err, result)
+		}
+	}
+}
+
+// merkleRoot and merklePath implement MTH and PATH of RFC 6962 section 2.1.
+func merkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		sum := sha256.Sum256(append([]byte{0}, leaves[0]...))
+
+		return sum[:]
+	}
+
+	k := 1
+	for k*2 < len(leaves) {
+		k *= 2
+	}
+
+	sum := sha256.Sum256(append(append([]byte{1}, merkleRoot(leaves[:k])...), merkleRoot(leaves[k:])...))
+
+	return sum[:]
+}
+
+func merklePath(m int, leaves [][]byte) []string {
+	if len(leaves) == 1 {
+		return nil
+	}
+
+	k := 1
+	for k*2 < len(leaves) {
+		k *= 2
+	}
+
+	if m < k {
+		return append(merklePath(m, leaves[:k]), hex.EncodeToString(merkleRoot(leaves[k:])))
+	}
+
+	return append(merklePath(m-k, leaves[k:]), hex.EncodeToString(merkleRoot(leaves[:k])))
+}
+
+// logLeaves returns the leaves of a fake transparency log of size entries
+// with body at index.
+func logLeaves(size, index int, body []byte) [][]byte {
+	leaves := make([][]byte, size)
+	for i := range leaves {
+		leaves[i] = []byte(strconv.Itoa(i))
+	}
+
+	leaves[index] = body
+
+	return leaves
+}
+
+// signedCheckpoint returns a checkpoint of the fake transparency log, signed
+// with its key.
+func signedCheckpoint(t *testing.T, rekorKey *ecdsa.PrivateKey, treeSize int, root []byte) string {
+	t.Helper()
+
+	text := fmt.Sprintf("rekor.example.org - 1193050959916656506\n%d\n%s\n", treeSize, base64.StdEncoding.EncodeToString(root))
+	digest := sha256.Sum256([]byte(text))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, rekorKey, digest[:])
+	if err != nil {
+		t.Fatalf("SignASN1: %v", err)
+	}
+
+	return text + "\n— rekor.example.org " + base64.StdEncoding.EncodeToString(append([]byte{0xde, 0xad, 0xbe, 0xef}, signature...)) + "\n"
+}
+
+// withLogEntry records sig at index 1003 of a fake transparency log and adds
+// the entry to its bundle.
+func withLogEntry(t *testing.T, sig *signer.Signature, rekorKey *ecdsa.PrivateKey, integrated time.Time) {
+	t.Helper()
+
+	bundle, err := sig.Bundle()
+	if err != nil {
+		t.Fatalf("Bundle: %v", err)
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data":      map[string]any{"hash": map[string]any{"algorithm": "sha256", "value": strings.TrimPrefix(bundle.Digest, "sha256:")}},
+			"signature": map[string]any{"content": sig.Signature},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	leaves := logLeaves(1010, 1003, body)
+	root := merkleRoot(leaves)
+
+	entry := &signer.TransparencyLogEntry{
+		LogIndex:       1003,
+		LogID:          "c0ffee",
+		IntegratedTime: integrated.Unix(),
+		Body:           base64.StdEncoding.EncodeToString(body),
+		InclusionProof: &signer.InclusionProof{
+			LogIndex:   1003,
+			TreeSize:   int64(len(leaves)),
+			RootHash:   hex.EncodeToString(root),
+			Hashes:     merklePath(1003, leaves),
+			Checkpoint: signedCheckpoint(t, rekorKey, len(leaves), root),
+		},
+	}
+
+	setPayload, _ := json.Marshal(map[string]any{
+		"body": entry.Body, "integratedTime": entry.IntegratedTime, "logID": entry.LogID, "logIndex": entry.LogIndex,
+	})
+	setDigest := sha256.Sum256(setPayload)
+
+	set, err := ecdsa.SignASN1(rand.Reader, rekorKey, setDigest[:])
+	if err != nil {
+		t.Fatalf("SignASN1: %v", err)
+	}
+
+	entry.SignedEntryTimestamp = base64.StdEncoding.EncodeToString(set)
+	bundle.TransparencyLog = entry
+
+	raw, _ := json.Marshal(bundle)
+	sig.ContentBundle = base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestVerifyTransparencyLog(t *testing.T) {
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	rec := testRecord(t)
+	sig, public := keySignature(t, rec)
+
+	policy := signer.Policy{TrustedKeys: []crypto.PublicKey{public}, RequireTransparencyLog: true}
+
+	result, err := signer.VerifyDetached(rec, sig, policy)
+	if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckTransparencyLog {
+		t.Errorf("missing log entry: %v %+v", err, result)
+	}
+
+	integrated := time.Now().Add(-time.Minute).Truncate(time.Second).UTC()
+	withLogEntry(t, sig, rekorKey, integrated)
+
+	if result, err := signer.VerifyDetached(rec, sig, policy); err == nil || failedCheck(result) != signer.CheckTransparencyLog {
+		t.Errorf("log entry without trusted log keys: %v %+v", err, result)
+	}
+
+	policy.RekorKeys = []crypto.PublicKey{rekorKey.Public()}
+
+	result, err = signer.VerifyDetached(rec, sig, policy)
+	if err != nil || result.LogIndex == nil || *result.LogIndex != 1003 || !result.SignedAt.Equal(integrated) {
+		t.Fatalf("VerifyDetached: %v %+v", err, result)
+	}
+
+	bundle, _ := sig.Bundle()
+	bundle.TransparencyLog.InclusionProof.RootHash = hex.EncodeToString(make([]byte, sha256.Size))
+	raw, _ := json.Marshal(bundle)
+	sig.ContentBundle = base64.StdEncoding.EncodeToString(raw)
+
+	if result, err := signer.VerifyDetached(rec, sig, policy); err == nil || failedCheck(result) != signer.CheckTransparencyLog {
+		t.Errorf("bad inclusion proof: %v %+v", err, result)
+	}
+}
+
+func TestVerifyInclusionProofForgedRoot(t *testing.T) {
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	rec := testRecord(t)
+	sig, public := keySignature(t, rec)
+	withLogEntry(t, sig, rekorKey, time.Now().Add(-time.Minute))
+
+	policy := signer.Policy{
+		TrustedKeys:           []crypto.PublicKey{public},
+		RekorKeys:             []crypto.PublicKey{rekorKey.Public()},
+		RequireInclusionProof: true,
+	}
+
+	forge := func(change func(proof *signer.InclusionProof, body []byte)) *signer.Signature {
+		forged := *sig
+
+		bundle, _ := forged.Bundle()
+		body, _ := base64.StdEncoding.DecodeString(bundle.TransparencyLog.Body)
+		change(bundle.TransparencyLog.InclusionProof, body)
+
+		raw, _ := json.Marshal(bundle)
+		forged.ContentBundle = base64.StdEncoding.EncodeToString(raw)
+
+		return &forged
+	}
+
+	// A tree of the entry alone is a self-consistent proof whose root the
+	// log never signed.
+	selfConsistent := func(proof *signer.InclusionProof, body []byte) {
+		proof.TreeSize = proof.LogIndex + 1
+		proof.RootHash = hex.EncodeToString(merkleRoot(logLeaves(int(proof.TreeSize), int(proof.LogIndex), body)))
+		proof.Hashes = merklePath(int(proof.LogIndex), logLeaves(int(proof.TreeSize), int(proof.LogIndex), body))
+	}
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	for name, change := range map[string]func(proof *signer.InclusionProof, body []byte){
+		"forged root": selfConsistent,
+		"forged root with its own checkpoint": func(proof *signer.InclusionProof, body []byte) {
+			selfConsistent(proof, body)
+			root, _ := hex.DecodeString(proof.RootHash)
+			proof.Checkpoint = signedCheckpoint(t, otherKey, int(proof.TreeSize), root)
+		},
+		"no checkpoint": func(proof *signer.InclusionProof, _ []byte) {
+			proof.Checkpoint = ""
+		},
+		"single leaf tree": func(proof *signer.InclusionProof, body []byte) {
+			proof.LogIndex, proof.TreeSize, proof.Hashes = 0, 1, nil
+			proof.RootHash = hex.EncodeToString(merkleRoot([][]byte{body}))
+		},
+	} {
+		result, err := signer.VerifyDetached(rec, forge(change), policy)
+		if !errors.Is(err, signer.ErrVerificationFailed) || failedCheck(result) != signer.CheckTransparencyLog {
+			t.Errorf("%s: %v %+v", name, err, result)
+		}
+	}
+
+	if _, err := signer.VerifyDetached(rec, sig, policy); err != nil {
+		t.Errorf("genuine proof: %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Command gen generates the typed skill, domain and module constants of the
+// taxonomy package from an OASF schema server:
+//
+//	go run ./internal/gen -schema-url <url> -versions 1.0.0 -out .          # rewrite the constants
+//	go run ./internal/gen -schema-url <url> -versions 1.0.0 -out . -check   # fail if they are stale
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+)
+
+// packageNames maps schema versions to the generated package, named after the
+// proto version the decoder uses for that schema version.
+var packageNames = map[string]string{
+	"0.7.0": "v1alpha1",
+	"0.8.0": "v1alpha2",
+	"1.0.0": "v1",
+}
+
+// class is a concrete taxonomy class rendered as a constant.
+type class struct {
+	Ident string
+	ID    int
+	Name  string
+}
+
+// kind is one taxonomy (skills, domains or modules) of a schema version.
+type kind struct {
+	Prefix  string
+	IDType  string
+	Classes []class
+}
+
+type file struct {
+	SchemaURL     string
+	SchemaVersion string
+	Package       string
+	Kinds         []kind
+}
+
+func main() {
+	schemaURL := flag.String("schema-url", "", "OASF schema server to generate from")
+	versions := flag.String("versions", "", "Comma-separated schema versions to generate")
+	out := flag.String("out", ".", "Directory of the taxonomy package")
+	check := flag.Bool("check", false, "report stale or missing generated files without rewriting them")
+
+	flag.Parse()
+
+	stale, err := run(context.Background(), *schemaURL, strings.Split(*versions, ","), *out, !*check)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, path := range stale {
+		fmt.Println(path)
+	}
+
+	if *check && len(stale) > 0 {
+		fmt.Fprintln(os.Stderr, "gen: taxonomy constants are stale, run go generate ./taxonomy")
+		os.Exit(1)
+	}
+}
+
+// run generates the constants of the versions into out and returns the
+// generated files that were stale or missing. With write false, the files are
+// only compared.
+func run(ctx context.Context, schemaURL string, versions []string, out string, write bool) ([]string, error) {
+	if schemaURL == "" {
+		return nil, errors.New("-schema-url is required")
+	}
+
+	client, err := schema.New(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema client: %w", err)
+	}
+
+	var stale []string
+
+	for _, version := range versions {
+		pkg, ok := packageNames[version]
+		if !ok {
+			return nil, fmt.Errorf("no package name for schema version %q", version)
+		}
+
+		f := file{SchemaURL: schemaURL, SchemaVersion: version, Package: pkg}
+
+		for _, k := range []struct {
+			prefix, idType string
+			fetch          func(context.Context, ...schema.SchemaOption) (schema.Taxonomy, error)
+		}{
+			{"Skill", "SkillID", client.GetSchemaSkills},
+			{"Domain", "DomainID", client.GetSchemaDomains},
+			{"Module", "ModuleID", client.GetSchemaModules},
+		} {
+			taxonomy, err := k.fetch(ctx, schema.WithSchemaVersion(version))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s taxonomy for %s: %w", strings.ToLower(k.prefix), version, err)
+			}
+
+			f.Kinds = append(f.Kinds, kind{Prefix: k.prefix, IDType: k.idType, Classes: flatten(k.prefix, taxonomy)})
+		}
+
+		src, err := render(f)
+		if err != nil {
+			return nil, err
+		}
+
+		dir := filepath.Join(out, pkg)
+		path := filepath.Join(dir, "zz_generated.go")
+
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, src) {
+			continue
+		}
+
+		stale = append(stale, path)
+
+		if !write {
+			continue
+		}
+
+		if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:mnd
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+
+		if err := os.WriteFile(path, src, 0o644); err != nil { //nolint:gosec,mnd
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return stale, nil
+}
+
+// flatten returns the concrete classes of a taxonomy tree sorted by ID,
+// skipping category nodes and the abstract id-0 base class. Deprecated classes
+// are kept so existing records keep compiling against them.
+func flatten(prefix string, taxonomy schema.Taxonomy) []class {
+	var out []class
+
+	var walk func(items map[string]schema.TaxonomyItem)
+
+	walk = func(items map[string]schema.TaxonomyItem) {
+		for _, item := range items {
+			if !item.Category && item.ID != 0 {
+				out = append(out, class{ID: item.ID, Name: item.Name})
+			}
+
+			walk(item.Classes)
+		}
+	}
+
+	walk(taxonomy)
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+
+	seen := map[string]bool{}
+
+	for i := range out {
+		ident := prefix + identifier(out[i].Name)
+		if seen[ident] {
+			ident = fmt.Sprintf("%s%d", ident, out[i].ID)
+		}
+
+		seen[ident] = true
+		out[i].Ident = ident
+	}
+
+	return out
+}
+
+// identifier turns a hierarchical class name such as
+// "natural_language_processing/text_completion" into NaturalLanguageProcessingTextCompletion.
+func identifier(name string) string {
+	var b strings.Builder
+
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	return b.String()
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{"lower": strings.ToLower}).Parse(`// Code generated by taxonomy/internal/gen from {{.SchemaURL}}. DO NOT EDIT.
+
+// Package {{.Package}} holds the OASF {{.SchemaVersion}} skill, domain and module classes.
+package {{.Package}}
+
+import "github.com/agntcy/oasf-sdk/pkg/taxonomy"
+
+// SchemaVersion is the OASF schema version these classes were generated from.
+const SchemaVersion = "{{.SchemaVersion}}"
+{{range .Kinds}}{{$kind := .}}
+// {{.Prefix}} classes.
+const (
+{{- range .Classes}}
+	// {{.Ident}} is {{printf "%q" .Name}}.
+	{{.Ident}} taxonomy.{{$kind.IDType}} = {{.ID}}
+{{- end}}
+)
+
+// {{.Prefix}}Names maps {{lower .Prefix}} IDs to their hierarchical names.
+var {{.Prefix}}Names = map[taxonomy.{{.IDType}}]string{
+{{- range .Classes}}
+	{{.Ident}}: {{printf "%q" .Name}},
+{{- end}}
+}
+{{end}}`))
+
+func render(f file) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := fileTemplate.Execute(&buf, f); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", f.Package, err)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w", f.Package, err)
+	}
+
+	return src, nil
+}
@@ -0,0 +1,105 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIdentifier(t *testing.T) {
+	cases := map[string]string{
+		"natural_language_processing/text_completion": "NaturalLanguageProcessingTextCompletion",
+		"integration/mcp":                 "IntegrationMcp",
+		"core/language_model/agentskills": "CoreLanguageModelAgentskills",
+		"technology/3d-printing":          "Technology3dPrinting",
+	}
+
+	for name, want := range cases {
+		if got := identifier(name); got != want {
+			t.Errorf("identifier(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	taxonomies := map[string]string{
+		"skill_categories": `{"nlp": {"id": 1, "name": "natural_language_processing", "category": true, "classes": {
+			"tc": {"id": 10201, "name": "natural_language_processing/text_completion"},
+			"old": {"id": 10202, "name": "natural_language_processing/legacy", "deprecated": true}}}}`,
+		"domain_categories": `{"tech": {"id": 1, "name": "technology", "category": true, "classes": {
+			"iot": {"id": 101, "name": "technology/internet_of_things"}}}}`,
+		"module_categories": `{"base": {"id": 0, "name": "base_module"}, "mcp": {"id": 202, "name": "integration/mcp"}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/versions" {
+			_, _ = w.Write([]byte(`{"default": {"schema_version": "1.0.0"}, "versions": [{"schema_version": "1.0.0"}]}`))
+
+			return
+		}
+
+		body, ok := taxonomies[strings.TrimPrefix(r.URL.Path, "/api/1.0.0/")]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	out := t.TempDir()
+
+	stale, err := run(context.Background(), srv.URL, []string{"1.0.0"}, out, false)
+	if err != nil || len(stale) != 1 {
+		t.Fatalf("check before generating: %v, stale %v", err, stale)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "v1")); !os.IsNotExist(err) {
+		t.Errorf("check must not write files: %v", err)
+	}
+
+	if _, err := run(context.Background(), srv.URL, []string{"1.0.0"}, out, true); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if stale, err := run(context.Background(), srv.URL, []string{"1.0.0"}, out, false); err != nil || len(stale) != 0 {
+		t.Errorf("check after generating: %v, stale %v", err, stale)
+	}
+
+	src, err := os.ReadFile(filepath.Join(out, "v1", "zz_generated.go"))
+	if err != nil {
+		t.Fatalf("generated file missing: %v", err)
+	}
+
+	for _, want := range []string{
+		"package v1",
+		`const SchemaVersion = "1.0.0"`,
+		"SkillNaturalLanguageProcessingTextCompletion taxonomy.SkillID = 10201",
+		"SkillNaturalLanguageProcessingLegacy taxonomy.SkillID = 10202",
+		"DomainTechnologyInternetOfThings taxonomy.DomainID = 101",
+		`ModuleIntegrationMcp: "integration/mcp",`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code missing %q:\n%s", want, src)
+		}
+	}
+
+	for _, unwanted := range []string{"SkillNaturalLanguageProcessing taxonomy", "ModuleBaseModule"} {
+		if strings.Contains(string(src), unwanted) {
+			t.Errorf("generated code must not contain %q", unwanted)
+		}
+	}
+
+	if _, err := run(context.Background(), srv.URL, []string{"2.0.0"}, out, true); err == nil {
+		t.Error("expected an error for a version without a package name")
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package taxonomy defines typed identifiers for OASF skill, domain and module
+// classes. Distinct ID types keep a domain from being passed where a skill is
+// expected.
+//
+// The constants of each schema version are generated from the OASF schema
+// server into a subpackage named after the matching proto version (v1alpha1
+// for 0.7.0, v1alpha2 for 0.8.0, v1 for 1.0.0). The subpackages are not
+// committed: generating them needs the schema server, so create them with
+// `go generate ./taxonomy` (or `task generate:taxonomy`) before importing
+// them, e.g.
+//
+//	record.NewBuilder().AddSkill(v1.SkillNaturalLanguageProcessingNaturalLanguageUnderstanding)
+//
+// `task generate:taxonomy:check` fails when generated subpackages are stale.
+package taxonomy
+
+//go:generate go run ./internal/gen -schema-url https://schema.oasf.outshift.com -versions 0.7.0,0.8.0,1.0.0 -out .
+
+// SkillID is the UID of an OASF skill class.
+type SkillID uint32
+
+// DomainID is the UID of an OASF domain class.
+type DomainID uint32
+
+// ModuleID is the UID of an OASF module class.
+type ModuleID uint32
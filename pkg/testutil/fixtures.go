@@ -0,0 +1,236 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testutil generates the expected outputs of the e2e fixtures from
+// the current decoder and translators, so they follow translator changes
+// instead of being edited by hand.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// PlaceholderTimestamp replaces the created_at of generated records, which
+// the e2e tests ignore.
+const PlaceholderTimestamp = "2025-01-01T00:00:00Z"
+
+// CatalogCID is the CID the e2e catalog test passes to RecordToCatalog.
+const CatalogCID = "baeareibxiiy45pg4bjwhbijgh35epzjhnh6lvaxts2qggcgssn3glzdh64"
+
+// commentPrefix marks fixture keys that document the fixture; they are kept
+// when a fixture is regenerated.
+const commentPrefix = "_comment_"
+
+// Fixture is an expected output file generated from an input fixture.
+type Fixture struct {
+	// Expected and Input are file names in the fixtures directory.
+	Expected string
+	Input    string
+	// Generate returns the expected output for the input, as the e2e tests
+	// see it in the service responses.
+	Generate func(input *structpb.Struct) (any, error)
+}
+
+// Fixtures lists the generated e2e fixtures. Where several inputs share an
+// expected file, the newest schema version's input generates it and the e2e
+// tests check that the others match.
+var Fixtures = []Fixture{
+	{"expected_0.8.0_decoded.json", "valid_0.8.0_record.json", decoded},
+	{"expected_1.0.0_decoded.json", "valid_1.0.0_record.json", decoded},
+	{"expected_0.7.0_decoded.json", "valid_0.7.0_record.json", decoded},
+	{"expected_gh_copilot_output.json", "translation_1.0.0_record.json", ghCopilot},
+	{"expected_dir_mcp_gh_copilot_output.json", "translation_dir_mcp_record.json", ghCopilot},
+	{"expected_a2a_output.json", "translation_1.0.0_record.json", a2aCard},
+	{"expected_a2a_from_070_080_output.json", "translation_0.8.0_record.json", a2aCard},
+	{"expected_a2atorecord_output.json", "translation_a2a.json", imported(translator.A2AToRecord)},
+	{"expected_mcptorecord_output.json", "translation_mcp.json", imported(translator.MCPToRecord)},
+	{"expected_mcp_minimal_local_output.json", "translation_mcp_minimal_local.json", imported(translator.MCPToRecord)},
+	{"expected_mcp_http_headers_output.json", "translation_mcp_http_headers.json", imported(translator.MCPToRecord)},
+	{"expected_mcp_sse_minimal_output.json", "translation_mcp_sse_minimal.json", imported(translator.MCPToRecord)},
+	{"expected_skilltorecord_output.json", "translation_skill.json", imported(translator.SkillMarkdownToRecord)},
+	{"expected_recordtoskill_output.md", "translation_agentskills_record.json", skillMarkdown},
+	{"expected_catalog_output.json", "translation_catalog_record.json", catalog},
+}
+
+func decoded(input *structpb.Struct) (any, error) {
+	resp, err := decoder.DecodeRecord(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	switch {
+	case resp.GetV1() != nil:
+		return resp.GetV1(), nil
+	case resp.GetV1Alpha2() != nil:
+		return resp.GetV1Alpha2(), nil
+	default:
+		return resp.GetV1Alpha1(), nil
+	}
+}
+
+func ghCopilot(input *structpb.Struct) (any, error) {
+	config, err := translator.RecordToGHCopilot(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return map[string]any{"mcpConfig": config}, nil
+}
+
+func a2aCard(input *structpb.Struct) (any, error) {
+	card, err := translator.RecordToA2A(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return map[string]any{"a2aCard": card.AsMap()}, nil
+}
+
+// imported wraps an importer, replacing the created_at of its records.
+func imported(importer func(*structpb.Struct, ...translator.TranslatorOption) (*structpb.Struct, error)) func(*structpb.Struct) (any, error) {
+	return func(input *structpb.Struct) (any, error) {
+		record, err := importer(input)
+		if err != nil {
+			return nil, err
+		}
+
+		out := record.AsMap()
+		if _, ok := out["created_at"]; ok {
+			out["created_at"] = PlaceholderTimestamp
+		}
+
+		return out, nil
+	}
+}
+
+func skillMarkdown(input *structpb.Struct) (any, error) {
+	return translator.RecordToSkillMarkdown(input) //nolint:wrapcheck
+}
+
+func catalog(input *structpb.Struct) (any, error) {
+	entry, err := translator.RecordToCatalog(input, translator.WithCatalogCID(CatalogCID))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return map[string]any{"data": entry.AsMap()}, nil
+}
+
+// Render generates the content of the fixture's expected file in dir. JSON
+// outputs are indented with sorted keys; the _comment_ keys of the current
+// file are kept.
+func (f Fixture) Render(dir string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(dir, f.Input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	input, err := decoder.JsonToProto(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.Input, err)
+	}
+
+	out, err := f.Generate(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate from %s: %w", f.Input, err)
+	}
+
+	if markdown, ok := out.(string); ok {
+		return []byte(markdown), nil
+	}
+
+	// Round trip through JSON so typed outputs get the same map form as
+	// the comments.
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	var doc any
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	if obj, ok := doc.(map[string]any); ok {
+		if err := keepComments(filepath.Join(dir, f.Expected), obj); err != nil {
+			return nil, err
+		}
+	}
+
+	rendered, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return append(rendered, '\n'), nil
+}
+
+// keepComments copies the top-level _comment_ keys of the file at path, if
+// it exists, into out.
+func keepComments(path string, out map[string]any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var current map[string]any
+	if err := json.Unmarshal(data, &current); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for key, value := range current {
+		if strings.HasPrefix(key, commentPrefix) {
+			out[key] = value
+		}
+	}
+
+	return nil
+}
+
+// Regenerate renders the fixtures in dir. With write, changed files are
+// rewritten. It returns the names of the files that changed or would change.
+func Regenerate(dir string, write bool) ([]string, error) {
+	var changed []string
+
+	for _, f := range Fixtures {
+		rendered, err := f.Render(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Expected, err)
+		}
+
+		path := filepath.Join(dir, f.Expected)
+
+		current, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if bytes.Equal(current, rendered) {
+			continue
+		}
+
+		changed = append(changed, f.Expected)
+
+		if write {
+			if err := os.WriteFile(path, rendered, 0o644); err != nil { //nolint:gosec,mnd
+				return nil, fmt.Errorf("failed to write %s: %w", path, err)
+			}
+		}
+	}
+
+	return changed, nil
+}
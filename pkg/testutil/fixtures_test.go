@@ -0,0 +1,70 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/testutil"
+)
+
+const fixturesDir = "../../e2e/fixtures"
+
+func TestFixturesUpToDate(t *testing.T) {
+	changed, err := testutil.Regenerate(fixturesDir, false)
+	if err != nil {
+		t.Fatalf("Regenerate: %v", err)
+	}
+
+	if len(changed) > 0 {
+		t.Errorf("stale e2e fixtures %v; run go run ./cmd/genfixtures in pkg", changed)
+	}
+}
+
+func TestRenderKeepsComments(t *testing.T) {
+	dir := t.TempDir()
+
+	input, err := os.ReadFile(filepath.Join(fixturesDir, "translation_mcp_sse_minimal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"in.json":       string(input),
+		"expected.json": `{"_comment_created_at": "ignored by the tests", "name": "stale"}`,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fixture := testutil.Fixtures[0]
+	for _, f := range testutil.Fixtures {
+		if f.Input == "translation_mcp_sse_minimal.json" {
+			fixture = f
+		}
+	}
+
+	fixture.Input, fixture.Expected = "in.json", "expected.json"
+
+	rendered, err := fixture.Render(dir)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	for _, want := range []string{`"_comment_created_at": "ignored by the tests"`, `"created_at": "` + testutil.PlaceholderTimestamp + `"`} {
+		if !strings.Contains(string(rendered), want) {
+			t.Errorf("rendered fixture misses %s:\n%s", want, rendered)
+		}
+	}
+
+	if strings.Contains(string(rendered), "stale") {
+		t.Errorf("rendered fixture kept stale content:\n%s", rendered)
+	}
+}
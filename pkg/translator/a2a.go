@@ -5,9 +5,11 @@ package translator
 
 import (
 	"encoding/json"
-	"errors"
-	"time"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
 	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -20,37 +22,119 @@ import (
 // Extraction priority:
 //  1. module.artifact.data – base64-encoded original card JSON written by A2AToRecord; decoded for lossless round-trip.
 //  2. module.data.card_data – card stored as a structured object inside the module data (all schema versions).
+//
+// Card keys are returned in the camelCase of the A2A JSON binding, also when
+// the record stores snake_case ones. A SLIM descriptor in the record
+// annotations (see RecordSLIM) is added to the card as the SLIMExtensionURI
+// extension.
 func RecordToA2A(record *structpb.Struct) (*structpb.Struct, error) {
-	// Try "integration/a2a" (0.8.0, 1.0.0) first, then fall back to "runtime/a2a" (0.7.0).
-	found, a2aModule := recordutil.GetModule(record, A2AModuleName)
-	if !found {
-		found, a2aModule = recordutil.GetModule(record, "runtime/a2a")
+	card, err := storedA2ACard(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// A SLIM descriptor annotated on the record is advertised as a card
+	// extension.
+	if slim, ok := RecordSLIM(record); ok {
+		card = withCardSLIM(card, slim)
 	}
 
+	return card, nil
+}
+
+// storedA2ACard returns the card stored in the record's A2A module, with
+// camelCase keys (see normalizeA2ACard).
+func storedA2ACard(record *structpb.Struct) (*structpb.Struct, error) {
+	// Matches "integration/a2a" (0.8.0, 1.0.0) as well as "runtime/a2a" (0.7.0),
+	// or any other name in the module alias table.
+	found, a2aModule := recordutil.FindModule(record, A2AModuleName)
 	if !found {
-		return nil, errors.New("A2A module not found in record")
+		return nil, fmt.Errorf("A2A %w", ErrModuleNotFound)
 	}
 
 	// Prefer the original card JSON from the artifact when available (lossless round-trip).
-	if s := structFromArtifactData(a2aModule); s != nil {
-		return s, nil
+	card := structFromArtifactData(a2aModule)
+	if card == nil {
+		cardData := a2aModule.GetFields()["data"].GetStructValue().GetFields()["card_data"].GetStructValue()
+		if len(cardData.GetFields()) == 0 {
+			return nil, fmt.Errorf("%w: A2A card data not found in module", ErrInvalidInput)
+		}
+
+		card = cardData
 	}
 
-	a2aModuleData := a2aModule.GetFields()["data"].GetStructValue()
+	return normalizeA2ACard(card), nil
+}
+
+// a2aMultiWordFields are the camelCase names of the A2A card fields that
+// have a distinct snake_case spelling, including those of the OAuth2 flows
+// of security schemes.
+var a2aMultiWordFields = map[string]bool{
+	"a2aCard":                           true,
+	"protocolVersion":                   true,
+	"preferredTransport":                true,
+	"additionalInterfaces":              true,
+	"iconUrl":                           true,
+	"documentationUrl":                  true,
+	"pushNotifications":                 true,
+	"stateTransitionHistory":            true,
+	"securitySchemes":                   true,
+	"defaultInputModes":                 true,
+	"defaultOutputModes":                true,
+	"inputModes":                        true,
+	"outputModes":                       true,
+	"supportsAuthenticatedExtendedCard": true,
+	"bearerFormat":                      true,
+	"openIdConnectUrl":                  true,
+	"authorizationCode":                 true,
+	"clientCredentials":                 true,
+	"authorizationUrl":                  true,
+	"tokenUrl":                          true,
+	"refreshUrl":                        true,
+}
+
+// normalizeA2ACard returns a copy of the card with camelCase keys. Only cards
+// spelling A2A fields in snake_case are normalized; camelCase cards are
+// copied as they are, so the keys of their free-form data (extension URIs,
+// "x-" fields, extension params) are never rewritten.
+func normalizeA2ACard(card *structpb.Struct) *structpb.Struct {
+	if !hasSnakeCaseA2AField(structpb.NewStructValue(card)) {
+		return recordutil.Clone(card)
+	}
+
+	return NormalizeKeys(card, KeyStyleCamelCase, a2aKeyOptions...)
+}
+
+// hasSnakeCaseA2AField reports whether the value has an object key that is
+// the snake_case spelling of an A2A card field.
+func hasSnakeCaseA2AField(value *structpb.Value) bool {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		for key, field := range kind.StructValue.GetFields() {
+			if strings.Contains(key, "_") && a2aMultiWordFields[convertKey(key, KeyStyleCamelCase)] {
+				return true
+			}
 
-	if cardDataVal, ok := a2aModuleData.GetFields()["card_data"]; ok {
-		if cardData := cardDataVal.GetStructValue(); cardData != nil && len(cardData.GetFields()) > 0 {
-			return cardData, nil
+			if hasSnakeCaseA2AField(field) {
+				return true
+			}
 		}
+	case *structpb.Value_ListValue:
+		return slices.ContainsFunc(kind.ListValue.GetValues(), hasSnakeCaseA2AField)
 	}
 
-	return nil, errors.New("A2A card data not found in module")
+	return false
 }
 
 // A2AToRecord translates an A2A card data back into an OASF-compliant record format.
 // Generates records using the specified schema version (via WithVersion option) or the default schema version.
 // The version must be 1.x.x format. Accepts both wrapped format ({"a2aCard": {...}}) and unwrapped format (direct card object).
+// Card keys may be camelCase or snake_case; snake_case cards are normalized to camelCase before the card is read and stored,
+// camelCase cards are stored as they are.
+// The descriptor of a SLIMExtensionURI card extension is kept in the "slim.*" record annotations.
 func A2AToRecord(a2aData *structpb.Struct, opts ...TranslatorOption) (*structpb.Struct, error) { //nolint:cyclop
+	a2aData = normalizeA2ACard(a2aData)
+
 	// Extract the a2aCard from the input data - handle both wrapped and unwrapped formats
 	var A2ACardStruct *structpb.Struct
 
@@ -58,7 +142,7 @@ func A2AToRecord(a2aData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 		// Wrapped format: {"a2aCard": {...}}
 		A2ACardStruct = a2aCardVal.GetStructValue()
 		if A2ACardStruct == nil {
-			return nil, errors.New("'a2aCard' is not a struct")
+			return nil, fmt.Errorf("%w: 'a2aCard' is not a struct", ErrInvalidInput)
 		}
 	} else {
 		// Unwrapped format: direct card object
@@ -106,124 +190,50 @@ func A2AToRecord(a2aData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 		}
 	}
 
-	authors := resolveRecordAuthors(sourceAuthors, options.authors)
-
-	// Use current timestamp for created_at (RFC3339 format)
-	// Note: For consistent test results, this could be overridden in test fixtures
-	createdAt := time.Now().UTC().Format(time.RFC3339)
+	authors := resolveRecordAuthors(sourceAuthors, options)
 
-	var targetVersion string
+	targetVersion := DefaultSchemaVersion
 
 	if options.version != "" {
+		if err := validateMajorVersion(options.version); err != nil {
+			return nil, err
+		}
+
 		targetVersion = options.version
+	}
 
-		// Validate provided version
-		if err := validateMajorVersion(targetVersion); err != nil {
+	// In 1.0.0, the A2A module data only carries card_data and card_schema_version.
+	moduleData := pb.Struct(pb.Fields{
+		"card_data":           structpb.NewStructValue(A2ACardStruct),
+		"card_schema_version": pb.Str(A2ACardSchemaVersion),
+	})
+
+	// Attach the original card JSON as the module artifact for lossless round-trips.
+	var moduleOpts []recordutil.ModuleOption
+	if raw, err := json.Marshal(A2ACardStruct.AsMap()); err == nil {
+		moduleOpts = append(moduleOpts, recordutil.WithModuleArtifact(raw, a2aMediaType))
+	}
+
+	record, err := recordutil.NewBuilder().
+		Name(cardName).
+		SchemaVersion(targetVersion).
+		Version(cardVersion).
+		Description(cardDescription).
+		Authors(authors...).
+		AddModule(A2AModuleName, moduleData, moduleOpts...).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
+
+	if slim, ok := cardSLIM(A2ACardStruct); ok {
+		if err := SetRecordSLIM(record, slim); err != nil {
 			return nil, err
 		}
-	} else {
-		// Use hardcoded default version
-		targetVersion = DefaultSchemaVersion
-	}
-
-	// Create A2A data structure conforming to OASF v1.0.0 A2A data schema
-	// In 1.0.0, the structure is simplified to only card_data and card_schema_version
-	A2AModuleData := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"card_data": {
-				Kind: &structpb.Value_StructValue{StructValue: A2ACardStruct},
-			},
-			"card_schema_version": {
-				Kind: &structpb.Value_StringValue{StringValue: A2ACardSchemaVersion},
-			},
-		},
-	}
-
-	// Build module fields and attach the artifact containing the original A2A card JSON.
-	A2AModuleFields := map[string]*structpb.Value{
-		"name": {
-			Kind: &structpb.Value_StringValue{StringValue: A2AModuleName},
-		},
-		"data": {
-			Kind: &structpb.Value_StructValue{StructValue: A2AModuleData},
-		},
-	}
-
-	raw, err := json.Marshal(A2ACardStruct.AsMap())
-	if err == nil {
-		if artifactStruct := buildArtifactDescriptor(raw, a2aMediaType); artifactStruct != nil {
-			A2AModuleFields["artifact"] = &structpb.Value{
-				Kind: &structpb.Value_StructValue{StructValue: artifactStruct},
-			}
-		}
 	}
 
-	// Create the A2A module with schema-compliant data
-	A2AModule := &structpb.Struct{
-		Fields: A2AModuleFields,
-	}
-
-	// Create the modules list
-	modulesList := &structpb.ListValue{
-		Values: []*structpb.Value{
-			{
-				Kind: &structpb.Value_StructValue{StructValue: A2AModule},
-			},
-		},
-	}
-
-	// Create OASF-compliant record with all required fields
-	record := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"name": {
-				Kind: &structpb.Value_StringValue{StringValue: cardName},
-			},
-			"schema_version": {
-				Kind: &structpb.Value_StringValue{StringValue: targetVersion},
-			},
-			"version": {
-				Kind: &structpb.Value_StringValue{StringValue: cardVersion},
-			},
-			"description": {
-				Kind: &structpb.Value_StringValue{StringValue: cardDescription},
-			},
-			"authors": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: func() []*structpb.Value {
-							authorValues := make([]*structpb.Value, 0, len(authors))
-							for _, author := range authors {
-								authorValues = append(authorValues, &structpb.Value{
-									Kind: &structpb.Value_StringValue{StringValue: author},
-								})
-							}
-
-							return authorValues
-						}(),
-					},
-				},
-			},
-			"created_at": {
-				Kind: &structpb.Value_StringValue{StringValue: createdAt},
-			},
-			"skills": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: []*structpb.Value{},
-					},
-				},
-			},
-			"domains": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: []*structpb.Value{},
-					},
-				},
-			},
-			"modules": {
-				Kind: &structpb.Value_ListValue{ListValue: modulesList},
-			},
-		},
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
 	}
 
 	return record, nil
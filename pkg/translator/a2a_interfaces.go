@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// A2A protocol bindings of agent interfaces.
+const (
+	A2ABindingJSONRPC  = "JSONRPC"
+	A2ABindingGRPC     = "GRPC"
+	A2ABindingHTTPJSON = "HTTP+JSON"
+)
+
+// A2AInterface is an endpoint an A2A agent serves on, with the protocol
+// binding it speaks there.
+type A2AInterface struct {
+	URL             string `json:"url"`
+	ProtocolBinding string `json:"protocolBinding"`
+}
+
+// CardInterfaces returns the interfaces of an A2A card in order of
+// preference: the supportedInterfaces of A2A 1.0 cards, or the url and
+// preferredTransport (JSONRPC when unset) of A2A 0.x cards followed by their
+// additionalInterfaces. Bindings are upper-cased; duplicates and interfaces
+// without a URL are dropped. Keys may be camelCase or snake_case.
+func CardInterfaces(card *structpb.Struct) []A2AInterface {
+	fields := NormalizeKeys(card, KeyStyleCamelCase, a2aKeyOptions...).GetFields()
+
+	var interfaces []A2AInterface
+
+	add := func(url, binding string) {
+		if url == "" {
+			return
+		}
+
+		iface := A2AInterface{URL: url, ProtocolBinding: strings.ToUpper(binding)}
+
+		for _, existing := range interfaces {
+			if existing == iface {
+				return
+			}
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+
+	for _, value := range fields["supportedInterfaces"].GetListValue().GetValues() {
+		iface := value.GetStructValue().GetFields()
+		add(iface["url"].GetStringValue(), iface["protocolBinding"].GetStringValue())
+	}
+
+	if len(interfaces) > 0 {
+		return interfaces
+	}
+
+	transport := fields["preferredTransport"].GetStringValue()
+	if transport == "" {
+		transport = A2ABindingJSONRPC
+	}
+
+	add(fields["url"].GetStringValue(), transport)
+
+	for _, value := range fields["additionalInterfaces"].GetListValue().GetValues() {
+		iface := value.GetStructValue().GetFields()
+		add(iface["url"].GetStringValue(), iface["transport"].GetStringValue())
+	}
+
+	return interfaces
+}
+
+// RecordA2AInterfaces returns the interfaces of the card stored in the A2A
+// module of a record (see CardInterfaces).
+func RecordA2AInterfaces(record *structpb.Struct) ([]A2AInterface, error) {
+	card, err := storedA2ACard(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return CardInterfaces(card), nil
+}
@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestCardInterfaces(t *testing.T) {
+	tests := map[string]struct {
+		card map[string]any
+		want []translator.A2AInterface
+	}{
+		"supported interfaces": {
+			card: map[string]any{
+				"url": "https://agent.example.org/ignored",
+				"supportedInterfaces": []any{
+					map[string]any{"url": "https://agent.example.org/a2a", "protocolBinding": "JSONRPC"},
+					map[string]any{"url": "agent.example.org:443", "protocolBinding": "grpc"},
+					map[string]any{"url": "https://agent.example.org/a2a", "protocolBinding": "JSONRPC"},
+					map[string]any{"protocolBinding": "HTTP+JSON"},
+				},
+			},
+			want: []translator.A2AInterface{
+				{URL: "https://agent.example.org/a2a", ProtocolBinding: translator.A2ABindingJSONRPC},
+				{URL: "agent.example.org:443", ProtocolBinding: translator.A2ABindingGRPC},
+			},
+		},
+		"preferred transport and additional interfaces": {
+			card: map[string]any{
+				"url":                 "https://agent.example.org/v1",
+				"preferred_transport": "HTTP+JSON",
+				"additional_interfaces": []any{
+					map[string]any{"url": "https://agent.example.org/v1", "transport": "HTTP+JSON"},
+					map[string]any{"url": "https://agent.example.org/jsonrpc", "transport": "JSONRPC"},
+				},
+			},
+			want: []translator.A2AInterface{
+				{URL: "https://agent.example.org/v1", ProtocolBinding: translator.A2ABindingHTTPJSON},
+				{URL: "https://agent.example.org/jsonrpc", ProtocolBinding: translator.A2ABindingJSONRPC},
+			},
+		},
+		"url only": {
+			card: map[string]any{"url": "http://localhost:8000"},
+			want: []translator.A2AInterface{{URL: "http://localhost:8000", ProtocolBinding: translator.A2ABindingJSONRPC}},
+		},
+		"none": {card: map[string]any{"name": "agent"}},
+	}
+
+	for name, tt := range tests {
+		card, err := structpb.NewStruct(tt.card)
+		if err != nil {
+			t.Fatalf("%s: failed to build card: %v", name, err)
+		}
+
+		if got := translator.CardInterfaces(card); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: CardInterfaces() = %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+func TestRecordA2AInterfaces(t *testing.T) {
+	card, err := structpb.NewStruct(map[string]any{
+		"name": "multi-transport-agent",
+		"supportedInterfaces": []any{
+			map[string]any{"url": "https://agent.example.org:8443/a2a", "protocolBinding": "JSONRPC"},
+			map[string]any{"url": "agent.example.org:50051", "protocolBinding": "GRPC"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build card: %v", err)
+	}
+
+	record, err := translator.A2AToRecord(card)
+	if err != nil {
+		t.Fatalf("A2AToRecord() error: %v", err)
+	}
+
+	interfaces, err := translator.RecordA2AInterfaces(record)
+	if err != nil {
+		t.Fatalf("RecordA2AInterfaces() error: %v", err)
+	}
+
+	if len(interfaces) != 2 || interfaces[1].ProtocolBinding != translator.A2ABindingGRPC {
+		t.Errorf("interfaces = %v, want both interfaces of the card", interfaces)
+	}
+
+	if _, err := translator.RecordA2AInterfaces(&structpb.Struct{}); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("error = %v, want ErrModuleNotFound", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Kinds of A2A card changes.
+const (
+	CardChangeAdded   = "added"
+	CardChangeRemoved = "removed"
+	CardChangeChanged = "changed"
+)
+
+// CardChange is a difference between the card stored in a record and the
+// agent's current card. Path is "skills.<id>" for skills and the card field
+// name otherwise.
+type CardChange struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// SyncA2ACard returns a copy of the record with its A2A module updated to
+// card (the card data and the artifact, as A2AToRecord stores them), and the
+// changes from the card stored before. Skills are matched by id, so a skill
+// whose description or tags changed is reported once. The record must have an
+// A2A module; it is returned unchanged, with no changes, when the card did not
+// change.
+func SyncA2ACard(record, card *structpb.Struct) (*structpb.Struct, []CardChange, error) {
+	if card == nil {
+		return nil, nil, fmt.Errorf("%w: card is nil", ErrInvalidInput)
+	}
+
+	current, err := storedA2ACard(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	card = normalizeA2ACard(card)
+
+	changes := diffCards(current.AsMap(), card.AsMap())
+	if len(changes) == 0 {
+		return record, nil, nil
+	}
+
+	raw, err := json.Marshal(card.AsMap())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode card: %w", err)
+	}
+
+	moduleData := pb.Struct(pb.Fields{
+		"card_data":           structpb.NewStructValue(card),
+		"card_schema_version": pb.Str(A2ACardSchemaVersion),
+	})
+
+	updated, err := recordutil.Update(record, func(r *structpb.Struct) error {
+		return recordutil.ReplaceModule(r, A2AModuleName, moduleData, recordutil.WithModuleArtifact(raw, a2aMediaType)) //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to update A2A module: %w", err)
+	}
+
+	return updated, changes, nil
+}
+
+// diffCards compares two cards: skills by id, the other fields as a whole.
+func diffCards(old, current map[string]any) []CardChange {
+	var changes []CardChange
+
+	for _, key := range sortedUnion(old, current) {
+		if key == "skills" {
+			changes = append(changes, diffCardSkills(old[key], current[key])...)
+
+			continue
+		}
+
+		if change, ok := cardChange(key, old[key], current[key]); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+func diffCardSkills(old, current any) []CardChange {
+	left, right := skillsByID(old), skillsByID(current)
+
+	var changes []CardChange
+
+	for _, id := range sortedUnion(left, right) {
+		if change, ok := cardChange("skills."+id, left[id], right[id]); ok {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// skillsByID indexes card skills by id, falling back to the name.
+func skillsByID(skills any) map[string]any {
+	list, _ := skills.([]any)
+	out := make(map[string]any, len(list))
+
+	for _, skill := range list {
+		m, _ := skill.(map[string]any)
+
+		id, _ := m["id"].(string)
+		if id == "" {
+			id, _ = m["name"].(string)
+		}
+
+		out[id] = skill
+	}
+
+	return out
+}
+
+func cardChange(path string, old, current any) (CardChange, bool) {
+	switch {
+	case old == nil && current == nil:
+		return CardChange{}, false
+	case old == nil:
+		return CardChange{Path: path, Kind: CardChangeAdded, New: current}, true
+	case current == nil:
+		return CardChange{Path: path, Kind: CardChangeRemoved, Old: old}, true
+	}
+
+	if reflect.DeepEqual(old, current) {
+		return CardChange{}, false
+	}
+
+	return CardChange{Path: path, Kind: CardChangeChanged, Old: old, New: current}, true
+}
+
+func sortedUnion(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSyncA2ACard(t *testing.T) {
+	stored, _ := structpb.NewStruct(map[string]any{
+		"name":        "weather-agent",
+		"description": "Weather",
+		"skills": []any{
+			map[string]any{"id": "forecast", "name": "Forecast", "description": "Daily forecast"},
+			map[string]any{"name": "alerts"},
+		},
+	})
+
+	rec, err := translator.A2AToRecord(stored)
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	// Snake case keys are normalized as on import, so they are no change.
+	live, _ := structpb.NewStruct(map[string]any{
+		"name":                "weather-agent",
+		"description":         "Weather",
+		"default_input_modes": []any{"text/plain"},
+		"skills": []any{
+			map[string]any{"id": "forecast", "name": "Forecast", "description": "Hourly forecast"},
+		},
+	})
+
+	updated, changes, err := translator.SyncA2ACard(rec, live)
+	if err != nil {
+		t.Fatalf("SyncA2ACard: %v", err)
+	}
+
+	want := []translator.CardChange{
+		{Path: "defaultInputModes", Kind: translator.CardChangeAdded},
+		{Path: "skills.alerts", Kind: translator.CardChangeRemoved},
+		{Path: "skills.forecast", Kind: translator.CardChangeChanged},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+
+	for i, c := range changes {
+		if c.Path != want[i].Path || c.Kind != want[i].Kind {
+			t.Errorf("change %d = %s %s, want %s %s", i, c.Kind, c.Path, want[i].Kind, want[i].Path)
+		}
+	}
+
+	card, err := translator.RecordToA2A(updated)
+	if err != nil {
+		t.Fatalf("RecordToA2A: %v", err)
+	}
+
+	if skills := card.GetFields()["skills"].GetListValue().GetValues(); len(skills) != 1 {
+		t.Errorf("got %d skills, want 1", len(skills))
+	}
+
+	if again, changes, err := translator.SyncA2ACard(updated, live); err != nil || len(changes) != 0 || again != updated {
+		t.Errorf("resync: changes %+v, err %v", changes, err)
+	}
+}
+
+func TestSyncA2ACardErrors(t *testing.T) {
+	card, _ := structpb.NewStruct(map[string]any{"name": "agent"})
+	rec, _ := structpb.NewStruct(map[string]any{"name": "no-modules"})
+
+	if _, _, err := translator.SyncA2ACard(rec, card); err == nil {
+		t.Error("expected an error for a record without A2A module")
+	}
+
+	if _, _, err := translator.SyncA2ACard(rec, nil); err == nil {
+		t.Error("expected an error for a nil card")
+	}
+}
@@ -4,6 +4,8 @@
 package translator_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/agntcy/oasf-sdk/pkg/translator"
@@ -164,6 +166,149 @@ func TestA2AToRecord_UnwrappedFormat(t *testing.T) {
 	}
 }
 
+func TestA2AToRecord_SnakeCaseCard(t *testing.T) {
+	input, err := structpb.NewStruct(map[string]any{
+		"name":                 "my-agent",
+		"protocol_version":     "0.3.0",
+		"default_output_modes": []any{"text"},
+		"security_schemes":     map[string]any{"api_key_auth": map[string]any{"type": "apiKey"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build input: %v", err)
+	}
+
+	record, err := translator.A2AToRecord(input)
+	if err != nil {
+		t.Fatalf("A2AToRecord() error = %v", err)
+	}
+
+	card, err := translator.RecordToA2A(record)
+	if err != nil {
+		t.Fatalf("RecordToA2A() error = %v", err)
+	}
+
+	fields := card.GetFields()
+	if fields["protocolVersion"].GetStringValue() != "0.3.0" || fields["defaultOutputModes"] == nil {
+		t.Errorf("expected camelCase card keys, got %v", card.AsMap())
+	}
+
+	if fields["securitySchemes"].GetStructValue().GetFields()["api_key_auth"] == nil {
+		t.Errorf("security scheme names must be kept, got %v", fields["securitySchemes"])
+	}
+}
+
+func TestA2AToRecord_ArtifactRoundTrip(t *testing.T) {
+	// A camelCase card comes back byte for byte, the keys of its free-form
+	// data included.
+	const cardJSON = `{
+		"name": "data-agent",
+		"protocolVersion": "0.3.0",
+		"url": "https://agent.example.org/a2a",
+		"capabilities": {
+			"extensions": [{
+				"uri": "https://example.org/ext/quota/v1",
+				"required": false,
+				"params": {"max_calls": 10, "per_minute": {"burst_size": 2}}
+			}]
+		},
+		"securitySchemes": {"api_key": {"type": "apiKey", "in": "header", "name": "X-API-Key"}},
+		"security": [{"api_key": []}],
+		"skills": [{"id": "lookup", "name": "Lookup", "tags": ["search"], "input_hint": {"query_text": "string"}}],
+		"signatures": [{"protected": "e30", "signature": "c2ln", "header": {"key_id": "k1"}}],
+		"x-vendor": {"rate_limit": {"requests_per_second": 5}},
+		"https://example.org/ext/quota/v1": {"default_plan": "free"}
+	}`
+
+	var input map[string]any
+	if err := json.Unmarshal([]byte(cardJSON), &input); err != nil {
+		t.Fatalf("failed to decode card: %v", err)
+	}
+
+	inputStruct, err := structpb.NewStruct(input)
+	if err != nil {
+		t.Fatalf("failed to build input: %v", err)
+	}
+
+	record, err := translator.A2AToRecord(inputStruct)
+	if err != nil {
+		t.Fatalf("A2AToRecord() error = %v", err)
+	}
+
+	card, err := translator.RecordToA2A(record)
+	if err != nil {
+		t.Fatalf("RecordToA2A() error = %v", err)
+	}
+
+	want, _ := json.Marshal(input)
+
+	got, err := json.Marshal(card.AsMap())
+	if err != nil {
+		t.Fatalf("failed to encode card: %v", err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("card = %s\nwant %s", got, want)
+	}
+}
+
+func TestA2AToRecord_SecuritySchemesRoundTrip(t *testing.T) {
+	input, err := structpb.NewStruct(map[string]any{
+		"name": "secured-agent",
+		"security_schemes": map[string]any{
+			"api_key": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			"oauth": map[string]any{
+				"type": "oauth2",
+				"flows": map[string]any{
+					"authorization_code": map[string]any{
+						"authorization_url": "https://auth.example.org/authorize",
+						"token_url":         "https://auth.example.org/token",
+						"scopes":            map[string]any{"repo_read": "Read repositories", "repo:write": "Write repositories"},
+					},
+				},
+			},
+			"oidc": map[string]any{"type": "openIdConnect", "open_id_connect_url": "https://auth.example.org/.well-known/openid-configuration"},
+		},
+		"security": []any{map[string]any{"oauth": []any{"repo_read"}}, map[string]any{"api_key": []any{}}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build input: %v", err)
+	}
+
+	record, err := translator.A2AToRecord(input)
+	if err != nil {
+		t.Fatalf("A2AToRecord() error = %v", err)
+	}
+
+	card, err := translator.RecordToA2A(record)
+	if err != nil {
+		t.Fatalf("RecordToA2A() error = %v", err)
+	}
+
+	schemes := card.GetFields()["securitySchemes"].GetStructValue().GetFields()
+
+	flow := schemes["oauth"].GetStructValue().GetFields()["flows"].GetStructValue().GetFields()["authorizationCode"].GetStructValue().GetFields()
+	if flow["tokenUrl"].GetStringValue() != "https://auth.example.org/token" {
+		t.Errorf("flow = %v, want the camelCase fields of the flow", flow)
+	}
+
+	if scopes := flow["scopes"].GetStructValue().GetFields(); scopes["repo_read"] == nil || scopes["repo:write"] == nil {
+		t.Errorf("scopes = %v, want the scope names kept", scopes)
+	}
+
+	if apiKey := schemes["api_key"].GetStructValue().AsMap(); apiKey["in"] != "header" || apiKey["name"] != "X-API-Key" {
+		t.Errorf("api_key = %v, want the location of the key kept", apiKey)
+	}
+
+	if oidc := schemes["oidc"].GetStructValue().GetFields(); oidc["openIdConnectUrl"].GetStringValue() == "" {
+		t.Errorf("oidc = %v, want the OpenID Connect discovery URL", oidc)
+	}
+
+	security := card.GetFields()["security"].GetListValue().GetValues()
+	if len(security) != 2 || security[0].GetStructValue().GetFields()["oauth"].GetListValue().GetValues()[0].GetStringValue() != "repo_read" {
+		t.Errorf("security = %v, want the requirements kept", security)
+	}
+}
+
 func TestA2AToRecord_DefaultNameAndDescription(t *testing.T) {
 	input, err := structpb.NewStruct(map[string]any{
 		"a2aCard": map[string]any{},
@@ -4,14 +4,13 @@
 package translator
 
 import (
-	"errors"
 	"fmt"
 	"maps"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
 	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -37,17 +36,17 @@ const (
 // is reconstructed from the stored manifest (frontmatter only, no body).
 func RecordToSkillMarkdown(record *structpb.Struct) (string, error) {
 	if record == nil {
-		return "", errors.New("record is nil")
+		return "", fmt.Errorf("%w: record is nil", ErrInvalidInput)
 	}
 
 	found, moduleStruct := recordutil.GetModule(record, AgentSkillsModuleName)
 	if !found || moduleStruct == nil {
-		return "", errors.New("agentskills module not found in record")
+		return "", fmt.Errorf("agentskills %w", ErrModuleNotFound)
 	}
 
 	mediaType := moduleStruct.GetFields()["artifact"].GetStructValue().GetFields()["media_type"].GetStringValue()
 	if mediaType == agentSkillsBundleMediaType {
-		return "", fmt.Errorf("record artifact is %q; SKILL.md cannot be extracted directly from a bundle archive", mediaType)
+		return "", fmt.Errorf("%w: record artifact is %q; SKILL.md cannot be extracted directly from a bundle archive", ErrInvalidInput, mediaType)
 	}
 
 	// Prefer returning the full original SKILL.md from the artifact when available.
@@ -65,12 +64,12 @@ func RecordToSkillMarkdown(record *structpb.Struct) (string, error) {
 func buildSkillMarkdownFromManifest(moduleData *structpb.Struct) (string, error) {
 	manifestField := moduleData.GetFields()["skill_manifest"]
 	if manifestField == nil {
-		return "", errors.New("skill_manifest is missing")
+		return "", fmt.Errorf("%w: skill_manifest is missing", ErrInvalidInput)
 	}
 
 	manifest := manifestField.GetStructValue()
 	if manifest == nil {
-		return "", errors.New("skill_manifest is not a struct")
+		return "", fmt.Errorf("%w: skill_manifest is not a struct", ErrInvalidInput)
 	}
 
 	manifestMap := manifest.AsMap()
@@ -78,7 +77,7 @@ func buildSkillMarkdownFromManifest(moduleData *structpb.Struct) (string, error)
 	description := getString(manifestMap, "description")
 
 	if name == "" || description == "" {
-		return "", errors.New("manifest must include name and description")
+		return "", fmt.Errorf("%w: manifest must include name and description", ErrInvalidInput)
 	}
 
 	license := getString(manifestMap, "license")
@@ -168,7 +167,7 @@ func skillToRecord(content string, artifactPayload []byte, artifactMediaType str
 		sourceAuthors = []string{author}
 	}
 
-	authors := authorsToValues(resolveRecordAuthors(sourceAuthors, options.authors))
+	authors := resolveRecordAuthors(sourceAuthors, options)
 	manifestFields := buildManifestFields(parsed, recordVersion)
 	moduleDataFields := buildModuleDataFields(manifestFields)
 
@@ -176,9 +175,6 @@ func skillToRecord(content string, artifactPayload []byte, artifactMediaType str
 		moduleDataFields["artifacts"] = buildArtifactsListValue(archiveEntries)
 	}
 
-	artifactStruct := buildArtifactDescriptor(artifactPayload, artifactMediaType)
-	agentSkillsModule := buildAgentSkillsModule(moduleDataFields, artifactStruct)
-
 	targetVersion := DefaultSchemaVersion
 
 	if options.version != "" {
@@ -189,116 +185,63 @@ func skillToRecord(content string, artifactPayload []byte, artifactMediaType str
 		targetVersion = options.version
 	}
 
-	return buildRecord(parsed.name, parsed.description, targetVersion, recordVersion, authors, agentSkillsModule), nil
-}
+	record, err := recordutil.NewBuilder().
+		Name(parsed.name).
+		SchemaVersion(targetVersion).
+		Version(recordVersion).
+		Description(parsed.description).
+		Authors(authors...).
+		AddModule(AgentSkillsModuleName, pb.Struct(moduleDataFields),
+			recordutil.WithModuleID(agentSkillsModuleID),
+			recordutil.WithModuleArtifact(artifactPayload, artifactMediaType)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
 
-func authorsToValues(authors []string) []*structpb.Value {
-	values := make([]*structpb.Value, 0, len(authors))
-	for _, author := range authors {
-		values = append(values, &structpb.Value{
-			Kind: &structpb.Value_StringValue{StringValue: author},
-		})
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
 	}
 
-	return values
+	return record, nil
 }
 
-func buildManifestFields(parsed skillMarkdownFields, version string) map[string]*structpb.Value {
-	fields := map[string]*structpb.Value{
-		"name":        {Kind: &structpb.Value_StringValue{StringValue: parsed.name}},
-		"description": {Kind: &structpb.Value_StringValue{StringValue: parsed.description}},
+func buildManifestFields(parsed skillMarkdownFields, version string) pb.Fields {
+	fields := pb.Fields{
+		"name":        pb.Str(parsed.name),
+		"description": pb.Str(parsed.description),
 	}
 
 	if parsed.license != "" {
-		fields["license"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: parsed.license}}
+		fields["license"] = pb.Str(parsed.license)
 	}
 
-	fields["version"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: version}}
+	fields["version"] = pb.Str(version)
 
 	if parsed.compatibility != "" {
-		fields["compatibility"] = &structpb.Value{
-			Kind: &structpb.Value_ListValue{
-				ListValue: &structpb.ListValue{
-					Values: []*structpb.Value{
-						{Kind: &structpb.Value_StringValue{StringValue: parsed.compatibility}},
-					},
-				},
-			},
-		}
+		fields["compatibility"] = pb.List(pb.Str(parsed.compatibility))
 	}
 
 	if len(parsed.allowedTools) > 0 {
-		toolValues := make([]*structpb.Value, 0, len(parsed.allowedTools))
-		for _, tool := range parsed.allowedTools {
-			toolValues = append(toolValues, &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: tool}})
-		}
-
-		fields["allowed_tools"] = &structpb.Value{
-			Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: toolValues}},
-		}
+		fields["allowed_tools"] = pb.Strs(parsed.allowedTools)
 	}
 
 	if len(parsed.metadata) > 0 {
-		metaFields := make(map[string]*structpb.Value, len(parsed.metadata))
+		metaFields := make(pb.Fields, len(parsed.metadata))
 		for k, v := range parsed.metadata {
-			metaFields[k] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: v}}
+			metaFields[k] = pb.Str(v)
 		}
 
-		fields["frontmatter_metadata"] = &structpb.Value{
-			Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: metaFields}},
-		}
+		fields["frontmatter_metadata"] = pb.Obj(metaFields)
 	}
 
 	return fields
 }
 
-func buildModuleDataFields(manifestFields map[string]*structpb.Value) map[string]*structpb.Value {
-	return map[string]*structpb.Value{
-		"skill_file": {Kind: &structpb.Value_StringValue{StringValue: "SKILL.md"}},
-		"skill_manifest": {
-			Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: manifestFields}},
-		},
-	}
-}
-
-func buildAgentSkillsModule(moduleDataFields map[string]*structpb.Value, artifactStruct *structpb.Struct) *structpb.Struct {
-	return &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"name":     {Kind: &structpb.Value_StringValue{StringValue: AgentSkillsModuleName}},
-			"id":       {Kind: &structpb.Value_NumberValue{NumberValue: agentSkillsModuleID}},
-			"data":     {Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: moduleDataFields}}},
-			"artifact": {Kind: &structpb.Value_StructValue{StructValue: artifactStruct}},
-		},
-	}
-}
-
-func buildRecord(name, description, schemaVersion, version string, authors []*structpb.Value, module *structpb.Struct) *structpb.Struct {
-	return &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"name":           {Kind: &structpb.Value_StringValue{StringValue: name}},
-			"schema_version": {Kind: &structpb.Value_StringValue{StringValue: schemaVersion}},
-			"version":        {Kind: &structpb.Value_StringValue{StringValue: version}},
-			"description":    {Kind: &structpb.Value_StringValue{StringValue: description}},
-			"authors": {
-				Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: authors}},
-			},
-			"created_at": {Kind: &structpb.Value_StringValue{StringValue: time.Now().UTC().Format(time.RFC3339)}},
-			"skills": {
-				Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{}}},
-			},
-			"domains": {
-				Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{}}},
-			},
-			"modules": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: []*structpb.Value{
-							{Kind: &structpb.Value_StructValue{StructValue: module}},
-						},
-					},
-				},
-			},
-		},
+func buildModuleDataFields(manifestFields pb.Fields) pb.Fields {
+	return pb.Fields{
+		"skill_file":     pb.Str("SKILL.md"),
+		"skill_manifest": pb.Obj(manifestFields),
 	}
 }
 
@@ -318,7 +261,7 @@ type skillMarkdownFields struct {
 func parseSkillMarkdownContent(content string) (skillMarkdownFields, error) {
 	sections := strings.SplitN(content, "---", frontmatterParts)
 	if len(sections) < frontmatterMinParts {
-		return skillMarkdownFields{}, errors.New("invalid SKILL.md: missing frontmatter delimiters")
+		return skillMarkdownFields{}, fmt.Errorf("%w: invalid SKILL.md: missing frontmatter delimiters", ErrInvalidInput)
 	}
 
 	frontmatter := strings.TrimSpace(sections[1])
@@ -365,7 +308,7 @@ func parseSkillMarkdownContent(content string) (skillMarkdownFields, error) {
 	}
 
 	if result.name == "" || result.description == "" {
-		return skillMarkdownFields{}, errors.New("SKILL.md must include name and description in frontmatter")
+		return skillMarkdownFields{}, fmt.Errorf("%w: SKILL.md must include name and description in frontmatter", ErrInvalidInput)
 	}
 
 	return result, nil
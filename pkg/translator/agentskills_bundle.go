@@ -17,6 +17,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
 	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -64,22 +65,22 @@ func SkillBundleToRecord(skillData *structpb.Struct, opts ...TranslatorOption) (
 // RecordToSkillBundle returns the .gzip bytes from a application/agent-skills+gzip record.
 func RecordToSkillBundle(record *structpb.Struct) ([]byte, error) {
 	if record == nil {
-		return nil, errors.New("record is nil")
+		return nil, fmt.Errorf("%w: record is nil", ErrInvalidInput)
 	}
 
 	found, moduleStruct := recordutil.GetModule(record, AgentSkillsModuleName)
 	if !found || moduleStruct == nil {
-		return nil, fmt.Errorf("module %q not found", AgentSkillsModuleName)
+		return nil, fmt.Errorf("%q %w", AgentSkillsModuleName, ErrModuleNotFound)
 	}
 
 	mediaType := moduleStruct.GetFields()["artifact"].GetStructValue().GetFields()["media_type"].GetStringValue()
 	if mediaType != agentSkillsBundleMediaType {
-		return nil, fmt.Errorf("not a skill bundle: %q", mediaType)
+		return nil, fmt.Errorf("%w: not a skill bundle: %q", ErrInvalidInput, mediaType)
 	}
 
 	raw := artifactDataBytes(moduleStruct)
 	if len(raw) == 0 {
-		return nil, errors.New("bundle artifact data is missing or invalid")
+		return nil, fmt.Errorf("%w: bundle artifact data is missing or invalid", ErrInvalidInput)
 	}
 
 	return raw, nil
@@ -87,17 +88,17 @@ func RecordToSkillBundle(record *structpb.Struct) ([]byte, error) {
 
 func extractSkillMarkdown(skillData *structpb.Struct) (string, error) {
 	if skillData == nil {
-		return "", errors.New("input data is nil")
+		return "", fmt.Errorf("%w: input data is nil", ErrInvalidInput)
 	}
 
 	mdVal, ok := skillData.GetFields()["skillMarkdown"]
 	if !ok {
-		return "", errors.New("missing 'skillMarkdown' in input data")
+		return "", fmt.Errorf("%w: missing 'skillMarkdown' in input data", ErrInvalidInput)
 	}
 
 	content := mdVal.GetStringValue()
 	if content == "" {
-		return "", errors.New("'skillMarkdown' is empty")
+		return "", fmt.Errorf("%w: 'skillMarkdown' is empty", ErrInvalidInput)
 	}
 
 	return content, nil
@@ -105,17 +106,17 @@ func extractSkillMarkdown(skillData *structpb.Struct) (string, error) {
 
 func extractSkillArchive(skillData *structpb.Struct) ([]byte, error) {
 	if skillData == nil {
-		return nil, errors.New("input data is nil")
+		return nil, fmt.Errorf("%w: input data is nil", ErrInvalidInput)
 	}
 
 	archiveVal, ok := skillData.GetFields()["skillArchive"]
 	if !ok {
-		return nil, errors.New("missing 'skillArchive' in input data")
+		return nil, fmt.Errorf("%w: missing 'skillArchive' in input data", ErrInvalidInput)
 	}
 
 	encoded := archiveVal.GetStringValue()
 	if encoded == "" {
-		return nil, errors.New("'skillArchive' is empty")
+		return nil, fmt.Errorf("%w: 'skillArchive' is empty", ErrInvalidInput)
 	}
 
 	archive, err := base64.StdEncoding.DecodeString(encoded)
@@ -124,7 +125,7 @@ func extractSkillArchive(skillData *structpb.Struct) ([]byte, error) {
 	}
 
 	if len(archive) == 0 {
-		return nil, errors.New("'skillArchive' is empty")
+		return nil, fmt.Errorf("%w: 'skillArchive' is empty", ErrInvalidInput)
 	}
 
 	return archive, nil
@@ -156,7 +157,7 @@ func getArchiveEntries(archive []byte) ([]archiveEntry, error) {
 	}
 
 	if !builder.foundSkillFile {
-		return nil, fmt.Errorf("archive must contain %q", skillFile)
+		return nil, fmt.Errorf("%w: archive must contain %q", ErrInvalidInput, skillFile)
 	}
 
 	return builder.entries, nil
@@ -216,17 +217,17 @@ func (b *archiveIndexBuilder) addEntry(reader *tar.Reader, header *tar.Header) e
 
 func (b *archiveIndexBuilder) checkLimits(size int64) error {
 	if size < 0 {
-		return errors.New("negative file size")
+		return fmt.Errorf("%w: negative file size", ErrInvalidInput)
 	}
 
 	b.uncompressedBytes += size
 	if b.uncompressedBytes > maxSkillArchiveUncompressed {
-		return fmt.Errorf("archive exceeds %d byte uncompressed limit", maxSkillArchiveUncompressed)
+		return fmt.Errorf("%w: archive exceeds %d byte uncompressed limit", ErrInvalidInput, maxSkillArchiveUncompressed)
 	}
 
 	b.fileCount++
 	if b.fileCount > maxSkillArchiveFiles {
-		return fmt.Errorf("archive exceeds %d file limit", maxSkillArchiveFiles)
+		return fmt.Errorf("%w: archive exceeds %d file limit", ErrInvalidInput, maxSkillArchiveFiles)
 	}
 
 	return nil
@@ -241,7 +242,7 @@ func readArchiveEntry(reader *tar.Reader, header *tar.Header, entryPath string)
 	}
 
 	if int64(len(payload)) != header.Size {
-		return archiveEntry{}, fmt.Errorf("truncated payload for %q", entryPath)
+		return archiveEntry{}, fmt.Errorf("%w: truncated payload for %q", ErrInvalidInput, entryPath)
 	}
 
 	sum := sha256.Sum256(payload)
@@ -258,11 +259,11 @@ func cleanPath(name string) (string, error) {
 	clean = strings.TrimPrefix(clean, "./")
 
 	if clean == "" || clean == "." {
-		return "", fmt.Errorf("invalid archive path %q", name)
+		return "", fmt.Errorf("%w: invalid archive path %q", ErrInvalidInput, name)
 	}
 
 	if strings.HasPrefix(clean, "../") || clean == ".." {
-		return "", fmt.Errorf("archive path traversal is not allowed: %q", name)
+		return "", fmt.Errorf("%w: archive path traversal is not allowed: %q", ErrInvalidInput, name)
 	}
 
 	return clean, nil
@@ -294,21 +295,17 @@ func buildArtifactsListValue(entries []archiveEntry) *structpb.Value {
 	values := make([]*structpb.Value, 0, len(sorted))
 
 	for _, entry := range sorted {
-		fields := map[string]*structpb.Value{
-			"path": {Kind: &structpb.Value_StringValue{StringValue: entry.path}},
-			"type": {Kind: &structpb.Value_StringValue{StringValue: entry.typ}},
+		fields := pb.Fields{
+			"path": pb.Str(entry.path),
+			"type": pb.Str(entry.typ),
 		}
 
 		if entry.hash != "" {
-			fields["artifact_hash"] = &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: entry.hash}}
+			fields["artifact_hash"] = pb.Str(entry.hash)
 		}
 
-		values = append(values, &structpb.Value{
-			Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}},
-		})
+		values = append(values, pb.Obj(fields))
 	}
 
-	return &structpb.Value{
-		Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}},
-	}
+	return pb.List(values...)
 }
@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// BedrockOpenAPIVersion is the OpenAPI version of the action group schemas,
+// the one Bedrock agents accept.
+const BedrockOpenAPIVersion = "3.0.0"
+
+// BedrockReturnControl is the custom control of action groups without a
+// Lambda executor: the agent returns the calls of its actions to the
+// application invoking it.
+const BedrockReturnControl = "RETURN_CONTROL"
+
+// Limits of the Bedrock agent APIs.
+const (
+	maxBedrockNameLength        = 100
+	maxBedrockDescriptionLength = 200
+	maxBedrockInstructionLength = 4000
+)
+
+// bedrockNameInvalid matches the runs of characters not allowed in Bedrock
+// agent and action group names.
+var bedrockNameInvalid = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// BedrockActionGroup is an AWS Bedrock agent action group, in the shape of
+// the input of the CreateAgentActionGroup API, and the instruction of the
+// agent it is added to.
+type BedrockActionGroup struct {
+	ActionGroupName     string                     `json:"actionGroupName"`
+	Description         string                     `json:"description,omitempty"`
+	ActionGroupExecutor BedrockActionGroupExecutor `json:"actionGroupExecutor"`
+	APISchema           BedrockAPISchema           `json:"apiSchema"`
+	// Instruction is the instruction of the agent (CreateAgent), telling it
+	// what it is for and when to use the actions of the group.
+	Instruction string `json:"instruction"`
+}
+
+// BedrockActionGroupExecutor is how the actions of a group are run.
+type BedrockActionGroupExecutor struct {
+	CustomControl string `json:"customControl,omitempty"`
+}
+
+// BedrockAPISchema is the API schema of an action group, inline.
+type BedrockAPISchema struct {
+	// Payload is the OpenAPI document of the actions, as JSON.
+	Payload string `json:"payload"`
+}
+
+// RecordToBedrockActionGroup translates a record into an AWS Bedrock agent
+// action group and the instruction of its agent. Supports OASF versions
+// 0.7.0, 0.8.0, and 1.0.0.
+//
+// The actions are the tools of RecordToOpenAITools: every MCP tool and A2A
+// skill is a POST operation of the OpenAPI payload, whose request body is
+// the parameters of the tool and whose description tells the agent when to
+// call it. The group returns control to the application invoking the agent,
+// which runs the actions against the MCP server or A2A agent. The
+// instruction is built from the record description and skills.
+func RecordToBedrockActionGroup(record *structpb.Struct) (*BedrockActionGroup, error) {
+	name := recordStringField(record, "name")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	groupName := bedrockName(name)
+	if groupName == "" {
+		return nil, fmt.Errorf("%w: record has no name", ErrInvalidInput)
+	}
+
+	tools, err := RecordToOpenAITools(record)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]any, len(tools))
+	for _, tool := range tools {
+		paths["/"+tool.Function.Name] = map[string]any{"post": map[string]any{
+			"operationId": tool.Function.Name,
+			"summary":     tool.Function.Name,
+			"description": firstNonEmptyString(tool.Function.Description, "Call "+tool.Function.Name+"."),
+			"requestBody": map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": tool.Function.Parameters}},
+			},
+			"responses": map[string]any{"200": map[string]any{
+				"description": "The result of " + tool.Function.Name,
+				"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"type": "object"}}},
+			}},
+		}}
+	}
+
+	description := recordStringField(record, "description")
+
+	payload, err := json.Marshal(map[string]any{
+		"openapi": BedrockOpenAPIVersion,
+		"info": map[string]any{
+			"title":       name,
+			"version":     firstNonEmptyString(recordStringField(record, "version"), "0.0.0"),
+			"description": firstNonEmptyString(description, "The actions of "+name+"."),
+		},
+		"paths": paths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI payload: %w", err)
+	}
+
+	return &BedrockActionGroup{
+		ActionGroupName:     groupName,
+		Description:         truncate(description, maxBedrockDescriptionLength),
+		ActionGroupExecutor: BedrockActionGroupExecutor{CustomControl: BedrockReturnControl},
+		APISchema:           BedrockAPISchema{Payload: string(payload)},
+		Instruction:         truncate(bedrockInstruction(record, name, groupName), maxBedrockInstructionLength),
+	}, nil
+}
+
+// bedrockInstruction returns the instruction of the agent of the record.
+func bedrockInstruction(record *structpb.Struct, name, groupName string) string {
+	instruction := []string{"You are " + name + "."}
+
+	if description := recordStringField(record, "description"); description != "" {
+		instruction = append(instruction, strings.TrimSuffix(description, ".")+".")
+	}
+
+	if skills := crewAISkills(record); len(skills) > 0 {
+		instruction = append(instruction, "You are skilled in "+strings.Join(skills, ", ")+".")
+	}
+
+	instruction = append(instruction, "Use the actions of the "+groupName+
+		" action group to fulfill the requests of the user, and answer with their results.")
+
+	return strings.Join(instruction, " ")
+}
+
+// bedrockName turns a name into a Bedrock agent or action group name, e.g.
+// "Weather Agent" into "Weather_Agent".
+func bedrockName(name string) string {
+	name = strings.Trim(bedrockNameInvalid.ReplaceAllString(name, "_"), "_")
+	if len(name) > maxBedrockNameLength {
+		name = strings.TrimRight(name[:maxBedrockNameLength], "_")
+	}
+
+	return name
+}
+
+// truncate cuts s to at most n bytes, on a rune boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n]
+}
@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToBedrockActionGroup(t *testing.T) {
+	record := a2aRecord(t, map[string]any{
+		"name":        "travel-agent",
+		"description": "Plans trips.",
+		"version":     "2.1.0",
+		"skills": []any{
+			map[string]any{"id": "flight search", "name": "Flight search", "description": "Finds flights."},
+			map[string]any{"id": "hotels"},
+		},
+	})
+
+	group, err := translator.RecordToBedrockActionGroup(record)
+	if err != nil {
+		t.Fatalf("RecordToBedrockActionGroup() error: %v", err)
+	}
+
+	if group.ActionGroupName != "travel_agent" {
+		t.Errorf("ActionGroupName = %q, want travel_agent", group.ActionGroupName)
+	}
+
+	if group.Description != "Plans trips." {
+		t.Errorf("Description = %q, want the record description", group.Description)
+	}
+
+	if group.ActionGroupExecutor.CustomControl != translator.BedrockReturnControl {
+		t.Errorf("CustomControl = %q, want %q", group.ActionGroupExecutor.CustomControl, translator.BedrockReturnControl)
+	}
+
+	wantInstruction := "You are travel-agent. Plans trips. You are skilled in Flight search, hotels. " +
+		"Use the actions of the travel_agent action group to fulfill the requests of the user, and answer with their results."
+	if group.Instruction != wantInstruction {
+		t.Errorf("Instruction = %q\nwant %q", group.Instruction, wantInstruction)
+	}
+
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(group.APISchema.Payload), &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+
+	if payload["openapi"] != translator.BedrockOpenAPIVersion {
+		t.Errorf("openapi = %v, want %s", payload["openapi"], translator.BedrockOpenAPIVersion)
+	}
+
+	if version := payload["info"].(map[string]any)["version"]; version != "2.1.0" {
+		t.Errorf("info.version = %v, want 2.1.0", version)
+	}
+
+	paths := payload["paths"].(map[string]any)
+	if len(paths) != 2 {
+		t.Fatalf("paths = %v, want one per skill", paths)
+	}
+
+	operation := paths["/flight_search"].(map[string]any)["post"].(map[string]any)
+	if operation["operationId"] != "flight_search" || operation["description"] != "Finds flights." {
+		t.Errorf("operation = %v, want flight_search described by its skill", operation)
+	}
+
+	schema := operation["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	if required := schema.(map[string]any)["required"]; len(required.([]any)) != 1 {
+		t.Errorf("request schema = %v, want the message parameter", schema)
+	}
+
+	if _, ok := operation["responses"].(map[string]any)["200"]; !ok {
+		t.Errorf("operation has no 200 response: %v", operation)
+	}
+}
+
+func TestRecordToBedrockActionGroupLimits(t *testing.T) {
+	record := a2aRecord(t, map[string]any{
+		"name":        strings.Repeat("agent ", 30),
+		"description": strings.Repeat("é", 150),
+		"skills":      []any{map[string]any{"id": "echo"}},
+	})
+
+	group, err := translator.RecordToBedrockActionGroup(record)
+	if err != nil {
+		t.Fatalf("RecordToBedrockActionGroup() error: %v", err)
+	}
+
+	if n := len(group.ActionGroupName); n > 100 || strings.HasSuffix(group.ActionGroupName, "_") {
+		t.Errorf("ActionGroupName = %q (%d bytes), want at most 100 bytes without a trailing separator", group.ActionGroupName, n)
+	}
+
+	if n := len(group.Description); n != 200 {
+		t.Errorf("Description has %d bytes, want 200", n)
+	}
+}
+
+func TestRecordToBedrockActionGroupNoActions(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{"name": "empty", "schema_version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	if _, err := translator.RecordToBedrockActionGroup(record); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func BenchmarkMCPToRecord(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", "translation_mcp.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	in := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, in); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := translator.MCPToRecord(in); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -116,7 +118,7 @@ type catalogModule struct {
 // here.
 func RecordToCatalog(record *structpb.Struct, opts ...CatalogOption) (*structpb.Struct, error) {
 	if record == nil {
-		return nil, errors.New("record is nil")
+		return nil, fmt.Errorf("%w: record is nil", ErrInvalidInput)
 	}
 
 	options := &catalogOptions{
@@ -134,7 +136,7 @@ func RecordToCatalog(record *structpb.Struct, opts ...CatalogOption) (*structpb.
 
 	modules := knownCatalogModules(record)
 	if len(modules) == 0 {
-		return nil, errors.New("record has no known catalog modules")
+		return nil, fmt.Errorf("%w: record has no known catalog modules", ErrModuleNotFound)
 	}
 
 	name := recordStringField(record, "name")
@@ -148,7 +150,7 @@ func RecordToCatalog(record *structpb.Struct, opts ...CatalogOption) (*structpb.
 	// Single known module — leaf entry on the parent URN.
 	if len(modules) == 1 {
 		entry := moduleToCatalogEntry(modules[0], baseURN)
-		entry.Fields["display_name"] = catalogStringValue(firstNonEmptyString(name, baseURN))
+		entry.Fields["display_name"] = pb.Str(firstNonEmptyString(name, baseURN))
 		setCatalogTags(entry, tags)
 		setOptionalString(entry, "version", version)
 		setOptionalString(entry, "description", description)
@@ -168,28 +170,22 @@ func RecordToCatalog(record *structpb.Struct, opts ...CatalogOption) (*structpb.
 		moduleURN := catalogURN(options.host, cid, catalogModules[m.name].URNSuffix)
 
 		entry := nestedCatalogEntry(m, moduleURN)
-		entry.Fields["displayName"] = catalogStringValue(moduleDisplayName(m, name))
+		entry.Fields["displayName"] = pb.Str(moduleDisplayName(m, name))
 
 		nested = append(nested, structpb.NewStructValue(entry))
 	}
 
-	nestedCatalog := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"specVersion": catalogStringValue(options.specVersion),
-			"entries": {
-				Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: nested}},
-			},
-		},
-	}
+	nestedCatalog := pb.Struct(pb.Fields{
+		"specVersion": pb.Str(options.specVersion),
+		"entries":     pb.List(nested...),
+	})
 
-	container := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"identifier":   catalogStringValue(baseURN),
-			"display_name": catalogStringValue(firstNonEmptyString(name, baseURN)),
-			"media_type":   catalogStringValue(CatalogContainerMediaType),
-			"data":         structpb.NewStructValue(nestedCatalog),
-		},
-	}
+	container := pb.Struct(pb.Fields{
+		"identifier":   pb.Str(baseURN),
+		"display_name": pb.Str(firstNonEmptyString(name, baseURN)),
+		"media_type":   pb.Str(CatalogContainerMediaType),
+		"data":         structpb.NewStructValue(nestedCatalog),
+	})
 	setCatalogTags(container, tags)
 	setOptionalString(container, "version", version)
 	setOptionalString(container, "description", description)
@@ -207,16 +203,14 @@ func moduleToCatalogEntry(m catalogModule, identifier string) *structpb.Struct {
 
 	data := m.data
 	if data == nil {
-		data = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		data = pb.Struct(nil)
 	}
 
-	return &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"identifier": catalogStringValue(identifier),
-			"media_type": catalogStringValue(proj.MediaType),
-			"data":       structpb.NewStructValue(data),
-		},
-	}
+	return pb.Struct(pb.Fields{
+		"identifier": pb.Str(identifier),
+		"media_type": pb.Str(proj.MediaType),
+		"data":       structpb.NewStructValue(data),
+	})
 }
 
 // nestedCatalogEntry builds a catalog entry for a module inside a
@@ -227,16 +221,14 @@ func nestedCatalogEntry(m catalogModule, identifier string) *structpb.Struct {
 
 	data := m.data
 	if data == nil {
-		data = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		data = pb.Struct(nil)
 	}
 
-	return &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"identifier": catalogStringValue(identifier),
-			"mediaType":  catalogStringValue(proj.MediaType),
-			"data":       structpb.NewStructValue(data),
-		},
-	}
+	return pb.Struct(pb.Fields{
+		"identifier": pb.Str(identifier),
+		"mediaType":  pb.Str(proj.MediaType),
+		"data":       structpb.NewStructValue(data),
+	})
 }
 
 // knownCatalogModules returns the record's modules that have a catalog
@@ -293,9 +285,9 @@ func catalogTags(record *structpb.Struct) []string {
 
 	skills := taxonomyNames(record, "skills")
 	domains := taxonomyNames(record, "domains")
-	annotations := sortedAnnotations(record)
+	tags := sortedAnnotations(record)
 
-	out := make([]string, 0, len(skills)+len(domains)+len(annotations))
+	out := make([]string, 0, len(skills)+len(domains)+len(tags))
 
 	for _, s := range skills {
 		out = append(out, fmt.Sprintf("oasf:v%s:skills:%s", schemaVer, s))
@@ -305,7 +297,7 @@ func catalogTags(record *structpb.Struct) []string {
 		out = append(out, fmt.Sprintf("oasf:v%s:domains:%s", schemaVer, d))
 	}
 
-	out = append(out, annotations...)
+	out = append(out, tags...)
 
 	return out
 }
@@ -342,27 +334,11 @@ func taxonomyNames(record *structpb.Struct, field string) []string {
 // sortedAnnotations renders a record's annotations map into a
 // deterministic (key-sorted) list of "key" or "key=value" tags.
 func sortedAnnotations(record *structpb.Struct) []string {
-	val, ok := record.GetFields()["annotations"]
-	if !ok {
-		return nil
-	}
-
-	annotations := val.GetStructValue()
-	if annotations == nil || len(annotations.GetFields()) == 0 {
-		return nil
-	}
-
-	keys := make([]string, 0, len(annotations.GetFields()))
-	for key := range annotations.GetFields() {
-		keys = append(keys, key)
-	}
-
-	sort.Strings(keys)
-
+	keys := annotations.Keys(record)
 	out := make([]string, 0, len(keys))
 
 	for _, key := range keys {
-		value := annotations.GetFields()[key].GetStringValue()
+		value, _ := annotations.Get(record, key)
 		if value == "" {
 			out = append(out, key)
 		} else {
@@ -407,15 +383,10 @@ func recordStringField(record *structpb.Struct, field string) string {
 	return record.GetFields()[field].GetStringValue()
 }
 
-// catalogStringValue wraps a string in a structpb.Value.
-func catalogStringValue(s string) *structpb.Value {
-	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
-}
-
 // setOptionalString sets a string field on an entry only when non-empty.
 func setOptionalString(entry *structpb.Struct, field, value string) {
 	if value != "" {
-		entry.Fields[field] = catalogStringValue(value)
+		entry.Fields[field] = pb.Str(value)
 	}
 }
 
@@ -425,14 +396,7 @@ func setCatalogTags(entry *structpb.Struct, tags []string) {
 		return
 	}
 
-	values := make([]*structpb.Value, 0, len(tags))
-	for _, t := range tags {
-		values = append(values, catalogStringValue(t))
-	}
-
-	entry.Fields["tags"] = &structpb.Value{
-		Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: values}},
-	}
+	entry.Fields["tags"] = pb.Strs(tags)
 }
 
 // firstNonEmptyString returns the first non-empty string, or "".
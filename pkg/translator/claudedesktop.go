@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// mcpRemotePackage is the npm package bridging stdio clients to remote MCP
+// servers.
+const mcpRemotePackage = "mcp-remote"
+
+// ClaudeDesktopServer is a server of the Claude Desktop configuration.
+type ClaudeDesktopServer struct {
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// ClaudeDesktopConfig is a Claude Desktop claude_desktop_config.json: the
+// MCP servers the app starts, by name.
+type ClaudeDesktopConfig struct {
+	MCPServers map[string]ClaudeDesktopServer `json:"mcpServers"`
+}
+
+// RecordToClaudeDesktop translates the MCP module of a record into a Claude
+// Desktop configuration. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// Claude Desktop starts its servers over stdio and has no inputs, so
+// environment variables without a default are set to a "${NAME}" placeholder
+// to fill in. A 1.0.0 server with remote connections only is started through
+// mcp-remote (npx -y mcp-remote <url>), with its headers as --header
+// arguments; their secrets are "${NAME}" placeholders, set in the
+// environment of mcp-remote.
+func RecordToClaudeDesktop(record *structpb.Struct, opts ...ClientConfigOption) (*ClaudeDesktopConfig, error) {
+	options, err := newClientConfigOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	copilot, err := RecordToGHCopilot(record, WithGHCopilotPlatform(options.platform), WithGHCopilotPythonRunner(options.pythonRunner))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ClaudeDesktopConfig{MCPServers: make(map[string]ClaudeDesktopServer, len(copilot.Servers))}
+
+	for name, server := range copilot.Servers {
+		config.MCPServers[name] = ClaudeDesktopServer{
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     placeholderEnv(server.Env),
+		}
+	}
+
+	if len(config.MCPServers) == 0 {
+		server, name, ok := claudeDesktopRemoteServer(record)
+		if !ok {
+			return nil, fmt.Errorf("%w: no supported MCP connections in record", ErrInvalidInput)
+		}
+
+		server.Command, server.Args = options.platform.command(server.Command, server.Args)
+		config.MCPServers[name] = server
+	}
+
+	return config, nil
+}
+
+// claudeDesktopRemoteServer bridges the first remote connection of a 1.0.0
+// MCP module through mcp-remote.
+func claudeDesktopRemoteServer(record *structpb.Struct) (ClaudeDesktopServer, string, bool) {
+	connection, ok := firstRemoteConnection(record)
+	if !ok {
+		return ClaudeDesktopServer{}, "", false
+	}
+
+	args := []string{"-y", mcpRemotePackage, connection.url}
+
+	var env map[string]string
+
+	for _, header := range slices.Sorted(maps.Keys(connection.headers)) {
+		value := connection.headers[header]
+		args = append(args, "--header", header+": "+value)
+
+		// mcp-remote expands the placeholders of headers from its
+		// environment, left to fill in like those of stdio servers.
+		for _, match := range vsCodePlaceholder.FindAllStringSubmatch(value, -1) {
+			if env == nil {
+				env = map[string]string{}
+			}
+
+			env[match[1]] = match[0]
+		}
+	}
+
+	return ClaudeDesktopServer{Command: "npx", Args: args, Env: env}, connection.name, true
+}
+
+// remoteConnection is a remote connection of a 1.0.0 MCP module. The secrets
+// of its headers are placeholders (see secretHeaders).
+type remoteConnection struct {
+	// name is the normalized name of the server.
+	name string
+	// kind is the connection type, connectionTypeSSE or connectionTypeHTTP.
+	kind    string
+	url     string
+	headers map[string]string
+}
+
+// firstRemoteConnection returns the first remote (sse or streamable-http)
+// connection of a 1.0.0 MCP module.
+func firstRemoteConnection(record *structpb.Struct) (remoteConnection, bool) {
+	_, module := recordutil.FindModule(record, MCPModuleName)
+	fields := module.GetFields()["data"].GetStructValue().GetFields()
+
+	for _, connectionVal := range fields["connections"].GetListValue().GetValues() {
+		connection := connectionVal.GetStructValue().GetFields()
+
+		kind := connection["type"].GetStringValue()
+		switch kind {
+		case connectionTypeSSE, connectionTypeHTTP:
+		default:
+			continue
+		}
+
+		url := connection["url"].GetStringValue()
+		if url == "" {
+			continue
+		}
+
+		var headers map[string]string
+
+		for name, value := range connection["headers"].GetStructValue().GetFields() {
+			if headers == nil {
+				headers = map[string]string{}
+			}
+
+			headers[name] = value.GetStringValue()
+		}
+
+		return remoteConnection{
+			name:    normalizeServerName(fields["name"].GetStringValue()),
+			kind:    kind,
+			url:     url,
+			headers: secretHeaders(headers),
+		}, true
+	}
+
+	return remoteConnection{}, false
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToClaudeDesktop(t *testing.T) {
+	config, err := translator.RecordToClaudeDesktop(ghCopilotRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToClaudeDesktop() error: %v", err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+
+	server, ok := out["mcpServers"].(map[string]any)["github"].(map[string]any)
+	if !ok {
+		t.Fatalf("config = %s, want the github server under mcpServers", data)
+	}
+
+	if server["command"] != "docker" || len(server["args"].([]any)) != 3 {
+		t.Errorf("server = %v", server)
+	}
+
+	env := server["env"].(map[string]any)
+	if env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "${GITHUB_PERSONAL_ACCESS_TOKEN}" || env["LOG_LEVEL"] != "info" {
+		t.Errorf("env = %v, want placeholders for secrets and literals kept", env)
+	}
+}
+
+func TestRecordToClaudeDesktopRemote(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "weather-mcp-server",
+					"connections": []any{
+						map[string]any{
+							"type":    "streamable-http",
+							"url":     "https://weather.example.org/mcp",
+							"headers": map[string]any{"Authorization": "Bearer ${WEATHER_TOKEN}"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToClaudeDesktop(record)
+	if err != nil {
+		t.Fatalf("RecordToClaudeDesktop() error: %v", err)
+	}
+
+	want := []string{"-y", "mcp-remote", "https://weather.example.org/mcp", "--header", "Authorization: Bearer ${WEATHER_TOKEN}"}
+	if server := config.MCPServers["weather"]; server.Command != "npx" || !slices.Equal(server.Args, want) {
+		t.Errorf("server = %+v, want an mcp-remote bridge", server)
+	}
+}
+
+func TestRecordToClaudeDesktopNoMCPModule(t *testing.T) {
+	record, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0", "modules": []any{}})
+
+	if _, err := translator.RecordToClaudeDesktop(record); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("RecordToClaudeDesktop() error = %v, want ErrModuleNotFound", err)
+	}
+}
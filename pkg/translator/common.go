@@ -4,12 +4,16 @@
 package translator
 
 import (
-	"crypto/sha256"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -43,6 +47,18 @@ type translatorOptions struct {
 	version       string
 	recordVersion string
 	authors       []string
+	schemaClient  recordutil.SchemaClient
+	provenance    Provenance
+	check         bool
+	validator     RecordValidator
+	pythonRunner  PythonRunner
+	ctx           context.Context //nolint:containedctx
+}
+
+// RecordValidator validates records against a schema server, e.g. a
+// validator.Validator.
+type RecordValidator interface {
+	ValidateRecordMessages(ctx context.Context, record *structpb.Struct) (bool, []messages.Message, []messages.Message, error)
 }
 
 // WithVersion sets the schema version to use for translation.
@@ -67,6 +83,95 @@ func WithAuthors(authors []string) TranslatorOption {
 	}
 }
 
+// WithSchemaClient fills translated records with the defaults declared by the
+// record JSON schema of their schema version (see record.FillDefaults).
+func WithSchemaClient(client recordutil.SchemaClient) TranslatorOption {
+	return func(opts *translatorOptions) {
+		opts.schemaClient = client
+	}
+}
+
+// WithContext sets the context of the upstream calls made for generated
+// records, i.e. the schema defaults of WithSchemaClient and the schema server
+// validation of WithValidation, so that they follow the deadline, the
+// cancellation and the values (e.g. request IDs) of the caller. The default
+// is context.Background.
+func WithContext(ctx context.Context) TranslatorOption {
+	return func(opts *translatorOptions) {
+		opts.ctx = ctx
+	}
+}
+
+// WithValidation checks generated records before returning them, so callers
+// fail fast instead of receiving invalid records: the record is migrated to
+// its schema version (see record.Migrate), which names its modules for that
+// version, and must decode into the typed OASF model of the version. With a
+// validator, it must also pass validation by the schema server; a nil
+// validator only runs the local checks. Records failing the checks are
+// reported with ErrInvalidRecord.
+func WithValidation(validator RecordValidator) TranslatorOption {
+	return func(opts *translatorOptions) {
+		opts.check = true
+		opts.validator = validator
+	}
+}
+
+// finishRecord adds the fallback locator of WithProvenance to a translated
+// record and fills it with the schema defaults, then checks it when
+// WithValidation is set. Schemas are fetched and records validated under the
+// context of WithContext.
+func finishRecord(record *structpb.Struct, options *translatorOptions) error {
+	if err := options.provenance.addFallbackLocator(record); err != nil {
+		return err
+	}
+
+	ctx := options.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := recordutil.FillDefaults(ctx, record, options.schemaClient); err != nil {
+		return fmt.Errorf("failed to fill record defaults: %w", err)
+	}
+
+	if !options.check {
+		return nil
+	}
+
+	version := record.GetFields()["schema_version"].GetStringValue()
+
+	migrated, err := recordutil.Migrate(record, version)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+
+	record.Fields = migrated.GetFields()
+
+	if _, err := decoder.DecodeRecord(record); err != nil {
+		return fmt.Errorf("%w for schema version %s: %w", ErrInvalidRecord, version, err)
+	}
+
+	if options.validator == nil {
+		return nil
+	}
+
+	valid, errs, _, err := options.validator.ValidateRecordMessages(ctx, record)
+	if err != nil {
+		return fmt.Errorf("failed to validate record: %w", err)
+	}
+
+	if !valid {
+		texts := make([]string, 0, len(errs))
+		for _, msg := range errs {
+			texts = append(texts, msg.Text)
+		}
+
+		return fmt.Errorf("%w for schema version %s: %s", ErrInvalidRecord, version, strings.Join(texts, "; "))
+	}
+
+	return nil
+}
+
 func nonEmptyAuthors(authors []string) []string {
 	if len(authors) == 0 {
 		return nil
@@ -82,9 +187,10 @@ func nonEmptyAuthors(authors []string) []string {
 	return filtered
 }
 
-// resolveRecordAuthors returns authors with precedence: WithAuthors > sourceAuthors > defaultAuthor.
-func resolveRecordAuthors(sourceAuthors, optionAuthors []string) []string {
-	if explicit := nonEmptyAuthors(optionAuthors); len(explicit) > 0 {
+// resolveRecordAuthors returns authors with precedence: WithAuthors >
+// sourceAuthors > the WithProvenance defaults > defaultAuthor.
+func resolveRecordAuthors(sourceAuthors []string, options *translatorOptions) []string {
+	if explicit := nonEmptyAuthors(options.authors); len(explicit) > 0 {
 		return explicit
 	}
 
@@ -92,7 +198,7 @@ func resolveRecordAuthors(sourceAuthors, optionAuthors []string) []string {
 		return explicit
 	}
 
-	return []string{defaultAuthor}
+	return options.provenance.defaultAuthors()
 }
 
 // resolveRecordVersion returns version with precedence: WithRecordVersion > sourceVersion > defaultVersion.
@@ -108,24 +214,6 @@ func resolveRecordVersion(sourceVersion, optionVersion string) string {
 	return defaultVersion
 }
 
-// buildArtifactDescriptor creates an OCI-like artifact descriptor struct from raw bytes.
-// The payload is base64-encoded into the `data` field; `size` and `digest` are derived
-// from the raw bytes so they remain verifiable independently of any serialization choice.
-func buildArtifactDescriptor(payload []byte, mediaType string) *structpb.Struct {
-	encoded := base64.StdEncoding.EncodeToString(payload)
-	sum := sha256.Sum256(payload)
-	digest := fmt.Sprintf("sha256:%x", sum)
-
-	return &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"media_type": {Kind: &structpb.Value_StringValue{StringValue: mediaType}},
-			"size":       {Kind: &structpb.Value_NumberValue{NumberValue: float64(len(payload))}},
-			"digest":     {Kind: &structpb.Value_StringValue{StringValue: digest}},
-			"data":       {Kind: &structpb.Value_StringValue{StringValue: encoded}},
-		},
-	}
-}
-
 // artifactDataBytes returns the raw bytes stored in module.artifact.data (base64-decoded).
 // Returns nil if the artifact field is absent, empty, or the base64 encoding is invalid.
 func artifactDataBytes(moduleStruct *structpb.Struct) []byte {
@@ -172,7 +260,7 @@ func validateMajorVersion(versionStr string) error {
 
 	major := version.Major()
 	if major != OASFMajorVersion {
-		return fmt.Errorf("unsupported schema version: %s (major version %d not supported, only version 1.x.x is supported for record generation)", versionStr, major)
+		return fmt.Errorf("%w: %s (major version %d not supported, only version 1.x.x is supported for record generation)", decoder.ErrUnsupportedSchemaVersion, versionStr, major)
 	}
 
 	return nil
@@ -4,8 +4,13 @@
 package translator_test
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
 	"github.com/agntcy/oasf-sdk/pkg/translator"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -80,6 +85,86 @@ func TestWithVersion_DefaultUsedWhenNotSet(t *testing.T) {
 
 // --- normalizeServerName (tested indirectly via RecordToGHCopilot) ---
 
+// --- WithSchemaClient ---
+
+type stubSchemaClient string
+
+func (c stubSchemaClient) GetRecordJSONSchema(context.Context, ...schema.SchemaOption) ([]byte, error) {
+	return []byte(c), nil
+}
+
+func TestWithSchemaClient_FillsDefaults(t *testing.T) {
+	client := stubSchemaClient(`{"required": ["locators"], "properties": {"locators": {"type": "array"}, "license": {"default": "Apache-2.0"}}}`)
+
+	record, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithSchemaClient(client))
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	fields := record.GetFields()
+	if fields["locators"].GetListValue() == nil || fields["license"].GetStringValue() != "Apache-2.0" {
+		t.Errorf("schema defaults not filled: %v", record)
+	}
+}
+
+// --- WithValidation ---
+
+type stubValidator struct {
+	errs []messages.Message
+	err  error
+}
+
+func (v stubValidator) ValidateRecordMessages(context.Context, *structpb.Struct) (bool, []messages.Message, []messages.Message, error) {
+	return len(v.errs) == 0, v.errs, nil, v.err
+}
+
+func TestWithValidation_ValidRecord(t *testing.T) {
+	if _, err := translator.MCPToRecord(minimalMCPInput(t, nil), translator.WithVersion(schemaVersion100), translator.WithValidation(nil)); err != nil {
+		t.Errorf("MCPToRecord: %v", err)
+	}
+
+	if _, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithValidation(stubValidator{})); err != nil {
+		t.Errorf("A2AToRecord: %v", err)
+	}
+}
+
+func TestWithValidation_InvalidRecord(t *testing.T) {
+	v := stubValidator{errs: []messages.Message{{Text: "missing required field locators"}}}
+
+	_, err := translator.MCPToRecord(minimalMCPInput(t, nil), translator.WithValidation(v))
+	if !errors.Is(err, translator.ErrInvalidRecord) || !strings.Contains(err.Error(), "missing required field locators") {
+		t.Errorf("MCPToRecord() error = %v, want ErrInvalidRecord with the validation errors", err)
+	}
+}
+
+func TestWithValidation_ValidatorError(t *testing.T) {
+	_, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithValidation(stubValidator{err: schema.ErrSchemaUnavailable}))
+	if !errors.Is(err, schema.ErrSchemaUnavailable) || errors.Is(err, translator.ErrInvalidRecord) {
+		t.Errorf("A2AToRecord() error = %v, want the validator error", err)
+	}
+}
+
+// ctxValidator fails with the error of the context it is called with.
+type ctxValidator struct{}
+
+func (ctxValidator) ValidateRecordMessages(ctx context.Context, _ *structpb.Struct) (bool, []messages.Message, []messages.Message, error) {
+	return ctx.Err() == nil, nil, nil, ctx.Err()
+}
+
+func TestWithContext_ReachesValidator(t *testing.T) {
+	if _, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithValidation(ctxValidator{})); err != nil {
+		t.Errorf("A2AToRecord() without context: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := translator.MCPToRecord(minimalMCPInput(t, nil), translator.WithContext(ctx), translator.WithValidation(ctxValidator{}))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("MCPToRecord() error = %v, want context.Canceled", err)
+	}
+}
+
 func TestNormalizeServerName_MCPSuffix(t *testing.T) {
 	// Build a record whose MCP module has a server named "github-mcp-server"
 	// and verify RecordToGHCopilot normalises the key to "github".
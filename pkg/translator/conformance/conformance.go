@@ -0,0 +1,193 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package conformance runs the translators against embedded canonical
+// fixtures and compares their outputs with the expected ones, so operators
+// can check that a deployed version still produces the outputs its clients
+// rely on, e.g. after an upgrade.
+package conformance
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fixtures holds the inputs and the expected outputs of the cases.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// Case translates an input fixture to a target.
+type Case struct {
+	// Name identifies the case and its expected output,
+	// fixtures/expected/<name>.json.
+	Name string
+	// Target is the translation target, e.g. "gh-copilot".
+	Target string
+	// Input is the input fixture, in fixtures/inputs.
+	Input string
+	// Translate returns the output compared to the expected one.
+	Translate func(input *structpb.Struct) (any, error)
+}
+
+// Cases are the conformance cases, one or more per translation target.
+var Cases = []Case{
+	{"gh-copilot_1.0.0", "gh-copilot", "translation_1.0.0_record.json", ghCopilot},
+	{"gh-copilot_0.8.0", "gh-copilot", "translation_0.8.0_record.json", ghCopilot},
+	{"a2a_1.0.0", "a2a", "translation_1.0.0_record.json", a2aCard},
+	{"a2a_0.8.0", "a2a", "translation_0.8.0_record.json", a2aCard},
+	{"langchain_1.0.0", "langchain", "translation_1.0.0_record.json", langChain},
+	{"skill-markdown", "skill-markdown", "translation_agentskills_record.json", skillMarkdown},
+	{"mcp-to-record", "mcp-to-record", "translation_mcp.json", imported(translator.MCPToRecord)},
+	{"a2a-to-record", "a2a-to-record", "translation_a2a.json", imported(translator.A2AToRecord)},
+	{"skill-to-record", "skill-to-record", "translation_skill.json", imported(translator.SkillMarkdownToRecord)},
+}
+
+// Result is the outcome of a case.
+type Result struct {
+	Case   string `json:"case"`
+	Target string `json:"target"`
+	Passed bool   `json:"passed"`
+	// Error says why the case failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the outcome of Run.
+type Report struct {
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+// Run runs the cases, by default Cases, and reports which pass. A case fails
+// when its translation fails or its output differs from the expected one.
+func Run(ctx context.Context, cases ...Case) *Report {
+	if len(cases) == 0 {
+		cases = Cases
+	}
+
+	report := &Report{Passed: true, Results: make([]Result, 0, len(cases))}
+
+	for _, c := range cases {
+		result := Result{Case: c.Name, Target: c.Target, Passed: true}
+
+		if err := ctx.Err(); err != nil {
+			result.Passed, result.Error = false, err.Error()
+		} else if err := c.check(); err != nil {
+			result.Passed, result.Error = false, err.Error()
+		}
+
+		report.Passed = report.Passed && result.Passed
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// Output returns the output of the case, normalized to its JSON form.
+func (c Case) Output() (any, error) {
+	data, err := fixtures.ReadFile(path.Join("fixtures", "inputs", c.Input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input %s: %w", c.Input, err)
+	}
+
+	input := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, input); err != nil {
+		return nil, fmt.Errorf("failed to parse input %s: %w", c.Input, err)
+	}
+
+	out, err := c.Translate(input)
+	if err != nil {
+		return nil, fmt.Errorf("translation failed: %w", err)
+	}
+
+	return normalize(out)
+}
+
+func (c Case) check() error {
+	got, err := c.Output()
+	if err != nil {
+		return err
+	}
+
+	data, err := fixtures.ReadFile(path.Join("fixtures", "expected", c.Name+".json"))
+	if err != nil {
+		return fmt.Errorf("no expected output: %w", err)
+	}
+
+	var want any
+	if err := json.Unmarshal(data, &want); err != nil {
+		return fmt.Errorf("invalid expected output: %w", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		return fmt.Errorf("output differs from the expected output %s.json", c.Name)
+	}
+
+	return nil
+}
+
+// normalize round trips a value through JSON, so typed outputs compare
+// with the decoded expected outputs.
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return out, nil
+}
+
+func ghCopilot(input *structpb.Struct) (any, error) {
+	return translator.RecordToGHCopilot(input) //nolint:wrapcheck
+}
+
+func a2aCard(input *structpb.Struct) (any, error) {
+	card, err := translator.RecordToA2A(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return card.AsMap(), nil
+}
+
+func langChain(input *structpb.Struct) (any, error) {
+	return translator.RecordToLangChain(input) //nolint:wrapcheck
+}
+
+func skillMarkdown(input *structpb.Struct) (any, error) {
+	markdown, err := translator.RecordToSkillMarkdown(input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return map[string]any{"skillMarkdown": markdown}, nil
+}
+
+// imported wraps an importer, dropping the volatile created_at of its
+// records.
+func imported(importer func(*structpb.Struct, ...translator.TranslatorOption) (*structpb.Struct, error)) func(*structpb.Struct) (any, error) {
+	return func(input *structpb.Struct) (any, error) {
+		record, err := importer(input)
+		if err != nil {
+			return nil, err
+		}
+
+		out := record.AsMap()
+		delete(out, "created_at")
+
+		return out, nil
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package conformance_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator/conformance"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var update = flag.Bool("update", false, "rewrite the expected outputs in fixtures/expected")
+
+func TestRun(t *testing.T) {
+	if *update {
+		for _, c := range conformance.Cases {
+			out, err := c.Output()
+			if err != nil {
+				t.Fatalf("%s: %v", c.Name, err)
+			}
+
+			data, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(filepath.Join("fixtures", "expected", c.Name+".json"), append(data, '\n'), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	report := conformance.Run(context.Background())
+	if !report.Passed || len(report.Results) != len(conformance.Cases) {
+		t.Errorf("conformance failed (run go test -update if the change is intended): %+v", report.Results)
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	failing := conformance.Case{
+		Name:   "gh-copilot_1.0.0",
+		Target: "gh-copilot",
+		Input:  "translation_1.0.0_record.json",
+		Translate: func(*structpb.Struct) (any, error) {
+			return map[string]any{"servers": map[string]any{}}, nil
+		},
+	}
+	broken := failing
+	broken.Translate = func(*structpb.Struct) (any, error) { return nil, errors.New("boom") }
+
+	report := conformance.Run(context.Background(), failing, broken)
+	if report.Passed || report.Results[0].Passed || report.Results[1].Passed || report.Results[1].Error == "" {
+		t.Errorf("expected both cases to fail, got %+v", report)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if report := conformance.Run(ctx); report.Passed {
+		t.Error("expected a canceled run to fail")
+	}
+}
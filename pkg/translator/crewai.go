@@ -0,0 +1,161 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// crewAIKeyInvalid matches the runs of characters not allowed in the keys of
+// CrewAI agents, which name the agent methods of the crew.
+var crewAIKeyInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+// CrewAIAgent is an agent of a CrewAI agents.yaml.
+type CrewAIAgent struct {
+	Role      string `json:"role"      yaml:"role"`
+	Goal      string `json:"goal"      yaml:"goal"`
+	Backstory string `json:"backstory" yaml:"backstory"`
+	// Tools are the names of the tools of the agent, to be bound to tool
+	// instances in the crew.
+	Tools []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+}
+
+// CrewAIAgents is a CrewAI agents.yaml: the agents by key.
+type CrewAIAgents map[string]CrewAIAgent
+
+// YAML renders the agents as an agents.yaml document.
+func (a CrewAIAgents) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2) //nolint:mnd
+
+	if err := encoder.Encode(map[string]CrewAIAgent(a)); err != nil {
+		return nil, fmt.Errorf("failed to encode agents: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode agents: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RecordToCrewAI translates a record into a CrewAI agents.yaml with a single
+// agent, keyed by the snake_case name of the record. Supports OASF versions
+// 0.7.0, 0.8.0, and 1.0.0.
+//
+// The role of the agent is the name of the record in title case, and its
+// goal the record description. Its backstory lists its skills: those of the
+// A2A card when the record has one, otherwise the last segment of the OASF
+// skill names. Its tools are the tools of the MCP module.
+func RecordToCrewAI(record *structpb.Struct) (CrewAIAgents, error) {
+	name := recordStringField(record, "name")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	key := strings.Trim(crewAIKeyInvalid.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if key == "" {
+		return nil, fmt.Errorf("%w: record has no name", ErrInvalidInput)
+	}
+
+	role := titleCase(strings.ReplaceAll(key, "_", " "))
+
+	agent := CrewAIAgent{
+		Role:  role,
+		Goal:  firstNonEmptyString(recordStringField(record, "description"), "Act as "+role+"."),
+		Tools: mcpToolNames(record),
+	}
+
+	backstory := []string{"You are " + role + "."}
+
+	if skills := crewAISkills(record); len(skills) > 0 {
+		backstory = append(backstory, "You are skilled in "+strings.Join(skills, ", ")+".")
+	}
+
+	if authors := recordAuthors(record); authors != "" {
+		backstory = append(backstory, "You are maintained by "+authors+".")
+	}
+
+	agent.Backstory = strings.Join(backstory, " ")
+
+	return CrewAIAgents{key: agent}, nil
+}
+
+// crewAISkills returns the skills of the record in words: the names of the
+// skills of its A2A card, or else the last segment of its OASF skills.
+func crewAISkills(record *structpb.Struct) []string {
+	var skills []string
+
+	if card, err := storedA2ACard(record); err == nil {
+		for _, skill := range card.GetFields()["skills"].GetListValue().GetValues() {
+			fields := skill.GetStructValue().GetFields()
+			if name := firstNonEmptyString(fields["name"].GetStringValue(), fields["id"].GetStringValue()); name != "" {
+				skills = append(skills, name)
+			}
+		}
+	}
+
+	if len(skills) > 0 {
+		return skills
+	}
+
+	for _, name := range taxonomyNames(record, "skills") {
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+
+		skills = append(skills, strings.ReplaceAll(name, "_", " "))
+	}
+
+	return skills
+}
+
+// mcpToolNames returns the names of the tools of the MCP module of the
+// record.
+func mcpToolNames(record *structpb.Struct) []string {
+	_, module := recordutil.FindModule(record, MCPModuleName)
+
+	var names []string
+
+	for _, tool := range module.GetFields()["data"].GetStructValue().GetFields()["tools"].GetListValue().GetValues() {
+		if name := tool.GetStructValue().GetFields()["name"].GetStringValue(); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// recordAuthors returns the authors of the record, joined with commas. The
+// placeholder author of generated records is left out.
+func recordAuthors(record *structpb.Struct) string {
+	var authors []string
+
+	for _, author := range record.GetFields()["authors"].GetListValue().GetValues() {
+		if s := author.GetStringValue(); s != "" && s != defaultAuthor {
+			authors = append(authors, s)
+		}
+	}
+
+	return strings.Join(authors, ", ")
+}
+
+// titleCase upper-cases the first letter of every word of s.
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToCrewAI(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"name":           "agntcy/github-mcp-server",
+		"description":    "Manage GitHub repositories, issues and pull requests.",
+		"authors":        []any{"GitHub"},
+		"schema_version": "1.0.0",
+		"skills": []any{
+			map[string]any{"name": "devops_mlops/continuous_integration_and_deployment"},
+			map[string]any{"name": "natural_language_processing/text_summarization"},
+		},
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name":  "github-mcp-server",
+					"tools": []any{map[string]any{"name": "create_issue"}, map[string]any{"name": "list_pull_requests"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	agents, err := translator.RecordToCrewAI(record)
+	if err != nil {
+		t.Fatalf("RecordToCrewAI() error: %v", err)
+	}
+
+	agent, ok := agents["github_mcp_server"]
+	if !ok {
+		t.Fatalf("agents = %v, want github_mcp_server", agents)
+	}
+
+	if agent.Role != "Github Mcp Server" || agent.Goal != "Manage GitHub repositories, issues and pull requests." {
+		t.Errorf("agent = %+v", agent)
+	}
+
+	want := "You are Github Mcp Server. You are skilled in continuous integration and deployment, text summarization. " +
+		"You are maintained by GitHub."
+
+	if agent.Backstory != want {
+		t.Errorf("backstory = %q, want %q", agent.Backstory, want)
+	}
+
+	if !slices.Equal(agent.Tools, []string{"create_issue", "list_pull_requests"}) {
+		t.Errorf("tools = %v, want the MCP tools", agent.Tools)
+	}
+
+	out, err := agents.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error: %v", err)
+	}
+
+	if !strings.HasPrefix(string(out), "github_mcp_server:\n  role: Github Mcp Server\n") {
+		t.Errorf("YAML() = %s", out)
+	}
+}
+
+func TestRecordToCrewAIFromA2ACard(t *testing.T) {
+	agents, err := translator.RecordToCrewAI(a2aRecord(t, map[string]any{
+		"name":   "Travel Agent",
+		"skills": []any{map[string]any{"id": "flights", "name": "Flight search"}, map[string]any{"id": "hotels"}},
+	}))
+	if err != nil {
+		t.Fatalf("RecordToCrewAI() error: %v", err)
+	}
+
+	agent := agents["travel_agent"]
+	if agent.Backstory != "You are Travel Agent. You are skilled in Flight search, hotels." || agent.Tools != nil {
+		t.Errorf("agent = %+v, want the card skills and no author or tools", agent)
+	}
+
+	if _, err := translator.RecordToCrewAI(&structpb.Struct{}); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("error = %v, want ErrInvalidInput without a name", err)
+	}
+}
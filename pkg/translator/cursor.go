@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"regexp"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// CursorServer is a server of a Cursor MCP configuration: a stdio server
+// with a command, or a remote (SSE or streamable HTTP) server with a URL.
+type CursorServer struct {
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// CursorConfig is a Cursor .cursor/mcp.json: the MCP servers of the project,
+// by name.
+type CursorConfig struct {
+	MCPServers map[string]CursorServer `json:"mcpServers"`
+}
+
+// cursorPlaceholder matches the "${input:NAME}" references of GitHub Copilot
+// configurations and the "${NAME}" placeholders of records.
+var cursorPlaceholder = regexp.MustCompile(`\$\{(?:input:)?([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RecordToCursor translates the MCP module of a record into a Cursor MCP
+// configuration. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// Cursor has no inputs but interpolates environment variables, so secrets
+// are read from "${env:NAME}" references. A 1.0.0 server with remote
+// connections only is configured with the URL and headers of its first SSE
+// or streamable HTTP connection.
+func RecordToCursor(record *structpb.Struct, opts ...ClientConfigOption) (*CursorConfig, error) {
+	options, err := newClientConfigOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	copilot, err := RecordToGHCopilot(record, WithGHCopilotPlatform(options.platform), WithGHCopilotPythonRunner(options.pythonRunner))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &CursorConfig{MCPServers: make(map[string]CursorServer, len(copilot.Servers))}
+
+	for name, server := range copilot.Servers {
+		args := make([]string, len(server.Args))
+		for i, arg := range server.Args {
+			args[i] = cursorEnvReference(arg)
+		}
+
+		config.MCPServers[name] = CursorServer{
+			Command: server.Command,
+			Args:    args,
+			Env:     cursorEnv(server.Env),
+		}
+	}
+
+	if len(config.MCPServers) == 0 {
+		connection, ok := firstRemoteConnection(record)
+		if !ok {
+			return nil, fmt.Errorf("%w: no supported MCP connections in record", ErrInvalidInput)
+		}
+
+		config.MCPServers[connection.name] = CursorServer{URL: cursorEnvReference(connection.url), Headers: cursorEnv(connection.headers)}
+	}
+
+	return config, nil
+}
+
+// cursorEnv rewrites the placeholders of environment or header values into
+// Cursor "${env:NAME}" references.
+func cursorEnv(values map[string]string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(values))
+	for name, value := range values {
+		out[name] = cursorEnvReference(value)
+	}
+
+	return out
+}
+
+func cursorEnvReference(value string) string {
+	return cursorPlaceholder.ReplaceAllString(value, "$${env:$1}")
+}
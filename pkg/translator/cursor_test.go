@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToCursor(t *testing.T) {
+	config, err := translator.RecordToCursor(ghCopilotRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToCursor() error: %v", err)
+	}
+
+	server := config.MCPServers["github"]
+	if server.Command != "docker" || len(server.Args) != 3 || server.URL != "" {
+		t.Errorf("server = %+v", server)
+	}
+
+	if server.Env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "${env:GITHUB_PERSONAL_ACCESS_TOKEN}" || server.Env["LOG_LEVEL"] != "info" {
+		t.Errorf("env = %v, want env references for secrets and literals kept", server.Env)
+	}
+}
+
+func TestRecordToCursorLegacyModule(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "0.7.0",
+		"modules": []any{
+			map[string]any{
+				"name": "runtime/mcp",
+				"data": map[string]any{
+					"servers": []any{
+						map[string]any{
+							"name":    "filesystem",
+							"command": "npx",
+							"args":    []any{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToCursor(record)
+	if err != nil {
+		t.Fatalf("RecordToCursor() error: %v", err)
+	}
+
+	if server, ok := config.MCPServers["filesystem"]; !ok || server.Command != "npx" || server.Env != nil {
+		t.Errorf("servers = %+v, want the filesystem server", config.MCPServers)
+	}
+}
+
+func TestRecordToCursorRemote(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "weather-mcp-server",
+					"connections": []any{
+						map[string]any{
+							"type":    "sse",
+							"url":     "https://weather.example.org/sse",
+							"headers": map[string]any{"Authorization": "Bearer ${WEATHER_TOKEN}"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToCursor(record)
+	if err != nil {
+		t.Fatalf("RecordToCursor() error: %v", err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+
+	want := `{"mcpServers":{"weather":{"url":"https://weather.example.org/sse","headers":{"Authorization":"Bearer ${env:WEATHER_TOKEN}"}}}}`
+	if string(data) != want {
+		t.Errorf("config = %s, want %s", data, want)
+	}
+}
+
+func TestRecordToCursorNoMCPModule(t *testing.T) {
+	record, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0", "modules": []any{}})
+
+	if _, err := translator.RecordToCursor(record); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("RecordToCursor() error = %v, want ErrModuleNotFound", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"slices"
+	"strings"
+)
+
+// dockerValueOptions are the docker run options taking a value as the next
+// argument, e.g. -v /data:/data. The others are flags, such as -i and --rm.
+var dockerValueOptions = []string{
+	"-e", "--env", "--env-file",
+	"-v", "--volume", "--mount", "--tmpfs",
+	"-p", "--publish", "--network", "--net", "--add-host",
+	"-w", "--workdir", "-u", "--user", "--entrypoint",
+	"--name", "-h", "--hostname", "-l", "--label",
+	"-m", "--memory", "--cpus", "--platform", "--pull",
+	"--device", "--cap-add", "--cap-drop", "--security-opt", "--ulimit",
+	"--restart", "--log-driver", "--log-opt",
+}
+
+// dockerRun is a docker (or podman) run command line of a stdio connection.
+type dockerRun struct {
+	// env are the names of the variables passed from the environment of
+	// docker to the container (-e NAME); variables given a value on the
+	// command line (-e NAME=value) are not listed.
+	env []string
+}
+
+// isContainerRuntime reports whether the command runs containers with the
+// docker command line.
+func isContainerRuntime(command string) bool {
+	return command == "docker" || command == "podman"
+}
+
+// parseDockerRun parses the arguments of a docker run command. It reports
+// false when the command is not docker or podman run.
+func parseDockerRun(command string, args []string) (dockerRun, bool) {
+	if !isContainerRuntime(command) || len(args) == 0 || args[0] != "run" {
+		return dockerRun{}, false
+	}
+
+	var run dockerRun
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			// The image; the arguments after it are those of the container.
+			break
+		}
+
+		option, value, hasValue := strings.Cut(arg, "=")
+		if strings.HasPrefix(arg, "-e") && len(arg) > len("-e") && arg[len("-e")] != '=' {
+			// -eNAME
+			option, value, hasValue = "-e", arg[len("-e"):], true
+		}
+
+		if !slices.Contains(dockerValueOptions, option) {
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(args) {
+				break
+			}
+
+			i++
+			value = args[i]
+		}
+
+		if (option == "-e" || option == "--env") && !strings.Contains(value, "=") {
+			run.env = append(run.env, value)
+		}
+	}
+
+	return run, true
+}
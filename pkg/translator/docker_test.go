@@ -0,0 +1,60 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+)
+
+func TestRecordToGHCopilotDockerEnv(t *testing.T) {
+	record := stdioRecord(t, "docker", "run", "-i", "--rm", "-e", "GITHUB_TOKEN", "--env=LOG_LEVEL=debug", "-eGITHUB_HOST",
+		"-v", "/data:/data", "ghcr.io/github/github-mcp-server", "-e", "NOT_DOCKER")
+
+	config, err := translator.RecordToGHCopilot(record)
+	if err != nil {
+		t.Fatalf("RecordToGHCopilot() error: %v", err)
+	}
+
+	wantEnv := map[string]string{"GITHUB_TOKEN": "${input:GITHUB_TOKEN}", "GITHUB_HOST": "${input:GITHUB_HOST}"}
+	if env := config.Servers["files"].Env; !reflect.DeepEqual(env, wantEnv) {
+		t.Errorf("env = %v, want the variables passed to the container", env)
+	}
+
+	if len(config.Inputs) != 2 || !config.Inputs[0].Password {
+		t.Errorf("inputs = %+v, want a secret input for each variable", config.Inputs)
+	}
+}
+
+func TestMCPToRecordDockerRoundTrip(t *testing.T) {
+	record, err := translator.MCPToRecord(minimalMCPInput(t, map[string]any{"packages": []any{
+		map[string]any{
+			"registryType":         "oci",
+			"identifier":           "ghcr.io/github/github-mcp-server",
+			"transport":            map[string]any{"type": "stdio"},
+			"environmentVariables": []any{map[string]any{"name": "GITHUB_PERSONAL_ACCESS_TOKEN", "isSecret": true}},
+		},
+	}}))
+	if err != nil {
+		t.Fatalf("MCPToRecord() error: %v", err)
+	}
+
+	config, err := translator.RecordToClaudeDesktop(record)
+	if err != nil {
+		t.Fatalf("RecordToClaudeDesktop() error: %v", err)
+	}
+
+	if len(config.MCPServers) != 1 {
+		t.Fatalf("servers = %v, want one", config.MCPServers)
+	}
+
+	for _, server := range config.MCPServers {
+		wantArgs := []string{"run", "-i", "--rm", "-e", "GITHUB_PERSONAL_ACCESS_TOKEN", "ghcr.io/github/github-mcp-server"}
+		if !reflect.DeepEqual(server.Args, wantArgs) || server.Env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "${GITHUB_PERSONAL_ACCESS_TOKEN}" {
+			t.Errorf("server = %+v, want the token passed to the container", server)
+		}
+	}
+}
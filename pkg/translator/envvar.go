@@ -0,0 +1,106 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Input types of GitHub Copilot configurations.
+const (
+	inputTypePrompt = "promptString"
+	inputTypePick   = "pickString"
+)
+
+// envVar is an env_var of a 1.0.0 MCP connection with its type hints. The
+// hints are optional: records without them are handled as before, with
+// every variable without a default treated as a secret.
+type envVar struct {
+	name         string
+	defaultValue string
+	// secret is nil when the record does not say whether the variable is a
+	// secret.
+	secret *bool
+	// choices are the values the variable may take, if restricted.
+	choices []string
+}
+
+// parseEnvVar reads an env_var object: name, default_value and the optional
+// secret and choices hints.
+func parseEnvVar(envVarMap *structpb.Struct) envVar {
+	fields := envVarMap.GetFields()
+
+	v := envVar{
+		name:         fields["name"].GetStringValue(),
+		defaultValue: fields["default_value"].GetStringValue(),
+	}
+
+	if secret, ok := fields["secret"].GetKind().(*structpb.Value_BoolValue); ok {
+		v.secret = &secret.BoolValue
+	}
+
+	for _, choice := range fields["choices"].GetListValue().GetValues() {
+		if s := choice.GetStringValue(); s != "" {
+			v.choices = append(v.choices, s)
+		}
+	}
+
+	return v
+}
+
+// isSecret reports whether the variable is a secret; variables without the
+// hint are.
+func (v envVar) isSecret() bool {
+	return v.secret == nil || *v.secret
+}
+
+// input returns the GitHub Copilot input prompting for the variable: a
+// pickString of its choices, or a promptString, masked for secrets.
+func (v envVar) input() MCPInput {
+	if len(v.choices) > 0 {
+		return MCPInput{
+			ID:          v.name,
+			Type:        inputTypePick,
+			Description: "Value for " + v.name,
+			Options:     v.choices,
+			Default:     v.defaultValue,
+		}
+	}
+
+	if !v.isSecret() {
+		return MCPInput{ID: v.name, Type: inputTypePrompt, Description: "Value for " + v.name}
+	}
+
+	return MCPInput{ID: v.name, Type: inputTypePrompt, Password: true, Description: "Secret value for " + v.name}
+}
+
+// envVarHints returns the type hint fields of the env_var of an MCP
+// registry environment variable: secret and required when the entry states
+// them, and its choices.
+func envVarHints(envMap map[string]any) map[string]*structpb.Value {
+	hints := map[string]*structpb.Value{}
+
+	if secret, ok := envMap["isSecret"].(bool); ok {
+		hints["secret"] = structpb.NewBoolValue(secret)
+	}
+
+	if required, ok := envMap["isRequired"].(bool); ok {
+		hints["required"] = structpb.NewBoolValue(required)
+	}
+
+	if choices, ok := envMap["choices"].([]any); ok {
+		values := make([]*structpb.Value, 0, len(choices))
+		for _, choice := range choices {
+			if s, ok := choice.(string); ok && s != "" {
+				values = append(values, structpb.NewStringValue(s))
+			}
+		}
+
+		if len(values) > 0 {
+			hints["choices"] = structpb.NewListValue(&structpb.ListValue{Values: values})
+		}
+	}
+
+	return hints
+}
@@ -0,0 +1,22 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import "errors"
+
+// Errors returned (wrapped, with details) by the translators. Callers can use
+// errors.Is to branch on them instead of matching error strings. Records
+// with an unsupported schema version fail with
+// decoder.ErrUnsupportedSchemaVersion.
+var (
+	// ErrModuleNotFound is returned when a record lacks the module a
+	// translator reads.
+	ErrModuleNotFound = errors.New("module not found in record")
+	// ErrInvalidInput is returned for malformed or incomplete input, either a
+	// third-party document or the module data of a record.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInvalidRecord is returned by translators given WithValidation when
+	// the record they generate fails the checks.
+	ErrInvalidRecord = errors.New("generated record is invalid")
+)
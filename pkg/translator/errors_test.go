@@ -0,0 +1,59 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestTranslatorErrors(t *testing.T) {
+	noModules, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0", "modules": []any{}})
+	badMCP, _ := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{"name": translator.MCPModuleName, "data": map[string]any{"unexpected": true}},
+		},
+	})
+	noServer, _ := structpb.NewStruct(map[string]any{"name": "x"})
+	badSkill, _ := structpb.NewStruct(map[string]any{"skillMarkdown": "no frontmatter"})
+
+	tests := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"RecordToA2A", func() error { _, err := translator.RecordToA2A(noModules); return err }, translator.ErrModuleNotFound},
+		{"RecordToGHCopilot", func() error { _, err := translator.RecordToGHCopilot(noModules); return err }, translator.ErrModuleNotFound},
+		{"RecordToLangChain", func() error { _, err := translator.RecordToLangChain(noModules); return err }, translator.ErrModuleNotFound},
+		{"RecordToSkillMarkdown", func() error { _, err := translator.RecordToSkillMarkdown(noModules); return err }, translator.ErrModuleNotFound},
+		{"RecordToSkillBundle", func() error { _, err := translator.RecordToSkillBundle(noModules); return err }, translator.ErrModuleNotFound},
+		{"RecordToCatalog", func() error {
+			_, err := translator.RecordToCatalog(noModules, translator.WithCatalogCID("cid"))
+
+			return err
+		}, translator.ErrModuleNotFound},
+		{"RecordToGHCopilot invalid data", func() error { _, err := translator.RecordToGHCopilot(badMCP); return err }, translator.ErrInvalidInput},
+		{"MCPToRecord", func() error { _, err := translator.MCPToRecord(noServer); return err }, translator.ErrInvalidInput},
+		{"SkillMarkdownToRecord", func() error { _, err := translator.SkillMarkdownToRecord(badSkill); return err }, translator.ErrInvalidInput},
+		{"A2AToRecord unsupported version", func() error {
+			card, _ := structpb.NewStruct(map[string]any{"name": "agent"})
+			_, err := translator.A2AToRecord(card, translator.WithVersion("2.0.0"))
+
+			return err
+		}, decoder.ErrUnsupportedSchemaVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want an error wrapping %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The fuzz targets feed malformed third-party documents to the importers.
+// Errors are fine; panics are not, and every record an importer accepts must
+// decode into the typed OASF model and translate back without error.
+//
+// Run one with e.g. go test -run '^$' -fuzz FuzzA2AToRecord -fuzztime 30s.
+
+// addFixtureSeeds seeds f with the e2e fixtures and a few malformed inputs.
+func addFixtureSeeds(f *testing.F, fixtures ...string) {
+	f.Helper()
+
+	for _, fixture := range fixtures {
+		data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", fixture))
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(data)
+	}
+
+	for _, seed := range []string{`{}`, `{"name": ""}`, `{"name": 1, "skills": {}}`, `{"packages": [null], "remotes": "x"}`} {
+		f.Add([]byte(seed))
+	}
+}
+
+// fuzzStruct parses a fuzz input, reporting false for non-objects.
+func fuzzStruct(data []byte) (*structpb.Struct, bool) {
+	s := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, s); err != nil {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// checkRecordInvariants asserts that an imported record decodes into the
+// typed model and that the input was not modified by the import.
+func checkRecordInvariants(t *testing.T, in, before proto.Message, record *structpb.Struct) {
+	t.Helper()
+
+	if _, err := decoder.DecodeRecord(record); err != nil {
+		t.Fatalf("imported record does not decode: %v\nrecord: %v", err, record)
+	}
+
+	if !proto.Equal(in, before) {
+		t.Fatalf("import modified its input:\nbefore: %v\nafter: %v", before, in)
+	}
+}
+
+func FuzzA2AToRecord(f *testing.F) {
+	addFixtureSeeds(f, "translation_a2a.json", "expected_a2a_output.json")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		card, ok := fuzzStruct(data)
+		if !ok {
+			return
+		}
+
+		before := proto.Clone(card)
+
+		record, err := translator.A2AToRecord(card)
+		if err != nil {
+			return
+		}
+
+		checkRecordInvariants(t, card, before, record)
+
+		exported, err := translator.RecordToA2A(record)
+		if err != nil {
+			t.Fatalf("RecordToA2A of an imported card: %v", err)
+		}
+
+		// A card that went through a record once comes back unchanged.
+		reimported, err := translator.A2AToRecord(exported)
+		if err != nil {
+			t.Fatalf("A2AToRecord of an exported card: %v", err)
+		}
+
+		again, err := translator.RecordToA2A(reimported)
+		if err != nil {
+			t.Fatalf("RecordToA2A of a reimported card: %v", err)
+		}
+
+		if !proto.Equal(exported, again) {
+			t.Fatalf("A2A round trip is not stable:\nfirst: %v\nsecond: %v", exported, again)
+		}
+	})
+}
+
+func FuzzMCPToRecord(f *testing.F) {
+	addFixtureSeeds(f,
+		"translation_mcp.json",
+		"translation_mcp_http_headers.json",
+		"translation_mcp_minimal_local.json",
+		"translation_mcp_sse_minimal.json",
+	)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		server, ok := fuzzStruct(data)
+		if !ok {
+			return
+		}
+
+		before := proto.Clone(server)
+
+		record, err := translator.MCPToRecord(server)
+		if err != nil {
+			return
+		}
+
+		checkRecordInvariants(t, server, before, record)
+
+		if _, err := translator.RecordToGHCopilot(record); err != nil {
+			t.Fatalf("RecordToGHCopilot of an imported server: %v", err)
+		}
+
+		if _, err := translator.RecordToLangChain(record); err != nil {
+			t.Fatalf("RecordToLangChain of an imported server: %v", err)
+		}
+	})
+}
+
+func FuzzSkillMarkdownToRecord(f *testing.F) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", "translation_skill.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	skill, _ := fuzzStruct(data)
+	f.Add(skill.GetFields()["skillMarkdown"].GetStringValue())
+
+	for _, seed := range []string{"", "---\n---\n", "---\nname: x\n", "---\nname: a\ndescription: b\nmetadata:\n  k: v\n---\nbody"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, markdown string) {
+		// Skills arrive in JSON or protobuf messages, which only carry
+		// valid UTF-8.
+		if !utf8.ValidString(markdown) {
+			return
+		}
+
+		in := &structpb.Struct{Fields: map[string]*structpb.Value{"skillMarkdown": structpb.NewStringValue(markdown)}}
+		before := proto.Clone(in)
+
+		record, err := translator.SkillMarkdownToRecord(in)
+		if err != nil {
+			return
+		}
+
+		checkRecordInvariants(t, in, before, record)
+
+		if _, err := translator.RecordToSkillMarkdown(record); err != nil {
+			t.Fatalf("RecordToSkillMarkdown of an imported skill: %v", err)
+		}
+	})
+}
+
+func FuzzRecordExporters(f *testing.F) {
+	addFixtureSeeds(f,
+		"translation_0.7.0_record.json",
+		"translation_0.8.0_record.json",
+		"translation_1.0.0_record.json",
+		"translation_dir_mcp_record.json",
+		"translation_agentskills_record.json",
+		"translation_catalog_record.json",
+	)
+
+	// Exporters read records written by anyone; they may reject them but
+	// must not panic or modify them.
+	f.Fuzz(func(t *testing.T, data []byte) {
+		record, ok := fuzzStruct(data)
+		if !ok {
+			return
+		}
+
+		before := proto.Clone(record)
+
+		_, _ = translator.RecordToA2A(record)
+		_, _ = translator.RecordToGHCopilot(record)
+		_, _ = translator.RecordToLangChain(record)
+		_, _ = translator.RecordToSkillMarkdown(record)
+		_, _ = translator.RecordToCatalog(record, translator.WithCatalogCID(testCID))
+
+		if !proto.Equal(record, before) {
+			t.Fatalf("exporters modified the record:\nbefore: %v\nafter: %v", before, record)
+		}
+	})
+}
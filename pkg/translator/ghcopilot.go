@@ -0,0 +1,175 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// GHCopilotFlavor selects the configuration format RecordToGHCopilot renders.
+// The formats differ in their envelope and in how they provide secrets.
+type GHCopilotFlavor string
+
+const (
+	// GHCopilotWorkspace is a VS Code workspace .vscode/mcp.json:
+	// {"servers": {...}, "inputs": [...]}. It is the default.
+	GHCopilotWorkspace GHCopilotFlavor = "workspace"
+	// GHCopilotUser is a fragment of the VS Code user settings.json, the
+	// workspace configuration under an "mcp" key.
+	GHCopilotUser GHCopilotFlavor = "user"
+	// GHCopilotCodingAgent is the MCP configuration of the Copilot coding
+	// agent in the settings of a GitHub.com repository or organization:
+	// {"mcpServers": {...}}. It has no inputs; secrets are read from
+	// COPILOT_MCP_* secrets of the Copilot environment instead.
+	GHCopilotCodingAgent GHCopilotFlavor = "coding-agent"
+)
+
+// codingAgentSecretPrefix is the prefix the Copilot coding agent requires of
+// the secrets it passes to MCP servers.
+const codingAgentSecretPrefix = "COPILOT_MCP_"
+
+// GHCopilotOption configures RecordToGHCopilot.
+type GHCopilotOption func(*ghCopilotOptions)
+
+type ghCopilotOptions struct {
+	flavor       GHCopilotFlavor
+	secrets      *SecretStore
+	platform     Platform
+	pythonRunner PythonRunner
+}
+
+// WithGHCopilotFlavor selects the configuration format; see GHCopilotFlavor.
+func WithGHCopilotFlavor(flavor GHCopilotFlavor) GHCopilotOption {
+	return func(o *ghCopilotOptions) {
+		o.flavor = flavor
+	}
+}
+
+// WithGHCopilotSecrets references the secrets of the servers in a secrets
+// manager instead of prompting for them: ${input:<id>} values become
+// references to the secret <id> of the store, and the config has no inputs.
+// Kubernetes stores are not supported, as Copilot cannot read them.
+func WithGHCopilotSecrets(store SecretStore) GHCopilotOption {
+	return func(o *ghCopilotOptions) {
+		o.secrets = &store
+	}
+}
+
+// WithGHCopilotPlatform generates the commands of stdio servers for the
+// platform of the workstation, e.g. npx.cmd on PlatformWindows. The default
+// is PlatformPOSIX.
+func WithGHCopilotPlatform(platform Platform) GHCopilotOption {
+	return func(o *ghCopilotOptions) {
+		o.platform = platform
+	}
+}
+
+// referenceSecrets replaces the secret inputs of the config with references
+// to the secrets of the store. Inputs that are not secrets, e.g. the
+// pickString of a variable with choices, are still prompted for.
+func (c *GHCopilotMCPConfig) referenceSecrets(store SecretStore) error {
+	// Input references without an input are secrets too.
+	plain := map[string]bool{}
+
+	for _, input := range c.Inputs {
+		if !input.Password {
+			plain[input.ID] = true
+		}
+	}
+
+	isSecret := func(id string) bool { return !plain[id] }
+
+	for name, server := range c.Servers {
+		for i, arg := range server.Args {
+			ref, err := referenceSecrets(arg, store, isSecret)
+			if err != nil {
+				return err
+			}
+
+			server.Args[i] = ref
+		}
+
+		for key, value := range server.Env {
+			ref, err := referenceSecrets(value, store, isSecret)
+			if err != nil {
+				return err
+			}
+
+			server.Env[key] = ref
+		}
+
+		c.Servers[name] = server
+	}
+
+	c.Inputs = slices.DeleteFunc(c.Inputs, func(input MCPInput) bool { return isSecret(input.ID) })
+
+	return nil
+}
+
+// codingAgentServer is a server of the Copilot coding agent configuration.
+type codingAgentServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env,omitempty"`
+	Tools   []string          `json:"tools"`
+}
+
+// MarshalJSON renders the configuration in its Flavor.
+func (c GHCopilotMCPConfig) MarshalJSON() ([]byte, error) {
+	// workspace has the fields of the config without its methods.
+	type workspace GHCopilotMCPConfig
+
+	switch c.Flavor {
+	case "", GHCopilotWorkspace:
+		return json.Marshal(workspace(c)) //nolint:wrapcheck
+	case GHCopilotUser:
+		return json.Marshal(map[string]workspace{"mcp": workspace(c)}) //nolint:wrapcheck
+	case GHCopilotCodingAgent:
+		servers := make(map[string]codingAgentServer, len(c.Servers))
+		for name, server := range c.Servers {
+			servers[name] = codingAgentServer{
+				Type:    "local",
+				Command: server.Command,
+				Args:    server.Args,
+				Env:     codingAgentEnv(server.Env),
+				Tools:   []string{"*"},
+			}
+		}
+
+		return json.Marshal(map[string]any{"mcpServers": servers}) //nolint:wrapcheck
+	default:
+		return nil, fmt.Errorf("%w: unsupported GitHub Copilot flavor %q", ErrInvalidInput, c.Flavor)
+	}
+}
+
+// codingAgentEnv replaces the input references of the environment with the
+// names of the COPILOT_MCP_* secrets the coding agent provides them from.
+func codingAgentEnv(env map[string]string) map[string]string {
+	out := maps.Clone(env)
+
+	for name, value := range out {
+		if after, ok := strings.CutPrefix(value, "${input:"); ok {
+			out[name] = codingAgentSecret(strings.TrimSuffix(after, "}"))
+		}
+	}
+
+	return out
+}
+
+// codingAgentSecret returns the name of the coding agent secret of an input,
+// e.g. COPILOT_MCP_GITHUB_TOKEN for github_token.
+func codingAgentSecret(input string) string {
+	name := envVarName(input)
+
+	if strings.HasPrefix(name, codingAgentSecretPrefix) {
+		return name
+	}
+
+	return codingAgentSecretPrefix + name
+}
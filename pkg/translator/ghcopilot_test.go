@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func ghCopilotRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "github-mcp-server",
+					"connections": []any{
+						map[string]any{
+							"type":     "stdio",
+							"command":  "docker",
+							"args":     []any{"run", "-i", "ghcr.io/github/github-mcp-server"},
+							"env_vars": []any{map[string]any{"name": "GITHUB_PERSONAL_ACCESS_TOKEN"}, map[string]any{"name": "LOG_LEVEL", "default_value": "info"}},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	return record
+}
+
+func marshalGHCopilot(t *testing.T, flavor translator.GHCopilotFlavor) map[string]any {
+	t.Helper()
+
+	config, err := translator.RecordToGHCopilot(ghCopilotRecord(t), translator.WithGHCopilotFlavor(flavor))
+	if err != nil {
+		t.Fatalf("RecordToGHCopilot(%s) error: %v", flavor, err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal %s config: %v", flavor, err)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to unmarshal %s config: %v", flavor, err)
+	}
+
+	return out
+}
+
+func TestRecordToGHCopilotFlavors(t *testing.T) {
+	workspace := marshalGHCopilot(t, translator.GHCopilotWorkspace)
+	if _, ok := workspace["servers"]; !ok || workspace["inputs"] == nil {
+		t.Errorf("workspace config = %v, want servers and inputs", workspace)
+	}
+
+	user := marshalGHCopilot(t, translator.GHCopilotUser)
+	if mcp, ok := user["mcp"].(map[string]any); !ok || mcp["servers"] == nil || mcp["inputs"] == nil {
+		t.Errorf("user config = %v, want servers and inputs under mcp", user)
+	}
+
+	agent := marshalGHCopilot(t, translator.GHCopilotCodingAgent)
+
+	server, ok := agent["mcpServers"].(map[string]any)["github"].(map[string]any)
+	if !ok {
+		t.Fatalf("coding agent config = %v, want the github server under mcpServers", agent)
+	}
+
+	if server["type"] != "local" || server["command"] != "docker" {
+		t.Errorf("coding agent server = %v", server)
+	}
+
+	env := server["env"].(map[string]any)
+	if env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "COPILOT_MCP_GITHUB_PERSONAL_ACCESS_TOKEN" || env["LOG_LEVEL"] != "info" {
+		t.Errorf("coding agent env = %v, want secrets as COPILOT_MCP_* names and literals kept", env)
+	}
+
+	if _, ok := agent["inputs"]; ok {
+		t.Error("coding agent config must not have inputs")
+	}
+}
+
+func TestRecordToGHCopilotUnknownFlavor(t *testing.T) {
+	_, err := translator.RecordToGHCopilot(ghCopilotRecord(t), translator.WithGHCopilotFlavor("jetbrains"))
+	if !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("RecordToGHCopilot() error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Annotations set on records bootstrapped from GitHub repositories.
+const (
+	GitHubRepositoryAnnotation = "github.repository"
+	GitHubTopicsAnnotation     = "github.topics"
+	GitHubLicenseAnnotation    = "github.license"
+	GitHubReleaseAnnotation    = "github.release"
+)
+
+// GitHubContainerRegistry is the registry of the container image locator
+// added for repositories with a Dockerfile.
+const GitHubContainerRegistry = "ghcr.io"
+
+// containerFiles are the root file names that mark a repository as building
+// a container image.
+var containerFiles = []string{"Dockerfile", "Containerfile"}
+
+// GitHubRepoToRecord bootstraps a draft record from GitHub repository
+// metadata:
+//
+//	{
+//	  "repository": {...},  // GitHub REST API repository object
+//	  "releases": [...],    // release objects, newest first (optional)
+//	  "files": [...]        // root file names, or contents API objects (optional)
+//	}
+//
+// A bare repository object is accepted too. The record is named
+// "github.com/<owner>/<repo>" and gets the repository description, its owner
+// as author and the tag of the latest stable release as version. The
+// repository URL becomes a source_code locator and, when the root has a
+// Dockerfile or Containerfile, the GitHub Container Registry image of the same
+// name a container_image locator. Topics, license, repository and release are
+// kept in "github.*" annotations. Skills and domains are left empty for the
+// author to fill in.
+func GitHubRepoToRecord(data *structpb.Struct, opts ...TranslatorOption) (*structpb.Struct, error) { //nolint:cyclop
+	repo := data.GetFields()["repository"].GetStructValue()
+	if repo == nil {
+		repo = data
+	}
+
+	repoFields := repo.GetFields()
+
+	fullName := repoFields["full_name"].GetStringValue()
+	if fullName == "" {
+		return nil, fmt.Errorf("%w: missing 'full_name' in repository data", ErrInvalidInput)
+	}
+
+	options := &translatorOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	targetVersion := DefaultSchemaVersion
+
+	if options.version != "" {
+		if err := validateMajorVersion(options.version); err != nil {
+			return nil, err
+		}
+
+		targetVersion = options.version
+	}
+
+	release := latestRelease(data.GetFields()["releases"].GetListValue())
+
+	var owners []string
+	if owner := repoFields["owner"].GetStructValue().GetFields()["login"].GetStringValue(); owner != "" {
+		owners = append(owners, owner)
+	}
+
+	builder := recordutil.NewBuilder().
+		Name("github.com/" + fullName).
+		SchemaVersion(targetVersion).
+		Version(resolveRecordVersion(release, options.recordVersion)).
+		Description(repoFields["description"].GetStringValue()).
+		Authors(resolveRecordAuthors(owners, options)...)
+
+	if htmlURL := repoFields["html_url"].GetStringValue(); htmlURL != "" {
+		builder.AddLocator(string(recordutil.LocatorSourceCode), htmlURL)
+	}
+
+	if hasContainerFile(data.GetFields()["files"].GetListValue()) {
+		builder.AddLocator(string(recordutil.LocatorContainerImage), (&url.URL{
+			Scheme: "https",
+			Host:   GitHubContainerRegistry,
+			Path:   "/" + strings.ToLower(fullName),
+		}).String())
+	}
+
+	record, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
+
+	githubAnnotations := map[string]string{GitHubRepositoryAnnotation: fullName}
+
+	if topics := stringValues(repoFields["topics"].GetListValue()); len(topics) > 0 {
+		githubAnnotations[GitHubTopicsAnnotation] = strings.Join(topics, ",")
+	}
+
+	if license := repoFields["license"].GetStructValue().GetFields()["spdx_id"].GetStringValue(); license != "" && license != "NOASSERTION" {
+		githubAnnotations[GitHubLicenseAnnotation] = license
+	}
+
+	if release != "" {
+		githubAnnotations[GitHubReleaseAnnotation] = release
+	}
+
+	if err := annotations.SetAll(record, githubAnnotations); err != nil {
+		return nil, fmt.Errorf("failed to annotate record: %w", err)
+	}
+
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// latestRelease returns the tag of the first release that is neither a draft
+// nor a prerelease.
+func latestRelease(releases *structpb.ListValue) string {
+	for _, release := range releases.GetValues() {
+		fields := release.GetStructValue().GetFields()
+		if fields["draft"].GetBoolValue() || fields["prerelease"].GetBoolValue() {
+			continue
+		}
+
+		if tag := fields["tag_name"].GetStringValue(); tag != "" {
+			return tag
+		}
+	}
+
+	return ""
+}
+
+func hasContainerFile(files *structpb.ListValue) bool {
+	for _, file := range files.GetValues() {
+		name := file.GetStringValue()
+		if name == "" {
+			name = file.GetStructValue().GetFields()["name"].GetStringValue()
+		}
+
+		if slices.Contains(containerFiles, path.Base(name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringValues(list *structpb.ListValue) []string {
+	var out []string
+
+	for _, v := range list.GetValues() {
+		if s := v.GetStringValue(); s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
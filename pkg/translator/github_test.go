@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func githubPayload(t *testing.T, files ...any) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(map[string]any{
+		"repository": map[string]any{
+			"full_name":   "Example/Burger-Agent",
+			"description": "Orders burgers",
+			"html_url":    "https://github.com/Example/Burger-Agent",
+			"owner":       map[string]any{"login": "Example"},
+			"topics":      []any{"a2a", "agents"},
+			"license":     map[string]any{"spdx_id": "Apache-2.0"},
+		},
+		"releases": []any{
+			map[string]any{"tag_name": "v2.0.0-rc1", "prerelease": true},
+			map[string]any{"tag_name": "v1.2.0"},
+		},
+		"files": files,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestGitHubRepoToRecord(t *testing.T) {
+	rec, err := translator.GitHubRepoToRecord(githubPayload(t, "README.md", map[string]any{"name": "Dockerfile", "type": "file"}))
+	if err != nil {
+		t.Fatalf("GitHubRepoToRecord: %v", err)
+	}
+
+	fields := rec.GetFields()
+	if fields["name"].GetStringValue() != "github.com/Example/Burger-Agent" || fields["version"].GetStringValue() != "v1.2.0" ||
+		fields["description"].GetStringValue() != "Orders burgers" || fields["authors"].GetListValue().GetValues()[0].GetStringValue() != "Example" {
+		t.Errorf("unexpected record metadata: %v", rec)
+	}
+
+	if got := record.LocatorURLs(rec, record.LocatorSourceCode); len(got) != 1 || got[0] != "https://github.com/Example/Burger-Agent" {
+		t.Errorf("unexpected source_code locators %v", got)
+	}
+
+	if got := record.LocatorURLs(rec, record.LocatorContainerImage); len(got) != 1 || got[0] != "https://ghcr.io/example/burger-agent" {
+		t.Errorf("unexpected container_image locators %v", got)
+	}
+
+	want := map[string]string{
+		translator.GitHubRepositoryAnnotation: "Example/Burger-Agent",
+		translator.GitHubTopicsAnnotation:     "a2a,agents",
+		translator.GitHubLicenseAnnotation:    "Apache-2.0",
+		translator.GitHubReleaseAnnotation:    "v1.2.0",
+	}
+	for key, value := range want {
+		if got, _ := annotations.Get(rec, key); got != value {
+			t.Errorf("annotation %s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestGitHubRepoToRecord_NoDockerfileOrRelease(t *testing.T) {
+	payload := githubPayload(t, "main.go")
+	delete(payload.GetFields(), "releases")
+
+	rec, err := translator.GitHubRepoToRecord(payload.GetFields()["repository"].GetStructValue(), translator.WithRecordVersion("v0.1.0"))
+	if err != nil {
+		t.Fatalf("GitHubRepoToRecord: %v", err)
+	}
+
+	if rec.GetFields()["version"].GetStringValue() != "v0.1.0" || len(record.LocatorURLs(rec, record.LocatorContainerImage)) != 0 {
+		t.Errorf("unexpected record: %v", rec)
+	}
+
+	if _, err := translator.GitHubRepoToRecord(&structpb.Struct{}); err == nil {
+		t.Error("expected an error without full_name")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenCases translate the e2e fixtures; their outputs are pinned in
+// testdata/golden so refactorings can prove they keep the output identical.
+var goldenCases = []struct {
+	name      string
+	fixture   string
+	translate func(*structpb.Struct) (any, error)
+}{
+	{"mcp_to_record", "translation_mcp.json", mcpToRecord},
+	{"mcp_to_record_http_headers", "translation_mcp_http_headers.json", mcpToRecord},
+	{"mcp_to_record_minimal_local", "translation_mcp_minimal_local.json", mcpToRecord},
+	{"mcp_to_record_sse_minimal", "translation_mcp_sse_minimal.json", mcpToRecord},
+	{"a2a_to_record", "translation_a2a.json", func(in *structpb.Struct) (any, error) {
+		return recordOutput(translator.A2AToRecord(in))
+	}},
+	{"skill_to_record", "translation_skill.json", func(in *structpb.Struct) (any, error) {
+		return recordOutput(translator.SkillMarkdownToRecord(in))
+	}},
+	{"gh_copilot_0.7.0", "translation_0.7.0_record.json", ghCopilot},
+	{"gh_copilot_0.8.0", "translation_0.8.0_record.json", ghCopilot},
+	{"gh_copilot_1.0.0", "translation_1.0.0_record.json", ghCopilot},
+	{"gh_copilot_dir_mcp", "translation_dir_mcp_record.json", ghCopilot},
+	{"record_to_a2a_0.8.0", "translation_0.8.0_record.json", recordToA2A},
+	{"record_to_a2a_1.0.0", "translation_1.0.0_record.json", recordToA2A},
+	{"record_to_catalog", "translation_catalog_record.json", func(in *structpb.Struct) (any, error) {
+		catalog, err := translator.RecordToCatalog(in, translator.WithCatalogCID(testCID))
+		if err != nil {
+			return nil, err
+		}
+
+		return catalog.AsMap(), nil
+	}},
+	{"record_to_skill", "translation_agentskills_record.json", func(in *structpb.Struct) (any, error) {
+		markdown, err := translator.RecordToSkillMarkdown(in)
+
+		return map[string]any{"skillMarkdown": markdown}, err
+	}},
+}
+
+func mcpToRecord(in *structpb.Struct) (any, error) {
+	return recordOutput(translator.MCPToRecord(in))
+}
+
+func ghCopilot(in *structpb.Struct) (any, error) {
+	return translator.RecordToGHCopilot(in)
+}
+
+func recordToA2A(in *structpb.Struct) (any, error) {
+	card, err := translator.RecordToA2A(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return card.AsMap(), nil
+}
+
+// recordOutput drops the volatile created_at of a generated record.
+func recordOutput(record *structpb.Struct, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	out := record.AsMap()
+	delete(out, "created_at")
+
+	return out, nil
+}
+
+func TestGolden(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", tc.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			in := &structpb.Struct{}
+			if err := protojson.Unmarshal(data, in); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := tc.translate(in)
+			if err != nil {
+				t.Fatalf("translate: %v", err)
+			}
+
+			got, err := json.MarshalIndent(out, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got = append(got, '\n')
+			path := filepath.Join("testdata", "golden", tc.name+".json")
+
+			if *update {
+				if err := os.WriteFile(path, got, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				return
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v (run go test -run TestGolden -update to create it)", err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("output differs from %s (run go test -run TestGolden -update if intended):\n%s", path, got)
+			}
+		})
+	}
+}
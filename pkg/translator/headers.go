@@ -0,0 +1,76 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"regexp"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+)
+
+// registryPlaceholder matches the "{name}" placeholders MCP registry
+// entries use in header values, and the character before them, so that
+// "${NAME}" placeholders are not matched.
+var registryPlaceholder = regexp.MustCompile(`(^|[^$])\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// authSchemes are the Authorization schemes kept in front of the
+// placeholder replacing a credential.
+var authSchemes = []string{"Bearer ", "Basic ", "Token "}
+
+// secretHeaders returns the headers of a remote connection with their
+// secrets as "${NAME}" placeholders, for the targets to turn into inputs or
+// secret references instead of copying them into configurations: "{name}"
+// registry placeholders become "${NAME}", and the literal values of secret
+// headers (see record.IsSecret) become a placeholder named after the header,
+// e.g. "Bearer ${AUTHORIZATION}".
+func secretHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(headers))
+	for name, value := range headers {
+		out[name] = secretHeader(name, value)
+	}
+
+	return out
+}
+
+func secretHeader(name, value string) string {
+	value = registryPlaceholder.ReplaceAllStringFunc(value, func(match string) string {
+		groups := registryPlaceholder.FindStringSubmatch(match)
+
+		return groups[1] + "${" + envVarName(groups[2]) + "}"
+	})
+
+	if value == "" || vsCodePlaceholder.MatchString(value) || !recordutil.IsSecret(name, value) {
+		return value
+	}
+
+	placeholder := "${" + envVarName(name) + "}"
+
+	for _, scheme := range authSchemes {
+		if len(value) > len(scheme) && strings.EqualFold(value[:len(scheme)], scheme) {
+			return value[:len(scheme)] + placeholder
+		}
+	}
+
+	return placeholder
+}
+
+// envVarName returns the environment variable name of an identifier, e.g.
+// X_API_KEY for X-API-Key.
+func envVarName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, id)
+}
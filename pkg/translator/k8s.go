@@ -0,0 +1,347 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// defaultK8sPort is the container port of manifests when neither the record
+// nor WithK8sPort sets one.
+const defaultK8sPort = 8080
+
+// maxK8sNameLength is the maximum length of a Kubernetes DNS label.
+const maxK8sNameLength = 63
+
+// k8sNameInvalid matches the runs of characters not allowed in Kubernetes
+// DNS labels.
+var k8sNameInvalid = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// K8sOption configures RecordToK8sManifests.
+type K8sOption func(*k8sOptions)
+
+type k8sOptions struct {
+	namespace string
+	port      int
+	replicas  int
+	secrets   *SecretStore
+}
+
+// WithK8sNamespace sets the namespace of the objects. Without it they have
+// none and go to the namespace they are applied to.
+func WithK8sNamespace(namespace string) K8sOption {
+	return func(o *k8sOptions) {
+		o.namespace = namespace
+	}
+}
+
+// WithK8sPort sets the port the container listens on, overriding the port of
+// the A2A interface or MCP remote connection URL of the record.
+func WithK8sPort(port int) K8sOption {
+	return func(o *k8sOptions) {
+		o.port = port
+	}
+}
+
+// WithK8sReplicas sets the replicas of the Deployment; the default is 1.
+func WithK8sReplicas(replicas int) K8sOption {
+	return func(o *k8sOptions) {
+		o.replicas = replicas
+	}
+}
+
+// WithK8sSecrets sets the Secret the secret environment variables are read
+// from. The store must be a SecretStoreKubernetes one; by default the Secret
+// is named after the record.
+func WithK8sSecrets(store SecretStore) K8sOption {
+	return func(o *k8sOptions) {
+		o.secrets = &store
+	}
+}
+
+// K8sManifests are the Kubernetes objects deploying a record on a cluster
+// without an operator, as plain maps ready to be marshaled.
+type K8sManifests struct {
+	Deployment map[string]any
+	Service    map[string]any
+	// ConfigMap holds the environment variables that are not secrets. It is
+	// nil when there are none.
+	ConfigMap map[string]any
+}
+
+// Objects returns the objects in the order they are applied: the ConfigMap,
+// if any, the Deployment and the Service.
+func (m *K8sManifests) Objects() []map[string]any {
+	var objects []map[string]any
+
+	if m.ConfigMap != nil {
+		objects = append(objects, m.ConfigMap)
+	}
+
+	return append(objects, m.Deployment, m.Service)
+}
+
+// YAML renders the objects as a multi-document YAML stream for kubectl
+// apply -f.
+func (m *K8sManifests) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2) //nolint:mnd
+
+	for _, object := range m.Objects() {
+		if err := encoder.Encode(object); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", object["kind"], err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifests: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RecordToK8sManifests translates a record into a Deployment and a Service,
+// plus a ConfigMap for its plain environment variables. Supports OASF
+// versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// The container runs the first container image locator of the record and
+// listens on the port of the first interface of its A2A card, or of its
+// first MCP remote connection, or 8080. The environment variables are those
+// of the MCP servers: values and the defaults of variables with choices go to
+// the ConfigMap, other variables without a default are left empty in it
+// unless they are secrets, which are read from a Secret with secretKeyRef.
+func RecordToK8sManifests(record *structpb.Struct, opts ...K8sOption) (*K8sManifests, error) {
+	options := &k8sOptions{replicas: 1}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	name := k8sName(record.GetFields()["name"].GetStringValue())
+	if name == "" {
+		return nil, fmt.Errorf("%w: record has no name", ErrInvalidInput)
+	}
+
+	image := containerImage(record)
+	if image == "" {
+		return nil, fmt.Errorf("%w: record has no container image locator", ErrInvalidInput)
+	}
+
+	secrets := SecretStore{Kind: SecretStoreKubernetes, Path: name}
+	if options.secrets != nil {
+		secrets = *options.secrets
+	}
+
+	if secrets.Kind != SecretStoreKubernetes {
+		return nil, fmt.Errorf("%w: Kubernetes manifests can only reference Kubernetes secrets, not %s", ErrInvalidInput, secrets.Kind)
+	}
+
+	if err := secrets.validate(); err != nil {
+		return nil, err
+	}
+
+	port := options.port
+	if port == 0 {
+		port = recordPort(record)
+	}
+
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("%w: invalid port %d", ErrInvalidInput, port)
+	}
+
+	plain, secret, err := k8sEnv(record)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]any{"app.kubernetes.io/name": name}
+
+	container := map[string]any{
+		"name":  name,
+		"image": image,
+		"ports": []any{map[string]any{"name": "http", "containerPort": port}},
+	}
+
+	manifests := &K8sManifests{}
+
+	if len(plain) > 0 {
+		manifests.ConfigMap = map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   k8sMetadata(name, options.namespace, labels),
+			"data":       plain,
+		}
+		container["envFrom"] = []any{map[string]any{"configMapRef": map[string]any{"name": name}}}
+	}
+
+	if len(secret) > 0 {
+		env := make([]any, 0, len(secret))
+		for _, variable := range secret {
+			env = append(env, map[string]any{"name": variable, "valueFrom": secrets.SecretKeyRef(variable)})
+		}
+
+		container["env"] = env
+	}
+
+	manifests.Deployment = map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   k8sMetadata(name, options.namespace, labels),
+		"spec": map[string]any{
+			"replicas": options.replicas,
+			"selector": map[string]any{"matchLabels": labels},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": labels},
+				"spec":     map[string]any{"containers": []any{container}},
+			},
+		},
+	}
+
+	manifests.Service = map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   k8sMetadata(name, options.namespace, labels),
+		"spec": map[string]any{
+			"selector": labels,
+			"ports":    []any{map[string]any{"name": "http", "port": port, "targetPort": "http"}},
+		},
+	}
+
+	return manifests, nil
+}
+
+// k8sMetadata returns the metadata of an object of the record.
+func k8sMetadata(name, namespace string, labels map[string]any) map[string]any {
+	metadata := map[string]any{"name": name, "labels": labels}
+	if namespace != "" {
+		metadata["namespace"] = namespace
+	}
+
+	return metadata
+}
+
+// k8sName turns a record name into a DNS label, e.g. "agntcy/Weather Agent"
+// into "weather-agent".
+func k8sName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	name = k8sNameInvalid.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > maxK8sNameLength {
+		name = name[:maxK8sNameLength]
+	}
+
+	return strings.Trim(name, "-")
+}
+
+// containerImage returns the reference of the first container image locator
+// of the record, without the URL scheme of the locator.
+func containerImage(record *structpb.Struct) string {
+	for _, u := range recordutil.LocatorURLs(record, recordutil.LocatorContainerImage) {
+		if _, ref, ok := strings.Cut(u, "://"); ok {
+			return ref
+		}
+
+		return u
+	}
+
+	return ""
+}
+
+// recordPort returns the port of the first interface of the A2A card of the
+// record, or else of its first MCP remote connection, or defaultK8sPort.
+func recordPort(record *structpb.Struct) int {
+	var urls []string
+
+	if interfaces, err := RecordA2AInterfaces(record); err == nil && len(interfaces) > 0 {
+		urls = append(urls, interfaces[0].URL)
+	}
+
+	if connection, ok := firstRemoteConnection(record); ok {
+		urls = append(urls, connection.url)
+	}
+
+	for _, rawURL := range urls {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+
+		if port, err := strconv.Atoi(u.Port()); err == nil {
+			return port
+		}
+	}
+
+	return defaultK8sPort
+}
+
+// k8sEnv returns the environment variables of the MCP servers of the record
+// split into the values of the plain ones and the names of the secrets, in
+// name order. Variables whose value references a secret input are secrets;
+// the other inputs take their default, or are left empty.
+func k8sEnv(record *structpb.Struct) (map[string]any, []string, error) {
+	if !recordutil.HasModule(record, MCPModuleName) {
+		return nil, nil, nil
+	}
+
+	copilot, err := RecordToGHCopilot(record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputs := make(map[string]MCPInput, len(copilot.Inputs))
+	for _, input := range copilot.Inputs {
+		inputs[input.ID] = input
+	}
+
+	plain := map[string]any{}
+
+	var secret []string
+
+	for _, serverName := range slices.Sorted(maps.Keys(copilot.Servers)) {
+		for name, value := range copilot.Servers[serverName].Env {
+			if _, ok := plain[name]; ok || slices.Contains(secret, name) {
+				continue
+			}
+
+			refs := inputReference.FindAllStringSubmatch(value, -1)
+			if len(refs) == 0 {
+				plain[name] = value
+
+				continue
+			}
+
+			if slices.ContainsFunc(refs, func(ref []string) bool {
+				input, ok := inputs[ref[1]]
+
+				return !ok || input.Password
+			}) {
+				secret = append(secret, name)
+
+				continue
+			}
+
+			plain[name] = inputReference.ReplaceAllStringFunc(value, func(match string) string {
+				return inputs[inputReference.FindStringSubmatch(match)[1]].Default
+			})
+		}
+	}
+
+	slices.Sort(secret)
+
+	return plain, secret, nil
+}
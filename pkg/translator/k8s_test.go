@@ -0,0 +1,181 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func k8sRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(map[string]any{
+		"name":           "agntcy/GitHub Agent",
+		"schema_version": "1.0.0",
+		"locators": []any{
+			map[string]any{"type": "source_code", "urls": []any{"https://github.com/agntcy/github-agent"}},
+			map[string]any{"type": "container_image", "urls": []any{"docker://ghcr.io/agntcy/github-agent:1.0.0"}},
+		},
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "github-mcp-server",
+					"connections": []any{
+						map[string]any{
+							"type":    "stdio",
+							"command": "docker",
+							"args":    []any{"run", "-i", "ghcr.io/github/github-mcp-server"},
+							"env_vars": []any{
+								map[string]any{"name": "GITHUB_PERSONAL_ACCESS_TOKEN"},
+								map[string]any{"name": "LOG_LEVEL", "default_value": "info"},
+								map[string]any{"name": "TOOLSETS", "choices": []any{"repos", "issues"}, "default_value": "repos"},
+								map[string]any{"name": "GITHUB_HOST", "secret": false},
+							},
+						},
+					},
+				},
+			},
+			map[string]any{
+				"name": translator.A2AModuleName,
+				"data": map[string]any{"card_data": map[string]any{"name": "GitHub Agent", "url": "http://localhost:9000/"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	return record
+}
+
+func TestRecordToK8sManifests(t *testing.T) {
+	manifests, err := translator.RecordToK8sManifests(k8sRecord(t), translator.WithK8sNamespace("agents"))
+	if err != nil {
+		t.Fatalf("RecordToK8sManifests() error: %v", err)
+	}
+
+	wantData := map[string]any{"LOG_LEVEL": "info", "TOOLSETS": "repos", "GITHUB_HOST": ""}
+	if data := manifests.ConfigMap["data"].(map[string]any); !maps.Equal(data, wantData) {
+		t.Errorf("ConfigMap data = %v, want %v", data, wantData)
+	}
+
+	spec := manifests.Deployment["spec"].(map[string]any)
+	container := spec["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+
+	if container["name"] != "github-agent" || container["image"] != "ghcr.io/agntcy/github-agent:1.0.0" {
+		t.Errorf("container = %v, want the image of the record named after it", container)
+	}
+
+	wantEnv := []any{map[string]any{
+		"name":      "GITHUB_PERSONAL_ACCESS_TOKEN",
+		"valueFrom": map[string]any{"secretKeyRef": map[string]any{"name": "github-agent", "key": "GITHUB_PERSONAL_ACCESS_TOKEN"}},
+	}}
+	if !reflect.DeepEqual(container["env"], wantEnv) {
+		t.Errorf("env = %v, want %v", container["env"], wantEnv)
+	}
+
+	if port := container["ports"].([]any)[0].(map[string]any)["containerPort"]; port != 9000 {
+		t.Errorf("containerPort = %v, want the port of the A2A card URL", port)
+	}
+
+	if metadata := manifests.Service["metadata"].(map[string]any); metadata["namespace"] != "agents" || metadata["name"] != "github-agent" {
+		t.Errorf("Service metadata = %v", metadata)
+	}
+
+	out, err := manifests.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error: %v", err)
+	}
+
+	for _, want := range []string{"kind: ConfigMap\n", "---\napiVersion: apps/v1\nkind: Deployment\n", "kind: Service\n", "targetPort: http\n"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("YAML() = %s, want %q", out, want)
+		}
+	}
+}
+
+func TestRecordToK8sManifestsOptions(t *testing.T) {
+	store := translator.SecretStore{Kind: translator.SecretStoreKubernetes, Path: "github-credentials"}
+
+	manifests, err := translator.RecordToK8sManifests(k8sRecord(t),
+		translator.WithK8sPort(3000), translator.WithK8sReplicas(3), translator.WithK8sSecrets(store))
+	if err != nil {
+		t.Fatalf("RecordToK8sManifests() error: %v", err)
+	}
+
+	spec := manifests.Deployment["spec"].(map[string]any)
+	if spec["replicas"] != 3 {
+		t.Errorf("replicas = %v, want 3", spec["replicas"])
+	}
+
+	if _, ok := manifests.Deployment["metadata"].(map[string]any)["namespace"]; ok {
+		t.Errorf("metadata = %v, want no namespace", manifests.Deployment["metadata"])
+	}
+
+	if port := manifests.Service["spec"].(map[string]any)["ports"].([]any)[0].(map[string]any)["port"]; port != 3000 {
+		t.Errorf("port = %v, want 3000", port)
+	}
+
+	container := spec["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	ref := container["env"].([]any)[0].(map[string]any)["valueFrom"].(map[string]any)["secretKeyRef"].(map[string]any)
+
+	if ref["name"] != "github-credentials" {
+		t.Errorf("secretKeyRef = %v, want the Secret of the store", ref)
+	}
+}
+
+func TestRecordToK8sManifestsWithoutEnv(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"name":           "weather-agent",
+		"schema_version": "0.7.0",
+		"locators":       []any{map[string]any{"type": "docker_image", "url": "https://ghcr.io/agntcy/weather-agent"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	manifests, err := translator.RecordToK8sManifests(record)
+	if err != nil {
+		t.Fatalf("RecordToK8sManifests() error: %v", err)
+	}
+
+	if manifests.ConfigMap != nil || len(manifests.Objects()) != 2 {
+		t.Errorf("objects = %v, want no ConfigMap", manifests.Objects())
+	}
+
+	container := manifests.Deployment["spec"].(map[string]any)["template"].(map[string]any)["spec"].(map[string]any)["containers"].([]any)[0].(map[string]any)
+	if container["image"] != "ghcr.io/agntcy/weather-agent" || container["ports"].([]any)[0].(map[string]any)["containerPort"] != 8080 {
+		t.Errorf("container = %v, want the legacy image locator on the default port", container)
+	}
+}
+
+func TestRecordToK8sManifestsErrors(t *testing.T) {
+	noImage, err := structpb.NewStruct(map[string]any{"name": "weather-agent", "schema_version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	tests := map[string]struct {
+		record *structpb.Struct
+		opts   []translator.K8sOption
+	}{
+		"no image":     {record: noImage},
+		"vault store":  {record: k8sRecord(t), opts: []translator.K8sOption{translator.WithK8sSecrets(translator.SecretStore{Kind: translator.SecretStoreVault, Path: "secret/agents"})}},
+		"invalid port": {record: k8sRecord(t), opts: []translator.K8sOption{translator.WithK8sPort(70000)}},
+	}
+
+	for name, tt := range tests {
+		if _, err := translator.RecordToK8sManifests(tt.record, tt.opts...); !errors.Is(err, translator.ErrInvalidInput) {
+			t.Errorf("%s: error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"strings"
+	"unicode"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// KeyStyle is the naming style of object keys.
+type KeyStyle int
+
+const (
+	// KeyStyleCamelCase names keys like "protocolVersion".
+	KeyStyleCamelCase KeyStyle = iota
+	// KeyStyleSnakeCase names keys like "protocol_version".
+	KeyStyleSnakeCase
+)
+
+// NormalizeOption configures NormalizeKeys.
+type NormalizeOption func(*normalizeOptions)
+
+type normalizeOptions struct {
+	verbatimKeys map[string]bool
+	opaque       map[string]bool
+}
+
+// WithVerbatimKeys names fields holding maps whose keys are data rather than
+// field names (e.g. security scheme names); those keys are kept as they are
+// while the values below them are still normalized. Maps inside lists held by
+// the fields are treated the same. Field names are given in the target style.
+func WithVerbatimKeys(fields ...string) NormalizeOption {
+	return func(opts *normalizeOptions) {
+		for _, field := range fields {
+			opts.verbatimKeys[field] = true
+		}
+	}
+}
+
+// WithOpaqueFields names free-form fields (e.g. "metadata") that are copied
+// unchanged. Field names are given in the target style.
+func WithOpaqueFields(fields ...string) NormalizeOption {
+	return func(opts *normalizeOptions) {
+		for _, field := range fields {
+			opts.opaque[field] = true
+		}
+	}
+}
+
+// a2aKeyOptions keeps security scheme names, the OAuth2 scopes of their
+// flows and free-form extension data of A2A cards intact.
+var a2aKeyOptions = []NormalizeOption{
+	WithVerbatimKeys("securitySchemes", "security", "scopes"),
+	WithOpaqueFields("metadata", "params"),
+}
+
+// NormalizeKeys returns a copy of s with every object key converted to style,
+// so payloads mixing "protocolVersion" and "protocol_version" can be read
+// with one spelling. When both spellings of a key are present, the one
+// already in the target style wins. The result shares no values with s.
+func NormalizeKeys(s *structpb.Struct, style KeyStyle, opts ...NormalizeOption) *structpb.Struct {
+	options := &normalizeOptions{verbatimKeys: map[string]bool{}, opaque: map[string]bool{}}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if s == nil {
+		return nil
+	}
+
+	return normalizeStruct(s, style, options, false)
+}
+
+func normalizeStruct(s *structpb.Struct, style KeyStyle, options *normalizeOptions, verbatim bool) *structpb.Struct {
+	out := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(s.GetFields()))}
+
+	for key, value := range s.GetFields() {
+		name := key
+		if !verbatim {
+			name = convertKey(key, style)
+
+			// A key already in the target style takes precedence over a
+			// converted one.
+			if _, exists := out.Fields[name]; exists && name != key {
+				continue
+			}
+		}
+
+		switch {
+		case !verbatim && options.opaque[name]:
+			out.Fields[name] = recordutil.CloneValue(value)
+		default:
+			out.Fields[name] = normalizeValue(value, style, options, !verbatim && options.verbatimKeys[name])
+		}
+	}
+
+	return out
+}
+
+func normalizeValue(value *structpb.Value, style KeyStyle, options *normalizeOptions, verbatim bool) *structpb.Value {
+	switch kind := value.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return structpb.NewStructValue(normalizeStruct(kind.StructValue, style, options, verbatim))
+	case *structpb.Value_ListValue:
+		items := make([]*structpb.Value, 0, len(kind.ListValue.GetValues()))
+		for _, item := range kind.ListValue.GetValues() {
+			items = append(items, normalizeValue(item, style, options, verbatim))
+		}
+
+		return structpb.NewListValue(&structpb.ListValue{Values: items})
+	default:
+		return recordutil.CloneValue(value)
+	}
+}
+
+// convertKey converts a camelCase or snake_case key to style. Keys in neither
+// style (e.g. "x-api-key" or "$schema") are returned unchanged.
+func convertKey(key string, style KeyStyle) string {
+	if strings.ContainsAny(key, "-$.: ") {
+		return key
+	}
+
+	if style == KeyStyleCamelCase {
+		parts := strings.Split(key, "_")
+		if len(parts) == 1 || parts[0] == "" {
+			return key
+		}
+
+		var b strings.Builder
+
+		b.WriteString(parts[0])
+
+		for _, part := range parts[1:] {
+			if part == "" {
+				continue
+			}
+
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+
+		return b.String()
+	}
+
+	var b strings.Builder
+
+	runes := []rune(key)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at a lower-to-upper transition and at the last
+			// capital of an acronym ("URLPath" -> "url_path").
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,59 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNormalizeKeys(t *testing.T) {
+	input, err := structpb.NewStruct(map[string]any{
+		"protocol_version":    "0.3.0",
+		"defaultInputModes":   []any{"text"},
+		"skills":              []any{map[string]any{"input_modes": []any{"text"}}},
+		"security_schemes":    map[string]any{"my_oauth": map[string]any{"open_id_connect_url": "https://id.example.org"}},
+		"metadata":            map[string]any{"build_id": "42"},
+		"x-custom":            "kept",
+		"preferred_transport": "JSONRPC",
+		"preferredTransport":  "HTTP+JSON",
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	got := translator.NormalizeKeys(input, translator.KeyStyleCamelCase,
+		translator.WithVerbatimKeys("securitySchemes"), translator.WithOpaqueFields("metadata")).AsMap()
+
+	want := map[string]any{
+		"protocolVersion":    "0.3.0",
+		"defaultInputModes":  []any{"text"},
+		"skills":             []any{map[string]any{"inputModes": []any{"text"}}},
+		"securitySchemes":    map[string]any{"my_oauth": map[string]any{"openIdConnectUrl": "https://id.example.org"}},
+		"metadata":           map[string]any{"build_id": "42"},
+		"x-custom":           "kept",
+		"preferredTransport": "HTTP+JSON",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeKeys(camelCase) =\n%v\nwant\n%v", got, want)
+	}
+
+	if _, ok := input.GetFields()["protocol_version"]; !ok {
+		t.Error("NormalizeKeys modified its input")
+	}
+
+	snake := translator.NormalizeKeys(translator.NormalizeKeys(input, translator.KeyStyleCamelCase), translator.KeyStyleSnakeCase)
+	for _, key := range []string{"protocol_version", "default_input_modes", "security_schemes", "preferred_transport"} {
+		if _, ok := snake.GetFields()[key]; !ok {
+			t.Errorf("snake_case result misses %q: %v", key, snake.AsMap())
+		}
+	}
+
+	if translator.NormalizeKeys(nil, translator.KeyStyleSnakeCase) != nil {
+		t.Error("NormalizeKeys(nil) must return nil")
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// LangChain MCP adapter transports.
+const (
+	LangChainTransportStdio          = "stdio"
+	LangChainTransportSSE            = "sse"
+	LangChainTransportStreamableHTTP = "streamable_http"
+)
+
+// LangChainConnection is a server connection of the langchain-mcp-adapters
+// MultiServerMCPClient.
+type LangChainConnection struct {
+	Transport string            `json:"transport"`
+	Command   string            `json:"command,omitempty"`
+	Args      []string          `json:"args,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	URL       string            `json:"url,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// LangChainTool is a LangChain tool definition.
+type LangChainTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	ArgsSchema  map[string]any `json:"args_schema,omitempty"`
+}
+
+// LangChainManifest is a LangChain tool manifest: the MCP server connections
+// by name, as passed to MultiServerMCPClient, and the tools they provide.
+type LangChainManifest struct {
+	Name        string                         `json:"name,omitempty"`
+	Description string                         `json:"description,omitempty"`
+	Version     string                         `json:"version,omitempty"`
+	Connections map[string]LangChainConnection `json:"connections"`
+	Tools       []LangChainTool                `json:"tools,omitempty"`
+}
+
+// RecordToLangChain translates the MCP module of a record into a LangChain
+// tool manifest. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// Every supported connection of a 1.0.0 module becomes a connection named
+// after the server, suffixed with its transport when the module has several.
+// Environment variables without a default, and the secrets of headers (see
+// secretHeaders), are set to a "${NAME}" placeholder for the application to
+// resolve. The module tools are copied with their input schema as
+// args_schema.
+func RecordToLangChain(record *structpb.Struct) (*LangChainManifest, error) {
+	found, mcpModuleStruct := recordutil.FindModule(record, MCPModuleName)
+	if !found {
+		return nil, fmt.Errorf("MCP %w", ErrModuleNotFound)
+	}
+
+	mcpModule := mcpModuleStruct.GetFields()["data"].GetStructValue()
+	fields := mcpModule.GetFields()
+
+	manifest := &LangChainManifest{
+		Name:        record.GetFields()["name"].GetStringValue(),
+		Description: record.GetFields()["description"].GetStringValue(),
+		Version:     record.GetFields()["version"].GetStringValue(),
+		Connections: map[string]LangChainConnection{},
+	}
+
+	switch {
+	case fields["connections"] != nil:
+		serverName := normalizeServerName(fields["name"].GetStringValue())
+		if serverName == "" {
+			return nil, fmt.Errorf("%w: missing 'name' in MCP module data (1.0.0 format)", ErrInvalidInput)
+		}
+
+		connections := fields["connections"].GetListValue().GetValues()
+
+		for _, connectionVal := range connections {
+			conn, ok := langChainConnection(connectionVal.GetStructValue())
+			if !ok {
+				continue
+			}
+
+			name := serverName
+			if len(connections) > 1 {
+				name += "-" + conn.Transport
+			}
+
+			if _, exists := manifest.Connections[name]; !exists {
+				manifest.Connections[name] = conn
+			}
+		}
+	case fields["servers"] != nil:
+		for _, serverVal := range fields["servers"].GetListValue().GetValues() {
+			serverMap := serverVal.GetStructValue()
+
+			name := serverMap.GetFields()["name"].GetStringValue()
+			if name == "" {
+				continue
+			}
+
+			server, err := processMCPServer(serverMap, name, &[]MCPInput{})
+			if err != nil {
+				return nil, err
+			}
+
+			manifest.Connections[normalizeServerName(name)] = LangChainConnection{
+				Transport: LangChainTransportStdio,
+				Command:   server.Command,
+				Args:      server.Args,
+				Env:       placeholderEnv(server.Env),
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: invalid MCP module data: missing 'servers' (0.7.0/0.8.0) or 'connections' (1.0.0)", ErrInvalidInput)
+	}
+
+	if len(manifest.Connections) == 0 {
+		return nil, fmt.Errorf("%w: no supported MCP connections in record", ErrInvalidInput)
+	}
+
+	for _, toolVal := range fields["tools"].GetListValue().GetValues() {
+		toolFields := toolVal.GetStructValue().GetFields()
+		if toolFields["name"].GetStringValue() == "" {
+			continue
+		}
+
+		manifest.Tools = append(manifest.Tools, LangChainTool{
+			Name:        toolFields["name"].GetStringValue(),
+			Description: toolFields["description"].GetStringValue(),
+			ArgsSchema:  toolFields["input_schema"].GetStructValue().AsMap(),
+		})
+	}
+
+	return manifest, nil
+}
+
+// langChainConnection converts a 1.0.0 MCP connection. It reports false for
+// connection types LangChain does not support.
+func langChainConnection(connection *structpb.Struct) (LangChainConnection, bool) {
+	fields := connection.GetFields()
+
+	switch fields["type"].GetStringValue() {
+	case connectionTypeStdio:
+		env := map[string]string{}
+
+		for _, envVarVal := range fields["env_vars"].GetListValue().GetValues() {
+			envFields := envVarVal.GetStructValue().GetFields()
+
+			name := envFields["name"].GetStringValue()
+			if name == "" {
+				continue
+			}
+
+			env[name] = envFields["default_value"].GetStringValue()
+			if env[name] == "" {
+				env[name] = "${" + name + "}"
+			}
+		}
+
+		if len(env) == 0 {
+			env = nil
+		}
+
+		return LangChainConnection{
+			Transport: LangChainTransportStdio,
+			Command:   fields["command"].GetStringValue(),
+			Args:      stringValues(fields["args"].GetListValue()),
+			Env:       env,
+		}, true
+	case connectionTypeSSE, connectionTypeHTTP:
+		transport := LangChainTransportSSE
+		if fields["type"].GetStringValue() == connectionTypeHTTP {
+			transport = LangChainTransportStreamableHTTP
+		}
+
+		var headers map[string]string
+		if h := fields["headers"].GetStructValue(); h != nil {
+			headers = make(map[string]string, len(h.GetFields()))
+			for name, value := range h.GetFields() {
+				headers[name] = value.GetStringValue()
+			}
+		}
+
+		return LangChainConnection{Transport: transport, URL: fields["url"].GetStringValue(), Headers: secretHeaders(headers)}, true
+	default:
+		return LangChainConnection{}, false
+	}
+}
+
+// placeholderEnv rewrites the "${input:NAME}" references of GitHub Copilot
+// configurations into "${NAME}" placeholders, for clients without inputs.
+func placeholderEnv(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(env))
+
+	for name, value := range env {
+		if id, ok := strings.CutPrefix(value, "${input:"); ok {
+			value = "${" + id
+		}
+
+		out[name] = value
+	}
+
+	return out
+}
+
+// LangChainToRecord translates a LangChain tool manifest (see
+// LangChainManifest) into an OASF record with an MCP module. A bare
+// connections map, as passed to MultiServerMCPClient, is accepted too.
+//
+// The record is named after the manifest, or after its first connection.
+// Environment values that are "${NAME}" placeholders become environment
+// variables without a default. Connections with unsupported transports
+// (e.g. websocket) are dropped.
+func LangChainToRecord(data *structpb.Struct, opts ...TranslatorOption) (*structpb.Struct, error) { //nolint:cyclop
+	fields := data.GetFields()
+
+	connectionsStruct := fields["connections"].GetStructValue()
+	if connectionsStruct == nil {
+		connectionsStruct = data
+	}
+
+	names := make([]string, 0, len(connectionsStruct.GetFields()))
+	for name := range connectionsStruct.GetFields() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var connections []any
+
+	for _, name := range names {
+		if conn := recordConnection(connectionsStruct.GetFields()[name].GetStructValue()); conn != nil {
+			connections = append(connections, conn)
+		}
+	}
+
+	if len(connections) == 0 {
+		return nil, fmt.Errorf("%w: no supported connections in LangChain manifest", ErrInvalidInput)
+	}
+
+	options := &translatorOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	targetVersion := DefaultSchemaVersion
+
+	if options.version != "" {
+		if err := validateMajorVersion(options.version); err != nil {
+			return nil, err
+		}
+
+		targetVersion = options.version
+	}
+
+	name := fields["name"].GetStringValue()
+	if name == "" {
+		name = names[0]
+	}
+
+	description := fields["description"].GetStringValue()
+	if description == "" {
+		description = "Agent generated from LangChain tool manifest"
+	}
+
+	moduleData := map[string]any{
+		"name":        name,
+		"description": description,
+		"connections": connections,
+	}
+
+	var tools []any
+
+	for _, toolVal := range fields["tools"].GetListValue().GetValues() {
+		toolFields := toolVal.GetStructValue().GetFields()
+		if toolFields["name"].GetStringValue() == "" {
+			continue
+		}
+
+		tool := map[string]any{"name": toolFields["name"].GetStringValue()}
+
+		if d := toolFields["description"].GetStringValue(); d != "" {
+			tool["description"] = d
+		}
+
+		if schema := toolFields["args_schema"].GetStructValue(); schema != nil {
+			tool["input_schema"] = schema.AsMap()
+		}
+
+		tools = append(tools, tool)
+	}
+
+	if len(tools) > 0 {
+		moduleData["tools"] = tools
+	}
+
+	moduleStruct, err := structpb.NewStruct(moduleData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build MCP module data: %w", err)
+	}
+
+	record, err := recordutil.NewBuilder().
+		Name(name).
+		SchemaVersion(targetVersion).
+		Version(resolveRecordVersion(fields["version"].GetStringValue(), options.recordVersion)).
+		Description(description).
+		Authors(resolveRecordAuthors(nil, options)...).
+		AddModule(MCPModuleName, moduleStruct).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
+
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// recordConnection converts a LangChain connection to a 1.0.0 MCP
+// connection, or returns nil for unsupported transports.
+func recordConnection(conn *structpb.Struct) map[string]any {
+	fields := conn.GetFields()
+
+	transport := fields["transport"].GetStringValue()
+	if transport == "" && fields["command"] != nil {
+		transport = LangChainTransportStdio
+	}
+
+	switch transport {
+	case LangChainTransportStdio:
+		out := map[string]any{
+			"type":    connectionTypeStdio,
+			"command": fields["command"].GetStringValue(),
+		}
+
+		if args := fields["args"].GetListValue(); len(args.GetValues()) > 0 {
+			out["args"] = args.AsSlice()
+		}
+
+		envStruct := fields["env"].GetStructValue()
+		envNames := make([]string, 0, len(envStruct.GetFields()))
+
+		for name := range envStruct.GetFields() {
+			envNames = append(envNames, name)
+		}
+
+		sort.Strings(envNames)
+
+		envVars := make([]any, 0, len(envNames))
+
+		for _, name := range envNames {
+			envVar := map[string]any{"name": name}
+
+			value := envStruct.GetFields()[name].GetStringValue()
+			if value != "" && !strings.HasPrefix(value, "${") {
+				envVar["default_value"] = value
+			}
+
+			envVars = append(envVars, envVar)
+		}
+
+		if len(envVars) > 0 {
+			out["env_vars"] = envVars
+		}
+
+		return out
+	case LangChainTransportSSE, LangChainTransportStreamableHTTP, "http":
+		out := map[string]any{"type": connectionTypeSSE, "url": fields["url"].GetStringValue()}
+		if transport != LangChainTransportSSE {
+			out["type"] = connectionTypeHTTP
+		}
+
+		if headers := fields["headers"].GetStructValue(); len(headers.GetFields()) > 0 {
+			out["headers"] = headers.AsMap()
+		}
+
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func langChainRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/github",
+		"version":        "v1.0.0",
+		"schema_version": "1.0.0",
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{
+				"name": "github-mcp-server",
+				"connections": []any{
+					map[string]any{
+						"type":    "stdio",
+						"command": "docker",
+						"args":    []any{"run", "-i", "ghcr.io/github/github-mcp-server"},
+						"env_vars": []any{
+							map[string]any{"name": "GITHUB_TOKEN"},
+							map[string]any{"name": "GITHUB_HOST", "default_value": "github.com"},
+						},
+					},
+					map[string]any{
+						"type":    "streamable-http",
+						"url":     "https://api.githubcopilot.com/mcp/",
+						"headers": map[string]any{"Authorization": "Bearer {token}"},
+					},
+				},
+				"tools": []any{map[string]any{
+					"name":         "create_issue",
+					"description":  "Creates an issue",
+					"input_schema": map[string]any{"type": "object"},
+				}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestRecordToLangChain(t *testing.T) {
+	manifest, err := translator.RecordToLangChain(langChainRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToLangChain: %v", err)
+	}
+
+	want := map[string]translator.LangChainConnection{
+		"github-stdio": {
+			Transport: translator.LangChainTransportStdio,
+			Command:   "docker",
+			Args:      []string{"run", "-i", "ghcr.io/github/github-mcp-server"},
+			Env:       map[string]string{"GITHUB_TOKEN": "${GITHUB_TOKEN}", "GITHUB_HOST": "github.com"},
+		},
+		"github-streamable_http": {
+			Transport: translator.LangChainTransportStreamableHTTP,
+			URL:       "https://api.githubcopilot.com/mcp/",
+			Headers:   map[string]string{"Authorization": "Bearer ${TOKEN}"},
+		},
+	}
+
+	if !reflect.DeepEqual(manifest.Connections, want) {
+		t.Errorf("connections = %+v, want %+v", manifest.Connections, want)
+	}
+
+	if manifest.Name != "example.org/github" || len(manifest.Tools) != 1 || manifest.Tools[0].Name != "create_issue" ||
+		manifest.Tools[0].ArgsSchema["type"] != "object" {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+}
+
+func TestRecordToLangChainLegacyServers(t *testing.T) {
+	rec, err := structpb.NewStruct(map[string]any{
+		"name": "legacy",
+		"modules": []any{map[string]any{
+			"name": "runtime/mcp",
+			"data": map[string]any{"servers": []any{map[string]any{
+				"name":    "files-server",
+				"command": "npx",
+				"args":    []any{"-y", "files", "-e", "ROOT"},
+			}}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := translator.RecordToLangChain(rec)
+	if err != nil {
+		t.Fatalf("RecordToLangChain: %v", err)
+	}
+
+	conn := manifest.Connections["files"]
+	if conn.Transport != translator.LangChainTransportStdio || conn.Command != "npx" || conn.Env["ROOT"] != "${ROOT}" {
+		t.Errorf("unexpected connection %+v", conn)
+	}
+}
+
+func TestRecordToLangChainNoMCPModule(t *testing.T) {
+	rec, _ := structpb.NewStruct(map[string]any{"name": "no-modules"})
+
+	if _, err := translator.RecordToLangChain(rec); err == nil {
+		t.Error("expected an error for a record without MCP module")
+	}
+}
+
+func TestLangChainToRecord(t *testing.T) {
+	data, err := structpb.NewStruct(map[string]any{
+		"weather": map[string]any{
+			"transport": "stdio",
+			"command":   "python",
+			"args":      []any{"weather.py"},
+			"env":       map[string]any{"API_KEY": "${API_KEY}", "UNITS": "metric"},
+		},
+		"search": map[string]any{"transport": "streamable_http", "url": "https://search.example.com/mcp"},
+		"socket": map[string]any{"transport": "websocket", "url": "ws://localhost:9000"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := translator.LangChainToRecord(data, translator.WithAuthors([]string{"Example"}))
+	if err != nil {
+		t.Fatalf("LangChainToRecord: %v", err)
+	}
+
+	if rec.GetFields()["name"].GetStringValue() != "search" {
+		t.Errorf("record named %q, want the first connection", rec.GetFields()["name"].GetStringValue())
+	}
+
+	found, module := record.FindModule(rec, translator.MCPModuleName)
+	if !found {
+		t.Fatal("MCP module missing")
+	}
+
+	connections := module.GetFields()["data"].GetStructValue().AsMap()["connections"].([]any)
+	if len(connections) != 2 {
+		t.Fatalf("got %d connections, want 2 (websocket dropped): %v", len(connections), connections)
+	}
+
+	if http := connections[0].(map[string]any); http["type"] != "streamable-http" || http["url"] != "https://search.example.com/mcp" {
+		t.Errorf("unexpected http connection %v", http)
+	}
+
+	stdio := connections[1].(map[string]any)
+	wantEnv := []any{
+		map[string]any{"name": "API_KEY"},
+		map[string]any{"name": "UNITS", "default_value": "metric"},
+	}
+
+	if stdio["command"] != "python" || !reflect.DeepEqual(stdio["env_vars"], wantEnv) {
+		t.Errorf("unexpected stdio connection %v", stdio)
+	}
+}
+
+func TestLangChainRoundTrip(t *testing.T) {
+	manifest, err := translator.RecordToLangChain(langChainRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToLangChain: %v", err)
+	}
+
+	tools := make([]any, 0, len(manifest.Tools))
+	for _, tool := range manifest.Tools {
+		tools = append(tools, map[string]any{"name": tool.Name, "description": tool.Description, "args_schema": tool.ArgsSchema})
+	}
+
+	connections := map[string]any{}
+	for name, conn := range manifest.Connections {
+		entry := map[string]any{"transport": conn.Transport, "url": conn.URL, "command": conn.Command}
+		if len(conn.Args) > 0 {
+			entry["args"] = []any{conn.Args[0], conn.Args[1], conn.Args[2]}
+		}
+
+		connections[name] = entry
+	}
+
+	data, err := structpb.NewStruct(map[string]any{"name": manifest.Name, "connections": connections, "tools": tools})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := translator.LangChainToRecord(data)
+	if err != nil {
+		t.Fatalf("LangChainToRecord: %v", err)
+	}
+
+	back, err := translator.RecordToLangChain(rec)
+	if err != nil {
+		t.Fatalf("RecordToLangChain: %v", err)
+	}
+
+	if back.Name != manifest.Name || len(back.Connections) != 2 || !reflect.DeepEqual(back.Tools, manifest.Tools) {
+		t.Errorf("round trip changed the manifest: %+v", back)
+	}
+}
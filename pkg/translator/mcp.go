@@ -5,13 +5,12 @@ package translator
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"maps"
 	"slices"
 	"strings"
-	"time"
 
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
 	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -20,7 +19,7 @@ import (
 func processMCPServer(serverMap *structpb.Struct, serverName string, inputs *[]MCPInput) (MCPServer, error) {
 	command, ok := serverMap.GetFields()["command"]
 	if !ok {
-		return MCPServer{}, fmt.Errorf("missing 'command' for server '%s'", serverName)
+		return MCPServer{}, fmt.Errorf("%w: missing 'command' for server '%s'", ErrInvalidInput, serverName)
 	}
 
 	args := []string{}
@@ -64,8 +63,16 @@ func processMCPServer(serverMap *structpb.Struct, serverName string, inputs *[]M
 	if envVal, ok := serverMap.GetFields()["env"]; ok {
 		envStruct := envVal.GetStructValue()
 		if envStruct != nil {
-			for key, val := range envStruct.GetFields() {
-				value := val.GetStringValue()
+			// Sorted, so the inputs come out in the same order every time.
+			keys := make([]string, 0, len(envStruct.GetFields()))
+			for key := range envStruct.GetFields() {
+				keys = append(keys, key)
+			}
+
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				value := envStruct.GetFields()[key].GetStringValue()
 				env[key] = value
 
 				// Only create input if it's an input reference (not a literal value)
@@ -84,52 +91,47 @@ func processMCPServer(serverMap *structpb.Struct, serverName string, inputs *[]M
 	}, nil
 }
 
-// addInputIfNotExists adds an input to the inputs slice if it doesn't already exist.
+// addInputIfNotExists adds a secret input to the inputs slice if it doesn't already exist.
 func addInputIfNotExists(inputs *[]MCPInput, id string) {
-	for _, input := range *inputs {
-		if input.ID == id {
+	addInput(inputs, envVar{name: id}.input())
+}
+
+// addInput adds an input to the inputs slice if it doesn't already exist.
+func addInput(inputs *[]MCPInput, input MCPInput) {
+	for _, existing := range *inputs {
+		if existing.ID == input.ID {
 			return
 		}
 	}
 
-	*inputs = append(*inputs, MCPInput{
-		ID:          id,
-		Type:        "promptString",
-		Password:    true,
-		Description: "Secret value for " + id,
-	})
+	*inputs = append(*inputs, input)
 }
 
 // processEnvVar processes a single env_var object and adds it to env map and inputs if needed.
+// Variables with choices are picked from them; others without a default are
+// prompted for, masked unless the record marks them as not secret.
 func processEnvVar(envVarMap *structpb.Struct, env map[string]string, inputs *[]MCPInput) {
-	nameVal, ok := envVarMap.GetFields()["name"]
-	if !ok {
+	v := parseEnvVar(envVarMap)
+	if v.name == "" {
 		return
 	}
 
-	envName := nameVal.GetStringValue()
-
-	// Get default_value if present
-	valueVal, hasDefaultValue := envVarMap.GetFields()["default_value"]
-
-	value := ""
-	if hasDefaultValue {
-		value = valueVal.GetStringValue()
-	}
-
-	// If default_value exists and is not empty, use it directly
-	if hasDefaultValue && value != "" {
-		env[envName] = value
+	switch {
+	case len(v.choices) > 0:
+		env[v.name] = "${input:" + v.name + "}"
+		addInput(inputs, v.input())
+	case v.defaultValue != "":
+		env[v.name] = v.defaultValue
 
 		// Only create input if it's an input reference
-		if after, ok0 := strings.CutPrefix(value, "${input:"); ok0 {
+		if after, ok := strings.CutPrefix(v.defaultValue, "${input:"); ok {
 			id := strings.TrimSuffix(after, "}")
 			addInputIfNotExists(inputs, id)
 		}
-	} else {
+	default:
 		// No default value, create input reference for required env
-		env[envName] = "${input:" + envName + "}"
-		addInputIfNotExists(inputs, envName)
+		env[v.name] = "${input:" + v.name + "}"
+		addInput(inputs, v.input())
 	}
 }
 
@@ -137,7 +139,7 @@ func processEnvVar(envVarMap *structpb.Struct, env map[string]string, inputs *[]
 func processMCPConnection(connectionMap *structpb.Struct, inputs *[]MCPInput) (MCPServer, error) {
 	commandVal, hasCommand := connectionMap.GetFields()["command"]
 	if !hasCommand {
-		return MCPServer{}, errors.New("missing 'command' in connection")
+		return MCPServer{}, fmt.Errorf("%w: missing 'command' in connection", ErrInvalidInput)
 	}
 
 	args := []string{}
@@ -165,6 +167,17 @@ func processMCPConnection(connectionMap *structpb.Struct, inputs *[]MCPInput) (M
 		}
 	}
 
+	// Variables a docker run command passes to the container (-e NAME)
+	// without an env_var are prompted for too.
+	if run, ok := parseDockerRun(commandVal.GetStringValue(), args); ok {
+		for _, name := range run.env {
+			if _, ok := env[name]; !ok {
+				env[name] = "${input:" + name + "}"
+				addInputIfNotExists(inputs, name)
+			}
+		}
+	}
+
 	return MCPServer{
 		Command: commandVal.GetStringValue(),
 		Args:    args,
@@ -176,7 +189,7 @@ func processMCPConnection(connectionMap *structpb.Struct, inputs *[]MCPInput) (M
 func processMCPModule100(mcpModule *structpb.Struct, servers map[string]MCPServer, inputs *[]MCPInput) error {
 	nameVal, ok := mcpModule.GetFields()["name"]
 	if !ok {
-		return errors.New("missing 'name' in MCP module data (1.0.0 format)")
+		return fmt.Errorf("%w: missing 'name' in MCP module data (1.0.0 format)", ErrInvalidInput)
 	}
 
 	originalName := nameVal.GetStringValue()
@@ -185,12 +198,12 @@ func processMCPModule100(mcpModule *structpb.Struct, servers map[string]MCPServe
 
 	connectionsVal, ok := mcpModule.GetFields()["connections"]
 	if !ok {
-		return errors.New("invalid or missing 'connections' in MCP module data (1.0.0 format)")
+		return fmt.Errorf("%w: invalid or missing 'connections' in MCP module data (1.0.0 format)", ErrInvalidInput)
 	}
 
 	connectionsList := connectionsVal.GetListValue()
 	if connectionsList == nil {
-		return errors.New("'connections' must be an array")
+		return fmt.Errorf("%w: 'connections' must be an array", ErrInvalidInput)
 	}
 
 	// Process each connection - for GH Copilot, we only support stdio connections
@@ -239,12 +252,12 @@ func normalizeServerName(name string) string {
 func processMCPModule070080(mcpModule *structpb.Struct, servers map[string]MCPServer, inputs *[]MCPInput) error {
 	serversVal, ok := mcpModule.GetFields()["servers"]
 	if !ok {
-		return errors.New("invalid or missing 'servers' in MCP module data")
+		return fmt.Errorf("%w: invalid or missing 'servers' in MCP module data", ErrInvalidInput)
 	}
 
 	serversList := serversVal.GetListValue()
 	if serversList == nil {
-		return errors.New("'servers' must be an array")
+		return fmt.Errorf("%w: 'servers' must be an array", ErrInvalidInput)
 	}
 
 	for _, serverVal := range serversList.GetValues() {
@@ -275,16 +288,34 @@ func processMCPModule070080(mcpModule *structpb.Struct, servers map[string]MCPSe
 }
 
 // RecordToGHCopilot translates a record into a GHCopilotMCPConfig structure.
-// Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
-func RecordToGHCopilot(record *structpb.Struct) (*GHCopilotMCPConfig, error) { //nolint:gocognit
-	// Get MCP module - try 0.8.0/1.0.0 name first, then fall back to 0.7.0 for backward compatibility
-	found, mcpModuleStruct := recordutil.GetModule(record, MCPModuleName) // "integration/mcp" (0.8.0, 1.0.0)
-	if !found {
-		found, mcpModuleStruct = recordutil.GetModule(record, "runtime/mcp") // 0.7.0 compatibility
+// Supports OASF versions 0.7.0, 0.8.0, and 1.0.0. The configuration is a VS
+// Code workspace .vscode/mcp.json unless WithGHCopilotFlavor selects another
+// format.
+func RecordToGHCopilot(record *structpb.Struct, opts ...GHCopilotOption) (*GHCopilotMCPConfig, error) { //nolint:gocognit
+	options := &ghCopilotOptions{flavor: GHCopilotWorkspace, platform: PlatformPOSIX}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.platform.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := options.pythonRunner.validate(); err != nil {
+		return nil, err
 	}
 
+	switch options.flavor {
+	case GHCopilotWorkspace, GHCopilotUser, GHCopilotCodingAgent:
+	default:
+		return nil, fmt.Errorf("%w: unsupported GitHub Copilot flavor %q", ErrInvalidInput, options.flavor)
+	}
+
+	// Matches "integration/mcp" (0.8.0, 1.0.0) as well as "runtime/mcp" (0.7.0),
+	// or any other name in the module alias table.
+	found, mcpModuleStruct := recordutil.FindModule(record, MCPModuleName)
 	if !found {
-		return nil, errors.New("MCP module not found in record")
+		return nil, fmt.Errorf("MCP %w", ErrModuleNotFound)
 	}
 
 	mcpModule := mcpModuleStruct.GetFields()["data"].GetStructValue()
@@ -306,32 +337,53 @@ func RecordToGHCopilot(record *structpb.Struct) (*GHCopilotMCPConfig, error) { /
 			return nil, err
 		}
 	} else {
-		return nil, errors.New("invalid MCP module data: missing 'servers' (0.7.0/0.8.0) or 'connections' (1.0.0)")
+		return nil, fmt.Errorf("%w: invalid MCP module data: missing 'servers' (0.7.0/0.8.0) or 'connections' (1.0.0)", ErrInvalidInput)
+	}
+
+	for name, server := range servers {
+		server.Command, server.Args = options.pythonRunner.rewrite(server.Command, server.Args)
+		server.Command, server.Args = options.platform.command(server.Command, server.Args)
+		servers[name] = server
 	}
 
-	return &GHCopilotMCPConfig{
+	config := &GHCopilotMCPConfig{
 		Servers: servers,
 		Inputs:  inputs,
-	}, nil
+		Flavor:  options.flavor,
+	}
+
+	if options.secrets != nil {
+		if err := config.referenceSecrets(*options.secrets); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
 }
 
-// processHeaders converts MCP headers array to structpb.Struct.
+// processHeaders converts MCP headers array to structpb.Struct. Headers
+// without a value, and secret headers with a literal value, get a "{name}"
+// placeholder, so records do not carry credentials.
 func processHeaders(headers []any) *structpb.Struct {
-	headersMap := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	headersMap := pb.Struct(nil)
 
 	for _, header := range headers {
 		if headerMap, ok := header.(map[string]any); ok {
 			if name, ok := headerMap["name"].(string); ok {
+				placeholder := "{" + strings.ToLower(strings.ReplaceAll(name, " ", "_")) + "}"
+				secret, _ := headerMap["isSecret"].(bool)
+
 				headerValue := ""
 				if val, ok := headerMap["value"].(string); ok {
 					headerValue = val
+					if secret && !registryPlaceholder.MatchString(val) {
+						headerValue = placeholder
+					}
 				} else if _, ok := headerMap["description"].(string); ok {
-					headerValue = "{" + strings.ToLower(strings.ReplaceAll(name, " ", "_")) + "}"
+					headerValue = placeholder
 				}
 
-				headersMap.Fields[name] = &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: headerValue},
-				}
+				headersMap.Fields[name] = pb.Str(headerValue)
 			}
 		}
 	}
@@ -350,9 +402,102 @@ func containsStringValue(argsValues []*structpb.Value, value string) bool {
 	})
 }
 
-// buildStdioConnection builds a stdio connection from package data.
-func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //nolint:gocognit,nestif,gocyclo,cyclop,maintidx
-	connectionFields := map[string]*structpb.Value{}
+// runtimeSubcommands are the arguments starting the commands of package
+// runtimes, before the runtime arguments and the package.
+var runtimeSubcommands = map[string][]string{
+	"python": {"-m"},
+	"pipx":   {"run"},
+	"docker": {"run", "-i", "--rm"},
+	"podman": {"run", "-i", "--rm"},
+	"dotnet": {"tool", "run"},
+	"mcpb":   {"run"},
+}
+
+// renderArgument returns the command line arguments of a runtime or package
+// argument of the MCP registry argument model. A named argument is its name
+// followed by its value ("--port", "8080"), or its name alone when it takes
+// no value (a flag); a name ending with "=" is joined to its value. A
+// positional argument is its value. Arguments without a value take their
+// default; required ones without either get a "{valueHint}" placeholder to
+// fill in, and optional ones are left out. The "{name}" references of a value
+// to its variables are replaced with the value or default of the variable;
+// those without either are left as placeholders. An entry has a single
+// value, so an isRepeated argument is rendered once per entry listing it.
+func renderArgument(arg map[string]any) []string {
+	name, _ := arg["name"].(string)
+	hint, _ := arg["valueHint"].(string)
+	required, _ := arg["isRequired"].(bool)
+
+	value, _ := arg["value"].(string)
+	if value == "" {
+		value, _ = arg["default"].(string)
+	}
+
+	if variables, ok := arg["variables"].(map[string]any); ok && value != "" {
+		value = substituteVariables(value, variables)
+	}
+
+	if value == "" && hint != "" && required {
+		value = "{" + hint + "}"
+	}
+
+	switch arg["type"] {
+	case "named":
+		switch {
+		case name == "":
+			return nil
+		case value != "" && strings.HasSuffix(name, "="):
+			return []string{name + value}
+		case value != "":
+			return []string{name, value}
+		case hint != "":
+			// An optional argument taking a value that is not given.
+			return nil
+		default:
+			return []string{name}
+		}
+	case "positional":
+		if value == "" {
+			return nil
+		}
+
+		return []string{value}
+	default:
+		return nil
+	}
+}
+
+// substituteVariables replaces the "{name}" references of the value to the
+// variables that have a value or a default.
+func substituteVariables(value string, variables map[string]any) string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	pairs := make([]string, 0, 2*len(names)) //nolint:mnd
+	for _, name := range names {
+		variable, _ := variables[name].(map[string]any)
+
+		resolved, _ := variable["value"].(string)
+		if resolved == "" {
+			resolved, _ = variable["default"].(string)
+		}
+
+		if resolved != "" {
+			pairs = append(pairs, "{"+name+"}", resolved)
+		}
+	}
+
+	return strings.NewReplacer(pairs...).Replace(value)
+}
+
+// buildStdioConnection builds a stdio connection from package data; PyPI
+// packages without a runtime hint are run with the runner.
+func buildStdioConnection(pkgMap map[string]any, runner PythonRunner) pb.Fields { //nolint:gocognit,nestif,gocyclo,cyclop,maintidx
+	connectionFields := pb.Fields{}
 
 	registryType := ""
 	identifier := ""
@@ -374,7 +519,7 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 		case packageTypeNPM:
 			command = "npx"
 		case packageTypePyPI:
-			command = "python"
+			command = runner.command()
 		case packageTypeOCI:
 			command = "docker"
 		case packageTypeNuGet:
@@ -386,62 +531,40 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 		}
 	}
 
-	connectionFields["command"] = &structpb.Value{
-		Kind: &structpb.Value_StringValue{StringValue: command},
+	connectionFields["command"] = pb.Str(command)
+
+	// Build args array, starting with the subcommand of the runtime, e.g.
+	// "docker run -i --rm", also when the runtime is given as a hint.
+	argsValues := make([]*structpb.Value, 0, len(runtimeSubcommands[command]))
+	for _, arg := range runtimeSubcommands[command] {
+		argsValues = append(argsValues, pb.Str(arg))
 	}
 
-	// Build args array
-	var argsValues []*structpb.Value
+	subcommand := len(argsValues)
 
 	_, hasRuntimeHint := pkgMap["runtimeHint"]
 
-	if !hasRuntimeHint && registryType != "" {
-		switch registryType {
-		case packageTypePyPI:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: "-m"},
-			})
-		case packageTypeOCI:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: "run"},
-			})
-		case packageTypeNuGet:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: "tool"},
-			})
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: "run"},
-			})
-		case packageTypeMCPB:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: "run"},
-			})
+	// Add runtime arguments, but those already in the subcommand
+	if runtimeArgs, ok := pkgMap["runtimeArguments"].([]any); ok {
+		for _, arg := range runtimeArgs {
+			argMap, _ := arg.(map[string]any)
+			for _, value := range renderArgument(argMap) {
+				if !containsStringValue(argsValues[:subcommand], value) {
+					argsValues = append(argsValues, pb.Str(value))
+				}
+			}
 		}
 	}
 
-	// Add runtime arguments
-	if runtimeArgs, ok := pkgMap["runtimeArguments"].([]any); ok { //nolint:nestif
-		for _, arg := range runtimeArgs {
-			if argMap, ok := arg.(map[string]any); ok {
-				if argType, ok := argMap["type"].(string); ok && argType == "named" {
-					if name, ok := argMap["name"].(string); ok {
-						// Only add if not already in argsValues
-						if !containsStringValue(argsValues, name) {
-							argsValues = append(argsValues, &structpb.Value{
-								Kind: &structpb.Value_StringValue{StringValue: name},
-							})
-						}
-					}
-				} else if argType == "positional" {
-					if value, ok := argMap["value"].(string); ok {
-						// Only add if not already in argsValues
-						if !containsStringValue(argsValues, value) {
-							argsValues = append(argsValues, &structpb.Value{
-								Kind: &structpb.Value_StringValue{StringValue: value},
-							})
-						}
-					}
-				}
+	// Pass the environment variables of the package to the container, but
+	// those the runtime arguments already pass.
+	if run, ok := parseDockerRun(command, stringValues(&structpb.ListValue{Values: argsValues})); ok {
+		envVars, _ := pkgMap["environmentVariables"].([]any)
+		for _, envVar := range envVars {
+			envMap, _ := envVar.(map[string]any)
+			if name, _ := envMap["name"].(string); name != "" && !slices.Contains(run.env, name) {
+				argsValues = append(argsValues, pb.Str("-e"), pb.Str(name))
+				run.env = append(run.env, name)
 			}
 		}
 	}
@@ -456,66 +579,48 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 		switch registryType {
 		case packageTypeNPM:
 			if pkgVersion != "" && !hasRuntimeHint {
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: fmt.Sprintf("%s@%s", identifier, pkgVersion)},
-				})
+				argsValues = append(argsValues, pb.Str(fmt.Sprintf("%s@%s", identifier, pkgVersion)))
 			} else {
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: identifier},
-				})
+				argsValues = append(argsValues, pb.Str(identifier))
+			}
+		case packageTypePyPI:
+			if hasRuntimeHint {
+				argsValues = append(argsValues, pb.Str(identifier))
+			} else {
+				for _, arg := range runner.packageArgs(identifier, pkgVersion) {
+					argsValues = append(argsValues, pb.Str(arg))
+				}
 			}
-		case packageTypePyPI, packageTypeMCPB:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: identifier},
-			})
+		case packageTypeMCPB:
+			argsValues = append(argsValues, pb.Str(identifier))
 		case packageTypeOCI:
 			if pkgVersion != "" && !hasRuntimeHint {
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: fmt.Sprintf("%s:%s", identifier, pkgVersion)},
-				})
+				argsValues = append(argsValues, pb.Str(fmt.Sprintf("%s:%s", identifier, pkgVersion)))
 			} else {
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: identifier},
-				})
+				argsValues = append(argsValues, pb.Str(identifier))
 			}
 		case packageTypeNuGet:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: identifier},
-			})
+			argsValues = append(argsValues, pb.Str(identifier))
 			if pkgVersion != "" && !hasRuntimeHint {
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: "--version"},
-				})
-				argsValues = append(argsValues, &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: pkgVersion},
-				})
+				argsValues = append(argsValues, pb.Str("--version"), pb.Str(pkgVersion))
 			}
 		default:
-			argsValues = append(argsValues, &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: identifier},
-			})
+			argsValues = append(argsValues, pb.Str(identifier))
 		}
 	}
 
 	// Add package arguments
 	if packageArgs, ok := pkgMap["packageArguments"].([]any); ok {
 		for _, arg := range packageArgs {
-			if argMap, ok := arg.(map[string]any); ok {
-				if value, ok := argMap["value"].(string); ok {
-					argsValues = append(argsValues, &structpb.Value{
-						Kind: &structpb.Value_StringValue{StringValue: value},
-					})
-				}
+			argMap, _ := arg.(map[string]any)
+			for _, value := range renderArgument(argMap) {
+				argsValues = append(argsValues, pb.Str(value))
 			}
 		}
 	}
 
 	if len(argsValues) > 0 {
-		connectionFields["args"] = &structpb.Value{
-			Kind: &structpb.Value_ListValue{
-				ListValue: &structpb.ListValue{Values: argsValues},
-			},
-		}
+		connectionFields["args"] = pb.List(argsValues...)
 	}
 
 	// Add environment variables
@@ -528,10 +633,8 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 					continue
 				}
 
-				envFields := map[string]*structpb.Value{
-					"name": {
-						Kind: &structpb.Value_StringValue{StringValue: name},
-					},
+				envFields := pb.Fields{
+					"name": pb.Str(name),
 				}
 
 				description := "Environment variable: " + name
@@ -539,34 +642,22 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 					description = desc
 				}
 
-				envFields["description"] = &structpb.Value{
-					Kind: &structpb.Value_StringValue{StringValue: description},
-				}
+				envFields["description"] = pb.Str(description)
 
 				if value, ok := envMap["value"].(string); ok {
-					envFields["default_value"] = &structpb.Value{
-						Kind: &structpb.Value_StringValue{StringValue: value},
-					}
+					envFields["default_value"] = pb.Str(value)
 				} else if defaultVal, ok := envMap["default"].(string); ok {
-					envFields["default_value"] = &structpb.Value{
-						Kind: &structpb.Value_StringValue{StringValue: defaultVal},
-					}
+					envFields["default_value"] = pb.Str(defaultVal)
 				}
 
-				envVarsValues = append(envVarsValues, &structpb.Value{
-					Kind: &structpb.Value_StructValue{
-						StructValue: &structpb.Struct{Fields: envFields},
-					},
-				})
+				maps.Copy(envFields, envVarHints(envMap))
+
+				envVarsValues = append(envVarsValues, pb.Obj(envFields))
 			}
 		}
 
 		if len(envVarsValues) > 0 {
-			connectionFields["env_vars"] = &structpb.Value{
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{Values: envVarsValues},
-				},
-			}
+			connectionFields["env_vars"] = pb.List(envVarsValues...)
 		}
 	}
 
@@ -574,8 +665,8 @@ func buildStdioConnection(pkgMap map[string]any) map[string]*structpb.Value { //
 }
 
 // convertPackageToConnection converts an MCP package to an mcp_server_connection.
-func convertPackageToConnection(pkgMap map[string]any) *structpb.Struct {
-	connectionFields := map[string]*structpb.Value{}
+func convertPackageToConnection(pkgMap map[string]any, runner PythonRunner) *structpb.Struct {
+	connectionFields := pb.Fields{}
 
 	// Determine connection type from transport
 	connectionType := connectionTypeStdio
@@ -596,22 +687,18 @@ func convertPackageToConnection(pkgMap map[string]any) *structpb.Struct {
 		}
 	}
 
-	connectionFields["type"] = &structpb.Value{
-		Kind: &structpb.Value_StringValue{StringValue: connectionType},
-	}
+	connectionFields["type"] = pb.Str(connectionType)
 
 	// For stdio connections, build command and args
 	if connectionType == connectionTypeStdio { //nolint:nestif
-		stdioFields := buildStdioConnection(pkgMap)
+		stdioFields := buildStdioConnection(pkgMap, runner)
 
 		// Copy fields from stdio connection
 		maps.Copy(connectionFields, stdioFields)
 	} else {
 		// For HTTP/SSE connections, add URL
 		if transportUrl != "" {
-			connectionFields["url"] = &structpb.Value{
-				Kind: &structpb.Value_StringValue{StringValue: transportUrl},
-			}
+			connectionFields["url"] = pb.Str(transportUrl)
 		}
 
 		// Add headers if present
@@ -619,20 +706,18 @@ func convertPackageToConnection(pkgMap map[string]any) *structpb.Struct {
 			if headers, ok := transport["headers"].([]any); ok && len(headers) > 0 {
 				headersMap := processHeaders(headers)
 				if headersMap != nil {
-					connectionFields["headers"] = &structpb.Value{
-						Kind: &structpb.Value_StructValue{StructValue: headersMap},
-					}
+					connectionFields["headers"] = structpb.NewStructValue(headersMap)
 				}
 			}
 		}
 	}
 
-	return &structpb.Struct{Fields: connectionFields}
+	return pb.Struct(connectionFields)
 }
 
 // convertRemoteToConnection converts an MCP remote to an mcp_server_connection.
 func convertRemoteToConnection(remoteMap map[string]any) *structpb.Struct {
-	connectionFields := map[string]*structpb.Value{}
+	connectionFields := pb.Fields{}
 
 	// Determine connection type
 	connectionType := connectionTypeHTTP
@@ -646,45 +731,48 @@ func convertRemoteToConnection(remoteMap map[string]any) *structpb.Struct {
 		}
 	}
 
-	connectionFields["type"] = &structpb.Value{
-		Kind: &structpb.Value_StringValue{StringValue: connectionType},
-	}
+	connectionFields["type"] = pb.Str(connectionType)
 
 	// Add URL (required)
 	if url, ok := remoteMap["url"].(string); ok {
-		connectionFields["url"] = &structpb.Value{
-			Kind: &structpb.Value_StringValue{StringValue: url},
-		}
+		connectionFields["url"] = pb.Str(url)
 	}
 
 	// Add headers if present
 	if headers, ok := remoteMap["headers"].([]any); ok && len(headers) > 0 {
 		headersMap := processHeaders(headers)
 		if headersMap != nil {
-			connectionFields["headers"] = &structpb.Value{
-				Kind: &structpb.Value_StructValue{StructValue: headersMap},
-			}
+			connectionFields["headers"] = structpb.NewStructValue(headersMap)
 		}
 	}
 
-	return &structpb.Struct{Fields: connectionFields}
+	return pb.Struct(connectionFields)
 }
 
 // MCPToRecord translates an MCP Registry server.json into an OASF-compliant record format.
 // Generates records using the specified schema version (via WithVersion option) or the default schema version.
-// The version must be 1.x.x format.
+// The version must be 1.x.x format. Servers of the snake_case schema revisions
+// before MCPServerSchemaCamelCase, detected by their $schema or their keys,
+// are stored in the mcp_data of the module in the current shape. A SLIM descriptor in the publisher-provided
+// _meta of the server ({"slim": {"endpoint", "organization", "namespace"}}) is
+// kept in the "slim.*" record annotations. PyPI packages without a runtimeHint
+// are run with python -m unless WithPythonRunner selects uvx or pipx.
 func MCPToRecord(mcpData *structpb.Struct, opts ...TranslatorOption) (*structpb.Struct, error) { //nolint:gocognit,cyclop,maintidx
 	// Extract the server from the input data
 	mcpServerVal, ok := mcpData.GetFields()["server"]
 	if !ok {
-		return nil, errors.New("missing 'server' in input data")
+		return nil, fmt.Errorf("%w: missing 'server' in input data", ErrInvalidInput)
 	}
 
 	mcpServerStruct := mcpServerVal.GetStructValue()
 	if mcpServerStruct == nil {
-		return nil, errors.New("'server' is not a struct")
+		return nil, fmt.Errorf("%w: 'server' is not a struct", ErrInvalidInput)
 	}
 
+	// Servers of earlier schema revisions are read in the current shape.
+	original := mcpServerStruct
+	mcpServerStruct = upgradeMCPServer(mcpServerStruct)
+
 	// Convert MCP server.json struct to map for easier access
 	serverMap := mcpServerStruct.AsMap()
 
@@ -693,6 +781,10 @@ func MCPToRecord(mcpData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 		opt(options)
 	}
 
+	if err := options.pythonRunner.validate(); err != nil {
+		return nil, err
+	}
+
 	// Extract metadata from server.json for record metadata
 	serverName := "generated-mcp-agent"
 	serverDescription := "Agent generated from MCP server JSON"
@@ -730,10 +822,7 @@ func MCPToRecord(mcpData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 		}
 	}
 
-	authors := resolveRecordAuthors(sourceAuthors, options.authors)
-
-	// Use current timestamp for created_at (RFC3339 format)
-	createdAt := time.Now().UTC().Format(time.RFC3339)
+	authors := resolveRecordAuthors(sourceAuthors, options)
 
 	// Build connections array from packages and/or remotes
 	var connections []*structpb.Value
@@ -742,11 +831,9 @@ func MCPToRecord(mcpData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 	if packages, ok := serverMap["packages"].([]any); ok {
 		for _, pkg := range packages {
 			if pkgMap, ok := pkg.(map[string]any); ok {
-				connection := convertPackageToConnection(pkgMap)
+				connection := convertPackageToConnection(pkgMap, options.pythonRunner)
 
-				connections = append(connections, &structpb.Value{
-					Kind: &structpb.Value_StructValue{StructValue: connection},
-				})
+				connections = append(connections, structpb.NewStructValue(connection))
 			}
 		}
 	}
@@ -757,185 +844,84 @@ func MCPToRecord(mcpData *structpb.Struct, opts ...TranslatorOption) (*structpb.
 			if remoteMap, ok := remote.(map[string]any); ok {
 				connection := convertRemoteToConnection(remoteMap)
 
-				connections = append(connections, &structpb.Value{
-					Kind: &structpb.Value_StructValue{StructValue: connection},
-				})
+				connections = append(connections, structpb.NewStructValue(connection))
 			}
 		}
 	}
 
 	if len(connections) == 0 {
-		return nil, errors.New("no packages or remotes found in MCP server data")
+		return nil, fmt.Errorf("%w: no packages or remotes found in MCP server data", ErrInvalidInput)
 	}
 
-	// Create a copy of mcpServerStruct without $schema field
-	mcpDataWithoutSchema := &structpb.Struct{
-		Fields: make(map[string]*structpb.Value),
-	}
-
-	for k, v := range mcpServerStruct.GetFields() {
-		if k != "$schema" {
-			mcpDataWithoutSchema.Fields[k] = v
+	// Copy mcpServerStruct without $schema field. A shallow copy is enough:
+	// the record builder deep-copies module data, so the record does not
+	// alias the input.
+	mcpDataWithoutSchema := pb.Struct(make(pb.Fields, len(mcpServerStruct.GetFields())))
+	for key, value := range mcpServerStruct.GetFields() {
+		if key != "$schema" {
+			mcpDataWithoutSchema.Fields[key] = value
 		}
 	}
 
 	// Create mcp_data structure with the entire server.json stored in mcp_data field (without $schema)
-	mcpDataFields := map[string]*structpb.Value{
-		"name": {
-			Kind: &structpb.Value_StringValue{StringValue: serverName},
-		},
-		"connections": {
-			Kind: &structpb.Value_ListValue{
-				ListValue: &structpb.ListValue{Values: connections},
-			},
-		},
-		"mcp_data": {
-			Kind: &structpb.Value_StructValue{StructValue: mcpDataWithoutSchema},
-		},
+	mcpDataFields := pb.Fields{
+		"name":        pb.Str(serverName),
+		"connections": pb.List(connections...),
+		"mcp_data":    structpb.NewStructValue(mcpDataWithoutSchema),
 	}
 
 	if serverDescription != "" {
-		mcpDataFields["description"] = &structpb.Value{
-			Kind: &structpb.Value_StringValue{StringValue: serverDescription},
-		}
+		mcpDataFields["description"] = pb.Str(serverDescription)
 	}
 
-	mcpModuleData := &structpb.Struct{Fields: mcpDataFields}
+	mcpModuleData := pb.Struct(mcpDataFields)
 
-	// Build artifact from the original MCP server JSON (without $schema).
-	mcpModuleFields := map[string]*structpb.Value{
-		"name": {
-			Kind: &structpb.Value_StringValue{StringValue: MCPModuleName},
-		},
-		"data": {
-			Kind: &structpb.Value_StructValue{StructValue: mcpModuleData},
-		},
+	// Attach the original MCP server JSON (without $schema) as the module
+	// artifact, in the shape of its schema revision.
+	originalWithoutSchema := original.AsMap()
+	delete(originalWithoutSchema, "$schema")
+
+	var moduleOpts []recordutil.ModuleOption
+	if rawMCP, err := json.Marshal(originalWithoutSchema); err == nil {
+		moduleOpts = append(moduleOpts, recordutil.WithModuleArtifact(rawMCP, mcpMediaType))
 	}
 
-	if rawMCP, err := json.Marshal(mcpDataWithoutSchema.AsMap()); err == nil {
-		if artifactStruct := buildArtifactDescriptor(rawMCP, mcpMediaType); artifactStruct != nil {
-			mcpModuleFields["artifact"] = &structpb.Value{
-				Kind: &structpb.Value_StructValue{StructValue: artifactStruct},
-			}
+	targetVersion := DefaultSchemaVersion
+
+	if options.version != "" {
+		if err := validateMajorVersion(options.version); err != nil {
+			return nil, err
 		}
-	}
 
-	// Create the MCP module with schema-compliant data
-	mcpModule := &structpb.Struct{
-		Fields: mcpModuleFields,
+		targetVersion = options.version
 	}
 
-	// Create the modules list
-	modulesList := &structpb.ListValue{
-		Values: []*structpb.Value{
-			{
-				Kind: &structpb.Value_StructValue{StructValue: mcpModule},
-			},
-		},
-	}
+	builder := recordutil.NewBuilder().
+		Name(serverName).
+		SchemaVersion(targetVersion).
+		Version(serverVersion).
+		Description(serverDescription).
+		Authors(authors...).
+		Set("locators", []any{}).
+		AddModule(MCPModuleName, mcpModuleData, moduleOpts...)
 
-	var targetVersion string
+	if repoUrl != "" {
+		builder.AddLocator("source_code", repoUrl)
+	}
 
-	if options.version != "" {
-		targetVersion = options.version
+	record, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
 
-		// Validate provided version
-		if err := validateMajorVersion(targetVersion); err != nil {
+	if slim, ok := mcpServerSLIM(mcpServerStruct); ok {
+		if err := SetRecordSLIM(record, slim); err != nil {
 			return nil, err
 		}
-	} else {
-		// Use hardcoded default version
-		targetVersion = DefaultSchemaVersion
-	}
-
-	// Create OASF-compliant record with all required fields
-	record := &structpb.Struct{
-		Fields: map[string]*structpb.Value{
-			"name": {
-				Kind: &structpb.Value_StringValue{StringValue: serverName},
-			},
-			"schema_version": {
-				Kind: &structpb.Value_StringValue{StringValue: targetVersion},
-			},
-			"version": {
-				Kind: &structpb.Value_StringValue{StringValue: serverVersion},
-			},
-			"description": {
-				Kind: &structpb.Value_StringValue{StringValue: serverDescription},
-			},
-			"authors": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: func() []*structpb.Value {
-							authorValues := make([]*structpb.Value, 0, len(authors))
-							for _, author := range authors {
-								authorValues = append(authorValues, &structpb.Value{
-									Kind: &structpb.Value_StringValue{StringValue: author},
-								})
-							}
-
-							return authorValues
-						}(),
-					},
-				},
-			},
-			"created_at": {
-				Kind: &structpb.Value_StringValue{StringValue: createdAt},
-			},
-			"skills": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: []*structpb.Value{},
-					},
-				},
-			},
-			"locators": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: func() []*structpb.Value {
-							if repoUrl == "" {
-								return []*structpb.Value{}
-							}
-
-							return []*structpb.Value{
-								{
-									Kind: &structpb.Value_StructValue{
-										StructValue: &structpb.Struct{
-											Fields: map[string]*structpb.Value{
-												"type": {
-													Kind: &structpb.Value_StringValue{StringValue: "source_code"},
-												},
-												"urls": {
-													Kind: &structpb.Value_ListValue{
-														ListValue: &structpb.ListValue{
-															Values: []*structpb.Value{
-																{
-																	Kind: &structpb.Value_StringValue{StringValue: repoUrl},
-																},
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							}
-						}(),
-					},
-				},
-			},
-			"domains": {
-				Kind: &structpb.Value_ListValue{
-					ListValue: &structpb.ListValue{
-						Values: []*structpb.Value{},
-					},
-				},
-			},
-			"modules": {
-				Kind: &structpb.Value_ListValue{ListValue: modulesList},
-			},
-		},
+	}
+
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
 	}
 
 	return record, nil
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"regexp"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// MCPServerSchemaCamelCase is the first revision of the MCP registry
+// server.json schema with the current shape: camelCase keys, packages with a
+// registryType and an identifier, and remotes with a type. Earlier revisions
+// (e.g. 2025-07-09) use snake_case keys, packages with a registry_name and a
+// name, remotes with a transport_type, and put the version in version_detail.
+const MCPServerSchemaCamelCase = "2025-09-16"
+
+// mcpSchemaRevision matches the revision date in the $schema URL of a
+// server.json, e.g. ".../schemas/2025-09-29/server.schema.json".
+var mcpSchemaRevision = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+
+// legacyMCPPackageKeys are package keys found only in the snake_case
+// revisions of server.json, used to detect them without a $schema.
+var legacyMCPPackageKeys = []string{
+	"registry_name", "registry_type", "runtime_hint", "runtime_arguments", "package_arguments", "environment_variables",
+}
+
+// MCPServerSchemaRevision returns the revision date of the $schema of an MCP
+// registry server.json, e.g. "2025-09-29", or "" when it has none.
+func MCPServerSchemaRevision(server *structpb.Struct) string {
+	match := mcpSchemaRevision.FindStringSubmatch(server.GetFields()["$schema"].GetStringValue())
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// upgradeMCPServer returns the server.json in the shape of the current
+// schema revisions. Servers of an earlier revision, by their $schema or,
+// without one, by their keys, are converted; others are returned as is.
+func upgradeMCPServer(server *structpb.Struct) *structpb.Struct {
+	if !legacyMCPServer(server) {
+		return server
+	}
+
+	upgraded := NormalizeKeys(server, KeyStyleCamelCase, WithVerbatimKeys("variables"), WithOpaqueFields("_meta"))
+	fields := upgraded.GetFields()
+
+	if detail := fields["versionDetail"].GetStructValue(); detail != nil {
+		if _, ok := fields["version"]; !ok && detail.GetFields()["version"] != nil {
+			fields["version"] = detail.GetFields()["version"]
+		}
+
+		delete(fields, "versionDetail")
+	}
+
+	for _, pkg := range fields["packages"].GetListValue().GetValues() {
+		pkgFields := pkg.GetStructValue().GetFields()
+		if pkgFields == nil {
+			continue
+		}
+
+		renameField(pkgFields, "registryName", "registryType")
+		renameField(pkgFields, "name", "identifier")
+	}
+
+	for _, remote := range fields["remotes"].GetListValue().GetValues() {
+		if remoteFields := remote.GetStructValue().GetFields(); remoteFields != nil {
+			renameField(remoteFields, "transportType", "type")
+		}
+	}
+
+	return upgraded
+}
+
+// legacyMCPServer reports whether the server.json is of a revision before
+// MCPServerSchemaCamelCase.
+func legacyMCPServer(server *structpb.Struct) bool {
+	if revision := MCPServerSchemaRevision(server); revision != "" {
+		return revision < MCPServerSchemaCamelCase
+	}
+
+	fields := server.GetFields()
+	if fields["version_detail"] != nil {
+		return true
+	}
+
+	for _, pkg := range fields["packages"].GetListValue().GetValues() {
+		for _, key := range legacyMCPPackageKeys {
+			if pkg.GetStructValue().GetFields()[key] != nil {
+				return true
+			}
+		}
+	}
+
+	for _, remote := range fields["remotes"].GetListValue().GetValues() {
+		if remote.GetStructValue().GetFields()["transport_type"] != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renameField moves a field to a new key, unless the new key is already set.
+func renameField(fields map[string]*structpb.Value, from, to string) {
+	value, ok := fields[from]
+	if !ok {
+		return
+	}
+
+	delete(fields, from)
+
+	if _, exists := fields[to]; !exists {
+		fields[to] = value
+	}
+}
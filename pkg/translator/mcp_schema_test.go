@@ -0,0 +1,176 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// currentServer is a server.json of the current camelCase schema revisions.
+func currentServer(schema string) map[string]any {
+	server := map[string]any{
+		"name":        "io.github.example/weather",
+		"description": "Weather MCP server",
+		"version":     "1.2.0",
+		"packages": []any{
+			map[string]any{
+				"registryType":     "npm",
+				"identifier":       "@example/weather",
+				"version":          "1.2.0",
+				"runtimeHint":      "npx",
+				"transport":        map[string]any{"type": "stdio"},
+				"packageArguments": []any{map[string]any{"type": "named", "name": "--units", "value": "metric"}},
+				"environmentVariables": []any{
+					map[string]any{"name": "WEATHER_API_KEY", "isSecret": true, "isRequired": true},
+				},
+			},
+		},
+		"remotes": []any{
+			map[string]any{
+				"type":    "sse",
+				"url":     "https://weather.example.org/sse",
+				"headers": []any{map[string]any{"name": "X-API-Key", "isSecret": true}},
+			},
+		},
+	}
+
+	if schema != "" {
+		server["$schema"] = schema
+	}
+
+	return server
+}
+
+// legacyServer is the same server.json in the snake_case shape of the schema
+// revisions before 2025-09-16.
+func legacyServer(schema string) map[string]any {
+	server := map[string]any{
+		"name":           "io.github.example/weather",
+		"description":    "Weather MCP server",
+		"version_detail": map[string]any{"version": "1.2.0"},
+		"packages": []any{
+			map[string]any{
+				"registry_name":     "npm",
+				"name":              "@example/weather",
+				"version":           "1.2.0",
+				"runtime_hint":      "npx",
+				"transport":         map[string]any{"type": "stdio"},
+				"package_arguments": []any{map[string]any{"type": "named", "name": "--units", "value": "metric"}},
+				"environment_variables": []any{
+					map[string]any{"name": "WEATHER_API_KEY", "is_secret": true, "is_required": true},
+				},
+			},
+		},
+		"remotes": []any{
+			map[string]any{
+				"transport_type": "sse",
+				"url":            "https://weather.example.org/sse",
+				"headers":        []any{map[string]any{"name": "X-API-Key", "is_secret": true}},
+			},
+		},
+	}
+
+	if schema != "" {
+		server["$schema"] = schema
+	}
+
+	return server
+}
+
+func mcpModuleData(t *testing.T, server map[string]any) *structpb.Struct {
+	t.Helper()
+
+	input, err := structpb.NewStruct(map[string]any{"server": server})
+	if err != nil {
+		t.Fatalf("failed to build input: %v", err)
+	}
+
+	record, err := translator.MCPToRecord(input)
+	if err != nil {
+		t.Fatalf("MCPToRecord() error: %v", err)
+	}
+
+	if got := record.GetFields()["version"].GetStringValue(); got != "1.2.0" {
+		t.Errorf("version = %q, want 1.2.0", got)
+	}
+
+	_, module := recordutil.FindModule(record, translator.MCPModuleName)
+
+	return module
+}
+
+func TestMCPToRecordSchemaRevisions(t *testing.T) {
+	want := mcpModuleData(t, currentServer("https://static.modelcontextprotocol.io/schemas/2025-09-29/server.schema.json"))
+	wantData := want.GetFields()["data"].GetStructValue()
+
+	tests := map[string]map[string]any{
+		"2025-07-09":             legacyServer("https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json"),
+		"snake_case, no $schema": legacyServer(""),
+		"2025-09-16":             currentServer("https://static.modelcontextprotocol.io/schemas/2025-09-16/server.schema.json"),
+		"camelCase, no $schema":  currentServer(""),
+		"future revision":        currentServer("https://static.modelcontextprotocol.io/schemas/2026-06-01/server.schema.json"),
+	}
+
+	for name, server := range tests {
+		module := mcpModuleData(t, server)
+		data := module.GetFields()["data"].GetStructValue()
+
+		if !proto.Equal(data.GetFields()["connections"], wantData.GetFields()["connections"]) {
+			t.Errorf("%s: connections = %v\nwant %v", name, data.GetFields()["connections"], wantData.GetFields()["connections"])
+		}
+
+		if !proto.Equal(data.GetFields()["mcp_data"], wantData.GetFields()["mcp_data"]) {
+			t.Errorf("%s: mcp_data = %v\nwant the server in the current shape", name, data.GetFields()["mcp_data"])
+		}
+	}
+}
+
+func TestMCPToRecordLegacyArtifact(t *testing.T) {
+	module := mcpModuleData(t, legacyServer("https://static.modelcontextprotocol.io/schemas/2025-07-09/server.schema.json"))
+
+	raw, err := base64.StdEncoding.DecodeString(module.GetFields()["artifact"].GetStructValue().GetFields()["data"].GetStringValue())
+	if err != nil {
+		t.Fatalf("failed to decode artifact: %v", err)
+	}
+
+	var artifact map[string]any
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		t.Fatalf("failed to unmarshal artifact: %v", err)
+	}
+
+	if _, ok := artifact["version_detail"]; !ok {
+		t.Errorf("artifact = %v, want the original server", artifact)
+	}
+
+	if _, ok := artifact["$schema"]; ok {
+		t.Errorf("artifact = %v, want no $schema", artifact)
+	}
+}
+
+func TestMCPServerSchemaRevision(t *testing.T) {
+	tests := map[string]string{
+		"https://static.modelcontextprotocol.io/schemas/2025-09-29/server.schema.json":                      "2025-09-29",
+		"https://modelcontextprotocol.io/schemas/draft/2025-07-09/server.json":                              "2025-07-09",
+		"https://raw.githubusercontent.com/modelcontextprotocol/registry/main/docs/server-json/schema.json": "",
+		"": "",
+	}
+
+	for schema, want := range tests {
+		server, err := structpb.NewStruct(map[string]any{"$schema": schema})
+		if err != nil {
+			t.Fatalf("failed to build server: %v", err)
+		}
+
+		if got := translator.MCPServerSchemaRevision(server); got != want {
+			t.Errorf("MCPServerSchemaRevision(%q) = %q, want %q", schema, got, want)
+		}
+	}
+}
@@ -4,9 +4,13 @@
 package translator_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"maps"
+	"slices"
 	"testing"
 
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
 	"github.com/agntcy/oasf-sdk/pkg/translator"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -69,6 +73,24 @@ func TestMCPToRecord_BasicFields(t *testing.T) {
 	}
 }
 
+func TestMCPToRecord_DoesNotAliasInput(t *testing.T) {
+	input := minimalMCPInput(t, nil)
+
+	record, err := translator.MCPToRecord(input)
+	if err != nil {
+		t.Fatalf("MCPToRecord() error: %v", err)
+	}
+
+	_, module := recordutil.FindModule(record, translator.MCPModuleName)
+	mcpData := module.GetFields()["data"].GetStructValue().GetFields()["mcp_data"].GetStructValue()
+	mcpData.GetFields()["packages"].GetListValue().GetValues()[0].GetStructValue().Fields["version"] = structpb.NewStringValue("2.0.0")
+
+	pkg := input.GetFields()["server"].GetStructValue().GetFields()["packages"].GetListValue().GetValues()[0]
+	if got := pkg.GetStructValue().GetFields()["version"].GetStringValue(); got != "1.0.0" {
+		t.Errorf("modifying the record changed the input: version = %q", got)
+	}
+}
+
 func TestMCPToRecord_AuthorsWithOption(t *testing.T) {
 	record, err := translator.MCPToRecord(minimalMCPInput(t, map[string]any{
 		"name": "my-server",
@@ -286,6 +308,154 @@ func TestMCPToRecord_PyPIPackage(t *testing.T) {
 	}
 }
 
+func TestMCPToRecord_ArgumentModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		pkg         map[string]any
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			name: "named arguments with values and repeated flags",
+			pkg: map[string]any{
+				"registryType": "npm",
+				"identifier":   "server",
+				"runtimeArguments": []any{
+					map[string]any{"type": "named", "name": "-y"},
+					map[string]any{"type": "named", "name": "--registry", "value": "https://npm.example.com"},
+				},
+				"packageArguments": []any{
+					map[string]any{"type": "named", "name": "-v"},
+					map[string]any{"type": "named", "name": "-v"},
+					map[string]any{"type": "named", "name": "--port", "default": "8080"},
+					map[string]any{"type": "named", "name": "--level=", "value": "debug"},
+					map[string]any{"type": "positional", "value": "/tmp"},
+					map[string]any{"type": "positional", "value": "/srv"},
+				},
+			},
+			wantCommand: "npx",
+			wantArgs: []string{
+				"-y", "--registry", "https://npm.example.com", "server",
+				"-v", "-v", "--port", "8080", "--level=debug", "/tmp", "/srv",
+			},
+		},
+		{
+			name: "value hints",
+			pkg: map[string]any{
+				"registryType": "npm",
+				"identifier":   "server",
+				"packageArguments": []any{
+					map[string]any{"type": "named", "name": "--root", "valueHint": "directory", "isRequired": true},
+					map[string]any{"type": "named", "name": "--cache", "valueHint": "directory"},
+					map[string]any{"type": "positional", "valueHint": "target_dir", "isRequired": true},
+					map[string]any{"type": "positional", "valueHint": "extra_dir"},
+				},
+			},
+			wantCommand: "npx",
+			wantArgs:    []string{"server", "--root", "{directory}", "{target_dir}"},
+		},
+		{
+			name: "variables",
+			pkg: map[string]any{
+				"registryType": "npm",
+				"identifier":   "server",
+				"packageArguments": []any{
+					map[string]any{
+						"type": "named", "name": "--db", "value": "postgres://{user}@{host}:{port}/{db}",
+						"variables": map[string]any{
+							"host": map[string]any{"default": "localhost"},
+							"port": map[string]any{"value": "5432", "default": "5433"},
+							"user": map[string]any{"value": "{host}"},
+							"db":   map[string]any{"isRequired": true},
+						},
+					},
+					map[string]any{
+						"type": "positional", "default": "{root}/data",
+						"variables": map[string]any{"root": map[string]any{"default": "/srv"}},
+					},
+				},
+			},
+			wantCommand: "npx",
+			wantArgs:    []string{"server", "--db", "postgres://{host}@localhost:5432/{db}", "/srv/data"},
+		},
+		{
+			name: "repeated arguments",
+			pkg: map[string]any{
+				"registryType": "npm",
+				"identifier":   "server",
+				"packageArguments": []any{
+					map[string]any{"type": "named", "name": "--allow", "value": "/tmp", "isRepeated": true},
+					map[string]any{"type": "named", "name": "--allow", "value": "/srv", "isRepeated": true},
+					map[string]any{"type": "positional", "valueHint": "path", "isRequired": true, "isRepeated": true},
+				},
+			},
+			wantCommand: "npx",
+			wantArgs:    []string{"server", "--allow", "/tmp", "--allow", "/srv", "{path}"},
+		},
+		{
+			name: "docker runtime hint keeps the run subcommand",
+			pkg: map[string]any{
+				"registryType": "oci",
+				"identifier":   "ghcr.io/example/server",
+				"runtimeHint":  "docker",
+				"runtimeArguments": []any{
+					map[string]any{"type": "named", "name": "-i"},
+					map[string]any{"type": "named", "name": "-e", "value": "TOKEN"},
+					map[string]any{"type": "named", "name": "-e", "value": "LEVEL"},
+				},
+			},
+			wantCommand: "docker",
+			wantArgs:    []string{"run", "-i", "--rm", "-e", "TOKEN", "-e", "LEVEL", "ghcr.io/example/server"},
+		},
+		{
+			name: "docker passes the environment variables to the container",
+			pkg: map[string]any{
+				"registryType": "oci",
+				"identifier":   "ghcr.io/example/server",
+				"version":      "1.0.0",
+				"runtimeArguments": []any{
+					map[string]any{"type": "named", "name": "-v", "value": "/data:/data"},
+					map[string]any{"type": "named", "name": "--env=TOKEN"},
+				},
+				"environmentVariables": []any{
+					map[string]any{"name": "TOKEN", "isSecret": true},
+					map[string]any{"name": "LEVEL", "default": "info"},
+				},
+			},
+			wantCommand: "docker",
+			wantArgs:    []string{"run", "-i", "--rm", "-v", "/data:/data", "--env=TOKEN", "-e", "LEVEL", "ghcr.io/example/server:1.0.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.pkg["transport"] = map[string]any{"type": "stdio"}
+
+			record, err := translator.MCPToRecord(minimalMCPInput(t, map[string]any{"packages": []any{tt.pkg}}))
+			if err != nil {
+				t.Fatalf("MCPToRecord() error: %v", err)
+			}
+
+			_, module := recordutil.FindModule(record, translator.MCPModuleName)
+			connection := module.GetFields()["data"].GetStructValue().
+				GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue().GetFields()
+
+			if got := connection["command"].GetStringValue(); got != tt.wantCommand {
+				t.Errorf("command = %q, want %q", got, tt.wantCommand)
+			}
+
+			var args []string
+			for _, arg := range connection["args"].GetListValue().GetValues() {
+				args = append(args, arg.GetStringValue())
+			}
+
+			if !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("args = %q, want %q", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
 func TestMCPToRecord_SSERemote(t *testing.T) {
 	record, err := translator.MCPToRecord(minimalMCPInput(t, map[string]any{
 		"remotes": []any{
@@ -440,6 +610,158 @@ func TestRecordToGHCopilot_EnvVarsCreateInputs(t *testing.T) {
 	}
 }
 
+func TestMCPToRecord_EnvVarHints(t *testing.T) {
+	input := minimalMCPInput(t, map[string]any{
+		"packages": []any{
+			map[string]any{
+				"registryType": "npm",
+				"identifier":   "@example/my-server",
+				"transport":    map[string]any{"type": "stdio"},
+				"environmentVariables": []any{
+					map[string]any{"name": "API_KEY", "isSecret": true, "isRequired": true},
+					map[string]any{"name": "REGION", "choices": []any{"eu", "us"}, "default": "eu"},
+					map[string]any{"name": "LOG_FORMAT"},
+				},
+			},
+		},
+	})
+
+	record, err := translator.MCPToRecord(input)
+	if err != nil {
+		t.Fatalf("MCPToRecord() error: %v", err)
+	}
+
+	_, module := recordutil.FindModule(record, translator.MCPModuleName)
+	connection := module.GetFields()["data"].GetStructValue().GetFields()["connections"].GetListValue().GetValues()[0]
+	envVars := connection.GetStructValue().GetFields()["env_vars"].GetListValue().GetValues()
+
+	apiKey := envVars[0].GetStructValue().AsMap()
+	if apiKey["secret"] != true || apiKey["required"] != true {
+		t.Errorf("API_KEY = %v, want the secret and required hints", apiKey)
+	}
+
+	region := envVars[1].GetStructValue().AsMap()
+	if choices, _ := region["choices"].([]any); len(choices) != 2 || region["default_value"] != "eu" {
+		t.Errorf("REGION = %v, want the choices and default", region)
+	}
+
+	if logFormat := envVars[2].GetStructValue().AsMap(); logFormat["secret"] != nil || logFormat["required"] != nil {
+		t.Errorf("LOG_FORMAT = %v, want no hints for an entry without them", logFormat)
+	}
+}
+
+func TestRecordToGHCopilot_EnvVarInputTypes(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "typed-server",
+					"connections": []any{
+						map[string]any{
+							"type":    "stdio",
+							"command": "npx",
+							"env_vars": []any{
+								map[string]any{"name": "API_KEY", "secret": true},
+								map[string]any{"name": "WORKSPACE", "secret": false},
+								map[string]any{"name": "REGION", "choices": []any{"eu", "us"}, "default_value": "eu"},
+								map[string]any{"name": "TOKEN"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToGHCopilot(record)
+	if err != nil {
+		t.Fatalf("RecordToGHCopilot() error: %v", err)
+	}
+
+	inputs := map[string]translator.MCPInput{}
+	for _, input := range config.Inputs {
+		inputs[input.ID] = input
+	}
+
+	if in := inputs["API_KEY"]; in.Type != "promptString" || !in.Password {
+		t.Errorf("API_KEY input = %+v, want a masked promptString", in)
+	}
+
+	if in := inputs["WORKSPACE"]; in.Type != "promptString" || in.Password {
+		t.Errorf("WORKSPACE input = %+v, want a plain promptString", in)
+	}
+
+	if in := inputs["REGION"]; in.Type != "pickString" || !slices.Equal(in.Options, []string{"eu", "us"}) || in.Default != "eu" {
+		t.Errorf("REGION input = %+v, want a pickString of the choices", in)
+	}
+
+	if in := inputs["TOKEN"]; !in.Password {
+		t.Errorf("TOKEN input = %+v, want variables without hints masked", in)
+	}
+
+	if env := config.Servers["typed"].Env; env["REGION"] != "${input:REGION}" {
+		t.Errorf("env = %v, want REGION picked from its input", env)
+	}
+}
+
+func TestRecordToGHCopilot_EnvInputsDeterministic(t *testing.T) {
+	env := map[string]any{}
+	for _, name := range []string{"ZETA", "ALPHA", "MID", "BETA", "OMEGA", "GAMMA"} {
+		env[name] = "${input:" + name + "}"
+	}
+
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "0.8.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"servers": []any{
+						map[string]any{"name": "env-server", "command": "npx", "env": env},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	var first []byte
+
+	for range 20 {
+		config, err := translator.RecordToGHCopilot(record)
+		if err != nil {
+			t.Fatalf("RecordToGHCopilot() error: %v", err)
+		}
+
+		ids := make([]string, 0, len(config.Inputs))
+		for _, input := range config.Inputs {
+			ids = append(ids, input.ID)
+		}
+
+		if !slices.IsSorted(ids) || len(ids) != len(env) {
+			t.Fatalf("expected the %d inputs sorted by id, got %v", len(env), ids)
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+
+		if first == nil {
+			first = data
+		} else if !bytes.Equal(first, data) {
+			t.Fatalf("output differs between runs:\n%s\n%s", first, data)
+		}
+	}
+}
+
 func TestRecordToGHCopilot_InvalidModuleData(t *testing.T) {
 	// Module data has neither 'name' (1.0.0 format) nor 'servers' (0.7.0/0.8.0)
 	record, err := structpb.NewStruct(map[string]any{
@@ -0,0 +1,336 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ObservabilityModuleName is the module describing how an agent reports
+// metrics and traces.
+const ObservabilityModuleName = "integration/observability"
+
+// Metrics endpoint formats.
+const (
+	// MetricsFormatPrometheus endpoints are scraped over HTTP(S).
+	MetricsFormatPrometheus = "prometheus"
+	// MetricsFormatOTLP endpoints are where the agent pushes OTLP metrics,
+	// i.e. the address of the collector.
+	MetricsFormatOTLP = "otlp"
+)
+
+// OTLP protocols.
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http/protobuf"
+)
+
+// ObservabilityConfig is the data of the observability module: the metrics
+// endpoints of the agent and its tracing configuration.
+type ObservabilityConfig struct {
+	Metrics []MetricsEndpoint `json:"metrics,omitempty"`
+	Tracing *TracingConfig    `json:"tracing,omitempty"`
+}
+
+// MetricsEndpoint is a metrics endpoint of an agent.
+type MetricsEndpoint struct {
+	// URL is the Prometheus endpoint (e.g. http://agent:9090/metrics) or, for
+	// OTLP, the collector address the agent pushes to (e.g. collector:4317).
+	URL string `json:"url"`
+	// Format is MetricsFormatPrometheus (the default) or MetricsFormatOTLP.
+	Format string `json:"format,omitempty"`
+	// Interval is the scrape interval of Prometheus endpoints, e.g. "30s".
+	Interval string `json:"interval,omitempty"`
+}
+
+// TracingConfig is where and how an agent exports OTLP traces.
+type TracingConfig struct {
+	// Endpoint is the collector address the agent exports to, e.g.
+	// collector:4317.
+	Endpoint string `json:"endpoint"`
+	// Protocol is OTLPProtocolGRPC (the default) or OTLPProtocolHTTP.
+	Protocol string `json:"protocol,omitempty"`
+	// SampleRatio is the ratio of traces to keep, from 0 to 1; nil keeps all.
+	SampleRatio *float64 `json:"sample_ratio,omitempty"`
+	// ServiceName is the service.name of the traces; it defaults to the
+	// record name.
+	ServiceName string `json:"service_name,omitempty"`
+}
+
+// Validate checks that the configuration has metrics or tracing and that
+// its endpoints, formats and values are valid.
+func (c ObservabilityConfig) Validate() error {
+	if len(c.Metrics) == 0 && c.Tracing == nil {
+		return fmt.Errorf("%w: observability module requires metrics or tracing", ErrInvalidInput)
+	}
+
+	var errs []error
+
+	for i, m := range c.Metrics {
+		if err := m.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("metrics[%d]: %w", i, err))
+		}
+	}
+
+	if c.Tracing != nil {
+		if err := c.Tracing.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("tracing: %w", err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: invalid observability module: %w", ErrInvalidInput, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func (m MetricsEndpoint) validate() error {
+	if m.Interval != "" {
+		if _, err := time.ParseDuration(m.Interval); err != nil {
+			return fmt.Errorf("invalid interval %q", m.Interval)
+		}
+	}
+
+	switch m.Format {
+	case "", MetricsFormatPrometheus:
+		u, err := url.Parse(m.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("prometheus endpoint %q must be an http(s) URL", m.URL)
+		}
+	case MetricsFormatOTLP:
+		if _, err := endpointPort(m.URL); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported format %q (want prometheus or otlp)", m.Format)
+	}
+
+	return nil
+}
+
+func (t TracingConfig) validate() error {
+	if _, err := endpointPort(t.Endpoint); err != nil {
+		return err
+	}
+
+	switch t.Protocol {
+	case "", OTLPProtocolGRPC, OTLPProtocolHTTP:
+	default:
+		return fmt.Errorf("unsupported protocol %q (want grpc or http/protobuf)", t.Protocol)
+	}
+
+	if t.SampleRatio != nil && (*t.SampleRatio < 0 || *t.SampleRatio > 1) {
+		return fmt.Errorf("sample_ratio %v must be between 0 and 1", *t.SampleRatio)
+	}
+
+	return nil
+}
+
+// endpointPort returns the port of an OTLP endpoint, "host:port" or a URL.
+func endpointPort(endpoint string) (string, error) {
+	hostPort := endpoint
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		hostPort = u.Host
+	}
+
+	_, port, err := net.SplitHostPort(hostPort)
+	if err != nil || port == "" {
+		return "", fmt.Errorf("OTLP endpoint %q must have a host and port", endpoint)
+	}
+
+	return port, nil
+}
+
+// RecordObservability returns the validated observability module of a
+// record.
+func RecordObservability(record *structpb.Struct) (*ObservabilityConfig, error) {
+	found, module := recordutil.FindModule(record, ObservabilityModuleName)
+	if !found {
+		return nil, fmt.Errorf("observability %w", ErrModuleNotFound)
+	}
+
+	data, err := json.Marshal(module.GetFields()["data"].GetStructValue().AsMap())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal observability module: %w", err)
+	}
+
+	config := &ObservabilityConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%w: invalid observability module: %w", ErrInvalidInput, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// SetRecordObservability validates the configuration and stores it as the
+// observability module of the record, replacing an existing one.
+func SetRecordObservability(record *structpb.Struct, config ObservabilityConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	data, err := decoder.StructToProto(config)
+	if err != nil {
+		return fmt.Errorf("failed to convert observability module: %w", err)
+	}
+
+	if recordutil.HasModule(record, ObservabilityModuleName) {
+		return recordutil.ReplaceModule(record, ObservabilityModuleName, data) //nolint:wrapcheck
+	}
+
+	return recordutil.AddModule(record, ObservabilityModuleName, data) //nolint:wrapcheck
+}
+
+// OTelCollectorConfig is an OpenTelemetry Collector configuration snippet.
+type OTelCollectorConfig struct {
+	Receivers  map[string]any `json:"receivers"            yaml:"receivers"`
+	Processors map[string]any `json:"processors,omitempty" yaml:"processors,omitempty"`
+	Exporters  map[string]any `json:"exporters"            yaml:"exporters"`
+	Service    OTelService    `json:"service"              yaml:"service"`
+}
+
+// OTelService is the service section of a collector configuration.
+type OTelService struct {
+	Pipelines map[string]OTelPipeline `json:"pipelines" yaml:"pipelines"`
+}
+
+// OTelPipeline is a collector pipeline.
+type OTelPipeline struct {
+	Receivers  []string `json:"receivers"            yaml:"receivers"`
+	Processors []string `json:"processors,omitempty" yaml:"processors,omitempty"`
+	Exporters  []string `json:"exporters"            yaml:"exporters"`
+}
+
+// otelExporterEndpoint is the endpoint of the OTLP exporter of collector
+// snippets, resolved by the collector from its environment.
+const otelExporterEndpoint = "${env:OTEL_EXPORTER_OTLP_ENDPOINT}"
+
+// jobNamePattern matches the characters not allowed in Prometheus job names.
+var jobNamePattern = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+// RecordToOTelCollector translates the observability module of a record into
+// an OpenTelemetry Collector configuration: a prometheus receiver scraping
+// its Prometheus endpoints, an otlp receiver listening where the agent pushes
+// metrics and traces, and pipelines to an otlp exporter sending to
+// ${env:OTEL_EXPORTER_OTLP_ENDPOINT}.
+func RecordToOTelCollector(record *structpb.Struct) (*OTelCollectorConfig, error) {
+	config, err := RecordObservability(record)
+	if err != nil {
+		return nil, err
+	}
+
+	name := record.GetFields()["name"].GetStringValue()
+	collector := &OTelCollectorConfig{
+		Receivers:  map[string]any{},
+		Processors: map[string]any{"batch": map[string]any{}},
+		Exporters:  map[string]any{"otlp": map[string]any{"endpoint": otelExporterEndpoint}},
+		Service:    OTelService{Pipelines: map[string]OTelPipeline{}},
+	}
+
+	otlp := map[string]any{}
+
+	var scrapeConfigs []any
+
+	for i, m := range config.Metrics {
+		if m.Format == MetricsFormatOTLP {
+			addOTLPProtocol(otlp, OTLPProtocolGRPC, m.URL)
+
+			continue
+		}
+
+		u, _ := url.Parse(m.URL) // validated by RecordObservability
+
+		scrape := map[string]any{
+			"job_name":       fmt.Sprintf("%s_%d", strings.Trim(jobNamePattern.ReplaceAllString(name, "_"), "_"), i),
+			"scheme":         u.Scheme,
+			"metrics_path":   u.Path,
+			"static_configs": []any{map[string]any{"targets": []any{u.Host}}},
+		}
+		if m.Interval != "" {
+			scrape["scrape_interval"] = m.Interval
+		}
+
+		scrapeConfigs = append(scrapeConfigs, scrape)
+	}
+
+	metricReceivers := []string{}
+
+	if len(scrapeConfigs) > 0 {
+		collector.Receivers["prometheus"] = map[string]any{"config": map[string]any{"scrape_configs": scrapeConfigs}}
+		metricReceivers = append(metricReceivers, "prometheus")
+	}
+
+	if len(otlp) > 0 {
+		metricReceivers = append(metricReceivers, "otlp")
+	}
+
+	if len(metricReceivers) > 0 {
+		collector.Service.Pipelines["metrics"] = OTelPipeline{Receivers: metricReceivers, Processors: []string{"batch"}, Exporters: []string{"otlp"}}
+	}
+
+	if t := config.Tracing; t != nil {
+		protocol := t.Protocol
+		if protocol == "" {
+			protocol = OTLPProtocolGRPC
+		}
+
+		addOTLPProtocol(otlp, protocol, t.Endpoint)
+
+		serviceName := t.ServiceName
+		if serviceName == "" {
+			serviceName = name
+		}
+
+		processors := []string{}
+
+		if serviceName != "" {
+			collector.Processors["resource"] = map[string]any{"attributes": []any{
+				map[string]any{"key": "service.name", "value": serviceName, "action": "upsert"},
+			}}
+			processors = append(processors, "resource")
+		}
+
+		if t.SampleRatio != nil {
+			collector.Processors["probabilistic_sampler"] = map[string]any{"sampling_percentage": *t.SampleRatio * 100} //nolint:mnd
+			processors = append(processors, "probabilistic_sampler")
+		}
+
+		collector.Service.Pipelines["traces"] = OTelPipeline{Receivers: []string{"otlp"}, Processors: append(processors, "batch"), Exporters: []string{"otlp"}}
+	}
+
+	if len(otlp) > 0 {
+		collector.Receivers["otlp"] = map[string]any{"protocols": otlp}
+	}
+
+	return collector, nil
+}
+
+// addOTLPProtocol makes the otlp receiver listen with the protocol on the
+// port of the endpoint.
+func addOTLPProtocol(protocols map[string]any, protocol, endpoint string) {
+	port, _ := endpointPort(endpoint) // validated by RecordObservability
+
+	key := "grpc"
+	if protocol == OTLPProtocolHTTP {
+		key = "http"
+	}
+
+	protocols[key] = map[string]any{"endpoint": "0.0.0.0:" + port}
+}
@@ -0,0 +1,112 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func observabilityRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(map[string]any{"name": "example.org/agent", "schema_version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	ratio := 0.25
+
+	err = translator.SetRecordObservability(record, translator.ObservabilityConfig{
+		Metrics: []translator.MetricsEndpoint{
+			{URL: "http://agent:9090/metrics", Interval: "15s"},
+			{URL: "collector:4317", Format: translator.MetricsFormatOTLP},
+		},
+		Tracing: &translator.TracingConfig{Endpoint: "http://collector:4318", Protocol: translator.OTLPProtocolHTTP, SampleRatio: &ratio},
+	})
+	if err != nil {
+		t.Fatalf("SetRecordObservability: %v", err)
+	}
+
+	return record
+}
+
+func TestRecordObservability(t *testing.T) {
+	record := observabilityRecord(t)
+
+	config, err := translator.RecordObservability(record)
+	if err != nil {
+		t.Fatalf("RecordObservability: %v", err)
+	}
+
+	if len(config.Metrics) != 2 || config.Tracing == nil || *config.Tracing.SampleRatio != 0.25 {
+		t.Errorf("RecordObservability() = %+v", config)
+	}
+
+	// Setting it again replaces the module.
+	if err := translator.SetRecordObservability(record, translator.ObservabilityConfig{Tracing: &translator.TracingConfig{Endpoint: "collector:4317"}}); err != nil {
+		t.Fatalf("SetRecordObservability: %v", err)
+	}
+
+	if modules := record.GetFields()["modules"].GetListValue().GetValues(); len(modules) != 1 {
+		t.Errorf("expected the module to be replaced, got %d modules", len(modules))
+	}
+}
+
+func TestObservabilityValidate(t *testing.T) {
+	ratio := 2.0
+
+	for name, config := range map[string]translator.ObservabilityConfig{
+		"empty":        {},
+		"bad url":      {Metrics: []translator.MetricsEndpoint{{URL: "agent:9090"}}},
+		"bad format":   {Metrics: []translator.MetricsEndpoint{{URL: "http://agent/metrics", Format: "statsd"}}},
+		"bad interval": {Metrics: []translator.MetricsEndpoint{{URL: "http://agent/metrics", Interval: "often"}}},
+		"no port":      {Tracing: &translator.TracingConfig{Endpoint: "collector"}},
+		"bad protocol": {Tracing: &translator.TracingConfig{Endpoint: "collector:4317", Protocol: "thrift"}},
+		"bad sampling": {Tracing: &translator.TracingConfig{Endpoint: "collector:4317", SampleRatio: &ratio}},
+	} {
+		if err := config.Validate(); !errors.Is(err, translator.ErrInvalidInput) {
+			t.Errorf("%s: Validate() = %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
+
+func TestRecordToOTelCollector(t *testing.T) {
+	collector, err := translator.RecordToOTelCollector(observabilityRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToOTelCollector: %v", err)
+	}
+
+	scrape := collector.Receivers["prometheus"].(map[string]any)["config"].(map[string]any)["scrape_configs"].([]any)[0].(map[string]any)
+	if scrape["job_name"] != "example_org_agent_0" || scrape["metrics_path"] != "/metrics" || scrape["scrape_interval"] != "15s" {
+		t.Errorf("scrape config = %v", scrape)
+	}
+
+	protocols := collector.Receivers["otlp"].(map[string]any)["protocols"].(map[string]any)
+	if protocols["grpc"] == nil || protocols["http"].(map[string]any)["endpoint"] != "0.0.0.0:4318" {
+		t.Errorf("otlp protocols = %v", protocols)
+	}
+
+	metrics := collector.Service.Pipelines["metrics"]
+	if len(metrics.Receivers) != 2 {
+		t.Errorf("metrics pipeline = %+v, want prometheus and otlp receivers", metrics)
+	}
+
+	traces := collector.Service.Pipelines["traces"]
+	if len(traces.Processors) != 3 || traces.Processors[1] != "probabilistic_sampler" {
+		t.Errorf("traces pipeline = %+v", traces)
+	}
+
+	if collector.Processors["probabilistic_sampler"].(map[string]any)["sampling_percentage"] != 25.0 {
+		t.Errorf("sampler = %v", collector.Processors["probabilistic_sampler"])
+	}
+
+	empty, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0"})
+	if _, err := translator.RecordToOTelCollector(empty); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("RecordToOTelCollector() without module = %v, want ErrModuleNotFound", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxOpenAIFunctionName is the maximum length of OpenAI function names.
+const maxOpenAIFunctionName = 64
+
+// openAIFunctionInvalid matches the runs of characters not allowed in OpenAI
+// function names.
+var openAIFunctionInvalid = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// OpenAITool is a tool of the OpenAI function calling APIs (Chat
+// Completions, Assistants and custom GPT actions).
+type OpenAITool struct {
+	Type     string         `json:"type"`
+	Function OpenAIFunction `json:"function"`
+}
+
+// OpenAIFunction is the function of an OpenAITool: its name, description
+// and the JSON schema of its parameters.
+type OpenAIFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters"`
+}
+
+// RecordToOpenAITools translates the MCP tools and A2A skills of a record
+// into OpenAI function calling tools. Supports OASF versions 0.7.0, 0.8.0,
+// and 1.0.0.
+//
+// Every tool of the MCP module is a function taking its input schema. Every
+// skill of the A2A card is a function taking the message to send to the
+// agent, described by the skill description and examples. Names are cut to
+// the characters and length OpenAI allows; a name already taken is skipped.
+func RecordToOpenAITools(record *structpb.Struct) ([]OpenAITool, error) {
+	var tools []OpenAITool
+
+	seen := map[string]bool{}
+
+	add := func(name, description string, parameters map[string]any) {
+		name = openAIFunctionName(name)
+		if name == "" || seen[name] {
+			return
+		}
+
+		seen[name] = true
+
+		tools = append(tools, OpenAITool{
+			Type:     "function",
+			Function: OpenAIFunction{Name: name, Description: description, Parameters: parameters},
+		})
+	}
+
+	_, mcpModule := recordutil.FindModule(record, MCPModuleName)
+
+	for _, toolVal := range mcpModule.GetFields()["data"].GetStructValue().GetFields()["tools"].GetListValue().GetValues() {
+		tool := toolVal.GetStructValue().GetFields()
+
+		parameters := tool["input_schema"].GetStructValue().AsMap()
+		if len(parameters) == 0 {
+			parameters = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+
+		add(tool["name"].GetStringValue(), tool["description"].GetStringValue(), parameters)
+	}
+
+	if card, err := storedA2ACard(record); err == nil {
+		for _, skillVal := range card.GetFields()["skills"].GetListValue().GetValues() {
+			skill := skillVal.GetStructValue().GetFields()
+
+			add(firstNonEmptyString(skill["id"].GetStringValue(), skill["name"].GetStringValue()), skillDescription(skill), map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"message": map[string]any{"type": "string", "description": "The request to send to the agent"},
+				},
+				"required": []any{"message"},
+			})
+		}
+	}
+
+	if len(tools) == 0 {
+		return nil, fmt.Errorf("%w: no MCP tools or A2A skills in record", ErrInvalidInput)
+	}
+
+	return tools, nil
+}
+
+// skillDescription returns the description of an A2A skill, or its name,
+// followed by its examples.
+func skillDescription(skill map[string]*structpb.Value) string {
+	description := firstNonEmptyString(skill["description"].GetStringValue(), skill["name"].GetStringValue())
+
+	var examples []string
+
+	for _, example := range skill["examples"].GetListValue().GetValues() {
+		if s := example.GetStringValue(); s != "" {
+			examples = append(examples, fmt.Sprintf("%q", s))
+		}
+	}
+
+	if len(examples) == 0 {
+		return description
+	}
+
+	return strings.TrimSpace(description + " Examples: " + strings.Join(examples, ", "))
+}
+
+// openAIFunctionName turns a tool or skill name into an OpenAI function
+// name, e.g. "Flight search" into "Flight_search".
+func openAIFunctionName(name string) string {
+	name = openAIFunctionInvalid.ReplaceAllString(name, "_")
+	if len(name) > maxOpenAIFunctionName {
+		name = name[:maxOpenAIFunctionName]
+	}
+
+	return name
+}
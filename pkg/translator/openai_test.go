@@ -0,0 +1,73 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToOpenAITools(t *testing.T) {
+	record := a2aRecord(t, map[string]any{
+		"name": "travel-agent",
+		"skills": []any{
+			map[string]any{"id": "flight search", "description": "Finds flights.", "examples": []any{"Flights to Rome on May 3"}},
+			map[string]any{"id": "get_weather", "name": "Weather"},
+		},
+	})
+
+	mcp, err := structpb.NewStruct(map[string]any{
+		"name": "weather-mcp-server",
+		"tools": []any{
+			map[string]any{
+				"name":         "get_weather",
+				"description":  "Current weather of a city.",
+				"input_schema": map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+			},
+			map[string]any{"name": "list_cities"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build module: %v", err)
+	}
+
+	modules := record.GetFields()["modules"].GetListValue()
+	modules.Values = append(modules.Values, structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+		"name": structpb.NewStringValue(translator.MCPModuleName),
+		"data": structpb.NewStructValue(mcp),
+	}}))
+
+	tools, err := translator.RecordToOpenAITools(record)
+	if err != nil {
+		t.Fatalf("RecordToOpenAITools() error: %v", err)
+	}
+
+	data, err := json.Marshal(tools)
+	if err != nil {
+		t.Fatalf("failed to marshal tools: %v", err)
+	}
+
+	want := `[` +
+		`{"type":"function","function":{"name":"get_weather","description":"Current weather of a city.",` +
+		`"parameters":{"properties":{"city":{"type":"string"}},"type":"object"}}},` +
+		`{"type":"function","function":{"name":"list_cities","parameters":{"properties":{},"type":"object"}}},` +
+		`{"type":"function","function":{"name":"flight_search","description":"Finds flights. Examples: \"Flights to Rome on May 3\"",` +
+		`"parameters":{"properties":{"message":{"description":"The request to send to the agent","type":"string"}},` +
+		`"required":["message"],"type":"object"}}}]`
+	if string(data) != want {
+		t.Errorf("tools = %s\nwant %s", data, want)
+	}
+}
+
+func TestRecordToOpenAIToolsNoTools(t *testing.T) {
+	record := a2aRecord(t, map[string]any{"name": "quiet-agent"})
+
+	if _, err := translator.RecordToOpenAITools(record); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// OpenAPIVersion is the OpenAPI version of the documents RecordToOpenAPI
+// generates.
+const OpenAPIVersion = "3.1.0"
+
+// agentCardPath is where A2A agents publish their card.
+const agentCardPath = "/.well-known/agent-card.json"
+
+// a2aSecuritySchemeTypes maps the wrapped security schemes of A2A 1.0 cards
+// to their OpenAPI type.
+var a2aSecuritySchemeTypes = map[string]string{
+	"apiKeySecurityScheme":        "apiKey",
+	"httpAuthSecurityScheme":      "http",
+	"oauth2SecurityScheme":        "oauth2",
+	"openIdConnectSecurityScheme": "openIdConnect",
+	"mtlsSecurityScheme":          "mutualTLS",
+}
+
+// openAPIBuilder accumulates the paths of an OpenAPI document.
+type openAPIBuilder struct {
+	paths      map[string]any
+	servers    []any
+	operations map[string]int
+	// tags are the skill tags of message operations.
+	tags []any
+	// modes are the x-a2a-input-modes and x-a2a-output-modes of message
+	// operations.
+	modes     map[string]any
+	streaming bool
+	security  []any
+}
+
+// RecordToOpenAPI translates the A2A module of a record into an OpenAPI 3.1
+// document of the HTTP surface of the agent, for API gateways to onboard
+// it. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// Each JSONRPC interface of the card (see CardInterfaces) is a POST
+// operation taking JSON-RPC requests, and each HTTP+JSON interface has the
+// message and task operations of the A2A REST binding; gRPC interfaces are
+// left out. The skills of the card become tags of the message operations,
+// its default input and output modes their x-a2a-input-modes and
+// x-a2a-output-modes, and its security schemes and requirements those of the
+// document. The card itself is served at /.well-known/agent-card.json.
+func RecordToOpenAPI(record *structpb.Struct) (*structpb.Struct, error) {
+	card, err := storedA2ACard(record)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := card.AsMap()
+
+	b := &openAPIBuilder{
+		paths:      map[string]any{},
+		operations: map[string]int{},
+		tags:       skillTags(fields),
+		modes:      map[string]any{},
+		security:   anySlice(fields["security"]),
+	}
+
+	if capabilities, ok := fields["capabilities"].(map[string]any); ok {
+		b.streaming, _ = capabilities["streaming"].(bool)
+	}
+
+	if modes := anySlice(fields["defaultInputModes"]); modes != nil {
+		b.modes["x-a2a-input-modes"] = modes
+	}
+
+	if modes := anySlice(fields["defaultOutputModes"]); modes != nil {
+		b.modes["x-a2a-output-modes"] = modes
+	}
+
+	for _, iface := range CardInterfaces(card) {
+		u, err := url.Parse(iface.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		server := u.Scheme + "://" + u.Host
+		base := strings.TrimSuffix(u.Path, "/")
+
+		switch iface.ProtocolBinding {
+		case A2ABindingJSONRPC:
+			b.addJSONRPC(server, base)
+		case A2ABindingHTTPJSON:
+			b.addREST(server, base)
+		}
+	}
+
+	if len(b.paths) == 0 {
+		return nil, fmt.Errorf("%w: A2A card has no JSONRPC or HTTP+JSON interface with an HTTP URL", ErrInvalidInput)
+	}
+
+	for _, server := range b.servers {
+		b.addOperation(agentCardPath, server.(map[string]any)["url"].(string), "get", map[string]any{
+			"operationId": "getAgentCard",
+			"summary":     "Get the agent card",
+			"security":    []any{},
+			"responses":   jsonResponse("The agent card", "AgentCard"),
+		})
+	}
+
+	doc := map[string]any{
+		"openapi": OpenAPIVersion,
+		"info":    openAPIInfo(record, fields),
+		"servers": b.servers,
+		"paths":   b.paths,
+		"components": map[string]any{
+			"schemas": a2aSchemas(),
+		},
+	}
+
+	if len(b.tags) > 0 {
+		doc["tags"] = b.tags
+	}
+
+	if schemes := openAPISecuritySchemes(fields["securitySchemes"]); len(schemes) > 0 {
+		doc["components"].(map[string]any)["securitySchemes"] = schemes
+	}
+
+	if len(b.security) > 0 {
+		doc["security"] = b.security
+	}
+
+	out, err := structpb.NewStruct(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI document: %w", err)
+	}
+
+	return out, nil
+}
+
+// addServer adds a server URL to the document, once.
+func (b *openAPIBuilder) addServer(server string) {
+	for _, existing := range b.servers {
+		if existing.(map[string]any)["url"] == server {
+			return
+		}
+	}
+
+	b.servers = append(b.servers, map[string]any{"url": server})
+}
+
+// addOperation adds an operation served by the server at the path. When
+// interfaces of several servers share the path, the operation of the first
+// one is kept and the path lists all their servers.
+func (b *openAPIBuilder) addOperation(path, server, method string, operation map[string]any) {
+	b.addServer(server)
+
+	item, ok := b.paths[path].(map[string]any)
+	if !ok {
+		item = map[string]any{"servers": []any{}}
+		b.paths[path] = item
+	}
+
+	item["servers"] = append(item["servers"].([]any), map[string]any{"url": server})
+
+	if _, ok := item[method]; !ok {
+		item[method] = operation
+	}
+}
+
+// operationID returns id, suffixed when an interface already used it.
+func (b *openAPIBuilder) operationID(id string) string {
+	b.operations[id]++
+	if n := b.operations[id]; n > 1 {
+		return id + strconv.Itoa(n)
+	}
+
+	return id
+}
+
+// messageOperation returns an operation sending messages, with the skill
+// tags and modes of the card.
+func (b *openAPIBuilder) messageOperation(operation map[string]any) map[string]any {
+	if len(b.tags) > 0 {
+		names := make([]any, 0, len(b.tags))
+		for _, tag := range b.tags {
+			names = append(names, tag.(map[string]any)["name"])
+		}
+
+		operation["tags"] = names
+	}
+
+	maps.Copy(operation, b.modes)
+
+	return operation
+}
+
+// addJSONRPC adds the operation of a JSONRPC interface.
+func (b *openAPIBuilder) addJSONRPC(server, base string) {
+	methods := []any{"message/send", "tasks/get", "tasks/cancel"}
+	responses := jsonResponse("The JSON-RPC response", "JSONRPCResponse")
+
+	if b.streaming {
+		methods = append(methods, "message/stream", "tasks/resubscribe")
+		responses["200"].(map[string]any)["content"].(map[string]any)["text/event-stream"] = map[string]any{
+			"schema": schemaRef("JSONRPCResponse"),
+		}
+	}
+
+	path := base
+	if path == "" {
+		path = "/"
+	}
+
+	b.addOperation(path, server, "post", b.messageOperation(map[string]any{
+		"operationId": b.operationID("jsonRpc"),
+		"summary":     "Call an A2A JSON-RPC method",
+		"requestBody": map[string]any{
+			"required": true,
+			"content": map[string]any{"application/json": map[string]any{"schema": map[string]any{
+				"allOf": []any{
+					schemaRef("JSONRPCRequest"),
+					map[string]any{"properties": map[string]any{"method": map[string]any{"enum": methods}}},
+				},
+			}}},
+		},
+		"responses": responses,
+	}))
+}
+
+// addREST adds the operations of an HTTP+JSON interface.
+func (b *openAPIBuilder) addREST(server, base string) {
+	send := map[string]any{
+		"required": true,
+		"content":  map[string]any{"application/json": map[string]any{"schema": schemaRef("SendMessageRequest")}},
+	}
+	taskID := []any{map[string]any{"name": "id", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}}
+
+	b.addOperation(base+"/v1/message:send", server, "post", b.messageOperation(map[string]any{
+		"operationId": b.operationID("sendMessage"),
+		"summary":     "Send a message to the agent",
+		"requestBody": send,
+		"responses":   jsonResponse("The task or message the agent replied with", "SendMessageResponse"),
+	}))
+
+	if b.streaming {
+		b.addOperation(base+"/v1/message:stream", server, "post", b.messageOperation(map[string]any{
+			"operationId": b.operationID("streamMessage"),
+			"summary":     "Send a message and stream the updates of its task",
+			"requestBody": send,
+			"responses": map[string]any{"200": map[string]any{
+				"description": "Server-sent events of the task",
+				"content":     map[string]any{"text/event-stream": map[string]any{"schema": schemaRef("SendMessageResponse")}},
+			}},
+		}))
+	}
+
+	b.addOperation(base+"/v1/tasks/{id}", server, "get", map[string]any{
+		"operationId": b.operationID("getTask"),
+		"summary":     "Get a task",
+		"parameters":  taskID,
+		"responses":   jsonResponse("The task", "Task"),
+	})
+
+	b.addOperation(base+"/v1/tasks/{id}:cancel", server, "post", map[string]any{
+		"operationId": b.operationID("cancelTask"),
+		"summary":     "Cancel a task",
+		"parameters":  taskID,
+		"responses":   jsonResponse("The canceled task", "Task"),
+	})
+}
+
+// openAPIInfo returns the info object of the document: the name and
+// description of the card, and its version or else that of the record.
+func openAPIInfo(record *structpb.Struct, card map[string]any) map[string]any {
+	title, _ := card["name"].(string)
+	if title == "" {
+		title = record.GetFields()["name"].GetStringValue()
+	}
+
+	version, _ := card["version"].(string)
+	if version == "" {
+		version = record.GetFields()["version"].GetStringValue()
+	}
+
+	if version == "" {
+		version = "0.0.0"
+	}
+
+	info := map[string]any{"title": title, "version": version}
+	if description, _ := card["description"].(string); description != "" {
+		info["description"] = description
+	}
+
+	return info
+}
+
+// skillTags returns a tag per skill of the card, named after the skill.
+func skillTags(card map[string]any) []any {
+	var tags []any
+
+	for _, skill := range anySlice(card["skills"]) {
+		skill, ok := skill.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		name, _ := skill["name"].(string)
+		if name == "" {
+			name, _ = skill["id"].(string)
+		}
+
+		if name == "" {
+			continue
+		}
+
+		tag := map[string]any{"name": name}
+		if description, _ := skill["description"].(string); description != "" {
+			tag["description"] = description
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// openAPISecuritySchemes returns the security schemes of the card as OpenAPI
+// ones. The schemes of A2A 0.x cards already are; the wrapped schemes of
+// A2A 1.0 cards (e.g. {"apiKeySecurityScheme": {...}}) are unwrapped.
+func openAPISecuritySchemes(value any) map[string]any {
+	schemes, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	out := make(map[string]any, len(schemes))
+
+	for _, name := range slices.Sorted(maps.Keys(schemes)) {
+		scheme, ok := schemes[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for wrapper, schemeType := range a2aSecuritySchemeTypes {
+			if inner, ok := scheme[wrapper].(map[string]any); ok && len(scheme) == 1 {
+				scheme = maps.Clone(inner)
+				scheme["type"] = schemeType
+			}
+		}
+
+		out[name] = scheme
+	}
+
+	return out
+}
+
+// jsonResponse returns the responses of an operation replying with a JSON
+// object of the schema.
+func jsonResponse(description, schema string) map[string]any {
+	return map[string]any{"200": map[string]any{
+		"description": description,
+		"content":     map[string]any{"application/json": map[string]any{"schema": schemaRef(schema)}},
+	}}
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// a2aSchemas returns the component schemas of the operations. They describe
+// the envelope of A2A objects, not every field.
+func a2aSchemas() map[string]any {
+	object := func(required []any, properties map[string]any) map[string]any {
+		schema := map[string]any{"type": "object", "properties": properties}
+		if required != nil {
+			schema["required"] = required
+		}
+
+		return schema
+	}
+	str := map[string]any{"type": "string"}
+
+	return map[string]any{
+		"AgentCard": object([]any{"name"}, map[string]any{"name": str}),
+		"Message": object([]any{"role", "parts"}, map[string]any{
+			"role":      map[string]any{"type": "string", "enum": []any{"user", "agent"}},
+			"parts":     map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+			"messageId": str,
+		}),
+		"Task": object([]any{"id", "status"}, map[string]any{
+			"id":        str,
+			"contextId": str,
+			"status":    map[string]any{"type": "object"},
+			"artifacts": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+		}),
+		"SendMessageRequest": object([]any{"message"}, map[string]any{
+			"message":       schemaRef("Message"),
+			"configuration": map[string]any{"type": "object"},
+		}),
+		"SendMessageResponse": map[string]any{"oneOf": []any{schemaRef("Task"), schemaRef("Message")}},
+		"JSONRPCRequest": object([]any{"jsonrpc", "method"}, map[string]any{
+			"jsonrpc": map[string]any{"const": "2.0"},
+			"id":      map[string]any{"type": []any{"string", "integer"}},
+			"method":  str,
+			"params":  map[string]any{"type": "object"},
+		}),
+		"JSONRPCResponse": object([]any{"jsonrpc"}, map[string]any{
+			"jsonrpc": map[string]any{"const": "2.0"},
+			"id":      map[string]any{"type": []any{"string", "integer", "null"}},
+			"result":  map[string]any{},
+			"error":   object([]any{"code", "message"}, map[string]any{"code": map[string]any{"type": "integer"}, "message": str}),
+		}),
+	}
+}
+
+// anySlice returns value as a list, or nil when it is not one.
+func anySlice(value any) []any {
+	list, _ := value.([]any)
+
+	return list
+}
@@ -0,0 +1,149 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func a2aRecord(t *testing.T, card map[string]any) *structpb.Struct {
+	t.Helper()
+
+	input, err := structpb.NewStruct(card)
+	if err != nil {
+		t.Fatalf("failed to build card: %v", err)
+	}
+
+	record, err := translator.A2AToRecord(input)
+	if err != nil {
+		t.Fatalf("A2AToRecord() error: %v", err)
+	}
+
+	return record
+}
+
+func TestRecordToOpenAPI(t *testing.T) {
+	record := a2aRecord(t, map[string]any{
+		"name":               "travel-agent",
+		"description":        "Plans trips.",
+		"version":            "2.1.0",
+		"capabilities":       map[string]any{"streaming": true},
+		"defaultInputModes":  []any{"text/plain"},
+		"defaultOutputModes": []any{"text/plain", "application/json"},
+		"skills": []any{
+			map[string]any{"id": "flights", "name": "Flight search", "description": "Finds flights."},
+			map[string]any{"id": "hotels"},
+		},
+		"supportedInterfaces": []any{
+			map[string]any{"url": "https://travel.example.org/a2a/", "protocolBinding": "JSONRPC"},
+			map[string]any{"url": "https://api.travel.example.org", "protocolBinding": "HTTP+JSON"},
+			map[string]any{"url": "travel.example.org:50051", "protocolBinding": "GRPC"},
+		},
+		"securitySchemes": map[string]any{
+			"bearer": map[string]any{"httpAuthSecurityScheme": map[string]any{"scheme": "Bearer"}},
+			"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		},
+		"security": []any{map[string]any{"bearer": []any{}}},
+	})
+
+	doc, err := translator.RecordToOpenAPI(record)
+	if err != nil {
+		t.Fatalf("RecordToOpenAPI() error: %v", err)
+	}
+
+	fields := doc.AsMap()
+
+	info := fields["info"].(map[string]any)
+	if fields["openapi"] != translator.OpenAPIVersion || info["title"] != "travel-agent" || info["version"] != "2.1.0" {
+		t.Errorf("openapi = %v, info = %v", fields["openapi"], info)
+	}
+
+	paths := fields["paths"].(map[string]any)
+
+	var got []string
+	for path := range paths {
+		got = append(got, path)
+	}
+
+	slices.Sort(got)
+
+	want := []string{
+		"/.well-known/agent-card.json", "/a2a", "/v1/message:send", "/v1/message:stream", "/v1/tasks/{id}", "/v1/tasks/{id}:cancel",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+
+	jsonRPC := paths["/a2a"].(map[string]any)
+	if servers := jsonRPC["servers"].([]any); len(servers) != 1 || servers[0].(map[string]any)["url"] != "https://travel.example.org" {
+		t.Errorf("servers = %v, want the server of the JSONRPC interface", servers)
+	}
+
+	send := paths["/v1/message:send"].(map[string]any)["post"].(map[string]any)
+	if tags := send["tags"].([]any); !slices.Equal(tags, []any{"Flight search", "hotels"}) {
+		t.Errorf("tags = %v, want the skills", tags)
+	}
+
+	if modes := send["x-a2a-output-modes"].([]any); len(modes) != 2 {
+		t.Errorf("x-a2a-output-modes = %v, want the default output modes", modes)
+	}
+
+	if card := paths["/.well-known/agent-card.json"].(map[string]any); len(card["servers"].([]any)) != 2 {
+		t.Errorf("agent card servers = %v, want both HTTP servers", card["servers"])
+	}
+
+	schemes := fields["components"].(map[string]any)["securitySchemes"].(map[string]any)
+	if bearer := schemes["bearer"].(map[string]any); bearer["type"] != "http" || bearer["scheme"] != "Bearer" {
+		t.Errorf("bearer = %v, want the unwrapped http scheme", bearer)
+	}
+
+	if apiKey := schemes["apiKey"].(map[string]any); apiKey["in"] != "header" {
+		t.Errorf("apiKey = %v, want the scheme as is", apiKey)
+	}
+
+	if len(fields["security"].([]any)) != 1 {
+		t.Errorf("security = %v, want the requirements of the card", fields["security"])
+	}
+}
+
+func TestRecordToOpenAPILegacyCard(t *testing.T) {
+	doc, err := translator.RecordToOpenAPI(a2aRecord(t, map[string]any{
+		"name": "example-agent",
+		"url":  "http://localhost:8000",
+	}))
+	if err != nil {
+		t.Fatalf("RecordToOpenAPI() error: %v", err)
+	}
+
+	paths := doc.AsMap()["paths"].(map[string]any)
+
+	post, ok := paths["/"].(map[string]any)["post"].(map[string]any)
+	if !ok {
+		t.Fatalf("paths = %v, want a JSON-RPC operation at /", paths)
+	}
+
+	if _, ok := post["tags"]; ok || post["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["text/event-stream"] != nil {
+		t.Errorf("operation = %v, want no tags and no stream without skills and streaming", post)
+	}
+}
+
+func TestRecordToOpenAPIErrors(t *testing.T) {
+	grpcOnly := a2aRecord(t, map[string]any{
+		"name":                "grpc-agent",
+		"supportedInterfaces": []any{map[string]any{"url": "agent.example.org:50051", "protocolBinding": "GRPC"}},
+	})
+
+	if _, err := translator.RecordToOpenAPI(grpcOnly); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("gRPC only: error = %v, want ErrInvalidInput", err)
+	}
+
+	if _, err := translator.RecordToOpenAPI(&structpb.Struct{}); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("no module: error = %v, want ErrModuleNotFound", err)
+	}
+}
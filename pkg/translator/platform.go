@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Platform is the operating system of the workstation a client configuration
+// is generated for. It decides how the commands of stdio servers are written.
+type Platform string
+
+const (
+	// PlatformPOSIX is Linux, macOS and other POSIX systems, whose commands
+	// are those of the record. It is the default.
+	PlatformPOSIX Platform = "posix"
+	// PlatformWindows is Windows, whose commands are rewritten to run
+	// without a shell; see windowsCommand.
+	PlatformWindows Platform = "windows"
+)
+
+// windowsCommands are the commands that are batch scripts or have another
+// name on Windows. Clients start servers without a shell, which does not
+// find scripts such as npx without their .cmd extension.
+var windowsCommands = map[string]string{
+	"npx":     "npx.cmd",
+	"npm":     "npm.cmd",
+	"pnpm":    "pnpm.cmd",
+	"yarn":    "yarn.cmd",
+	"python":  "py",
+	"python3": "py",
+}
+
+// ClientConfigOption configures RecordToClaudeDesktop, RecordToCursor and
+// RecordToVSCodeMCP.
+type ClientConfigOption func(*clientConfigOptions)
+
+type clientConfigOptions struct {
+	platform     Platform
+	pythonRunner PythonRunner
+}
+
+// WithPlatform generates the commands of stdio servers for the platform of
+// the workstation; the default is PlatformPOSIX.
+func WithPlatform(platform Platform) ClientConfigOption {
+	return func(o *clientConfigOptions) {
+		o.platform = platform
+	}
+}
+
+func newClientConfigOptions(opts []ClientConfigOption) (*clientConfigOptions, error) {
+	options := &clientConfigOptions{platform: PlatformPOSIX}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := options.platform.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := options.pythonRunner.validate(); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func (p Platform) validate() error {
+	switch p {
+	case PlatformPOSIX, PlatformWindows:
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported platform %q", ErrInvalidInput, p)
+	}
+}
+
+// command returns the command and arguments of a stdio server on the
+// platform.
+func (p Platform) command(command string, args []string) (string, []string) {
+	if p != PlatformWindows {
+		return command, args
+	}
+
+	return windowsCommand(command, args)
+}
+
+// windowsCommand rewrites a command for Windows: batch scripts such as npx
+// take their .cmd extension, python is run with the py launcher, and the
+// separators of the relative and absolute paths of the arguments become
+// backslashes. Arguments with a colon, such as URLs and Docker volumes, are
+// left as they are, and so are the arguments of docker and podman, whose
+// paths are those of the Linux container (-w /app).
+func windowsCommand(command string, args []string) (string, []string) {
+	if isContainerRuntime(command) {
+		return command, args
+	}
+
+	if windows, ok := windowsCommands[command]; ok {
+		command = windows
+	}
+
+	if args == nil {
+		return command, nil
+	}
+
+	out := make([]string, len(args))
+	for i, arg := range args {
+		out[i] = arg
+
+		if isPOSIXPath(arg) {
+			out[i] = strings.ReplaceAll(arg, "/", `\`)
+		}
+	}
+
+	return command, out
+}
+
+// isPOSIXPath reports whether the argument is a relative ("./", "../") or
+// absolute path.
+func isPOSIXPath(arg string) bool {
+	if strings.Contains(arg, ":") || strings.HasPrefix(arg, "//") {
+		return false
+	}
+
+	return strings.HasPrefix(arg, "./") || strings.HasPrefix(arg, "../") || strings.HasPrefix(arg, "/")
+}
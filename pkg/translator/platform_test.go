@@ -0,0 +1,136 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func stdioRecord(t *testing.T, command string, args ...any) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "files",
+					"connections": []any{
+						map[string]any{"type": "stdio", "command": command, "args": args},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	return record
+}
+
+func TestWithPlatformWindows(t *testing.T) {
+	tests := []struct {
+		command     string
+		args        []any
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			command:     "npx",
+			args:        []any{"-y", "@modelcontextprotocol/server-filesystem", "/tmp", "./data/files"},
+			wantCommand: "npx.cmd",
+			wantArgs:    []string{"-y", "@modelcontextprotocol/server-filesystem", `\tmp`, `.\data\files`},
+		},
+		{
+			command:     "python",
+			args:        []any{"-m", "mcp_server", "--root", "../shared", "--url", "http://localhost:8080/mcp"},
+			wantCommand: "py",
+			wantArgs:    []string{"-m", "mcp_server", "--root", `..\shared`, "--url", "http://localhost:8080/mcp"},
+		},
+		{
+			command:     "docker",
+			args:        []any{"run", "-i", "--rm", "-v", "/data:/data", "ghcr.io/example/server"},
+			wantCommand: "docker",
+			wantArgs:    []string{"run", "-i", "--rm", "-v", "/data:/data", "ghcr.io/example/server"},
+		},
+		{
+			command:     "docker",
+			args:        []any{"run", "-i", "--rm", "-w", "/app", "--tmpfs", "/tmp", "ghcr.io/example/server", "--root", "/srv"},
+			wantCommand: "docker",
+			wantArgs:    []string{"run", "-i", "--rm", "-w", "/app", "--tmpfs", "/tmp", "ghcr.io/example/server", "--root", "/srv"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			record := stdioRecord(t, tt.command, tt.args...)
+
+			config, err := translator.RecordToVSCodeMCP(record, translator.WithPlatform(translator.PlatformWindows))
+			if err != nil {
+				t.Fatalf("RecordToVSCodeMCP() error: %v", err)
+			}
+
+			server := config.Servers["files"]
+			if server.Command != tt.wantCommand || !slices.Equal(server.Args, tt.wantArgs) {
+				t.Errorf("server = %s %q, want %s %q", server.Command, server.Args, tt.wantCommand, tt.wantArgs)
+			}
+
+			posix, err := translator.RecordToVSCodeMCP(record)
+			if err != nil {
+				t.Fatalf("RecordToVSCodeMCP() error: %v", err)
+			}
+
+			if server := posix.Servers["files"]; server.Command != tt.command {
+				t.Errorf("default command = %q, want %q", server.Command, tt.command)
+			}
+		})
+	}
+}
+
+func TestWithPlatformWindowsRemoteBridge(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name":        "weather-mcp-server",
+					"connections": []any{map[string]any{"type": "sse", "url": "https://weather.example.org/sse"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToClaudeDesktop(record, translator.WithPlatform(translator.PlatformWindows))
+	if err != nil {
+		t.Fatalf("RecordToClaudeDesktop() error: %v", err)
+	}
+
+	want := []string{"-y", "mcp-remote", "https://weather.example.org/sse"}
+	if server := config.MCPServers["weather"]; server.Command != "npx.cmd" || !slices.Equal(server.Args, want) {
+		t.Errorf("server = %+v, want an mcp-remote bridge started with npx.cmd", server)
+	}
+}
+
+func TestWithPlatformUnsupported(t *testing.T) {
+	record := stdioRecord(t, "npx", "-y", "server")
+
+	if _, err := translator.RecordToCursor(record, translator.WithPlatform("plan9")); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("error = %v, want ErrInvalidInput", err)
+	}
+
+	if _, err := translator.RecordToGHCopilot(record, translator.WithGHCopilotPlatform("plan9")); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("error = %v, want ErrInvalidInput", err)
+	}
+}
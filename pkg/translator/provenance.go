@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Provenance holds the defaults the reverse translators use for the
+// provenance a source does not carry, e.g. an MCP server.json without a
+// namespace or a SKILL.md without authors.
+type Provenance struct {
+	// Authors are the authors of records whose source has none. WithAuthors
+	// and the authors of the source take precedence.
+	Authors []string
+	// Organization is the author when Authors is empty, as
+	// "Organization <Contact>" when Contact is set.
+	Organization string
+	Contact      string
+	// FallbackLocator is the URL of the source_code locator added to records
+	// whose source has no locator. Empty omits the locators.
+	FallbackLocator string
+}
+
+// WithProvenance sets the provenance defaults of the reverse translators.
+// Without it, records whose source has no authors are authored by
+// "Unknown" and have no locators.
+func WithProvenance(provenance Provenance) TranslatorOption {
+	return func(opts *translatorOptions) {
+		opts.provenance = provenance
+	}
+}
+
+// defaultAuthors returns the authors of records whose source has none.
+func (p Provenance) defaultAuthors() []string {
+	if authors := nonEmptyAuthors(p.Authors); len(authors) > 0 {
+		return authors
+	}
+
+	switch {
+	case p.Organization != "" && p.Contact != "":
+		return []string{p.Organization + " <" + p.Contact + ">"}
+	case p.Organization != "":
+		return []string{p.Organization}
+	default:
+		return []string{defaultAuthor}
+	}
+}
+
+// addFallbackLocator adds the fallback locator to a record without locators.
+func (p Provenance) addFallbackLocator(record *structpb.Struct) error {
+	if p.FallbackLocator == "" || len(record.GetFields()["locators"].GetListValue().GetValues()) > 0 {
+		return nil
+	}
+
+	if err := recordutil.AddLocator(record, recordutil.LocatorSourceCode, p.FallbackLocator); err != nil {
+		return fmt.Errorf("%w: fallback locator: %w", ErrInvalidInput, err)
+	}
+
+	return nil
+}
+
+// ApplyProvenance applies the provenance defaults to a record generated
+// without them, e.g. by a server without provenance configuration: the
+// "Unknown" author the translators fall back to is replaced with the default
+// authors, and a record without locators gets the fallback locator.
+func ApplyProvenance(record *structpb.Struct, provenance Provenance) error {
+	if record == nil {
+		return fmt.Errorf("%w: record is nil", ErrInvalidInput)
+	}
+
+	authors := record.GetFields()["authors"].GetListValue().GetValues()
+	if len(authors) == 0 || (len(authors) == 1 && authors[0].GetStringValue() == defaultAuthor) {
+		defaults := provenance.defaultAuthors()
+
+		values := make([]*structpb.Value, 0, len(defaults))
+		for _, author := range defaults {
+			values = append(values, structpb.NewStringValue(author))
+		}
+
+		record.Fields["authors"] = structpb.NewListValue(&structpb.ListValue{Values: values})
+	}
+
+	return provenance.addFallbackLocator(record)
+}
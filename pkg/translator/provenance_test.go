@@ -0,0 +1,124 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func recordAuthors(record *structpb.Struct) []string {
+	var authors []string
+	for _, value := range record.GetFields()["authors"].GetListValue().GetValues() {
+		authors = append(authors, value.GetStringValue())
+	}
+
+	return authors
+}
+
+func TestWithProvenance_Authors(t *testing.T) {
+	cases := []struct {
+		name       string
+		provenance translator.Provenance
+		want       []string
+	}{
+		{"none", translator.Provenance{}, []string{"Unknown"}},
+		{"authors", translator.Provenance{Authors: []string{"Platform Team"}, Organization: "ACME Corp"}, []string{"Platform Team"}},
+		{"organization", translator.Provenance{Organization: "ACME Corp"}, []string{"ACME Corp"}},
+		{"organization with contact", translator.Provenance{Organization: "ACME Corp", Contact: "agents@acme.example"}, []string{"ACME Corp <agents@acme.example>"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			record, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithProvenance(tc.provenance))
+			if err != nil {
+				t.Fatalf("A2AToRecord: %v", err)
+			}
+
+			if got := recordAuthors(record); !slices.Equal(got, tc.want) {
+				t.Errorf("authors = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithProvenance_SourceAuthorsTakePrecedence(t *testing.T) {
+	input := minimalMCPInput(t, nil) // authored by its "example" namespace
+
+	record, err := translator.MCPToRecord(input, translator.WithProvenance(translator.Provenance{Organization: "ACME Corp"}))
+	if err != nil {
+		t.Fatalf("MCPToRecord: %v", err)
+	}
+
+	if got := recordAuthors(record); !slices.Equal(got, []string{"example"}) {
+		t.Errorf("authors = %v, want those of the source", got)
+	}
+}
+
+func TestWithProvenance_FallbackLocator(t *testing.T) {
+	provenance := translator.Provenance{FallbackLocator: "https://github.com/acme/agents"}
+
+	record, err := translator.MCPToRecord(minimalMCPInput(t, nil), translator.WithProvenance(provenance))
+	if err != nil {
+		t.Fatalf("MCPToRecord: %v", err)
+	}
+
+	if got := recordutil.LocatorURLs(record, recordutil.LocatorSourceCode); !slices.Equal(got, []string{provenance.FallbackLocator}) {
+		t.Errorf("source_code locators = %v, want the fallback locator", got)
+	}
+
+	withRepo := minimalMCPInput(t, map[string]any{"repository": map[string]any{"url": "https://github.com/example/my-server", "source": "github"}})
+
+	record, err = translator.MCPToRecord(withRepo, translator.WithProvenance(provenance))
+	if err != nil {
+		t.Fatalf("MCPToRecord: %v", err)
+	}
+
+	if got := recordutil.LocatorURLs(record, recordutil.LocatorSourceCode); !slices.Equal(got, []string{"https://github.com/example/my-server"}) {
+		t.Errorf("source_code locators = %v, want the repository only", got)
+	}
+
+	_, err = translator.A2AToRecord(minimalA2AInput(t), translator.WithProvenance(translator.Provenance{FallbackLocator: "not a url"}))
+	if !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("A2AToRecord() error = %v, want ErrInvalidInput for an invalid fallback locator", err)
+	}
+}
+
+func TestApplyProvenance(t *testing.T) {
+	record, err := translator.A2AToRecord(minimalA2AInput(t))
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	provenance := translator.Provenance{Organization: "ACME Corp", FallbackLocator: "https://github.com/acme/agents"}
+	if err := translator.ApplyProvenance(record, provenance); err != nil {
+		t.Fatalf("ApplyProvenance: %v", err)
+	}
+
+	if got := recordAuthors(record); !slices.Equal(got, []string{"ACME Corp"}) {
+		t.Errorf("authors = %v, want the organization", got)
+	}
+
+	if got := recordutil.LocatorURLs(record, recordutil.LocatorSourceCode); len(got) != 1 {
+		t.Errorf("source_code locators = %v, want the fallback locator", got)
+	}
+
+	authored, err := translator.A2AToRecord(minimalA2AInput(t), translator.WithAuthors([]string{"Example Team"}))
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	if err := translator.ApplyProvenance(authored, provenance); err != nil {
+		t.Fatalf("ApplyProvenance: %v", err)
+	}
+
+	if got := recordAuthors(authored); !slices.Equal(got, []string{"Example Team"}) {
+		t.Errorf("authors = %v, want the authors kept", got)
+	}
+}
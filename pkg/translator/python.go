@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import "fmt"
+
+// PythonRunner is how the stdio commands of Python (PyPI) MCP servers are
+// run.
+type PythonRunner string
+
+const (
+	// PythonRunnerModule runs the installed package as a module:
+	// python -m <package>. It is the default.
+	PythonRunnerModule PythonRunner = "python"
+	// PythonRunnerUVX runs the package in a temporary environment of uv:
+	// uvx <package>.
+	PythonRunnerUVX PythonRunner = "uvx"
+	// PythonRunnerPipx runs the package in a temporary environment of pipx:
+	// pipx run <package>.
+	PythonRunnerPipx PythonRunner = "pipx"
+)
+
+// WithPythonRunner sets how MCPToRecord runs PyPI packages without a
+// runtimeHint; the default is PythonRunnerModule.
+func WithPythonRunner(runner PythonRunner) TranslatorOption {
+	return func(opts *translatorOptions) {
+		opts.pythonRunner = runner
+	}
+}
+
+// WithClientPythonRunner rewrites the python -m <package> commands of stdio
+// servers to run the package with the runner.
+func WithClientPythonRunner(runner PythonRunner) ClientConfigOption {
+	return func(o *clientConfigOptions) {
+		o.pythonRunner = runner
+	}
+}
+
+// WithGHCopilotPythonRunner rewrites the python -m <package> commands of
+// stdio servers to run the package with the runner.
+func WithGHCopilotPythonRunner(runner PythonRunner) GHCopilotOption {
+	return func(o *ghCopilotOptions) {
+		o.pythonRunner = runner
+	}
+}
+
+func (r PythonRunner) validate() error {
+	switch r {
+	case "", PythonRunnerModule, PythonRunnerUVX, PythonRunnerPipx:
+		return nil
+	default:
+		return fmt.Errorf("%w: unsupported Python runner %q", ErrInvalidInput, r)
+	}
+}
+
+// command returns the command running PyPI packages.
+func (r PythonRunner) command() string {
+	if r == "" {
+		return string(PythonRunnerModule)
+	}
+
+	return string(r)
+}
+
+// packageArgs returns the arguments naming a PyPI package of the version
+// ("" for the latest) after the command and its runtime subcommand.
+func (r PythonRunner) packageArgs(identifier, version string) []string {
+	if version == "" {
+		return []string{identifier}
+	}
+
+	switch r {
+	case PythonRunnerUVX:
+		return []string{identifier + "@" + version}
+	case PythonRunnerPipx:
+		return []string{"--spec", identifier + "==" + version, identifier}
+	default:
+		// python -m runs the installed version.
+		return []string{identifier}
+	}
+}
+
+// rewrite rewrites a python -m <package> command to run the package with
+// the runner. Other commands, and python commands running scripts, are
+// returned as they are. The module is taken as the package name, as in the
+// commands MCPToRecord generates for PyPI packages.
+func (r PythonRunner) rewrite(command string, args []string) (string, []string) {
+	if r == "" || r == PythonRunnerModule || (command != "python" && command != "python3") {
+		return command, args
+	}
+
+	if len(args) < 2 || args[0] != "-m" { //nolint:mnd
+		return command, args
+	}
+
+	out := append([]string{}, runtimeSubcommands[r.command()]...)
+
+	return r.command(), append(out, args[1:]...)
+}
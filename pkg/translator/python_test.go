@@ -0,0 +1,174 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+)
+
+func TestMCPToRecord_PythonRunner(t *testing.T) {
+	tests := []struct {
+		name        string
+		runner      translator.PythonRunner
+		pkg         map[string]any
+		wantCommand string
+		wantArgs    []any
+	}{
+		{
+			name:        "module by default",
+			pkg:         map[string]any{"version": "2025.9.25"},
+			wantCommand: "python",
+			wantArgs:    []any{"-m", "mcp-server-time", "--local-timezone", "UTC"},
+		},
+		{
+			name:        "uvx pins the version",
+			runner:      translator.PythonRunnerUVX,
+			pkg:         map[string]any{"version": "2025.9.25"},
+			wantCommand: "uvx",
+			wantArgs:    []any{"mcp-server-time@2025.9.25", "--local-timezone", "UTC"},
+		},
+		{
+			name:        "pipx pins the version",
+			runner:      translator.PythonRunnerPipx,
+			pkg:         map[string]any{"version": "2025.9.25"},
+			wantCommand: "pipx",
+			wantArgs:    []any{"run", "--spec", "mcp-server-time==2025.9.25", "mcp-server-time", "--local-timezone", "UTC"},
+		},
+		{
+			name:        "pipx without a version",
+			runner:      translator.PythonRunnerPipx,
+			pkg:         map[string]any{},
+			wantCommand: "pipx",
+			wantArgs:    []any{"run", "mcp-server-time", "--local-timezone", "UTC"},
+		},
+		{
+			name:        "runtime hint wins",
+			runner:      translator.PythonRunnerPipx,
+			pkg:         map[string]any{"version": "2025.9.25", "runtimeHint": "uvx"},
+			wantCommand: "uvx",
+			wantArgs:    []any{"mcp-server-time", "--local-timezone", "UTC"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := map[string]any{
+				"registryType": "pypi",
+				"identifier":   "mcp-server-time",
+				"transport":    map[string]any{"type": "stdio"},
+				"packageArguments": []any{
+					map[string]any{"type": "named", "name": "--local-timezone", "value": "UTC"},
+				},
+			}
+			for key, value := range tt.pkg {
+				pkg[key] = value
+			}
+
+			record, err := translator.MCPToRecord(minimalMCPInput(t, map[string]any{"packages": []any{pkg}}), translator.WithPythonRunner(tt.runner))
+			if err != nil {
+				t.Fatalf("MCPToRecord() error: %v", err)
+			}
+
+			_, module := recordutil.FindModule(record, translator.MCPModuleName)
+			connection := module.GetFields()["data"].GetStructValue().GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue().AsMap()
+
+			if connection["command"] != tt.wantCommand || !reflect.DeepEqual(connection["args"], tt.wantArgs) {
+				t.Errorf("command = %v %v, want %s %v", connection["command"], connection["args"], tt.wantCommand, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestWithClientPythonRunner(t *testing.T) {
+	tests := []struct {
+		name        string
+		runner      translator.PythonRunner
+		command     string
+		args        []any
+		wantCommand string
+		wantArgs    []string
+	}{
+		{
+			name:        "uvx",
+			runner:      translator.PythonRunnerUVX,
+			command:     "python",
+			args:        []any{"-m", "mcp_server_time", "--local-timezone", "UTC"},
+			wantCommand: "uvx",
+			wantArgs:    []string{"mcp_server_time", "--local-timezone", "UTC"},
+		},
+		{
+			name:        "pipx",
+			runner:      translator.PythonRunnerPipx,
+			command:     "python3",
+			args:        []any{"-m", "mcp_server_time"},
+			wantCommand: "pipx",
+			wantArgs:    []string{"run", "mcp_server_time"},
+		},
+		{
+			name:        "script is left as it is",
+			runner:      translator.PythonRunnerUVX,
+			command:     "python",
+			args:        []any{"server.py"},
+			wantCommand: "python",
+			wantArgs:    []string{"server.py"},
+		},
+		{
+			name:        "other commands are left as they are",
+			runner:      translator.PythonRunnerUVX,
+			command:     "npx",
+			args:        []any{"-y", "@example/server"},
+			wantCommand: "npx",
+			wantArgs:    []string{"-y", "@example/server"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := translator.RecordToClaudeDesktop(stdioRecord(t, tt.command, tt.args...), translator.WithClientPythonRunner(tt.runner))
+			if err != nil {
+				t.Fatalf("RecordToClaudeDesktop() error: %v", err)
+			}
+
+			server := config.MCPServers["files"]
+			if server.Command != tt.wantCommand || !reflect.DeepEqual(server.Args, tt.wantArgs) {
+				t.Errorf("command = %s %v, want %s %v", server.Command, server.Args, tt.wantCommand, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPythonRunnerOnWindows(t *testing.T) {
+	// The runner is applied before the platform: uvx needs no launcher.
+	config, err := translator.RecordToVSCodeMCP(stdioRecord(t, "python", "-m", "mcp_server_time"),
+		translator.WithClientPythonRunner(translator.PythonRunnerUVX), translator.WithPlatform(translator.PlatformWindows))
+	if err != nil {
+		t.Fatalf("RecordToVSCodeMCP() error: %v", err)
+	}
+
+	if server := config.Servers["files"]; server.Command != "uvx" {
+		t.Errorf("command = %q, want uvx", server.Command)
+	}
+}
+
+func TestPythonRunnerInvalid(t *testing.T) {
+	if _, err := translator.RecordToCursor(stdioRecord(t, "python", "-m", "server"), translator.WithClientPythonRunner("conda")); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("RecordToCursor() error = %v, want ErrInvalidInput", err)
+	}
+
+	if _, err := translator.RecordToGHCopilot(stdioRecord(t, "python", "-m", "server"), translator.WithGHCopilotPythonRunner("conda")); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("RecordToGHCopilot() error = %v, want ErrInvalidInput", err)
+	}
+
+	input := minimalMCPInput(t, map[string]any{"packages": []any{
+		map[string]any{"registryType": "pypi", "identifier": "server", "transport": map[string]any{"type": "stdio"}},
+	}})
+	if _, err := translator.MCPToRecord(input, translator.WithPythonRunner("conda")); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("MCPToRecord() error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SecretStoreKind is a secrets manager generated configs can reference.
+type SecretStoreKind string
+
+const (
+	// SecretStoreVault references HashiCorp Vault secrets as
+	// "vault:<path>#<key>", the form resolved by vault-env and the Vault
+	// agent injector.
+	SecretStoreVault SecretStoreKind = "vault"
+	// SecretStoreOnePassword references 1Password items as
+	// "op://<vault>/<item>/<key>", resolved by "op run".
+	SecretStoreOnePassword SecretStoreKind = "1password"
+	// SecretStoreKubernetes references the keys of a Kubernetes Secret. It has
+	// no string form; manifest targets render it as a secretKeyRef.
+	SecretStoreKubernetes SecretStoreKind = "kubernetes"
+)
+
+// SecretStore is where the secrets of generated configs are kept. Translators
+// given one emit references to its secrets, named after the environment
+// variables, instead of ${input:...} prompts.
+type SecretStore struct {
+	Kind SecretStoreKind
+	// Path locates the secrets in the store: the Vault secret path (e.g.
+	// secret/data/agents/github), the 1Password "<vault>/<item>", or the
+	// name of the Kubernetes Secret.
+	Path string
+}
+
+// ParseSecretStore parses a "<kind>:<path>" secret store spec, e.g.
+// "vault:secret/data/agents/github" or "1password:Production/github".
+func ParseSecretStore(spec string) (SecretStore, error) {
+	kind, path, ok := strings.Cut(spec, ":")
+
+	store := SecretStore{Kind: SecretStoreKind(kind), Path: strings.Trim(path, "/")}
+	if !ok {
+		return SecretStore{}, fmt.Errorf("%w: invalid secret store %q, want <kind>:<path>", ErrInvalidInput, spec)
+	}
+
+	if err := store.validate(); err != nil {
+		return SecretStore{}, err
+	}
+
+	return store, nil
+}
+
+func (s SecretStore) validate() error {
+	switch s.Kind {
+	case SecretStoreVault, SecretStoreKubernetes:
+	case SecretStoreOnePassword:
+		if strings.Count(s.Path, "/") != 1 {
+			return fmt.Errorf("%w: 1Password secret store path %q must be <vault>/<item>", ErrInvalidInput, s.Path)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported secret store %q (want vault, 1password or kubernetes)", ErrInvalidInput, s.Kind)
+	}
+
+	if s.Path == "" {
+		return fmt.Errorf("%w: %s secret store has no path", ErrInvalidInput, s.Kind)
+	}
+
+	return nil
+}
+
+// Reference returns the string reference of the secret key, e.g.
+// "vault:secret/data/agents/github#GITHUB_TOKEN". Kubernetes secrets have no
+// string reference.
+func (s SecretStore) Reference(key string) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+
+	switch s.Kind {
+	case SecretStoreVault:
+		return "vault:" + s.Path + "#" + key, nil
+	case SecretStoreOnePassword:
+		return "op://" + s.Path + "/" + key, nil
+	default:
+		return "", fmt.Errorf("%w: %s secrets can only be referenced from Kubernetes manifests", ErrInvalidInput, s.Kind)
+	}
+}
+
+// SecretKeyRef returns the Kubernetes valueFrom of the secret key, for
+// manifests referencing a SecretStoreKubernetes store.
+func (s SecretStore) SecretKeyRef(key string) map[string]any {
+	return map[string]any{"secretKeyRef": map[string]any{"name": s.Path, "key": key}}
+}
+
+// inputReference matches the ${input:<id>} prompts of generated configs.
+var inputReference = regexp.MustCompile(`\$\{input:([^}]+)\}`)
+
+// referenceSecrets replaces the ${input:<id>} prompts of the value with
+// references to the secrets named <id> in the store. Prompts of inputs that
+// are not secrets, per isSecret, are kept.
+func referenceSecrets(value string, store SecretStore, isSecret func(id string) bool) (string, error) {
+	var err error
+
+	out := inputReference.ReplaceAllStringFunc(value, func(match string) string {
+		id := inputReference.FindStringSubmatch(match)[1]
+		if !isSecret(id) {
+			return match
+		}
+
+		ref, refErr := store.Reference(id)
+		if refErr != nil {
+			err = refErr
+		}
+
+		return ref
+	})
+
+	return out, err
+}
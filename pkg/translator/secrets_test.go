@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSecretStoreReference(t *testing.T) {
+	cases := map[string]string{
+		"vault:secret/data/agents/github": "vault:secret/data/agents/github#TOKEN",
+		"1password:Production/github/":    "op://Production/github/TOKEN",
+	}
+
+	for spec, want := range cases {
+		store, err := translator.ParseSecretStore(spec)
+		if err != nil {
+			t.Fatalf("ParseSecretStore(%q): %v", spec, err)
+		}
+
+		if got, err := store.Reference("TOKEN"); err != nil || got != want {
+			t.Errorf("Reference() of %q = %q, %v, want %q", spec, got, err, want)
+		}
+	}
+
+	store, err := translator.ParseSecretStore("kubernetes:agent-secrets")
+	if err != nil {
+		t.Fatalf("ParseSecretStore: %v", err)
+	}
+
+	if _, err := store.Reference("TOKEN"); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("Reference() of a Kubernetes store error = %v, want ErrInvalidInput", err)
+	}
+
+	ref := store.SecretKeyRef("TOKEN")["secretKeyRef"].(map[string]any)
+	if ref["name"] != "agent-secrets" || ref["key"] != "TOKEN" {
+		t.Errorf("SecretKeyRef() = %v", ref)
+	}
+
+	for _, spec := range []string{"vault", "aws:secret", "vault:", "1password:Production"} {
+		if _, err := translator.ParseSecretStore(spec); !errors.Is(err, translator.ErrInvalidInput) {
+			t.Errorf("ParseSecretStore(%q) error = %v, want ErrInvalidInput", spec, err)
+		}
+	}
+}
+
+func TestRecordToGHCopilotSecrets(t *testing.T) {
+	store := translator.SecretStore{Kind: translator.SecretStoreVault, Path: "secret/data/github"}
+
+	config, err := translator.RecordToGHCopilot(ghCopilotRecord(t), translator.WithGHCopilotSecrets(store))
+	if err != nil {
+		t.Fatalf("RecordToGHCopilot() error: %v", err)
+	}
+
+	env := config.Servers["github"].Env
+	if env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "vault:secret/data/github#GITHUB_PERSONAL_ACCESS_TOKEN" || env["LOG_LEVEL"] != "info" {
+		t.Errorf("env = %v, want secrets referenced in Vault and literals kept", env)
+	}
+
+	if len(config.Inputs) != 0 {
+		t.Errorf("inputs = %v, want none", config.Inputs)
+	}
+
+	_, err = translator.RecordToGHCopilot(ghCopilotRecord(t), translator.WithGHCopilotSecrets(translator.SecretStore{Kind: translator.SecretStoreKubernetes, Path: "s"}))
+	if !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("RecordToGHCopilot() with a Kubernetes store error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestRecordToGHCopilotSecretsKeepPlainInputs(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "typed-server",
+					"connections": []any{
+						map[string]any{
+							"type":    "stdio",
+							"command": "npx",
+							"env_vars": []any{
+								map[string]any{"name": "API_KEY", "secret": true},
+								map[string]any{"name": "REGION", "choices": []any{"eu", "us"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	store := translator.SecretStore{Kind: translator.SecretStoreVault, Path: "secret/data/typed"}
+
+	config, err := translator.RecordToGHCopilot(record, translator.WithGHCopilotSecrets(store))
+	if err != nil {
+		t.Fatalf("RecordToGHCopilot() error: %v", err)
+	}
+
+	env := config.Servers["typed"].Env
+	if env["API_KEY"] != "vault:secret/data/typed#API_KEY" || env["REGION"] != "${input:REGION}" {
+		t.Errorf("env = %v, want the secret referenced and the choice prompted for", env)
+	}
+
+	if len(config.Inputs) != 1 || config.Inputs[0].ID != "REGION" {
+		t.Errorf("inputs = %+v, want the REGION input only", config.Inputs)
+	}
+}
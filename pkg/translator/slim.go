@@ -0,0 +1,144 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/agntcy/oasf-sdk/pkg/annotations"
+	"github.com/agntcy/oasf-sdk/pkg/internal/pb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Annotations holding the SLIM transport descriptor of a record.
+const (
+	SLIMEndpointAnnotation     = "slim.endpoint"
+	SLIMOrganizationAnnotation = "slim.organization"
+	SLIMNamespaceAnnotation    = "slim.namespace"
+)
+
+// SLIMExtensionURI identifies the A2A card extension advertising that the
+// agent is reachable over AGNTCY SLIM. Its params carry the descriptor
+// ("endpoint", "organization", "namespace").
+const SLIMExtensionURI = "https://agntcy.org/a2a/extensions/slim/v1"
+
+// mcpPublisherMetaKey is the server.json _meta key of publisher-provided
+// metadata, where MCP servers routed over SLIM carry a "slim" descriptor.
+const mcpPublisherMetaKey = "io.modelcontextprotocol.registry/publisher-provided"
+
+// SLIMDescriptor locates an agent on AGNTCY SLIM (Secure Low-latency
+// Interactive Messaging): the SLIM node to connect to and the
+// organization/namespace the agent is routed under.
+type SLIMDescriptor struct {
+	Endpoint     string `json:"endpoint"`
+	Organization string `json:"organization"`
+	Namespace    string `json:"namespace"`
+}
+
+// Validate checks that every field of the descriptor is set.
+func (d SLIMDescriptor) Validate() error {
+	if d.Endpoint == "" || d.Organization == "" || d.Namespace == "" {
+		return fmt.Errorf("%w: SLIM descriptor requires endpoint, organization and namespace", ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// RecordSLIM returns the SLIM descriptor of a record, and whether the record
+// has a complete one.
+func RecordSLIM(record *structpb.Struct) (SLIMDescriptor, bool) {
+	d := SLIMDescriptor{}
+	d.Endpoint, _ = annotations.Get(record, SLIMEndpointAnnotation)
+	d.Organization, _ = annotations.Get(record, SLIMOrganizationAnnotation)
+	d.Namespace, _ = annotations.Get(record, SLIMNamespaceAnnotation)
+
+	return d, d.Validate() == nil
+}
+
+// SetRecordSLIM stores a SLIM descriptor in the record annotations.
+func SetRecordSLIM(record *structpb.Struct, d SLIMDescriptor) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	if err := annotations.SetAll(record, map[string]string{
+		SLIMEndpointAnnotation:     d.Endpoint,
+		SLIMOrganizationAnnotation: d.Organization,
+		SLIMNamespaceAnnotation:    d.Namespace,
+	}); err != nil {
+		return fmt.Errorf("failed to annotate record: %w", err)
+	}
+
+	return nil
+}
+
+// slimFromStruct reads a descriptor from an object with "endpoint",
+// "organization" and "namespace" fields.
+func slimFromStruct(s *structpb.Struct) (SLIMDescriptor, bool) {
+	fields := s.GetFields()
+	d := SLIMDescriptor{
+		Endpoint:     fields["endpoint"].GetStringValue(),
+		Organization: fields["organization"].GetStringValue(),
+		Namespace:    fields["namespace"].GetStringValue(),
+	}
+
+	return d, d.Validate() == nil
+}
+
+// cardSLIM returns the descriptor of the SLIM extension of an A2A card.
+func cardSLIM(card *structpb.Struct) (SLIMDescriptor, bool) {
+	extensions := card.GetFields()["capabilities"].GetStructValue().GetFields()["extensions"].GetListValue()
+
+	for _, ext := range extensions.GetValues() {
+		fields := ext.GetStructValue().GetFields()
+		if fields["uri"].GetStringValue() == SLIMExtensionURI {
+			return slimFromStruct(fields["params"].GetStructValue())
+		}
+	}
+
+	return SLIMDescriptor{}, false
+}
+
+// withCardSLIM returns a copy of the card advertising the SLIM extension,
+// unless it already does.
+func withCardSLIM(card *structpb.Struct, d SLIMDescriptor) *structpb.Struct {
+	if _, ok := cardSLIM(card); ok {
+		return card
+	}
+
+	out := pb.Struct(make(pb.Fields, len(card.GetFields())+1))
+	maps.Copy(out.Fields, card.GetFields())
+
+	capabilities := pb.Struct(nil)
+	maps.Copy(capabilities.Fields, card.GetFields()["capabilities"].GetStructValue().GetFields())
+
+	extensions := &structpb.ListValue{}
+	if existing := capabilities.GetFields()["extensions"].GetListValue(); existing != nil {
+		extensions.Values = append(extensions.Values, existing.GetValues()...)
+	}
+
+	extensions.Values = append(extensions.Values, pb.Obj(pb.Fields{
+		"uri":         pb.Str(SLIMExtensionURI),
+		"description": pb.Str("Agent reachable over AGNTCY SLIM"),
+		"params": pb.Obj(pb.Fields{
+			"endpoint":     pb.Str(d.Endpoint),
+			"organization": pb.Str(d.Organization),
+			"namespace":    pb.Str(d.Namespace),
+		}),
+	}))
+
+	capabilities.Fields["extensions"] = structpb.NewListValue(extensions)
+	out.Fields["capabilities"] = structpb.NewStructValue(capabilities)
+
+	return out
+}
+
+// mcpServerSLIM returns the descriptor an MCP server.json carries in its
+// publisher-provided _meta.
+func mcpServerSLIM(server *structpb.Struct) (SLIMDescriptor, bool) {
+	meta := server.GetFields()["_meta"].GetStructValue().GetFields()[mcpPublisherMetaKey].GetStructValue()
+
+	return slimFromStruct(meta.GetFields()["slim"].GetStructValue())
+}
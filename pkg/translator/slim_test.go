@@ -0,0 +1,129 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var slimDescriptor = translator.SLIMDescriptor{
+	Endpoint:     "https://slim.example.com:46357",
+	Organization: "example",
+	Namespace:    "agents",
+}
+
+func slimParams() map[string]any {
+	return map[string]any{
+		"endpoint":     slimDescriptor.Endpoint,
+		"organization": slimDescriptor.Organization,
+		"namespace":    slimDescriptor.Namespace,
+	}
+}
+
+func TestA2ASLIMRoundTrip(t *testing.T) {
+	card, err := structpb.NewStruct(map[string]any{
+		"name": "weather-agent",
+		"url":  "https://weather.example.com/a2a",
+		"capabilities": map[string]any{
+			"streaming":  true,
+			"extensions": []any{map[string]any{"uri": translator.SLIMExtensionURI, "params": slimParams()}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := translator.A2AToRecord(card)
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	if got, ok := translator.RecordSLIM(rec); !ok || got != slimDescriptor {
+		t.Errorf("RecordSLIM = %+v, %v", got, ok)
+	}
+
+	back, err := translator.RecordToA2A(rec)
+	if err != nil {
+		t.Fatalf("RecordToA2A: %v", err)
+	}
+
+	if n := len(back.GetFields()["capabilities"].GetStructValue().GetFields()["extensions"].GetListValue().GetValues()); n != 1 {
+		t.Errorf("card has %d extensions, want the original one only", n)
+	}
+}
+
+func TestRecordToA2AAddsSLIMExtension(t *testing.T) {
+	card, _ := structpb.NewStruct(map[string]any{"name": "weather-agent", "capabilities": map[string]any{"streaming": true}})
+
+	rec, err := translator.A2AToRecord(card)
+	if err != nil {
+		t.Fatalf("A2AToRecord: %v", err)
+	}
+
+	if _, ok := translator.RecordSLIM(rec); ok {
+		t.Fatal("record without SLIM extension has a descriptor")
+	}
+
+	if err := translator.SetRecordSLIM(rec, slimDescriptor); err != nil {
+		t.Fatalf("SetRecordSLIM: %v", err)
+	}
+
+	out, err := translator.RecordToA2A(rec)
+	if err != nil {
+		t.Fatalf("RecordToA2A: %v", err)
+	}
+
+	capabilities := out.GetFields()["capabilities"].GetStructValue().GetFields()
+	if !capabilities["streaming"].GetBoolValue() {
+		t.Error("existing capabilities dropped")
+	}
+
+	extensions := capabilities["extensions"].GetListValue().GetValues()
+	if len(extensions) != 1 {
+		t.Fatalf("got %d extensions, want 1", len(extensions))
+	}
+
+	ext := extensions[0].GetStructValue().GetFields()
+	if ext["uri"].GetStringValue() != translator.SLIMExtensionURI ||
+		ext["params"].GetStructValue().GetFields()["namespace"].GetStringValue() != "agents" {
+		t.Errorf("unexpected extension %v", ext)
+	}
+
+	if _, ok := card.GetFields()["capabilities"].GetStructValue().GetFields()["extensions"]; ok {
+		t.Error("input card modified")
+	}
+}
+
+func TestMCPToRecordSLIM(t *testing.T) {
+	data, err := structpb.NewStruct(map[string]any{"server": map[string]any{
+		"name":    "io.github.example/weather",
+		"remotes": []any{map[string]any{"type": "streamable-http", "url": "https://weather.example.com/mcp"}},
+		"_meta": map[string]any{
+			"io.modelcontextprotocol.registry/publisher-provided": map[string]any{"slim": slimParams()},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := translator.MCPToRecord(data)
+	if err != nil {
+		t.Fatalf("MCPToRecord: %v", err)
+	}
+
+	if got, ok := translator.RecordSLIM(rec); !ok || got != slimDescriptor {
+		t.Errorf("RecordSLIM = %+v, %v", got, ok)
+	}
+}
+
+func TestSetRecordSLIMIncomplete(t *testing.T) {
+	rec, _ := structpb.NewStruct(map[string]any{"name": "agent"})
+
+	if err := translator.SetRecordSLIM(rec, translator.SLIMDescriptor{Endpoint: "https://slim.example.com"}); err == nil {
+		t.Error("expected an error for an incomplete descriptor")
+	}
+}
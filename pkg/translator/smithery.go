@@ -0,0 +1,463 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Start command types of smithery.yaml.
+const (
+	// SmitheryStdio servers are started with the command of their
+	// commandFunction and spoken to over stdio.
+	SmitheryStdio = "stdio"
+	// SmitheryHTTP servers are containers serving streamable HTTP, hosted
+	// by Smithery.
+	SmitheryHTTP = "http"
+)
+
+// SmitheryGateway is the URL Smithery serves its hosted servers at, as
+// <gateway>/<name>/mcp.
+const SmitheryGateway = "https://server.smithery.ai"
+
+// Patterns of the parts of the commandFunction of a smithery.yaml that are
+// read: the command, args and env of the object it returns. Literals are
+// single, double or back quoted; "config.<property>" reads the config.
+var (
+	smitheryCommand = regexp.MustCompile(`\bcommand\s*:\s*(?:'([^']*)'|"([^"]*)"|` + "`([^`]*)`)")
+	smitheryArgs    = regexp.MustCompile(`\bargs\s*:\s*\[([^\]]*)\]`)
+	smitheryEnv     = regexp.MustCompile(`\benv\s*:\s*\{([^}]*)\}`)
+	smitheryValue   = regexp.MustCompile(`'([^']*)'|"([^"]*)"|` + "`([^`]*)`" + `|\bconfig\.([A-Za-z_$][\w$]*)`)
+	smitheryEnvPair = regexp.MustCompile(`(?:([A-Za-z_][\w]*)|'([^']+)'|"([^"]+)")\s*:\s*(?:'([^']*)'|"([^"]*)"|` +
+		"`([^`]*)`" + `|\bconfig\.([A-Za-z_$][\w$]*))`)
+)
+
+// SmitheryConfig is a Smithery smithery.yaml.
+type SmitheryConfig struct {
+	// Runtime is "typescript" or "container" for servers Smithery builds;
+	// empty for the legacy Dockerfile builds.
+	Runtime      string               `json:"runtime,omitempty" yaml:"runtime,omitempty"`
+	StartCommand SmitheryStartCommand `json:"startCommand"      yaml:"startCommand"`
+	Build        map[string]any       `json:"build,omitempty"   yaml:"build,omitempty"`
+}
+
+// SmitheryStartCommand is how Smithery starts a server, and the JSON schema
+// of the configuration users give it.
+type SmitheryStartCommand struct {
+	Type         string         `json:"type"                      yaml:"type"`
+	ConfigSchema map[string]any `json:"configSchema,omitempty"    yaml:"configSchema,omitempty"`
+	// CommandFunction is a JavaScript function of the config returning the
+	// command, args and env of stdio servers.
+	CommandFunction string         `json:"commandFunction,omitempty" yaml:"commandFunction,omitempty"`
+	ExampleConfig   map[string]any `json:"exampleConfig,omitempty"   yaml:"exampleConfig,omitempty"`
+}
+
+// YAML renders the configuration as a smithery.yaml document.
+func (c *SmitheryConfig) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2) //nolint:mnd
+
+	if err := encoder.Encode(c); err != nil {
+		return nil, fmt.Errorf("failed to encode smithery.yaml: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode smithery.yaml: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SmitheryToRecord translates a Smithery smithery.yaml into an OASF record
+// with an MCP module. The smithery.yaml has no metadata of its own, so it may
+// be given with that of its repository:
+//
+//	{
+//	  "smithery": {...},                  // the smithery.yaml
+//	  "name": "...",                      // optional
+//	  "description": "...",               // optional
+//	  "version": "...",                   // optional
+//	  "repository": {"url": "..."}        // optional
+//	}
+//
+// A bare smithery.yaml is accepted too. The record is named after the
+// metadata, or else the repository URL, and the repository becomes a
+// source_code locator.
+//
+// A stdio server is a stdio connection with the command and args of the
+// object its commandFunction returns; the function is read, not run, so only
+// literals and config properties are understood, and the properties in
+// args become "{property}" placeholders. The env entries reading config
+// properties are environment variables described by the configSchema: its
+// defaults, enums and required properties become default values, choices
+// and required hints, and secret names secret hints. An http server is a
+// streamable HTTP connection to the Smithery gateway. The smithery.yaml is
+// kept as the module artifact.
+func SmitheryToRecord(data *structpb.Struct, opts ...TranslatorOption) (*structpb.Struct, error) { //nolint:cyclop
+	fields := data.GetFields()
+
+	config := fields["smithery"].GetStructValue()
+	if config == nil {
+		config = data
+	}
+
+	start := config.GetFields()["startCommand"].GetStructValue().AsMap()
+	if len(start) == 0 {
+		return nil, fmt.Errorf("%w: missing 'startCommand' in smithery.yaml", ErrInvalidInput)
+	}
+
+	options := &translatorOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	targetVersion := DefaultSchemaVersion
+
+	if options.version != "" {
+		if err := validateMajorVersion(options.version); err != nil {
+			return nil, err
+		}
+
+		targetVersion = options.version
+	}
+
+	repoURL := fields["repository"].GetStructValue().GetFields()["url"].GetStringValue()
+
+	name := firstNonEmptyString(fields["name"].GetStringValue(), repositoryName(repoURL), "generated-smithery-server")
+	description := firstNonEmptyString(fields["description"].GetStringValue(), "Agent generated from smithery.yaml")
+
+	schema, _ := start["configSchema"].(map[string]any)
+
+	var connection map[string]any
+
+	switch start["type"] {
+	case SmitheryStdio:
+		function, _ := start["commandFunction"].(string)
+
+		var err error
+
+		connection, err = smitheryStdioConnection(function, schema)
+		if err != nil {
+			return nil, err
+		}
+	case SmitheryHTTP:
+		connection = map[string]any{
+			"type": connectionTypeHTTP,
+			"url":  SmitheryGateway + "/" + smitheryQualifiedName(name) + "/mcp",
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported startCommand type %v", ErrInvalidInput, start["type"])
+	}
+
+	moduleData, err := structpb.NewStruct(map[string]any{
+		"name":        name,
+		"description": description,
+		"connections": []any{connection},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build MCP module data: %w", err)
+	}
+
+	var moduleOpts []recordutil.ModuleOption
+	if raw, err := json.Marshal(config.AsMap()); err == nil {
+		moduleOpts = append(moduleOpts, recordutil.WithModuleArtifact(raw, mcpMediaType))
+	}
+
+	builder := recordutil.NewBuilder().
+		Name(name).
+		SchemaVersion(targetVersion).
+		Version(resolveRecordVersion(fields["version"].GetStringValue(), options.recordVersion)).
+		Description(description).
+		Authors(resolveRecordAuthors(nil, options)...).
+		Set("locators", []any{}).
+		AddModule(MCPModuleName, moduleData, moduleOpts...)
+
+	if repoURL != "" {
+		builder.AddLocator("source_code", repoURL)
+	}
+
+	record, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
+
+	if err := finishRecord(record, options); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// smitheryStdioConnection reads the stdio connection of a commandFunction.
+func smitheryStdioConnection(function string, schema map[string]any) (map[string]any, error) {
+	match := smitheryCommand.FindStringSubmatch(function)
+	if match == nil {
+		return nil, fmt.Errorf("%w: commandFunction has no literal command", ErrInvalidInput)
+	}
+
+	connection := map[string]any{
+		"type":    connectionTypeStdio,
+		"command": firstNonEmptyString(match[1:]...),
+	}
+
+	if body := smitheryArgs.FindStringSubmatch(function); body != nil {
+		var args []any
+
+		for _, value := range smitheryValue.FindAllStringSubmatch(body[1], -1) {
+			if property := value[4]; property != "" {
+				args = append(args, "{"+property+"}")
+			} else {
+				args = append(args, firstNonEmptyString(value[1:4]...))
+			}
+		}
+
+		if len(args) > 0 {
+			connection["args"] = args
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	required := anySlice(schema["required"])
+
+	var envVars []any
+
+	if body := smitheryEnv.FindStringSubmatch(function); body != nil {
+		for _, pair := range smitheryEnvPair.FindAllStringSubmatch(body[1], -1) {
+			envVar := map[string]any{"name": firstNonEmptyString(pair[1:4]...)}
+
+			if property := pair[7]; property != "" {
+				propertySchema, _ := properties[property].(map[string]any)
+				maps.Copy(envVar, smitheryEnvVar(envVar["name"].(string), property, propertySchema, slices.Contains(required, any(property))))
+			} else {
+				envVar["default_value"] = firstNonEmptyString(pair[4:7]...)
+			}
+
+			envVars = append(envVars, envVar)
+		}
+	}
+
+	if len(envVars) > 0 {
+		connection["env_vars"] = envVars
+	}
+
+	return connection, nil
+}
+
+// smitheryEnvVar returns the fields of the env_var reading a config property.
+func smitheryEnvVar(name, property string, schema map[string]any, required bool) map[string]any {
+	envVar := map[string]any{
+		"description": firstNonEmptyString(stringValue(schema["description"]), "Environment variable: "+name),
+		"required":    required,
+		"secret":      recordutil.IsSecret(name, "") || recordutil.IsSecret(property, ""),
+	}
+
+	if value, ok := schema["default"]; ok {
+		envVar["default_value"] = fmt.Sprint(value)
+	}
+
+	var choices []any
+
+	for _, choice := range anySlice(schema["enum"]) {
+		choices = append(choices, fmt.Sprint(choice))
+	}
+
+	if len(choices) > 0 {
+		envVar["choices"] = choices
+	}
+
+	return envVar
+}
+
+// RecordToSmithery translates the MCP module of a record into a Smithery
+// smithery.yaml. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// A record translated from a smithery.yaml gets it back from its module
+// artifact. Otherwise, the first stdio server (by name) is a stdio start
+// command whose commandFunction returns its command, args and env; the
+// variables prompted for are properties of the configSchema, in camelCase,
+// required unless they have a default. A server with remote connections only
+// is an http start command whose configSchema has the "${NAME}" placeholders
+// of its headers.
+func RecordToSmithery(record *structpb.Struct) (*SmitheryConfig, error) {
+	found, module := recordutil.FindModule(record, MCPModuleName)
+	if !found {
+		return nil, fmt.Errorf("MCP %w", ErrModuleNotFound)
+	}
+
+	if stored := structFromArtifactData(module); stored.GetFields()["startCommand"] != nil {
+		raw, err := json.Marshal(stored.AsMap())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stored smithery.yaml: %w", err)
+		}
+
+		config := &SmitheryConfig{}
+		if err := json.Unmarshal(raw, config); err != nil {
+			return nil, fmt.Errorf("failed to read stored smithery.yaml: %w", err)
+		}
+
+		return config, nil
+	}
+
+	copilot, err := RecordToGHCopilot(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(copilot.Servers) == 0 {
+		connection, ok := firstRemoteConnection(record)
+		if !ok {
+			return nil, fmt.Errorf("%w: no supported MCP connections in record", ErrInvalidInput)
+		}
+
+		schema := newSmitheryConfigSchema()
+
+		for _, name := range slices.Sorted(maps.Keys(connection.headers)) {
+			for _, match := range vsCodePlaceholder.FindAllStringSubmatch(connection.headers[name], -1) {
+				schema.add(match[1], MCPInput{ID: match[1], Password: true, Description: "Secret value for " + match[1]})
+			}
+		}
+
+		return &SmitheryConfig{StartCommand: SmitheryStartCommand{Type: SmitheryHTTP, ConfigSchema: schema.build()}}, nil
+	}
+
+	server := copilot.Servers[slices.Sorted(maps.Keys(copilot.Servers))[0]]
+
+	inputs := make(map[string]MCPInput, len(copilot.Inputs))
+	for _, input := range copilot.Inputs {
+		inputs[input.ID] = input
+	}
+
+	schema := newSmitheryConfigSchema()
+
+	env := make([]string, 0, len(server.Env))
+
+	for _, name := range slices.Sorted(maps.Keys(server.Env)) {
+		value := server.Env[name]
+
+		ref := inputReference.FindStringSubmatch(value)
+		if ref == nil || ref[0] != value {
+			env = append(env, name+": "+jsString(value))
+
+			continue
+		}
+
+		input, ok := inputs[ref[1]]
+		if !ok {
+			input = MCPInput{ID: ref[1], Password: true, Description: "Secret value for " + ref[1]}
+		}
+
+		env = append(env, name+": config."+schema.add(ref[1], input))
+	}
+
+	args := make([]string, 0, len(server.Args))
+	for _, arg := range server.Args {
+		args = append(args, jsString(arg))
+	}
+
+	function := "(config) => ({ command: " + jsString(server.Command) + ", args: [" + strings.Join(args, ", ") + "]"
+	if len(env) > 0 {
+		function += ", env: { " + strings.Join(env, ", ") + " }"
+	}
+
+	function += " })"
+
+	return &SmitheryConfig{StartCommand: SmitheryStartCommand{
+		Type:            SmitheryStdio,
+		ConfigSchema:    schema.build(),
+		CommandFunction: function,
+	}}, nil
+}
+
+// smitheryConfigSchema accumulates the properties of a configSchema.
+type smitheryConfigSchema struct {
+	properties map[string]any
+	required   []any
+}
+
+func newSmitheryConfigSchema() *smitheryConfigSchema {
+	return &smitheryConfigSchema{properties: map[string]any{}}
+}
+
+// add adds the property of an input, named after it in camelCase, and
+// returns its name.
+func (s *smitheryConfigSchema) add(id string, input MCPInput) string {
+	name := convertKey(strings.ToLower(id), KeyStyleCamelCase)
+
+	property := map[string]any{"type": "string", "description": input.Description}
+
+	if input.Default != "" {
+		property["default"] = input.Default
+	} else if !slices.Contains(s.required, any(name)) {
+		s.required = append(s.required, name)
+	}
+
+	if len(input.Options) > 0 {
+		choices := make([]any, 0, len(input.Options))
+		for _, option := range input.Options {
+			choices = append(choices, option)
+		}
+
+		property["enum"] = choices
+	}
+
+	s.properties[name] = property
+
+	return name
+}
+
+func (s *smitheryConfigSchema) build() map[string]any {
+	schema := map[string]any{"type": "object", "properties": s.properties}
+	if len(s.required) > 0 {
+		schema["required"] = s.required
+	}
+
+	return schema
+}
+
+// repositoryName returns the name of a record from its repository URL, e.g.
+// "github.com/owner/repo".
+func repositoryName(repoURL string) string {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(u.Host+strings.TrimSuffix(u.Path, "/"), ".git")
+}
+
+// smitheryQualifiedName returns the Smithery name of a server, its name
+// without the host of repository names, e.g. "owner/repo" for
+// "github.com/owner/repo".
+func smitheryQualifiedName(name string) string {
+	if host, rest, ok := strings.Cut(name, "/"); ok && strings.Contains(host, ".") {
+		return rest
+	}
+
+	return name
+}
+
+// jsString quotes s as a single-quoted JavaScript string.
+func jsString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`).Replace(s) + "'"
+}
+
+// stringValue returns value when it is a string, or "".
+func stringValue(value any) string {
+	s, _ := value.(string)
+
+	return s
+}
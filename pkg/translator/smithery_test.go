@@ -0,0 +1,240 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const smitheryStdioYAML = `
+startCommand:
+  type: stdio
+  configSchema:
+    type: object
+    required: [apiKey]
+    properties:
+      apiKey:
+        type: string
+        description: The API key of the weather service.
+      units:
+        type: string
+        default: metric
+        enum: [metric, imperial]
+  commandFunction: |-
+    (config) => ({ command: 'node', args: ['dist/index.js', '--units', config.units], env: { WEATHER_API_KEY: config.apiKey, LOG_LEVEL: "info", UNITS: config.units } })
+build:
+  dockerfile: Dockerfile
+`
+
+func smitheryInput(t *testing.T, doc string, metadata map[string]any) *structpb.Struct {
+	t.Helper()
+
+	var config map[string]any
+	if err := yaml.Unmarshal([]byte(doc), &config); err != nil {
+		t.Fatalf("failed to parse smithery.yaml: %v", err)
+	}
+
+	input := map[string]any{"smithery": config}
+	for key, value := range metadata {
+		input[key] = value
+	}
+
+	s, err := structpb.NewStruct(input)
+	if err != nil {
+		t.Fatalf("failed to build input: %v", err)
+	}
+
+	return s
+}
+
+func TestSmitheryToRecordStdio(t *testing.T) {
+	record, err := translator.SmitheryToRecord(smitheryInput(t, smitheryStdioYAML, map[string]any{
+		"description": "Weather forecasts.",
+		"repository":  map[string]any{"url": "https://github.com/example/weather-mcp.git"},
+	}))
+	if err != nil {
+		t.Fatalf("SmitheryToRecord() error: %v", err)
+	}
+
+	if name := record.GetFields()["name"].GetStringValue(); name != "github.com/example/weather-mcp" {
+		t.Errorf("name = %q, want the repository name", name)
+	}
+
+	if locators := recordutil.LocatorURLs(record, recordutil.LocatorSourceCode); len(locators) != 1 {
+		t.Errorf("source_code locators = %v, want the repository", locators)
+	}
+
+	_, module := recordutil.FindModule(record, translator.MCPModuleName)
+	connection := module.GetFields()["data"].GetStructValue().GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue().AsMap()
+
+	want := map[string]any{
+		"type":    "stdio",
+		"command": "node",
+		"args":    []any{"dist/index.js", "--units", "{units}"},
+		"env_vars": []any{
+			map[string]any{
+				"name":        "WEATHER_API_KEY",
+				"description": "The API key of the weather service.",
+				"required":    true,
+				"secret":      true,
+			},
+			map[string]any{"name": "LOG_LEVEL", "default_value": "info"},
+			map[string]any{
+				"name":          "UNITS",
+				"description":   "Environment variable: UNITS",
+				"required":      false,
+				"secret":        false,
+				"default_value": "metric",
+				"choices":       []any{"metric", "imperial"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(connection, want) {
+		t.Errorf("connection = %v\nwant %v", connection, want)
+	}
+
+	// The smithery.yaml is returned as it was.
+	config, err := translator.RecordToSmithery(record)
+	if err != nil {
+		t.Fatalf("RecordToSmithery() error: %v", err)
+	}
+
+	if config.Build["dockerfile"] != "Dockerfile" || !strings.HasPrefix(config.StartCommand.CommandFunction, "(config) => ({ command: 'node'") {
+		t.Errorf("config = %+v, want the stored smithery.yaml", config)
+	}
+}
+
+func TestSmitheryToRecordHTTP(t *testing.T) {
+	record, err := translator.SmitheryToRecord(smitheryInput(t, "runtime: container\nstartCommand:\n  type: http\n", map[string]any{
+		"name": "example/weather-mcp",
+	}))
+	if err != nil {
+		t.Fatalf("SmitheryToRecord() error: %v", err)
+	}
+
+	_, module := recordutil.FindModule(record, translator.MCPModuleName)
+	connection := module.GetFields()["data"].GetStructValue().GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue().AsMap()
+
+	if connection["type"] != "streamable-http" || connection["url"] != "https://server.smithery.ai/example/weather-mcp/mcp" {
+		t.Errorf("connection = %v, want the Smithery gateway", connection)
+	}
+}
+
+func TestSmitheryToRecordInvalid(t *testing.T) {
+	for name, doc := range map[string]string{
+		"no start command":   "build:\n  dockerfile: Dockerfile\n",
+		"unsupported type":   "startCommand:\n  type: websocket\n",
+		"no literal command": "startCommand:\n  type: stdio\n  commandFunction: (config) => config.command\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := translator.SmitheryToRecord(smitheryInput(t, doc, nil)); !errors.Is(err, translator.ErrInvalidInput) {
+				t.Errorf("error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestRecordToSmithery(t *testing.T) {
+	record := stdioRecord(t, "npx", "-y", "@example/server")
+	data := record.GetFields()["modules"].GetListValue().GetValues()[0].GetStructValue().GetFields()["data"].GetStructValue()
+
+	connection := data.GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue()
+	connection.Fields["env_vars"] = structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+		structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{"name": structpb.NewStringValue("API_TOKEN")}}),
+		structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{
+			"name":          structpb.NewStringValue("LOG_LEVEL"),
+			"default_value": structpb.NewStringValue("info"),
+		}}),
+	}})
+
+	config, err := translator.RecordToSmithery(record)
+	if err != nil {
+		t.Fatalf("RecordToSmithery() error: %v", err)
+	}
+
+	wantFunction := "(config) => ({ command: 'npx', args: ['-y', '@example/server'], env: { API_TOKEN: config.apiToken, LOG_LEVEL: 'info' } })"
+	if config.StartCommand.Type != translator.SmitheryStdio || config.StartCommand.CommandFunction != wantFunction {
+		t.Errorf("start command = %+v\nwant commandFunction %s", config.StartCommand, wantFunction)
+	}
+
+	wantSchema := map[string]any{
+		"type":     "object",
+		"required": []any{"apiToken"},
+		"properties": map[string]any{
+			"apiToken": map[string]any{"type": "string", "description": "Secret value for API_TOKEN"},
+		},
+	}
+	if !reflect.DeepEqual(config.StartCommand.ConfigSchema, wantSchema) {
+		t.Errorf("configSchema = %v\nwant %v", config.StartCommand.ConfigSchema, wantSchema)
+	}
+
+	out, err := config.YAML()
+	if err != nil {
+		t.Fatalf("YAML() error: %v", err)
+	}
+
+	// The generated smithery.yaml reads back into the same connection.
+	back, err := translator.SmitheryToRecord(smitheryInput(t, string(out), map[string]any{"name": "example/server"}))
+	if err != nil {
+		t.Fatalf("SmitheryToRecord() error: %v\n%s", err, out)
+	}
+
+	_, module := recordutil.FindModule(back, translator.MCPModuleName)
+	got := module.GetFields()["data"].GetStructValue().GetFields()["connections"].GetListValue().GetValues()[0].GetStructValue().AsMap()
+
+	if got["command"] != "npx" || !reflect.DeepEqual(got["args"], []any{"-y", "@example/server"}) || len(got["env_vars"].([]any)) != 2 {
+		t.Errorf("connection = %v, want the connection of the record", got)
+	}
+}
+
+func TestRecordToSmitheryRemote(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "weather-mcp-server",
+					"connections": []any{map[string]any{
+						"type":    "streamable-http",
+						"url":     "https://weather.example.org/mcp",
+						"headers": map[string]any{"Authorization": "Bearer ${WEATHER_TOKEN}"},
+					}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToSmithery(record)
+	if err != nil {
+		t.Fatalf("RecordToSmithery() error: %v", err)
+	}
+
+	if config.StartCommand.Type != translator.SmitheryHTTP {
+		t.Errorf("type = %q, want http", config.StartCommand.Type)
+	}
+
+	if _, ok := config.StartCommand.ConfigSchema["properties"].(map[string]any)["weatherToken"]; !ok {
+		t.Errorf("configSchema = %v, want the weatherToken header secret", config.StartCommand.ConfigSchema)
+	}
+}
+
+func TestRecordToSmitheryNoMCPModule(t *testing.T) {
+	record := a2aRecord(t, map[string]any{"name": "agent"})
+
+	if _, err := translator.RecordToSmithery(record); !errors.Is(err, translator.ErrModuleNotFound) {
+		t.Errorf("error = %v, want ErrModuleNotFound", err)
+	}
+}
@@ -14,12 +14,19 @@ type MCPServer struct {
 type MCPInput struct {
 	ID          string `json:"id"`
 	Type        string `json:"type"`
-	Password    bool   `json:"password"`
+	Password    bool   `json:"password,omitempty"`
 	Description string `json:"description"`
+	// Options are the choices of a pickString input.
+	Options []string `json:"options,omitempty"`
+	// Default is the value preselected by a pickString input.
+	Default string `json:"default,omitempty"`
 }
 
-// GHCopilotMCPConfig represents GitHub Copilot MCP configuration.
+// GHCopilotMCPConfig represents GitHub Copilot MCP configuration. It is
+// marshaled to JSON in the format of its Flavor.
 type GHCopilotMCPConfig struct {
 	Servers map[string]MCPServer `json:"servers"`
 	Inputs  []MCPInput           `json:"inputs"`
+	// Flavor is the format of the configuration; empty is GHCopilotWorkspace.
+	Flavor GHCopilotFlavor `json:"-"`
 }
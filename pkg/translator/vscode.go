@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// VS Code server types.
+const (
+	vsCodeTypeStdio = "stdio"
+	vsCodeTypeHTTP  = "http"
+	vsCodeTypeSSE   = "sse"
+)
+
+// VSCodeServer is a server of a VS Code MCP configuration: a stdio server
+// with a command, or a remote (http or sse) server with a URL.
+type VSCodeServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// VSCodeMCPConfig is a VS Code .vscode/mcp.json: the MCP servers of the
+// workspace by name, and the inputs VS Code prompts for.
+type VSCodeMCPConfig struct {
+	Servers map[string]VSCodeServer `json:"servers"`
+	Inputs  []MCPInput              `json:"inputs"`
+}
+
+// vsCodePlaceholder matches the "${NAME}" placeholders of records.
+var vsCodePlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// RecordToVSCodeMCP translates the MCP module of a record into a VS Code MCP
+// configuration. Supports OASF versions 0.7.0, 0.8.0, and 1.0.0.
+//
+// Unlike RecordToGHCopilot, servers are typed, and a 1.0.0 server with
+// remote connections only is configured with the URL and headers of its
+// first SSE or streamable HTTP connection. The "${NAME}" placeholders of its
+// URL and headers become "${input:NAME}" references to password inputs.
+func RecordToVSCodeMCP(record *structpb.Struct, opts ...ClientConfigOption) (*VSCodeMCPConfig, error) {
+	options, err := newClientConfigOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	copilot, err := RecordToGHCopilot(record, WithGHCopilotPlatform(options.platform), WithGHCopilotPythonRunner(options.pythonRunner))
+	if err != nil {
+		return nil, err
+	}
+
+	config := &VSCodeMCPConfig{
+		Servers: make(map[string]VSCodeServer, len(copilot.Servers)),
+		Inputs:  copilot.Inputs,
+	}
+
+	for name, server := range copilot.Servers {
+		config.Servers[name] = VSCodeServer{
+			Type:    vsCodeTypeStdio,
+			Command: server.Command,
+			Args:    server.Args,
+			Env:     server.Env,
+		}
+	}
+
+	if len(config.Servers) > 0 {
+		return config, nil
+	}
+
+	connection, ok := firstRemoteConnection(record)
+	if !ok {
+		return nil, fmt.Errorf("%w: no supported MCP connections in record", ErrInvalidInput)
+	}
+
+	server := VSCodeServer{Type: vsCodeTypeHTTP, URL: config.inputReferences(connection.url)}
+	if connection.kind == connectionTypeSSE {
+		server.Type = vsCodeTypeSSE
+	}
+
+	if len(connection.headers) > 0 {
+		server.Headers = make(map[string]string, len(connection.headers))
+	}
+
+	// Sorted, so the inputs come out in the same order every time.
+	for _, name := range slices.Sorted(maps.Keys(connection.headers)) {
+		server.Headers[name] = config.inputReferences(connection.headers[name])
+	}
+
+	config.Servers[connection.name] = server
+
+	return config, nil
+}
+
+// inputReferences rewrites the placeholders of a value into input references,
+// adding the inputs the config does not have yet.
+func (c *VSCodeMCPConfig) inputReferences(value string) string {
+	return vsCodePlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+		id := vsCodePlaceholder.FindStringSubmatch(placeholder)[1]
+
+		if !slices.ContainsFunc(c.Inputs, func(input MCPInput) bool { return input.ID == id }) {
+			c.Inputs = append(c.Inputs, MCPInput{
+				ID:          id,
+				Type:        "promptString",
+				Password:    true,
+				Description: "Secret value for " + id,
+			})
+		}
+
+		return "${input:" + id + "}"
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package translator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestRecordToVSCodeMCP(t *testing.T) {
+	config, err := translator.RecordToVSCodeMCP(ghCopilotRecord(t))
+	if err != nil {
+		t.Fatalf("RecordToVSCodeMCP() error: %v", err)
+	}
+
+	server := config.Servers["github"]
+	if server.Type != "stdio" || server.Command != "docker" || len(server.Args) != 3 {
+		t.Errorf("server = %+v, want a typed stdio server", server)
+	}
+
+	if server.Env["GITHUB_PERSONAL_ACCESS_TOKEN"] != "${input:GITHUB_PERSONAL_ACCESS_TOKEN}" {
+		t.Errorf("env = %v, want an input reference", server.Env)
+	}
+
+	if len(config.Inputs) != 1 || config.Inputs[0].ID != "GITHUB_PERSONAL_ACCESS_TOKEN" {
+		t.Errorf("inputs = %+v", config.Inputs)
+	}
+}
+
+func TestRecordToVSCodeMCPRemote(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name": "weather-mcp-server",
+					"connections": []any{
+						map[string]any{"type": "websocket", "url": "wss://weather.example.org/ws"},
+						map[string]any{
+							"type": "streamable-http",
+							"url":  "https://weather.example.org/mcp",
+							"headers": map[string]any{
+								"Authorization": "Bearer ${WEATHER_TOKEN}",
+								"X-Region":      "eu",
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	config, err := translator.RecordToVSCodeMCP(record)
+	if err != nil {
+		t.Fatalf("RecordToVSCodeMCP() error: %v", err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal config: %v", err)
+	}
+
+	want := `{"servers":{"weather":{"type":"http","url":"https://weather.example.org/mcp",` +
+		`"headers":{"Authorization":"Bearer ${input:WEATHER_TOKEN}","X-Region":"eu"}}},` +
+		`"inputs":[{"id":"WEATHER_TOKEN","type":"promptString","password":true,"description":"Secret value for WEATHER_TOKEN"}]}`
+	if string(data) != want {
+		t.Errorf("config = %s\nwant %s", data, want)
+	}
+}
+
+func TestRecordToVSCodeMCPNoConnections(t *testing.T) {
+	record, _ := structpb.NewStruct(map[string]any{
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{
+				"name": translator.MCPModuleName,
+				"data": map[string]any{
+					"name":        "ws-mcp-server",
+					"connections": []any{map[string]any{"type": "websocket", "url": "wss://ws.example.org"}},
+				},
+			},
+		},
+	})
+
+	if _, err := translator.RecordToVSCodeMCP(record); !errors.Is(err, translator.ErrInvalidInput) {
+		t.Errorf("RecordToVSCodeMCP() error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DefaultConcurrency is the number of records ValidateRecords validates at a
+// time unless Concurrency is given.
+const DefaultConcurrency = 4
+
+// BatchOption configures ValidateRecords.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	concurrency int
+}
+
+// Concurrency sets the number of records validated at a time; values below 1
+// mean one.
+func Concurrency(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.concurrency = max(n, 1)
+	}
+}
+
+// RecordResult is the validation outcome of one record of a batch.
+type RecordResult struct {
+	Valid    bool
+	Errors   []string
+	Warnings []string
+	// Err is set when the record could not be validated, e.g. because the
+	// schema server was unreachable or the batch was canceled; the other
+	// fields are then unset.
+	Err error
+}
+
+// BatchResult aggregates the results of ValidateRecords.
+type BatchResult struct {
+	// Results are in the order of the records.
+	Results []RecordResult
+	// Valid, Invalid and Failed count the records that are valid, have
+	// validation errors, and could not be validated.
+	Valid   int
+	Invalid int
+	Failed  int
+}
+
+// ValidateRecords validates the records like ValidateRecord, several at a
+// time. A record that cannot be validated does not stop the others; its error
+// is in its result. When ctx is canceled, the records not yet validated fail
+// with the context error, which is also returned.
+func (v *Validator) ValidateRecords(ctx context.Context, records []*structpb.Struct, opts ...BatchOption) (*BatchResult, error) {
+	options := batchOptions{concurrency: DefaultConcurrency}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	results := make([]RecordResult, len(records))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(options.concurrency, len(records)) {
+		wg.Go(func() {
+			for i := range indexes {
+				results[i] = v.validateBatchRecord(ctx, records[i])
+			}
+		})
+	}
+
+feed:
+	for i := range records {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			for j := i; j < len(records); j++ {
+				results[j] = RecordResult{Err: ctx.Err()}
+			}
+
+			break feed
+		}
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	batch := &BatchResult{Results: results}
+
+	for _, result := range results {
+		switch {
+		case result.Err != nil:
+			batch.Failed++
+		case result.Valid:
+			batch.Valid++
+		default:
+			batch.Invalid++
+		}
+	}
+
+	return batch, ctx.Err() //nolint:wrapcheck
+}
+
+func (v *Validator) validateBatchRecord(ctx context.Context, record *structpb.Struct) RecordResult {
+	if err := ctx.Err(); err != nil {
+		return RecordResult{Err: err}
+	}
+
+	valid, errs, warnings, err := v.ValidateRecord(ctx, record)
+	if err != nil {
+		return RecordResult{Err: err}
+	}
+
+	return RecordResult{Valid: valid, Errors: errs, Warnings: warnings}
+}
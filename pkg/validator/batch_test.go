@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newBatchServer reports records named "invalid" as invalid and fails
+// records named "fail", tracking the peak number of concurrent requests.
+func newBatchServer(t *testing.T, delay time.Duration, peak *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	var inFlight atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		var record map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+			t.Errorf("Failed to decode record: %v", err)
+		}
+
+		time.Sleep(delay)
+
+		resp := ValidationResponse{}
+
+		switch record["name"] {
+		case "fail":
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		case "invalid":
+			resp.Errors = []ValidationError{{Error: "bad", Message: "record is invalid"}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func batchRecords(t *testing.T, names ...string) []*structpb.Struct {
+	t.Helper()
+
+	records := make([]*structpb.Struct, 0, len(names))
+
+	for _, name := range names {
+		record, err := structpb.NewStruct(map[string]any{"schema_version": "0.8.0", "name": name})
+		if err != nil {
+			t.Fatalf("Failed to create test record: %v", err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestValidateRecords(t *testing.T) {
+	var peak atomic.Int32
+
+	server := newBatchServer(t, 20*time.Millisecond, &peak)
+
+	v, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	records := batchRecords(t, "ok", "invalid", "fail", "ok", "ok", "invalid")
+
+	batch, err := v.ValidateRecords(context.Background(), records, Concurrency(3))
+	if err != nil {
+		t.Fatalf("ValidateRecords: %v", err)
+	}
+
+	if batch.Valid != 3 || batch.Invalid != 2 || batch.Failed != 1 {
+		t.Errorf("Got valid=%d invalid=%d failed=%d, want 3/2/1", batch.Valid, batch.Invalid, batch.Failed)
+	}
+
+	if len(batch.Results) != len(records) {
+		t.Fatalf("Got %d results, want %d", len(batch.Results), len(records))
+	}
+
+	if r := batch.Results[1]; r.Valid || len(r.Errors) != 1 || r.Err != nil {
+		t.Errorf("Result 1 = %+v, want one validation error", r)
+	}
+
+	if r := batch.Results[2]; !errors.Is(r.Err, ErrSchemaUnavailable) {
+		t.Errorf("Result 2 = %+v, want ErrSchemaUnavailable", r)
+	}
+
+	if r := batch.Results[3]; !r.Valid {
+		t.Errorf("Result 3 = %+v, want valid", r)
+	}
+
+	if p := peak.Load(); p < 2 || p > 3 {
+		t.Errorf("Peak concurrency = %d, want 2 to 3", p)
+	}
+}
+
+func TestValidateRecordsCanceled(t *testing.T) {
+	var peak atomic.Int32
+
+	server := newBatchServer(t, 50*time.Millisecond, &peak)
+
+	v, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	records := batchRecords(t, "a", "b", "c", "d", "e")
+
+	batch, err := v.ValidateRecords(ctx, records, Concurrency(1))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Got error %v, want deadline exceeded", err)
+	}
+
+	if batch.Failed != len(records) {
+		t.Errorf("Got %d failed records, want %d", batch.Failed, len(records))
+	}
+
+	for i, r := range batch.Results {
+		if r.Err == nil {
+			t.Errorf("Result %d has no error", i)
+		}
+	}
+}
+
+func TestValidateRecordsEmpty(t *testing.T) {
+	v, err := New("http://localhost")
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	batch, err := v.ValidateRecords(context.Background(), nil)
+	if err != nil || len(batch.Results) != 0 {
+		t.Errorf("Got %+v, %v for no records", batch, err)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+)
+
+func BenchmarkValidateRecord(b *testing.B) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", "valid_1.0.0_record.json"))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	record, err := decoder.JsonToProto(data)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// A schema server answering with one warning, so the response is decoded
+	// and converted as in production.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors":[],"warnings":[{"error":"attribute_recommended_missing","message":"Recommended attribute is missing.","attribute_path":"locators"}],"error_count":0,"warning_count":1}`))
+	}))
+	defer server.Close()
+
+	v, err := New(server.URL)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, _, _, err := v.ValidateRecord(ctx, record); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+)
+
+// CodeUnspecified is the code of validation messages the schema server
+// reports without an error kind, or with a kind this package does not know.
+const CodeUnspecified messages.Code = "validation/unspecified"
+
+// codes are the error kinds of the OASF schema server validation API.
+var codes = []messages.Entry{
+	{
+		Code:        "validation/attribute_required_missing",
+		Description: "A required attribute is missing.",
+		Template:    `Required attribute "{attribute}" is missing. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_recommended_missing",
+		Description: "A recommended attribute is missing (reported as a warning).",
+		Template:    `Recommended attribute "{attribute}" is missing. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_unknown",
+		Description: "An attribute is not defined by the schema.",
+		Template:    `Unknown attribute "{attribute}". Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_wrong_type",
+		Description: "An attribute value has the wrong type.",
+		Template:    `Attribute "{attribute}" has type {value_type}, expected {expected_type}. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_enum_value_unknown",
+		Description: "An attribute value is not one of the values of its enum.",
+		Template:    `Value {value} of attribute "{attribute}" is not a known enum value. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/deprecated_attribute",
+		Description: "An attribute is deprecated (reported as a warning).",
+		Template:    `Attribute "{attribute}" is deprecated. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_value_exceeds_max_len",
+		Description: "An attribute value is longer than its maximum length.",
+		Template:    `Value of attribute "{attribute}" exceeds its maximum length. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_value_regex_not_matched",
+		Description: "An attribute value does not match the pattern of its type.",
+		Template:    `Value {value} of attribute "{attribute}" does not match its pattern. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/attribute_value_exceeds_range",
+		Description: "An attribute value is outside the range of its type.",
+		Template:    `Value {value} of attribute "{attribute}" is out of range. Attribute path: {attribute_path}.`,
+	},
+	{
+		Code:        "validation/constraint_failed",
+		Description: "An object does not satisfy a constraint of its class, e.g. at least one of several attributes.",
+		Template:    `Object "{object_name}" does not satisfy its constraint. Attribute path: {attribute_path}. Constraint: {constraint}`,
+	},
+	{
+		Code:        CodeUnspecified,
+		Description: "The schema server reported a message without a known error kind.",
+		Template:    `{message}`,
+	},
+}
+
+func init() {
+	messages.Define(codes...)
+}
+
+// toMessage returns the coded message of the error, with the text reported
+// by ValidateRecord.
+func (e ValidationError) toMessage(text string) messages.Message {
+	code := messages.Code("validation/" + e.Error)
+	if _, ok := messages.Lookup(code); !ok {
+		code = CodeUnspecified
+	}
+
+	args := map[string]string{"message": e.Message}
+
+	for name, value := range map[string]string{
+		"attribute":      e.Attribute,
+		"attribute_path": e.AttributePath,
+		"value_type":     e.ValueType,
+		"expected_type":  e.ExpectedType,
+		"object_name":    e.ObjectName,
+	} {
+		if value != "" {
+			args[name] = value
+		}
+	}
+
+	if e.Value != nil {
+		if data, err := json.Marshal(e.Value); err == nil {
+			args["value"] = string(data)
+		} else {
+			args["value"] = fmt.Sprint(e.Value)
+		}
+	}
+
+	if e.Constraint != nil {
+		if data, err := json.Marshal(e.Constraint); err == nil {
+			args["constraint"] = string(data)
+		}
+	}
+
+	return messages.Message{Code: code, Text: text, Path: e.AttributePath, Args: args}
+}
+
+// texts returns the texts of the messages.
+func texts(msgs []messages.Message) []string {
+	out := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		out = append(out, msg.Text)
+	}
+
+	return out
+}
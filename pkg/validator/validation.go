@@ -11,20 +11,60 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/internal/transport"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const defaultHTTPTimeoutSeconds = 30
 
+// ErrSchemaUnavailable is returned (wrapped) when the schema server cannot be
+// reached, fails (HTTP 5xx) or does not answer a validation request. It is
+// the same error as schema.ErrSchemaUnavailable. Records without a usable
+// schema_version fail with the decoder errors.
+var ErrSchemaUnavailable = schema.ErrSchemaUnavailable
+
+// ErrInvalidInput is returned (wrapped) when the schema server rejects a
+// validation request (HTTP 4xx), e.g. for a schema version or profile it does
+// not know. It is the same error as translator.ErrInvalidInput.
+var ErrInvalidInput = translator.ErrInvalidInput
+
+// Validator validates records against an OASF schema server. It holds no
+// mutable state, so a Validator is safe for concurrent use and can be shared
+// across goroutines, e.g. by a server handling requests in parallel.
 type Validator struct {
 	schemaURL  string
+	profiles   []string
 	httpClient *http.Client
 }
 
+// Option configures a Validator.
+type Option func(*Validator)
+
+// WithProfiles enables the given OASF schema profiles during validation.
+// Profiles are passed to the schema server's validation API as-is.
+func WithProfiles(profiles ...string) Option {
+	return func(v *Validator) {
+		v.profiles = append(v.profiles, profiles...)
+	}
+}
+
+// WithHTTPClient sets the HTTP client calling the schema server. It defaults
+// to a client with a 30 second timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(v *Validator) {
+		v.httpClient = client
+	}
+}
+
 // ValidationError represents a single validation error from the API.
 type ValidationError struct {
 	Error         string         `json:"error"`
@@ -46,34 +86,53 @@ type ValidationResponse struct {
 	WarningCount int               `json:"warning_count"`
 }
 
-func New(schemaURL string) (*Validator, error) {
+func New(schemaURL string, opts ...Option) (*Validator, error) {
 	if schemaURL == "" {
 		return nil, errors.New("schema URL is required")
 	}
 
-	return &Validator{
+	v := &Validator{
 		schemaURL: schemaURL,
 		httpClient: &http.Client{
-			Timeout: defaultHTTPTimeoutSeconds * time.Second,
+			Transport: transport.Default(),
+			Timeout:   defaultHTTPTimeoutSeconds * time.Second,
 		},
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
 }
 
 // ValidateRecord validates a record against the configured schema URL.
 func (v *Validator) ValidateRecord(ctx context.Context, record *structpb.Struct) (bool, []string, []string, error) {
+	isValid, errs, warnings, err := v.ValidateRecordMessages(ctx, record)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	return isValid, texts(errs), texts(warnings), nil
+}
+
+// ValidateRecordMessages validates a record like ValidateRecord and returns
+// the errors and warnings as coded messages, which UIs can localize with
+// messages.Message.Localize. Their Text is the string ValidateRecord returns.
+func (v *Validator) ValidateRecordMessages(ctx context.Context, record *structpb.Struct) (bool, []messages.Message, []messages.Message, error) {
 	// Validate against schema URL
-	errorMessages, warningMessages, err := v.validateWithSchemaURL(ctx, record, v.schemaURL)
+	errs, warnings, err := v.validateWithSchemaURL(ctx, record, v.schemaURL)
 	if err != nil {
 		return false, nil, nil, fmt.Errorf("schema URL validation failed: %w", err)
 	}
 
 	// Record is valid if there are no errors (warnings don't affect validity)
-	isValid := len(errorMessages) == 0
+	isValid := len(errs) == 0
 
-	return isValid, errorMessages, warningMessages, nil
+	return isValid, errs, warnings, nil
 }
 
-func (v *Validator) validateWithSchemaURL(ctx context.Context, record *structpb.Struct, schemaURL string) ([]string, []string, error) {
+func (v *Validator) validateWithSchemaURL(ctx context.Context, record *structpb.Struct, schemaURL string) ([]messages.Message, []messages.Message, error) {
 	// Get schema version from the record
 	schemaVersion, err := decoder.GetRecordSchemaVersion(record)
 	if err != nil {
@@ -81,16 +140,17 @@ func (v *Validator) validateWithSchemaURL(ctx context.Context, record *structpb.
 	}
 
 	// Construct the canonical validation URL for the declared record schema version.
-	validationURL := constructValidationURL(schemaURL, schemaVersion)
+	validationURL := constructValidationURL(schemaURL, schemaVersion, v.profiles...)
 
-	// Convert record to JSON for the POST request
-	recordJSON, err := json.Marshal(record)
+	// Convert record to JSON for the POST request. protojson directly, as
+	// json.Marshal would call it too and then copy and re-validate its output.
+	recordJSON, err := protojson.Marshal(record)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to marshal record to JSON: %w", err)
 	}
 
 	// Create POST request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, validationURL, bytes.NewBuffer(recordJSON))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, validationURL, bytes.NewReader(recordJSON))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create POST request to %s: %w", validationURL, err)
 	}
@@ -100,29 +160,32 @@ func (v *Validator) validateWithSchemaURL(ctx context.Context, record *structpb.
 	// Send request
 	resp, err := v.httpClient.Do(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to send POST request to %s: %w", validationURL, err)
+		return nil, nil, fmt.Errorf("%w: failed to send POST request to %s: %w", ErrSchemaUnavailable, validationURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("failed to validate record at URL %s: HTTP %d", validationURL, resp.StatusCode)
-	}
+		cause := ErrSchemaUnavailable
+		if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError {
+			cause = ErrInvalidInput
+		}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read validation response from URL %s: %w", validationURL, err)
+		return nil, nil, fmt.Errorf("%w: failed to validate record at URL %s: HTTP %d", cause, validationURL, resp.StatusCode)
 	}
 
-	// Parse response
+	// Parse response, streaming it rather than reading it into memory first
 	var validationResp ValidationResponse
 
-	decoder := json.NewDecoder(bytes.NewReader(respBody))
-	if err := decoder.Decode(&validationResp); err != nil {
-		return nil, nil, fmt.Errorf("failed to decode validation response from URL %s: %w", validationURL, err)
+	if err := json.NewDecoder(resp.Body).Decode(&validationResp); err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to decode validation response from URL %s: %w", ErrSchemaUnavailable, validationURL, err)
 	}
 
-	// Convert errors to string format
-	errorMessages := make([]string, 0, len(validationResp.Errors))
+	// Drain what the decoder left (e.g. a trailing newline) so the connection
+	// is reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	// Convert errors to messages
+	errorMessages := make([]messages.Message, 0, len(validationResp.Errors))
 	for _, err := range validationResp.Errors {
 		errorMsg := "Validation Error: " + err.Message
 		if err.AttributePath != "" {
@@ -137,18 +200,18 @@ func (v *Validator) validateWithSchemaURL(ctx context.Context, record *structpb.
 			}
 		}
 
-		errorMessages = append(errorMessages, errorMsg)
+		errorMessages = append(errorMessages, err.toMessage(errorMsg))
 	}
 
-	// Convert warnings to string format
-	warningMessages := make([]string, 0, len(validationResp.Warnings))
+	// Convert warnings to messages
+	warningMessages := make([]messages.Message, 0, len(validationResp.Warnings))
 	for _, warning := range validationResp.Warnings {
 		warningMsg := warning.Message
 		if warning.AttributePath != "" {
 			warningMsg = fmt.Sprintf("%s Attribute path: %s.", warning.Message, warning.AttributePath)
 		}
 
-		warningMessages = append(warningMessages, warningMsg)
+		warningMessages = append(warningMessages, warning.toMessage(warningMsg))
 	}
 
 	return errorMessages, warningMessages, nil
@@ -166,8 +229,13 @@ func normalizeURL(baseURL string) string {
 	return normalizedURL
 }
 
-func constructValidationURL(baseURL, schemaVersion string) string {
+func constructValidationURL(baseURL, schemaVersion string, profiles ...string) string {
 	normalizedBaseURL := normalizeURL(baseURL)
 
-	return fmt.Sprintf("%s/api/%s/validate/object/record?missing_recommended=true", normalizedBaseURL, schemaVersion)
+	validationURL := fmt.Sprintf("%s/api/%s/validate/object/record?missing_recommended=true", normalizedBaseURL, schemaVersion)
+	if len(profiles) > 0 {
+		validationURL += "&profiles=" + url.QueryEscape(strings.Join(profiles, ","))
+	}
+
+	return validationURL
 }
@@ -6,6 +6,7 @@ package validator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -347,3 +348,145 @@ func containsHelper(s, substr string) bool {
 
 	return false
 }
+
+func TestConstructValidationURLProfiles(t *testing.T) {
+	got := constructValidationURL("schema.example.org/", "1.0.0")
+	if want := "http://schema.example.org/api/1.0.0/validate/object/record?missing_recommended=true"; got != want {
+		t.Errorf("constructValidationURL() = %q, want %q", got, want)
+	}
+
+	got = constructValidationURL("https://schema.example.org", "1.0.0", "security", "cloud")
+	if want := "https://schema.example.org/api/1.0.0/validate/object/record?missing_recommended=true&profiles=security%2Ccloud"; got != want {
+		t.Errorf("constructValidationURL() with profiles = %q, want %q", got, want)
+	}
+}
+
+// TestValidateRecordMessages tests that errors and warnings carry stable codes
+// and arguments, and the texts of ValidateRecord.
+func TestValidateRecordMessages(t *testing.T) {
+	mockResponse := ValidationResponse{
+		Errors: []ValidationError{
+			{
+				Error:         "attribute_required_missing",
+				Message:       "Required attribute \"name\" is missing.",
+				Attribute:     "name",
+				AttributePath: "name",
+			},
+			{
+				Error:   "something_new",
+				Message: "Something new happened.",
+			},
+		},
+		Warnings: []ValidationError{
+			{
+				Error:         "deprecated_attribute",
+				Message:       "Attribute is deprecated",
+				Attribute:     "old_field",
+				AttributePath: "data.old_field",
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(mockResponse); err != nil {
+			t.Errorf("Failed to encode mock response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	validator, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	record, err := structpb.NewStruct(map[string]any{"schema_version": "0.8.0"})
+	if err != nil {
+		t.Fatalf("Failed to create test record: %v", err)
+	}
+
+	valid, errs, warnings, err := validator.ValidateRecordMessages(context.Background(), record)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if valid || len(errs) != 2 || len(warnings) != 1 {
+		t.Fatalf("Expected 2 errors and 1 warning, got valid=%v %v %v", valid, errs, warnings)
+	}
+
+	if errs[0].Code != "validation/attribute_required_missing" || errs[0].Path != "name" || errs[0].Args["attribute"] != "name" {
+		t.Errorf("Unexpected error message: %+v", errs[0])
+	}
+
+	if errs[0].Text != "Required attribute \"name\" is missing. Attribute path: name." {
+		t.Errorf("Unexpected error text: %q", errs[0].Text)
+	}
+
+	if errs[1].Code != CodeUnspecified || errs[1].Text != "Validation Error: Something new happened." {
+		t.Errorf("Expected unknown kinds to be unspecified, got %+v", errs[1])
+	}
+
+	if warnings[0].Code != "validation/deprecated_attribute" || warnings[0].Args["attribute_path"] != "data.old_field" {
+		t.Errorf("Unexpected warning message: %+v", warnings[0])
+	}
+
+	_, legacyErrs, legacyWarnings, err := validator.ValidateRecord(context.Background(), record)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if legacyErrs[1] != errs[1].Text || legacyWarnings[0] != warnings[0].Text {
+		t.Errorf("Expected ValidateRecord to return the texts of the messages, got %v %v", legacyErrs, legacyWarnings)
+	}
+}
+
+// TestValidateRecordMessages_HTTPErrors tests that rejected requests are told
+// apart from schema server failures.
+func TestValidateRecordMessages_HTTPErrors(t *testing.T) {
+	tests := []struct {
+		status  int
+		wantErr error
+	}{
+		{http.StatusBadRequest, ErrInvalidInput},
+		{http.StatusNotFound, ErrInvalidInput},
+		{http.StatusUnprocessableEntity, ErrInvalidInput},
+		{http.StatusInternalServerError, ErrSchemaUnavailable},
+		{http.StatusBadGateway, ErrSchemaUnavailable},
+		{http.StatusNoContent, ErrSchemaUnavailable},
+	}
+
+	record, err := structpb.NewStruct(map[string]any{"schema_version": "0.8.0"})
+	if err != nil {
+		t.Fatalf("Failed to create test record: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			validator, err := New(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create validator: %v", err)
+			}
+
+			_, _, _, err = validator.ValidateRecordMessages(context.Background(), record)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateRecordMessages() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	unreachable, err := New("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	_, _, _, err = unreachable.ValidateRecordMessages(context.Background(), record)
+	if !errors.Is(err, ErrSchemaUnavailable) {
+		t.Errorf("ValidateRecordMessages() error = %v, want ErrSchemaUnavailable for an unreachable server", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type analyzeOptions struct {
+	*globalOptions
+
+	dirs            []string
+	skillsTaxonomy  string
+	domainsTaxonomy string
+}
+
+func newAnalyzeCommand(g *globalOptions) *cobra.Command {
+	opts := &analyzeOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "analyze [--dir <records/>]... [<record.json|->...]",
+		Short: "Report how a set of records covers the skill and domain taxonomies",
+		Long: `Aggregate the skills and domains of a corpus of records and report, per
+top-level category of the taxonomies, how many classes are used and by how
+many records, the classes the taxonomies do not define, and the description
+sentences no skill or domain of their record accounts for, with the classes
+they may map to.
+
+The taxonomies are fetched from --schema-url for --oasf-version (default the
+server's default version), or read from --skills-taxonomy and
+--domains-taxonomy, JSON files in the format of the schema server's
+skill_categories and domain_categories endpoints, e.g. to cover proposed
+categories that are not published yet.
+
+Exit codes: 0 when the report was printed, 2 when the analysis could not run.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAnalyze(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), append(opts.dirs, args...), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.dirs, "dir", nil, "Directory of records to analyze (repeatable)")
+	cmd.Flags().StringVar(&opts.skillsTaxonomy, "skills-taxonomy", "", "Skill taxonomy JSON file to use instead of --schema-url")
+	cmd.Flags().StringVar(&opts.domainsTaxonomy, "domains-taxonomy", "", "Domain taxonomy JSON file to use instead of --schema-url")
+
+	return cmd
+}
+
+func runAnalyze(ctx context.Context, stdin io.Reader, out io.Writer, args []string, opts *analyzeOptions) error {
+	if len(args) == 0 {
+		return errors.New("no records to analyze, pass --dir or record files")
+	}
+
+	inputs, err := resolveInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	records := make([]*structpb.Struct, 0, len(inputs))
+
+	for _, in := range inputs {
+		rec, err := parseRecord(in)
+		if err != nil {
+			return err
+		}
+
+		records = append(records, rec)
+	}
+
+	skills, domains, err := loadTaxonomies(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	report := record.Coverage(records, skills, domains)
+
+	if opts.structured() {
+		return writeStructured(out, opts.output, report)
+	}
+
+	printCoverage(out, report)
+
+	return nil
+}
+
+// loadTaxonomies reads the taxonomy files, and fetches the taxonomies without
+// a file from the schema server.
+func loadTaxonomies(ctx context.Context, opts *analyzeOptions) (schema.Taxonomy, schema.Taxonomy, error) {
+	var (
+		sc         *schema.Schema
+		schemaOpts []schema.SchemaOption
+	)
+
+	if opts.skillsTaxonomy == "" || opts.domainsTaxonomy == "" {
+		if opts.schemaURL == "" {
+			return nil, nil, errors.New("--schema-url is required without --skills-taxonomy and --domains-taxonomy")
+		}
+
+		var err error
+
+		if sc, err = schema.New(opts.schemaURL); err != nil {
+			return nil, nil, fmt.Errorf("invalid schema URL: %w", err)
+		}
+
+		if opts.oasfVersion != "" {
+			schemaOpts = append(schemaOpts, schema.WithSchemaVersion(opts.oasfVersion))
+		}
+	}
+
+	skills, err := loadTaxonomy(opts.skillsTaxonomy, func() (schema.Taxonomy, error) {
+		return sc.GetSchemaSkills(ctx, schemaOpts...)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the skill taxonomy: %w", err)
+	}
+
+	domains, err := loadTaxonomy(opts.domainsTaxonomy, func() (schema.Taxonomy, error) {
+		return sc.GetSchemaDomains(ctx, schemaOpts...)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the domain taxonomy: %w", err)
+	}
+
+	return skills, domains, nil
+}
+
+func loadTaxonomy(path string, fetch func() (schema.Taxonomy, error)) (schema.Taxonomy, error) {
+	if path == "" {
+		return fetch()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var taxonomy schema.Taxonomy
+	if err := json.Unmarshal(data, &taxonomy); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return taxonomy, nil
+}
+
+func printCoverage(out io.Writer, report *record.CoverageReport) {
+	fmt.Fprintf(out, "%d record(s)\n", report.Records)
+
+	for _, t := range []struct {
+		kind     string
+		coverage record.TaxonomyCoverage
+	}{
+		{"skills", report.Skills},
+		{"domains", report.Domains},
+	} {
+		fmt.Fprintf(out, "\n%s: %d/%d classes used (%s)\n", t.kind, t.coverage.Used, t.coverage.Classes, percent(t.coverage.Used, t.coverage.Classes))
+
+		for _, c := range t.coverage.Categories {
+			caption := c.Caption
+			if caption == "" {
+				caption = c.Name
+			}
+
+			fmt.Fprintf(out, "  [%d] %-40s %d/%d classes, %d record(s)\n", c.ID, caption, c.Used, c.Classes, c.Records)
+
+			for _, u := range c.Usage {
+				fmt.Fprintf(out, "      %-50s %d record(s)\n", u.Name, u.Records)
+			}
+		}
+
+		if len(t.coverage.Unknown) > 0 {
+			fmt.Fprintf(out, "  not in the taxonomy: %s\n", strings.Join(t.coverage.Unknown, ", "))
+		}
+	}
+
+	if len(report.Unmapped) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "\nunmapped description text:")
+
+	for _, u := range report.Unmapped {
+		fmt.Fprintf(out, "  %s: %q", u.Record, u.Text)
+
+		if len(u.Suggestions) > 0 {
+			fmt.Fprintf(out, " (maybe %s)", strings.Join(u.Suggestions, ", "))
+		}
+
+		fmt.Fprintln(out)
+	}
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total)) //nolint:mnd
+}
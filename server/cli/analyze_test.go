@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+)
+
+func TestAnalyze(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"skills.json": `{
+			"natural_language_processing": {"id": 1, "caption": "Natural Language Processing", "category": true, "classes": {
+				"summarization": {"id": 10201, "name": "natural_language_processing/summarization", "caption": "Text Summarization"}
+			}},
+			"security_privacy": {"id": 8, "caption": "Security and Privacy", "category": true, "classes": {
+				"threat_detection": {"id": 80101, "name": "security_privacy/threat_detection", "caption": "Threat Detection"}
+			}}
+		}`,
+		"domains.json": `{}`,
+	})
+	records := writeFiles(t, map[string]string{
+		"a.json": `{"name": "example.org/a", "description": "Summarizes reports. Detects threats.", "skills": [{"id": 10201}]}`,
+		"b.json": `{"name": "example.org/b", "skills": [{"name": "natural_language_processing/summarization"}]}`,
+	})
+	taxonomyFlags := []string{"--skills-taxonomy", filepath.Join(dir, "skills.json"), "--domains-taxonomy", filepath.Join(dir, "domains.json")}
+
+	out, err := runCLI(t, "", append([]string{"analyze", "--dir", records}, taxonomyFlags...)...)
+	if err != nil {
+		t.Fatalf("analyze: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"2 record(s)", "skills: 1/2 classes used (50.0%)", "[8] Security and Privacy", `example.org/a: "Detects threats" (maybe security_privacy/threat_detection)`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output misses %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCLI(t, "", append([]string{"analyze", "-o", "json", "--dir", records}, taxonomyFlags...)...)
+	if err != nil {
+		t.Fatalf("analyze: %v\n%s", err, out)
+	}
+
+	var report record.CoverageReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil || report.Skills.Categories[0].Records != 2 {
+		t.Errorf("unexpected report: %v\n%s", err, out)
+	}
+
+	if _, err := runCLI(t, "", "analyze", "--dir", records); err == nil || !strings.Contains(err.Error(), "--schema-url") {
+		t.Errorf("expected a missing taxonomy error, got %v", err)
+	}
+
+	if _, err := runCLI(t, "", "analyze"); err == nil {
+		t.Error("expected an error without records")
+	}
+}
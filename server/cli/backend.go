@@ -0,0 +1,339 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// apiKeyEnv is read when --api-key is not given.
+const apiKeyEnv = "OASF_API_KEY"
+
+// errServer marks failures to reach or use the --server backend, as opposed to
+// errors about the record itself, which the server reports as InvalidArgument.
+var errServer = errors.New("server request failed")
+
+// backend runs the SDK operations used by the commands, either in-process or
+// against a deployed oasf-sdk server (--server). Commands only talk to the
+// backend, so both modes share one code path.
+type backend interface {
+	DecodeRecord(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error)
+	// ValidateRecord validates against the schema server at schemaURL and
+	// returns the validation errors and warnings.
+	ValidateRecord(ctx context.Context, record *structpb.Struct, schemaURL string, profiles []string) ([]string, []string, error)
+	// ValidateRecords validates several records like ValidateRecord, up to
+	// concurrency at a time, and returns their results in order.
+	ValidateRecords(ctx context.Context, records []*structpb.Struct, schemaURL string, profiles []string, concurrency int) ([]validator.RecordResult, error)
+	RecordToGHCopilot(ctx context.Context, record *structpb.Struct) (any, error)
+	RecordToA2A(ctx context.Context, record *structpb.Struct) (*structpb.Struct, error)
+	RecordToSkillMarkdown(ctx context.Context, record *structpb.Struct) (string, error)
+	A2AToRecord(ctx context.Context, card *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error)
+	MCPToRecord(ctx context.Context, entry *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error)
+	Close() error
+}
+
+// toRecordOptions are the options of the reverse translators.
+type toRecordOptions struct {
+	oasfVersion   string
+	recordVersion string
+	authors       []string
+	provenance    translator.Provenance
+}
+
+// hasProvenance reports whether provenance defaults are set.
+func (o toRecordOptions) hasProvenance() bool {
+	p := o.provenance
+
+	return len(p.Authors) > 0 || p.Organization != "" || p.Contact != "" || p.FallbackLocator != ""
+}
+
+func (o toRecordOptions) translatorOptions() []translator.TranslatorOption {
+	var opts []translator.TranslatorOption
+
+	if o.oasfVersion != "" {
+		opts = append(opts, translator.WithVersion(o.oasfVersion))
+	}
+
+	if o.recordVersion != "" {
+		opts = append(opts, translator.WithRecordVersion(o.recordVersion))
+	}
+
+	if len(o.authors) > 0 {
+		opts = append(opts, translator.WithAuthors(o.authors))
+	}
+
+	if o.hasProvenance() {
+		opts = append(opts, translator.WithProvenance(o.provenance))
+	}
+
+	return opts
+}
+
+// newBackend returns the in-process backend, or a client for --server.
+func (g *globalOptions) newBackend() (backend, error) {
+	if g.server == "" {
+		return localBackend{}, nil
+	}
+
+	var opts []client.Option
+
+	if g.tls {
+		opts = append(opts, client.WithTLS(nil))
+	}
+
+	apiKey := g.apiKey
+	if apiKey == "" {
+		apiKey = os.Getenv(apiKeyEnv)
+	}
+
+	if apiKey != "" {
+		opts = append(opts, client.WithAPIKey(apiKey))
+	}
+
+	conn, err := client.Dial([]string{g.server}, opts...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &remoteBackend{
+		conn:        conn,
+		decoding:    decodingv1grpc.NewDecodingServiceClient(conn),
+		validation:  validationv1grpc.NewValidationServiceClient(conn),
+		translation: translationv1grpc.NewTranslationServiceClient(conn),
+	}, nil
+}
+
+// localBackend runs the pkg libraries in-process.
+type localBackend struct{}
+
+func (localBackend) DecodeRecord(_ context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
+	return decoder.DecodeRecord(record) //nolint:wrapcheck
+}
+
+func (localBackend) ValidateRecord(ctx context.Context, record *structpb.Struct, schemaURL string, profiles []string) ([]string, []string, error) {
+	v, err := validator.New(schemaURL, validator.WithProfiles(profiles...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	_, errs, warnings, err := v.ValidateRecord(ctx, record)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to validate record: %w", err)
+	}
+
+	return errs, warnings, nil
+}
+
+func (localBackend) ValidateRecords(ctx context.Context, records []*structpb.Struct, schemaURL string, profiles []string, concurrency int) ([]validator.RecordResult, error) {
+	v, err := validator.New(schemaURL, validator.WithProfiles(profiles...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	batch, err := v.ValidateRecords(ctx, records, validator.Concurrency(concurrency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate records: %w", err)
+	}
+
+	for i, result := range batch.Results {
+		if result.Err != nil {
+			batch.Results[i].Err = fmt.Errorf("failed to validate record: %w", result.Err)
+		}
+	}
+
+	return batch.Results, nil
+}
+
+func (localBackend) RecordToGHCopilot(_ context.Context, record *structpb.Struct) (any, error) {
+	return translator.RecordToGHCopilot(record) //nolint:wrapcheck
+}
+
+func (localBackend) RecordToA2A(_ context.Context, record *structpb.Struct) (*structpb.Struct, error) {
+	return translator.RecordToA2A(record) //nolint:wrapcheck
+}
+
+func (localBackend) RecordToSkillMarkdown(_ context.Context, record *structpb.Struct) (string, error) {
+	return translator.RecordToSkillMarkdown(record) //nolint:wrapcheck
+}
+
+func (localBackend) A2AToRecord(_ context.Context, card *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error) {
+	return translator.A2AToRecord(card, opts.translatorOptions()...) //nolint:wrapcheck
+}
+
+func (localBackend) MCPToRecord(_ context.Context, entry *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error) {
+	return translator.MCPToRecord(entry, opts.translatorOptions()...) //nolint:wrapcheck
+}
+
+func (localBackend) Close() error { return nil }
+
+// remoteBackend calls a deployed oasf-sdk server.
+type remoteBackend struct {
+	conn        *grpc.ClientConn
+	decoding    decodingv1grpc.DecodingServiceClient
+	validation  validationv1grpc.ValidationServiceClient
+	translation translationv1grpc.TranslationServiceClient
+}
+
+func (b *remoteBackend) DecodeRecord(ctx context.Context, record *structpb.Struct) (*decodingv1.DecodeRecordResponse, error) {
+	resp, err := b.decoding.DecodeRecord(ctx, &decodingv1.DecodeRecordRequest{Record: record})
+	if err != nil {
+		return nil, fromRPCError(err)
+	}
+
+	return resp, nil
+}
+
+func (b *remoteBackend) ValidateRecord(ctx context.Context, record *structpb.Struct, schemaURL string, profiles []string) ([]string, []string, error) {
+	if len(profiles) > 0 {
+		return nil, nil, errors.New("--profile is not supported with --server")
+	}
+
+	resp, err := b.validation.ValidateRecord(ctx, &validationv1.ValidateRecordRequest{Record: record, SchemaUrl: schemaURL})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to validate record: %w", fromRPCError(err))
+	}
+
+	return resp.GetErrors(), resp.GetWarnings(), nil
+}
+
+// ValidateRecords validates the records one by one: the server validates
+// each request against the schema server itself.
+func (b *remoteBackend) ValidateRecords(ctx context.Context, records []*structpb.Struct, schemaURL string, profiles []string, _ int) ([]validator.RecordResult, error) {
+	results := make([]validator.RecordResult, 0, len(records))
+
+	for _, record := range records {
+		errs, warnings, err := b.ValidateRecord(ctx, record, schemaURL, profiles)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, validator.RecordResult{Valid: len(errs) == 0, Errors: errs, Warnings: warnings})
+	}
+
+	return results, nil
+}
+
+func (b *remoteBackend) RecordToGHCopilot(ctx context.Context, record *structpb.Struct) (any, error) {
+	resp, err := b.translation.RecordToGHCopilot(ctx, &translationv1.RecordToGHCopilotRequest{Record: record})
+	if err != nil {
+		return nil, fromRPCError(err)
+	}
+
+	return resp.GetData().AsMap(), nil
+}
+
+func (b *remoteBackend) RecordToA2A(ctx context.Context, record *structpb.Struct) (*structpb.Struct, error) {
+	resp, err := b.translation.RecordToA2A(ctx, &translationv1.RecordToA2ARequest{Record: record})
+	if err != nil {
+		return nil, fromRPCError(err)
+	}
+
+	return resp.GetData(), nil
+}
+
+func (b *remoteBackend) RecordToSkillMarkdown(ctx context.Context, record *structpb.Struct) (string, error) {
+	resp, err := b.translation.RecordToSkillMarkdown(ctx, &translationv1.RecordToSkillMarkdownRequest{Record: record})
+	if err != nil {
+		return "", fromRPCError(err)
+	}
+
+	return resp.GetData(), nil
+}
+
+func (b *remoteBackend) A2AToRecord(ctx context.Context, card *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error) {
+	resp, err := b.translation.A2AToRecord(ctx, &translationv1.A2AToRecordRequest{Data: card})
+	if err != nil {
+		return nil, fromRPCError(err)
+	}
+
+	return applyToRecordOptions(resp.GetRecord(), opts)
+}
+
+func (b *remoteBackend) MCPToRecord(ctx context.Context, entry *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error) {
+	resp, err := b.translation.MCPToRecord(ctx, &translationv1.MCPToRecordRequest{Data: entry})
+	if err != nil {
+		return nil, fromRPCError(err)
+	}
+
+	return applyToRecordOptions(resp.GetRecord(), opts)
+}
+
+func (b *remoteBackend) Close() error {
+	return b.conn.Close() //nolint:wrapcheck
+}
+
+// fromRPCError turns a gRPC error into a plain error carrying the server's
+// message. Errors other than InvalidArgument wrap errServer.
+func fromRPCError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("%w: %w", errServer, err)
+	}
+
+	if st.Code() == codes.InvalidArgument {
+		return errors.New(st.Message())
+	}
+
+	return fmt.Errorf("%w: %s: %s", errServer, st.Code(), st.Message())
+}
+
+// applyToRecordOptions applies the reverse translator options that the RPCs
+// do not carry to a record returned by the server.
+func applyToRecordOptions(record *structpb.Struct, opts toRecordOptions) (*structpb.Struct, error) {
+	fields := record.GetFields()
+	if fields == nil {
+		return nil, errors.New("server returned an empty record")
+	}
+
+	if opts.oasfVersion != "" && fields["schema_version"].GetStringValue() != opts.oasfVersion {
+		return nil, fmt.Errorf("server produced schema version %s; --oasf-version is not supported with --server",
+			fields["schema_version"].GetStringValue())
+	}
+
+	if opts.recordVersion != "" {
+		fields["version"] = structpb.NewStringValue(opts.recordVersion)
+	}
+
+	if len(opts.authors) > 0 {
+		authors := make([]any, 0, len(opts.authors))
+		for _, author := range opts.authors {
+			authors = append(authors, author)
+		}
+
+		list, err := structpb.NewList(authors)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set authors: %w", err)
+		}
+
+		fields["authors"] = structpb.NewListValue(list)
+	}
+
+	// The defaults of the CLI replace those of the server, which cannot tell
+	// the authors of the source from its own.
+	if opts.hasProvenance() {
+		if err := translator.ApplyProvenance(record, opts.provenance); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return record, nil
+}
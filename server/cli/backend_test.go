@@ -0,0 +1,151 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
+	decodingcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/decoding/v1"
+	translationcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/translation/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// startTestServer serves the decoding and translation services on a local
+// port and returns its address and the authorization headers it received.
+func startTestServer(t *testing.T) (string, func() []string) {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		auth []string
+	)
+
+	record := func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		mu.Lock()
+		auth = append(auth, md.Get("authorization")...)
+		mu.Unlock()
+
+		return handler(ctx, req)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(record))
+	decodingv1grpc.RegisterDecodingServiceServer(srv, decodingcontrollerv1.New())
+	translationv1grpc.RegisterTranslationServiceServer(srv, translationcontrollerv1.New())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	go func() { _ = srv.Serve(lis) }()
+
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]string(nil), auth...)
+	}
+}
+
+func TestValidateRemote(t *testing.T) {
+	addr, authHeaders := startTestServer(t)
+
+	dir := writeFiles(t, map[string]string{
+		"good.json":  validRecord,
+		"nover.json": `{"name": "no-version"}`,
+	})
+
+	out, err := runCLI(t, "", "--server", addr, "--api-key", "secret", "validate", dir)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"schema_version field is missing", "2 record(s) validated: 1 passed, 1 failed"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	headers := authHeaders()
+	if len(headers) != 2 || headers[0] != "Bearer secret" {
+		t.Errorf("unexpected authorization headers %v", headers)
+	}
+}
+
+func TestPipelineRemoteTranslate(t *testing.T) {
+	addr, _ := startTestServer(t)
+
+	out, err := runCLI(t, string(readFixture(t, "translation_1.0.0_record.json")),
+		"--server", addr, "-o", "json", "pipeline", "--in", "-", "--steps", "validate,translate:a2a")
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, `"passed": 1`) {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func TestFetchRemote(t *testing.T) {
+	addr, _ := startTestServer(t)
+	card := readFixture(t, "translation_a2a.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(card)
+	}))
+	defer srv.Close()
+
+	out, err := runCLI(t, "", "--server", addr, "fetch", "a2a", "--author", "Jane", "--fallback-locator", "https://github.com/acme/agents", srv.URL+"/card.json")
+	if err != nil {
+		t.Fatalf("fetch a2a: %v\n%s", err, out)
+	}
+
+	record := decodeRecordOutput(t, out)
+	if authors, _ := record["authors"].([]any); len(authors) != 1 || authors[0] != "Jane" {
+		t.Errorf("authors override not applied: %v", record["authors"])
+	}
+
+	if locators, _ := record["locators"].([]any); len(locators) != 1 {
+		t.Errorf("fallback locator not applied: %v", record["locators"])
+	}
+
+	if _, err := runCLI(t, "", "--server", addr, "--oasf-version", "0.7.0", "fetch", "a2a", srv.URL+"/card.json"); err == nil {
+		t.Error("expected an error for an --oasf-version the server does not produce")
+	}
+}
+
+func TestRemoteUnavailable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	addr := lis.Addr().String()
+	_ = lis.Close()
+
+	_, err = runCLI(t, validRecord, "--server", addr, "validate", "-")
+	if err == nil || errors.Is(err, errChecksFailed) || !errors.Is(err, errServer) {
+		t.Errorf("expected a server error, got %v", err)
+	}
+}
+
+func TestServerFlagsRequireServer(t *testing.T) {
+	if _, err := runCLI(t, validRecord, "--tls", "validate", "-"); err == nil {
+		t.Error("expected --tls without --server to fail")
+	}
+}
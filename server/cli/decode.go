@@ -0,0 +1,261 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// decodeSummary is the machine-readable form of the decode command output.
+type decodeSummary struct {
+	Name          string         `json:"name"              yaml:"name"`
+	Version       string         `json:"version,omitempty" yaml:"version,omitempty"`
+	SchemaVersion string         `json:"schema_version"    yaml:"schema_version"`
+	ProtoVersion  string         `json:"proto_version"     yaml:"proto_version"`
+	Modules       []string       `json:"modules"           yaml:"modules"`
+	Skills        int            `json:"skills"            yaml:"skills"`
+	Domains       int            `json:"domains"           yaml:"domains"`
+	Locators      int            `json:"locators"          yaml:"locators"`
+	Record        map[string]any `json:"record"            yaml:"record"`
+}
+
+func newDecodeCommand(g *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode <file|bundle|->",
+		Short: "Decode an OASF record and inspect it",
+		Long: `Decode an OASF record into the typed OASF model for its schema version.
+
+Prints the detected schema version, the modules carried by the record and a
+summary table. With --output json or yaml, the summary is printed together with
+the full typed record. For a record bundle, every record is decoded and
+reported, and the command exits with 1 when one fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDecode(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args[0], g)
+		},
+	}
+
+	return cmd
+}
+
+func runDecode(ctx context.Context, stdin io.Reader, out io.Writer, arg string, g *globalOptions) error {
+	inputs, err := resolveInputs([]string{arg}, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) > 0 && inputs[0].bundle != "" {
+		return runDecodeBundle(ctx, out, inputs, g)
+	}
+
+	if len(inputs) != 1 {
+		return fmt.Errorf("decode expects a single record, %s matched %d files", arg, len(inputs))
+	}
+
+	record, err := parseRecord(inputs[0])
+	if err != nil {
+		return err
+	}
+
+	b, err := g.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	summary, err := decodeRecordSummary(ctx, b, record)
+	if err != nil {
+		return fmt.Errorf("%s: %w", inputs[0].name, err)
+	}
+
+	if g.structured() {
+		return writeStructured(out, g.output, summary)
+	}
+
+	return printDecodeTable(out, summary)
+}
+
+// decodeEntry is the decode result of a record of a bundle.
+type decodeEntry struct {
+	File           string `json:"file"            yaml:"file"`
+	Error          string `json:"error,omitempty" yaml:"error,omitempty"`
+	*decodeSummary `yaml:",inline"`
+}
+
+// runDecodeBundle decodes every record of a bundle and reports each one; a
+// record that fails to decode does not stop the others.
+func runDecodeBundle(ctx context.Context, out io.Writer, inputs []inputFile, g *globalOptions) error {
+	b, err := g.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	entries := make([]decodeEntry, 0, len(inputs))
+	failed := false
+
+	for _, in := range inputs {
+		entry := decodeEntry{File: in.name}
+
+		record, err := parseRecord(in)
+		if err == nil {
+			entry.decodeSummary, err = decodeRecordSummary(ctx, b, record)
+		}
+
+		if err != nil {
+			entry.Error = err.Error()
+			failed = true
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if g.structured() {
+		if err := writeStructured(out, g.output, entries); err != nil {
+			return err
+		}
+	} else {
+		for i, entry := range entries {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+
+			fmt.Fprintf(out, "%s\n", entry.File)
+
+			if entry.Error != "" {
+				fmt.Fprintf(out, "  error: %s\n", entry.Error)
+
+				continue
+			}
+
+			if err := printDecodeTable(out, entry.decodeSummary); err != nil {
+				return err
+			}
+		}
+	}
+
+	if failed {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// decodeRecordSummary decodes the record and collects the inspection summary.
+func decodeRecordSummary(ctx context.Context, b backend, record *structpb.Struct) (*decodeSummary, error) {
+	decoded, err := b.DecodeRecord(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode record: %w", err)
+	}
+
+	typed, protoVersion, err := typedRecordMap(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaVersion, err := decoder.GetRecordSchemaVersion(record)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := record.GetFields()
+
+	summary := &decodeSummary{
+		Name:          fields["name"].GetStringValue(),
+		Version:       fields["version"].GetStringValue(),
+		SchemaVersion: schemaVersion,
+		ProtoVersion:  protoVersion,
+		Modules:       []string{},
+		Skills:        len(fields["skills"].GetListValue().GetValues()),
+		Domains:       len(fields["domains"].GetListValue().GetValues()),
+		Locators:      len(fields["locators"].GetListValue().GetValues()),
+		Record:        typed,
+	}
+
+	for _, module := range fields["modules"].GetListValue().GetValues() {
+		if name := module.GetStructValue().GetFields()["name"].GetStringValue(); name != "" {
+			summary.Modules = append(summary.Modules, name)
+		}
+	}
+
+	return summary, nil
+}
+
+// typedRecordMap renders the typed record carried by a decode response as a
+// generic map (via protojson, so field names match the OASF JSON shape) and
+// returns the proto version it was decoded into.
+func typedRecordMap(decoded *decodingv1.DecodeRecordResponse) (map[string]any, string, error) {
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+
+	var (
+		raw          []byte
+		err          error
+		protoVersion string
+	)
+
+	switch {
+	case decoded.GetV1() != nil:
+		protoVersion = "v1"
+		raw, err = marshaler.Marshal(decoded.GetV1())
+	case decoded.GetV1Alpha2() != nil:
+		protoVersion = "v1alpha2"
+		raw, err = marshaler.Marshal(decoded.GetV1Alpha2())
+	case decoded.GetV1Alpha1() != nil:
+		protoVersion = "v1alpha1"
+		raw, err = marshaler.Marshal(decoded.GetV1Alpha1())
+	default:
+		return nil, "", errors.New("decoded record is empty")
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal decoded record: %w", err)
+	}
+
+	var typed map[string]any
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal decoded record: %w", err)
+	}
+
+	return typed, protoVersion, nil
+}
+
+func printDecodeTable(out io.Writer, summary *decodeSummary) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	rows := [][2]string{
+		{"NAME", summary.Name},
+		{"VERSION", summary.Version},
+		{"SCHEMA VERSION", summary.SchemaVersion},
+		{"PROTO VERSION", summary.ProtoVersion},
+		{"SKILLS", fmt.Sprint(summary.Skills)},
+		{"DOMAINS", fmt.Sprint(summary.Domains)},
+		{"LOCATORS", fmt.Sprint(summary.Locators)},
+		{"MODULES", fmt.Sprint(len(summary.Modules))},
+	}
+
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1])
+	}
+
+	for _, module := range summary.Modules {
+		fmt.Fprintf(tw, "  - %s\n", module)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
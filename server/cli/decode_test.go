@@ -0,0 +1,100 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const moduleRecord = `{
+  "name": "example.org/agent",
+  "version": "v1.2.0",
+  "schema_version": "1.0.0",
+  "skills": [{"name": "natural_language_processing/natural_language_understanding", "id": 10101}],
+  "modules": [{"name": "integration/mcp", "data": {}}, {"name": "integration/a2a", "data": {}}]
+}`
+
+func TestDecodeTable(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"record.json": moduleRecord})
+
+	out, err := runCLI(t, "", "decode", filepath.Join(dir, "record.json"))
+	if err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"example.org/agent", "SCHEMA VERSION  1.0.0", "PROTO VERSION   v1", "SKILLS          1", "- integration/mcp", "- integration/a2a"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDecodeJSONAndYAML(t *testing.T) {
+	out, err := runCLI(t, moduleRecord, "decode", "--output", "json", "-")
+	if err != nil {
+		t.Fatalf("decode --output json: %v\n%s", err, out)
+	}
+
+	var summary decodeSummary
+	if err := json.Unmarshal([]byte(out), &summary); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+
+	if summary.SchemaVersion != "1.0.0" || len(summary.Modules) != 2 || summary.Record["name"] != "example.org/agent" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	out, err = runCLI(t, moduleRecord, "decode", "-o", "yaml", "-")
+	if err != nil {
+		t.Fatalf("decode -o yaml: %v\n%s", err, out)
+	}
+
+	var fromYAML decodeSummary
+	if err := yaml.Unmarshal([]byte(out), &fromYAML); err != nil {
+		t.Fatalf("invalid YAML output: %v\n%s", err, out)
+	}
+
+	if fromYAML.ProtoVersion != "v1" {
+		t.Errorf("proto_version = %q, want v1", fromYAML.ProtoVersion)
+	}
+
+	if _, err := runCLI(t, moduleRecord, "decode", "--output", "xml", "-"); err == nil {
+		t.Error("expected an error for an unknown output format")
+	}
+}
+
+func TestDecodeUnsupportedVersion(t *testing.T) {
+	_, err := runCLI(t, `{"schema_version": "9.0.0"}`, "decode", "-")
+	if err == nil || !strings.Contains(err.Error(), "unsupported OASF version") {
+		t.Fatalf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestDecodeBundle(t *testing.T) {
+	bundle := `[` + validRecord + `, {"name": "example.org/broken", "schema_version": "9.0.0"}]`
+
+	out, err := runCLI(t, bundle, "decode", "-o", "json", "-")
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(out), &entries); err != nil || len(entries) != 2 {
+		t.Fatalf("unexpected output: %v\n%s", err, out)
+	}
+
+	if entries[0]["file"] != "<stdin>[0]" || entries[0]["name"] != "example.org/agent" || entries[0]["error"] != nil {
+		t.Errorf("unexpected first entry: %v", entries[0])
+	}
+
+	if entries[1]["error"] == nil || entries[1]["name"] != nil {
+		t.Errorf("expected the second entry to fail: %v", entries[1])
+	}
+}
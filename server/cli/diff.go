@@ -0,0 +1,341 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+// Record sections compared by the diff command.
+const (
+	sectionMetadata = "metadata"
+	sectionSkills   = "skills"
+	sectionDomains  = "domains"
+	sectionLocators = "locators"
+	sectionModules  = "modules"
+)
+
+// Kinds of record changes.
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+)
+
+type diffOptions struct {
+	*globalOptions
+
+	color string
+}
+
+// recordChange is a single semantic difference between two records.
+type recordChange struct {
+	Section string `json:"section"       yaml:"section"`
+	Kind    string `json:"kind"          yaml:"kind"`
+	Path    string `json:"path"          yaml:"path"`
+	Old     any    `json:"old,omitempty" yaml:"old,omitempty"`
+	New     any    `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+func newDiffCommand(g *globalOptions) *cobra.Command {
+	opts := &diffOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "diff <a.json> <b.json>",
+		Short: "Show the semantic difference between two OASF records",
+		Long: `Compare two OASF records section by section: metadata fields, skills and
+domains added or removed, locators, and module data changes.
+
+Exit codes: 0 when the records are equivalent, 1 when they differ, 2 when the
+comparison could not run.`,
+		Args: cobra.ExactArgs(2), //nolint:mnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd.InOrStdin(), cmd.OutOrStdout(), args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.color, "color", "auto", "Colorize output: auto, always or never")
+
+	return cmd
+}
+
+func runDiff(stdin io.Reader, out io.Writer, args []string, opts *diffOptions) error {
+	records := make([]map[string]any, 0, len(args))
+
+	for _, arg := range args {
+		inputs, err := resolveInputs([]string{arg}, stdin)
+		if err != nil {
+			return err
+		}
+
+		if len(inputs) != 1 {
+			return fmt.Errorf("diff expects a single record per argument, %s matched %d files", arg, len(inputs))
+		}
+
+		record, err := parseRecord(inputs[0])
+		if err != nil {
+			return err
+		}
+
+		records = append(records, record.AsMap())
+	}
+
+	changes := diffRecords(records[0], records[1])
+
+	if opts.structured() {
+		if err := writeStructured(out, opts.output, changes); err != nil {
+			return err
+		}
+	} else {
+		color, err := useColor(opts.color, out)
+		if err != nil {
+			return err
+		}
+
+		printDiff(out, changes, color)
+	}
+
+	if len(changes) > 0 {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// diffRecords returns the semantic changes needed to turn a into b.
+func diffRecords(a, b map[string]any) []recordChange {
+	changes := []recordChange{}
+
+	for _, key := range unionKeys(a, b) {
+		switch key {
+		case sectionSkills, sectionDomains, sectionLocators:
+			changes = append(changes, diffNamedList(key, a[key], b[key])...)
+		case sectionModules:
+			changes = append(changes, diffModules(a[key], b[key])...)
+		default:
+			changes = append(changes, diffValues(sectionMetadata, key, a[key], b[key])...)
+		}
+	}
+
+	// Group changes by section, keeping the path order within each section.
+	sort.SliceStable(changes, func(i, j int) bool {
+		return sectionRank(changes[i].Section) < sectionRank(changes[j].Section)
+	})
+
+	return changes
+}
+
+func sectionRank(section string) int {
+	return slices.Index([]string{sectionMetadata, sectionSkills, sectionDomains, sectionLocators, sectionModules}, section)
+}
+
+// diffNamedList compares list entries by identity (see entryKey), reporting
+// entries present in only one of the records.
+func diffNamedList(section string, a, b any) []recordChange {
+	left := indexEntries(a)
+	right := indexEntries(b)
+
+	var changes []recordChange
+
+	for _, key := range unionKeys(left, right) {
+		l, inLeft := left[key]
+		r, inRight := right[key]
+
+		switch {
+		case !inLeft:
+			changes = append(changes, recordChange{Section: section, Kind: changeAdded, Path: key, New: r})
+		case !inRight:
+			changes = append(changes, recordChange{Section: section, Kind: changeRemoved, Path: key, Old: l})
+		case !reflect.DeepEqual(l, r):
+			changes = append(changes, recordChange{Section: section, Kind: changeChanged, Path: key, Old: l, New: r})
+		}
+	}
+
+	return changes
+}
+
+// diffModules matches modules by name and diffs their data field by field.
+func diffModules(a, b any) []recordChange {
+	left := indexEntries(a)
+	right := indexEntries(b)
+
+	var changes []recordChange
+
+	for _, name := range unionKeys(left, right) {
+		l, inLeft := left[name]
+		r, inRight := right[name]
+
+		switch {
+		case !inLeft:
+			changes = append(changes, recordChange{Section: sectionModules, Kind: changeAdded, Path: name, New: r})
+		case !inRight:
+			changes = append(changes, recordChange{Section: sectionModules, Kind: changeRemoved, Path: name, Old: l})
+		default:
+			lm, _ := l.(map[string]any)
+			rm, _ := r.(map[string]any)
+
+			for _, key := range unionKeys(lm, rm) {
+				changes = append(changes, diffValues(sectionModules, name+"."+key, lm[key], rm[key])...)
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffValues recursively compares two JSON values, descending into objects so
+// changes are reported at the deepest differing path.
+func diffValues(section, path string, a, b any) []recordChange {
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	switch {
+	case a == nil:
+		return []recordChange{{Section: section, Kind: changeAdded, Path: path, New: b}}
+	case b == nil:
+		return []recordChange{{Section: section, Kind: changeRemoved, Path: path, Old: a}}
+	}
+
+	am, aIsMap := a.(map[string]any)
+	bm, bIsMap := b.(map[string]any)
+
+	if !aIsMap || !bIsMap {
+		return []recordChange{{Section: section, Kind: changeChanged, Path: path, Old: a, New: b}}
+	}
+
+	var changes []recordChange
+
+	for _, key := range unionKeys(am, bm) {
+		changes = append(changes, diffValues(section, path+"."+key, am[key], bm[key])...)
+	}
+
+	return changes
+}
+
+// indexEntries indexes a JSON list by entry identity.
+func indexEntries(list any) map[string]any {
+	items, _ := list.([]any)
+	index := make(map[string]any, len(items))
+
+	for _, item := range items {
+		index[entryKey(item)] = item
+	}
+
+	return index
+}
+
+// entryKey identifies a list entry: by name, then id, then the entry itself
+// (locators have neither and are compared as a whole).
+func entryKey(item any) string {
+	if m, ok := item.(map[string]any); ok {
+		if name, ok := m["name"].(string); ok && name != "" {
+			return name
+		}
+
+		if id, ok := m["id"]; ok {
+			return fmt.Sprint(id)
+		}
+	}
+
+	raw, _ := json.Marshal(item)
+
+	return string(raw)
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func useColor(mode string, out io.Writer) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		f, ok := out.(*os.File)
+		if !ok || os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+
+		info, err := f.Stat()
+
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid --color value %q (want auto, always or never)", mode)
+	}
+}
+
+func printDiff(out io.Writer, changes []recordChange, color bool) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "records are equivalent")
+
+		return
+	}
+
+	section := ""
+
+	for _, c := range changes {
+		if c.Section != section {
+			section = c.Section
+			fmt.Fprintf(out, "%s:\n", section)
+		}
+
+		var line, ansi string
+
+		switch c.Kind {
+		case changeAdded:
+			line, ansi = fmt.Sprintf("+ %s: %s", c.Path, compactJSON(c.New)), ansiGreen
+		case changeRemoved:
+			line, ansi = fmt.Sprintf("- %s: %s", c.Path, compactJSON(c.Old)), ansiRed
+		default:
+			line, ansi = fmt.Sprintf("~ %s: %s -> %s", c.Path, compactJSON(c.Old), compactJSON(c.New)), ansiYellow
+		}
+
+		if color {
+			line = ansi + line + ansiReset
+		}
+
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+}
+
+func compactJSON(v any) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(raw)
+}
@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	diffRecordA = `{
+		"name": "example.org/agent", "schema_version": "1.0.0", "version": "v1.0.0",
+		"description": "old",
+		"skills": [{"name": "natural_language_processing/summarization", "id": 10201}],
+		"modules": [{"name": "integration/mcp", "data": {"name": "srv", "transport": {"type": "stdio"}}}]
+	}`
+	diffRecordB = `{
+		"name": "example.org/agent", "schema_version": "1.0.0", "version": "v1.1.0",
+		"description": "old",
+		"skills": [{"name": "natural_language_processing/translation", "id": 10202}],
+		"modules": [
+			{"name": "integration/mcp", "data": {"name": "srv", "transport": {"type": "http"}}},
+			{"name": "integration/a2a", "data": {}}
+		]
+	}`
+)
+
+func TestDiffRecords(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.json": diffRecordA, "b.json": diffRecordB})
+
+	out, err := runCLI(t, "", "diff", "--color", "never", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"))
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed for differing records, got %v\n%s", err, out)
+	}
+
+	for _, want := range []string{
+		`~ version: "v1.0.0" -> "v1.1.0"`,
+		"- natural_language_processing/summarization",
+		"+ natural_language_processing/translation",
+		`~ integration/mcp.data.transport.type: "stdio" -> "http"`,
+		"+ integration/a2a",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "description") || strings.Contains(out, "\033[") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if strings.Index(out, "metadata:") > strings.Index(out, "skills:") {
+		t.Errorf("sections out of order:\n%s", out)
+	}
+}
+
+func TestDiffJSONAndEqual(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.json": diffRecordA, "b.json": diffRecordB})
+
+	out, err := runCLI(t, "", "diff", "--output", "json", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"))
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v", err)
+	}
+
+	var changes []recordChange
+	if err := json.Unmarshal([]byte(out), &changes); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+
+	if len(changes) != 5 { //nolint:mnd
+		t.Errorf("expected 5 changes, got %d: %s", len(changes), out)
+	}
+
+	out, err = runCLI(t, "", "diff", filepath.Join(dir, "a.json"), filepath.Join(dir, "a.json"))
+	if err != nil || !strings.Contains(out, "records are equivalent") {
+		t.Errorf("identical records: err=%v\n%s", err, out)
+	}
+}
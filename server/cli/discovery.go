@@ -0,0 +1,98 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/server/discovery"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	discoveryReadHeaderTimeout = 10 * time.Second
+	discoveryShutdownTimeout   = 5 * time.Second
+)
+
+// runDiscovery serves the discovery documents of the input records until the
+// context is done.
+func runDiscovery(ctx context.Context, stdin io.Reader, out io.Writer, opts *serverOptions) error {
+	if len(opts.inputs) == 0 {
+		return errors.New("discovery mode requires --input")
+	}
+
+	handler, err := newDiscoveryHandler(ctx, stdin, opts.inputs)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	lc := &net.ListenConfig{}
+
+	listen, err := lc.Listen(ctx, "tcp", opts.listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.listen, err)
+	}
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: discoveryReadHeaderTimeout}
+
+	fmt.Fprintf(out, "serving discovery documents on %s\n", listen.Addr())
+
+	for _, path := range handler.Paths() {
+		fmt.Fprintf(out, "  %s\n", path)
+	}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- srv.Serve(listen)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("discovery server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), discoveryShutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx) //nolint:wrapcheck
+}
+
+// newDiscoveryHandler loads the records at the input locations.
+func newDiscoveryHandler(ctx context.Context, stdin io.Reader, locations []string) (*discovery.Handler, error) {
+	inputs, err := resolvePipelineInputs(ctx, locations, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inputs) == 0 {
+		return nil, errors.New("no records found at the --input locations")
+	}
+
+	records := make([]*structpb.Struct, 0, len(inputs))
+
+	for _, in := range inputs {
+		record, err := parseRecord(in)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return discovery.New(records) //nolint:wrapcheck
+}
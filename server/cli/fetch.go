@@ -0,0 +1,179 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/a2aclient"
+	"github.com/agntcy/oasf-sdk/pkg/mcpregistry"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/spf13/cobra"
+)
+
+const defaultFetchTimeout = 30 * time.Second
+
+// a2aTokenEnv holds the bearer token for authenticated extended agent cards.
+const a2aTokenEnv = "OASF_A2A_TOKEN"
+
+type fetchOptions struct {
+	*globalOptions
+
+	recordVersion string
+	authors       []string
+	provenance    translator.Provenance
+	outFile       string
+	timeout       time.Duration
+	registryURL   string
+	serverVersion string
+	extended      bool
+	githubAPIURL  string
+}
+
+func newFetchCommand(g *globalOptions) *cobra.Command {
+	opts := &fetchOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "fetch",
+		Short: "Fetch live agent sources and convert them to OASF records",
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.recordVersion, "record-version", "", "Override the record version taken from the source")
+	cmd.PersistentFlags().StringSliceVar(&opts.authors, "author", nil, "Override the record authors taken from the source")
+	cmd.PersistentFlags().StringSliceVar(&opts.provenance.Authors, "default-author", nil, "Authors of records whose source has none")
+	cmd.PersistentFlags().StringVar(&opts.provenance.Organization, "organization", "", "Author of records whose source has none, when --default-author is not set")
+	cmd.PersistentFlags().StringVar(&opts.provenance.Contact, "contact", "", "Contact of --organization, as \"<organization> <<contact>>\"")
+	cmd.PersistentFlags().StringVar(&opts.provenance.FallbackLocator, "fallback-locator", "", "Source code locator URL of records whose source has none (omitted by default)")
+	cmd.PersistentFlags().StringVar(&opts.outFile, "out", "", "Write the record to a file instead of stdout")
+	cmd.PersistentFlags().DurationVar(&opts.timeout, "timeout", defaultFetchTimeout, "HTTP timeout")
+
+	a2aCmd := &cobra.Command{
+		Use:   "a2a <url>",
+		Short: "Fetch an A2A agent card",
+		Long: `Fetch an A2A agent card and convert it to an OASF record.
+
+The URL may point at the card itself (a .json path) or at the agent's base URL,
+in which case the well-known card locations are tried in order:
+` + strings.Join(a2aclient.WellKnownPaths, ", ") + `.
+
+With --extended, the authenticated extended card is fetched instead when the
+agent advertises one, using the bearer token in $` + a2aTokenEnv + `.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFetchA2A(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	mcpCmd := &cobra.Command{
+		Use:   "mcp <server-name>",
+		Short: "Fetch an MCP registry entry",
+		Long: `Fetch a server entry (e.g. io.github.vendor/server) from an MCP registry and
+convert it to an OASF record. Requests failing with 429 or 5xx are retried.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFetchMCP(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	githubCmd := &cobra.Command{
+		Use:   "github <owner/repo|url>",
+		Short: "Bootstrap a draft record from a GitHub repository",
+		Long: `Read a GitHub repository's metadata, latest release and root files and
+convert them to a draft OASF record (see translator.GitHubRepoToRecord): name,
+description, owner, latest release version, a source_code locator and, for
+repositories with a Dockerfile, a ghcr.io container_image locator. Topics and
+license are kept as github.* annotations; skills and domains are left to fill
+in. A token in $` + githubTokenEnv + ` raises the API rate limit and gives
+access to private repositories.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFetchGitHub(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	githubCmd.Flags().StringVar(&opts.githubAPIURL, "api-url", defaultGitHubAPIURL, "GitHub API base URL")
+
+	a2aCmd.Flags().BoolVar(&opts.extended, "extended", false, "Fetch the authenticated extended card when the agent has one")
+
+	mcpCmd.Flags().StringVar(&opts.registryURL, "registry-url", mcpregistry.DefaultURL, "MCP registry base URL")
+	mcpCmd.Flags().StringVar(&opts.serverVersion, "server-version", mcpregistry.LatestVersion, "Server version to fetch")
+
+	cmd.AddCommand(a2aCmd, mcpCmd, githubCmd)
+
+	return cmd
+}
+
+func runFetchA2A(ctx context.Context, out io.Writer, rawURL string, opts *fetchOptions) error {
+	clientOpts := []a2aclient.Option{a2aclient.WithHTTPClient(&http.Client{Timeout: opts.timeout})}
+
+	if opts.extended {
+		token := os.Getenv(a2aTokenEnv)
+		if token == "" {
+			return fmt.Errorf("--extended requires a bearer token in $%s", a2aTokenEnv)
+		}
+
+		clientOpts = append(clientOpts, a2aclient.WithAuthenticator(a2aclient.BearerToken(token)))
+	}
+
+	client := a2aclient.New(clientOpts...)
+
+	card, err := client.Fetch(ctx, rawURL)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if opts.extended && card.SupportsExtendedCard() {
+		if card, err = client.FetchExtended(ctx, card); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	record, err := b.A2AToRecord(ctx, card.Data, opts.toRecordOptions())
+	if err != nil {
+		return fmt.Errorf("failed to translate agent card: %w", err)
+	}
+
+	return writeRecord(out, record, opts.outFile, opts.output)
+}
+
+func runFetchMCP(ctx context.Context, out io.Writer, serverName string, opts *fetchOptions) error {
+	client, err := mcpregistry.New(opts.registryURL, mcpregistry.WithHTTPClient(&http.Client{Timeout: opts.timeout}))
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	entry, err := client.Get(ctx, serverName, opts.serverVersion)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	record, err := b.MCPToRecord(ctx, entry.Raw, opts.toRecordOptions())
+	if err != nil {
+		return fmt.Errorf("failed to translate registry entry: %w", err)
+	}
+
+	return writeRecord(out, record, opts.outFile, opts.output)
+}
+
+func (o *fetchOptions) toRecordOptions() toRecordOptions {
+	return toRecordOptions{oasfVersion: o.oasfVersion, recordVersion: o.recordVersion, authors: o.authors, provenance: o.provenance}
+}
@@ -0,0 +1,205 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", name))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	return data
+}
+
+func decodeRecordOutput(t *testing.T, out string) map[string]any {
+	t.Helper()
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(out), &record); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+
+	return record
+}
+
+func TestFetchA2AWellKnownFallback(t *testing.T) {
+	card := readFixture(t, "translation_a2a.json")
+
+	var paths []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		if r.URL.Path != "/.well-known/agent.json" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write(card)
+	}))
+	defer srv.Close()
+
+	out, err := runCLI(t, "", "fetch", "a2a", "--record-version", "v2.0.0", srv.URL)
+	if err != nil {
+		t.Fatalf("fetch a2a: %v\n%s", err, out)
+	}
+
+	record := decodeRecordOutput(t, out)
+	if record["name"] != "burger_seller_agent" || record["version"] != "v2.0.0" {
+		t.Errorf("unexpected record: %v", record)
+	}
+
+	if len(paths) != 2 || paths[0] != "/.well-known/agent-card.json" {
+		t.Errorf("unexpected request paths %v", paths)
+	}
+}
+
+func TestFetchProvenance(t *testing.T) {
+	card := readFixture(t, "translation_a2a.json")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(card)
+	}))
+	defer srv.Close()
+
+	out, err := runCLI(t, "", "fetch", "a2a", "--organization", "ACME Corp", "--fallback-locator", "https://github.com/acme/agents", srv.URL+"/card.json")
+	if err != nil {
+		t.Fatalf("fetch a2a: %v\n%s", err, out)
+	}
+
+	record := decodeRecordOutput(t, out)
+	if authors, _ := record["authors"].([]any); len(authors) != 1 || authors[0] != "Example Organization" {
+		t.Errorf("authors = %v, want the provider of the card", record["authors"])
+	}
+
+	if locators, _ := record["locators"].([]any); len(locators) != 1 {
+		t.Errorf("locators = %v, want the fallback locator", record["locators"])
+	}
+}
+
+func TestFetchA2AExtended(t *testing.T) {
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/agent-card.json":
+			_, _ = w.Write([]byte(`{"name": "public_agent", "version": "1.0.0", "url": "` + srv.URL + `", "supportsAuthenticatedExtendedCard": true}`))
+		case "/agent/authenticatedExtendedCard":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"name": "extended_agent", "version": "1.0.0"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	if _, err := runCLI(t, "", "fetch", "a2a", "--extended", srv.URL); err == nil {
+		t.Error("expected an error without a token")
+	}
+
+	t.Setenv(a2aTokenEnv, "secret")
+
+	out, err := runCLI(t, "", "fetch", "a2a", "--extended", srv.URL)
+	if err != nil {
+		t.Fatalf("fetch a2a: %v\n%s", err, out)
+	}
+
+	if record := decodeRecordOutput(t, out); record["name"] != "extended_agent" {
+		t.Errorf("expected the extended card, got %v", record)
+	}
+}
+
+func TestFetchMCP(t *testing.T) {
+	entry := readFixture(t, "translation_mcp_minimal_local.json")
+
+	var gotPath string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		_, _ = w.Write(entry)
+	}))
+	defer srv.Close()
+
+	out, err := runCLI(t, "", "fetch", "mcp", "--registry-url", srv.URL, "ai.mcpcap/mcpcap")
+	if err != nil {
+		t.Fatalf("fetch mcp: %v\n%s", err, out)
+	}
+
+	if gotPath != "/v0/servers/ai.mcpcap%2Fmcpcap/versions/latest" {
+		t.Errorf("unexpected request path %q", gotPath)
+	}
+
+	if record := decodeRecordOutput(t, out); record["name"] != "ai.mcpcap/mcpcap" {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestFetchNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	if _, err := runCLI(t, "", "fetch", "mcp", "--registry-url", srv.URL, "missing/server"); err == nil {
+		t.Fatal("expected an error for a missing registry entry")
+	}
+}
+
+func TestFetchGitHub(t *testing.T) {
+	var auth string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+
+		switch r.URL.Path {
+		case "/repos/example/agent":
+			_, _ = w.Write([]byte(`{"full_name": "example/agent", "description": "An agent", "html_url": "https://github.com/example/agent",
+				"owner": {"login": "example"}, "topics": ["a2a"], "license": {"spdx_id": "MIT"}}`))
+		case "/repos/example/agent/releases":
+			_, _ = w.Write([]byte(`[{"tag_name": "v1.4.0"}]`))
+		case "/repos/example/agent/contents/":
+			_, _ = w.Write([]byte(`[{"name": "Dockerfile", "type": "file"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(githubTokenEnv, "gh-token")
+
+	out, err := runCLI(t, "", "fetch", "github", "--api-url", srv.URL, "https://github.com/example/agent.git")
+	if err != nil {
+		t.Fatalf("fetch github: %v\n%s", err, out)
+	}
+
+	record := decodeRecordOutput(t, out)
+	if record["name"] != "github.com/example/agent" || record["version"] != "v1.4.0" || len(record["locators"].([]any)) != 2 {
+		t.Errorf("unexpected record: %v", record)
+	}
+
+	if auth != "Bearer gh-token" {
+		t.Errorf("token not sent, Authorization = %q", auth)
+	}
+
+	for _, arg := range []string{"example", "https://gitlab.com/example/agent"} {
+		if _, err := runCLI(t, "", "fetch", "github", "--api-url", srv.URL, arg); err == nil {
+			t.Errorf("%s: expected an error", arg)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+	githubTokenEnv      = "GITHUB_TOKEN"
+	// maxGitHubResponseSize caps the size of GitHub API responses.
+	maxGitHubResponseSize = 10 << 20
+	// githubReleasesPerPage is how many recent releases are searched for the
+	// latest stable one.
+	githubReleasesPerPage = 20
+)
+
+func runFetchGitHub(ctx context.Context, out io.Writer, arg string, opts *fetchOptions) error {
+	repo, err := parseGitHubRepo(arg)
+	if err != nil {
+		return err
+	}
+
+	api := &githubAPI{
+		baseURL: strings.TrimSuffix(opts.githubAPIURL, "/"),
+		client:  &http.Client{Timeout: opts.timeout},
+		token:   os.Getenv(githubTokenEnv),
+	}
+
+	payload := map[string]any{}
+
+	if payload["repository"], err = api.get(ctx, "/repos/"+repo); err != nil {
+		return err
+	}
+
+	if payload["releases"], err = api.get(ctx, fmt.Sprintf("/repos/%s/releases?per_page=%d", repo, githubReleasesPerPage)); err != nil {
+		return err
+	}
+
+	// Empty repositories have no contents; they simply get no file locators.
+	if payload["files"], err = api.get(ctx, "/repos/"+repo+"/contents/"); err != nil {
+		payload["files"] = []any{}
+	}
+
+	data, err := structpb.NewStruct(payload)
+	if err != nil {
+		return fmt.Errorf("failed to convert repository data: %w", err)
+	}
+
+	record, err := translator.GitHubRepoToRecord(data, opts.toRecordOptions().translatorOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to translate repository: %w", err)
+	}
+
+	return writeRecord(out, record, opts.outFile, opts.output)
+}
+
+// parseGitHubRepo returns the "owner/repo" of a repository given as such or
+// as a github.com URL.
+func parseGitHubRepo(arg string) (string, error) {
+	repo := arg
+
+	if u, err := url.Parse(arg); err == nil && u.Host != "" {
+		if u.Host != "github.com" && u.Host != "www.github.com" {
+			return "", fmt.Errorf("not a GitHub repository URL: %s", arg)
+		}
+
+		repo = u.Path
+	}
+
+	repo = strings.TrimSuffix(strings.Trim(repo, "/"), ".git")
+
+	parts := strings.Split(repo, "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid GitHub repository %q (want owner/repo or a github.com URL)", arg)
+	}
+
+	return parts[0] + "/" + parts[1], nil
+}
+
+// githubAPI is a minimal GitHub REST API client.
+type githubAPI struct {
+	baseURL string
+	client  *http.Client
+	token   string
+}
+
+// get GETs a path and decodes the JSON response.
+func (a *githubAPI) get(ctx context.Context, path string) (any, error) {
+	target := a.baseURL + path
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GET request to %s: %w", target, err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send GET request to %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGitHubResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", target, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch %s: HTTP %d, body: %s", target, resp.StatusCode, string(body))
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse response from %s: %w", target, err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,222 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// stdinArg is the argument that selects standard input.
+	stdinArg = "-"
+	// stdinName is the input name reported for standard input.
+	stdinName = "<stdin>"
+)
+
+// recordExt is the extension of record files picked up from directories.
+const recordExt = ".json"
+
+// inputFile is a single record source resolved from a command argument.
+type inputFile struct {
+	name string
+	data []byte
+	// bundle is the file of records expanded from a bundle, named
+	// "<bundle>[<index>]".
+	bundle string
+}
+
+// resolveInputs expands command arguments into record sources. Each argument
+// may be a file, a directory (its *.json files, non-recursive), a glob pattern,
+// or "-" for standard input. Record bundles (see record.ParseBundle) are
+// expanded into one source per record, with the bundle defaults applied.
+// Results keep argument order; directory and glob matches are sorted so
+// output is deterministic.
+func resolveInputs(args []string, stdin io.Reader) ([]inputFile, error) {
+	var inputs []inputFile
+
+	for _, arg := range args {
+		if arg == stdinArg {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read stdin: %w", err)
+			}
+
+			expanded, err := expandBundle(inputFile{name: stdinName, data: data})
+			if err != nil {
+				return nil, err
+			}
+
+			inputs = append(inputs, expanded...)
+
+			continue
+		}
+
+		paths, err := expandPath(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+
+			expanded, err := expandBundle(inputFile{name: path, data: data})
+			if err != nil {
+				return nil, err
+			}
+
+			inputs = append(inputs, expanded...)
+		}
+	}
+
+	return inputs, nil
+}
+
+// expandBundle returns the records of a bundle as sources, or the input
+// itself when it is a single JSON record.
+func expandBundle(in inputFile) ([]inputFile, error) {
+	if !record.IsBundle(in.data) {
+		return []inputFile{in}, nil
+	}
+
+	bundle, err := record.ParseBundle(in.data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", in.name, err)
+	}
+
+	records, err := bundle.Records()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", in.name, err)
+	}
+
+	inputs := make([]inputFile, 0, len(records))
+
+	for i, rec := range records {
+		data, err := protojson.Marshal(rec)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", in.name, i, err)
+		}
+
+		inputs = append(inputs, inputFile{name: fmt.Sprintf("%s[%d]", in.name, i), data: data, bundle: in.name})
+	}
+
+	return inputs, nil
+}
+
+// streamInputs reads the JSON values of r, e.g. NDJSON records, as they
+// arrive and hands them to handle in batches of up to size, named
+// "<stdin>[<index>]", so unbounded streams are processed with bounded
+// memory. Bundles among the values are expanded.
+func streamInputs(r io.Reader, size int, handle func([]inputFile) error) error {
+	dec := json.NewDecoder(r)
+	batch := make([]inputFile, 0, size)
+
+	for i := 0; ; i++ {
+		var raw json.RawMessage
+
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			if flushErr := flushInputs(batch, handle); flushErr != nil {
+				return flushErr
+			}
+
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+
+		expanded, err := expandBundle(inputFile{name: fmt.Sprintf("%s[%d]", stdinName, i), data: raw})
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, expanded...)
+
+		if len(batch) >= size {
+			if err := handle(batch); err != nil {
+				return err
+			}
+
+			batch = batch[:0]
+		}
+	}
+
+	return flushInputs(batch, handle)
+}
+
+func flushInputs(batch []inputFile, handle func([]inputFile) error) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return handle(batch)
+}
+
+// expandPath resolves a single non-stdin argument into file paths.
+func expandPath(arg string) ([]string, error) {
+	info, err := os.Stat(arg)
+	if err == nil {
+		if !info.IsDir() {
+			return []string{arg}, nil
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", arg, err)
+		}
+
+		var paths []string
+
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), recordExt) {
+				paths = append(paths, filepath.Join(arg, entry.Name()))
+			}
+		}
+
+		return paths, nil
+	}
+
+	matches, globErr := filepath.Glob(arg)
+	if globErr != nil {
+		return nil, fmt.Errorf("invalid glob pattern %q: %w", arg, globErr)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no such file, directory or match: %s", arg)
+	}
+
+	sort.Strings(matches)
+
+	return matches, nil
+}
+
+// parseRecord decodes a record source into a struct.
+func parseRecord(in inputFile) (*structpb.Struct, error) {
+	rec, err := decoder.JsonToProto(in.data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", in.name, err)
+	}
+
+	if rec == nil {
+		return nil, fmt.Errorf("%s: record is empty", in.name)
+	}
+
+	return rec, nil
+}
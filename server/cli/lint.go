@@ -0,0 +1,360 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"github.com/spf13/cobra"
+)
+
+// Lint report formats for CI, used instead of --output when set.
+const (
+	lintFormatSARIF  = "sarif"
+	lintFormatGitHub = "github"
+)
+
+// Rule IDs for findings reported by schema validation rather than a lint rule.
+const (
+	ruleSchemaError   = "schema-validation"
+	ruleSchemaWarning = "schema-recommendation"
+)
+
+type lintOptions struct {
+	validateOptions
+
+	format string
+}
+
+// lintFinding is a linter finding attributed to an input.
+type lintFinding struct {
+	File     string          `json:"file"           yaml:"file"`
+	RuleID   string          `json:"rule_id"        yaml:"rule_id"`
+	Code     messages.Code   `json:"code,omitempty" yaml:"code,omitempty"`
+	Severity linter.Severity `json:"severity"       yaml:"severity"`
+	Message  string          `json:"message"        yaml:"message"`
+	Path     string          `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// lintReport is the machine-readable form of the lint command output.
+type lintReport struct {
+	Findings []lintFinding `json:"findings" yaml:"findings"`
+	Records  int           `json:"records"  yaml:"records"`
+	Errors   int           `json:"errors"   yaml:"errors"`
+	Warnings int           `json:"warnings" yaml:"warnings"`
+	Notes    int           `json:"notes"    yaml:"notes"`
+}
+
+// ndjsonLines returns the findings, one line each with NDJSON output.
+func (r lintReport) ndjsonLines() []any {
+	return ndjsonEntries(r.Findings)
+}
+
+func newLintFinding(file string, f linter.Finding) lintFinding {
+	return lintFinding{File: file, RuleID: f.RuleID, Code: f.Code(), Severity: f.Severity, Message: f.Message, Path: f.Path}
+}
+
+func newLintCommand(g *globalOptions) *cobra.Command {
+	opts := &lintOptions{validateOptions: validateOptions{globalOptions: g}}
+
+	cmd := &cobra.Command{
+		Use:   "lint <file|dir|-|glob>...",
+		Short: "Validate and lint OASF records",
+		Long: `Validate OASF records (see "validate") and run the lint rule set on them.
+
+Findings are printed in the --output format, or with --format as SARIF 2.1.0
+(sarif, for code scanning) or GitHub Actions workflow commands that annotate
+the linted files (github).
+
+Exit codes: 0 when there are no error findings (no warnings either with
+--strict), 1 otherwise, 2 when linting could not run.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "Treat warnings as failures")
+	cmd.Flags().StringSliceVar(&opts.profiles, "profile", nil, "Schema profiles to enable (requires --schema-url)")
+	cmd.Flags().StringVar(&opts.format, "format", "", "CI report format: sarif or github (overrides --output)")
+
+	return cmd
+}
+
+func runLint(ctx context.Context, stdin io.Reader, out io.Writer, args []string, opts *lintOptions) error {
+	switch opts.format {
+	case "", lintFormatSARIF, lintFormatGitHub:
+	default:
+		return fmt.Errorf("invalid --format value %q (want sarif or github)", opts.format)
+	}
+
+	if len(opts.profiles) > 0 && opts.schemaURL == "" {
+		return errors.New("--profile requires --schema-url")
+	}
+
+	inputs, err := resolveInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	check := newRecordCheck(b, &opts.validateOptions)
+
+	var findings []lintFinding
+
+	for _, in := range inputs {
+		inputFindings, err := lintInput(ctx, in, check)
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, inputFindings...)
+	}
+
+	switch opts.format {
+	case lintFormatSARIF:
+		if err := writeSARIF(out, findings); err != nil {
+			return err
+		}
+	case lintFormatGitHub:
+		writeGitHubAnnotations(out, findings)
+	default:
+		if err := writeLintReport(out, opts.output, len(inputs), findings); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range findings {
+		if f.Severity == linter.SeverityError || (opts.strict && f.Severity == linter.SeverityWarning) {
+			return errChecksFailed
+		}
+	}
+
+	return nil
+}
+
+func lintInput(ctx context.Context, in inputFile, check recordCheck) ([]lintFinding, error) {
+	record, err := parseRecord(in)
+	if err != nil {
+		return []lintFinding{{File: in.name, RuleID: ruleSchemaError, Severity: linter.SeverityError, Message: err.Error()}}, nil
+	}
+
+	errs, warnings, err := check(ctx, record)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", in.name, err)
+	}
+
+	findings := make([]lintFinding, 0, len(errs)+len(warnings))
+
+	for _, e := range errs {
+		findings = append(findings, lintFinding{File: in.name, RuleID: ruleSchemaError, Severity: linter.SeverityError, Message: e})
+	}
+
+	for _, w := range warnings {
+		findings = append(findings, lintFinding{File: in.name, RuleID: ruleSchemaWarning, Severity: linter.SeverityWarning, Message: w})
+	}
+
+	for _, f := range linter.Lint(record) {
+		findings = append(findings, newLintFinding(in.name, f))
+	}
+
+	return findings, nil
+}
+
+func writeLintReport(out io.Writer, format string, inputs int, findings []lintFinding) error {
+	report := lintReport{Findings: findings, Records: inputs}
+	if report.Findings == nil {
+		report.Findings = []lintFinding{}
+	}
+
+	for _, f := range findings {
+		switch f.Severity {
+		case linter.SeverityError:
+			report.Errors++
+		case linter.SeverityWarning:
+			report.Warnings++
+		case linter.SeverityNote:
+			report.Notes++
+		}
+	}
+
+	if format != outputTable {
+		return writeStructured(out, format, report)
+	}
+
+	for _, f := range findings {
+		location := f.File
+		if f.Path != "" {
+			location += ": " + f.Path
+		}
+
+		fmt.Fprintf(out, "%s: %s: %s [%s]\n", location, f.Severity, f.Message, f.RuleID)
+	}
+
+	fmt.Fprintf(out, "%d record(s) linted: %d error(s), %d warning(s), %d note(s)\n",
+		report.Records, report.Errors, report.Warnings, report.Notes)
+
+	return nil
+}
+
+// writeGitHubAnnotations prints GitHub Actions workflow commands, which show
+// up as annotations on the linted files.
+func writeGitHubAnnotations(out io.Writer, findings []lintFinding) {
+	for _, f := range findings {
+		command := string(f.Severity)
+		if f.Severity == linter.SeverityNote {
+			command = "notice"
+		}
+
+		message := f.Message
+		if f.Path != "" {
+			message = f.Path + ": " + message
+		}
+
+		fmt.Fprintf(out, "::%s file=%s,title=%s::%s\n",
+			command, escapeGitHubProperty(f.File), escapeGitHubProperty(f.RuleID), escapeGitHubData(message))
+	}
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeGitHubData(s))
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	LogicalLocations []sarifLogical        `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogical struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func writeSARIF(out io.Writer, findings []lintFinding) error {
+	rules := []sarifRule{
+		{
+			ID:                   ruleSchemaError,
+			ShortDescription:     sarifMessage{Text: "Records must pass OASF schema validation."},
+			DefaultConfiguration: sarifConfiguration{Level: string(linter.SeverityError)},
+		},
+		{
+			ID:                   ruleSchemaWarning,
+			ShortDescription:     sarifMessage{Text: "Records should set the attributes recommended by the OASF schema."},
+			DefaultConfiguration: sarifConfiguration{Level: string(linter.SeverityWarning)},
+		},
+	}
+
+	for _, rule := range linter.Rules() {
+		rules = append(rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: string(rule.Severity)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+
+	for _, f := range findings {
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifact{URI: f.File}},
+		}
+		if f.Path != "" {
+			location.LogicalLocations = []sarifLogical{{FullyQualifiedName: f.Path}}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    f.RuleID,
+			Level:     string(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{location},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "oasf-sdk",
+				InformationURI: "https://github.com/agntcy/oasf-sdk",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to encode SARIF: %w", err)
+	}
+
+	return nil
+}
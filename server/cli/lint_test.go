@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lintCleanRecord = `{
+	"name": "example.org/agent", "schema_version": "1.0.0", "version": "v1.0.0",
+	"description": "An agent", "authors": ["Jane"], "created_at": "2025-01-01T00:00:00Z",
+	"skills": [{"name": "a/b", "id": 1}],
+	"locators": [{"type": "source_code", "urls": ["https://example.org"]}]
+}`
+
+func TestLintText(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"clean.json": lintCleanRecord, "bare.json": validRecord})
+
+	out, err := runCLI(t, "", "lint", dir)
+	if err != nil {
+		t.Fatalf("warnings alone must not fail lint: %v\n%s", err, out)
+	}
+
+	bare := filepath.Join(dir, "bare.json")
+	for _, want := range []string{
+		bare + ": description: warning: record has no description [description-missing]",
+		"2 record(s) linted: 0 error(s), 3 warning(s), 1 note(s)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "clean.json") {
+		t.Errorf("clean record must have no findings:\n%s", out)
+	}
+
+	if _, err := runCLI(t, "", "lint", "--strict", dir); !errors.Is(err, errChecksFailed) {
+		t.Errorf("--strict should fail on warnings, got %v", err)
+	}
+}
+
+func TestLintSARIF(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"nover.json": `{"name": "x", "description": "d"}`})
+
+	out, err := runCLI(t, "", "lint", "--format", "sarif", dir)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("output is not SARIF JSON: %v\n%s", err, out)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %s", out)
+	}
+
+	results := log.Runs[0].Results
+	if len(results) == 0 || results[0].RuleID != ruleSchemaError || results[0].Level != "error" {
+		t.Errorf("expected a schema-validation error first, got %+v", results)
+	}
+
+	if uri := results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != filepath.Join(dir, "nover.json") {
+		t.Errorf("unexpected artifact URI %q", uri)
+	}
+}
+
+func TestLintGitHubAnnotations(t *testing.T) {
+	out, err := runCLI(t, validRecord, "lint", "--format", "github", "-")
+	if err != nil {
+		t.Fatalf("lint: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{
+		"::warning file=<stdin>,title=description-missing::description: record has no description",
+		"::notice file=<stdin>,title=locators-missing::",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if got := escapeGitHubProperty("a:b,c%\n"); got != "a%3Ab%2Cc%25%0A" {
+		t.Errorf("escapeGitHubProperty = %q", got)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/server/objectstore"
+)
+
+// outputSink stores the files generated by the pipeline and returns where
+// each was written.
+type outputSink interface {
+	write(ctx context.Context, name string, data []byte) (string, error)
+}
+
+// newOutputSink returns the sink for --out: a local directory, or an s3://
+// bucket prefix. It returns nil when no output is configured.
+func newOutputSink(out string) (outputSink, error) {
+	if out == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	if strings.HasPrefix(out, objectstore.Scheme) {
+		bucket, prefix, err := objectstore.ParseURL(out)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if prefix != "" && !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+
+		return &objectSink{client: objectstore.New(objectstore.ConfigFromEnv()), bucket: bucket, prefix: prefix}, nil
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return dirSink(out), nil
+}
+
+// dirSink writes outputs to a local directory.
+type dirSink string
+
+func (d dirSink) write(_ context.Context, name string, data []byte) (string, error) {
+	path := filepath.Join(string(d), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil { //nolint:gosec,mnd
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return path, nil
+}
+
+// objectSink writes outputs below a prefix of an object storage bucket.
+type objectSink struct {
+	client *objectstore.Client
+	bucket string
+	prefix string
+}
+
+func (s *objectSink) write(ctx context.Context, name string, data []byte) (string, error) {
+	key := s.prefix + name
+	if err := s.client.Put(ctx, s.bucket, key, data); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return objectstore.Scheme + s.bucket + "/" + key, nil
+}
+
+// resolvePipelineInputs resolves pipeline inputs like resolveInputs, and
+// additionally s3://bucket/prefix arguments to the .json objects below the
+// prefix.
+func resolvePipelineInputs(ctx context.Context, args []string, stdin io.Reader) ([]inputFile, error) {
+	var (
+		inputs []inputFile
+		client *objectstore.Client
+	)
+
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, objectstore.Scheme) {
+			resolved, err := resolveInputs([]string{arg}, stdin)
+			if err != nil {
+				return nil, err
+			}
+
+			inputs = append(inputs, resolved...)
+
+			continue
+		}
+
+		if client == nil {
+			client = objectstore.New(objectstore.ConfigFromEnv())
+		}
+
+		resolved, err := resolveObjectInputs(ctx, client, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, resolved...)
+	}
+
+	return inputs, nil
+}
+
+func resolveObjectInputs(ctx context.Context, client *objectstore.Client, location string) ([]inputFile, error) {
+	bucket, prefix, err := objectstore.ParseURL(location)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	keys, err := client.List(ctx, bucket, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", location, err)
+	}
+
+	var inputs []inputFile
+
+	for _, key := range keys {
+		if !strings.EqualFold(filepath.Ext(key), recordExt) {
+			continue
+		}
+
+		data, err := client.Get(ctx, bucket, key)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		inputs = append(inputs, inputFile{name: objectstore.Scheme + bucket + "/" + key, data: data})
+	}
+
+	return inputs, nil
+}
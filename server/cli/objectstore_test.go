@@ -0,0 +1,149 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeS3 is a path-style S3 endpoint holding objects in memory.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func newFakeS3(t *testing.T, objects map[string]string) *fakeS3 {
+	t.Helper()
+
+	s := &fakeS3{objects: objects}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	t.Setenv("AWS_ENDPOINT_URL", srv.URL)
+	t.Setenv("AWS_ENDPOINT_URL_S3", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	return s
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		var result struct {
+			XMLName  xml.Name `xml:"ListBucketResult"`
+			Contents []struct {
+				Key string `xml:"Key"`
+			} `xml:"Contents"`
+		}
+
+		bucket := strings.TrimSuffix(path, "/")
+		prefix := bucket + "/" + r.URL.Query().Get("prefix")
+
+		for name := range s.objects {
+			if strings.HasPrefix(name, prefix) {
+				result.Contents = append(result.Contents, struct {
+					Key string `xml:"Key"`
+				}{Key: strings.TrimPrefix(name, bucket+"/")})
+			}
+		}
+
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodGet:
+		data, ok := s.objects[path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, data)
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		s.objects[path] = string(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *fakeS3) keys(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []string
+
+	for name := range s.objects {
+		if strings.HasPrefix(name, prefix) {
+			keys = append(keys, name)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func TestServerBatchObjectStorage(t *testing.T) {
+	store := newFakeS3(t, map[string]string{
+		"bucket/records/good.json":  validRecord,
+		"bucket/records/nover.json": `{"name": "no-version"}`,
+		"bucket/records/notes.txt":  "ignored",
+		"bucket/other/skip.json":    validRecord,
+	})
+
+	out, err := runCLI(t, "", "server", "--mode", "batch", "--input", "s3://bucket/records/", "--output", "s3://bucket/validated/")
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	var report pipelineReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("report is not JSON: %v\n%s", err, out)
+	}
+
+	if report.Total != 2 || report.Passed != 1 || report.Failed != 1 {
+		t.Errorf("unexpected totals %+v", report)
+	}
+
+	if got := strings.Join(report.Steps, ","); got != "migrate,validate" {
+		t.Errorf("default steps = %q", got)
+	}
+
+	if got := store.keys("bucket/validated/"); len(got) != 1 || got[0] != "bucket/validated/good.json" {
+		t.Errorf("written objects = %v", got)
+	}
+
+	if len(report.Results) != 2 || report.Results[0].Name != "s3://bucket/records/good.json" ||
+		len(report.Results[0].Outputs) != 1 || report.Results[0].Outputs[0] != "s3://bucket/validated/good.json" {
+		t.Errorf("unexpected results %+v", report.Results)
+	}
+}
+
+func TestServerInvalidMode(t *testing.T) {
+	if _, err := runCLI(t, "", "server", "--mode", "stream"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error, got %v", err)
+	}
+
+	if _, err := runCLI(t, "", "server", "--mode", "batch"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error without --input, got %v", err)
+	}
+
+	if _, err := runCLI(t, "", "server", "--mode", "discovery"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error without --input, got %v", err)
+	}
+}
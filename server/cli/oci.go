@@ -0,0 +1,338 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OCI media types used for published records.
+const (
+	mediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIEmpty    = "application/vnd.oci.empty.v1+json"
+	mediaTypeRecord      = "application/vnd.agntcy.oasf.record.v1+json"
+	mediaTypeSignature   = "application/vnd.agntcy.oasf.signature.v1+json"
+)
+
+const defaultOCITag = "latest"
+
+// ociReference is a parsed "registry/repository[:tag]" reference.
+type ociReference struct {
+	registry   string
+	repository string
+	tag        string
+}
+
+func (r ociReference) String() string {
+	return r.registry + "/" + r.repository + ":" + r.tag
+}
+
+// parseOCIReference parses an OCI reference, with or without an "oci://" prefix.
+func parseOCIReference(ref string) (ociReference, error) {
+	ref = strings.TrimPrefix(ref, "oci://")
+
+	registry, rest, ok := strings.Cut(ref, "/")
+	if !ok || registry == "" || rest == "" {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q (want registry/repository[:tag])", ref)
+	}
+
+	repository, tag := rest, defaultOCITag
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		repository, tag = rest[:i], rest[i+1:]
+	}
+
+	if repository == "" || tag == "" || strings.Contains(rest, "@") {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q (want registry/repository[:tag])", ref)
+	}
+
+	return ociReference{registry: registry, repository: repository, tag: tag}, nil
+}
+
+// ociDescriptor describes a blob in an OCI manifest.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int    `json:"size"`
+}
+
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// ociBlob is content to upload together with its media type.
+type ociBlob struct {
+	mediaType string
+	data      []byte
+}
+
+func (b ociBlob) descriptor() ociDescriptor {
+	return ociDescriptor{MediaType: b.mediaType, Digest: digestOf(b.data), Size: len(b.data)}
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ociClient pushes artifacts using the OCI distribution API. It supports
+// anonymous, basic and bearer token (WWW-Authenticate) authentication.
+type ociClient struct {
+	httpClient *http.Client
+	scheme     string
+	username   string
+	password   string
+	token      string
+}
+
+// pushArtifact uploads the layers and tags a manifest referencing them. It
+// returns the manifest digest.
+func (c *ociClient) pushArtifact(ctx context.Context, ref ociReference, layers []ociBlob, annotations map[string]string) (string, error) {
+	config := ociBlob{mediaType: mediaTypeOCIEmpty, data: []byte("{}")}
+
+	manifest := ociManifest{
+		SchemaVersion: 2, //nolint:mnd
+		MediaType:     mediaTypeOCIManifest,
+		ArtifactType:  mediaTypeRecord,
+		Config:        config.descriptor(),
+		Annotations:   annotations,
+	}
+
+	for _, blob := range append([]ociBlob{config}, layers...) {
+		if err := c.pushBlob(ctx, ref, blob.data); err != nil {
+			return "", err
+		}
+	}
+
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, layer.descriptor())
+	}
+
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	resp, err := c.do(ctx, ref, http.MethodPut, c.url(ref, "/manifests/"+ref.tag), mediaTypeOCIManifest, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", unexpectedStatus("push manifest", resp)
+	}
+
+	return digestOf(body), nil
+}
+
+func (c *ociClient) pushBlob(ctx context.Context, ref ociReference, data []byte) error {
+	digest := digestOf(data)
+
+	resp, err := c.do(ctx, ref, http.MethodHead, c.url(ref, "/blobs/"+digest), "", nil)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = c.do(ctx, ref, http.MethodPost, c.url(ref, "/blobs/uploads/"), "", nil)
+	if err != nil {
+		return err
+	}
+
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return unexpectedStatus("start blob upload", resp)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return fmt.Errorf("blob upload returned no location: %w", err)
+	}
+
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref, http.MethodPut, location.String(), "application/octet-stream", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return unexpectedStatus("upload blob", resp)
+	}
+
+	return nil
+}
+
+func (c *ociClient) url(ref ociReference, path string) string {
+	return c.scheme + "://" + ref.registry + "/v2/" + ref.repository + path
+}
+
+// do sends a request, authenticating and retrying once when the registry
+// answers 401.
+func (c *ociClient) do(ctx context.Context, ref ociReference, method, target, contentType string, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s request to %s: %w", method, target, err)
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		switch {
+		case c.token != "":
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		case c.username != "":
+			req.SetBasicAuth(c.username, c.password)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send %s request to %s: %w", method, target, err)
+		}
+
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+
+		if err := c.authenticate(ctx, ref, challenge); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// authenticate handles a WWW-Authenticate challenge: basic challenges use the
+// configured credentials as-is, bearer challenges exchange them for a token.
+func (c *ociClient) authenticate(ctx context.Context, ref ociReference, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		if c.username == "" {
+			return errors.New("registry requires credentials (set --username and --password)")
+		}
+
+		return nil
+	}
+
+	attrs := parseChallengeParams(params)
+
+	realm, err := url.Parse(attrs["realm"])
+	if err != nil || attrs["realm"] == "" {
+		return fmt.Errorf("invalid bearer challenge %q", challenge)
+	}
+
+	query := realm.Query()
+	if service := attrs["service"]; service != "" {
+		query.Set("service", service)
+	}
+
+	scope := attrs["scope"]
+	if scope == "" {
+		scope = "repository:" + ref.repository + ":pull,push"
+	}
+
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request registry token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return unexpectedStatus("request registry token", resp)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"` //nolint:tagliatelle
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+
+	if c.token == "" {
+		return errors.New("registry token response contained no token")
+	}
+
+	return nil
+}
+
+// parseChallengeParams parses `key="value",key2="value2"` challenge parameters.
+func parseChallengeParams(params string) map[string]string {
+	attrs := map[string]string{}
+
+	for params != "" {
+		var pair string
+
+		key, rest, ok := strings.Cut(strings.TrimLeft(params, ", "), "=")
+		if !ok {
+			break
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				break
+			}
+
+			pair, params = rest[1:end+1], rest[end+2:]
+		} else {
+			pair, params, _ = strings.Cut(rest, ",")
+		}
+
+		attrs[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+
+	return attrs
+}
+
+func unexpectedStatus(action string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096)) //nolint:mnd
+
+	return fmt.Errorf("failed to %s: HTTP %d, body: %s", action, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,169 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Output formats selected with the global --output flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	// outputNDJSON writes one compact JSON document per line: the entries of
+	// lists and reports, e.g. one validation result per record, for jq and
+	// shell pipelines.
+	outputNDJSON = "ndjson"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML, outputNDJSON}
+
+// globalOptions holds the flags shared by all commands.
+type globalOptions struct {
+	output      string
+	schemaURL   string
+	oasfVersion string
+	server      string
+	tls         bool
+	apiKey      string
+}
+
+func (g *globalOptions) validate() error {
+	switch g.output {
+	case outputTable, outputJSON, outputYAML, outputNDJSON:
+	default:
+		return fmt.Errorf("invalid --output value %q (want table, json, yaml or ndjson)", g.output)
+	}
+
+	if g.server == "" && (g.tls || g.apiKey != "") {
+		return errors.New("--tls and --api-key require --server")
+	}
+
+	return nil
+}
+
+// structured reports whether a machine-readable output format was selected.
+func (g *globalOptions) structured() bool {
+	return g.output == outputJSON || g.output == outputYAML || g.output == outputNDJSON
+}
+
+// ndjsonReport is implemented by reports written as their entries with
+// NDJSON output.
+type ndjsonReport interface {
+	ndjsonLines() []any
+}
+
+// ndjsonEntries returns the entries of a report as NDJSON lines.
+func ndjsonEntries[T any](entries []T) []any {
+	lines := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		lines = append(lines, entry)
+	}
+
+	return lines
+}
+
+// writeStructured writes v as indented JSON or YAML, or as NDJSON: one line
+// per entry of a list or ndjsonReport, or a single line.
+func writeStructured(out io.Writer, format string, v any) error {
+	if format == outputNDJSON {
+		return writeNDJSON(out, v)
+	}
+
+	if format == outputYAML {
+		enc := yaml.NewEncoder(out)
+		enc.SetIndent(2) //nolint:mnd
+
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+
+		return enc.Close() //nolint:wrapcheck
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
+
+func writeNDJSON(out io.Writer, v any) error {
+	lines := []any{v}
+
+	if report, ok := v.(ndjsonReport); ok {
+		lines = report.ndjsonLines()
+	} else if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		lines = make([]any, 0, rv.Len())
+		for i := range rv.Len() {
+			lines = append(lines, rv.Index(i).Interface())
+		}
+	}
+
+	enc := json.NewEncoder(out)
+
+	for _, line := range lines {
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeRecord writes a record to out, or to path when set. Records are written
+// as JSON unless YAML output was selected, since table output does not apply.
+// YAML records keep the stable key order of record.MarshalYAML.
+func writeRecord(out io.Writer, record *structpb.Struct, path, format string) error {
+	if path == "" {
+		return encodeRecord(out, record, format)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := encodeRecord(f, record, format); err != nil {
+		_ = f.Close()
+
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func encodeRecord(out io.Writer, rec *structpb.Struct, format string) error {
+	if format != outputYAML {
+		return writeStructured(out, format, rec.AsMap())
+	}
+
+	data, err := record.MarshalYAML(rec)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if _, err := out.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	return nil
+}
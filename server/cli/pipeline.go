@@ -0,0 +1,528 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/mcpclient"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// defaultMigrateVersion is the migrate step target when neither the step nor
+// --oasf-version name one.
+const defaultMigrateVersion = "1.0.0"
+
+type pipelineOptions struct {
+	*globalOptions
+
+	inputs        []string
+	steps         []string
+	outDir        string
+	probeRuntimes bool
+	// allowExec allows the introspect step, which runs the stdio commands of
+	// the records.
+	allowExec bool
+}
+
+// pipelineItem is a record moving through the pipeline.
+type pipelineItem struct {
+	base   string
+	record *structpb.Struct
+	// outputs maps file names (relative to --out) to their content.
+	outputs map[string][]byte
+}
+
+// pipelineStep is a configured pipeline step. A step error fails the record
+// and skips its remaining steps.
+type pipelineStep struct {
+	name string
+	run  func(ctx context.Context, item *pipelineItem) error
+}
+
+// pipelineResult is the outcome of running the pipeline on one input.
+type pipelineResult struct {
+	Name       string   `json:"name"                  yaml:"name"`
+	OK         bool     `json:"ok"                    yaml:"ok"`
+	FailedStep string   `json:"failed_step,omitempty" yaml:"failed_step,omitempty"`
+	Error      string   `json:"error,omitempty"       yaml:"error,omitempty"`
+	Outputs    []string `json:"outputs,omitempty"     yaml:"outputs,omitempty"`
+}
+
+// pipelineReport is the summary report of a pipeline run.
+type pipelineReport struct {
+	Steps   []string         `json:"steps"   yaml:"steps"`
+	Results []pipelineResult `json:"results" yaml:"results"`
+	Total   int              `json:"total"   yaml:"total"`
+	Passed  int              `json:"passed"  yaml:"passed"`
+	Failed  int              `json:"failed"  yaml:"failed"`
+}
+
+// ndjsonLines returns the results, one line each with NDJSON output.
+func (r pipelineReport) ndjsonLines() []any {
+	return ndjsonEntries(r.Results)
+}
+
+// translateTargets maps translate step targets to their translator and the
+// suffix of the generated file. Targets generating a client config, whose
+// commands --probe-runtimes checks, are marked.
+var translateTargets = map[string]struct {
+	suffix    string
+	config    bool
+	translate func(context.Context, backend, *structpb.Struct) ([]byte, error)
+}{
+	"gh-copilot": {".gh-copilot.json", true, func(ctx context.Context, b backend, r *structpb.Struct) ([]byte, error) {
+		cfg, err := b.RecordToGHCopilot(ctx, r)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return json.MarshalIndent(cfg, "", "  ") //nolint:wrapcheck
+	}},
+	"a2a": {".a2a.json", false, func(ctx context.Context, b backend, r *structpb.Struct) ([]byte, error) {
+		card, err := b.RecordToA2A(ctx, r)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return json.MarshalIndent(card.AsMap(), "", "  ") //nolint:wrapcheck
+	}},
+	"skill-md": {".SKILL.md", false, func(ctx context.Context, b backend, r *structpb.Struct) ([]byte, error) {
+		md, err := b.RecordToSkillMarkdown(ctx, r)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return []byte(md), nil
+	}},
+}
+
+func newPipelineCommand(g *globalOptions) *cobra.Command {
+	opts := &pipelineOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "pipeline --in <file|dir|glob|s3-url>... --steps <step,...> [--out <dir|s3-url>]",
+		Short: "Run a multi-step processing pipeline over many records",
+		Long: `Run each input record through the given steps, in order:
+
+  migrate[:<version>]   upgrade the record (default target: --oasf-version or ` + defaultMigrateVersion + `)
+  merge:<file>          merge a record overlay (e.g. curated metadata) into the record
+  patch:<file>          apply a JSON Patch (RFC 6902) or merge patch (RFC 7386)
+  sanitize              redact secrets, normalize, drop internal annotations and validate
+                        (see record.Sanitize); run it last before publishing
+  validate              validate the record (against --schema-url when set)
+  lint                  run the lint rule set; error findings fail the record
+  introspect            connect to the record's MCP server (running stdio commands) and
+                        write the tools and resources it lists into the MCP module;
+                        records without MCP module pass unchanged. Requires
+                        --allow-exec: only introspect trusted records
+  translate:<target>    translate the record (gh-copilot, a2a, skill-md); with
+                        --probe-runtimes, a client config whose commands (npx,
+                        docker, uvx, ...) are not on PATH fails the record, with
+                        an alternative found on PATH when there is one
+  export:<format>       write the record as YAML or TOML too (yaml, toml)
+
+A failing step stops processing of that record only. With --out, the processed
+record and any translations are written to the directory; without it the
+pipeline only reports.
+
+Inputs and --out may also be object storage locations (s3://bucket/prefix/),
+read and written with the credentials of the standard AWS environment
+variables; AWS_ENDPOINT_URL selects S3-compatible services such as MinIO.
+
+Exit codes: 0 when all records passed, 1 when at least one failed, 2 when the
+pipeline could not run.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runPipeline(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.inputs, "in", nil, "Input records: files, directories, globs, s3:// prefixes or - for stdin")
+	cmd.Flags().StringSliceVar(&opts.steps, "steps", nil, "Comma-separated pipeline steps")
+	cmd.Flags().StringVar(&opts.outDir, "out", "", "Directory or s3:// prefix to write processed records and translations to")
+	cmd.Flags().BoolVar(&opts.probeRuntimes, "probe-runtimes", false, "Check that the commands of translated client configs are on PATH before writing them")
+	addAllowExecFlag(cmd, opts)
+
+	_ = cmd.MarkFlagRequired("in")
+	_ = cmd.MarkFlagRequired("steps")
+
+	return cmd
+}
+
+// addAllowExecFlag registers --allow-exec, off by default so that records
+// cannot run commands on the host unless the operator trusts them.
+func addAllowExecFlag(cmd *cobra.Command, opts *pipelineOptions) {
+	cmd.Flags().BoolVar(&opts.allowExec, "allow-exec", false, "Allow the introspect step, which runs the stdio commands of the records")
+}
+
+func runPipeline(ctx context.Context, stdin io.Reader, out io.Writer, opts *pipelineOptions) error {
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	steps, err := buildPipelineSteps(opts, b)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := resolvePipelineInputs(ctx, opts.inputs, stdin)
+	if err != nil {
+		return err
+	}
+
+	sink, err := newOutputSink(opts.outDir)
+	if err != nil {
+		return err
+	}
+
+	report := pipelineReport{Steps: opts.steps, Results: make([]pipelineResult, 0, len(inputs)), Total: len(inputs)}
+
+	for _, in := range inputs {
+		result, err := runPipelineInput(ctx, in, steps, sink)
+		if err != nil {
+			return err
+		}
+
+		if !result.OK {
+			report.Failed++
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	report.Passed = report.Total - report.Failed
+
+	if opts.structured() {
+		if err := writeStructured(out, opts.output, report); err != nil {
+			return err
+		}
+	} else if err := printPipelineReport(out, report); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+func buildPipelineSteps(opts *pipelineOptions, b backend) ([]pipelineStep, error) {
+	if len(opts.steps) == 0 {
+		return nil, errors.New("--steps must name at least one step")
+	}
+
+	validateOpts := &validateOptions{globalOptions: opts.globalOptions}
+
+	steps := make([]pipelineStep, 0, len(opts.steps))
+
+	for _, spec := range opts.steps {
+		name, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+
+		var run func(ctx context.Context, item *pipelineItem) error
+
+		switch name {
+		case "migrate":
+			target := arg
+			if target == "" {
+				target = opts.oasfVersion
+			}
+
+			if target == "" {
+				target = defaultMigrateVersion
+			}
+
+			run = func(_ context.Context, item *pipelineItem) error {
+				migrated, err := record.Migrate(item.record, target)
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				item.record = migrated
+
+				return nil
+			}
+		case "merge":
+			overlay, err := loadOverlay(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			run = func(_ context.Context, item *pipelineItem) error {
+				merged, err := record.Merge(item.record, overlay, record.MergePreferOverlay)
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				item.record = merged
+
+				return nil
+			}
+		case "patch":
+			if arg == "" {
+				return nil, errors.New("patch step requires a file (patch:<file>)")
+			}
+
+			patch, err := os.ReadFile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read patch: %w", err)
+			}
+
+			run = func(_ context.Context, item *pipelineItem) error {
+				patched, err := record.ApplyPatch(item.record, patch)
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				item.record = patched
+
+				return nil
+			}
+		case "sanitize":
+			check := newRecordCheck(b, validateOpts)
+
+			run = func(ctx context.Context, item *pipelineItem) error {
+				sanitized, err := record.Sanitize(item.record, record.WithValidator(func(r *structpb.Struct) error {
+					return check.must(ctx, r)
+				}))
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				item.record = sanitized
+
+				return nil
+			}
+		case "validate":
+			check := newRecordCheck(b, validateOpts)
+
+			run = func(ctx context.Context, item *pipelineItem) error {
+				return check.must(ctx, item.record)
+			}
+		case "lint":
+			run = func(_ context.Context, item *pipelineItem) error {
+				var errs []string
+
+				for _, f := range linter.Lint(item.record) {
+					if f.Severity == linter.SeverityError {
+						errs = append(errs, fmt.Sprintf("%s [%s]", f.Message, f.RuleID))
+					}
+				}
+
+				if len(errs) > 0 {
+					return errors.New(strings.Join(errs, "; "))
+				}
+
+				return nil
+			}
+		case "introspect":
+			if !opts.allowExec {
+				return nil, errors.New("the introspect step runs the stdio commands of the records; pass --allow-exec to run it on trusted records")
+			}
+
+			run = func(ctx context.Context, item *pipelineItem) error {
+				enriched := record.Clone(item.record)
+
+				if _, err := mcpclient.Enrich(ctx, enriched); err != nil {
+					if errors.Is(err, mcpclient.ErrNoMCPModule) {
+						return nil
+					}
+
+					return err //nolint:wrapcheck
+				}
+
+				item.record = enriched
+
+				return nil
+			}
+		case "translate":
+			target, ok := translateTargets[arg]
+			if !ok {
+				return nil, fmt.Errorf("unknown translate target %q (want gh-copilot, a2a or skill-md)", arg)
+			}
+
+			var probe *runtimeProbe
+			if opts.probeRuntimes && target.config {
+				probe = newRuntimeProbe()
+			}
+
+			run = func(ctx context.Context, item *pipelineItem) error {
+				data, err := target.translate(ctx, b, item.record)
+				if err != nil {
+					return err
+				}
+
+				if probe != nil {
+					if err := probe.checkConfig(data); err != nil {
+						return err
+					}
+				}
+
+				item.outputs[item.base+target.suffix] = data
+
+				return nil
+			}
+		case "export":
+			format, ok := exportFormats[arg]
+			if !ok {
+				return nil, fmt.Errorf("unknown export format %q (want yaml or toml)", arg)
+			}
+
+			run = func(_ context.Context, item *pipelineItem) error {
+				data, err := format.marshal(item.record)
+				if err != nil {
+					return err
+				}
+
+				item.outputs[item.base+format.suffix] = data
+
+				return nil
+			}
+		default:
+			return nil, fmt.Errorf("unknown pipeline step %q", spec)
+		}
+
+		steps = append(steps, pipelineStep{name: spec, run: run})
+	}
+
+	return steps, nil
+}
+
+// loadOverlay reads the record overlay of a merge step.
+func loadOverlay(path string) (*structpb.Struct, error) {
+	if path == "" {
+		return nil, errors.New("merge step requires a file (merge:<file>)")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read merge overlay: %w", err)
+	}
+
+	return parseRecord(inputFile{name: path, data: data})
+}
+
+// runPipelineInput runs all steps on one input. Step failures are reported in
+// the result; only output write failures are returned as errors.
+func runPipelineInput(ctx context.Context, in inputFile, steps []pipelineStep, sink outputSink) (pipelineResult, error) {
+	result := pipelineResult{Name: in.name}
+
+	rec, err := parseRecord(in)
+	if err != nil {
+		result.FailedStep = "parse"
+		result.Error = err.Error()
+
+		return result, nil
+	}
+
+	item := &pipelineItem{base: outputBase(in), record: rec, outputs: map[string][]byte{}}
+
+	if err := runPipelineSteps(ctx, item, steps); err != nil {
+		result.FailedStep = err.step
+		result.Error = err.Error()
+
+		return result, nil
+	}
+
+	result.OK = true
+
+	if sink == nil {
+		return result, nil
+	}
+
+	recordJSON, err := json.MarshalIndent(item.record.AsMap(), "", "  ")
+	if err != nil {
+		return result, fmt.Errorf("%s: failed to marshal record: %w", in.name, err)
+	}
+
+	item.outputs[item.base+".json"] = append(recordJSON, '\n')
+
+	for name, data := range item.outputs {
+		path, err := sink.write(ctx, name, data)
+		if err != nil {
+			return result, err
+		}
+
+		result.Outputs = append(result.Outputs, path)
+	}
+
+	sort.Strings(result.Outputs)
+
+	return result, nil
+}
+
+// stepError is the failure of a pipeline step.
+type stepError struct {
+	step string
+	err  error
+}
+
+func (e *stepError) Error() string { return e.err.Error() }
+
+func (e *stepError) Unwrap() error { return e.err }
+
+// runPipelineSteps runs the steps on an item, stopping at the first failure.
+func runPipelineSteps(ctx context.Context, item *pipelineItem, steps []pipelineStep) *stepError {
+	for _, step := range steps {
+		if err := step.run(ctx, item); err != nil {
+			return &stepError{step: step.name, err: err}
+		}
+	}
+
+	return nil
+}
+
+// outputBase is the base name for files generated from an input; records of
+// a bundle are told apart by their index, e.g. "fleet-0".
+func outputBase(in inputFile) string {
+	if in.bundle != "" {
+		index := strings.TrimSuffix(strings.TrimPrefix(in.name, in.bundle+"["), "]")
+
+		return outputBase(inputFile{name: in.bundle}) + "-" + index
+	}
+
+	if in.name == stdinName {
+		return "stdin"
+	}
+
+	return strings.TrimSuffix(filepath.Base(in.name), filepath.Ext(in.name))
+}
+
+func printPipelineReport(out io.Writer, report pipelineReport) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(tw, "RECORD\tSTATUS\tDETAILS")
+
+	for _, r := range report.Results {
+		status, details := "ok", strings.Join(r.Outputs, ", ")
+		if !r.OK {
+			status, details = "failed", r.FailedStep+": "+r.Error
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Name, status, details)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	fmt.Fprintf(out, "%d record(s) processed: %d passed, %d failed\n", report.Total, report.Passed, report.Failed)
+
+	return nil
+}
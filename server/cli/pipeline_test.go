@@ -0,0 +1,235 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	in := writeFiles(t, map[string]string{
+		"agent.json":  string(readFixture(t, "translation_0.8.0_record.json")),
+		"nover.json":  `{"name": "no-version"}`,
+		"broken.json": `{not json`,
+	})
+	out := filepath.Join(t.TempDir(), "out")
+
+	stdout, err := runCLI(t, "", "pipeline", "--in", in, "--steps", "migrate,validate,translate:gh-copilot", "--out", out)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, stdout)
+	}
+
+	for _, want := range []string{
+		"3 record(s) processed: 1 passed, 2 failed",
+		"failed  parse:",
+		"failed  migrate: cannot migrate from schema version",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("output missing %q:\n%s", want, stdout)
+		}
+	}
+
+	raw, err := os.ReadFile(filepath.Join(out, "agent.json"))
+	if err != nil {
+		t.Fatalf("processed record not written: %v", err)
+	}
+
+	var migrated map[string]any
+	if err := json.Unmarshal(raw, &migrated); err != nil || migrated["schema_version"] != "1.0.0" {
+		t.Errorf("record was not migrated to 1.0.0: %v\n%s", err, raw)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "agent.gh-copilot.json")); err != nil {
+		t.Errorf("translation not written: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "nover.json")); !os.IsNotExist(err) {
+		t.Errorf("failed records must not be written, stat err = %v", err)
+	}
+}
+
+func TestPipelineBundle(t *testing.T) {
+	out := t.TempDir()
+
+	stdout, err := runCLI(t, "["+validRecord+","+validRecord+"]", "pipeline", "--in", "-", "--steps", "validate", "--out", out)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	for _, name := range []string{"stdin-0.json", "stdin-1.json"} {
+		if _, err := os.Stat(filepath.Join(out, name)); err != nil {
+			t.Errorf("bundle record not written: %v", err)
+		}
+	}
+}
+
+func TestPipelineReportJSON(t *testing.T) {
+	stdout, err := runCLI(t, validRecord, "pipeline", "-o", "json", "--in", "-", "--steps", "validate,lint")
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	var report pipelineReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout)
+	}
+
+	if report.Passed != 1 || len(report.Steps) != 2 || len(report.Results[0].Outputs) != 0 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestPipelineMerge(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"overlay.json": `{"description": "Curated", "skills": [{"id": 10201}]}`,
+	})
+
+	stdout, err := runCLI(t, validRecord, "pipeline", "--in", "-", "--steps", "merge:"+filepath.Join(dir, "overlay.json"), "--out", dir)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("merged record not written: %v", err)
+	}
+
+	var merged map[string]any
+	if err := json.Unmarshal(raw, &merged); err != nil || merged["description"] != "Curated" || merged["name"] != "example.org/agent" {
+		t.Errorf("overlay not merged: %v\n%s", err, raw)
+	}
+}
+
+func TestPipelinePatch(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"fix.json": `[{"op": "add", "path": "/description", "value": "Patched"}]`,
+		"bad.json": `[{"op": "remove", "path": "/missing"}]`,
+	})
+
+	stdout, err := runCLI(t, validRecord, "pipeline", "--in", "-", "--steps", "patch:"+filepath.Join(dir, "fix.json"), "--out", dir)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("patched record not written: %v", err)
+	}
+
+	var patched map[string]any
+	if err := json.Unmarshal(raw, &patched); err != nil || patched["description"] != "Patched" {
+		t.Errorf("patch not applied: %v\n%s", err, raw)
+	}
+
+	if _, err := runCLI(t, validRecord, "pipeline", "--in", "-", "--steps", "patch:"+filepath.Join(dir, "bad.json")); !errors.Is(err, errChecksFailed) {
+		t.Errorf("expected a failed record for a patch that does not apply, got %v", err)
+	}
+}
+
+func TestPipelineSanitize(t *testing.T) {
+	dir := t.TempDir()
+	in := strings.Replace(validRecord, "{", `{"annotations": {"oasf.sdk.source": "import", "team": "x"}, "description": " Agent ", `, 1)
+
+	stdout, err := runCLI(t, in, "pipeline", "--in", "-", "--steps", "sanitize", "--out", dir)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "stdin.json"))
+	if err != nil {
+		t.Fatalf("sanitized record not written: %v", err)
+	}
+
+	var sanitized struct {
+		Description string            `json:"description"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(raw, &sanitized); err != nil || sanitized.Description != "Agent" || len(sanitized.Annotations) != 1 {
+		t.Errorf("record not sanitized: %v\n%s", err, raw)
+	}
+
+	if _, err := runCLI(t, `{"name": "x"}`, "pipeline", "--in", "-", "--steps", "sanitize"); !errors.Is(err, errChecksFailed) {
+		t.Errorf("expected a failed record for an unpublishable record, got %v", err)
+	}
+}
+
+func TestPipelineExport(t *testing.T) {
+	dir := t.TempDir()
+
+	stdout, err := runCLI(t, validRecord, "pipeline", "--in", "-", "--steps", "export:yaml,export:toml", "--out", dir)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	for _, name := range []string{"stdin.yaml", "stdin.toml"} {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !strings.Contains(string(raw), "example.org/agent") {
+			t.Errorf("%s not exported: %v\n%s", name, err, raw)
+		}
+	}
+}
+
+func TestPipelineIntrospect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		var result any
+
+		switch req.Method {
+		case "initialize":
+			result = map[string]any{"capabilities": map[string]any{"tools": map[string]any{}}, "serverInfo": map[string]any{"name": "weather"}}
+		case "tools/list":
+			result = map[string]any{"tools": []any{map[string]any{"name": "forecast", "description": "Weather forecast"}}}
+		default:
+			w.WriteHeader(http.StatusAccepted)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	defer srv.Close()
+
+	in := writeFiles(t, map[string]string{
+		"mcp.json": `{"name": "example.org/weather", "schema_version": "1.0.0", "version": "1.0.0", "modules": [
+			{"name": "integration/mcp", "data": {"name": "weather", "connections": [{"type": "streamable-http", "url": "` + srv.URL + `"}]}}]}`,
+		"plain.json": validRecord,
+	})
+	out := t.TempDir()
+
+	if _, err := runCLI(t, "", "pipeline", "--in", in, "--steps", "introspect", "--out", out); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("introspect without --allow-exec: expected an operational error, got %v", err)
+	}
+
+	stdout, err := runCLI(t, "", "pipeline", "--in", in, "--steps", "introspect", "--allow-exec", "--out", out)
+	if err != nil {
+		t.Fatalf("pipeline: %v\n%s", err, stdout)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(out, "mcp.json"))
+	if err != nil || !strings.Contains(string(raw), `"forecast"`) {
+		t.Errorf("discovered tools not written: %v\n%s", err, raw)
+	}
+}
+
+func TestPipelineInvalidSteps(t *testing.T) {
+	for _, steps := range []string{"frobnicate", "translate:unknown", "merge", "merge:missing.json", "patch", "patch:missing.json", "export:xml"} {
+		if _, err := runCLI(t, validRecord, "pipeline", "--in", "-", "--steps", steps); err == nil || errors.Is(err, errChecksFailed) {
+			t.Errorf("steps %q: expected an operational error, got %v", steps, err)
+		}
+	}
+}
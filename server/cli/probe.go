@@ -0,0 +1,144 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// runtimeInstallHints tell how to get the runtimes of generated commands.
+var runtimeInstallHints = map[string]string{
+	"npx":     "install Node.js",
+	"python":  "install Python",
+	"python3": "install Python",
+	"uvx":     "install uv",
+	"docker":  "install Docker",
+	"dotnet":  "install the .NET SDK",
+}
+
+// runtimeProbe checks that the commands of generated client configurations
+// can run on this machine.
+type runtimeProbe struct {
+	// lookPath finds a command on PATH; exec.LookPath unless tests replace
+	// it.
+	lookPath func(file string) (string, error)
+}
+
+func newRuntimeProbe() *runtimeProbe {
+	return &runtimeProbe{lookPath: exec.LookPath}
+}
+
+// probedServer is a server of a generated client configuration.
+type probedServer struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// checkConfig checks the stdio servers of a generated client configuration
+// ({"servers": {...}} or {"mcpServers": {...}}), and reports the commands
+// that are not on PATH, with an alternative that is when there is one.
+func (p *runtimeProbe) checkConfig(data []byte) error {
+	var config struct {
+		Servers    map[string]probedServer `json:"servers"`
+		MCPServers map[string]probedServer `json:"mcpServers"`
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	servers := maps.Clone(config.Servers)
+	if servers == nil {
+		servers = map[string]probedServer{}
+	}
+
+	maps.Copy(servers, config.MCPServers)
+
+	var errs []string
+
+	for _, name := range slices.Sorted(maps.Keys(servers)) {
+		server := servers[name]
+		if server.Command == "" {
+			continue
+		}
+
+		if err := p.checkCommand(server.Command, server.Args); err != nil {
+			errs = append(errs, fmt.Sprintf("server %q: %v", name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// checkCommand checks that the command is on PATH. When it is not, the
+// error suggests an alternative command found on PATH, or how to install
+// the runtime.
+func (p *runtimeProbe) checkCommand(command string, args []string) error {
+	if _, err := p.lookPath(command); err == nil {
+		return nil
+	}
+
+	if alternative := p.alternative(command, args); alternative != "" {
+		return fmt.Errorf("command %q not found on PATH; use %q instead", command, alternative)
+	}
+
+	if hint, ok := runtimeInstallHints[command]; ok {
+		return fmt.Errorf("command %q not found on PATH; %s", command, hint)
+	}
+
+	return fmt.Errorf("command %q not found on PATH", command)
+}
+
+// alternative returns a command line doing the same as the command with
+// runtimes found on PATH, or "" when there is none.
+func (p *runtimeProbe) alternative(command string, args []string) string {
+	found := func(command string) bool {
+		_, err := p.lookPath(command)
+
+		return err == nil
+	}
+
+	switch command {
+	case "python", "python3":
+		// python -m <package> runs an installed package; uvx and pipx run
+		// it without installing it first.
+		if len(args) >= 2 && args[0] == "-m" { //nolint:mnd
+			for _, runner := range []string{"uvx", "pipx run"} {
+				if found(strings.Fields(runner)[0]) {
+					return strings.Join(append([]string{runner}, args[1:]...), " ")
+				}
+			}
+		}
+
+		for _, python := range []string{"python3", "python", "py"} {
+			if python != command && found(python) {
+				return strings.Join(append([]string{python}, args...), " ")
+			}
+		}
+	case "uvx":
+		if found("pipx") {
+			return strings.Join(append([]string{"pipx", "run"}, args...), " ")
+		}
+	case "npx":
+		if found("bunx") {
+			return strings.Join(append([]string{"bunx"}, args...), " ")
+		}
+	case "docker":
+		if found("podman") {
+			return strings.Join(append([]string{"podman"}, args...), " ")
+		}
+	}
+
+	return ""
+}
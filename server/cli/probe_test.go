@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakePath is a probe whose PATH has the commands only.
+func fakePath(commands ...string) *runtimeProbe {
+	return &runtimeProbe{lookPath: func(file string) (string, error) {
+		if slices.Contains(commands, file) {
+			return "/usr/bin/" + file, nil
+		}
+
+		return "", errors.New("not found")
+	}}
+}
+
+func TestRuntimeProbeCheckCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    []string
+		command string
+		args    []string
+		wantErr string
+	}{
+		{name: "found", path: []string{"npx"}, command: "npx", args: []string{"-y", "server"}},
+		{
+			name: "python module with uvx", path: []string{"uvx", "python3"}, command: "python", args: []string{"-m", "mcpcap"},
+			wantErr: `command "python" not found on PATH; use "uvx mcpcap" instead`,
+		},
+		{
+			name: "python module with pipx", path: []string{"pipx"}, command: "python", args: []string{"-m", "mcpcap"},
+			wantErr: `use "pipx run mcpcap" instead`,
+		},
+		{
+			name: "python script with python3", path: []string{"python3"}, command: "python", args: []string{"server.py"},
+			wantErr: `use "python3 server.py" instead`,
+		},
+		{
+			name: "docker with podman", path: []string{"podman"}, command: "docker", args: []string{"run", "-i", "--rm", "image"},
+			wantErr: `use "podman run -i --rm image" instead`,
+		},
+		{
+			name: "install hint", command: "dotnet", args: []string{"tool", "run", "server"},
+			wantErr: `command "dotnet" not found on PATH; install the .NET SDK`,
+		},
+		{
+			name: "unknown", command: "my-server",
+			wantErr: `command "my-server" not found on PATH`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fakePath(tt.path...).checkCommand(tt.command, tt.args)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("checkCommand() error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("checkCommand() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuntimeProbeCheckConfig(t *testing.T) {
+	config := `{
+		"servers": {
+			"files": {"command": "npx", "args": ["-y", "server"]},
+			"github": {"command": "docker", "args": ["run", "-i", "--rm", "image"]}
+		},
+		"inputs": [{"id": "TOKEN", "type": "promptString"}]
+	}`
+
+	err := fakePath("npx").checkConfig([]byte(config))
+	if err == nil || err.Error() != `server "github": command "docker" not found on PATH; install Docker` {
+		t.Errorf("checkConfig() error = %v", err)
+	}
+
+	if err := fakePath("npx", "docker").checkConfig([]byte(config)); err != nil {
+		t.Errorf("checkConfig() error: %v", err)
+	}
+
+	if err := fakePath().checkConfig([]byte(`{"mcpServers": {"weather": {"url": "https://weather.example.org/mcp"}}}`)); err != nil {
+		t.Errorf("checkConfig() error for a remote server: %v", err)
+	}
+}
+
+func TestPipelineProbeRuntimes(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	in := writeFiles(t, map[string]string{"agent.json": string(readFixture(t, "translation_0.8.0_record.json"))})
+	out := t.TempDir()
+
+	stdout, err := runCLI(t, "", "pipeline", "--in", in, "--steps", "translate:gh-copilot", "--probe-runtimes", "--out", out)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, stdout)
+	}
+
+	if !strings.Contains(stdout, `command "docker" not found on PATH`) {
+		t.Errorf("output does not report the missing command:\n%s", stdout)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "agent.gh-copilot.json")); !os.IsNotExist(err) {
+		t.Errorf("config with a missing command must not be written, stat err = %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"github.com/spf13/cobra"
+)
+
+// registryPasswordEnv is read when --password is not given, so secrets do
+// not have to appear on the command line.
+const registryPasswordEnv = "OASF_REGISTRY_PASSWORD"
+
+type publishOptions struct {
+	*globalOptions
+
+	to        string
+	keyFile   string
+	username  string
+	password  string
+	plainHTTP bool
+	sanitize  bool
+	timeout   time.Duration
+}
+
+// publishResult is the machine-readable form of the publish command output.
+type publishResult struct {
+	Reference string `json:"reference" yaml:"reference"`
+	Digest    string `json:"digest"    yaml:"digest"`
+	Signed    bool   `json:"signed"    yaml:"signed"`
+}
+
+// recordSignature is the detached signature published next to a record.
+type recordSignature struct {
+	Algorithm string `json:"algorithm"`
+	Digest    string `json:"digest"`
+	Signature string `json:"signature"`
+	PublicKey string `json:"public_key"` //nolint:tagliatelle
+}
+
+func newPublishCommand(g *globalOptions) *cobra.Command {
+	opts := &publishOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "publish <record.json|-> --to <oci-ref>",
+		Short: "Validate, sign and publish a record to an OCI registry",
+		Long: `Validate a record, optionally sign it, and push it as an OCI artifact.
+
+--to takes an OCI reference (registry/repository[:tag], optionally prefixed
+with oci://). AGNTCY directories store records in an OCI registry, so records
+are published to a directory by pointing --to at its registry.
+
+With --key (a PEM encoded ECDSA P-256 or Ed25519 private key), a detached
+signature over the record's canonical JSON is pushed as a second layer.
+
+With --sanitize, secrets are redacted, the record is normalized and the SDK's
+internal annotations are dropped before it is validated and signed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPublish(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.to, "to", "", "OCI reference to publish to (registry/repository[:tag])")
+	cmd.Flags().StringVar(&opts.keyFile, "key", "", "PEM private key to sign the record with")
+	cmd.Flags().StringVar(&opts.username, "username", "", "Registry username")
+	cmd.Flags().StringVar(&opts.password, "password", "", "Registry password (defaults to $"+registryPasswordEnv+")")
+	cmd.Flags().BoolVar(&opts.plainHTTP, "plain-http", false, "Use HTTP instead of HTTPS for the registry")
+	cmd.Flags().BoolVar(&opts.sanitize, "sanitize", false, "Sanitize the record for publication first")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", defaultFetchTimeout, "HTTP timeout")
+
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runPublish(ctx context.Context, stdin io.Reader, out io.Writer, arg string, opts *publishOptions) error {
+	ref, err := parseOCIReference(opts.to)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := resolveInputs([]string{arg}, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) != 1 {
+		return fmt.Errorf("publish expects a single record, %s matched %d files", arg, len(inputs))
+	}
+
+	record, err := parseRecord(inputs[0])
+	if err != nil {
+		return err
+	}
+
+	if opts.sanitize {
+		if record, err = recordutil.Sanitize(record); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	errs, _, err := newRecordCheck(b, &validateOptions{globalOptions: opts.globalOptions})(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("record is not valid: %s", strings.Join(errs, "; "))
+	}
+
+	canonical, err := recordutil.CanonicalJSON(record, nil)
+	if err != nil {
+		return err
+	}
+
+	layers := []ociBlob{{mediaType: mediaTypeRecord, data: canonical}}
+
+	if opts.keyFile != "" {
+		signature, err := signRecordFile(ctx, canonical, opts.keyFile)
+		if err != nil {
+			return err
+		}
+
+		layers = append(layers, ociBlob{mediaType: mediaTypeSignature, data: signature})
+	}
+
+	client := &ociClient{
+		httpClient: &http.Client{Timeout: opts.timeout},
+		scheme:     "https",
+		username:   opts.username,
+		password:   opts.password,
+	}
+
+	if client.password == "" {
+		client.password = os.Getenv(registryPasswordEnv)
+	}
+
+	if opts.plainHTTP {
+		client.scheme = "http"
+	}
+
+	fields := record.GetFields()
+	annotations := map[string]string{
+		"org.opencontainers.image.title":   fields["name"].GetStringValue(),
+		"org.opencontainers.image.version": fields["version"].GetStringValue(),
+		"org.agntcy.oasf.schema_version":   fields["schema_version"].GetStringValue(),
+	}
+
+	digest, err := client.pushArtifact(ctx, ref, layers, annotations)
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", ref, err)
+	}
+
+	result := publishResult{Reference: ref.String(), Digest: digest, Signed: opts.keyFile != ""}
+
+	if opts.structured() {
+		return writeStructured(out, opts.output, result)
+	}
+
+	fmt.Fprintf(out, "published %s@%s\n", result.Reference, result.Digest)
+
+	return nil
+}
+
+// signRecordFile signs the canonical record with the PEM private key at path
+// and returns the encoded detached signature.
+func signRecordFile(ctx context.Context, canonical []byte, path string) ([]byte, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	key, err := signer.LoadPrivateKey(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	s, err := signer.NewKeySigner(key)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	sig, err := signer.SignPayload(ctx, canonical, s)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	publicKey, err := signer.EncodePublicKey(s.Public())
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	data, err := json.Marshal(recordSignature{
+		Algorithm: sig.Algorithm,
+		Digest:    signer.Digest(canonical),
+		Signature: sig.Signature,
+		PublicKey: publicKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signature: %w", err)
+	}
+
+	return data, nil
+}
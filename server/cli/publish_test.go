@@ -0,0 +1,204 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeRegistry is a minimal OCI distribution server requiring a bearer token.
+type fakeRegistry struct {
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+func newFakeRegistry(t *testing.T) (*fakeRegistry, *httptest.Server) {
+	t.Helper()
+
+	reg := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+
+		if r.URL.Path == "/token" {
+			if user, pass, _ := r.BasicAuth(); user != "user" || pass != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"token": "abc"}`))
+
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+srv.URL+`/token",service="fake"`)
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		path := strings.TrimPrefix(r.URL.Path, "/v2/agents/demo")
+
+		switch {
+		case r.Method == http.MethodHead && strings.HasPrefix(path, "/blobs/"):
+			if _, ok := reg.blobs[strings.TrimPrefix(path, "/blobs/")]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodPost && path == "/blobs/uploads/":
+			w.Header().Set("Location", "/v2/agents/demo/blobs/uploads/1?state=x")
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/blobs/uploads/"):
+			digest := r.URL.Query().Get("digest")
+			if digestOf(body) != digest || r.URL.Query().Get("state") != "x" {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			reg.blobs[digest] = body
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPut && strings.HasPrefix(path, "/manifests/"):
+			reg.manifests[strings.TrimPrefix(path, "/manifests/")] = body
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return reg, srv
+}
+
+func writeECKey(t *testing.T) (string, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	return path, key
+}
+
+func TestPublishSigned(t *testing.T) {
+	reg, srv := newFakeRegistry(t)
+	keyPath, key := writeECKey(t)
+	t.Setenv(registryPasswordEnv, "secret")
+
+	ref := strings.TrimPrefix(srv.URL, "http://") + "/agents/demo:v1"
+
+	out, err := runCLI(t, validRecord, "publish", "-", "--to", "oci://"+ref, "--plain-http", "--username", "user", "--key", keyPath)
+	if err != nil {
+		t.Fatalf("publish: %v\n%s", err, out)
+	}
+
+	if !strings.HasPrefix(out, "published "+ref+"@sha256:") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(reg.manifests["v1"], &manifest); err != nil {
+		t.Fatalf("manifest not pushed: %v", err)
+	}
+
+	if len(manifest.Layers) != 2 || manifest.Layers[1].MediaType != mediaTypeSignature {
+		t.Fatalf("unexpected layers: %+v", manifest.Layers)
+	}
+
+	var sig recordSignature
+	if err := json.Unmarshal(reg.blobs[manifest.Layers[1].Digest], &sig); err != nil {
+		t.Fatalf("signature blob: %v", err)
+	}
+
+	raw, _ := base64.StdEncoding.DecodeString(sig.Signature)
+	digest := sha256.Sum256(reg.blobs[manifest.Layers[0].Digest])
+
+	if sig.Algorithm != "ES256" || !ecdsa.VerifyASN1(&key.PublicKey, digest[:], raw) {
+		t.Errorf("signature does not verify: %+v", sig)
+	}
+}
+
+func TestPublishSanitized(t *testing.T) {
+	reg, srv := newFakeRegistry(t)
+	ref := strings.TrimPrefix(srv.URL, "http://") + "/agents/demo:v1"
+	in := strings.Replace(validRecord, "{", `{"annotations": {"oasf.sdk.source": "import"}, `, 1)
+	t.Setenv(registryPasswordEnv, "secret")
+
+	if out, err := runCLI(t, in, "publish", "-", "--to", ref, "--plain-http", "--username", "user", "--sanitize"); err != nil {
+		t.Fatalf("publish: %v\n%s", err, out)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(reg.manifests["v1"], &manifest); err != nil {
+		t.Fatalf("manifest not pushed: %v", err)
+	}
+
+	if published := string(reg.blobs[manifest.Layers[0].Digest]); strings.Contains(published, "annotations") {
+		t.Errorf("internal annotations were published: %s", published)
+	}
+}
+
+func TestPublishRejectsInvalidRecord(t *testing.T) {
+	reg, srv := newFakeRegistry(t)
+	ref := strings.TrimPrefix(srv.URL, "http://") + "/agents/demo"
+
+	if _, err := runCLI(t, `{"name": "x"}`, "publish", "-", "--to", ref, "--plain-http"); err == nil {
+		t.Fatal("expected invalid records to be rejected")
+	}
+
+	if len(reg.manifests) != 0 {
+		t.Error("invalid record must not be pushed")
+	}
+}
+
+func TestParseOCIReference(t *testing.T) {
+	for in, want := range map[string]string{
+		"ghcr.io/org/agent":             "ghcr.io/org/agent:latest",
+		"oci://localhost:5000/a/b:v1.0": "localhost:5000/a/b:v1.0",
+	} {
+		ref, err := parseOCIReference(in)
+		if err != nil || ref.String() != want {
+			t.Errorf("parseOCIReference(%q) = %q, %v; want %q", in, ref, err, want)
+		}
+	}
+
+	for _, in := range []string{"agent", "registry/", "ghcr.io/org/agent@sha256:abc"} {
+		if _, err := parseOCIReference(in); err == nil {
+			t.Errorf("parseOCIReference(%q): expected an error", in)
+		}
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultInitSchemaVersion = "1.0.0"
+	defaultInitRecordVersion = "v1.0.0"
+)
+
+type recordInitOptions struct {
+	*globalOptions
+
+	name          string
+	recordVersion string
+	description   string
+	authors       []string
+	modules       []string
+	outFile       string
+	interactive   bool
+}
+
+func newRecordCommand(g *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Work with OASF records",
+	}
+
+	cmd.AddCommand(newRecordInitCommand(g))
+	cmd.AddCommand(newRecordDigestCommand())
+	cmd.AddCommand(newRecordBumpCommand(g))
+	cmd.AddCommand(newRecordAnalyzeCommand(g))
+	cmd.AddCommand(newRecordExportCommand())
+	cmd.AddCommand(newRecordSyncA2ACommand(g))
+
+	return cmd
+}
+
+func newRecordInitCommand(g *globalOptions) *cobra.Command {
+	opts := &recordInitOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Scaffold a new OASF record",
+		Long: `Scaffold a new OASF record for the schema version selected with
+--oasf-version (` + defaultInitSchemaVersion + ` by default).
+
+The scaffold carries the required top-level fields and one entry per --module.
+With --schema-url, module data is pre-filled with a sample generated from the
+module's JSON schema; otherwise module data is left empty. The scaffold is
+checked against the typed OASF model before it is written.
+
+Use --interactive to be prompted for values that were not given as flags.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.interactive {
+				if err := promptRecordInit(cmd.InOrStdin(), cmd.ErrOrStderr(), cmd, opts); err != nil {
+					return err
+				}
+			}
+
+			return runRecordInit(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Record name (e.g. example.org/my-agent)")
+	cmd.Flags().StringVar(&opts.recordVersion, "record-version", defaultInitRecordVersion, "Version of the record itself")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Record description")
+	cmd.Flags().StringSliceVar(&opts.authors, "author", nil, "Record authors")
+	cmd.Flags().StringSliceVar(&opts.modules, "module", nil, "Modules to include (e.g. integration/mcp)")
+	cmd.Flags().StringVar(&opts.outFile, "out", "", "Write the record to a file instead of stdout")
+	cmd.Flags().BoolVarP(&opts.interactive, "interactive", "i", false, "Prompt for values not given as flags")
+
+	return cmd
+}
+
+// promptRecordInit asks for every value the user did not set explicitly.
+func promptRecordInit(in io.Reader, out io.Writer, cmd *cobra.Command, opts *recordInitOptions) error {
+	reader := bufio.NewReader(in)
+
+	if opts.oasfVersion == "" {
+		opts.oasfVersion = defaultInitSchemaVersion
+	}
+
+	ask := func(flag, label string, value *string) error {
+		if cmd.Flags().Changed(flag) {
+			return nil
+		}
+
+		if *value != "" {
+			fmt.Fprintf(out, "%s [%s]: ", label, *value)
+		} else {
+			fmt.Fprintf(out, "%s: ", label)
+		}
+
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read %s: %w", flag, err)
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			*value = line
+		}
+
+		return nil
+	}
+
+	askList := func(flag, label string, values *[]string) error {
+		joined := strings.Join(*values, ",")
+		if err := ask(flag, label+" (comma separated)", &joined); err != nil {
+			return err
+		}
+
+		*values = splitList(joined)
+
+		return nil
+	}
+
+	for _, step := range []func() error{
+		func() error { return ask("name", "Name", &opts.name) },
+		func() error { return ask("oasf-version", "Schema version", &opts.oasfVersion) },
+		func() error { return ask("record-version", "Record version", &opts.recordVersion) },
+		func() error { return ask("description", "Description", &opts.description) },
+		func() error { return askList("author", "Authors", &opts.authors) },
+		func() error { return askList("module", "Modules", &opts.modules) },
+	} {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func splitList(value string) []string {
+	var out []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
+
+func runRecordInit(ctx context.Context, out io.Writer, opts *recordInitOptions) error {
+	if opts.name == "" {
+		return errors.New("--name is required")
+	}
+
+	if opts.oasfVersion == "" {
+		opts.oasfVersion = defaultInitSchemaVersion
+	}
+
+	recordStruct, err := scaffoldRecord(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	if _, err := b.DecodeRecord(ctx, recordStruct); err != nil {
+		return fmt.Errorf("scaffolded record is not valid: %w", err)
+	}
+
+	return writeRecord(out, recordStruct, opts.outFile, opts.output)
+}
+
+// scaffoldRecord builds the generic record map for the given options.
+func scaffoldRecord(ctx context.Context, opts *recordInitOptions) (*structpb.Struct, error) {
+	builder := record.NewBuilder().
+		Name(opts.name).
+		Version(opts.recordVersion).
+		SchemaVersion(opts.oasfVersion).
+		Description(opts.description).
+		Authors(opts.authors...).
+		Set("locators", []any{})
+
+	var (
+		client *schema.Schema
+		err    error
+	)
+
+	if len(opts.modules) > 0 && opts.schemaURL != "" {
+		client, err = schema.New(opts.schemaURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create schema client: %w", err)
+		}
+	}
+
+	for _, name := range opts.modules {
+		name = record.ModuleNameForVersion(name, opts.oasfVersion)
+
+		data := map[string]any{}
+
+		if client != nil {
+			data, err = sampleModuleData(ctx, client, name, opts.oasfVersion)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		dataStruct, err := structpb.NewStruct(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s module data: %w", name, err)
+		}
+
+		builder.AddModule(name, dataStruct)
+	}
+
+	rec, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build record: %w", err)
+	}
+
+	return rec, nil
+}
+
+// sampleModuleData fetches the module's JSON schema and generates sample data
+// for its required "data" properties.
+func sampleModuleData(ctx context.Context, client *schema.Schema, name, schemaVersion string) (map[string]any, error) {
+	raw, err := client.GetJSONSchema(ctx, schema.EntityTypeModules, name, schema.WithSchemaVersion(schemaVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema for module %s: %w", name, err)
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal(raw, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse schema for module %s: %w", name, err)
+	}
+
+	// Module schemas describe the whole module object; the sample is for its
+	// data payload when the schema exposes one.
+	target := root
+	if properties, ok := root["properties"].(map[string]any); ok {
+		if dataSchema, ok := properties["data"].(map[string]any); ok {
+			target = dataSchema
+		}
+	}
+
+	data, _ := sampleFromJSONSchema(target, root).(map[string]any)
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	return data, nil
+}
+
+func newRecordDigestCommand() *cobra.Command {
+	var exclude []string
+
+	cmd := &cobra.Command{
+		Use:   "digest <record.json|->...",
+		Short: "Print the content digest of records",
+		Long: `Print the sha256 digest of each record's canonical JSON (compact, sorted
+keys). Fields listed with --exclude are left out; by default created_at and
+signature are, so re-created or re-signed records keep their digest.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inputs, err := resolveInputs(args, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			for _, in := range inputs {
+				rec, err := parseRecord(in)
+				if err != nil {
+					return err
+				}
+
+				digest, err := record.Digest(rec, exclude)
+				if err != nil {
+					return fmt.Errorf("%s: %w", in.name, err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", digest, in.name)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&exclude, "exclude", record.DefaultExclusions, "Top-level fields left out of the digest")
+
+	return cmd
+}
+
+func newRecordBumpCommand(g *globalOptions) *cobra.Command {
+	var level, outFile string
+
+	cmd := &cobra.Command{
+		Use:   "bump <record.json|-> [--level patch|minor|major]",
+		Short: "Prepare the next version of a record",
+		Long: `Print the next version of a record: its semantic version incremented by
+--level, created_at set to now, linked to the record by its digest (in
+previous_record_cid for 0.7.0 and 0.8.0 records, in the
+lineage.previous_record_cid annotation otherwise) and without the signature,
+which no longer matches. Sign the result again before publishing it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bump, err := record.ParseVersionBump(level)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			inputs, err := resolveInputs(args, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			if len(inputs) != 1 {
+				return fmt.Errorf("expected a single record, got %d", len(inputs))
+			}
+
+			rec, err := parseRecord(inputs[0])
+			if err != nil {
+				return err
+			}
+
+			bumped, err := record.BumpVersion(rec, bump)
+			if err != nil {
+				return fmt.Errorf("%s: %w", inputs[0].name, err)
+			}
+
+			return writeRecord(cmd.OutOrStdout(), bumped, outFile, g.output)
+		},
+	}
+
+	cmd.Flags().StringVar(&level, "level", string(record.BumpPatch), "Version part to increment: patch, minor or major")
+	cmd.Flags().StringVar(&outFile, "out", "", "Write the record to a file instead of stdout")
+
+	_ = cmd.RegisterFlagCompletionFunc("level", cobra.FixedCompletions(
+		[]string{string(record.BumpPatch), string(record.BumpMinor), string(record.BumpMajor)}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// recordAnalysis is the analysis of an input, as printed by "record analyze".
+type recordAnalysis struct {
+	File string `json:"file" yaml:"file"`
+
+	*record.Analysis `yaml:",inline"`
+}
+
+func newRecordAnalyzeCommand(g *globalOptions) *cobra.Command {
+	limits := record.DefaultLimits
+
+	cmd := &cobra.Command{
+		Use:   "analyze <record.json|->...",
+		Short: "Report the size and complexity of records",
+		Long: `Report the canonical JSON size of records, their largest modules, and their
+module, connection, skill and annotation counts. Records exceeding a limit
+(the 4 MiB default gRPC message size and 64 KiB of annotations by default,
+0 disables a limit) are reported with a warning, e.g. before publishing to a
+directory with size caps.
+
+Exit codes: 0 when all records are within the limits, 1 otherwise, 2 when
+the analysis could not run.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecordAnalyze(cmd.InOrStdin(), cmd.OutOrStdout(), args, g, limits)
+		},
+	}
+
+	cmd.Flags().IntVar(&limits.MaxSize, "max-size", limits.MaxSize, "Maximum record size in bytes")
+	cmd.Flags().IntVar(&limits.MaxModuleSize, "max-module-size", limits.MaxModuleSize, "Maximum module size in bytes")
+	cmd.Flags().IntVar(&limits.MaxModules, "max-modules", limits.MaxModules, "Maximum number of modules")
+	cmd.Flags().IntVar(&limits.MaxConnections, "max-connections", limits.MaxConnections, "Maximum number of connections")
+	cmd.Flags().IntVar(&limits.MaxAnnotationBytes, "max-annotation-bytes", limits.MaxAnnotationBytes, "Maximum size of the annotations in bytes")
+
+	return cmd
+}
+
+func runRecordAnalyze(stdin io.Reader, out io.Writer, args []string, g *globalOptions, limits record.Limits) error {
+	inputs, err := resolveInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	analyses := make([]recordAnalysis, 0, len(inputs))
+	exceeded := false
+
+	for _, in := range inputs {
+		rec, err := parseRecord(in)
+		if err != nil {
+			return err
+		}
+
+		analysis, err := record.Analyze(rec, limits)
+		if err != nil {
+			return fmt.Errorf("%s: %w", in.name, err)
+		}
+
+		analyses = append(analyses, recordAnalysis{File: in.name, Analysis: analysis})
+		exceeded = exceeded || len(analysis.Warnings) > 0
+	}
+
+	if g.structured() {
+		if err := writeStructured(out, g.output, analyses); err != nil {
+			return err
+		}
+	} else {
+		for _, a := range analyses {
+			printRecordAnalysis(out, a)
+		}
+	}
+
+	if exceeded {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+func printRecordAnalysis(out io.Writer, a recordAnalysis) {
+	fmt.Fprintf(out, "%s: %d bytes, %d module(s), %d connection(s), %d skill(s), %d domain(s), %d locator(s), %d annotation(s) (%d bytes), depth %d\n",
+		a.File, a.Size, len(a.Modules), a.Connections, a.Skills, a.Domains, a.Locators, a.Annotations, a.AnnotationBytes, a.Depth)
+
+	for _, m := range a.Modules {
+		fmt.Fprintf(out, "  module %-24s %d bytes\n", m.Name, m.Size)
+	}
+
+	for _, w := range a.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", w)
+	}
+}
+
+// exportFormats maps record export formats to their encoder and the suffix of
+// exported files.
+var exportFormats = map[string]struct {
+	suffix  string
+	marshal func(*structpb.Struct) ([]byte, error)
+}{
+	"yaml": {".yaml", record.MarshalYAML},
+	"toml": {".toml", record.MarshalTOML},
+}
+
+func newRecordExportCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "export <record.json|->... [--format yaml|toml]",
+		Short: "Print records as YAML or TOML",
+		Long: `Print records as YAML, with identifying fields first and other keys sorted,
+or as TOML, with sorted keys, e.g. to keep records in a GitOps repository.
+Several records are printed as separate YAML documents; TOML export takes a
+single record. Use the pipeline "export" step to write files.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecordExport(cmd.InOrStdin(), cmd.OutOrStdout(), args, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "yaml", "Export format: yaml or toml")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"yaml", "toml"}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func runRecordExport(stdin io.Reader, out io.Writer, args []string, format string) error {
+	encoding, ok := exportFormats[format]
+	if !ok {
+		return fmt.Errorf("invalid --format value %q (want yaml or toml)", format)
+	}
+
+	inputs, err := resolveInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	if format == "toml" && len(inputs) != 1 {
+		return fmt.Errorf("toml export expects a single record, got %d", len(inputs))
+	}
+
+	for i, in := range inputs {
+		rec, err := parseRecord(in)
+		if err != nil {
+			return err
+		}
+
+		data, err := encoding.marshal(rec)
+		if err != nil {
+			return fmt.Errorf("%s: %w", in.name, err)
+		}
+
+		if i > 0 {
+			fmt.Fprintln(out, "---")
+		}
+
+		if _, err := out.Write(data); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
+		}
+	}
+
+	return nil
+}
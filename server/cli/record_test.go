@@ -0,0 +1,212 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordInit(t *testing.T) {
+	out, err := runCLI(t, "", "record", "init",
+		"--name", "example.org/agent", "--oasf-version", "0.7.0", "--module", "integration/mcp", "--author", "Jane")
+	if err != nil {
+		t.Fatalf("record init: %v\n%s", err, out)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(out), &record); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+
+	if record["schema_version"] != "0.7.0" || record["name"] != "example.org/agent" {
+		t.Errorf("unexpected record: %v", record)
+	}
+
+	modules, _ := record["modules"].([]any)
+	if len(modules) != 1 || modules[0].(map[string]any)["name"] != "runtime/mcp" {
+		t.Errorf("expected runtime/mcp module for 0.7.0, got %v", record["modules"])
+	}
+
+	// The scaffold must pass local validation as-is.
+	dir := writeFiles(t, map[string]string{"record.json": out})
+	if out, err := runCLI(t, "", "validate", filepath.Join(dir, "record.json")); err != nil {
+		t.Errorf("scaffold does not validate: %v\n%s", err, out)
+	}
+}
+
+func TestRecordInitSchemaSample(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/versions" {
+			_, _ = w.Write([]byte(`{"default": {"schema_version": "0.8.0"}, "versions": [{"schema_version": "0.8.0"}]}`))
+
+			return
+		}
+
+		if !strings.HasSuffix(r.URL.Path, "/modules/integration/mcp") {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"properties": {"data": {"$ref": "#/$defs/data"}},
+			"$defs": {
+				"data": {
+					"type": "object",
+					"required": ["servers", "transport"],
+					"properties": {
+						"servers": {"type": "array"},
+						"transport": {"enum": ["stdio", "http"]},
+						"optional": {"type": "string"}
+					}
+				}
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "record.json")
+
+	out, err := runCLI(t, "", "record", "init",
+		"--name", "example.org/agent", "--oasf-version", "0.8.0", "--module", "integration/mcp",
+		"--schema-url", srv.URL, "--out", file)
+	if err != nil {
+		t.Fatalf("record init: %v\n%s", err, out)
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	var record struct {
+		Modules []struct {
+			Data map[string]any `json:"data"`
+		} `json:"modules"`
+	}
+	if err := json.Unmarshal(raw, &record); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if len(record.Modules) != 1 {
+		t.Fatalf("expected one module, got %s", raw)
+	}
+
+	data := record.Modules[0].Data
+	if data["transport"] != "stdio" || data["servers"] == nil {
+		t.Errorf("unexpected sample data: %v", data)
+	}
+
+	if _, ok := data["optional"]; ok {
+		t.Errorf("optional properties must not be sampled: %v", data)
+	}
+}
+
+func TestRecordInitInteractive(t *testing.T) {
+	out, err := runCLI(t, "example.org/prompted\n\n\nA description\n\n\n", "record", "init", "-i")
+	if err != nil {
+		t.Fatalf("record init: %v\n%s", err, out)
+	}
+
+	for _, want := range []string{`"name": "example.org/prompted"`, `"description": "A description"`, `"schema_version": "1.0.0"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestRecordInitRequiresName(t *testing.T) {
+	if _, err := runCLI(t, "", "record", "init"); err == nil {
+		t.Fatal("expected an error without --name")
+	}
+}
+
+func TestRecordDigest(t *testing.T) {
+	signed := strings.Replace(validRecord, "{", `{"created_at": "2025-01-01T00:00:00Z", `, 1)
+	dir := writeFiles(t, map[string]string{"a.json": validRecord, "b.json": signed})
+
+	out, err := runCLI(t, "", "record", "digest", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"))
+	if err != nil {
+		t.Fatalf("record digest: %v\n%s", err, out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || strings.Fields(lines[0])[0] != strings.Fields(lines[1])[0] {
+		t.Errorf("expected equal digests, got\n%s", out)
+	}
+
+	out, err = runCLI(t, "", "record", "digest", filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json"), "--exclude", "signature")
+	if err != nil {
+		t.Fatalf("record digest: %v\n%s", err, out)
+	}
+
+	if lines = strings.Split(strings.TrimSpace(out), "\n"); strings.Fields(lines[0])[0] == strings.Fields(lines[1])[0] {
+		t.Errorf("expected created_at to change the digest, got\n%s", out)
+	}
+}
+
+func TestRecordBump(t *testing.T) {
+	out, err := runCLI(t, validRecord, "record", "bump", "--level", "minor", "-")
+	if err != nil {
+		t.Fatalf("record bump: %v\n%s", err, out)
+	}
+
+	var bumped map[string]any
+	if err := json.Unmarshal([]byte(out), &bumped); err != nil {
+		t.Fatalf("invalid output: %v\n%s", err, out)
+	}
+
+	annotations, _ := bumped["annotations"].(map[string]any)
+	if bumped["version"] != "1.1.0" || bumped["created_at"] == nil || annotations["lineage.previous_record_cid"] == nil {
+		t.Errorf("unexpected record: %v", bumped)
+	}
+
+	if _, err := runCLI(t, validRecord, "record", "bump", "--level", "micro", "-"); err == nil {
+		t.Error("expected an error for an invalid level")
+	}
+}
+
+func TestRecordAnalyze(t *testing.T) {
+	out, err := runCLI(t, validRecord, "record", "analyze", "-o", "json", "-")
+	if err != nil {
+		t.Fatalf("record analyze: %v\n%s", err, out)
+	}
+
+	var analyses []map[string]any
+	if err := json.Unmarshal([]byte(out), &analyses); err != nil || len(analyses) != 1 || analyses[0]["size"] != float64(len(`{"name":"example.org/agent","schema_version":"1.0.0","version":"1.0.0"}`)) {
+		t.Errorf("unexpected analysis: %v\n%s", err, out)
+	}
+
+	out, err = runCLI(t, validRecord, "record", "analyze", "--max-size", "10", "-")
+	if !errors.Is(err, errChecksFailed) || !strings.Contains(out, "warning: record is 71 bytes, over the 10 byte limit") {
+		t.Errorf("expected a size warning, got %v\n%s", err, out)
+	}
+}
+
+func TestRecordExport(t *testing.T) {
+	out, err := runCLI(t, validRecord, "record", "export", "-")
+	if err != nil {
+		t.Fatalf("record export: %v\n%s", err, out)
+	}
+
+	if out != "name: example.org/agent\nversion: 1.0.0\nschema_version: 1.0.0\n" {
+		t.Errorf("unexpected YAML:\n%s", out)
+	}
+
+	out, err = runCLI(t, validRecord, "record", "export", "--format", "toml", "-")
+	if err != nil || !strings.Contains(out, "name = 'example.org/agent'") {
+		t.Errorf("unexpected TOML: %v\n%s", err, out)
+	}
+
+	if _, err := runCLI(t, validRecord, "record", "export", "--format", "xml", "-"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cli implements the oasf-sdk command line. Running the binary without
+// a subcommand starts the gRPC server, so existing deployments keep working;
+// the subcommands expose the SDK libraries for local and CI use.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/agntcy/oasf-sdk/server"
+	"github.com/agntcy/oasf-sdk/server/config"
+	"github.com/spf13/cobra"
+)
+
+// Process exit codes returned by Execute.
+const (
+	// ExitOK means the command succeeded and all inputs passed.
+	ExitOK = 0
+	// ExitFailed means the command ran but at least one input failed its check
+	// (e.g. an invalid record), which CI should treat as a failure.
+	ExitFailed = 1
+	// ExitError means the command could not run (bad flags, unreadable input,
+	// unreachable schema server).
+	ExitError = 2
+)
+
+// errChecksFailed is returned by commands whose inputs did not pass. The
+// command has already reported the details, so Execute only maps it to
+// ExitFailed without printing it again.
+var errChecksFailed = errors.New("one or more inputs failed")
+
+// NewRootCommand builds the root command with all subcommands attached.
+func NewRootCommand() *cobra.Command {
+	g := &globalOptions{}
+
+	rootCmd := &cobra.Command{
+		Use:           "oasf-sdk",
+		Short:         "OASF SDK",
+		Long:          "Tools and a server for handling OASF records. Without a subcommand, starts the server.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE:          runServer,
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			return g.validate()
+		},
+	}
+
+	flags := rootCmd.PersistentFlags()
+	flags.StringVarP(&g.output, "output", "o", outputTable, "Output format: table, json, yaml or ndjson")
+	flags.StringVar(&g.schemaURL, "schema-url", "", "OASF schema server to use (local checks only when empty)")
+	flags.StringVar(&g.oasfVersion, "oasf-version", "", "OASF schema version for generated records")
+	flags.StringVar(&g.server, "server", "", "Run against a deployed oasf-sdk server (host:port) instead of in-process")
+	flags.BoolVar(&g.tls, "tls", false, "Use TLS to connect to --server")
+	flags.StringVar(&g.apiKey, "api-key", "", "API key sent as a bearer token to --server (defaults to $"+apiKeyEnv+")")
+
+	_ = rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.RegisterFlagCompletionFunc("oasf-version", cobra.FixedCompletions(
+		[]string{"0.7.0", "0.8.0", "1.0.0"}, cobra.ShellCompDirectiveNoFileComp))
+
+	rootCmd.AddCommand(
+		newServerCommand(g),
+		newValidateCommand(g),
+		newDecodeCommand(g),
+		newRecordCommand(g),
+		newDiffCommand(g),
+		newLintCommand(g),
+		newFetchCommand(g),
+		newPipelineCommand(g),
+		newWorkerCommand(g),
+		newPublishCommand(g),
+		newSignCommand(g),
+		newVerifyCommand(g),
+		newSelfTestCommand(g),
+		newAnalyzeCommand(g),
+		newCompletionCommand(),
+	)
+
+	return rootCmd
+}
+
+// Server modes selected with "server --mode".
+const (
+	serverModeGRPC      = "grpc"
+	serverModeBatch     = "batch"
+	serverModeDiscovery = "discovery"
+)
+
+const defaultDiscoveryListenAddress = "0.0.0.0:8080"
+
+type serverOptions struct {
+	*globalOptions
+
+	mode   string
+	inputs []string
+	output string
+	steps  []string
+	listen string
+}
+
+func newServerCommand(g *globalOptions) *cobra.Command {
+	opts := &serverOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "server [--mode batch|discovery --input <location>...]",
+		Short: "Run the OASF SDK server",
+		Long: `A server for handling OASF SDK requests.
+
+With --mode batch, the server instead processes the records at the --input
+locations once: each is run through --steps (see "pipeline"; by default
+migrate and validate) and re-written to --output, and a JSON summary report is
+printed. Locations are directories, globs or object storage prefixes
+(s3://bucket/prefix/), which suits periodic catalog hygiene jobs. The exit
+code is 1 when a record failed.
+
+With --mode discovery, the server hosts the discovery documents of the
+records at the --input locations over HTTP on --listen: the A2A agent card
+(/.well-known/agent-card.json and /.well-known/agent.json) and the VS Code MCP
+configuration (/mcp.json), under /<record name> or, for a single record, at
+the root. A liveness probe is served on /healthz.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch opts.mode {
+			case serverModeGRPC:
+				return runServer(cmd, args)
+			case serverModeBatch:
+				return runBatch(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), opts)
+			case serverModeDiscovery:
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				return runDiscovery(ctx, cmd.InOrStdin(), cmd.OutOrStdout(), opts)
+			default:
+				return fmt.Errorf("unsupported mode %q (want %s, %s or %s)", opts.mode, serverModeGRPC, serverModeBatch, serverModeDiscovery)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.mode, "mode", serverModeGRPC, "Server mode: grpc, batch or discovery")
+	cmd.Flags().StringSliceVar(&opts.inputs, "input", nil, "Batch and discovery modes: records to process (directories, globs or s3:// prefixes)")
+	// Shadows the global --output format flag; the batch report is always JSON.
+	cmd.Flags().StringVar(&opts.output, "output", "", "Batch mode: directory or s3:// prefix to write the processed records to")
+	cmd.Flags().StringSliceVar(&opts.steps, "steps", []string{"migrate", "validate"}, "Batch mode: comma-separated pipeline steps")
+	cmd.Flags().StringVar(&opts.listen, "listen", defaultDiscoveryListenAddress, "Discovery mode: HTTP listen address")
+
+	_ = cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions(
+		[]string{serverModeGRPC, serverModeBatch, serverModeDiscovery}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+// runBatch runs the pipeline once over the batch inputs and reports in JSON.
+func runBatch(ctx context.Context, stdin io.Reader, out io.Writer, opts *serverOptions) error {
+	if len(opts.inputs) == 0 {
+		return errors.New("batch mode requires --input")
+	}
+
+	g := *opts.globalOptions
+	g.output = outputJSON
+
+	return runPipeline(ctx, stdin, out, &pipelineOptions{
+		globalOptions: &g,
+		inputs:        opts.inputs,
+		steps:         opts.steps,
+		outDir:        opts.output,
+	})
+}
+
+func newCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion <bash|zsh|fish|powershell>",
+		Short: "Generate a shell completion script",
+		Long: `Generate a shell completion script, e.g.
+
+  source <(oasf-sdk completion bash)
+  oasf-sdk completion zsh > "${fpath[1]}/_oasf-sdk"`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true) //nolint:wrapcheck
+			case "zsh":
+				return root.GenZshCompletion(out) //nolint:wrapcheck
+			case "fish":
+				return root.GenFishCompletion(out, true) //nolint:wrapcheck
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(out) //nolint:wrapcheck
+			default:
+				return fmt.Errorf("unsupported shell %q", args[0])
+			}
+		},
+	}
+}
+
+func runServer(cmd *cobra.Command, _ []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return server.Run(cmd.Context(), cfg)
+}
+
+// Execute runs the root command and returns the process exit code.
+func Execute(ctx context.Context) int {
+	cmd := NewRootCommand()
+
+	err := cmd.ExecuteContext(ctx)
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, errChecksFailed):
+		return ExitFailed
+	default:
+		fmt.Fprintln(os.Stderr, "Error:", err)
+
+		return ExitError
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+func TestGlobalOutputFormats(t *testing.T) {
+	out, err := runCLI(t, validRecord, "validate", "--output", "json", "-")
+	if err != nil {
+		t.Fatalf("validate: %v\n%s", err, out)
+	}
+
+	var report validateReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out)
+	}
+
+	if report.Mode != validateModeDecode || report.Total != 1 || report.Passed != 1 || report.Results[0].Name != "<stdin>" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	out, err = runCLI(t, validRecord, "lint", "-o", "yaml", "-")
+	if err != nil {
+		t.Fatalf("lint: %v\n%s", err, out)
+	}
+
+	var lint lintReport
+	if err := yaml.Unmarshal([]byte(out), &lint); err != nil {
+		t.Fatalf("output is not YAML: %v\n%s", err, out)
+	}
+
+	if lint.Records != 1 || lint.Warnings == 0 || lint.Findings[0].RuleID == "" {
+		t.Errorf("unexpected report: %+v", lint)
+	}
+
+	if f := lint.Findings[0]; string(f.Code) != "lint/"+f.RuleID {
+		t.Errorf("finding code = %q, want lint/%s", f.Code, f.RuleID)
+	}
+
+	if _, err := runCLI(t, validRecord, "validate", "--output", "xml", "-"); err == nil {
+		t.Error("expected an error for an unknown output format")
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		out, err := runCLI(t, "", "completion", shell)
+		if err != nil {
+			t.Fatalf("completion %s: %v", shell, err)
+		}
+
+		if !strings.Contains(out, "oasf-sdk") {
+			t.Errorf("completion %s does not mention the command:\n%.200s", shell, out)
+		}
+	}
+
+	out, err := runCLI(t, "", "__complete", "validate", "--output", "")
+	if err != nil {
+		t.Fatalf("__complete: %v", err)
+	}
+
+	for _, format := range outputFormats {
+		if !strings.Contains(out, format) {
+			t.Errorf("--output completion missing %q:\n%s", format, out)
+		}
+	}
+}
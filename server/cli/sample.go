@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSampleDepth bounds recursion through nested (or self-referencing) schemas.
+const maxSampleDepth = 8
+
+// sampleFromJSONSchema generates a minimal sample value for a JSON schema: only
+// required object properties are filled in, using const/default/enum/example
+// values when present and zero values for the declared type otherwise. Local
+// "$ref"s ("#/$defs/...", "#/definitions/...") are resolved against root.
+func sampleFromJSONSchema(schema, root map[string]any) any {
+	return sampleValue(schema, root, 0)
+}
+
+func sampleValue(schema, root map[string]any, depth int) any { //nolint:cyclop
+	if schema == nil || depth > maxSampleDepth {
+		return nil
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		return sampleValue(resolveLocalRef(root, ref), root, depth+1)
+	}
+
+	for _, key := range []string{"const", "default"} {
+		if v, ok := schema[key]; ok {
+			return v
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if examples, ok := schema["examples"].([]any); ok && len(examples) > 0 {
+		return examples[0]
+	}
+
+	for _, key := range []string{"oneOf", "anyOf", "allOf"} {
+		if variants, ok := schema[key].([]any); ok && len(variants) > 0 {
+			if variant, ok := variants[0].(map[string]any); ok {
+				return sampleValue(variant, root, depth+1)
+			}
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return sampleObject(schema, root, depth)
+	case "array":
+		return []any{}
+	case "string":
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	default:
+		if _, ok := schema["properties"]; ok {
+			return sampleObject(schema, root, depth)
+		}
+
+		return nil
+	}
+}
+
+func sampleObject(schema, root map[string]any, depth int) map[string]any {
+	out := map[string]any{}
+
+	properties, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+
+	names := make([]string, 0, len(required))
+	for _, r := range required {
+		if name, ok := r.(string); ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propSchema, _ := properties[name].(map[string]any)
+		out[name] = sampleValue(propSchema, root, depth+1)
+	}
+
+	return out
+}
+
+// schemaType returns the schema's type, picking the first non-null entry when
+// "type" is a list.
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+
+	return ""
+}
+
+// resolveLocalRef resolves a JSON pointer reference within the root document.
+func resolveLocalRef(root map[string]any, ref string) map[string]any {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+
+	var current any = root
+
+	for _, part := range strings.Split(pointer, "/") {
+		node, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = node[strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")]
+	}
+
+	resolved, _ := current.(map[string]any)
+
+	return resolved
+}
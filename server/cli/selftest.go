@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/translator/conformance"
+	"github.com/spf13/cobra"
+)
+
+type selfTestOptions struct {
+	*globalOptions
+
+	targets []string
+}
+
+func newSelfTestCommand(g *globalOptions) *cobra.Command {
+	opts := &selfTestOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Check the translators against the embedded conformance fixtures",
+		Long: `Run the translators against the canonical fixtures embedded in the binary
+and report, per target, whether they produce the expected outputs. Use it to
+verify a build or an upgraded deployment.
+
+Exit codes: 0 when every case passes, 1 otherwise, 2 when the test could not
+run.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSelfTest(cmd.Context(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.targets, "target", nil, "Targets to test (default all), e.g. gh-copilot,a2a")
+
+	return cmd
+}
+
+func runSelfTest(ctx context.Context, out io.Writer, opts *selfTestOptions) error {
+	cases, err := selfTestCases(opts.targets)
+	if err != nil {
+		return err
+	}
+
+	report := conformance.Run(ctx, cases...)
+
+	if opts.output != outputTable {
+		if err := writeStructured(out, opts.output, report); err != nil {
+			return err
+		}
+	} else {
+		for _, result := range report.Results {
+			status := "PASS"
+			if !result.Passed {
+				status = "FAIL"
+			}
+
+			fmt.Fprintf(out, "%s  %-16s %s", status, result.Target, result.Case)
+
+			if result.Error != "" {
+				fmt.Fprintf(out, ": %s", result.Error)
+			}
+
+			fmt.Fprintln(out)
+		}
+	}
+
+	if !report.Passed {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// selfTestCases returns the conformance cases of the targets, all of them
+// when none are given.
+func selfTestCases(targets []string) ([]conformance.Case, error) {
+	if len(targets) == 0 {
+		return conformance.Cases, nil
+	}
+
+	var (
+		cases []conformance.Case
+		known []string
+	)
+
+	for _, c := range conformance.Cases {
+		if !slices.Contains(known, c.Target) {
+			known = append(known, c.Target)
+		}
+
+		if slices.Contains(targets, c.Target) {
+			cases = append(cases, c)
+		}
+	}
+
+	for _, target := range targets {
+		if !slices.Contains(known, target) {
+			return nil, fmt.Errorf("unknown target %q (want one of %s)", target, strings.Join(known, ", "))
+		}
+	}
+
+	return cases, nil
+}
@@ -0,0 +1,33 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	out, err := runCLI(t, "", "selftest")
+	if err != nil {
+		t.Fatalf("selftest failed: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "PASS  gh-copilot") || strings.Contains(out, "FAIL") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	out, err = runCLI(t, "", "selftest", "--target", "a2a", "-o", "json")
+	if err != nil {
+		t.Fatalf("selftest failed: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, `"passed": true`) || strings.Contains(out, "gh-copilot") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if _, err := runCLI(t, "", "selftest", "--target", "nope"); err == nil || !strings.Contains(err.Error(), "unknown target") {
+		t.Errorf("expected unknown target error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"github.com/spf13/cobra"
+)
+
+// identityTokenEnv is read when --identity-token is not given; it is the
+// variable Sigstore tooling and CI integrations already export.
+const identityTokenEnv = "SIGSTORE_ID_TOKEN"
+
+type signOptions struct {
+	*globalOptions
+
+	keyFile       string
+	identityToken string
+	fulcioURL     string
+	tlog          bool
+	rekorURL      string
+}
+
+func newSignCommand(g *globalOptions) *cobra.Command {
+	opts := &signOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "sign <record.json|-> (--key <key.pem> | --identity-token <jwt>)",
+		Short: "Sign a record and print its OASF signature object",
+		Long: `Sign a record's canonical JSON (compact, sorted keys, without the "signature"
+field) and print the OASF signature object.
+
+With --key (a PEM encoded ECDSA P-256 or Ed25519 private key) the signature
+bundle carries the public key. Without it, the record is signed keyless: an
+ephemeral key is certified by Fulcio for the identity of the OIDC token given
+with --identity-token (or $` + identityTokenEnv + `), and the bundle carries the
+certificate chain.
+
+With --tlog the signature is also recorded in the Rekor transparency log at
+--rekor-url; the log entry and its inclusion proof are added to the bundle and
+the log index to the signature annotations.
+
+Signing always runs locally, also with --server, so keys and identity tokens
+never leave the machine.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSign(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.keyFile, "key", "", "PEM private key to sign with")
+	cmd.Flags().StringVar(&opts.identityToken, "identity-token", "", "OIDC identity token for keyless signing (defaults to $"+identityTokenEnv+")")
+	cmd.Flags().StringVar(&opts.fulcioURL, "fulcio-url", signer.DefaultFulcioURL, "Fulcio instance for keyless signing")
+	cmd.Flags().BoolVar(&opts.tlog, "tlog", false, "Record the signature in a transparency log")
+	cmd.Flags().StringVar(&opts.rekorURL, "rekor-url", signer.DefaultRekorURL, "Rekor instance for --tlog")
+
+	return cmd
+}
+
+func runSign(ctx context.Context, stdin io.Reader, out io.Writer, arg string, opts *signOptions) error {
+	inputs, err := resolveInputs([]string{arg}, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) != 1 {
+		return fmt.Errorf("sign expects a single record, %s matched %d files", arg, len(inputs))
+	}
+
+	record, err := parseRecord(inputs[0])
+	if err != nil {
+		return err
+	}
+
+	s, err := opts.newSigner(ctx)
+	if err != nil {
+		return err
+	}
+
+	var signOpts []signer.SignOption
+	if opts.tlog {
+		signOpts = append(signOpts, signer.WithTransparencyLog(opts.rekorURL))
+	}
+
+	sig, err := signer.Sign(ctx, record, s, signOpts...)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	format := opts.output
+	if !opts.structured() {
+		format = outputJSON
+	}
+
+	return writeStructured(out, format, sig)
+}
+
+// newSigner returns the key signer for --key, or a keyless signer for the
+// identity token.
+func (o *signOptions) newSigner(ctx context.Context) (signer.Signer, error) {
+	token := o.identityToken
+	if token == "" {
+		token = os.Getenv(identityTokenEnv)
+	}
+
+	if o.keyFile != "" {
+		if o.identityToken != "" {
+			return nil, errors.New("--key and --identity-token are mutually exclusive")
+		}
+
+		keyPEM, err := os.ReadFile(o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key: %w", err)
+		}
+
+		key, err := signer.LoadPrivateKey(keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.keyFile, err)
+		}
+
+		return signer.NewKeySigner(key) //nolint:wrapcheck
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("either --key or --identity-token (or $%s) is required", identityTokenEnv)
+	}
+
+	s, err := signer.NewKeylessSigner(ctx, token, signer.WithFulcioURL(o.fulcioURL))
+	if err != nil {
+		return nil, fmt.Errorf("keyless signing failed: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestSignWithKey(t *testing.T) {
+	keyPath, key := writeECKey(t)
+
+	out, err := runCLI(t, validRecord, "sign", "-", "--key", keyPath)
+	if err != nil {
+		t.Fatalf("sign: %v\n%s", err, out)
+	}
+
+	var sig signer.Signature
+	if err := json.Unmarshal([]byte(out), &sig); err != nil {
+		t.Fatalf("output is not a signature object: %v\n%s", err, out)
+	}
+
+	rec := &structpb.Struct{}
+	if err := rec.UnmarshalJSON([]byte(validRecord)); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	payload, err := signer.CanonicalJSON(rec)
+	if err != nil {
+		t.Fatalf("CanonicalJSON: %v", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	raw, _ := base64.StdEncoding.DecodeString(sig.Signature)
+
+	if sig.Algorithm != signer.AlgorithmES256 || !ecdsa.VerifyASN1(&key.PublicKey, digest[:], raw) {
+		t.Errorf("signature does not verify: %+v", sig)
+	}
+}
+
+func TestSignRequiresKeyOrToken(t *testing.T) {
+	t.Setenv(identityTokenEnv, "")
+
+	_, err := runCLI(t, validRecord, "sign", "-")
+	if err == nil || !strings.Contains(err.Error(), "--identity-token") {
+		t.Errorf("expected a missing credentials error, got %v", err)
+	}
+
+	keyPath, _ := writeECKey(t)
+
+	if _, err := runCLI(t, validRecord, "sign", "-", "--key", keyPath, "--identity-token", "a.b.c"); err == nil {
+		t.Error("expected --key and --identity-token to be mutually exclusive")
+	}
+}
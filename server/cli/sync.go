@@ -0,0 +1,136 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/a2aclient"
+	"github.com/spf13/cobra"
+)
+
+const sectionA2ACard = "a2a card"
+
+type syncA2AOptions struct {
+	*globalOptions
+
+	url      string
+	timeout  time.Duration
+	extended bool
+	write    bool
+	color    string
+}
+
+func newRecordSyncA2ACommand(g *globalOptions) *cobra.Command {
+	opts := &syncA2AOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "sync-a2a <record.json|->",
+		Short: "Update a record's A2A module from the deployed agent's card",
+		Long: `Fetch the agent's current A2A card and compare it with the card stored in the
+record's A2A module: skills are matched by id, other card fields compared as a
+whole. With --write, the record file is updated to the fetched card.
+
+The card is fetched from --url, or else from the origin of the URL in the
+stored card. With --extended, the authenticated extended card is used when the
+agent advertises one, using the bearer token in $` + a2aTokenEnv + `.
+
+Exit codes: 0 when the record is in sync or was updated, 1 when it drifted from
+the agent, 2 when the sync could not run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSyncA2A(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.url, "url", "", "Agent or agent card URL (default: from the stored card)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", defaultFetchTimeout, "HTTP timeout")
+	cmd.Flags().BoolVar(&opts.extended, "extended", false, "Use the authenticated extended card when the agent has one")
+	cmd.Flags().BoolVar(&opts.write, "write", false, "Update the record file")
+	cmd.Flags().StringVar(&opts.color, "color", "auto", "Colorize output: auto, always or never")
+
+	return cmd
+}
+
+func runSyncA2A(ctx context.Context, stdin io.Reader, out io.Writer, path string, opts *syncA2AOptions) error {
+	if opts.write && path == stdinArg {
+		return errors.New("--write needs a record file, not stdin")
+	}
+
+	inputs, err := resolveInputs([]string{path}, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) != 1 {
+		return fmt.Errorf("sync-a2a expects a single record, %s matched %d files", path, len(inputs))
+	}
+
+	record, err := parseRecord(inputs[0])
+	if err != nil {
+		return err
+	}
+
+	clientOpts := []a2aclient.Option{a2aclient.WithHTTPClient(&http.Client{Timeout: opts.timeout})}
+
+	if opts.extended {
+		token := os.Getenv(a2aTokenEnv)
+		if token == "" {
+			return fmt.Errorf("--extended requires a bearer token in $%s", a2aTokenEnv)
+		}
+
+		clientOpts = append(clientOpts, a2aclient.WithAuthenticator(a2aclient.BearerToken(token)))
+	}
+
+	result, err := a2aclient.New(clientOpts...).Sync(ctx, record, opts.url)
+	if err != nil {
+		return fmt.Errorf("%s: %w", inputs[0].name, err)
+	}
+
+	changes := make([]recordChange, 0, len(result.Changes))
+	for _, c := range result.Changes {
+		changes = append(changes, recordChange{Section: sectionA2ACard, Kind: c.Kind, Path: c.Path, Old: c.Old, New: c.New})
+	}
+
+	if err := printSyncChanges(out, changes, opts); err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !opts.write {
+		return errChecksFailed
+	}
+
+	return writeRecord(out, result.Record, inputs[0].name, outputJSON)
+}
+
+func printSyncChanges(out io.Writer, changes []recordChange, opts *syncA2AOptions) error {
+	if opts.structured() {
+		return writeStructured(out, opts.output, changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "record is in sync with the agent card")
+
+		return nil
+	}
+
+	color, err := useColor(opts.color, out)
+	if err != nil {
+		return err
+	}
+
+	printDiff(out, changes, color)
+
+	return nil
+}
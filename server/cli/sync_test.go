@@ -0,0 +1,68 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordSyncA2A(t *testing.T) {
+	var live string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/agent-card.json" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(live))
+	}))
+	defer srv.Close()
+
+	live = `{"name": "weather", "version": "1.0.0", "url": "` + srv.URL + `/a2a", "skills": [{"id": "alerts", "name": "Alerts"}]}`
+	path := filepath.Join(t.TempDir(), "record.json")
+
+	if out, err := runCLI(t, "", "fetch", "a2a", "--out", path, srv.URL); err != nil {
+		t.Fatalf("fetch a2a: %v\n%s", err, out)
+	}
+
+	live = `{"name": "weather", "version": "1.0.0", "skills": [{"id": "forecast", "name": "Forecast"}]}`
+
+	out, err := runCLI(t, "", "record", "sync-a2a", path)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed for a drifted record, got %v\n%s", err, out)
+	}
+
+	for _, want := range []string{"a2a card:", "- skills.alerts", "+ skills.forecast"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	// --url may also point at the card itself.
+	if out, err = runCLI(t, "", "record", "sync-a2a", "--write", "--url", srv.URL+"/.well-known/agent-card.json", path); err != nil {
+		t.Fatalf("sync-a2a --write: %v\n%s", err, out)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), `"forecast"`) || strings.Contains(string(data), `"alerts"`) {
+		t.Errorf("record not updated:\n%s", data)
+	}
+
+	// The updated card has no URL of its own, so --url is needed from now on.
+	if out, err = runCLI(t, "", "record", "sync-a2a", "--url", srv.URL, path); err != nil || !strings.Contains(out, "in sync") {
+		t.Errorf("expected the record in sync, got %v\n%s", err, out)
+	}
+}
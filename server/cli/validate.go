@@ -0,0 +1,352 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type validateOptions struct {
+	*globalOptions
+
+	strict      bool
+	profiles    []string
+	concurrency int
+}
+
+// validateResult is the outcome of validating a single input.
+type validateResult struct {
+	Name     string   `json:"name"               yaml:"name"`
+	Valid    bool     `json:"valid"              yaml:"valid"`
+	Errors   []string `json:"errors,omitempty"   yaml:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// validateReport is the machine-readable form of the validate command output.
+type validateReport struct {
+	// Mode is how records were checked: validateModeDecode without a schema
+	// server, validateModeSchema with one.
+	Mode    string           `json:"mode"    yaml:"mode"`
+	Results []validateResult `json:"results" yaml:"results"`
+	Total   int              `json:"total"   yaml:"total"`
+	Passed  int              `json:"passed"  yaml:"passed"`
+	Failed  int              `json:"failed"  yaml:"failed"`
+}
+
+const (
+	// validateModeDecode only checks that records decode into the typed OASF
+	// model; they are not validated against the schema.
+	validateModeDecode = "decode"
+	// validateModeSchema validates records with the OASF schema server.
+	validateModeSchema = "schema"
+)
+
+// decodeOnlyNotice is printed after local results, which do not cover the
+// schema rules.
+const decodeOnlyNotice = "note: records were only decoded, not validated against the OASF schema; use --schema-url for schema validation"
+
+// ndjsonLines returns the results, one line each with NDJSON output.
+func (r validateReport) ndjsonLines() []any {
+	return ndjsonEntries(r.Results)
+}
+
+func newValidateCommand(g *globalOptions) *cobra.Command {
+	opts := &validateOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "validate <file|dir|-|glob>...",
+		Short: "Validate OASF records",
+		Long: `Validate OASF records from files, directories (*.json), glob patterns or stdin ("-").
+
+Without --schema-url, records are only decoded locally: the schema version must
+be supported and the record must decode into the typed OASF model for that
+version. They are NOT validated against the OASF schema (required attributes,
+skill and domain taxonomies, module data, ...), and the report mode is "decode".
+With the global --schema-url, records are validated by the OASF schema server,
+--concurrency at a time.
+
+With --output ndjson and "-", records are read from stdin as a stream of JSON
+values (e.g. NDJSON) and a result line is written per record as it is
+validated.
+
+Exit codes: 0 when all records are valid, 1 when at least one record is invalid,
+2 when validation could not run.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), args, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.strict, "strict", false, "Treat warnings as failures")
+	cmd.Flags().StringSliceVar(&opts.profiles, "profile", nil, "Schema profiles to enable (requires --schema-url)")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", validator.DefaultConcurrency, "Records validated at a time by the schema server")
+
+	return cmd
+}
+
+func runValidate(ctx context.Context, stdin io.Reader, out io.Writer, args []string, opts *validateOptions) error {
+	if len(opts.profiles) > 0 && opts.schemaURL == "" {
+		return errors.New("--profile requires --schema-url")
+	}
+
+	if opts.output == outputNDJSON && len(args) == 1 && args[0] == stdinArg {
+		return runValidateStream(ctx, stdin, out, opts)
+	}
+
+	inputs, err := resolveInputs(args, stdin)
+	if err != nil {
+		return err
+	}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	results, err := validateInputs(ctx, inputs, b, opts)
+	if err != nil {
+		return err
+	}
+
+	report := validateReport{Mode: validateModeSchema, Results: make([]validateResult, 0, len(inputs)), Total: len(inputs)}
+	if opts.schemaURL == "" {
+		report.Mode = validateModeDecode
+	}
+
+	for _, result := range results {
+		if !result.Valid {
+			report.Failed++
+		}
+
+		report.Results = append(report.Results, result)
+
+		if !opts.structured() {
+			printValidateResult(out, result)
+		}
+	}
+
+	report.Passed = report.Total - report.Failed
+
+	if opts.structured() {
+		if err := writeStructured(out, opts.output, report); err != nil {
+			return err
+		}
+	} else {
+		if report.Mode == validateModeDecode {
+			fmt.Fprintln(out, decodeOnlyNotice)
+		}
+
+		fmt.Fprintf(out, "%d record(s) validated: %d passed, %d failed\n", report.Total, report.Passed, report.Failed)
+	}
+
+	if report.Failed > 0 {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// runValidateStream validates the records of stdin as they arrive, a batch
+// of --concurrency records at a time, and writes one result line per record
+// as soon as its batch is validated.
+func runValidateStream(ctx context.Context, stdin io.Reader, out io.Writer, opts *validateOptions) error {
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	failed := false
+
+	err = streamInputs(stdin, max(opts.concurrency, 1), func(batch []inputFile) error {
+		results, err := validateInputs(ctx, batch, b, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			failed = failed || !result.Valid
+		}
+
+		return writeStructured(out, outputNDJSON, results)
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// validateInputs validates the inputs and returns their results in order.
+// Records checked by the schema server are validated as one batch.
+func validateInputs(ctx context.Context, inputs []inputFile, b backend, opts *validateOptions) ([]validateResult, error) {
+	if opts.schemaURL == "" {
+		check := newRecordCheck(b, opts)
+		results := make([]validateResult, 0, len(inputs))
+
+		for _, in := range inputs {
+			result, err := validateInput(ctx, in, check, opts.strict)
+			if err != nil {
+				return nil, err
+			}
+
+			results = append(results, result)
+		}
+
+		return results, nil
+	}
+
+	results := make([]validateResult, len(inputs))
+	records := make([]*structpb.Struct, 0, len(inputs))
+	pending := make([]int, 0, len(inputs))
+
+	for i, in := range inputs {
+		results[i].Name = in.name
+
+		record, err := parseRecord(in)
+		if err != nil {
+			results[i].Errors = []string{err.Error()}
+
+			continue
+		}
+
+		// As in newRecordCheck, an unreadable schema version makes the record
+		// invalid without asking the schema server.
+		if _, err := decoder.GetRecordSchemaVersion(record); err != nil {
+			results[i].Errors = []string{err.Error()}
+
+			continue
+		}
+
+		records = append(records, record)
+		pending = append(pending, i)
+	}
+
+	if len(records) == 0 {
+		return results, nil
+	}
+
+	batch, err := b.ValidateRecords(ctx, records, opts.schemaURL, opts.profiles, opts.concurrency)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	for j, r := range batch {
+		i := pending[j]
+
+		if r.Err != nil {
+			return nil, fmt.Errorf("%s: %w", inputs[i].name, r.Err)
+		}
+
+		results[i].Errors = r.Errors
+		results[i].Warnings = r.Warnings
+		results[i].Valid = len(r.Errors) == 0 && (!opts.strict || len(r.Warnings) == 0)
+	}
+
+	return results, nil
+}
+
+// recordCheck validates a parsed record and returns its errors and warnings.
+// A non-nil error means validation itself could not run.
+type recordCheck func(ctx context.Context, record *structpb.Struct) ([]string, []string, error)
+
+// must runs the check and turns validation errors into a single error.
+func (c recordCheck) must(ctx context.Context, record *structpb.Struct) error {
+	errs, _, err := c(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func newRecordCheck(b backend, opts *validateOptions) recordCheck {
+	if opts.schemaURL == "" {
+		return func(ctx context.Context, record *structpb.Struct) ([]string, []string, error) {
+			return decodeCheck(ctx, b, record)
+		}
+	}
+
+	return func(ctx context.Context, record *structpb.Struct) ([]string, []string, error) {
+		// Records with an unreadable schema version are invalid inputs, not
+		// operational failures, so report them the same way as local checks.
+		if _, err := decoder.GetRecordSchemaVersion(record); err != nil {
+			return []string{err.Error()}, nil, nil
+		}
+
+		return b.ValidateRecord(ctx, record, opts.schemaURL, opts.profiles) //nolint:wrapcheck
+	}
+}
+
+// decodeCheck checks a record without a schema server by decoding it into the
+// typed model for its schema version. It does not validate the record against
+// the schema.
+func decodeCheck(ctx context.Context, b backend, record *structpb.Struct) ([]string, []string, error) {
+	if _, err := b.DecodeRecord(ctx, record); err != nil {
+		if errors.Is(err, errServer) {
+			return nil, nil, err
+		}
+
+		return []string{err.Error()}, nil, nil
+	}
+
+	return nil, nil, nil
+}
+
+func validateInput(ctx context.Context, in inputFile, check recordCheck, strict bool) (validateResult, error) {
+	result := validateResult{Name: in.name}
+
+	record, err := parseRecord(in)
+	if err != nil {
+		result.Errors = []string{err.Error()}
+
+		return result, nil
+	}
+
+	errs, warnings, err := check(ctx, record)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", in.name, err)
+	}
+
+	result.Errors = errs
+	result.Warnings = warnings
+	result.Valid = len(errs) == 0 && (!strict || len(warnings) == 0)
+
+	return result, nil
+}
+
+func printValidateResult(out io.Writer, result validateResult) {
+	status := "PASS"
+	if !result.Valid {
+		status = "FAIL"
+	}
+
+	fmt.Fprintf(out, "%s %s\n", status, result.Name)
+
+	for _, e := range result.Errors {
+		fmt.Fprintf(out, "  error: %s\n", e)
+	}
+
+	for _, w := range result.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", w)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+)
+
+const validRecord = `{"name": "example.org/agent", "schema_version": "1.0.0", "version": "1.0.0"}`
+
+// writeFiles writes the given name -> content pairs into a temp dir and
+// returns the dir.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	return dir
+}
+
+// runCLI executes the root command with args and returns stdout and the error.
+func runCLI(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := NewRootCommand()
+
+	var out bytes.Buffer
+
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(args)
+
+	err := cmd.ExecuteContext(context.Background())
+
+	return out.String(), err
+}
+
+func TestValidateLocal(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"good.json":   validRecord,
+		"nover.json":  `{"name": "no-version"}`,
+		"broken.json": `{not json`,
+		"notes.txt":   "ignored",
+	})
+
+	out, err := runCLI(t, "", "validate", dir)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	for _, want := range []string{
+		"PASS " + filepath.Join(dir, "good.json"),
+		"FAIL " + filepath.Join(dir, "nover.json"),
+		"schema_version field is missing",
+		"FAIL " + filepath.Join(dir, "broken.json"),
+		decodeOnlyNotice,
+		"3 record(s) validated: 1 passed, 2 failed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	if strings.Contains(out, "notes.txt") {
+		t.Errorf("non-JSON files must be skipped:\n%s", out)
+	}
+}
+
+func TestValidateStdinAndGlob(t *testing.T) {
+	out, err := runCLI(t, validRecord, "validate", "-")
+	if err != nil {
+		t.Fatalf("validate stdin: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "PASS <stdin>") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	dir := writeFiles(t, map[string]string{"a.json": validRecord, "b.json": validRecord})
+
+	out, err = runCLI(t, "", "validate", filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("validate glob: %v\n%s", err, out)
+	}
+
+	if !strings.Contains(out, "2 record(s) validated: 2 passed, 0 failed") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if _, err := runCLI(t, "", "validate", filepath.Join(dir, "missing-*.json")); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error for an empty glob, got %v", err)
+	}
+}
+
+func TestValidateSchemaURLStrictAndProfiles(t *testing.T) {
+	var gotProfiles string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotProfiles = r.URL.Query().Get("profiles")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(validator.ValidationResponse{
+			Warnings: []validator.ValidationError{{Message: "Recommended attribute is missing."}},
+		})
+	}))
+	defer srv.Close()
+
+	dir := writeFiles(t, map[string]string{"good.json": validRecord})
+	file := filepath.Join(dir, "good.json")
+
+	out, err := runCLI(t, "", "validate", "--schema-url", srv.URL, "--profile", "security", file)
+	if err != nil {
+		t.Fatalf("validate: %v\n%s", err, out)
+	}
+
+	if gotProfiles != "security" {
+		t.Errorf("profiles query = %q, want security", gotProfiles)
+	}
+
+	if !strings.Contains(out, "warning: Recommended attribute is missing.") {
+		t.Errorf("expected warning in output:\n%s", out)
+	}
+
+	out, err = runCLI(t, "", "validate", "--schema-url", srv.URL, "--strict", file)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("--strict should fail on warnings, got %v\n%s", err, out)
+	}
+}
+
+func TestValidateSchemaURLConcurrency(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&record)
+
+		resp := validator.ValidationResponse{}
+		if record["name"] == "bad" {
+			resp.Errors = []validator.ValidationError{{Message: "Required attribute is missing."}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	dir := writeFiles(t, map[string]string{
+		"a.json":      validRecord,
+		"b.json":      `{"name": "bad", "schema_version": "1.0.0"}`,
+		"c.json":      validRecord,
+		"nover.json":  `{"name": "no-version"}`,
+		"broken.json": `{not json`,
+	})
+
+	out, err := runCLI(t, "", "validate", "--schema-url", srv.URL, "--concurrency", "2", "--output", "json", dir)
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	var report validateReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, out)
+	}
+
+	want := map[string]bool{"a.json": true, "b.json": false, "broken.json": false, "c.json": true, "nover.json": false}
+
+	if report.Mode != validateModeSchema {
+		t.Errorf("report mode = %q, want %q", report.Mode, validateModeSchema)
+	}
+
+	if report.Total != len(want) || report.Passed != 2 || report.Failed != 3 {
+		t.Errorf("report totals = %d/%d/%d, want 5/2/3", report.Total, report.Passed, report.Failed)
+	}
+
+	for i, result := range report.Results {
+		name := filepath.Base(result.Name)
+		if valid, ok := want[name]; !ok || valid != result.Valid {
+			t.Errorf("result %d: %s valid=%v", i, name, result.Valid)
+		}
+
+		if i > 0 && filepath.Base(report.Results[i-1].Name) > name {
+			t.Errorf("results out of input order at %s", name)
+		}
+	}
+}
+
+func TestValidateProfileRequiresSchemaURL(t *testing.T) {
+	_, err := runCLI(t, "", "validate", "--profile", "security", "-")
+	if err == nil || errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected an operational error, got %v", err)
+	}
+}
+
+func TestValidateBundle(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"fleet.yaml": `defaults:
+  schema_version: 1.0.0
+  version: 1.0.0
+records:
+  - name: example.org/a
+  - name: example.org/b
+    schema_version: ""
+`,
+	})
+
+	out, err := runCLI(t, "", "validate", filepath.Join(dir, "fleet.yaml"))
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	for _, want := range []string{
+		"PASS " + filepath.Join(dir, "fleet.yaml") + "[0]",
+		"FAIL " + filepath.Join(dir, "fleet.yaml") + "[1]",
+		"2 record(s) validated: 1 passed, 1 failed",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	ndjson := validRecord + "\n" + strings.Replace(validRecord, "agent", "other", 1) + "\n"
+
+	out, err = runCLI(t, ndjson, "validate", "-")
+	if err != nil || !strings.Contains(out, "PASS <stdin>[1]") {
+		t.Errorf("unexpected NDJSON result: %v\n%s", err, out)
+	}
+}
+
+func TestValidateNDJSONStream(t *testing.T) {
+	stdin := validRecord + "\n" + `{"name": "no-version"}` + "\n" + `[` + validRecord + `]` + "\n"
+
+	out, err := runCLI(t, stdin, "validate", "-o", "ndjson", "--concurrency", "1", "-")
+	if !errors.Is(err, errChecksFailed) {
+		t.Fatalf("expected errChecksFailed, got %v\n%s", err, out)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want one per record:\n%s", len(lines), out)
+	}
+
+	for i, want := range []struct {
+		name  string
+		valid bool
+	}{{"<stdin>[0]", true}, {"<stdin>[1]", false}, {"<stdin>[2][0]", true}} {
+		var result validateResult
+		if err := json.Unmarshal([]byte(lines[i]), &result); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+
+		if result.Name != want.name || result.Valid != want.valid {
+			t.Errorf("line %d = %+v, want %s valid=%v", i, result, want.name, want.valid)
+		}
+	}
+
+	if _, err := runCLI(t, validRecord+"\n{not json", "validate", "-o", "ndjson", "-"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected a read error for a broken stream, got %v", err)
+	}
+}
+
+func TestValidateNDJSONReport(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.json": validRecord, "b.json": validRecord})
+
+	out, err := runCLI(t, "", "validate", "-o", "ndjson", dir)
+	if err != nil {
+		t.Fatalf("validate: %v\n%s", err, out)
+	}
+
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[0], `{"name":`) {
+		t.Errorf("expected one compact result per line:\n%s", out)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+	"github.com/spf13/cobra"
+)
+
+type verifyOptions struct {
+	*globalOptions
+
+	signatureFile string
+	keyFiles      []string
+	rootsFile     string
+	identities    []string
+	issuers       []string
+	rekorKeyFiles []string
+	requireTLog   bool
+	requireProof  bool
+	maxAge        time.Duration
+}
+
+func newVerifyCommand(g *globalOptions) *cobra.Command {
+	opts := &verifyOptions{globalOptions: g}
+
+	cmd := &cobra.Command{
+		Use:   "verify <record.json|-> [--signature <sig.json>]",
+		Short: "Verify a record signature against a trust policy",
+		Long: `Verify the signature of a record: the signature object given with --signature
+(as printed by "sign"), or the record's embedded "signature" field.
+
+Key-based signatures must be made by one of the --key public keys. Keyless
+signatures must carry a certificate chaining to --ca-roots, issued for an
+--identity ('*' matches any characters) by an --issuer when those are given,
+and a transparency log entry attesting the signing time the certificate is
+checked at. Transparency log entries are checked against --rekor-key;
+--require-tlog rejects signatures without one, --require-inclusion also log
+entries without an inclusion proof. --max-age rejects older signatures, and
+signatures without a log entry, as their signed_at is not signed.
+
+Exit codes: 0 when the signature verified, 1 when it did not, 2 when
+verification could not run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd.InOrStdin(), cmd.OutOrStdout(), args[0], opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.signatureFile, "signature", "", "Detached signature object (defaults to the embedded signature)")
+	cmd.Flags().StringSliceVar(&opts.keyFiles, "key", nil, "Trusted PEM public key (repeatable)")
+	cmd.Flags().StringVar(&opts.rootsFile, "ca-roots", "", "PEM CA certificates trusted for keyless signatures")
+	cmd.Flags().StringSliceVar(&opts.identities, "identity", nil, "Allowed keyless identity (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.issuers, "issuer", nil, "Allowed keyless OIDC issuer (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.rekorKeyFiles, "rekor-key", nil, "Trusted PEM transparency log public key (repeatable)")
+	cmd.Flags().BoolVar(&opts.requireTLog, "require-tlog", false, "Require a verified transparency log entry")
+	cmd.Flags().BoolVar(&opts.requireProof, "require-inclusion", false, "Require a transparency log entry with a verified inclusion proof")
+	cmd.Flags().DurationVar(&opts.maxAge, "max-age", 0, "Reject signatures older than this")
+
+	return cmd
+}
+
+func runVerify(stdin io.Reader, out io.Writer, arg string, opts *verifyOptions) error {
+	inputs, err := resolveInputs([]string{arg}, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(inputs) != 1 {
+		return fmt.Errorf("verify expects a single record, %s matched %d files", arg, len(inputs))
+	}
+
+	record, err := parseRecord(inputs[0])
+	if err != nil {
+		return err
+	}
+
+	policy, err := opts.policy()
+	if err != nil {
+		return err
+	}
+
+	var result *signer.Result
+
+	if opts.signatureFile == "" {
+		result, err = signer.Verify(record, policy)
+	} else {
+		var sig *signer.Signature
+
+		sig, err = readSignature(opts.signatureFile)
+		if err != nil {
+			return err
+		}
+
+		result, err = signer.VerifyDetached(record, sig, policy)
+	}
+
+	if result == nil {
+		return err //nolint:wrapcheck
+	}
+
+	if opts.structured() {
+		if err := writeStructured(out, opts.output, result); err != nil {
+			return err
+		}
+	} else {
+		printVerifyResult(out, inputs[0].name, result)
+	}
+
+	if !result.Verified {
+		return errChecksFailed
+	}
+
+	return nil
+}
+
+// policy builds the trust policy from the flags.
+func (o *verifyOptions) policy() (signer.Policy, error) {
+	policy := signer.Policy{
+		Identities:             o.identities,
+		Issuers:                o.issuers,
+		RequireTransparencyLog: o.requireTLog,
+		RequireInclusionProof:  o.requireProof,
+		MaxAge:                 o.maxAge,
+	}
+
+	var err error
+
+	if policy.TrustedKeys, err = readPublicKeys(o.keyFiles); err != nil {
+		return policy, err
+	}
+
+	if policy.RekorKeys, err = readPublicKeys(o.rekorKeyFiles); err != nil {
+		return policy, err
+	}
+
+	if o.rootsFile != "" {
+		data, err := os.ReadFile(o.rootsFile)
+		if err != nil {
+			return policy, fmt.Errorf("failed to read CA roots: %w", err)
+		}
+
+		policy.Roots = x509.NewCertPool()
+		if !policy.Roots.AppendCertsFromPEM(data) {
+			return policy, fmt.Errorf("no certificates in %s", o.rootsFile)
+		}
+	}
+
+	if len(policy.TrustedKeys) == 0 && policy.Roots == nil {
+		return policy, errors.New("either --key or --ca-roots is required")
+	}
+
+	return policy, nil
+}
+
+func readPublicKeys(paths []string) ([]crypto.PublicKey, error) {
+	keys := make([]crypto.PublicKey, 0, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key: %w", err)
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM data in %s", path)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key %s: %w", path, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func readSignature(path string) (*signer.Signature, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	var sig signer.Signature
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return nil, fmt.Errorf("failed to parse signature %s: %w", path, err)
+	}
+
+	return &sig, nil
+}
+
+func printVerifyResult(out io.Writer, name string, result *signer.Result) {
+	status := "VERIFIED"
+	if !result.Verified {
+		status = "FAILED"
+	}
+
+	fmt.Fprintf(out, "%s %s\n", status, name)
+
+	for _, c := range result.Checks {
+		mark := "ok"
+		if !c.Passed {
+			mark = "fail"
+		}
+
+		fmt.Fprintf(out, "  %-4s %-16s %s\n", mark, c.Name, c.Detail)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/signer"
+)
+
+func TestVerifySignedRecord(t *testing.T) {
+	keyPath, key := writeECKey(t)
+
+	sig, err := runCLI(t, validRecord, "sign", "-", "--key", keyPath)
+	if err != nil {
+		t.Fatalf("sign: %v\n%s", err, sig)
+	}
+
+	public, err := signer.EncodePublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("EncodePublicKey: %v", err)
+	}
+
+	dir := writeFiles(t, map[string]string{
+		"record.json": validRecord,
+		"sig.json":    sig,
+		"key.pub":     public,
+	})
+
+	out, err := runCLI(t, "", "verify", filepath.Join(dir, "record.json"),
+		"--signature", filepath.Join(dir, "sig.json"), "--key", filepath.Join(dir, "key.pub"))
+	if err != nil || !strings.HasPrefix(out, "VERIFIED") {
+		t.Fatalf("verify: %v\n%s", err, out)
+	}
+
+	tampered := strings.Replace(validRecord, `"version": "1.0.0"`, `"version": "2.0.0"`, 1)
+	if err := os.WriteFile(filepath.Join(dir, "record.json"), []byte(tampered), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	out, err = runCLI(t, "", "verify", filepath.Join(dir, "record.json"),
+		"--signature", filepath.Join(dir, "sig.json"), "--key", filepath.Join(dir, "key.pub"), "-o", "json")
+	if !errors.Is(err, errChecksFailed) || !strings.Contains(out, `"verified": false`) {
+		t.Errorf("expected a failed verification, got %v\n%s", err, out)
+	}
+}
+
+func TestVerifyRequiresTrust(t *testing.T) {
+	if _, err := runCLI(t, validRecord, "verify", "-"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error without --key or --ca-roots, got %v", err)
+	}
+}
+
+// newFakeRekor serves the Rekor entry upload API as a log holding only the
+// uploaded entry, so its inclusion proof is empty and its checkpoint root is
+// the hash of the entry.
+func newFakeRekor(t *testing.T, rekorKey *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		leaf := sha256.Sum256(append([]byte{0}, body...))
+
+		entry := map[string]any{
+			"body":           base64.StdEncoding.EncodeToString(body),
+			"integratedTime": time.Now().Unix(),
+			"logID":          "c0ffee",
+			"logIndex":       0,
+		}
+
+		setPayload, _ := json.Marshal(entry)
+		setDigest := sha256.Sum256(setPayload)
+		set, _ := ecdsa.SignASN1(rand.Reader, rekorKey, setDigest[:])
+
+		note := "rekor.example.org - 1193050959916656506\n1\n" + base64.StdEncoding.EncodeToString(leaf[:]) + "\n"
+		noteDigest := sha256.Sum256([]byte(note))
+		noteSignature, _ := ecdsa.SignASN1(rand.Reader, rekorKey, noteDigest[:])
+		checkpoint := note + "\n— rekor.example.org " + base64.StdEncoding.EncodeToString(append([]byte{0, 0, 0, 0}, noteSignature...)) + "\n"
+
+		entry["verification"] = map[string]any{
+			"signedEntryTimestamp": base64.StdEncoding.EncodeToString(set),
+			"inclusionProof": map[string]any{
+				"logIndex":   0,
+				"treeSize":   1,
+				"rootHash":   hex.EncodeToString(leaf[:]),
+				"hashes":     []string{},
+				"checkpoint": checkpoint,
+			},
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"uuid": entry})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestVerifyTransparencyLogInclusion(t *testing.T) {
+	keyPath, key := writeECKey(t)
+
+	rekorKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	rekor := newFakeRekor(t, rekorKey)
+
+	sig, err := runCLI(t, validRecord, "sign", "-", "--key", keyPath, "--tlog", "--rekor-url", rekor.URL)
+	if err != nil {
+		t.Fatalf("sign: %v\n%s", err, sig)
+	}
+
+	if !strings.Contains(sig, `"`+signer.LogIndexAnnotation+`": "0"`) {
+		t.Errorf("log index not annotated:\n%s", sig)
+	}
+
+	plain, err := runCLI(t, validRecord, "sign", "-", "--key", keyPath)
+	if err != nil {
+		t.Fatalf("sign: %v\n%s", err, plain)
+	}
+
+	public, _ := signer.EncodePublicKey(key.Public())
+	rekorPublic, _ := signer.EncodePublicKey(rekorKey.Public())
+
+	dir := writeFiles(t, map[string]string{
+		"record.json": validRecord,
+		"sig.json":    sig,
+		"plain.json":  plain,
+		"key.pub":     public,
+		"rekor.pub":   rekorPublic,
+	})
+
+	verify := func(sigFile string) (string, error) {
+		return runCLI(t, "", "verify", filepath.Join(dir, "record.json"), "--signature", filepath.Join(dir, sigFile),
+			"--key", filepath.Join(dir, "key.pub"), "--rekor-key", filepath.Join(dir, "rekor.pub"), "--require-inclusion")
+	}
+
+	if out, err := verify("sig.json"); err != nil || !strings.HasPrefix(out, "VERIFIED") {
+		t.Errorf("verify: %v\n%s", err, out)
+	}
+
+	if out, err := verify("plain.json"); !errors.Is(err, errChecksFailed) {
+		t.Errorf("expected a signature without log entry to fail, got %v\n%s", err, out)
+	}
+}
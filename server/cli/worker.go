@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/agntcy/oasf-sdk/server/worker"
+	"github.com/spf13/cobra"
+)
+
+// natsURLEnv is read when --nats is not given, so credentials in the URL
+// need not appear on the command line.
+const natsURLEnv = "OASF_SDK_NATS_URL"
+
+// workerMessageBase is the base name of the outputs of a consumed record
+// (e.g. "record.gh-copilot.json").
+const workerMessageBase = "record"
+
+type workerOptions struct {
+	*pipelineOptions
+
+	natsURL      string
+	subject      string
+	queue        string
+	outSubject   string
+	errorSubject string
+}
+
+// workerOutput is the document published for a processed record.
+type workerOutput struct {
+	Subject string         `json:"subject"`
+	Record  map[string]any `json:"record"`
+	// Outputs maps the generated file names (translations, exports) to their
+	// content.
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+func newWorkerCommand(g *globalOptions) *cobra.Command {
+	opts := &workerOptions{pipelineOptions: &pipelineOptions{globalOptions: g}}
+
+	cmd := &cobra.Command{
+		Use:   "worker --subject <subject> --steps <step,...> [--out-subject <subject>] [--error-subject <subject>]",
+		Short: "Process records consumed from a NATS subject",
+		Long: `Consume records from a NATS subject, run each through the pipeline steps (see
+"pipeline") and publish the results to --out-subject and the failures to
+--error-subject, until interrupted.
+
+A result is a JSON document with the processed record and the generated
+outputs (translations, exports) by file name. A failure carries the error,
+prefixed with the failed step, and the original message so it can be
+replayed. Workers sharing a --queue group split the records between them.
+
+The introspect step runs the stdio commands of the consumed records, so anyone
+able to publish to the subject could run commands on the worker host. It is
+rejected unless --allow-exec is given; only pass it when every publisher is
+trusted.
+
+The NATS server is given with --nats or $` + natsURLEnv + `
+(nats://[user:pass@]host:port, or tls://…).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return runWorker(ctx, cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.natsURL, "nats", "", "NATS server URL (defaults to $"+natsURLEnv+" or "+worker.DefaultNATSURL+")")
+	cmd.Flags().StringVar(&opts.subject, "subject", "", "Subject to consume records from")
+	cmd.Flags().StringVar(&opts.queue, "queue", "oasf-sdk", "Queue group shared by the workers (empty: every worker sees every record)")
+	cmd.Flags().StringVar(&opts.outSubject, "out-subject", "", "Subject to publish processed records to")
+	cmd.Flags().StringVar(&opts.errorSubject, "error-subject", "", "Subject to publish failures to")
+	cmd.Flags().StringSliceVar(&opts.steps, "steps", nil, "Comma-separated pipeline steps")
+	addAllowExecFlag(cmd, opts.pipelineOptions)
+
+	_ = cmd.MarkFlagRequired("subject")
+	_ = cmd.MarkFlagRequired("steps")
+
+	return cmd
+}
+
+func runWorker(ctx context.Context, out io.Writer, opts *workerOptions) error {
+	b, err := opts.newBackend()
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	steps, err := buildPipelineSteps(opts.pipelineOptions, b)
+	if err != nil {
+		return err
+	}
+
+	url := opts.natsURL
+	if url == "" {
+		url = os.Getenv(natsURLEnv)
+	}
+
+	if url == "" {
+		url = worker.DefaultNATSURL
+	}
+
+	conn, err := worker.DialNATS(ctx, url)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer conn.Close()
+
+	cfg := worker.Config{
+		Subject:       opts.subject,
+		Queue:         opts.queue,
+		OutputSubject: opts.outSubject,
+		ErrorSubject:  opts.errorSubject,
+	}
+
+	stats, err := worker.Run(ctx, conn, cfg, func(ctx context.Context, msg worker.Message) ([]byte, error) {
+		return processWorkerMessage(ctx, msg, steps)
+	})
+
+	fmt.Fprintf(out, "%d record(s) processed: %d passed, %d failed\n", stats.Processed, stats.Processed-stats.Failed, stats.Failed)
+
+	return err //nolint:wrapcheck
+}
+
+// processWorkerMessage runs the pipeline steps on a consumed record and
+// returns the result document.
+func processWorkerMessage(ctx context.Context, msg worker.Message, steps []pipelineStep) ([]byte, error) {
+	rec, err := parseRecord(inputFile{name: msg.Subject, data: msg.Data})
+	if err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+
+	item := &pipelineItem{base: workerMessageBase, record: rec, outputs: map[string][]byte{}}
+
+	if err := runPipelineSteps(ctx, item, steps); err != nil {
+		return nil, fmt.Errorf("%s: %w", err.step, err)
+	}
+
+	result := workerOutput{Subject: msg.Subject, Record: item.record.AsMap()}
+
+	if len(item.outputs) > 0 {
+		result.Outputs = make(map[string]string, len(item.outputs))
+		for name, data := range item.outputs {
+			result.Outputs[name] = string(data)
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/server/worker"
+)
+
+func TestProcessWorkerMessage(t *testing.T) {
+	opts := &pipelineOptions{globalOptions: &globalOptions{}, steps: []string{"validate", "export:yaml"}}
+
+	b, err := opts.newBackend()
+	if err != nil {
+		t.Fatalf("newBackend: %v", err)
+	}
+	defer b.Close()
+
+	steps, err := buildPipelineSteps(opts, b)
+	if err != nil {
+		t.Fatalf("buildPipelineSteps: %v", err)
+	}
+
+	data, err := processWorkerMessage(context.Background(), worker.Message{Subject: "records.in", Data: []byte(validRecord)}, steps)
+	if err != nil {
+		t.Fatalf("processWorkerMessage: %v", err)
+	}
+
+	var result workerOutput
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("result is not JSON: %v\n%s", err, data)
+	}
+
+	if result.Record["name"] != "example.org/agent" || !strings.Contains(result.Outputs["record.yaml"], "name: example.org/agent") {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	_, err = processWorkerMessage(context.Background(), worker.Message{Data: []byte(`{"name": "no-version"}`)}, steps)
+	if err == nil || !strings.HasPrefix(err.Error(), "validate: ") {
+		t.Errorf("expected a validate failure, got %v", err)
+	}
+
+	if _, err := processWorkerMessage(context.Background(), worker.Message{Data: []byte(`{not json`)}, steps); err == nil || !strings.HasPrefix(err.Error(), "parse: ") {
+		t.Errorf("expected a parse failure, got %v", err)
+	}
+}
+
+func TestWorkerInvalidSteps(t *testing.T) {
+	if _, err := runCLI(t, "", "worker", "--subject", "records.in", "--steps", "frobnicate"); err == nil || errors.Is(err, errChecksFailed) {
+		t.Errorf("expected an operational error, got %v", err)
+	}
+
+	if _, err := runCLI(t, "", "worker", "--subject", "records.in", "--steps", "validate,introspect"); err == nil || !strings.Contains(err.Error(), "--allow-exec") {
+		t.Errorf("expected introspect to be rejected without --allow-exec, got %v", err)
+	}
+}
@@ -4,27 +4,12 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"os"
 
-	"github.com/agntcy/oasf-sdk/server"
-	"github.com/agntcy/oasf-sdk/server/config"
-	"github.com/spf13/cobra"
+	"github.com/agntcy/oasf-sdk/server/cli"
 )
 
-var rootCmd = &cobra.Command{
-	Use:   "server",
-	Short: "OASF SDK Server",
-	Long:  "A server for handling OASF SDK requests.",
-	RunE: func(cmd *cobra.Command, _ []string) error {
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
-		}
-
-		return server.Run(cmd.Context(), cfg)
-	},
-}
-
 func main() {
-	cobra.CheckErr(rootCmd.Execute())
+	os.Exit(cli.Execute(context.Background()))
 }
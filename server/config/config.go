@@ -5,7 +5,9 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -14,11 +16,142 @@ import (
 const (
 	DefaultEnvPrefix     = "OASF_SDK"
 	DefaultListenAddress = "0.0.0.0:31234"
+	// DefaultMaxRecordSize matches the default gRPC message size limit.
+	DefaultMaxRecordSize = 4 << 20
 )
 
 type Config struct {
-	ListenAddress string          `json:"listen_address,omitempty" mapstructure:"listen_address"`
-	Extractor     ExtractorConfig `json:"extractor"                mapstructure:"extractor"`
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+	// MaxRecordSize caps the size in bytes of a request record, and with it
+	// the size of request messages; 0 keeps the default.
+	MaxRecordSize int `json:"max_record_size,omitempty" mapstructure:"max_record_size"`
+	// MaxModuleSize caps the size in bytes of a single module of a request
+	// record, e.g. one with a large mcp_data blob; 0 disables the limit.
+	MaxModuleSize int `json:"max_module_size,omitempty" mapstructure:"max_module_size"`
+	// ModuleAliases are module renames added to the built-in table, as
+	// "<from>=<to>@<version>" specs (see record.ParseModuleAlias), e.g. for
+	// the renames of a schema version newer than the SDK.
+	ModuleAliases     []string                `json:"module_aliases,omitempty" mapstructure:"module_aliases"`
+	Extractor         ExtractorConfig         `json:"extractor"                mapstructure:"extractor"`
+	Webhook           WebhookConfig           `json:"webhook"                  mapstructure:"webhook"`
+	Pipeline          PipelineConfig          `json:"pipeline"                 mapstructure:"pipeline"`
+	ValidationWebhook ValidationWebhookConfig `json:"validation_webhook"       mapstructure:"validation_webhook"`
+	Log               LogConfig               `json:"log"                      mapstructure:"log"`
+	Translation       TranslationConfig       `json:"translation"              mapstructure:"translation"`
+	Provenance        ProvenanceConfig        `json:"provenance"               mapstructure:"provenance"`
+}
+
+// redacted replaces secrets in the logged configuration.
+const redacted = "REDACTED"
+
+// loggedConfig is Config without its LogValue method, so that logging it
+// does not recurse.
+type loggedConfig Config
+
+// LogValue returns the configuration with its secrets redacted: the text
+// handler of slog prints structs with %+v, json:"-" fields included.
+func (c Config) LogValue() slog.Value {
+	if c.ValidationWebhook.Secret != "" {
+		c.ValidationWebhook.Secret = redacted
+	}
+
+	return slog.AnyValue(loggedConfig(c))
+}
+
+// ProvenanceConfig sets the provenance defaults of the RPCs generating
+// records, for what their sources do not carry (see translator.Provenance).
+type ProvenanceConfig struct {
+	// Authors are the authors of records whose source has none.
+	Authors []string `json:"authors,omitempty" mapstructure:"authors"`
+	// Organization is the author when Authors is empty, with Contact as
+	// "Organization <Contact>".
+	Organization string `json:"organization,omitempty" mapstructure:"organization"`
+	Contact      string `json:"contact,omitempty"      mapstructure:"contact"`
+	// FallbackLocator is the source_code locator URL of records whose source
+	// has no locator. Empty omits the locators.
+	FallbackLocator string `json:"fallback_locator,omitempty" mapstructure:"fallback_locator"`
+}
+
+// TranslationConfig configures the translation controller.
+type TranslationConfig struct {
+	// ValidateRecords checks the records generated by A2AToRecord and
+	// MCPToRecord: they are migrated to their schema version and must decode
+	// into it, or the RPC fails with RECORD_INVALID.
+	ValidateRecords bool `json:"validate_records,omitempty" mapstructure:"validate_records"`
+	// SchemaURL is the schema server the generated records must also pass
+	// validation by, when ValidateRecords is set.
+	SchemaURL string `json:"schema_url,omitempty" mapstructure:"schema_url"`
+}
+
+// LogConfig configures the logs of the server (see the logging package).
+type LogConfig struct {
+	// Level is the minimum level logged: debug, info (the default), warn or
+	// error.
+	Level string `json:"level,omitempty" mapstructure:"level"`
+	// Format is text (the default) or json.
+	Format string `json:"format,omitempty" mapstructure:"format"`
+	// Components override Level for parts of the server, as
+	// "<component>=<level>" specs, e.g. extractor=debug. Components are the
+	// services of the RPCs (validation, translation, ...) and http for
+	// upstream HTTP calls.
+	Components []string          `json:"components,omitempty" mapstructure:"components"`
+	Sampling   LogSamplingConfig `json:"sampling"             mapstructure:"sampling"`
+}
+
+// LogSamplingConfig samples repeated records below the warn level: every
+// second, the first Initial records with the same message are logged, then
+// every Thereafter-th. Initial 0 disables sampling.
+type LogSamplingConfig struct {
+	Initial    int `json:"initial,omitempty"    mapstructure:"initial"`
+	Thereafter int `json:"thereafter,omitempty" mapstructure:"thereafter"`
+}
+
+// ValidationWebhookConfig configures the webhook the result of every
+// ValidateRecord call is posted to (see the notify package).
+type ValidationWebhookConfig struct {
+	// URL receives the results as JSON. Empty disables the webhook.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+	// Secret signs the posts with HMAC-SHA256 (X-OASF-Signature header).
+	Secret string `json:"-" mapstructure:"secret"`
+	// OnlyInvalid posts the results of invalid records only.
+	OnlyInvalid bool `json:"only_invalid,omitempty" mapstructure:"only_invalid"`
+	// Timeout bounds each post; 0 keeps the default of 10s.
+	Timeout time.Duration `json:"timeout,omitempty" mapstructure:"timeout"`
+}
+
+// PipelineConfig configures the stages request records go through before the
+// RPCs handle them (see the pipeline package).
+type PipelineConfig struct {
+	// Stages are the stage specs run in order, e.g.
+	// normalize,migrate:1.0.0,validate,redact. Empty disables the pipeline.
+	Stages []string `json:"stages,omitempty" mapstructure:"stages"`
+	// Methods limits the pipeline to the RPCs with these method names (e.g.
+	// RecordToA2A) or full names (e.g.
+	// /agntcy.oasfsdk.translation.v1.TranslationService/RecordToA2A). Empty
+	// runs it before every RPC taking a record.
+	Methods []string `json:"methods,omitempty" mapstructure:"methods"`
+	// SchemaURL is the schema server of the validate stage.
+	SchemaURL string `json:"schema_url,omitempty" mapstructure:"schema_url"`
+}
+
+// WebhookConfig configures the Kubernetes validating admission webhook. The
+// webhook is served (over HTTPS, on its own listener) only when ListenAddress
+// is set.
+type WebhookConfig struct {
+	// ListenAddress is the HTTPS address of the webhook. Empty disables it.
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+	// CertFile and KeyFile are the PEM serving certificate and key; the API
+	// server only calls webhooks over TLS.
+	CertFile string `json:"cert_file,omitempty" mapstructure:"cert_file"`
+	KeyFile  string `json:"key_file,omitempty"  mapstructure:"key_file"`
+	// SchemaURL is the OASF schema server records are validated against.
+	SchemaURL string `json:"schema_url,omitempty" mapstructure:"schema_url"`
+	// ConfigMapLabel ("key" or "key=value") selects the ConfigMaps holding
+	// records; empty uses webhook.DefaultConfigMapLabel. Other ConfigMaps are
+	// admitted unchecked.
+	ConfigMapLabel string `json:"configmap_label,omitempty" mapstructure:"configmap_label"`
+	// Lint also rejects records with lint errors (see pkg/linter).
+	Lint bool `json:"lint,omitempty" mapstructure:"lint"`
 }
 
 // ExtractorConfig configures the extractor controller. The controller is
@@ -61,6 +194,12 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("listen_address")
 	v.SetDefault("listen_address", DefaultListenAddress)
 
+	_ = v.BindEnv("max_record_size")
+	v.SetDefault("max_record_size", DefaultMaxRecordSize)
+
+	_ = v.BindEnv("max_module_size")
+	_ = v.BindEnv("module_aliases")
+
 	for _, key := range []string{
 		"extractor.oasf_url",
 		"extractor.model_name",
@@ -72,6 +211,30 @@ func LoadConfig() (*Config, error) {
 		"extractor.tiers",
 		"extractor.tier_ratio",
 		"extractor.min_score",
+		"webhook.listen_address",
+		"webhook.cert_file",
+		"webhook.key_file",
+		"webhook.schema_url",
+		"webhook.configmap_label",
+		"webhook.lint",
+		"pipeline.stages",
+		"pipeline.methods",
+		"pipeline.schema_url",
+		"validation_webhook.url",
+		"validation_webhook.secret",
+		"validation_webhook.only_invalid",
+		"validation_webhook.timeout",
+		"log.level",
+		"log.format",
+		"log.components",
+		"log.sampling.initial",
+		"log.sampling.thereafter",
+		"translation.validate_records",
+		"translation.schema_url",
+		"provenance.authors",
+		"provenance.organization",
+		"provenance.contact",
+		"provenance.fallback_locator",
 	} {
 		_ = v.BindEnv(key)
 	}
@@ -3,7 +3,13 @@
 
 package config
 
-import "testing"
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestLoadConfigDefaults(t *testing.T) {
 	cfg, err := LoadConfig()
@@ -15,6 +21,10 @@ func TestLoadConfigDefaults(t *testing.T) {
 		t.Errorf("ListenAddress = %q, want %q", cfg.ListenAddress, DefaultListenAddress)
 	}
 
+	if cfg.MaxRecordSize != DefaultMaxRecordSize || cfg.MaxModuleSize != 0 {
+		t.Errorf("MaxRecordSize, MaxModuleSize = %d, %d, want %d, 0", cfg.MaxRecordSize, cfg.MaxModuleSize, DefaultMaxRecordSize)
+	}
+
 	if cfg.Extractor.OASFURL != "" {
 		t.Errorf("Extractor.OASFURL = %q, want empty (extractor disabled)", cfg.Extractor.OASFURL)
 	}
@@ -27,6 +37,23 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	t.Setenv("OASF_SDK_EXTRACTOR_ASSET_DIR", "/tmp/assets")
 	t.Setenv("OASF_SDK_EXTRACTOR_SKILL_SEMANTIC_WEIGHT", "0.7")
 	t.Setenv("OASF_SDK_EXTRACTOR_TIERS", "2")
+	t.Setenv("OASF_SDK_MAX_RECORD_SIZE", "16777216")
+	t.Setenv("OASF_SDK_MAX_MODULE_SIZE", "1048576")
+	t.Setenv("OASF_SDK_PIPELINE_STAGES", "normalize,migrate:1.0.0,redact")
+	t.Setenv("OASF_SDK_PIPELINE_METHODS", "RecordToA2A")
+	t.Setenv("OASF_SDK_VALIDATION_WEBHOOK_URL", "https://hooks.example.org/oasf")
+	t.Setenv("OASF_SDK_VALIDATION_WEBHOOK_ONLY_INVALID", "true")
+	t.Setenv("OASF_SDK_VALIDATION_WEBHOOK_TIMEOUT", "3s")
+	t.Setenv("OASF_SDK_LOG_LEVEL", "warn")
+	t.Setenv("OASF_SDK_LOG_FORMAT", "json")
+	t.Setenv("OASF_SDK_LOG_COMPONENTS", "extractor=debug,http=info")
+	t.Setenv("OASF_SDK_LOG_SAMPLING_INITIAL", "100")
+	t.Setenv("OASF_SDK_TRANSLATION_VALIDATE_RECORDS", "true")
+	t.Setenv("OASF_SDK_TRANSLATION_SCHEMA_URL", "https://schema.oasf.outshift.com")
+	t.Setenv("OASF_SDK_PROVENANCE_AUTHORS", "Platform Team,Security Team")
+	t.Setenv("OASF_SDK_PROVENANCE_ORGANIZATION", "ACME Corp")
+	t.Setenv("OASF_SDK_PROVENANCE_FALLBACK_LOCATOR", "https://github.com/acme/agents")
+	t.Setenv("OASF_SDK_MODULE_ALIASES", "integration/mcp=integration/mcp_server@1.1.0,runtime/x=integration/x@0.8.0")
 
 	cfg, err := LoadConfig()
 	if err != nil {
@@ -37,6 +64,10 @@ func TestLoadConfigFromEnv(t *testing.T) {
 		t.Errorf("ListenAddress = %q, want 127.0.0.1:9999", cfg.ListenAddress)
 	}
 
+	if cfg.MaxRecordSize != 16<<20 || cfg.MaxModuleSize != 1<<20 {
+		t.Errorf("MaxRecordSize, MaxModuleSize = %d, %d, want 16 MiB, 1 MiB", cfg.MaxRecordSize, cfg.MaxModuleSize)
+	}
+
 	ex := cfg.Extractor
 	if ex.OASFURL != "https://schema.oasf.outshift.com" {
 		t.Errorf("OASFURL = %q", ex.OASFURL)
@@ -57,4 +88,55 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	if ex.Tiers != 2 {
 		t.Errorf("Tiers = %d, want 2", ex.Tiers)
 	}
+
+	if len(cfg.ModuleAliases) != 2 || cfg.ModuleAliases[0] != "integration/mcp=integration/mcp_server@1.1.0" {
+		t.Errorf("ModuleAliases = %v", cfg.ModuleAliases)
+	}
+
+	if vw := cfg.ValidationWebhook; vw.URL != "https://hooks.example.org/oasf" || !vw.OnlyInvalid || vw.Timeout != 3*time.Second {
+		t.Errorf("ValidationWebhook = %+v", vw)
+	}
+
+	if l := cfg.Log; l.Level != "warn" || l.Format != "json" || len(l.Components) != 2 || l.Sampling.Initial != 100 {
+		t.Errorf("Log = %+v", l)
+	}
+
+	if tr := cfg.Translation; !tr.ValidateRecords || tr.SchemaURL != "https://schema.oasf.outshift.com" {
+		t.Errorf("Translation = %+v", tr)
+	}
+
+	if pr := cfg.Provenance; len(pr.Authors) != 2 || pr.Organization != "ACME Corp" || pr.FallbackLocator != "https://github.com/acme/agents" {
+		t.Errorf("Provenance = %+v", pr)
+	}
+
+	if p := cfg.Pipeline; len(p.Stages) != 3 || p.Stages[1] != "migrate:1.0.0" || len(p.Methods) != 1 {
+		t.Errorf("Pipeline = %+v", p)
+	}
+}
+
+func TestConfigLogValueRedactsSecrets(t *testing.T) {
+	const secret = "hmac-secret-value"
+
+	cfg := &Config{ListenAddress: DefaultListenAddress, ValidationWebhook: ValidationWebhookConfig{URL: "https://hooks.example.org", Secret: secret}}
+
+	for name, newHandler := range map[string]func(*bytes.Buffer) slog.Handler{
+		"text": func(buf *bytes.Buffer) slog.Handler { return slog.NewTextHandler(buf, nil) },
+		"json": func(buf *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(buf, nil) },
+	} {
+		var buf bytes.Buffer
+
+		slog.New(newHandler(&buf)).Info("Creating new server", "config", cfg)
+
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("%s log contains the secret: %s", name, buf.String())
+		}
+
+		if !strings.Contains(buf.String(), "hooks.example.org") {
+			t.Errorf("%s log misses the configuration: %s", name, buf.String())
+		}
+	}
+
+	if cfg.ValidationWebhook.Secret != secret {
+		t.Error("LogValue must not change the configuration")
+	}
 }
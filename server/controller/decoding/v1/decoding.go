@@ -5,12 +5,14 @@ package v1
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
 	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"google.golang.org/grpc/codes"
 )
 
 type decodingCtrl struct{}
@@ -19,12 +21,12 @@ func New() decodingv1grpc.DecodingServiceServer {
 	return &decodingCtrl{}
 }
 
-func (t *decodingCtrl) DecodeRecord(_ context.Context, req *decodingv1.DecodeRecordRequest) (*decodingv1.DecodeRecordResponse, error) {
-	slog.Info("Received DecodeRecord request", "request", req)
+func (t *decodingCtrl) DecodeRecord(ctx context.Context, req *decodingv1.DecodeRecordRequest) (*decodingv1.DecodeRecordResponse, error) {
+	slog.InfoContext(ctx, "Received DecodeRecord request", "request", logging.Redacted(req))
 
 	res, err := decoder.DecodeRecord(req.GetRecord())
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonDecodingFailed, "failed to decode record")
 	}
 
 	return res, nil
@@ -11,6 +11,8 @@ import (
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/extractor/v1/extractorv1grpc"
 	extractorv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/extractor/v1"
 	"github.com/agntcy/oasf-sdk/pkg/extractor"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"google.golang.org/grpc/codes"
 )
 
 // extractorEngine is the subset of *extractor.Extractor the controller depends
@@ -46,11 +48,11 @@ func New(ctx context.Context, opts ...extractor.Option) (extractorv1grpc.Extract
 }
 
 func (c *extractorCtrl) Extract(ctx context.Context, req *extractorv1.ExtractRequest) (*extractorv1.ExtractResponse, error) {
-	slog.Debug("Received Extract request", "text_len", len(req.GetText()), "scope", req.GetScope())
+	slog.DebugContext(ctx, "Received Extract request", "text_len", len(req.GetText()), "scope", req.GetScope())
 
 	res, err := c.engine.Extract(ctx, req.GetText(), queryOptions(req)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract: %w", err)
+		return nil, rpcerr.New(codes.Internal, rpcerr.ReasonExtractionFailed, fmt.Sprintf("failed to extract: %v", err))
 	}
 
 	return &extractorv1.ExtractResponse{
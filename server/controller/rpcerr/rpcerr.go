@@ -0,0 +1,174 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rpcerr builds gRPC status errors carrying google.rpc error details,
+// so clients can branch on machine-readable reasons (ErrorInfo) and on the
+// offending request fields (BadRequest) instead of matching error strings.
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain attached to every error produced by the server.
+const Domain = "oasf-sdk.agntcy.org"
+
+// Machine-readable ErrorInfo reasons. Values are stable and safe to branch on.
+const (
+	ReasonRecordMissing            = "RECORD_MISSING"
+	ReasonRecordTooLarge           = "RECORD_TOO_LARGE"
+	ReasonRecordRejected           = "RECORD_REJECTED"
+	ReasonRecordInvalid            = "RECORD_INVALID"
+	ReasonSchemaVersionMissing     = "SCHEMA_VERSION_MISSING"
+	ReasonSchemaVersionInvalid     = "SCHEMA_VERSION_INVALID"
+	ReasonSchemaVersionUnsupported = "SCHEMA_VERSION_UNSUPPORTED"
+	ReasonSchemaURLInvalid         = "SCHEMA_URL_INVALID"
+	ReasonSchemaUnavailable        = "SCHEMA_UNAVAILABLE"
+	ReasonModuleMissing            = "MODULE_MISSING"
+	ReasonInvalidInput             = "INVALID_INPUT"
+	ReasonDecodingFailed           = "DECODING_FAILED"
+	ReasonTranslationFailed        = "TRANSLATION_FAILED"
+	ReasonExtractionFailed         = "EXTRACTION_FAILED"
+	ReasonPipelineFailed           = "PIPELINE_FAILED"
+	ReasonUnimplemented            = "UNIMPLEMENTED"
+	ReasonInternal                 = "INTERNAL"
+)
+
+// Request field paths reported in BadRequest field violations.
+const (
+	recordField        = "record"
+	schemaVersionField = "record.schema_version"
+)
+
+// Error describes a status error before it is converted to a gRPC status.
+type Error struct {
+	Code       codes.Code
+	Reason     string
+	Message    string
+	Metadata   map[string]string
+	Violations []*errdetails.BadRequest_FieldViolation
+}
+
+// FieldViolation returns a BadRequest field violation for the given request field.
+func FieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// Status converts the error into a gRPC status error with an ErrorInfo detail
+// and, when field violations are present, a BadRequest detail.
+func (e *Error) Status() error {
+	st := status.New(e.Code, e.Message)
+
+	withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: e.Reason, Domain: Domain, Metadata: e.Metadata})
+	if err != nil {
+		return st.Err()
+	}
+
+	if len(e.Violations) == 0 {
+		return withInfo.Err()
+	}
+
+	withViolations, err := withInfo.WithDetails(&errdetails.BadRequest{FieldViolations: e.Violations})
+	if err != nil {
+		return withInfo.Err()
+	}
+
+	return withViolations.Err()
+}
+
+// New returns a gRPC status error with the given code, reason and message.
+func New(code codes.Code, reason, message string, violations ...*errdetails.BadRequest_FieldViolation) error {
+	return (&Error{Code: code, Reason: reason, Message: message, Violations: violations}).Status()
+}
+
+// InvalidArgument returns an INVALID_ARGUMENT status error for a single request field.
+func InvalidArgument(reason, field, message string) error {
+	return New(codes.InvalidArgument, reason, message, FieldViolation(field, message))
+}
+
+// Internal returns an INTERNAL status error for failures that are not caused by
+// the request, such as converting a result into its response shape.
+func Internal(prefix string, err error) error {
+	return New(codes.Internal, ReasonInternal, fmt.Sprintf("%s: %v", prefix, err))
+}
+
+// FromRecordError maps an error produced while handling a request record onto a
+// gRPC status error. Known validation-shaped errors (missing or oversized
+// record, missing or unsupported schema_version) become INVALID_ARGUMENT with a BadRequest detail;
+// an invalid translated record INVALID_ARGUMENT; a missing module FAILED_PRECONDITION, other invalid input INVALID_ARGUMENT
+// and an unavailable schema server UNAVAILABLE; anything else uses the
+// fallback code and reason. The message keeps the
+// "<prefix>: <cause>" shape so existing substring checks continue to work.
+func FromRecordError(err error, fallback codes.Code, fallbackReason, prefix string) error {
+	if err == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s: %v", prefix, err)
+
+	switch {
+	case errors.Is(err, translator.ErrInvalidRecord):
+		return New(codes.InvalidArgument, ReasonRecordInvalid, message)
+	case errors.Is(err, decoder.ErrNilRecord):
+		return New(codes.InvalidArgument, ReasonRecordMissing, message, FieldViolation(recordField, err.Error()))
+	case errors.Is(err, record.ErrTooLarge):
+		return New(codes.InvalidArgument, ReasonRecordTooLarge, message, FieldViolation(recordField, err.Error()))
+	case errors.Is(err, decoder.ErrMissingSchemaVersion):
+		return New(codes.InvalidArgument, ReasonSchemaVersionMissing, message, FieldViolation(schemaVersionField, err.Error()))
+	case errors.Is(err, decoder.ErrInvalidSchemaVersion):
+		return New(codes.InvalidArgument, ReasonSchemaVersionInvalid, message, FieldViolation(schemaVersionField, err.Error()))
+	case errors.Is(err, decoder.ErrUnsupportedSchemaVersion), errors.Is(err, schema.ErrUnsupportedVersion):
+		return New(codes.InvalidArgument, ReasonSchemaVersionUnsupported, message, FieldViolation(schemaVersionField, err.Error()))
+	case errors.Is(err, translator.ErrModuleNotFound):
+		return New(codes.FailedPrecondition, ReasonModuleMissing, message)
+	case errors.Is(err, translator.ErrInvalidInput):
+		return New(codes.InvalidArgument, ReasonInvalidInput, message)
+	case errors.Is(err, schema.ErrSchemaUnavailable):
+		return New(codes.Unavailable, ReasonSchemaUnavailable, message)
+	default:
+		return New(fallback, fallbackReason, message)
+	}
+}
+
+// Reason extracts the ErrorInfo reason from a status error, or "" when absent.
+func Reason(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return ""
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+
+	return ""
+}
+
+// Violations extracts BadRequest field violations from a status error.
+func Violations(err error) []*errdetails.BadRequest_FieldViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+
+	var out []*errdetails.BadRequest_FieldViolation
+
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			out = append(out, br.GetFieldViolations()...)
+		}
+	}
+
+	return out
+}
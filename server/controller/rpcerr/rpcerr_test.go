@@ -0,0 +1,138 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/client"
+	"github.com/agntcy/oasf-sdk/pkg/decoder"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestFromRecordError(t *testing.T) {
+	missing, err := structpb.NewStruct(map[string]any{"name": "no-version"})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	unsupported, err := structpb.NewStruct(map[string]any{"schema_version": "99.0.0"})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	_, missingErr := decoder.DecodeRecord(missing)
+	_, unsupportedErr := decoder.DecodeRecord(unsupported)
+	_, nilErr := decoder.DecodeRecord(nil)
+
+	cases := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantReason string
+		wantField  string
+		wantSubstr string
+	}{
+		{"nil record", nilErr, codes.InvalidArgument, ReasonRecordMissing, "record", "request is nil"},
+		{"missing schema_version", missingErr, codes.InvalidArgument, ReasonSchemaVersionMissing, "record.schema_version", "schema_version field is missing"},
+		{"unsupported schema_version", unsupportedErr, codes.InvalidArgument, ReasonSchemaVersionUnsupported, "record.schema_version", "unsupported OASF version"},
+		{"wrapped", fmt.Errorf("outer: %w", decoder.ErrMissingSchemaVersion), codes.InvalidArgument, ReasonSchemaVersionMissing, "record.schema_version", "outer"},
+		{"record too large", fmt.Errorf("%w: record is 10 bytes, over the 5 byte limit", record.ErrTooLarge), codes.InvalidArgument, ReasonRecordTooLarge, "record", "over the 5 byte limit"},
+		{"module missing", fmt.Errorf("MCP %w", translator.ErrModuleNotFound), codes.FailedPrecondition, ReasonModuleMissing, "", "MCP module not found"},
+		{"invalid input", fmt.Errorf("%w: 'server' is not a struct", translator.ErrInvalidInput), codes.InvalidArgument, ReasonInvalidInput, "", "'server' is not a struct"},
+		{"invalid record", fmt.Errorf("%w for schema version 1.0.0: missing name", translator.ErrInvalidRecord), codes.InvalidArgument, ReasonRecordInvalid, "", "missing name"},
+		{"schema unavailable", fmt.Errorf("%w: HTTP 503", validator.ErrSchemaUnavailable), codes.Unavailable, ReasonSchemaUnavailable, "", "HTTP 503"},
+		{"unknown falls back", errors.New("boom"), codes.Unavailable, ReasonSchemaUnavailable, "", "boom"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FromRecordError(tc.err, codes.Unavailable, ReasonSchemaUnavailable, "failed to decode record")
+
+			st, ok := status.FromError(got)
+			if !ok {
+				t.Fatalf("expected a status error, got %T", got)
+			}
+
+			if st.Code() != tc.wantCode {
+				t.Errorf("code = %v, want %v", st.Code(), tc.wantCode)
+			}
+
+			if !strings.HasPrefix(st.Message(), "failed to decode record: ") || !strings.Contains(st.Message(), tc.wantSubstr) {
+				t.Errorf("message = %q, want prefix and substring %q", st.Message(), tc.wantSubstr)
+			}
+
+			if reason := Reason(got); reason != tc.wantReason {
+				t.Errorf("reason = %q, want %q", reason, tc.wantReason)
+			}
+
+			violations := Violations(got)
+			if tc.wantField == "" {
+				if len(violations) != 0 {
+					t.Errorf("expected no field violations, got %v", violations)
+				}
+
+				return
+			}
+
+			if len(violations) != 1 || violations[0].GetField() != tc.wantField {
+				t.Errorf("violations = %v, want one on %q", violations, tc.wantField)
+			}
+		})
+	}
+}
+
+func TestFromRecordErrorNil(t *testing.T) {
+	if err := FromRecordError(nil, codes.Internal, ReasonInternal, "x"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestInternal(t *testing.T) {
+	err := Internal("failed to convert result", errors.New("bad value"))
+
+	if status.Code(err) != codes.Internal {
+		t.Errorf("code = %v, want Internal", status.Code(err))
+	}
+
+	if Reason(err) != ReasonInternal {
+		t.Errorf("reason = %q, want %q", Reason(err), ReasonInternal)
+	}
+
+	if got := status.Convert(err).Message(); got != "failed to convert result: bad value" {
+		t.Errorf("message = %q", got)
+	}
+}
+
+// TestClientRoundTrip guards the reasons pkg/client maps back to the SDK
+// errors: a client receiving the status of a record error can match it with
+// errors.Is like the in-process error.
+func TestClientRoundTrip(t *testing.T) {
+	for _, sentinel := range []error{
+		decoder.ErrNilRecord,
+		decoder.ErrMissingSchemaVersion,
+		decoder.ErrInvalidSchemaVersion,
+		decoder.ErrUnsupportedSchemaVersion,
+		schema.ErrUnsupportedVersion,
+		schema.ErrSchemaUnavailable,
+		record.ErrTooLarge,
+		translator.ErrModuleNotFound,
+		translator.ErrInvalidInput,
+		translator.ErrInvalidRecord,
+	} {
+		st := FromRecordError(fmt.Errorf("detail: %w", sentinel), codes.Internal, ReasonInternal, "failed")
+		if err := client.FromStatus(st); !errors.Is(err, sentinel) {
+			t.Errorf("%v: client error %v does not match", sentinel, err)
+		}
+	}
+}
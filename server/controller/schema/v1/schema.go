@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -14,18 +15,37 @@ import (
 	schemav1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/schema/v1"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 	"github.com/agntcy/oasf-sdk/pkg/schema"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"google.golang.org/grpc/codes"
 )
 
 // schemaURLPathParts is the number of slash-separated parts after trimming the leading slash:
 // ["schema", "<version>", "<type>", "<name>"].
 const schemaURLPathParts = 4
 
+// Option configures New.
+type Option func(*schemaCtrl)
+
+// WithHTTPClient sets the HTTP client of the schema clients of requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *schemaCtrl) {
+		s.httpClient = client
+	}
+}
+
 type schemaCtrl struct {
 	schemav1grpc.UnimplementedSchemaServiceServer
+
+	httpClient *http.Client
 }
 
-func New() schemav1grpc.SchemaServiceServer {
-	return &schemaCtrl{}
+func New(opts ...Option) schemav1grpc.SchemaServiceServer {
+	ctrl := &schemaCtrl{}
+	for _, opt := range opts {
+		opt(ctrl)
+	}
+
+	return ctrl
 }
 
 func schemaOptionsFromVersion(version string) []schema.SchemaOption {
@@ -36,10 +56,15 @@ func schemaOptionsFromVersion(version string) []schema.SchemaOption {
 	return []schema.SchemaOption{schema.WithSchemaVersion(version)}
 }
 
-func newSchemaClient(schemaURL string) (*schema.Schema, error) {
-	client, err := schema.New(schemaURL)
+func (s *schemaCtrl) newSchemaClient(schemaURL string) (*schema.Schema, error) {
+	var opts []schema.ConstructorOption
+	if s.httpClient != nil {
+		opts = append(opts, schema.WithHTTPClient(s.httpClient))
+	}
+
+	client, err := schema.New(schemaURL, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create schema client: %w", err)
+		return nil, rpcerr.InvalidArgument(rpcerr.ReasonSchemaURLInvalid, "schema_url", "failed to create schema client: "+err.Error())
 	}
 
 	return client, nil
@@ -80,53 +105,53 @@ func taxonomyItemToProto(src schema.TaxonomyItem) *schemav1.TaxonomyItem {
 }
 
 func (s *schemaCtrl) GetDefaultSchemaVersion(ctx context.Context, req *schemav1.GetDefaultSchemaVersionRequest) (*schemav1.GetDefaultSchemaVersionResponse, error) {
-	slog.Info("Received GetDefaultSchemaVersion request")
+	slog.InfoContext(ctx, "Received GetDefaultSchemaVersion request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	version, err := client.GetDefaultSchemaVersion(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get default schema version: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get default schema version: %v", err))
 	}
 
 	return &schemav1.GetDefaultSchemaVersionResponse{Version: version}, nil
 }
 
 func (s *schemaCtrl) GetAvailableSchemaVersions(ctx context.Context, req *schemav1.GetAvailableSchemaVersionsRequest) (*schemav1.GetAvailableSchemaVersionsResponse, error) {
-	slog.Info("Received GetAvailableSchemaVersions request")
+	slog.InfoContext(ctx, "Received GetAvailableSchemaVersions request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	versions, err := client.GetAvailableSchemaVersions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get available schema versions: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get available schema versions: %v", err))
 	}
 
 	return &schemav1.GetAvailableSchemaVersionsResponse{Versions: versions}, nil
 }
 
 func (s *schemaCtrl) GetRecordJSONSchema(ctx context.Context, req *schemav1.GetRecordJSONSchemaRequest) (*schemav1.GetRecordJSONSchemaResponse, error) {
-	slog.Info("Received GetRecordJSONSchema request")
+	slog.InfoContext(ctx, "Received GetRecordJSONSchema request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	schemaContent, err := client.GetRecordJSONSchema(ctx, schemaOptionsFromVersion(req.GetSchemaVersion())...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get record JSON schema: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get record JSON schema: %v", err))
 	}
 
 	schemaStruct, err := decoder.JsonToProto(schemaContent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert schema JSON to proto struct: %w", err)
+		return nil, rpcerr.Internal("failed to convert schema JSON to proto struct", err)
 	}
 
 	return &schemav1.GetRecordJSONSchemaResponse{Schema: schemaStruct}, nil
@@ -149,74 +174,74 @@ func parseSchemaURL(rawURL string) (string, string, schema.EntityType, string, e
 }
 
 func (s *schemaCtrl) GetJSONSchema(ctx context.Context, req *schemav1.GetJSONSchemaRequest) (*schemav1.GetJSONSchemaResponse, error) {
-	slog.Info("Received GetJSONSchema request")
+	slog.InfoContext(ctx, "Received GetJSONSchema request")
 
 	schemaBase, version, schemaType, name, err := parseSchemaURL(req.GetUrl())
 	if err != nil {
-		return nil, err
+		return nil, rpcerr.InvalidArgument(rpcerr.ReasonSchemaURLInvalid, "url", err.Error())
 	}
 
-	client, err := newSchemaClient(schemaBase)
+	client, err := s.newSchemaClient(schemaBase)
 	if err != nil {
 		return nil, err
 	}
 
 	schemaContent, err := client.GetJSONSchema(ctx, schemaType, name, schema.WithSchemaVersion(version))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get JSON schema: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get JSON schema: %v", err))
 	}
 
 	schemaStruct, err := decoder.JsonToProto(schemaContent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert schema JSON to proto struct: %w", err)
+		return nil, rpcerr.Internal("failed to convert schema JSON to proto struct", err)
 	}
 
 	return &schemav1.GetJSONSchemaResponse{Schema: schemaStruct}, nil
 }
 
 func (s *schemaCtrl) GetSchemaSkills(ctx context.Context, req *schemav1.GetSchemaSkillsRequest) (*schemav1.GetSchemaSkillsResponse, error) {
-	slog.Info("Received GetSchemaSkills request")
+	slog.InfoContext(ctx, "Received GetSchemaSkills request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	taxonomy, err := client.GetSchemaSkills(ctx, schemaOptionsFromVersion(req.GetSchemaVersion())...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schema skills: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get schema skills: %v", err))
 	}
 
 	return &schemav1.GetSchemaSkillsResponse{Items: taxonomyToProto(taxonomy)}, nil
 }
 
 func (s *schemaCtrl) GetSchemaDomains(ctx context.Context, req *schemav1.GetSchemaDomainsRequest) (*schemav1.GetSchemaDomainsResponse, error) {
-	slog.Info("Received GetSchemaDomains request")
+	slog.InfoContext(ctx, "Received GetSchemaDomains request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	taxonomy, err := client.GetSchemaDomains(ctx, schemaOptionsFromVersion(req.GetSchemaVersion())...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schema domains: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get schema domains: %v", err))
 	}
 
 	return &schemav1.GetSchemaDomainsResponse{Items: taxonomyToProto(taxonomy)}, nil
 }
 
 func (s *schemaCtrl) GetSchemaModules(ctx context.Context, req *schemav1.GetSchemaModulesRequest) (*schemav1.GetSchemaModulesResponse, error) {
-	slog.Info("Received GetSchemaModules request")
+	slog.InfoContext(ctx, "Received GetSchemaModules request")
 
-	client, err := newSchemaClient(req.GetSchemaUrl())
+	client, err := s.newSchemaClient(req.GetSchemaUrl())
 	if err != nil {
 		return nil, err
 	}
 
 	taxonomy, err := client.GetSchemaModules(ctx, schemaOptionsFromVersion(req.GetSchemaVersion())...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get schema modules: %w", err)
+		return nil, rpcerr.New(codes.Unavailable, rpcerr.ReasonSchemaUnavailable, fmt.Sprintf("failed to get schema modules: %v", err))
 	}
 
 	return &schemav1.GetSchemaModulesResponse{Items: taxonomyToProto(taxonomy)}, nil
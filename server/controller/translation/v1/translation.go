@@ -5,110 +5,159 @@ package v1
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
+	"slices"
 
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
 	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
 	"github.com/agntcy/oasf-sdk/pkg/decoder"
 	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"google.golang.org/grpc/codes"
 )
 
-type translationCtrl struct{}
+type Option func(*translationCtrl)
 
-func New() translationv1grpc.TranslationServiceServer {
-	return &translationCtrl{}
+// WithRecordValidation checks the records generated by A2AToRecord and
+// MCPToRecord before returning them, failing the RPC with RECORD_INVALID
+// instead (see translator.WithValidation). A nil validator only runs the
+// local checks.
+func WithRecordValidation(validator translator.RecordValidator) Option {
+	return func(t *translationCtrl) {
+		t.checkOpts = append(t.checkOpts, translator.WithValidation(validator))
+	}
+}
+
+// WithProvenance sets the provenance defaults of the RPCs generating records
+// (see translator.Provenance).
+func WithProvenance(provenance translator.Provenance) Option {
+	return func(t *translationCtrl) {
+		t.recordOpts = append(t.recordOpts, translator.WithProvenance(provenance))
+	}
 }
 
-func (t *translationCtrl) RecordToGHCopilot(_ context.Context, req *translationv1.RecordToGHCopilotRequest) (*translationv1.RecordToGHCopilotResponse, error) {
-	slog.Info("Received Publish request", "request", req)
+type translationCtrl struct {
+	// recordOpts are the options of the translators generating records.
+	recordOpts []translator.TranslatorOption
+	// checkOpts are the options checking the records of A2AToRecord and
+	// MCPToRecord.
+	checkOpts []translator.TranslatorOption
+}
+
+// recordOptsFor returns the options of the translators generating records
+// for an RPC, whose upstream calls run under its context.
+func (t *translationCtrl) recordOptsFor(ctx context.Context) []translator.TranslatorOption {
+	return append(slices.Clip(t.recordOpts), translator.WithContext(ctx))
+}
+
+// checkedRecordOpts returns the options of A2AToRecord and MCPToRecord.
+func (t *translationCtrl) checkedRecordOpts(ctx context.Context) []translator.TranslatorOption {
+	return append(t.recordOptsFor(ctx), t.checkOpts...)
+}
+
+func New(opts ...Option) translationv1grpc.TranslationServiceServer {
+	ctrl := &translationCtrl{}
+	for _, opt := range opts {
+		opt(ctrl)
+	}
+
+	return ctrl
+}
+
+func (t *translationCtrl) RecordToGHCopilot(ctx context.Context, req *translationv1.RecordToGHCopilotRequest) (*translationv1.RecordToGHCopilotResponse, error) {
+	slog.InfoContext(ctx, "Received Publish request", "request", logging.Redacted(req))
 
 	result, err := translator.RecordToGHCopilot(req.GetRecord())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate GHCopilot config from record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate GHCopilot config from record")
 	}
 
 	data, err := decoder.StructToProto(map[string]any{"mcpConfig": result})
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert result to proto struct: %w", err)
+		return nil, rpcerr.Internal("failed to convert result to proto struct", err)
 	}
 
 	return &translationv1.RecordToGHCopilotResponse{Data: data}, nil
 }
 
-func (t *translationCtrl) RecordToA2A(_ context.Context, req *translationv1.RecordToA2ARequest) (*translationv1.RecordToA2AResponse, error) {
-	slog.Info("Received RecordToA2A request", "request", req)
+func (t *translationCtrl) RecordToA2A(ctx context.Context, req *translationv1.RecordToA2ARequest) (*translationv1.RecordToA2AResponse, error) {
+	slog.InfoContext(ctx, "Received RecordToA2A request", "request", logging.Redacted(req))
 
 	result, err := translator.RecordToA2A(req.GetRecord())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate A2A card from record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate A2A card from record")
 	}
 
 	// Wrap the A2A card data in the expected structure
 	data, err := decoder.StructToProto(map[string]any{"a2aCard": result.AsMap()})
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert result to proto struct: %w", err)
+		return nil, rpcerr.Internal("failed to convert result to proto struct", err)
 	}
 
 	return &translationv1.RecordToA2AResponse{Data: data}, nil
 }
 
 // GHCopilotToRecord implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) GHCopilotToRecord(context.Context, *translationv1.GHCopilotToRecordRequest) (*translationv1.GHCopilotToRecordResponse, error) {
-	panic("unimplemented")
+// There is no translator from GitHub Copilot configs to records, so it fails
+// with UNIMPLEMENTED instead of taking the server down.
+func (t *translationCtrl) GHCopilotToRecord(ctx context.Context, req *translationv1.GHCopilotToRecordRequest) (*translationv1.GHCopilotToRecordResponse, error) {
+	slog.InfoContext(ctx, "Received GHCopilotToRecord request", "request", logging.Redacted(req))
+
+	return nil, rpcerr.New(codes.Unimplemented, rpcerr.ReasonUnimplemented, "translating GitHub Copilot configs to records is not supported")
 }
 
 // A2AToRecord implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) A2AToRecord(_ context.Context, req *translationv1.A2AToRecordRequest) (*translationv1.A2AToRecordResponse, error) {
-	slog.Info("Received A2AToRecord request", "request", req)
+func (t *translationCtrl) A2AToRecord(ctx context.Context, req *translationv1.A2AToRecordRequest) (*translationv1.A2AToRecordResponse, error) {
+	slog.InfoContext(ctx, "Received A2AToRecord request", "request", logging.Redacted(req))
 
-	result, err := translator.A2AToRecord(req.GetData())
+	result, err := translator.A2AToRecord(req.GetData(), t.checkedRecordOpts(ctx)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate record from A2A data: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate record from A2A data")
 	}
 
 	return &translationv1.A2AToRecordResponse{Record: result}, nil
 }
 
 // MCPToRecord implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) MCPToRecord(_ context.Context, req *translationv1.MCPToRecordRequest) (*translationv1.MCPToRecordResponse, error) {
-	slog.Info("Received MCPToRecord request", "request", req)
+func (t *translationCtrl) MCPToRecord(ctx context.Context, req *translationv1.MCPToRecordRequest) (*translationv1.MCPToRecordResponse, error) {
+	slog.InfoContext(ctx, "Received MCPToRecord request", "request", logging.Redacted(req))
 
-	result, err := translator.MCPToRecord(req.GetData())
+	result, err := translator.MCPToRecord(req.GetData(), t.checkedRecordOpts(ctx)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate record from MCP Registry data: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate record from MCP Registry data")
 	}
 
 	return &translationv1.MCPToRecordResponse{Record: result}, nil
 }
 
 // SkillMarkdownToRecord implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) SkillMarkdownToRecord(_ context.Context, req *translationv1.SkillMarkdownToRecordRequest) (*translationv1.SkillMarkdownToRecordResponse, error) {
-	slog.Info("Received SkillMarkdownToRecord request")
+func (t *translationCtrl) SkillMarkdownToRecord(ctx context.Context, req *translationv1.SkillMarkdownToRecordRequest) (*translationv1.SkillMarkdownToRecordResponse, error) {
+	slog.InfoContext(ctx, "Received SkillMarkdownToRecord request")
 
-	result, err := translator.SkillMarkdownToRecord(req.GetData())
+	result, err := translator.SkillMarkdownToRecord(req.GetData(), t.recordOptsFor(ctx)...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate record from SKILL.md: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate record from SKILL.md")
 	}
 
 	return &translationv1.SkillMarkdownToRecordResponse{Record: result}, nil
 }
 
 // RecordToSkillMarkdown implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) RecordToSkillMarkdown(_ context.Context, req *translationv1.RecordToSkillMarkdownRequest) (*translationv1.RecordToSkillMarkdownResponse, error) {
-	slog.Info("Received RecordToSkillMarkdown request")
+func (t *translationCtrl) RecordToSkillMarkdown(ctx context.Context, req *translationv1.RecordToSkillMarkdownRequest) (*translationv1.RecordToSkillMarkdownResponse, error) {
+	slog.InfoContext(ctx, "Received RecordToSkillMarkdown request")
 
 	result, err := translator.RecordToSkillMarkdown(req.GetRecord())
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate SKILL.md from record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate SKILL.md from record")
 	}
 
 	return &translationv1.RecordToSkillMarkdownResponse{Data: result}, nil
 }
 
 // RecordToCatalog implements translationv1grpc.TranslationServiceServer.
-func (t *translationCtrl) RecordToCatalog(_ context.Context, req *translationv1.RecordToCatalogRequest) (*translationv1.RecordToCatalogResponse, error) {
-	slog.Info("Received RecordToCatalog request")
+func (t *translationCtrl) RecordToCatalog(ctx context.Context, req *translationv1.RecordToCatalogRequest) (*translationv1.RecordToCatalogResponse, error) {
+	slog.InfoContext(ctx, "Received RecordToCatalog request")
 
 	opts := []translator.CatalogOption{translator.WithCatalogCID(req.GetCid())}
 	if host := req.GetHost(); host != "" {
@@ -121,7 +170,7 @@ func (t *translationCtrl) RecordToCatalog(_ context.Context, req *translationv1.
 
 	result, err := translator.RecordToCatalog(req.GetRecord(), opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate AI Catalog entry from record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonTranslationFailed, "failed to generate AI Catalog entry from record")
 	}
 
 	return &translationv1.RecordToCatalogResponse{Data: result}, nil
@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"testing"
+
+	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestA2AToRecord(t *testing.T) {
+	card, err := structpb.NewStruct(map[string]any{"name": "travel-agent", "description": "Plans trips.", "version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("failed to build card: %v", err)
+	}
+
+	resp, err := New().A2AToRecord(t.Context(), &translationv1.A2AToRecordRequest{Data: card})
+	if err != nil {
+		t.Fatalf("A2AToRecord() error: %v", err)
+	}
+
+	if name := resp.GetRecord().GetFields()["name"].GetStringValue(); name != "travel-agent" {
+		t.Errorf("record name = %q, want travel-agent", name)
+	}
+}
+
+func TestGHCopilotToRecordUnimplemented(t *testing.T) {
+	_, err := New().GHCopilotToRecord(t.Context(), &translationv1.GHCopilotToRecordRequest{Data: &structpb.Struct{}})
+
+	if code := status.Code(err); code != codes.Unimplemented {
+		t.Errorf("code = %v, want Unimplemented", code)
+	}
+
+	if reason := rpcerr.Reason(err); reason != rpcerr.ReasonUnimplemented {
+		t.Errorf("reason = %q, want %q", reason, rpcerr.ReasonUnimplemented)
+	}
+}
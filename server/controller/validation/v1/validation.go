@@ -9,29 +9,71 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
 	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/pkg/messages"
 	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"github.com/agntcy/oasf-sdk/server/notify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
-type validationCtrl struct{}
+// Option configures New.
+type Option func(*validationCtrl)
 
-func New() (validationv1grpc.ValidationServiceServer, error) {
-	return &validationCtrl{}, nil
+// WithNotifier posts the result of every validated record to the notifier's
+// webhook, in the background.
+func WithNotifier(notifier *notify.Notifier) Option {
+	return func(v *validationCtrl) {
+		v.notifier = notifier
+	}
+}
+
+// WithHTTPClient sets the HTTP client of the validators of requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(v *validationCtrl) {
+		v.httpClient = client
+	}
+}
+
+type validationCtrl struct {
+	notifier   *notify.Notifier
+	httpClient *http.Client
+}
+
+// validatorOptions returns the options of the validators of requests.
+func (v validationCtrl) validatorOptions() []validator.Option {
+	if v.httpClient == nil {
+		return nil
+	}
+
+	return []validator.Option{validator.WithHTTPClient(v.httpClient)}
+}
+
+func New(opts ...Option) (validationv1grpc.ValidationServiceServer, error) {
+	ctrl := &validationCtrl{}
+	for _, opt := range opts {
+		opt(ctrl)
+	}
+
+	return ctrl, nil
 }
 
 func (v validationCtrl) ValidateRecord(ctx context.Context, req *validationv1.ValidateRecordRequest) (*validationv1.ValidateRecordResponse, error) {
-	slog.Info("Received ValidateRecord request", "request", req)
+	slog.InfoContext(ctx, "Received ValidateRecord request", "request", logging.Redacted(req))
 
-	validatorInstance, err := validator.New(req.GetSchemaUrl())
+	validatorInstance, err := validator.New(req.GetSchemaUrl(), v.validatorOptions()...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create validator: %w", err)
+		return nil, rpcerr.InvalidArgument(rpcerr.ReasonSchemaURLInvalid, "schema_url", "failed to create validator: "+err.Error())
 	}
 
-	isValid, errors, warnings, err := validatorInstance.ValidateRecord(ctx, req.GetRecord())
+	isValid, errors, warnings, err := v.validate(ctx, validatorInstance, req.GetRecord(), req.GetSchemaUrl())
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate record: %w", err)
+		return nil, rpcerr.FromRecordError(err, codes.Unavailable, rpcerr.ReasonSchemaUnavailable, "failed to validate record")
 	}
 
 	return &validationv1.ValidateRecordResponse{
@@ -42,7 +84,7 @@ func (v validationCtrl) ValidateRecord(ctx context.Context, req *validationv1.Va
 }
 
 func (v validationCtrl) ValidateRecordStream(stream validationv1grpc.ValidationService_ValidateRecordStreamServer) error {
-	slog.Info("Received ValidateRecordStream request")
+	slog.InfoContext(stream.Context(), "Received ValidateRecordStream request")
 
 	for {
 		req, err := stream.Recv()
@@ -54,14 +96,14 @@ func (v validationCtrl) ValidateRecordStream(stream validationv1grpc.ValidationS
 			return fmt.Errorf("failed to receive record: %w", err)
 		}
 
-		validatorInstance, err := validator.New(req.GetSchemaUrl())
+		validatorInstance, err := validator.New(req.GetSchemaUrl(), v.validatorOptions()...)
 		if err != nil {
-			return fmt.Errorf("failed to create validator: %w", err)
+			return rpcerr.InvalidArgument(rpcerr.ReasonSchemaURLInvalid, "schema_url", "failed to create validator: "+err.Error())
 		}
 
-		isValid, errors, warnings, err := validatorInstance.ValidateRecord(stream.Context(), req.GetRecord())
+		isValid, errors, warnings, err := v.validate(stream.Context(), validatorInstance, req.GetRecord(), req.GetSchemaUrl())
 		if err != nil {
-			return fmt.Errorf("failed to validate record: %w", err)
+			return rpcerr.FromRecordError(err, codes.Unavailable, rpcerr.ReasonSchemaUnavailable, "failed to validate record")
 		}
 
 		response := &validationv1.ValidateRecordStreamResponse{
@@ -75,3 +117,28 @@ func (v validationCtrl) ValidateRecordStream(stream validationv1grpc.ValidationS
 		}
 	}
 }
+
+// validate validates the record like validator.ValidateRecord and notifies
+// the result.
+func (v validationCtrl) validate(ctx context.Context, validatorInstance *validator.Validator, record *structpb.Struct, schemaURL string) (bool, []string, []string, error) {
+	isValid, errs, warnings, err := validatorInstance.ValidateRecordMessages(ctx, record)
+	if err != nil {
+		return false, nil, nil, err //nolint:wrapcheck
+	}
+
+	if v.notifier != nil {
+		v.notifier.Send(notify.NewResult(record, schemaURL, errs, warnings))
+	}
+
+	return isValid, texts(errs), texts(warnings), nil
+}
+
+// texts returns the texts of the messages.
+func texts(msgs []messages.Message) []string {
+	out := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		out = append(out, msg.Text)
+	}
+
+	return out
+}
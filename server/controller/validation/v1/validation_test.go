@@ -0,0 +1,51 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestValidateRecordHTTPClient(t *testing.T) {
+	requestIDs := make(chan string, 1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case requestIDs <- r.Header.Get(logging.RequestIDHeader):
+		default:
+		}
+
+		_, _ = w.Write([]byte(`{"errors": [], "warnings": [], "error_count": 0, "warning_count": 0}`))
+	}))
+	defer srv.Close()
+
+	ctrl, err := New(WithHTTPClient(&http.Client{Transport: logging.Transport(nil)}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	record, err := structpb.NewStruct(map[string]any{"name": "agent", "schema_version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	ctx := logging.WithRequestID(context.Background(), "req-1234")
+	_, _ = ctrl.ValidateRecord(ctx, &validationv1.ValidateRecordRequest{Record: record, SchemaUrl: srv.URL})
+
+	select {
+	case id := <-requestIDs:
+		if id != "req-1234" {
+			t.Errorf("X-Request-ID = %q, want the ID of the request", id)
+		}
+	default:
+		t.Fatal("the schema server was not called")
+	}
+}
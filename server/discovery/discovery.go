@@ -0,0 +1,146 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package discovery serves agent discovery documents rendered from OASF
+// records: the A2A agent card (/.well-known/agent-card.json and the older
+// /.well-known/agent.json) and the VS Code MCP configuration (/mcp.json), so
+// the records stay the single source of truth for them.
+package discovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Paths of the documents served for each record.
+const (
+	AgentCardPath       = "/.well-known/agent-card.json"
+	LegacyAgentCardPath = "/.well-known/agent.json"
+	MCPConfigPath       = "/mcp.json"
+)
+
+// Handler serves the discovery documents of a fixed set of records. Each
+// record's documents are served under /<record name>, e.g.
+// /example.org/weather/.well-known/agent-card.json; with a single record they
+// are also served at the root. Documents are only served for records with the
+// matching module.
+type Handler struct {
+	docs map[string][]byte
+}
+
+// New renders the discovery documents of the records. Record names must be
+// set and unique.
+func New(records []*structpb.Struct) (*Handler, error) {
+	h := &Handler{docs: map[string][]byte{}}
+
+	for _, record := range records {
+		name := strings.Trim(record.GetFields()["name"].GetStringValue(), "/")
+		if name == "" {
+			return nil, errors.New("record has no name")
+		}
+
+		prefix := "/" + name
+		if _, ok := h.docs[prefix]; ok {
+			return nil, fmt.Errorf("duplicate record name %q", name)
+		}
+
+		docs, err := render(record)
+		if err != nil {
+			return nil, fmt.Errorf("record %q: %w", name, err)
+		}
+
+		// The prefix marks the name as taken, also for records without
+		// documents.
+		h.docs[prefix] = nil
+
+		for path, doc := range docs {
+			h.docs[prefix+path] = doc
+
+			if len(records) == 1 {
+				h.docs[path] = doc
+			}
+		}
+	}
+
+	return h, nil
+}
+
+// render returns the documents of a record by path.
+func render(record *structpb.Struct) (map[string][]byte, error) {
+	docs := map[string][]byte{}
+
+	if found, _ := recordutil.FindModule(record, translator.A2AModuleName); found {
+		card, err := translator.RecordToA2A(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		data, err := json.Marshal(card.AsMap())
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode agent card: %w", err)
+		}
+
+		docs[AgentCardPath] = data
+		docs[LegacyAgentCardPath] = data
+	}
+
+	if found, _ := recordutil.FindModule(record, translator.MCPModuleName); found {
+		config, err := translator.RecordToVSCodeMCP(record)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode MCP config: %w", err)
+		}
+
+		docs[MCPConfigPath] = data
+	}
+
+	return docs, nil
+}
+
+// Paths returns the served paths, sorted.
+func (h *Handler) Paths() []string {
+	paths := make([]string, 0, len(h.docs))
+
+	for path, doc := range h.docs {
+		if doc != nil {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	doc := h.docs[r.URL.Path]
+	if doc == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// Discovery documents are fetched by browser-based clients too.
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	_, _ = w.Write(doc)
+}
@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package discovery_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/server/discovery"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func loadRecord(t *testing.T, name string) *structpb.Struct {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "e2e", "fixtures", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, record); err != nil {
+		t.Fatal(err)
+	}
+
+	return record
+}
+
+func get(t *testing.T, h http.Handler, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+
+	return rec
+}
+
+func TestHandlerSingleRecord(t *testing.T) {
+	h, err := discovery.New([]*structpb.Struct{loadRecord(t, "translation_1.0.0_record.json")})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, path := range []string{
+		discovery.AgentCardPath,
+		discovery.LegacyAgentCardPath,
+		"/burger_seller_agent" + discovery.AgentCardPath,
+	} {
+		resp := get(t, h, http.MethodGet, path)
+		if resp.Code != http.StatusOK || resp.Header().Get("Content-Type") != "application/json" {
+			t.Fatalf("GET %s: %d %s", path, resp.Code, resp.Header().Get("Content-Type"))
+		}
+
+		var card map[string]any
+		if err := json.Unmarshal(resp.Body.Bytes(), &card); err != nil || card["name"] == nil {
+			t.Errorf("GET %s: not an agent card: %s", path, resp.Body)
+		}
+	}
+
+	resp := get(t, h, http.MethodGet, discovery.MCPConfigPath)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("GET %s: %d", discovery.MCPConfigPath, resp.Code)
+	}
+
+	var config struct {
+		Servers map[string]any `json:"servers"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &config); err != nil || len(config.Servers) == 0 {
+		t.Errorf("not an MCP config: %s", resp.Body)
+	}
+
+	if resp := get(t, h, http.MethodGet, "/other.json"); resp.Code != http.StatusNotFound {
+		t.Errorf("GET /other.json: %d, want 404", resp.Code)
+	}
+
+	if resp := get(t, h, http.MethodPost, discovery.AgentCardPath); resp.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: %d, want 405", resp.Code)
+	}
+}
+
+func TestHandlerRemoteMCPServer(t *testing.T) {
+	record, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/notes",
+		"schema_version": "1.0.0",
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{
+				"name":        "notes",
+				"connections": []any{map[string]any{"type": "streamable-http", "url": "https://notes.example.org/mcp"}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("failed to build record: %v", err)
+	}
+
+	h, err := discovery.New([]*structpb.Struct{record})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	resp := get(t, h, http.MethodGet, discovery.MCPConfigPath)
+	if resp.Code != http.StatusOK {
+		t.Fatalf("GET %s: %d", discovery.MCPConfigPath, resp.Code)
+	}
+
+	var config struct {
+		Servers map[string]struct {
+			Type string `json:"type"`
+			URL  string `json:"url"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &config); err != nil {
+		t.Fatalf("not an MCP config: %v\n%s", err, resp.Body)
+	}
+
+	if server := config.Servers["notes"]; server.Type != "http" || server.URL != "https://notes.example.org/mcp" {
+		t.Errorf("servers = %+v, want the remote server", config.Servers)
+	}
+}
+
+func TestHandlerSeveralRecords(t *testing.T) {
+	bare, _ := structpb.NewStruct(map[string]any{"name": "example.org/tools"})
+
+	h, err := discovery.New([]*structpb.Struct{loadRecord(t, "translation_0.8.0_record.json"), bare})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	want := []string{
+		"/poc/integrations-agent-example" + discovery.AgentCardPath,
+		"/poc/integrations-agent-example" + discovery.LegacyAgentCardPath,
+		"/poc/integrations-agent-example" + discovery.MCPConfigPath,
+	}
+
+	if got := h.Paths(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Paths() = %v, want %v", got, want)
+	}
+
+	// Root documents are only served for a single record.
+	if resp := get(t, h, http.MethodGet, discovery.AgentCardPath); resp.Code != http.StatusNotFound {
+		t.Errorf("GET %s: %d, want 404", discovery.AgentCardPath, resp.Code)
+	}
+
+	if _, err := discovery.New([]*structpb.Struct{bare, bare}); err == nil {
+		t.Error("expected an error for duplicate record names")
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/server/config"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// requestEnvelopeSize is the room left next to the record in a request
+// message for its other fields, so records just over the limit get a
+// RECORD_TOO_LARGE error rather than the transport's RESOURCE_EXHAUSTED.
+const requestEnvelopeSize = 64 << 10
+
+// recordRequest is implemented by the requests that carry a record.
+type recordRequest interface {
+	GetRecord() *structpb.Struct
+}
+
+// recordLimits returns the record size limits of the configuration.
+func recordLimits(cfg *config.Config) record.Limits {
+	limits := record.Limits{MaxSize: cfg.MaxRecordSize, MaxModuleSize: cfg.MaxModuleSize}
+	if limits.MaxSize <= 0 {
+		limits.MaxSize = config.DefaultMaxRecordSize
+	}
+
+	return limits
+}
+
+// limitServerOptions caps the size of request messages, so oversized
+// records are dropped by the transport before they are decoded, and checks
+// the records of unary and streamed requests against the limits.
+func limitServerOptions(limits record.Limits) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(limits.MaxSize + requestEnvelopeSize),
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := checkRequestRecord(req, limits); err != nil {
+				return nil, err
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			return handler(srv, &limitedStream{ServerStream: ss, limits: limits})
+		}),
+	}
+}
+
+// checkRequestRecord checks the record of a request, if it has one.
+func checkRequestRecord(req any, limits record.Limits) error {
+	r, ok := req.(recordRequest)
+	if !ok {
+		return nil
+	}
+
+	if err := record.CheckSize(r.GetRecord(), limits); err != nil {
+		return rpcerr.FromRecordError(err, codes.InvalidArgument, rpcerr.ReasonRecordTooLarge, "record rejected")
+	}
+
+	return nil
+}
+
+// limitedStream checks the record of every message received on a stream.
+type limitedStream struct {
+	grpc.ServerStream
+
+	limits record.Limits
+}
+
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return checkRequestRecord(m, s.limits)
+}
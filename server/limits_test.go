@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/server/config"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// limitedConn serves a server with the given configuration over an
+// in-memory listener and returns a client connection to it.
+func limitedConn(t *testing.T, cfg *config.Config, opts ...Option) *grpc.ClientConn {
+	t.Helper()
+
+	srv, err := NewServer(context.Background(), cfg, opts...)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+
+	go func() { _ = srv.grpcServer.Serve(lis) }()
+
+	t.Cleanup(srv.grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func sizedRecord(t *testing.T, blobSize int) *structpb.Struct {
+	t.Helper()
+
+	record, err := structpb.NewStruct(map[string]any{
+		"name":           "example.org/agent",
+		"version":        "1.0.0",
+		"schema_version": "1.0.0",
+		"modules": []any{
+			map[string]any{"name": "integration/mcp", "data": map[string]any{"blob": strings.Repeat("x", blobSize)}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	return record
+}
+
+// TestRecordSizeLimits verifies that records over the configured limits are
+// rejected with RECORD_TOO_LARGE before reaching the controllers, and that
+// messages far over the limit are dropped by the transport.
+func TestRecordSizeLimits(t *testing.T) {
+	conn := limitedConn(t, &config.Config{MaxRecordSize: 4 << 10, MaxModuleSize: 1 << 10})
+	client := decodingv1grpc.NewDecodingServiceClient(conn)
+
+	if _, err := client.DecodeRecord(context.Background(), &decodingv1.DecodeRecordRequest{Record: sizedRecord(t, 100)}); err != nil {
+		t.Fatalf("DecodeRecord of a small record: %v", err)
+	}
+
+	cases := []struct {
+		name       string
+		blobSize   int
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{"module over limit", 2 << 10, codes.InvalidArgument, rpcerr.ReasonRecordTooLarge},
+		{"record over limit", 8 << 10, codes.InvalidArgument, rpcerr.ReasonRecordTooLarge},
+		{"message over limit", 128 << 10, codes.ResourceExhausted, ""},
+	}
+
+	for _, tc := range cases {
+		_, err := client.DecodeRecord(context.Background(), &decodingv1.DecodeRecordRequest{Record: sizedRecord(t, tc.blobSize)})
+		if status.Code(err) != tc.wantCode || rpcerr.Reason(err) != tc.wantReason {
+			t.Errorf("%s: got %v (reason %q), want %v %q", tc.name, err, rpcerr.Reason(err), tc.wantCode, tc.wantReason)
+		}
+	}
+}
+
+// TestRecordSizeLimitsStream verifies the limits also apply to each record
+// of a validation stream.
+func TestRecordSizeLimitsStream(t *testing.T) {
+	conn := limitedConn(t, &config.Config{MaxModuleSize: 1 << 10})
+
+	stream, err := validationv1grpc.NewValidationServiceClient(conn).ValidateRecordStream(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateRecordStream: %v", err)
+	}
+
+	if err := stream.Send(&validationv1.ValidateRecordStreamRequest{Record: sizedRecord(t, 2<<10)}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	_, err = stream.Recv()
+	if status.Code(err) != codes.InvalidArgument || rpcerr.Reason(err) != rpcerr.ReasonRecordTooLarge {
+		t.Fatalf("got %v (reason %q), want RECORD_TOO_LARGE", err, rpcerr.Reason(err))
+	}
+}
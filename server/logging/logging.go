@@ -0,0 +1,316 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logging builds the slog logger of the server: its level and format,
+// per-component levels, sampling of repeated messages, and the request IDs
+// correlating the logs of a request across interceptors, controllers and the
+// upstream HTTP calls made for it.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Attribute keys added to log records.
+const (
+	// ComponentKey names the part of the server a record comes from, e.g.
+	// "validation" for the RPCs of the validation service. Per-component
+	// levels apply to loggers with this attribute (see Component) and to the
+	// records logged with the context of a request.
+	ComponentKey = "component"
+	// RequestIDKey is the ID of the request a record was logged for.
+	RequestIDKey = "request_id"
+	// TraceIDKey is the W3C trace ID of the request, when the client sent a
+	// traceparent.
+	TraceIDKey = "trace_id"
+)
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	level      slog.Level
+	format     string
+	components map[string]slog.Level
+	initial    int
+	thereafter int
+}
+
+// WithLevel sets the minimum level of the records logged; the default is
+// slog.LevelInfo.
+func WithLevel(level slog.Level) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
+
+// WithFormat sets the format, FormatText (the default) or FormatJSON.
+func WithFormat(format string) Option {
+	return func(o *options) {
+		o.format = format
+	}
+}
+
+// WithComponentLevel sets the minimum level of the records of a component,
+// overriding WithLevel, e.g. debug logs for the extractor only.
+func WithComponentLevel(component string, level slog.Level) Option {
+	return func(o *options) {
+		o.components[component] = level
+	}
+}
+
+// WithSampling samples records below slog.LevelWarn: every second, the first
+// initial records with the same level and message are logged, then every
+// thereafter-th of them. With thereafter 0 the rest are dropped. Sampling is
+// disabled when initial is 0.
+func WithSampling(initial, thereafter int) Option {
+	return func(o *options) {
+		o.initial, o.thereafter = initial, thereafter
+	}
+}
+
+// New returns a logger writing to w.
+func New(w io.Writer, opts ...Option) (*slog.Logger, error) {
+	o := &options{level: slog.LevelInfo, format: FormatText, components: map[string]slog.Level{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var base slog.Handler
+
+	// Levels are applied by the handler, so the base handler logs everything.
+	handlerOpts := &slog.HandlerOptions{Level: slog.Level(-1 << 10)}
+
+	switch o.format {
+	case FormatText:
+		base = slog.NewTextHandler(w, handlerOpts)
+	case FormatJSON:
+		base = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unsupported log format %q (want text or json)", o.format)
+	}
+
+	if o.initial < 0 || o.thereafter < 0 {
+		return nil, errors.New("log sampling values must not be negative")
+	}
+
+	h := &handler{base: base, level: o.level, components: o.components}
+	if o.initial > 0 {
+		h.sampler = &sampler{initial: o.initial, thereafter: o.thereafter, counts: map[sampleKey]int{}}
+	}
+
+	return slog.New(h), nil
+}
+
+// ParseLevel parses a level name: debug, info, warn or error, in any case.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q (want debug, info, warn or error)", name)
+	}
+
+	return level, nil
+}
+
+// ParseComponentLevel parses a "<component>=<level>" spec, e.g.
+// "extractor=debug".
+func ParseComponentLevel(spec string) (string, slog.Level, error) {
+	component, name, ok := strings.Cut(spec, "=")
+	if !ok || strings.TrimSpace(component) == "" {
+		return "", 0, fmt.Errorf("invalid component level %q (want <component>=<level>)", spec)
+	}
+
+	level, err := ParseLevel(strings.TrimSpace(name))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return strings.TrimSpace(component), level, nil
+}
+
+// Component returns the default logger with the component attribute, so
+// the level of the component applies to its records.
+func Component(name string) *slog.Logger {
+	return slog.Default().With(ComponentKey, name)
+}
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	componentKey
+	traceparentKey
+)
+
+// WithRequestID returns a context carrying the ID of a request, added to
+// the records logged with it and sent upstream by Transport.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID of the context, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+
+	return id
+}
+
+// WithTraceparent returns a context carrying the W3C traceparent of a
+// request, whose trace ID is added to the records logged with it and which
+// Transport forwards upstream.
+func WithTraceparent(ctx context.Context, traceparent string) context.Context {
+	return context.WithValue(ctx, traceparentKey, traceparent)
+}
+
+// Traceparent returns the traceparent of the context, or "".
+func Traceparent(ctx context.Context) string {
+	traceparent, _ := ctx.Value(traceparentKey).(string)
+
+	return traceparent
+}
+
+// WithComponent returns a context whose records are logged as those of a
+// component, e.g. the service of the RPC being handled.
+func WithComponent(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, componentKey, name)
+}
+
+func contextComponent(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	name, _ := ctx.Value(componentKey).(string)
+
+	return name
+}
+
+// handler applies the levels and sampling, and adds the request ID and the
+// component of the context to records.
+type handler struct {
+	base       slog.Handler
+	level      slog.Level
+	components map[string]slog.Level
+	// component is the component set with WithAttrs, which takes precedence
+	// over the one of the context.
+	component string
+	sampler   *sampler
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.minLevel(h.componentOf(ctx))
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if h.sampler != nil && r.Level < slog.LevelWarn && !h.sampler.keep(r) {
+		return nil
+	}
+
+	if ctx != nil {
+		if id := RequestID(ctx); id != "" {
+			r.AddAttrs(slog.String(RequestIDKey, id))
+		}
+
+		if traceID := traceID(Traceparent(ctx)); traceID != "" {
+			r.AddAttrs(slog.String(TraceIDKey, traceID))
+		}
+	}
+
+	if h.component == "" {
+		if component := contextComponent(ctx); component != "" {
+			r.AddAttrs(slog.String(ComponentKey, component))
+		}
+	}
+
+	return h.base.Handle(ctx, r) //nolint:wrapcheck
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.base = h.base.WithAttrs(attrs)
+
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey {
+			c.component = attr.Value.String()
+		}
+	}
+
+	return &c
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.base = h.base.WithGroup(name)
+
+	return &c
+}
+
+func (h *handler) componentOf(ctx context.Context) string {
+	if h.component != "" {
+		return h.component
+	}
+
+	return contextComponent(ctx)
+}
+
+func (h *handler) minLevel(component string) slog.Level {
+	if level, ok := h.components[component]; ok {
+		return level
+	}
+
+	return h.level
+}
+
+type sampleKey struct {
+	level   slog.Level
+	message string
+}
+
+// sampler counts the records of every level and message over one-second
+// ticks.
+type sampler struct {
+	initial    int
+	thereafter int
+
+	mu     sync.Mutex
+	tick   time.Time
+	counts map[sampleKey]int
+}
+
+func (s *sampler) keep(r slog.Record) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := r.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if tick := now.Truncate(time.Second); !tick.Equal(s.tick) {
+		s.tick = tick
+		clear(s.counts)
+	}
+
+	key := sampleKey{r.Level, r.Message}
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.initial {
+		return true
+	}
+
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,272 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	translationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/translation/v1"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// records decodes the JSON lines written by a logger.
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var out []map[string]any
+
+	for line := range strings.Lines(buf.String()) {
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid JSON log line %q: %v", line, err)
+		}
+
+		out = append(out, r)
+	}
+
+	return out
+}
+
+func TestNewLevels(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := logging.New(&buf,
+		logging.WithFormat(logging.FormatJSON),
+		logging.WithLevel(slog.LevelWarn),
+		logging.WithComponentLevel("extractor", slog.LevelDebug),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx := logging.WithRequestID(logging.WithComponent(context.Background(), "extractor"), "req-1")
+
+	logger.Info("dropped")
+	logger.DebugContext(ctx, "from context")
+	logger.With(logging.ComponentKey, "extractor").Debug("from attribute")
+	logger.With(logging.ComponentKey, "validation").DebugContext(ctx, "attribute wins")
+	logger.Warn("kept")
+
+	got := records(t, &buf)
+	if len(got) != 3 {
+		t.Fatalf("got %d records, want 3:\n%s", len(got), buf.String())
+	}
+
+	if got[0]["msg"] != "from context" || got[0][logging.RequestIDKey] != "req-1" || got[0][logging.ComponentKey] != "extractor" {
+		t.Errorf("record = %v, want the request ID and component of the context", got[0])
+	}
+
+	if got[1]["msg"] != "from attribute" || got[2]["msg"] != "kept" {
+		t.Errorf("records = %v", got)
+	}
+}
+
+func TestNewSampling(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := logging.New(&buf, logging.WithFormat(logging.FormatJSON), logging.WithSampling(2, 3))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for range 8 {
+		logger.Info("repeated")
+		logger.Warn("never sampled")
+	}
+
+	counts := map[string]int{}
+	for _, r := range records(t, &buf) {
+		counts[r["msg"].(string)]++
+	}
+
+	// 2 initial records, then the 5th and 8th.
+	if counts["repeated"] != 4 || counts["never sampled"] != 8 {
+		t.Errorf("counts = %v, want 4 sampled and 8 warnings", counts)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := logging.New(&bytes.Buffer{}, logging.WithFormat("xml")); err == nil {
+		t.Error("expected an error for an unsupported format")
+	}
+
+	if _, err := logging.New(&bytes.Buffer{}, logging.WithSampling(-1, 0)); err == nil {
+		t.Error("expected an error for negative sampling")
+	}
+}
+
+func TestParseComponentLevel(t *testing.T) {
+	component, level, err := logging.ParseComponentLevel("extractor = DEBUG")
+	if err != nil || component != "extractor" || level != slog.LevelDebug {
+		t.Errorf("ParseComponentLevel = %q, %v, %v", component, level, err)
+	}
+
+	for _, spec := range []string{"extractor", "=debug", "extractor=verbose"} {
+		if _, _, err := logging.ParseComponentLevel(spec); err == nil {
+			t.Errorf("ParseComponentLevel(%q): expected an error", spec)
+		}
+	}
+}
+
+// useLogger makes a JSON logger writing to the returned buffer the default
+// for the test.
+func useLogger(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	logger, err := logging.New(&buf, logging.WithFormat(logging.FormatJSON), logging.WithLevel(slog.LevelDebug))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	return &buf
+}
+
+func TestServerOptions(t *testing.T) {
+	buf := useLogger(t)
+
+	srv := grpc.NewServer(logging.ServerOptions()...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis := bufconn.Listen(1 << 20)
+
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+
+	client := healthpb.NewHealthClient(conn)
+
+	var header metadata.MD
+
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := metadata.AppendToOutgoingContext(context.Background(), logging.RequestIDHeader, "caller-42", logging.TraceparentHeader, traceparent)
+	if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	if got := header.Get(logging.RequestIDHeader); len(got) != 1 || got[0] != "caller-42" {
+		t.Errorf("response request ID = %v, want the one of the caller", got)
+	}
+
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	generated := header.Get(logging.RequestIDHeader)
+	if len(generated) != 1 || len(generated[0]) != 32 {
+		t.Errorf("response request ID = %v, want a generated one", generated)
+	}
+
+	got := records(t, buf)
+	if len(got) != 2 || got[0][logging.RequestIDKey] != "caller-42" || got[1][logging.RequestIDKey] != generated[0] {
+		t.Fatalf("records = %v, want one per request with its ID", got)
+	}
+
+	if got[0][logging.ComponentKey] != "health" || got[0]["method"] != "/grpc.health.v1.Health/Check" {
+		t.Errorf("record = %v, want the health component and method", got[0])
+	}
+
+	if got[0][logging.TraceIDKey] != "4bf92f3577b34da6a3ce929d0e0e4736" || got[1][logging.TraceIDKey] != nil {
+		t.Errorf("records = %v, want the trace ID of the traceparent only", got)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	buf := useLogger(t)
+
+	var received, receivedTraceparent string
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(logging.RequestIDHeader)
+		receivedTraceparent = r.Header.Get(logging.TraceparentHeader)
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: logging.Transport(nil)}
+	traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := logging.WithTraceparent(logging.WithRequestID(context.Background(), "req-7"), traceparent)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+"/api/versions", nil)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	resp.Body.Close()
+
+	if received != "req-7" || receivedTraceparent != traceparent {
+		t.Errorf("upstream request ID, traceparent = %q, %q, want those of the context", received, receivedTraceparent)
+	}
+
+	if req.Header.Get(logging.RequestIDHeader) != "" {
+		t.Error("the request of the caller was modified")
+	}
+
+	got := records(t, buf)
+	if len(got) != 1 || got[0][logging.RequestIDKey] != "req-7" || got[0][logging.ComponentKey] != "http" {
+		t.Errorf("records = %v, want the upstream call with the request ID", got)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := logging.New(&buf, logging.WithFormat(logging.FormatJSON))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	data, err := structpb.NewStruct(map[string]any{
+		"name": "notes",
+		"remotes": []any{
+			map[string]any{
+				"url":     "https://notes.example.org/mcp",
+				"headers": []any{map[string]any{"name": "Authorization", "value": "Bearer 0123456789abcdef"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	req := &translationv1.MCPToRecordRequest{Data: data}
+	logger.Info("request", "request", logging.Redacted(req))
+
+	if strings.Contains(buf.String(), "0123456789abcdef") || !strings.Contains(buf.String(), "notes.example.org") {
+		t.Errorf("log = %s, want the request with its secrets redacted", buf.String())
+	}
+
+	if got := req.GetData().GetFields()["remotes"].GetListValue().GetValues()[0].GetStructValue().GetFields()["headers"]; !strings.Contains(got.String(), "0123456789abcdef") {
+		t.Errorf("headers = %v, want the request left untouched", got)
+	}
+}
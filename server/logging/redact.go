@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"log/slog"
+
+	recordutil "github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Redacted returns a log value of the message with the secrets of its
+// records, cards and server definitions redacted by record.Redact, e.g.
+// "request", Redacted(req): requests carry the credentials of remote
+// connections, such as Authorization headers, which must not reach the
+// logs. The message is copied only when the record is logged.
+func Redacted(msg proto.Message) slog.LogValuer {
+	return redactedMessage{msg: msg}
+}
+
+type redactedMessage struct {
+	msg proto.Message
+}
+
+func (r redactedMessage) LogValue() slog.Value {
+	if r.msg == nil {
+		return slog.AnyValue(nil)
+	}
+
+	redacted := proto.Clone(r.msg)
+	redactMessage(redacted.ProtoReflect())
+
+	return slog.AnyValue(redacted)
+}
+
+// redactMessage replaces in place the structs set in the fields of msg,
+// including in lists and maps, by their redacted copy.
+func redactMessage(msg protoreflect.Message) {
+	if s, ok := msg.Interface().(*structpb.Struct); ok {
+		s.Fields = recordutil.Redact(s).GetFields()
+
+		return
+	}
+
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := range list.Len() {
+				redactMessage(list.Get(i).Message())
+			}
+		case field.IsMap() && field.MapValue().Message() != nil:
+			value.Map().Range(func(_ protoreflect.MapKey, item protoreflect.Value) bool {
+				redactMessage(item.Message())
+
+				return true
+			})
+		case field.Message() != nil && !field.IsMap():
+			redactMessage(value.Message())
+		}
+
+		return true
+	})
+}
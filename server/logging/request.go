@@ -0,0 +1,201 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader carries request IDs: read from incoming gRPC metadata,
+// returned in the response headers and sent on upstream HTTP calls.
+const RequestIDHeader = "x-request-id"
+
+// TraceparentHeader carries the W3C trace context of a request: read from
+// incoming gRPC metadata and forwarded on upstream HTTP calls.
+const TraceparentHeader = "traceparent"
+
+// traceparentPattern matches a W3C traceparent:
+// <version>-<trace-id>-<parent-id>-<flags>.
+var traceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// maxRequestIDLength caps the length of the request IDs accepted from
+// clients.
+const maxRequestIDLength = 128
+
+// ServerOptions returns the interceptors giving every RPC a request ID and
+// the component of its service (e.g. "validation"), and logging its outcome.
+// The ID of the x-request-id metadata of the client is kept, so the logs of
+// a caller and of the server correlate; otherwise one is generated. They
+// should run before the other interceptors, so their logs carry the ID.
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx = requestContext(ctx, info.FullMethod)
+			_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, RequestID(ctx)))
+
+			start := time.Now()
+			resp, err := handler(ctx, req)
+			logRequest(ctx, info.FullMethod, start, err)
+
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx := requestContext(ss.Context(), info.FullMethod)
+			_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, RequestID(ctx)))
+
+			start := time.Now()
+			err := handler(srv, &requestStream{ServerStream: ss, ctx: ctx})
+			logRequest(ctx, info.FullMethod, start, err)
+
+			return err
+		}),
+	}
+}
+
+func requestContext(ctx context.Context, fullMethod string) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := ""
+	if values := md.Get(RequestIDHeader); len(values) > 0 && validRequestID(values[0]) {
+		id = values[0]
+	}
+
+	if id == "" {
+		id = NewRequestID()
+	}
+
+	if values := md.Get(TraceparentHeader); len(values) > 0 && traceID(values[0]) != "" {
+		ctx = WithTraceparent(ctx, values[0])
+	}
+
+	return WithComponent(WithRequestID(ctx, id), serviceComponent(fullMethod))
+}
+
+// traceID returns the trace ID of a valid traceparent, or "".
+func traceID(traceparent string) string {
+	m := traceparentPattern.FindStringSubmatch(traceparent)
+	if m == nil {
+		return ""
+	}
+
+	return m[1]
+}
+
+func logRequest(ctx context.Context, fullMethod string, start time.Time, err error) {
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelInfo
+	}
+
+	slog.Log(ctx, level, "Handled request", "method", fullMethod, "code", status.Code(err).String(), "duration", time.Since(start))
+}
+
+// serviceComponent returns the component of the RPCs of a service: the
+// package of the service without its version, e.g. "validation" for
+// /agntcy.oasfsdk.validation.v1.ValidationService/ValidateRecord.
+func serviceComponent(fullMethod string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+
+	parts := strings.Split(service, ".")
+	if len(parts) < 3 { //nolint:mnd
+		return service
+	}
+
+	return parts[len(parts)-3]
+}
+
+// NewRequestID returns a random request ID.
+func NewRequestID() string {
+	b := make([]byte, 16) //nolint:mnd
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// requestStream gives the handler of a stream the request context.
+type requestStream struct {
+	grpc.ServerStream
+
+	ctx context.Context //nolint:containedctx
+}
+
+func (s *requestStream) Context() context.Context {
+	return s.ctx
+}
+
+// Transport wraps an HTTP transport, http.DefaultTransport when base is nil,
+// so upstream calls made with the context of a request send its ID in the
+// X-Request-ID header, and its traceparent, and are logged with them.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t, ok := base.(*transport); ok {
+		return t
+	}
+
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	headers := map[string]string{RequestIDHeader: RequestID(ctx), TraceparentHeader: Traceparent(ctx)}
+	for name, value := range headers {
+		if value == "" || req.Header.Get(name) != "" {
+			delete(headers, name)
+		}
+	}
+
+	if len(headers) > 0 {
+		req = req.Clone(ctx)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	logger := Component("http")
+	if err != nil {
+		logger.InfoContext(ctx, "Upstream request failed", "method", req.Method, "url", req.URL.Redacted(), "error", err)
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	logger.DebugContext(ctx, "Upstream request", "method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode, "duration", time.Since(start))
+
+	return resp, nil
+}
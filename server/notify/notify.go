@@ -0,0 +1,196 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notify posts validation results to a webhook URL, so notification
+// and ticketing systems (e.g. a Slack workflow or an issue tracker) can act
+// on them without a custom client.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+
+	// SignatureHeader carries the HMAC-SHA256 of the body, "sha256=<hex>",
+	// when a secret is configured.
+	SignatureHeader = "X-OASF-Signature"
+)
+
+// Outcome is the outcome of a validation.
+type Outcome string
+
+const (
+	OutcomeValid   Outcome = "valid"
+	OutcomeInvalid Outcome = "invalid"
+)
+
+// Result is the document posted to the webhook.
+type Result struct {
+	// Digest identifies the validated record (see record.RecordDigest).
+	Digest    string  `json:"digest"`
+	Name      string  `json:"name,omitempty"`
+	Version   string  `json:"version,omitempty"`
+	SchemaURL string  `json:"schema_url"`
+	Outcome   Outcome `json:"outcome"`
+	// Errors and Warnings are the issues of the record, with their codes.
+	Errors    []messages.Message `json:"errors"`
+	Warnings  []messages.Message `json:"warnings"`
+	Timestamp time.Time          `json:"timestamp"`
+}
+
+// NewResult returns the result of validating rec against schemaURL.
+func NewResult(rec *structpb.Struct, schemaURL string, errs, warnings []messages.Message) Result {
+	digest, _ := record.RecordDigest(rec) // only fails for unmarshalable records
+
+	outcome := OutcomeValid
+	if len(errs) > 0 {
+		outcome = OutcomeInvalid
+	}
+
+	return Result{
+		Digest:    digest,
+		Name:      rec.GetFields()["name"].GetStringValue(),
+		Version:   rec.GetFields()["version"].GetStringValue(),
+		SchemaURL: schemaURL,
+		Outcome:   outcome,
+		Errors:    nonNil(errs),
+		Warnings:  nonNil(warnings),
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	httpClient  *http.Client
+	secret      string
+	onlyInvalid bool
+}
+
+// WithHTTPClient sets the client posting results.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithSecret signs the body of every post with HMAC-SHA256 in the
+// SignatureHeader, so receivers can authenticate results.
+func WithSecret(secret string) Option {
+	return func(o *options) {
+		o.secret = secret
+	}
+}
+
+// WithOnlyInvalid posts the results of invalid records only.
+func WithOnlyInvalid() Option {
+	return func(o *options) {
+		o.onlyInvalid = true
+	}
+}
+
+// Notifier posts validation results to a webhook URL. It is safe for
+// concurrent use.
+type Notifier struct {
+	url     string
+	options *options
+	wg      sync.WaitGroup
+}
+
+// New returns a notifier posting to webhookURL, which must be an http(s)
+// URL.
+func New(webhookURL string, opts ...Option) (*Notifier, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", webhookURL)
+	}
+
+	o := &options{httpClient: &http.Client{Timeout: defaultTimeout}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Notifier{url: webhookURL, options: o}, nil
+}
+
+// Post posts the result to the webhook and fails unless it answers with a
+// 2xx status.
+func (n *Notifier) Post(ctx context.Context, result Result) error {
+	if n.options.onlyInvalid && result.Outcome == OutcomeValid {
+		return nil
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal validation result: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if n.options.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.options.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.options.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post validation result: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered with status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Send posts the result in the background, so it does not delay the
+// response to the validation request. Failures are logged.
+func (n *Notifier) Send(result Result) {
+	n.wg.Go(func() {
+		if err := n.Post(context.Background(), result); err != nil {
+			slog.Error("Failed to notify validation webhook", "digest", result.Digest, "error", err)
+		}
+	})
+}
+
+// Wait waits for the results being sent, e.g. before shutting down.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+func nonNil(msgs []messages.Message) []messages.Message {
+	if msgs == nil {
+		return []messages.Message{}
+	}
+
+	return msgs
+}
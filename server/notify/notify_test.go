@@ -0,0 +1,125 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package notify_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/messages"
+	"github.com/agntcy/oasf-sdk/server/notify"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// receiver records the bodies and signatures posted to it.
+type receiver struct {
+	mu         sync.Mutex
+	bodies     [][]byte
+	signatures []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.bodies = append(r.bodies, body)
+	r.signatures = append(r.signatures, req.Header.Get(notify.SignatureHeader))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func testRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	rec, err := structpb.NewStruct(map[string]any{"name": "example.org/agent", "version": "v1.0.0", "schema_version": "1.0.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec
+}
+
+func TestNotifierSend(t *testing.T) {
+	recv := &receiver{}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	notifier, err := notify.New(srv.URL, notify.WithSecret("s3cret"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	errs := []messages.Message{{Code: "validation/attribute_required_missing", Text: "missing", Path: "skills"}}
+	notifier.Send(notify.NewResult(testRecord(t), "https://schema.oasf.outshift.com", errs, nil))
+	notifier.Wait()
+
+	if len(recv.bodies) != 1 {
+		t.Fatalf("got %d posts, want 1", len(recv.bodies))
+	}
+
+	var result notify.Result
+	if err := json.Unmarshal(recv.bodies[0], &result); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+
+	if result.Outcome != notify.OutcomeInvalid || result.Name != "example.org/agent" || result.Digest == "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].Code != "validation/attribute_required_missing" || result.Warnings == nil {
+		t.Errorf("unexpected issues: %+v, %+v", result.Errors, result.Warnings)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(recv.bodies[0])
+
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); recv.signatures[0] != want {
+		t.Errorf("signature = %q, want %q", recv.signatures[0], want)
+	}
+}
+
+func TestNotifierOnlyInvalid(t *testing.T) {
+	recv := &receiver{}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	notifier, err := notify.New(srv.URL, notify.WithOnlyInvalid())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := notifier.Post(context.Background(), notify.NewResult(testRecord(t), "", nil, nil)); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	if len(recv.bodies) != 0 || recv.signatures != nil {
+		t.Errorf("expected no post for a valid record, got %d", len(recv.bodies))
+	}
+}
+
+func TestNotifierErrors(t *testing.T) {
+	if _, err := notify.New("ftp://example.org"); err == nil {
+		t.Error("expected an error for a non-http URL")
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	notifier, _ := notify.New(srv.URL)
+	if err := notifier.Post(context.Background(), notify.NewResult(testRecord(t), "", nil, nil)); err == nil {
+		t.Error("expected an error for a failing webhook")
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package objectstore reads and writes records in S3-compatible object
+// storage (AWS S3, MinIO, Ceph, …) for batch processing jobs. Requests are
+// signed with AWS Signature Version 4.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Scheme is the URL scheme of object storage locations.
+const Scheme = "s3://"
+
+const (
+	defaultRegion  = "us-east-1"
+	defaultTimeout = 60 * time.Second
+	// maxObjectSize bounds the objects read; records are far smaller.
+	maxObjectSize = 64 << 20
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Config configures the S3 client.
+type Config struct {
+	// Region is the bucket region (default us-east-1).
+	Region string
+	// Endpoint is the base URL of an S3-compatible service (e.g.
+	// http://minio:9000). Empty uses AWS. Custom endpoints are addressed
+	// path-style (<endpoint>/<bucket>/<key>).
+	Endpoint string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// ConfigFromEnv reads the configuration from the standard AWS environment
+// variables (AWS_REGION, AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL,
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN).
+func ConfigFromEnv() Config {
+	cfg := Config{
+		Region:          os.Getenv("AWS_REGION"),
+		Endpoint:        os.Getenv("AWS_ENDPOINT_URL_S3"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+
+	return cfg
+}
+
+// Client is an S3 client.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// New returns a client for cfg. Requests are unsigned when no access key is
+// configured, e.g. for public buckets.
+func New(cfg Config, opts ...Option) *Client {
+	if cfg.Region == "" {
+		cfg.Region = defaultRegion
+	}
+
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	c := &Client{cfg: cfg, httpClient: &http.Client{Timeout: defaultTimeout}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ParseURL splits an s3://bucket/prefix location into bucket and key prefix.
+func ParseURL(location string) (string, string, error) {
+	rest, ok := strings.CutPrefix(location, Scheme)
+	if !ok {
+		return "", "", fmt.Errorf("%q is not an %s URL", location, Scheme)
+	}
+
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("%q has no bucket", location)
+	}
+
+	return bucket, prefix, nil
+}
+
+// listResult is the part of a ListObjectsV2 response the client reads.
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns the keys of the objects in bucket starting with prefix, in
+// lexical order.
+func (c *Client) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+
+	for {
+		body, err := c.do(ctx, http.MethodGet, bucket, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listResult
+		if err := xml.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse object listing: %w", err)
+		}
+
+		for _, obj := range page.Contents {
+			keys = append(keys, obj.Key)
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+
+		query.Set("continuation-token", page.NextContinuationToken)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Get returns the content of an object.
+func (c *Client) Get(ctx context.Context, bucket, key string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, bucket, key, nil, nil)
+}
+
+// Put writes an object.
+func (c *Client) Put(ctx context.Context, bucket, key string, data []byte) error {
+	_, err := c.do(ctx, http.MethodPut, bucket, key, nil, data)
+
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method, bucket, key string, query url.Values, body []byte) ([]byte, error) {
+	target := c.objectURL(bucket, key)
+	target.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.cfg.AccessKeyID != "" {
+		sign(req, body, c.cfg, time.Now())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", target.Host, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound && key != "":
+		return nil, fmt.Errorf("%s%s/%s: %w", Scheme, bucket, key, ErrNotFound)
+	case resp.StatusCode/100 != 2: //nolint:mnd
+		return nil, fmt.Errorf("%s %s%s/%s: %s: %s", method, Scheme, bucket, key, resp.Status, errorMessage(data))
+	}
+
+	return data, nil
+}
+
+// objectURL addresses an object virtual-hosted style on AWS and path-style
+// on custom endpoints.
+func (c *Client) objectURL(bucket, key string) *url.URL {
+	u := &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", bucket, c.cfg.Region), Path: "/" + key}
+
+	if c.cfg.Endpoint != "" {
+		endpoint, err := url.Parse(c.cfg.Endpoint)
+		if err != nil {
+			endpoint = &url.URL{Scheme: "https", Host: c.cfg.Endpoint}
+		}
+
+		u = endpoint
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + bucket + "/" + key
+	}
+
+	// Send the path exactly as it is signed.
+	u.RawPath = uriEncode(u.Path, false)
+
+	return u
+}
+
+// errorMessage extracts the message of an S3 error document.
+func errorMessage(body []byte) string {
+	var doc struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+
+	if err := xml.Unmarshal(body, &doc); err != nil || doc.Code == "" {
+		return strings.TrimSpace(string(body))
+	}
+
+	return doc.Code + ": " + doc.Message
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to req.
+func sign(req *http.Request, body []byte, cfg Config, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	day := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + cfg.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretAccessKey), day)
+	key = hmacSHA256(key, cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		cfg.AccessKeyID, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+}
+
+// canonicalQuery encodes a query as SigV4 requires: sorted by key, every
+// reserved character percent-encoded.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		for _, value := range query[key] {
+			parts = append(parts, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything but unreserved characters (and '/'
+// unless encodeSlash).
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+
+	for i := range len(s) {
+		c := s[i]
+
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
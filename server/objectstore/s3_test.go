@@ -0,0 +1,146 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package objectstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/server/objectstore"
+)
+
+// fakeS3 is a path-style S3 endpoint holding objects in memory. Listings
+// return at most two keys per page.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+}
+
+func newFakeS3(t *testing.T, objects map[string]string) (*fakeS3, *httptest.Server) {
+	t.Helper()
+
+	s := &fakeS3{objects: objects}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			http.Error(w, "<Error><Code>AccessDenied</Code><Message>unsigned</Message></Error>", http.StatusForbidden)
+
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/")
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+			s.list(w, strings.TrimSuffix(path, "/"), r.URL.Query().Get("prefix"), r.URL.Query().Get("continuation-token"))
+		case r.Method == http.MethodGet:
+			data, ok := s.objects[path]
+			if !ok {
+				http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = io.WriteString(w, data)
+		case r.Method == http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			s.objects[path] = string(data)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return s, srv
+}
+
+func (s *fakeS3) list(w io.Writer, bucket, prefix, token string) {
+	var keys []string
+
+	for path := range s.objects {
+		if key, ok := strings.CutPrefix(path, bucket+"/"); ok && strings.HasPrefix(key, prefix) && key > token {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	truncated := len(keys) > 2
+	if truncated {
+		keys = keys[:2]
+	}
+
+	fmt.Fprint(w, "<ListBucketResult>")
+
+	for _, key := range keys {
+		fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+	}
+
+	if truncated {
+		fmt.Fprintf(w, "<IsTruncated>true</IsTruncated><NextContinuationToken>%s</NextContinuationToken>", keys[1])
+	}
+
+	fmt.Fprint(w, "</ListBucketResult>")
+}
+
+func TestClient(t *testing.T) {
+	store, srv := newFakeS3(t, map[string]string{
+		"bucket/records/a.json": "a",
+		"bucket/records/b.json": "b",
+		"bucket/records/c.json": "c",
+		"bucket/other/d.json":   "d",
+	})
+
+	client := objectstore.New(objectstore.Config{Endpoint: srv.URL + "/", AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	ctx := context.Background()
+
+	keys, err := client.List(ctx, "bucket", "records/")
+	if err != nil || strings.Join(keys, ",") != "records/a.json,records/b.json,records/c.json" {
+		t.Fatalf("List = %v, %v", keys, err)
+	}
+
+	data, err := client.Get(ctx, "bucket", "records/b.json")
+	if err != nil || string(data) != "b" {
+		t.Errorf("Get = %q, %v", data, err)
+	}
+
+	if _, err := client.Get(ctx, "bucket", "records/missing.json"); !errors.Is(err, objectstore.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := client.Put(ctx, "bucket", "validated/a b.json", []byte("out")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if store.objects["bucket/validated/a b.json"] != "out" {
+		t.Errorf("object not written: %v", store.objects)
+	}
+
+	unsigned := objectstore.New(objectstore.Config{Endpoint: srv.URL})
+	if _, err := unsigned.Get(ctx, "bucket", "records/a.json"); err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("expected the S3 error, got %v", err)
+	}
+}
+
+func TestParseURL(t *testing.T) {
+	bucket, prefix, err := objectstore.ParseURL("s3://bucket/records/")
+	if err != nil || bucket != "bucket" || prefix != "records/" {
+		t.Errorf("ParseURL = %q, %q, %v", bucket, prefix, err)
+	}
+
+	for _, invalid := range []string{"https://bucket/x", "s3://", "s3:///x"} {
+		if _, _, err := objectstore.ParseURL(invalid); err == nil {
+			t.Errorf("ParseURL(%q): expected an error", invalid)
+		}
+	}
+}
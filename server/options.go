@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"github.com/agntcy/oasf-sdk/server/pipeline"
+	"google.golang.org/grpc"
+)
+
+// Option configures NewServer and Run. Options let embedders of the server
+// package extend the gRPC server (e.g. tenancy or quota middleware) without
+// patching the server itself.
+type Option func(*options)
+
+type options struct {
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	pipelineOptions    []pipeline.Option
+}
+
+// WithUnaryInterceptor appends unary interceptors to the server's chain.
+// Interceptors run in the order they are added, across all options.
+func WithUnaryInterceptor(interceptors ...grpc.UnaryServerInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptor appends stream interceptors to the server's chain.
+// Interceptors run in the order they are added, across all options.
+func WithStreamInterceptor(interceptors ...grpc.StreamServerInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithPipelineOptions configures the request pipeline, e.g. with custom
+// stages (pipeline.WithStage) to list in the configured stages, or with hooks
+// (pipeline.WithHooks) run around every stage.
+func WithPipelineOptions(opts ...pipeline.Option) Option {
+	return func(o *options) {
+		o.pipelineOptions = append(o.pipelineOptions, opts...)
+	}
+}
+
+// grpcServerOptions translates the collected options into grpc.ServerOption
+// values. Chained interceptors are only installed when at least one is set.
+func (o *options) grpcServerOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+
+	if len(o.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(o.unaryInterceptors...))
+	}
+
+	if len(o.streamInterceptors) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(o.streamInterceptors...))
+	}
+
+	return opts
+}
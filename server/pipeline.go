@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+
+	"github.com/agntcy/oasf-sdk/server/config"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"github.com/agntcy/oasf-sdk/server/pipeline"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// pipelineRequest is implemented by the requests whose record the pipeline
+// processes.
+type pipelineRequest interface {
+	recordRequest
+	SetRecord(record *structpb.Struct)
+}
+
+// pipelineServerOptions returns the interceptors running the records of
+// unary and streamed requests through the configured pipeline, or nothing
+// when no stage is configured.
+func pipelineServerOptions(cfg config.PipelineConfig, opts []pipeline.Option) ([]grpc.ServerOption, error) {
+	if len(cfg.Stages) == 0 {
+		return nil, nil
+	}
+
+	p, err := pipeline.New(cfg.Stages, append([]pipeline.Option{pipeline.WithSchemaURL(cfg.SchemaURL)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request pipeline: %w", err)
+	}
+
+	slog.Info("Request pipeline enabled", "stages", p.Stages(), "methods", cfg.Methods)
+
+	applies := func(fullMethod string) bool {
+		return len(cfg.Methods) == 0 || slices.Contains(cfg.Methods, fullMethod) || slices.Contains(cfg.Methods, path.Base(fullMethod))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if applies(info.FullMethod) {
+				if err := runPipeline(ctx, p, req); err != nil {
+					return nil, err
+				}
+			}
+
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if applies(info.FullMethod) {
+				ss = &pipelineStream{ServerStream: ss, pipeline: p}
+			}
+
+			return handler(srv, ss)
+		}),
+	}, nil
+}
+
+// runPipeline replaces the record of the request with its pipeline output.
+// Requests without a record are left to the handler, which rejects them.
+func runPipeline(ctx context.Context, p *pipeline.Pipeline, req any) error {
+	r, ok := req.(pipelineRequest)
+	if !ok || r.GetRecord() == nil {
+		return nil
+	}
+
+	out, err := p.Run(ctx, r.GetRecord())
+	if err != nil {
+		return pipelineError(err)
+	}
+
+	r.SetRecord(out)
+
+	return nil
+}
+
+// pipelineError maps a pipeline error onto a status error. Status errors of
+// custom stages and hooks are kept.
+func pipelineError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+
+	if errors.Is(err, pipeline.ErrRejected) {
+		return rpcerr.New(codes.InvalidArgument, rpcerr.ReasonRecordRejected, "pipeline "+err.Error(), rpcerr.FieldViolation("record", err.Error()))
+	}
+
+	return rpcerr.FromRecordError(err, codes.Internal, rpcerr.ReasonPipelineFailed, "pipeline failed")
+}
+
+// pipelineStream runs the record of every message received on a stream
+// through the pipeline.
+type pipelineStream struct {
+	grpc.ServerStream
+
+	pipeline *pipeline.Pipeline
+}
+
+func (s *pipelineStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return runPipeline(s.Context(), s.pipeline, m)
+}
@@ -0,0 +1,261 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pipeline runs request records through a configured list of stages,
+// e.g. normalize, migrate, validate and redact, before an RPC handles them.
+// Deployments compose behaviors by listing stages instead of calling one RPC
+// per step; hooks observe or veto every stage.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ErrRejected is returned (wrapped, with the reasons) by the validate and
+// lint stages for records that fail their checks.
+var ErrRejected = errors.New("record rejected")
+
+// Stage processes a record and returns the record to pass on, which may be
+// the input. An error stops the pipeline and fails the request. Stages must
+// not modify their input in place.
+type Stage func(ctx context.Context, record *structpb.Struct) (*structpb.Struct, error)
+
+// StageFactory builds a stage from the argument of its spec, the part after
+// the colon in "migrate:1.0.0", which may be empty.
+type StageFactory func(arg string) (Stage, error)
+
+// Hook observes the stages of a pipeline. Before is called with the input of
+// each stage and may veto it by returning an error, which fails the request.
+// After is called with the output and the error of each stage and returns
+// the error to continue with, so it can also replace or clear it. Either may
+// be nil.
+type Hook struct {
+	Before func(ctx context.Context, stage string, record *structpb.Struct) error
+	After  func(ctx context.Context, stage string, record *structpb.Struct, err error) error
+}
+
+// Option configures New.
+type Option func(*options)
+
+type options struct {
+	factories map[string]StageFactory
+	hooks     []Hook
+	schemaURL string
+}
+
+// WithStage registers a stage, or replaces a built-in one, under name.
+func WithStage(name string, factory StageFactory) Option {
+	return func(o *options) {
+		o.factories[name] = factory
+	}
+}
+
+// WithHooks adds hooks; they are called in the order they are added.
+func WithHooks(hooks ...Hook) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+// WithSchemaURL sets the schema server of the validate stage.
+func WithSchemaURL(url string) Option {
+	return func(o *options) {
+		o.schemaURL = url
+	}
+}
+
+type namedStage struct {
+	name string
+	run  Stage
+}
+
+// Pipeline is a configured list of stages. It is safe for concurrent use.
+type Pipeline struct {
+	stages []namedStage
+	hooks  []Hook
+}
+
+// New builds the pipeline of the stage specs, "<name>[:<arg>]", run in
+// order. The built-in stages are:
+//
+//	normalize            trim strings and drop duplicate list entries (record.Normalize)
+//	migrate[:<version>]  upgrade the record (record.Migrate), by default to record.DefaultSchemaVersion
+//	defaults             add the lists every record requires (record.FillDefaults)
+//	validate             validate against the WithSchemaURL server; errors reject the record
+//	lint                 run the lint rules; error findings reject the record
+//	redact               replace secrets with record.Redacted (record.Redact)
+func New(specs []string, opts ...Option) (*Pipeline, error) {
+	o := &options{factories: map[string]StageFactory{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	builtins := builtinStages(o.schemaURL)
+	p := &Pipeline{hooks: o.hooks}
+
+	for _, spec := range specs {
+		name, arg, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		if name == "" {
+			continue
+		}
+
+		factory, ok := o.factories[name]
+		if !ok {
+			factory, ok = builtins[name]
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %q", name)
+		}
+
+		stage, err := factory(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline stage %q: %w", spec, err)
+		}
+
+		p.stages = append(p.stages, namedStage{name: name, run: stage})
+	}
+
+	return p, nil
+}
+
+// Stages returns the names of the stages, in order.
+func (p *Pipeline) Stages() []string {
+	names := make([]string, 0, len(p.stages))
+	for _, stage := range p.stages {
+		names = append(names, stage.name)
+	}
+
+	return names
+}
+
+// Run runs the record through the stages and returns the result. Errors are
+// prefixed with the name of the failing stage.
+func (p *Pipeline) Run(ctx context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+	for _, stage := range p.stages {
+		out, err := p.runStage(ctx, stage, rec)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %w", stage.name, err)
+		}
+
+		if out != nil {
+			rec = out
+		}
+	}
+
+	return rec, nil
+}
+
+func (p *Pipeline) runStage(ctx context.Context, stage namedStage, rec *structpb.Struct) (*structpb.Struct, error) {
+	for _, hook := range p.hooks {
+		if hook.Before == nil {
+			continue
+		}
+
+		if err := hook.Before(ctx, stage.name, rec); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := stage.run(ctx, rec)
+
+	for _, hook := range p.hooks {
+		if hook.After != nil {
+			err = hook.After(ctx, stage.name, out, err)
+		}
+	}
+
+	return out, err
+}
+
+func builtinStages(schemaURL string) map[string]StageFactory {
+	return map[string]StageFactory{
+		"normalize": noArg(func(_ context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+			return record.Normalize(rec), nil
+		}),
+		"migrate": func(arg string) (Stage, error) {
+			target := arg
+			if target == "" {
+				target = record.DefaultSchemaVersion
+			}
+
+			return func(_ context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+				return record.Migrate(rec, target) //nolint:wrapcheck
+			}, nil
+		},
+		"defaults": noArg(func(ctx context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+			filled := record.Clone(rec)
+			if err := record.FillDefaults(ctx, filled, nil); err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			return filled, nil
+		}),
+		"validate": validateStage(schemaURL),
+		"lint": noArg(func(_ context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+			var problems []string
+
+			for _, finding := range linter.Lint(rec) {
+				if finding.Severity == linter.SeverityError {
+					problems = append(problems, fmt.Sprintf("%s [%s]", finding.Message, finding.RuleID))
+				}
+			}
+
+			if len(problems) > 0 {
+				return nil, fmt.Errorf("%w: %s", ErrRejected, strings.Join(problems, "; "))
+			}
+
+			return rec, nil
+		}),
+		"redact": noArg(func(_ context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+			return record.Redact(rec), nil
+		}),
+	}
+}
+
+// validateStage returns the factory of the validate stage against the
+// schema server at schemaURL.
+func validateStage(schemaURL string) StageFactory {
+	return func(arg string) (Stage, error) {
+		if arg != "" {
+			return nil, errors.New("the stage takes no argument")
+		}
+
+		v, err := validator.New(schemaURL)
+		if err != nil {
+			return nil, fmt.Errorf("the stage needs a schema URL: %w", err)
+		}
+
+		return func(ctx context.Context, rec *structpb.Struct) (*structpb.Struct, error) {
+			valid, errs, _, err := v.ValidateRecord(ctx, rec)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			if !valid {
+				return nil, fmt.Errorf("%w: %s", ErrRejected, strings.Join(errs, "; "))
+			}
+
+			return rec, nil
+		}, nil
+	}
+}
+
+// noArg returns the factory of a stage without argument.
+func noArg(stage Stage) StageFactory {
+	return func(arg string) (Stage, error) {
+		if arg != "" {
+			return nil, errors.New("the stage takes no argument")
+		}
+
+		return stage, nil
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/server/pipeline"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func testRecord(t *testing.T) *structpb.Struct {
+	t.Helper()
+
+	rec, err := structpb.NewStruct(map[string]any{
+		"name":           " example.org/agent ",
+		"version":        "v1.0.0",
+		"schema_version": "0.8.0",
+		"modules": []any{map[string]any{
+			"name": "integration/mcp",
+			"data": map[string]any{"api_key": "abc123"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rec
+}
+
+func TestRunBuiltinStages(t *testing.T) {
+	p, err := pipeline.New([]string{"normalize", "migrate:1.0.0", "redact"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	in := testRecord(t)
+
+	out, err := p.Run(context.Background(), in)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	fields := out.GetFields()
+	if fields["name"].GetStringValue() != "example.org/agent" || fields["schema_version"].GetStringValue() != "1.0.0" {
+		t.Errorf("unexpected record %v", out)
+	}
+
+	data := fields["modules"].GetListValue().GetValues()[0].GetStructValue().GetFields()["data"].GetStructValue()
+	if data.GetFields()["api_key"].GetStringValue() != record.Redacted {
+		t.Errorf("api_key was not redacted: %v", data)
+	}
+
+	if in.GetFields()["name"].GetStringValue() != " example.org/agent " {
+		t.Error("Run modified its input")
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var seen []string
+
+	errVeto := errors.New("veto")
+
+	hook := pipeline.Hook{
+		Before: func(_ context.Context, stage string, _ *structpb.Struct) error {
+			seen = append(seen, "before "+stage)
+
+			if stage == "redact" {
+				return errVeto
+			}
+
+			return nil
+		},
+		After: func(_ context.Context, stage string, _ *structpb.Struct, err error) error {
+			seen = append(seen, "after "+stage)
+
+			return err
+		},
+	}
+
+	p, err := pipeline.New([]string{"normalize", "redact", "defaults"}, pipeline.WithHooks(hook))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = p.Run(context.Background(), testRecord(t))
+	if !errors.Is(err, errVeto) || !strings.HasPrefix(err.Error(), "stage redact: ") {
+		t.Fatalf("got %v, want the veto of the redact stage", err)
+	}
+
+	if want := []string{"before normalize", "after normalize", "before redact"}; !slices.Equal(seen, want) {
+		t.Errorf("got hook calls %v, want %v", seen, want)
+	}
+}
+
+func TestAfterHookClearsError(t *testing.T) {
+	failing := func(string) (pipeline.Stage, error) {
+		return func(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+			return nil, errors.New("flaky")
+		}, nil
+	}
+
+	p, err := pipeline.New([]string{"flaky", "normalize"},
+		pipeline.WithStage("flaky", failing),
+		pipeline.WithHooks(pipeline.Hook{After: func(_ context.Context, _ string, _ *structpb.Struct, _ error) error { return nil }}),
+	)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	out, err := p.Run(context.Background(), testRecord(t))
+	if err != nil || out.GetFields()["name"].GetStringValue() != "example.org/agent" {
+		t.Errorf("Run = %v, %v, want the input passed on to normalize", out, err)
+	}
+}
+
+func TestLintRejects(t *testing.T) {
+	p, err := pipeline.New([]string{"lint"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rec, _ := structpb.NewStruct(map[string]any{"schema_version": "1.0.0", "created_at": "yesterday"})
+
+	if _, err := p.Run(context.Background(), rec); !errors.Is(err, pipeline.ErrRejected) {
+		t.Errorf("got %v, want ErrRejected", err)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	cases := map[string][]string{
+		"unknown stage":         {"normalize", "translate"},
+		"validate without URL":  {"validate"},
+		"argument to normalize": {"normalize:x"},
+		"argument to redact":    {"redact:all"},
+	}
+
+	for name, specs := range cases {
+		if _, err := pipeline.New(specs); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	p, err := pipeline.New([]string{" normalize ", "", "validate"}, pipeline.WithSchemaURL("https://schema.example.org"))
+	if err != nil || !slices.Equal(p.Stages(), []string{"normalize", "validate"}) {
+		t.Errorf("New = %v, %v", p, err)
+	}
+}
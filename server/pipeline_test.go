@@ -0,0 +1,118 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
+	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
+	decodingv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/decoding/v1"
+	validationv1 "buf.build/gen/go/agntcy/oasf-sdk/protocolbuffers/go/agntcy/oasfsdk/validation/v1"
+	"github.com/agntcy/oasf-sdk/server/config"
+	"github.com/agntcy/oasf-sdk/server/controller/rpcerr"
+	"github.com/agntcy/oasf-sdk/server/pipeline"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func paddedRecord(t *testing.T, extra map[string]any) *structpb.Struct {
+	t.Helper()
+
+	fields := map[string]any{"name": "  example.org/agent ", "version": "1.0.0", "schema_version": "1.0.0"}
+	for key, value := range extra {
+		fields[key] = value
+	}
+
+	record, err := structpb.NewStruct(fields)
+	if err != nil {
+		t.Fatalf("NewStruct: %v", err)
+	}
+
+	return record
+}
+
+// TestPipeline verifies that configured stages process request records
+// before the RPCs, only for the configured methods.
+func TestPipeline(t *testing.T) {
+	cases := []struct {
+		name     string
+		methods  []string
+		wantName string
+	}{
+		{"all methods", nil, "example.org/agent"},
+		{"method name", []string{"DecodeRecord"}, "example.org/agent"},
+		{"full method name", []string{decodingv1grpc.DecodingService_DecodeRecord_FullMethodName}, "example.org/agent"},
+		{"other method", []string{"RecordToA2A"}, "  example.org/agent "},
+	}
+
+	for _, tc := range cases {
+		conn := limitedConn(t, &config.Config{Pipeline: config.PipelineConfig{Stages: []string{"normalize"}, Methods: tc.methods}})
+
+		resp, err := decodingv1grpc.NewDecodingServiceClient(conn).DecodeRecord(context.Background(), &decodingv1.DecodeRecordRequest{Record: paddedRecord(t, nil)})
+		if err != nil {
+			t.Fatalf("%s: DecodeRecord: %v", tc.name, err)
+		}
+
+		if got := resp.GetV1().GetName(); got != tc.wantName {
+			t.Errorf("%s: got name %q, want %q", tc.name, got, tc.wantName)
+		}
+	}
+}
+
+// TestPipelineErrors verifies rejected records get RECORD_REJECTED and that
+// status errors of custom stages reach the client unchanged.
+func TestPipelineErrors(t *testing.T) {
+	quota := pipeline.WithStage("quota", func(string) (pipeline.Stage, error) {
+		return func(context.Context, *structpb.Struct) (*structpb.Struct, error) {
+			return nil, status.Error(codes.ResourceExhausted, "quota exceeded")
+		}, nil
+	})
+
+	cases := []struct {
+		stages     []string
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{[]string{"lint"}, codes.InvalidArgument, rpcerr.ReasonRecordRejected},
+		{[]string{"quota"}, codes.ResourceExhausted, ""},
+		{[]string{"migrate:9.9.9"}, codes.Internal, rpcerr.ReasonPipelineFailed},
+	}
+
+	for _, tc := range cases {
+		conn := limitedConn(t, &config.Config{Pipeline: config.PipelineConfig{Stages: tc.stages}}, WithPipelineOptions(quota))
+
+		_, err := decodingv1grpc.NewDecodingServiceClient(conn).DecodeRecord(context.Background(), &decodingv1.DecodeRecordRequest{Record: paddedRecord(t, map[string]any{"created_at": "yesterday"})})
+		if status.Code(err) != tc.wantCode || rpcerr.Reason(err) != tc.wantReason {
+			t.Errorf("%v: got %v (reason %q), want %v %q", tc.stages, err, rpcerr.Reason(err), tc.wantCode, tc.wantReason)
+		}
+	}
+}
+
+// TestPipelineStream verifies the pipeline also runs on each record of a
+// validation stream.
+func TestPipelineStream(t *testing.T) {
+	conn := limitedConn(t, &config.Config{Pipeline: config.PipelineConfig{Stages: []string{"lint"}}})
+
+	stream, err := validationv1grpc.NewValidationServiceClient(conn).ValidateRecordStream(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateRecordStream: %v", err)
+	}
+
+	if err := stream.Send(&validationv1.ValidateRecordStreamRequest{Record: paddedRecord(t, map[string]any{"created_at": "yesterday"})}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if _, err := stream.Recv(); rpcerr.Reason(err) != rpcerr.ReasonRecordRejected {
+		t.Fatalf("got %v (reason %q), want RECORD_REJECTED", err, rpcerr.Reason(err))
+	}
+}
+
+func TestPipelineConfigErrors(t *testing.T) {
+	if _, err := NewServer(context.Background(), &config.Config{Pipeline: config.PipelineConfig{Stages: []string{"validate"}}}); err == nil {
+		t.Error("expected an error for a validate stage without schema URL")
+	}
+}
@@ -5,12 +5,15 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/decoding/v1/decodingv1grpc"
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/extractor/v1/extractorv1grpc"
@@ -18,26 +21,58 @@ import (
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/translation/v1/translationv1grpc"
 	"buf.build/gen/go/agntcy/oasf-sdk/grpc/go/agntcy/oasfsdk/validation/v1/validationv1grpc"
 	"github.com/agntcy/oasf-sdk/pkg/extractor"
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/record"
+	"github.com/agntcy/oasf-sdk/pkg/translator"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
 	"github.com/agntcy/oasf-sdk/server/config"
 	decodingcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/decoding/v1"
 	extractorcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/extractor/v1"
 	schemacontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/schema/v1"
 	translationcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/translation/v1"
 	validationcontrollerv1 "github.com/agntcy/oasf-sdk/server/controller/validation/v1"
+	"github.com/agntcy/oasf-sdk/server/logging"
+	"github.com/agntcy/oasf-sdk/server/notify"
+	"github.com/agntcy/oasf-sdk/server/webhook"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
+const (
+	webhookReadHeaderTimeout = 10 * time.Second
+	webhookShutdownTimeout   = 5 * time.Second
+	// keepaliveMinTime is the shortest client keepalive interval accepted,
+	// below the default of pkg/client, so shared client connections can
+	// stay open while idle.
+	keepaliveMinTime = 20 * time.Second
+	// upstreamTimeout bounds the calls to the schema server.
+	upstreamTimeout = 30 * time.Second
+	// notifierTimeout bounds the posts to the validation webhook when its
+	// configuration sets no timeout.
+	notifierTimeout = 10 * time.Second
+)
+
 type Server struct {
-	cfg          *config.Config
-	grpcServer   *grpc.Server
-	healthServer *health.Server
+	cfg           *config.Config
+	grpcServer    *grpc.Server
+	healthServer  *health.Server
+	webhookServer *http.Server
+	notifier      *notify.Notifier
 }
 
-func Run(ctx context.Context, cfg *config.Config) error {
-	server, err := NewServer(ctx, cfg)
+func Run(ctx context.Context, cfg *config.Config, opts ...Option) error {
+	logger, err := newLogger(cfg.Log)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	// Run owns the process, so its logger becomes the default.
+	slog.SetDefault(logger)
+
+	server, err := NewServer(ctx, cfg, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to create server: %w", err)
 	}
@@ -58,12 +93,26 @@ func Run(ctx context.Context, cfg *config.Config) error {
 	}
 }
 
-func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
+func NewServer(ctx context.Context, cfg *config.Config, opts ...Option) (*Server, error) {
 	slog.Info("Creating new server", "config", cfg)
 
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if err := registerModuleAliases(cfg.ModuleAliases); err != nil {
+		return nil, err
+	}
+
+	grpcOpts, err := serverOptions(cfg, o)
+	if err != nil {
+		return nil, err
+	}
+
 	server := &Server{
 		cfg:          cfg,
-		grpcServer:   grpc.NewServer(),
+		grpcServer:   grpc.NewServer(grpcOpts...),
 		healthServer: health.NewServer(),
 	}
 
@@ -74,14 +123,34 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 	healthpb.RegisterHealthServer(server.grpcServer, server.healthServer)
 	server.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
 
-	validationController, err := validationcontrollerv1.New()
+	// Upstream HTTP calls made for requests carry their request ID.
+	upstream := &http.Client{Transport: logging.Transport(nil), Timeout: upstreamTimeout}
+
+	validationOpts := []validationcontrollerv1.Option{validationcontrollerv1.WithHTTPClient(upstream)}
+
+	// Validation results are posted to the validation webhook when configured.
+	if cfg.ValidationWebhook.URL != "" {
+		server.notifier, err = newNotifier(cfg.ValidationWebhook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validation webhook: %w", err)
+		}
+
+		validationOpts = append(validationOpts, validationcontrollerv1.WithNotifier(server.notifier))
+	}
+
+	validationController, err := validationcontrollerv1.New(validationOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create validation controller: %w", err)
 	}
 
+	translationController, err := newTranslationController(cfg.Translation, cfg.Provenance, upstream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation controller: %w", err)
+	}
+
 	decodingv1grpc.RegisterDecodingServiceServer(server.grpcServer, decodingcontrollerv1.New())
-	schemav1grpc.RegisterSchemaServiceServer(server.grpcServer, schemacontrollerv1.New())
-	translationv1grpc.RegisterTranslationServiceServer(server.grpcServer, translationcontrollerv1.New())
+	schemav1grpc.RegisterSchemaServiceServer(server.grpcServer, schemacontrollerv1.New(schemacontrollerv1.WithHTTPClient(upstream)))
+	translationv1grpc.RegisterTranslationServiceServer(server.grpcServer, translationController)
 	validationv1grpc.RegisterValidationServiceServer(server.grpcServer, validationController)
 
 	// The extractor controller is registered only when an OASF endpoint is
@@ -98,9 +167,192 @@ func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
 
 	reflection.Register(server.grpcServer)
 
+	// The admission webhook is served next to the gRPC API when configured,
+	// on its own HTTPS listener as the Kubernetes API server requires.
+	if cfg.Webhook.ListenAddress != "" {
+		server.webhookServer, err = newWebhookServer(cfg.Webhook)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create admission webhook: %w", err)
+		}
+	}
+
 	return server, nil
 }
 
+// registerModuleAliases adds the configured module renames to the alias
+// table of the record package, which the translators and decoders look
+// modules up by.
+func registerModuleAliases(specs []string) error {
+	aliases := make([]record.ModuleAlias, 0, len(specs))
+
+	for _, spec := range specs {
+		if spec == "" {
+			continue
+		}
+
+		alias, err := record.ParseModuleAlias(spec)
+		if err != nil {
+			return fmt.Errorf("invalid module alias configuration: %w", err)
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	if err := record.RegisterModuleAliases(aliases...); err != nil {
+		return fmt.Errorf("invalid module alias configuration: %w", err)
+	}
+
+	return nil
+}
+
+// serverOptions returns the gRPC server options: keepalive enforcement, the
+// request IDs, the record size limits, the request pipeline, then the
+// embedder's options.
+func serverOptions(cfg *config.Config, o *options) ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: keepaliveMinTime, PermitWithoutStream: true}),
+	}
+	opts = append(opts, logging.ServerOptions()...)
+	opts = append(opts, limitServerOptions(recordLimits(cfg))...)
+
+	pipelineOpts, err := pipelineServerOptions(cfg.Pipeline, o.pipelineOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, pipelineOpts...)
+
+	return append(opts, o.grpcServerOptions()...), nil
+}
+
+// newWebhookServer builds the HTTPS server of the admission webhook. It
+// serves reviews on /validate and a liveness probe on /healthz.
+func newWebhookServer(cfg config.WebhookConfig) (*http.Server, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("webhook requires cert_file and key_file")
+	}
+
+	var opts []webhook.Option
+
+	if cfg.ConfigMapLabel != "" {
+		opts = append(opts, webhook.WithConfigMapLabel(cfg.ConfigMapLabel))
+	}
+
+	if cfg.Lint {
+		opts = append(opts, webhook.WithLintRules(linter.Rules()...))
+	}
+
+	handler, err := webhook.New(cfg.SchemaURL, opts...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/validate", handler)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return &http.Server{
+		Addr:              cfg.ListenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: webhookReadHeaderTimeout,
+	}, nil
+}
+
+// newLogger builds the logger of the server, writing to stderr.
+func newLogger(cfg config.LogConfig) (*slog.Logger, error) {
+	var opts []logging.Option
+
+	if cfg.Level != "" {
+		level, err := logging.ParseLevel(cfg.Level)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		opts = append(opts, logging.WithLevel(level))
+	}
+
+	if cfg.Format != "" {
+		opts = append(opts, logging.WithFormat(cfg.Format))
+	}
+
+	for _, spec := range cfg.Components {
+		if spec == "" {
+			continue
+		}
+
+		component, level, err := logging.ParseComponentLevel(spec)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		opts = append(opts, logging.WithComponentLevel(component, level))
+	}
+
+	if cfg.Sampling.Initial > 0 {
+		opts = append(opts, logging.WithSampling(cfg.Sampling.Initial, cfg.Sampling.Thereafter))
+	}
+
+	return logging.New(os.Stderr, opts...) //nolint:wrapcheck
+}
+
+// newTranslationController returns the translation controller, with the
+// provenance defaults of the records it generates and checking them when
+// configured, against the schema server called with httpClient.
+func newTranslationController(cfg config.TranslationConfig, provenance config.ProvenanceConfig, httpClient *http.Client) (translationv1grpc.TranslationServiceServer, error) {
+	opts := []translationcontrollerv1.Option{
+		translationcontrollerv1.WithProvenance(translator.Provenance{
+			Authors:         provenance.Authors,
+			Organization:    provenance.Organization,
+			Contact:         provenance.Contact,
+			FallbackLocator: provenance.FallbackLocator,
+		}),
+	}
+
+	if !cfg.ValidateRecords {
+		return translationcontrollerv1.New(opts...), nil
+	}
+
+	var recordValidator translator.RecordValidator
+
+	if cfg.SchemaURL != "" {
+		v, err := validator.New(cfg.SchemaURL, validator.WithHTTPClient(httpClient))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create record validator: %w", err)
+		}
+
+		recordValidator = v
+	}
+
+	opts = append(opts, translationcontrollerv1.WithRecordValidation(recordValidator))
+
+	return translationcontrollerv1.New(opts...), nil
+}
+
+// newNotifier builds the notifier of the validation webhook.
+func newNotifier(cfg config.ValidationWebhookConfig) (*notify.Notifier, error) {
+	var opts []notify.Option
+
+	if cfg.Secret != "" {
+		opts = append(opts, notify.WithSecret(cfg.Secret))
+	}
+
+	if cfg.OnlyInvalid {
+		opts = append(opts, notify.WithOnlyInvalid())
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = notifierTimeout
+	}
+
+	// Posts made for requests carry their request ID.
+	opts = append(opts, notify.WithHTTPClient(&http.Client{Transport: logging.Transport(nil), Timeout: timeout}))
+
+	return notify.New(cfg.URL, opts...) //nolint:wrapcheck
+}
+
 // extractorOptions builds the pkg/extractor options from config. Fields that are
 // unset (zero-valued) fall back to the library defaults.
 func extractorOptions(cfg *config.Config) []extractor.Option {
@@ -146,6 +398,18 @@ func (s Server) close() {
 	// stop routing new work during the graceful drain.
 	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
 	s.grpcServer.GracefulStop()
+
+	if s.webhookServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), webhookShutdownTimeout)
+		defer cancel()
+
+		_ = s.webhookServer.Shutdown(ctx)
+	}
+
+	// Deliver the validation results of the drained requests.
+	if s.notifier != nil {
+		s.notifier.Wait()
+	}
 }
 
 func (s Server) start(ctx context.Context) error {
@@ -164,6 +428,22 @@ func (s Server) start(ctx context.Context) error {
 		}
 	}()
 
+	if s.webhookServer != nil {
+		webhookListen, err := lc.Listen(ctx, "tcp", s.webhookServer.Addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", s.webhookServer.Addr, err)
+		}
+
+		go func() {
+			slog.Info("Starting admission webhook", "address", s.webhookServer.Addr)
+
+			err := s.webhookServer.ServeTLS(webhookListen, s.cfg.Webhook.CertFile, s.cfg.Webhook.KeyFile)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slog.Error("Admission webhook stopped unexpectedly", "error", err)
+			}
+		}()
+	}
+
 	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 
 	return nil
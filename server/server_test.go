@@ -5,10 +5,16 @@ package server
 
 import (
 	"context"
+	"net"
+	"net/http"
 	"testing"
 
+	"github.com/agntcy/oasf-sdk/pkg/record"
 	"github.com/agntcy/oasf-sdk/server/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 // TestExtractorOptions checks how ExtractorConfig maps to extractor options by
@@ -94,3 +100,131 @@ func TestHealthLifecycle(t *testing.T) {
 		t.Fatalf("status after close = %v, want NOT_SERVING", got)
 	}
 }
+
+// TestWithUnaryInterceptor verifies that embedder-supplied unary interceptors
+// are chained in registration order and wrap the registered services.
+func TestWithUnaryInterceptor(t *testing.T) {
+	var calls []string
+
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+
+			return handler(ctx, req)
+		}
+	}
+
+	srv, err := NewServer(context.Background(), &config.Config{ListenAddress: config.DefaultListenAddress},
+		WithUnaryInterceptor(record("first")),
+		WithUnaryInterceptor(record("second")),
+	)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+
+	go func() { _ = srv.grpcServer.Serve(lis) }()
+	defer srv.grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("health Check: %v", err)
+	}
+
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Fatalf("interceptor calls = %v, want [first second]", calls)
+	}
+}
+
+// TestNewServerWebhookRequiresCertificate verifies the admission webhook is
+// only created with a serving certificate, as the API server calls webhooks
+// over TLS only.
+func TestNewServerWebhookRequiresCertificate(t *testing.T) {
+	cfg := &config.Config{
+		ListenAddress: "127.0.0.1:0",
+		Webhook:       config.WebhookConfig{ListenAddress: "127.0.0.1:0", SchemaURL: "http://localhost:8080"},
+	}
+
+	if _, err := NewServer(context.Background(), cfg); err == nil {
+		t.Fatal("expected an error for a webhook without certificate")
+	}
+
+	cfg.Webhook.CertFile, cfg.Webhook.KeyFile = "tls.crt", "tls.key"
+
+	srv, err := NewServer(context.Background(), cfg)
+	if err != nil || srv.webhookServer == nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+}
+
+// TestNewServerValidationWebhook verifies the validation webhook is created
+// when configured, and that an invalid URL fails the server.
+func TestNewServerValidationWebhook(t *testing.T) {
+	cfg := &config.Config{
+		ListenAddress:     "127.0.0.1:0",
+		ValidationWebhook: config.ValidationWebhookConfig{URL: "hooks.example.org"},
+	}
+
+	if _, err := NewServer(context.Background(), cfg); err == nil {
+		t.Fatal("expected an error for an invalid validation webhook URL")
+	}
+
+	cfg.ValidationWebhook.URL = "https://hooks.example.org/oasf"
+
+	srv, err := NewServer(context.Background(), cfg)
+	if err != nil || srv.notifier == nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+}
+
+// TestNewServerModuleAliases verifies configured module renames are added to
+// the record alias table, and invalid ones fail the server.
+func TestNewServerModuleAliases(t *testing.T) {
+	cfg := &config.Config{ListenAddress: "127.0.0.1:0", ModuleAliases: []string{"integration/mcp"}}
+
+	if _, err := NewServer(context.Background(), cfg); err == nil {
+		t.Fatal("expected an error for an invalid module alias")
+	}
+
+	cfg.ModuleAliases = []string{"example/server=example/server_v2@1.1.0"}
+
+	if _, err := NewServer(context.Background(), cfg); err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	if got := record.ModuleNameForVersion("example/server", "1.1.0"); got != "example/server_v2" {
+		t.Errorf("ModuleNameForVersion = %q, want the configured alias", got)
+	}
+}
+
+// TestNewLogger verifies the log configuration is checked when the logger is
+// built.
+func TestNewLogger(t *testing.T) {
+	valid := config.LogConfig{Level: "debug", Format: "json", Components: []string{"extractor=warn"}, Sampling: config.LogSamplingConfig{Initial: 10}}
+	if _, err := newLogger(valid); err != nil {
+		t.Fatalf("newLogger: %v", err)
+	}
+
+	for _, cfg := range []config.LogConfig{{Level: "verbose"}, {Format: "xml"}, {Components: []string{"extractor"}}} {
+		if _, err := newLogger(cfg); err == nil {
+			t.Errorf("newLogger(%+v): expected an error", cfg)
+		}
+	}
+}
+
+func TestNewTranslationController(t *testing.T) {
+	for _, cfg := range []config.TranslationConfig{{}, {ValidateRecords: true}, {ValidateRecords: true, SchemaURL: "https://schema.oasf.outshift.com"}} {
+		if _, err := newTranslationController(cfg, config.ProvenanceConfig{Organization: "ACME Corp"}, http.DefaultClient); err != nil {
+			t.Errorf("newTranslationController(%+v): %v", cfg, err)
+		}
+	}
+}
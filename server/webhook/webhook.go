@@ -0,0 +1,308 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook implements a Kubernetes validating admission webhook for
+// OASF records. It rejects OASFRecord resources, and ConfigMaps carrying
+// records, that fail schema validation or the lint policy, so bad records
+// never enter the cluster.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// RecordKind is the kind of the OASFRecord custom resource. Its spec is the
+// record.
+const RecordKind = "OASFRecord"
+
+// DefaultConfigMapLabel is the label selecting the ConfigMaps holding
+// records when WithConfigMapLabel is not given. Other ConfigMaps are admitted
+// without looking at their data.
+const DefaultConfigMapLabel = "oasf.agntcy.org/record=true"
+
+// maxReviewSize bounds the AdmissionReview bodies read; the API server caps
+// objects well below this.
+const maxReviewSize = 8 << 20
+
+// Handler serves AdmissionReview requests (admission.k8s.io/v1).
+type Handler struct {
+	validator      *validator.Validator
+	rules          []linter.Rule
+	configMapLabel string
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithLintRules rejects records with error findings of these rules, e.g.
+// linter.Rules(). Warnings and notes are returned as admission warnings.
+func WithLintRules(rules ...linter.Rule) Option {
+	return func(h *Handler) {
+		h.rules = append(h.rules, rules...)
+	}
+}
+
+// WithConfigMapLabel restricts ConfigMap validation to ConfigMaps carrying
+// the label, given as "key" or "key=value", instead of DefaultConfigMapLabel.
+// An empty label keeps the default.
+func WithConfigMapLabel(label string) Option {
+	return func(h *Handler) {
+		if label != "" {
+			h.configMapLabel = label
+		}
+	}
+}
+
+// New returns a handler validating records against the OASF schema server at
+// schemaURL.
+func New(schemaURL string, opts ...Option) (*Handler, error) {
+	v, err := validator.New(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+
+	h := &Handler{validator: v, configMapLabel: DefaultConfigMapLabel}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// AdmissionReview is the part of the admission.k8s.io/v1 AdmissionReview
+// the webhook reads and writes.
+type AdmissionReview struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Request    *AdmissionRequest  `json:"request,omitempty"`
+	Response   *AdmissionResponse `json:"response,omitempty"`
+}
+
+// AdmissionRequest describes the object being admitted.
+type AdmissionRequest struct {
+	UID       string           `json:"uid"`
+	Kind      GroupVersionKind `json:"kind"`
+	Name      string           `json:"name,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	Operation string           `json:"operation"`
+	Object    json.RawMessage  `json:"object,omitempty"`
+}
+
+// GroupVersionKind identifies the kind of the admitted object.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+// AdmissionResponse is the webhook's decision.
+type AdmissionResponse struct {
+	UID      string   `json:"uid"`
+	Allowed  bool     `json:"allowed"`
+	Status   *Status  `json:"status,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Status explains a rejection.
+type Status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ServeHTTP decodes an AdmissionReview and answers it with the decision for
+// its request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var review AdmissionReview
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxReviewSize)).Decode(&review); err != nil || review.Request == nil {
+		http.Error(w, "invalid AdmissionReview", http.StatusBadRequest)
+
+		return
+	}
+
+	review.Response = h.Review(r.Context(), review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		slog.Error("Failed to write admission response", "error", err)
+	}
+}
+
+// Review decides whether the object of an admission request is admitted.
+// Objects that are not records (other kinds, unlabelled ConfigMaps, deletes)
+// are always admitted.
+func (h *Handler) Review(ctx context.Context, req *AdmissionRequest) *AdmissionResponse {
+	resp := &AdmissionResponse{UID: req.UID, Allowed: true}
+
+	if req.Operation == "DELETE" || len(req.Object) == 0 {
+		return resp
+	}
+
+	records, err := h.records(req)
+	if err != nil {
+		return deny(resp, http.StatusBadRequest, err.Error())
+	}
+
+	var problems []string
+
+	for _, rec := range records {
+		errs, warnings, err := h.check(ctx, rec.record)
+		if err != nil {
+			// Fail closed: a record that could not be validated is not
+			// admitted. The failurePolicy of the webhook configuration
+			// decides what happens when the webhook itself is down.
+			return deny(resp, http.StatusInternalServerError, fmt.Sprintf("%s: %v", rec.source, err))
+		}
+
+		for _, e := range errs {
+			problems = append(problems, rec.source+": "+e)
+		}
+
+		for _, w := range warnings {
+			resp.Warnings = append(resp.Warnings, rec.source+": "+w)
+		}
+	}
+
+	if len(problems) > 0 {
+		return deny(resp, http.StatusUnprocessableEntity, "invalid OASF record: "+strings.Join(problems, "; "))
+	}
+
+	return resp
+}
+
+// check validates one record against the schema and the lint rules.
+func (h *Handler) check(ctx context.Context, record *structpb.Struct) ([]string, []string, error) {
+	_, errs, warnings, err := h.validator.ValidateRecord(ctx, record)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	if len(h.rules) == 0 {
+		return errs, warnings, nil
+	}
+
+	for _, f := range linter.Lint(record, h.rules...) {
+		msg := fmt.Sprintf("%s (%s)", f.Message, f.RuleID)
+		if f.Path != "" {
+			msg = f.Path + ": " + msg
+		}
+
+		if f.Severity == linter.SeverityError {
+			errs = append(errs, msg)
+		} else {
+			warnings = append(warnings, msg)
+		}
+	}
+
+	return errs, warnings, nil
+}
+
+type sourcedRecord struct {
+	// source names the record in messages: "spec" or the ConfigMap key.
+	source string
+	record *structpb.Struct
+}
+
+// records extracts the records of the admitted object.
+func (h *Handler) records(req *AdmissionRequest) ([]sourcedRecord, error) {
+	switch req.Kind.Kind {
+	case RecordKind:
+		var obj struct {
+			Spec json.RawMessage `json:"spec"`
+		}
+
+		if err := json.Unmarshal(req.Object, &obj); err != nil || len(obj.Spec) == 0 {
+			return nil, errors.New("OASFRecord has no spec")
+		}
+
+		record := &structpb.Struct{}
+		if err := record.UnmarshalJSON(obj.Spec); err != nil {
+			return nil, fmt.Errorf("spec is not a record: %w", err)
+		}
+
+		return []sourcedRecord{{source: "spec", record: record}}, nil
+	case "ConfigMap":
+		if req.Kind.Group != "" {
+			return nil, nil
+		}
+
+		var obj struct {
+			Metadata struct {
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+			Data map[string]string `json:"data"`
+		}
+
+		if err := json.Unmarshal(req.Object, &obj); err != nil {
+			return nil, fmt.Errorf("invalid ConfigMap: %w", err)
+		}
+
+		if !hasLabel(obj.Metadata.Labels, h.configMapLabel) {
+			return nil, nil
+		}
+
+		return configMapRecords(obj.Data)
+	default:
+		return nil, nil
+	}
+}
+
+// configMapRecords parses the ".json" entries of a ConfigMap as records.
+func configMapRecords(data map[string]string) ([]sourcedRecord, error) {
+	keys := make([]string, 0, len(data))
+
+	for key := range data {
+		if strings.HasSuffix(key, ".json") {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	records := make([]sourcedRecord, 0, len(keys))
+
+	for _, key := range keys {
+		record := &structpb.Struct{}
+		if err := record.UnmarshalJSON([]byte(data[key])); err != nil {
+			return nil, fmt.Errorf("%s is not a record: %w", key, err)
+		}
+
+		records = append(records, sourcedRecord{source: key, record: record})
+	}
+
+	return records, nil
+}
+
+// hasLabel reports whether labels match a "key" or "key=value" selector.
+func hasLabel(labels map[string]string, selector string) bool {
+	key, value, hasValue := strings.Cut(selector, "=")
+	got, ok := labels[key]
+
+	return ok && (!hasValue || got == value)
+}
+
+func deny(resp *AdmissionResponse, code int, message string) *AdmissionResponse {
+	resp.Allowed = false
+	resp.Status = &Status{Code: code, Message: message}
+
+	return resp
+}
@@ -0,0 +1,204 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/pkg/linter"
+	"github.com/agntcy/oasf-sdk/pkg/validator"
+	"github.com/agntcy/oasf-sdk/server/webhook"
+)
+
+// newSchemaServer fakes the OASF schema validation API, reporting an error
+// for records without a description.
+func newSchemaServer(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var record map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&record)
+
+		var resp validator.ValidationResponse
+		if _, ok := record["description"]; !ok {
+			resp.Errors = []validator.ValidationError{{Error: "attribute_required_missing", Message: "Required attribute description is missing."}}
+		}
+
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+func review(t *testing.T, h http.Handler, kind webhook.GroupVersionKind, object string) *webhook.AdmissionResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(webhook.AdmissionReview{
+		APIVersion: "admission.k8s.io/v1",
+		Kind:       "AdmissionReview",
+		Request:    &webhook.AdmissionRequest{UID: "42", Kind: kind, Operation: "CREATE", Object: json.RawMessage(object)},
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+
+	var out webhook.AdmissionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil || out.Response == nil {
+		t.Fatalf("invalid response: %v\n%s", err, rec.Body)
+	}
+
+	if out.Response.UID != "42" || out.Request != nil {
+		t.Errorf("response does not answer the request: %+v", out)
+	}
+
+	return out.Response
+}
+
+var (
+	recordKind    = webhook.GroupVersionKind{Group: "oasf.agntcy.org", Version: "v1alpha1", Kind: webhook.RecordKind}
+	configMapKind = webhook.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+)
+
+const (
+	validRecord   = `{"name": "example.org/agent", "version": "1.0.0", "schema_version": "1.0.0", "description": "Agent"}`
+	invalidRecord = `{"name": "example.org/agent", "version": "1.0.0", "schema_version": "1.0.0"}`
+)
+
+func TestReviewRecord(t *testing.T) {
+	h, err := webhook.New(newSchemaServer(t))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if resp := review(t, h, recordKind, `{"spec": `+validRecord+`}`); !resp.Allowed {
+		t.Errorf("valid record denied: %+v", resp.Status)
+	}
+
+	resp := review(t, h, recordKind, `{"spec": `+invalidRecord+`}`)
+	if resp.Allowed || resp.Status == nil || !strings.Contains(resp.Status.Message, "description is missing") {
+		t.Errorf("invalid record admitted: %+v", resp)
+	}
+
+	if resp := review(t, h, recordKind, `{"metadata": {}}`); resp.Allowed {
+		t.Error("record without spec admitted")
+	}
+
+	if resp := review(t, h, webhook.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, `{}`); !resp.Allowed {
+		t.Error("unrelated kinds must be admitted")
+	}
+}
+
+func TestReviewConfigMap(t *testing.T) {
+	h, err := webhook.New(newSchemaServer(t), webhook.WithConfigMapLabel("oasf.agntcy.org/record=true"))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	configMap := func(labels string, data map[string]string) string {
+		raw, _ := json.Marshal(data)
+
+		return `{"metadata": {"labels": ` + labels + `}, "data": ` + string(raw) + `}`
+	}
+
+	labelled := `{"oasf.agntcy.org/record": "true"}`
+
+	if resp := review(t, h, configMapKind, configMap(labelled, map[string]string{"agent.json": validRecord, "README": "x"})); !resp.Allowed {
+		t.Errorf("valid ConfigMap denied: %+v", resp.Status)
+	}
+
+	resp := review(t, h, configMapKind, configMap(labelled, map[string]string{"a.json": validRecord, "b.json": invalidRecord}))
+	if resp.Allowed || !strings.Contains(resp.Status.Message, "b.json:") || strings.Contains(resp.Status.Message, "a.json:") {
+		t.Errorf("invalid ConfigMap entry not reported: %+v", resp)
+	}
+
+	if resp := review(t, h, configMapKind, configMap(`{}`, map[string]string{"b.json": invalidRecord})); !resp.Allowed {
+		t.Error("unlabelled ConfigMaps must be admitted")
+	}
+}
+
+func TestReviewConfigMapLabel(t *testing.T) {
+	configMap := func(labels string) string {
+		return `{"metadata": {"labels": ` + labels + `}, "data": {"b.json": ` + strconv.Quote(invalidRecord) + `}}`
+	}
+
+	tests := []struct {
+		name    string
+		opts    []webhook.Option
+		labels  string
+		checked bool
+	}{
+		{name: "default label", labels: `{"oasf.agntcy.org/record": "true"}`, checked: true},
+		{name: "default label with another value", labels: `{"oasf.agntcy.org/record": "false"}`},
+		{name: "no label", labels: `{}`},
+		{name: "empty label keeps the default", opts: []webhook.Option{webhook.WithConfigMapLabel("")}, labels: `{}`},
+		{name: "custom label", opts: []webhook.Option{webhook.WithConfigMapLabel("team")}, labels: `{"team": "agents"}`, checked: true},
+		{name: "custom label replaces the default", opts: []webhook.Option{webhook.WithConfigMapLabel("team")}, labels: `{"oasf.agntcy.org/record": "true"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := webhook.New(newSchemaServer(t), tt.opts...)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			if resp := review(t, h, configMapKind, configMap(tt.labels)); resp.Allowed == tt.checked {
+				t.Errorf("allowed = %v, want the ConfigMap checked = %v", resp.Allowed, tt.checked)
+			}
+		})
+	}
+}
+
+func TestReviewLintPolicy(t *testing.T) {
+	object := json.RawMessage(`{"spec": ` + strings.Replace(validRecord, "{", `{"created_at": "yesterday", `, 1) + `}`)
+
+	plain, err := webhook.New(newSchemaServer(t))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if resp := plain.Review(context.Background(), &webhook.AdmissionRequest{UID: "1", Kind: recordKind, Operation: "CREATE", Object: object}); !resp.Allowed || len(resp.Warnings) != 0 {
+		t.Errorf("records must only be linted with lint rules: %+v", resp)
+	}
+
+	h, err := webhook.New(newSchemaServer(t), webhook.WithLintRules(linter.Rules()...))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	resp := h.Review(context.Background(), &webhook.AdmissionRequest{UID: "1", Kind: recordKind, Operation: "CREATE", Object: object})
+	if resp.Allowed || !strings.Contains(resp.Status.Message, "created-at-format") || len(resp.Warnings) == 0 {
+		t.Errorf("lint errors must deny and warnings be reported: %+v %+v", resp, resp.Status)
+	}
+
+	if resp := h.Review(context.Background(), &webhook.AdmissionRequest{UID: "2", Kind: recordKind, Operation: "DELETE"}); !resp.Allowed {
+		t.Error("deletes must be admitted")
+	}
+}
+
+func TestServeHTTPRejectsMalformedReviews(t *testing.T) {
+	h, err := webhook.New(newSchemaServer(t))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(`{"kind": "AdmissionReview"}`)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
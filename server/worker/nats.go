@@ -0,0 +1,314 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultNATSURL is the address of a local NATS server.
+const DefaultNATSURL = "nats://127.0.0.1:4222"
+
+const (
+	defaultNATSPort = "4222"
+	// natsBuffer is the number of messages buffered per subscription.
+	natsBuffer = 64
+)
+
+// NATSConn is a minimal NATS core client: it publishes and subscribes, with
+// queue groups, over a single connection. It speaks the text protocol
+// directly, without JetStream.
+type NATSConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	// mu guards the writer and the connection error.
+	mu  sync.Mutex
+	w   *bufio.Writer
+	err error
+
+	// dispatch guards the subscriptions. It is held while a message is
+	// delivered, so a subscription is never closed during a send.
+	dispatch sync.Mutex
+	subs     map[int]chan Message
+	sid      int
+
+	done chan struct{}
+}
+
+// natsInfo is the part of the server INFO the client uses.
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+// DialNATS connects to the NATS server at rawURL (nats://[user:pass@]host:port
+// or tls://…; a user without password is sent as a token).
+func DialNATS(ctx context.Context, rawURL string) (*NATSConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid NATS URL %q", rawURL)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), defaultNATSPort)
+	}
+
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	c, err := handshake(ctx, conn, u)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	go c.readLoop()
+
+	return c, nil
+}
+
+// handshake reads the server INFO, upgrades to TLS when required and sends
+// CONNECT, waiting for the PONG that confirms it was accepted.
+func handshake(ctx context.Context, conn net.Conn, u *url.URL) (*NATSConn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{}) //nolint:errcheck
+	}
+
+	r := bufio.NewReader(conn)
+
+	line, err := readLine(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NATS server info: %w", err)
+	}
+
+	op, args, _ := strings.Cut(line, " ")
+	if !strings.EqualFold(op, "INFO") {
+		return nil, fmt.Errorf("unexpected NATS greeting %q", line)
+	}
+
+	var info natsInfo
+	if err := json.Unmarshal([]byte(args), &info); err != nil {
+		return nil, fmt.Errorf("invalid NATS server info: %w", err)
+	}
+
+	if info.TLSRequired || u.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, fmt.Errorf("NATS TLS handshake failed: %w", err)
+		}
+
+		conn, r = tlsConn, bufio.NewReader(tlsConn)
+	}
+
+	connect := map[string]any{"verbose": false, "pedantic": false, "lang": "go", "name": "oasf-sdk-worker"}
+
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			connect["user"], connect["pass"] = u.User.Username(), pass
+		} else {
+			connect["auth_token"] = u.User.Username()
+		}
+	}
+
+	payload, _ := json.Marshal(connect)
+
+	c := &NATSConn{conn: conn, r: r, w: bufio.NewWriter(conn), subs: map[int]chan Message{}, done: make(chan struct{})}
+
+	if err := c.write("CONNECT " + string(payload) + "\r\nPING\r\n"); err != nil {
+		return nil, err
+	}
+
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+		}
+
+		switch {
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, fmt.Errorf("NATS rejected the connection: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case line == "PONG":
+			return c, nil
+		}
+	}
+}
+
+// Subscribe implements Broker. The channel must be drained until it is
+// closed.
+func (c *NATSConn) Subscribe(ctx context.Context, subject, queue string) (<-chan Message, error) {
+	c.dispatch.Lock()
+	c.sid++
+	sid := c.sid
+	ch := make(chan Message, natsBuffer)
+	c.subs[sid] = ch
+	c.dispatch.Unlock()
+
+	sub := fmt.Sprintf("SUB %s %d\r\n", subject, sid)
+	if queue != "" {
+		sub = fmt.Sprintf("SUB %s %s %d\r\n", subject, queue, sid)
+	}
+
+	if err := c.write(sub); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = c.write(fmt.Sprintf("UNSUB %d\r\n", sid))
+			c.unsubscribe(sid)
+		case <-c.done:
+		}
+	}()
+
+	return ch, nil
+}
+
+// Publish implements Broker.
+func (c *NATSConn) Publish(_ context.Context, subject string, data []byte) error {
+	return c.write(fmt.Sprintf("PUB %s %d\r\n%s\r\n", subject, len(data), data))
+}
+
+// Close implements Broker.
+func (c *NATSConn) Close() error {
+	return c.conn.Close() //nolint:wrapcheck
+}
+
+func (c *NATSConn) write(s string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.err != nil {
+		return c.err
+	}
+
+	if _, err := c.w.WriteString(s); err != nil {
+		return fmt.Errorf("failed to write to NATS: %w", err)
+	}
+
+	if err := c.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write to NATS: %w", err)
+	}
+
+	return nil
+}
+
+func (c *NATSConn) unsubscribe(sid int) {
+	c.dispatch.Lock()
+	defer c.dispatch.Unlock()
+
+	if ch, ok := c.subs[sid]; ok {
+		delete(c.subs, sid)
+		close(ch)
+	}
+}
+
+// readLoop dispatches messages to their subscriptions and answers server
+// pings until the connection fails, then closes all subscriptions.
+func (c *NATSConn) readLoop() {
+	err := c.read()
+
+	c.mu.Lock()
+	c.err = errors.New("NATS connection closed")
+	c.mu.Unlock()
+
+	c.dispatch.Lock()
+
+	for sid, ch := range c.subs {
+		delete(c.subs, sid)
+		close(ch)
+	}
+
+	c.dispatch.Unlock()
+	close(c.done)
+
+	if err != nil && !errors.Is(err, net.ErrClosed) {
+		slog.Error("NATS connection lost", "error", err)
+	}
+}
+
+func (c *NATSConn) read() error {
+	for {
+		line, err := readLine(c.r)
+		if err != nil {
+			return err
+		}
+
+		op, args, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(op) {
+		case "MSG":
+			if err := c.readMessage(args); err != nil {
+				return err
+			}
+		case "PING":
+			if err := c.write("PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("NATS error: %s", args)
+		}
+	}
+}
+
+// readMessage reads the payload of "MSG <subject> <sid> [reply-to] <size>".
+func (c *NATSConn) readMessage(args string) error {
+	fields := strings.Fields(args)
+	if len(fields) < 3 { //nolint:mnd
+		return fmt.Errorf("malformed NATS message %q", args)
+	}
+
+	sid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Errorf("malformed NATS message %q", args)
+	}
+
+	size, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || size < 0 {
+		return fmt.Errorf("malformed NATS message %q", args)
+	}
+
+	data := make([]byte, size+2) //nolint:mnd // payload and CRLF
+	if _, err := io.ReadFull(c.r, data); err != nil {
+		return fmt.Errorf("failed to read NATS message: %w", err)
+	}
+
+	c.dispatch.Lock()
+	defer c.dispatch.Unlock()
+
+	if ch := c.subs[sid]; ch != nil {
+		ch <- Message{Subject: fields[0], Data: data[:size]}
+	}
+
+	return nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,232 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package worker_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agntcy/oasf-sdk/server/worker"
+)
+
+// fakeNATS is a NATS server speaking enough of the protocol for the client:
+// CONNECT, PING, SUB, UNSUB and PUB, routing messages by exact subject.
+type fakeNATS struct {
+	ln net.Listener
+
+	mu   sync.Mutex
+	subs map[string][]fakeSub
+	// connect is the last CONNECT payload received.
+	connect string
+}
+
+type fakeSub struct {
+	w   *syncWriter
+	sid string
+}
+
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) write(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(s.w, format, args...)
+}
+
+func newFakeNATS(t *testing.T) *fakeNATS {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+
+	s := &fakeNATS{ln: ln, subs: map[string][]fakeSub{}}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeNATS) url() string { return "nats://" + s.ln.Addr().String() }
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+
+	w := &syncWriter{w: conn}
+	r := bufio.NewReader(conn)
+
+	w.write("INFO {\"server_id\":\"fake\",\"max_payload\":1048576}\r\n")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connect = strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+			s.mu.Unlock()
+		case "PING":
+			w.write("PONG\r\n")
+		case "SUB":
+			s.mu.Lock()
+			s.subs[fields[1]] = append(s.subs[fields[1]], fakeSub{w: w, sid: fields[len(fields)-1]})
+			s.mu.Unlock()
+		case "PUB":
+			size, _ := strconv.Atoi(fields[2])
+			data := make([]byte, size+2)
+			_, _ = io.ReadFull(r, data)
+
+			s.mu.Lock()
+			for _, sub := range s.subs[fields[1]] {
+				sub.w.write("MSG %s %s %d\r\n%s\r\n", fields[1], sub.sid, size, data[:size])
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func TestNATSPublishSubscribe(t *testing.T) {
+	srv := newFakeNATS(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := worker.DialNATS(ctx, strings.Replace(srv.url(), "nats://", "nats://secret@", 1))
+	if err != nil {
+		t.Fatalf("DialNATS: %v", err)
+	}
+	defer conn.Close()
+
+	if !strings.Contains(srv.connect, `"auth_token":"secret"`) {
+		t.Errorf("token not sent in CONNECT: %s", srv.connect)
+	}
+
+	subCtx, unsubscribe := context.WithCancel(ctx)
+
+	messages, err := conn.Subscribe(subCtx, "records.in", "workers")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Publishing is ordered after SUB on the same connection, so the server
+	// has registered the subscription by then.
+	for _, payload := range []string{"first", "multi\r\nline"} {
+		if err := conn.Publish(ctx, "records.in", []byte(payload)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	for _, want := range []string{"first", "multi\r\nline"} {
+		select {
+		case msg := <-messages:
+			if msg.Subject != "records.in" || string(msg.Data) != want {
+				t.Errorf("got %+v, want %q", msg, want)
+			}
+		case <-ctx.Done():
+			t.Fatal("message not delivered")
+		}
+	}
+
+	unsubscribe()
+
+	select {
+	case _, ok := <-messages:
+		if ok {
+			t.Error("unexpected message after unsubscribing")
+		}
+	case <-ctx.Done():
+		t.Fatal("subscription not closed")
+	}
+}
+
+func TestNATSRunEndToEnd(t *testing.T) {
+	srv := newFakeNATS(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, err := worker.DialNATS(ctx, srv.url())
+	if err != nil {
+		t.Fatalf("DialNATS: %v", err)
+	}
+	defer conn.Close()
+
+	results, err := conn.Subscribe(ctx, "records.out", "")
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := worker.Run(runCtx, conn, worker.Config{Subject: "records.in", OutputSubject: "records.out"},
+			func(_ context.Context, msg worker.Message) ([]byte, error) {
+				return []byte(strings.ToUpper(string(msg.Data))), nil
+			})
+		done <- err
+	}()
+
+	// Retry until the worker's subscription is in place.
+	for {
+		if err := conn.Publish(ctx, "records.in", []byte("record")); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+
+		select {
+		case msg := <-results:
+			if string(msg.Data) != "RECORD" {
+				t.Errorf("unexpected result %q", msg.Data)
+			}
+
+			stop()
+
+			if err := <-done; err != nil {
+				t.Errorf("Run: %v", err)
+			}
+
+			return
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			t.Fatal("no result published")
+		}
+	}
+}
+
+func TestDialNATSInvalidURL(t *testing.T) {
+	if _, err := worker.DialNATS(context.Background(), "not a url"); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package worker consumes records from a message broker, hands each message
+// to a handler (e.g. a validate/translate pipeline) and publishes the results
+// and failures to output subjects, for asynchronous processing at registry
+// scale.
+//
+// NATS is supported out of the box (DialNATS); other brokers, such as Kafka,
+// plug in by implementing Broker.
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// Message is a message received from a broker.
+type Message struct {
+	Subject string
+	Data    []byte
+}
+
+// Broker is a publish/subscribe message broker.
+type Broker interface {
+	// Subscribe delivers the messages of subject until ctx is done or the
+	// connection is lost, then closes the channel. Subscribers sharing a
+	// non-empty queue group each receive a share of the messages.
+	Subscribe(ctx context.Context, subject, queue string) (<-chan Message, error)
+	// Publish sends data to subject.
+	Publish(ctx context.Context, subject string, data []byte) error
+	// Close closes the connection to the broker.
+	Close() error
+}
+
+// Handler processes one message and returns the document to publish to the
+// output subject. An error routes the message to the error subject instead.
+type Handler func(ctx context.Context, msg Message) ([]byte, error)
+
+// Config names the subjects a worker uses.
+type Config struct {
+	// Subject is the subject records are consumed from.
+	Subject string
+	// Queue is the queue group shared by the workers of a deployment, so each
+	// record is processed once. Empty makes every worker see every record.
+	Queue string
+	// OutputSubject receives the handler's documents; empty drops them.
+	OutputSubject string
+	// ErrorSubject receives a Failure for every failed message; empty drops
+	// them (they are still logged).
+	ErrorSubject string
+}
+
+// Failure is the document published to the error subject.
+type Failure struct {
+	Subject string `json:"subject"`
+	Error   string `json:"error"`
+	// Data is the failed message, so it can be inspected or replayed.
+	Data string `json:"data"`
+}
+
+// Stats counts the messages a worker processed.
+type Stats struct {
+	Processed int
+	Failed    int
+}
+
+// Run consumes cfg.Subject until ctx is done or the broker connection is
+// lost and returns what it processed. Handler failures do not stop the
+// worker; failures to publish do.
+func Run(ctx context.Context, broker Broker, cfg Config, handler Handler) (Stats, error) {
+	var stats Stats
+
+	if cfg.Subject == "" {
+		return stats, errors.New("subject is required")
+	}
+
+	messages, err := broker.Subscribe(ctx, cfg.Subject, cfg.Queue)
+	if err != nil {
+		return stats, fmt.Errorf("failed to subscribe to %s: %w", cfg.Subject, err)
+	}
+
+	for msg := range messages {
+		stats.Processed++
+
+		out, err := handler(ctx, msg)
+		if err != nil {
+			stats.Failed++
+
+			slog.Warn("Failed to process message", "subject", msg.Subject, "error", err)
+
+			if cfg.ErrorSubject == "" {
+				continue
+			}
+
+			failure, _ := json.Marshal(Failure{Subject: msg.Subject, Error: err.Error(), Data: string(msg.Data)})
+			if err := broker.Publish(ctx, cfg.ErrorSubject, failure); err != nil {
+				return stats, fmt.Errorf("failed to publish to %s: %w", cfg.ErrorSubject, err)
+			}
+
+			continue
+		}
+
+		if cfg.OutputSubject != "" {
+			if err := broker.Publish(ctx, cfg.OutputSubject, out); err != nil {
+				return stats, fmt.Errorf("failed to publish to %s: %w", cfg.OutputSubject, err)
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return stats, nil
+	}
+
+	return stats, errors.New("broker connection lost")
+}
@@ -0,0 +1,91 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/agntcy/oasf-sdk/server/worker"
+)
+
+// memBroker delivers the queued input messages and records publications.
+type memBroker struct {
+	input []worker.Message
+
+	mu        sync.Mutex
+	published map[string][][]byte
+}
+
+func (b *memBroker) Subscribe(_ context.Context, _, _ string) (<-chan worker.Message, error) {
+	ch := make(chan worker.Message, len(b.input))
+	for _, msg := range b.input {
+		ch <- msg
+	}
+
+	close(ch)
+
+	return ch, nil
+}
+
+func (b *memBroker) Publish(_ context.Context, subject string, data []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.published == nil {
+		b.published = map[string][][]byte{}
+	}
+
+	b.published[subject] = append(b.published[subject], data)
+
+	return nil
+}
+
+func (b *memBroker) Close() error { return nil }
+
+func TestRun(t *testing.T) {
+	broker := &memBroker{input: []worker.Message{
+		{Subject: "records.in", Data: []byte("good")},
+		{Subject: "records.in", Data: []byte("bad")},
+		{Subject: "records.in", Data: []byte("good")},
+	}}
+
+	cfg := worker.Config{Subject: "records.in", OutputSubject: "records.out", ErrorSubject: "records.errors"}
+
+	stats, err := worker.Run(context.Background(), broker, cfg, func(_ context.Context, msg worker.Message) ([]byte, error) {
+		if string(msg.Data) == "bad" {
+			return nil, errors.New("invalid record")
+		}
+
+		return []byte("processed " + string(msg.Data)), nil
+	})
+
+	// The input channel closing without cancellation means the connection
+	// was lost.
+	if err == nil || stats.Processed != 3 || stats.Failed != 1 {
+		t.Fatalf("Run = %+v, %v", stats, err)
+	}
+
+	if out := broker.published["records.out"]; len(out) != 2 || string(out[0]) != "processed good" {
+		t.Errorf("unexpected outputs %q", out)
+	}
+
+	var failure worker.Failure
+	if errs := broker.published["records.errors"]; len(errs) != 1 || json.Unmarshal(errs[0], &failure) != nil {
+		t.Fatalf("unexpected errors %q", errs)
+	}
+
+	if failure.Error != "invalid record" || failure.Data != "bad" || failure.Subject != "records.in" {
+		t.Errorf("unexpected failure %+v", failure)
+	}
+}
+
+func TestRunRequiresSubject(t *testing.T) {
+	if _, err := worker.Run(context.Background(), &memBroker{}, worker.Config{}, nil); err == nil {
+		t.Error("expected an error without subject")
+	}
+}